@@ -63,7 +63,7 @@ func abapEnvironmentRunATCCheck(options abapEnvironmentRunATCCheckOptions, telem
 		resp, err = triggerATCRun(options, details, &client)
 	}
 	if err == nil {
-		if err = fetchAndPersistATCResults(resp, details, &client, &fileUtils, options.AtcResultsFileName, options.GenerateHTML, options.FailOnSeverity); err != nil {
+		if err = fetchAndPersistATCResults(resp, details, &client, &fileUtils, &options); err != nil {
 			log.Entry().WithError(err).Fatal("step execution failed")
 		}
 	}
@@ -71,7 +71,7 @@ func abapEnvironmentRunATCCheck(options abapEnvironmentRunATCCheckOptions, telem
 	log.Entry().Info("ATC run completed successfully. If there are any results from the respective run they will be listed in the logs above as well as being saved in the output .xml file")
 }
 
-func fetchAndPersistATCResults(resp *http.Response, details abaputils.ConnectionDetailsHTTP, client piperhttp.Sender, utils piperutils.FileUtils, atcResultFileName string, generateHTML bool, failOnSeverityLevel string) error {
+func fetchAndPersistATCResults(resp *http.Response, details abaputils.ConnectionDetailsHTTP, client piperhttp.Sender, utils piperutils.FileUtils, options *abapEnvironmentRunATCCheckOptions) error {
 	var err error
 	var failStep bool
 	abapEndpoint := details.URL
@@ -89,7 +89,7 @@ func fetchAndPersistATCResults(resp *http.Response, details abaputils.Connection
 	}
 	if err == nil {
 		defer resp.Body.Close()
-		err, failStep = logAndPersistAndEvaluateATCResults(utils, body, atcResultFileName, generateHTML, failOnSeverityLevel)
+		err, failStep = logAndPersistAndEvaluateATCResults(utils, body, options, abapEndpoint)
 	}
 	if err != nil {
 		return errors.Errorf("Handling ATC result failed: %v", err)
@@ -206,8 +206,9 @@ func getATCObjectSet(ATCConfig ATCConfiguration) (objectSet string, err error) {
 	return objectSet, nil
 }
 
-func logAndPersistAndEvaluateATCResults(utils piperutils.FileUtils, body []byte, atcResultFileName string, generateHTML bool, failOnSeverityLevel string) (error, bool) {
+func logAndPersistAndEvaluateATCResults(utils piperutils.FileUtils, body []byte, options *abapEnvironmentRunATCCheckOptions, systemURL string) (error, bool) {
 	var failStep bool
+	atcResultFileName := options.AtcResultsFileName
 	if len(body) == 0 {
 		return errors.Errorf("Parsing ATC result failed: %v", errors.New("Body is empty, can't parse empty body")), failStep
 	}
@@ -218,28 +219,34 @@ func logAndPersistAndEvaluateATCResults(utils piperutils.FileUtils, body []byte,
 		return errors.New("The Software Component could not be checked. Please make sure the respective Software Component has been cloned successfully on the system"), failStep
 	}
 
-	parsedXML := new(Result)
+	parsedXML := new(abaputils.Checkstyle)
 	if err := xml.Unmarshal([]byte(body), &parsedXML); err != nil {
 		log.Entry().WithError(err).Warning("failed to unmarschal xml response")
 	}
-	if len(parsedXML.Files) == 0 {
+	if len(parsedXML.File) == 0 {
 		log.Entry().Info("There were no results from this run, most likely the checked Software Components are empty or contain no ATC findings")
 	}
+	findings := parsedXML.Findings()
+	if err := abaputils.ApplyBaseline(findings, options.BaselineFile, options.UpdateBaseline, utils); err != nil {
+		return err, failStep
+	}
 
 	err := os.WriteFile(atcResultFileName, body, 0o644)
 	if err == nil {
 		log.Entry().Infof("Writing %s file was successful", atcResultFileName)
 		var reports []piperutils.Path
 		reports = append(reports, piperutils.Path{Target: atcResultFileName, Name: "ATC Results", Mandatory: true})
-		for _, s := range parsedXML.Files {
-			for _, t := range s.ATCErrors {
-				log.Entry().Infof("%s in file '%s': %s in line %s found by %s", t.Severity, s.Key, t.Message, t.Line, t.Source)
-				if !failStep {
-					failStep = checkStepFailing(t.Severity, failOnSeverityLevel)
-				}
+		for _, f := range findings {
+			if f.Suppressed {
+				log.Entry().Debugf("Known %s in file '%s': %s in line %d found by %s", f.Severity, f.File, f.Message, f.Line, f.Rule)
+				continue
+			}
+			log.Entry().Infof("%s in file '%s': %s in line %d found by %s", f.Severity, f.File, f.Message, f.Line, f.Rule)
+			if !failStep && !options.UpdateBaseline {
+				failStep = checkStepFailing(f.Severity, options.FailOnSeverity)
 			}
 		}
-		if generateHTML {
+		if options.GenerateHTML {
 			htmlString := generateHTMLDocument(parsedXML)
 			htmlStringByte := []byte(htmlString)
 			atcResultHTMLFileName := strings.Trim(atcResultFileName, ".xml") + ".html"
@@ -249,6 +256,14 @@ func logAndPersistAndEvaluateATCResults(utils piperutils.FileUtils, body []byte,
 				reports = append(reports, piperutils.Path{Target: atcResultFileName, Name: "ATC Results HTML file", Mandatory: true})
 			}
 		}
+		if err == nil && options.GenerateSARIF {
+			atcResultSARIFFileName := strings.TrimSuffix(atcResultFileName, ".xml") + ".sarif"
+			err = abaputils.WriteSARIF(abaputils.ToSARIF("ABAP Test Cockpit", findings, systemURL), atcResultSARIFFileName, utils)
+			if err == nil {
+				log.Entry().Info("Writing " + atcResultSARIFFileName + " file was successful")
+				reports = append(reports, piperutils.Path{Target: atcResultSARIFFileName, Name: "ATC Results SARIF file"})
+			}
+		}
 		piperutils.PersistReportsAndLinks("abapEnvironmentRunATCCheck", "", utils, reports, nil)
 	}
 	if err != nil {
@@ -256,6 +271,7 @@ func logAndPersistAndEvaluateATCResults(utils piperutils.FileUtils, body []byte,
 	}
 	return nil, failStep
 }
+
 func checkStepFailing(severity string, failOnSeverityLevel string) bool {
 	switch failOnSeverityLevel {
 	case "error":
@@ -418,27 +434,27 @@ func convertATCOptions(options *abapEnvironmentRunATCCheckOptions) abaputils.Aba
 	return subOptions
 }
 
-func generateHTMLDocument(parsedXML *Result) (htmlDocumentString string) {
+func generateHTMLDocument(parsedXML *abaputils.Checkstyle) (htmlDocumentString string) {
 	htmlDocumentString = `<!DOCTYPE html><html lang="en" xmlns="http://www.w3.org/1999/xhtml"><head><title>ATC Results</title><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /><style>table,th,td {border: 1px solid black;border-collapse:collapse;}th,td{padding: 5px;text-align:left;font-size:medium;}</style></head><body><h1 style="text-align:left;font-size:large">ATC Results</h1><table style="width:100%"><tr><th>Severity</th><th>File</th><th>Message</th><th>Line</th><th>Checked by</th></tr>`
 	var htmlDocumentStringError, htmlDocumentStringWarning, htmlDocumentStringInfo, htmlDocumentStringDefault string
-	for _, s := range parsedXML.Files {
-		for _, t := range s.ATCErrors {
+	for _, s := range parsedXML.File {
+		for _, t := range s.Error {
 			var trBackgroundColor string
 			if t.Severity == "error" {
 				trBackgroundColor = "rgba(227,85,0)"
-				htmlDocumentStringError += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Key + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
+				htmlDocumentStringError += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Name + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
 			}
 			if t.Severity == "warning" {
 				trBackgroundColor = "rgba(255,175,0, 0.75)"
-				htmlDocumentStringWarning += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Key + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
+				htmlDocumentStringWarning += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Name + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
 			}
 			if t.Severity == "info" {
 				trBackgroundColor = "rgba(255,175,0, 0.2)"
-				htmlDocumentStringInfo += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Key + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
+				htmlDocumentStringInfo += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Name + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
 			}
 			if t.Severity != "info" && t.Severity != "warning" && t.Severity != "error" {
 				trBackgroundColor = "rgba(255,175,0, 0)"
-				htmlDocumentStringDefault += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Key + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
+				htmlDocumentStringDefault += `<tr style="background-color: ` + trBackgroundColor + `">` + `<td>` + t.Severity + `</td>` + `<td>` + s.Name + `</td>` + `<td>` + t.Message + `</td>` + `<td style="text-align:center">` + t.Line + `</td>` + `<td>` + t.Source + `</td>` + `</tr>`
 			}
 		}
 	}
@@ -484,25 +500,3 @@ type Link struct {
 	Key   string `xml:"href,attr"`
 	Value string `xml:",chardata"`
 }
-
-// Result from ATC check for all files that were checked
-type Result struct {
-	XMLName xml.Name `xml:"checkstyle"`
-	Files   []File   `xml:"file"`
-}
-
-// File that contains ATC check with error for checked file
-type File struct {
-	Key       string     `xml:"name,attr"`
-	Value     string     `xml:",chardata"`
-	ATCErrors []ATCError `xml:"error"`
-}
-
-// ATCError with message
-type ATCError struct {
-	Text     string `xml:",chardata"`
-	Message  string `xml:"message,attr"`
-	Source   string `xml:"source,attr"`
-	Line     string `xml:"line,attr"`
-	Severity string `xml:"severity,attr"`
-}
@@ -30,6 +30,9 @@ type abapEnvironmentRunATCCheckOptions struct {
 	AtcResultsFileName string `json:"atcResultsFileName,omitempty"`
 	GenerateHTML       bool   `json:"generateHTML,omitempty"`
 	FailOnSeverity     string `json:"failOnSeverity,omitempty"`
+	GenerateSARIF      bool   `json:"generateSARIF,omitempty"`
+	BaselineFile       string `json:"baselineFile,omitempty"`
+	UpdateBaseline     bool   `json:"updateBaseline,omitempty"`
 }
 
 // AbapEnvironmentRunATCCheckCommand Runs an ATC Check
@@ -109,9 +112,11 @@ Regardless of the option you chose, please make sure to provide the configuratio
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -171,6 +176,9 @@ func addAbapEnvironmentRunATCCheckFlags(cmd *cobra.Command, stepConfig *abapEnvi
 	cmd.Flags().StringVar(&stepConfig.AtcResultsFileName, "atcResultsFileName", `ATCResults.xml`, "Specifies output file name for the results from the ATC run. This file name will also be used for generating the HTML file")
 	cmd.Flags().BoolVar(&stepConfig.GenerateHTML, "generateHTML", false, "Specifies whether the ATC results should also be generated as an HTML document")
 	cmd.Flags().StringVar(&stepConfig.FailOnSeverity, "failOnSeverity", os.Getenv("PIPER_failOnSeverity"), "Specifies the severity level, for which the ATC step should fail if at least one message with this severity (or \"higher\") level is returned by the ATC Check Run (possible values - error, warning, info). Initial value is default behavior and ATC findings of any severity do not fail the step")
+	cmd.Flags().BoolVar(&stepConfig.GenerateSARIF, "generateSARIF", false, "Specifies whether the ATC results should also be generated as SARIF file, e.g. for the upload to GitHub code scanning. The file name is derived from `atcResultsFileName`.")
+	cmd.Flags().StringVar(&stepConfig.BaselineFile, "baselineFile", os.Getenv("PIPER_baselineFile"), "Specifies a JSON file containing known ATC findings. Findings contained in the baseline are reported as suppressed and do not fail the step, so that `failOnSeverity` applies to new findings only.")
+	cmd.Flags().BoolVar(&stepConfig.UpdateBaseline, "updateBaseline", false, "Specifies whether the baseline file should be (re-)created with all findings of the current ATC run. The step does not fail on ATC findings in this case.")
 
 	cmd.MarkFlagRequired("username")
 	cmd.MarkFlagRequired("password")
@@ -319,6 +327,33 @@ func abapEnvironmentRunATCCheckMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_failOnSeverity"),
 					},
+					{
+						Name:        "generateSARIF",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS", "GENERAL"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "baselineFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS", "GENERAL"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_baselineFile"),
+					},
+					{
+						Name:        "updateBaseline",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 				},
 			},
 			Containers: []config.Container{
@@ -244,7 +244,7 @@ func TestParseATCResult(t *testing.T) {
 			</file>
 		</checkstyle>`
 		body := []byte(bodyString)
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, "")
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml"}, "")
 		assert.Equal(t, false, failStep)
 		assert.Equal(t, nil, err)
 	})
@@ -271,7 +271,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "error"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail true
 		assert.Equal(t, true, failStep)
 		//but no error here
@@ -300,7 +300,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "warning"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail true
 		assert.Equal(t, true, failStep)
 		//but no error here
@@ -329,7 +329,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "info"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail true
 		assert.Equal(t, true, failStep)
 		//but no error here
@@ -358,7 +358,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "warning"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail true
 		assert.Equal(t, true, failStep)
 		//but no error here
@@ -387,7 +387,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "info"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail true
 		assert.Equal(t, true, failStep)
 		//but no error here
@@ -416,7 +416,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "info"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail true
 		assert.Equal(t, true, failStep)
 		//but no error here
@@ -445,7 +445,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "warning"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail false
 		assert.Equal(t, false, failStep)
 		//no error here
@@ -474,7 +474,7 @@ func TestParseATCResult(t *testing.T) {
 		</checkstyle>`
 		body := []byte(bodyString)
 		doFailOnSeverityLevel := "error"
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, doFailOnSeverityLevel)
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: doFailOnSeverityLevel}, "")
 		//fail false
 		assert.Equal(t, false, failStep)
 		//no error here
@@ -492,7 +492,7 @@ func TestParseATCResult(t *testing.T) {
 		<checkstyle>
 		</checkstyle>`
 		body := []byte(bodyString)
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, "")
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml"}, "")
 		assert.Equal(t, false, failStep)
 		assert.Equal(t, nil, err)
 	})
@@ -500,7 +500,7 @@ func TestParseATCResult(t *testing.T) {
 		var bodyString string
 		body := []byte(bodyString)
 
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, "")
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml"}, "")
 		assert.Equal(t, false, failStep)
 		assert.EqualError(t, err, "Parsing ATC result failed: Body is empty, can't parse empty body")
 	})
@@ -514,12 +514,85 @@ func TestParseATCResult(t *testing.T) {
 		}()
 		bodyString := `<html><head><title>HTMLTestResponse</title</head></html>`
 		body := []byte(bodyString)
-		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, "ATCResults.xml", false, "")
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, body, &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml"}, "")
 		assert.Equal(t, false, failStep)
 		assert.EqualError(t, err, "The Software Component could not be checked. Please make sure the respective Software Component has been cloned successfully on the system")
 	})
 }
 
+func TestATCBaseline(t *testing.T) {
+	bodyString := `<?xml version="1.0" encoding="UTF-8"?>
+		<checkstyle>
+			<file name="src/zcl_legacy.clas.abap">
+				<error message="Missing authority check" source="Security Check" line="12" severity="error">
+				</error>
+			</file>
+		</checkstyle>`
+	newFinding := `<?xml version="1.0" encoding="UTF-8"?>
+		<checkstyle>
+			<file name="src/zcl_legacy.clas.abap">
+				<error message="Missing authority check" source="Security Check" line="15" severity="error">
+				</error>
+			</file>
+			<file name="src/zcl_new.clas.abap">
+				<error message="Missing authority check" source="Security Check" line="3" severity="error">
+				</error>
+			</file>
+		</checkstyle>`
+
+	t.Run("create baseline and suppress known findings", func(t *testing.T) {
+		dir := t.TempDir()
+		oldCWD, _ := os.Getwd()
+		_ = os.Chdir(dir)
+		defer func() {
+			_ = os.Chdir(oldCWD)
+		}()
+		files := &mock.FilesMock{}
+		options := &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: "error", BaselineFile: "atcBaseline.json", UpdateBaseline: true}
+
+		err, failStep := logAndPersistAndEvaluateATCResults(files, []byte(bodyString), options, "https://example.com")
+		assert.NoError(t, err)
+		assert.False(t, failStep)
+		assert.True(t, files.HasFile("atcBaseline.json"))
+
+		options.UpdateBaseline = false
+		err, failStep = logAndPersistAndEvaluateATCResults(files, []byte(bodyString), options, "https://example.com")
+		assert.NoError(t, err)
+		assert.False(t, failStep, "known finding must not fail the step")
+
+		options.GenerateSARIF = true
+		err, failStep = logAndPersistAndEvaluateATCResults(files, []byte(newFinding), options, "https://example.com")
+		assert.NoError(t, err)
+		assert.True(t, failStep, "new finding must fail the step")
+		sarif, err := files.FileRead("ATCResults.sarif")
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(sarif), `"suppressions":[{"kind":"external","status":"accepted"`)
+			assert.Contains(t, string(sarif), `"uri":"https://example.com/sap/bc/adt/oo/classes/zcl_new/source/main#start=3,0"`)
+		}
+	})
+
+	t.Run("missing baseline file", func(t *testing.T) {
+		dir := t.TempDir()
+		oldCWD, _ := os.Getwd()
+		_ = os.Chdir(dir)
+		defer func() {
+			_ = os.Chdir(oldCWD)
+		}()
+		options := &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", FailOnSeverity: "error", BaselineFile: "atcBaseline.json"}
+
+		err, failStep := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, []byte(bodyString), options, "")
+		assert.NoError(t, err)
+		assert.True(t, failStep)
+	})
+
+	t.Run("update baseline without baseline file", func(t *testing.T) {
+		options := &abapEnvironmentRunATCCheckOptions{AtcResultsFileName: "ATCResults.xml", UpdateBaseline: true}
+
+		err, _ := logAndPersistAndEvaluateATCResults(&mock.FilesMock{}, []byte(bodyString), options, "")
+		assert.EqualError(t, err, "Updating the baseline requires the parameter baselineFile")
+	})
+}
+
 func TestBuildATCCheckBody(t *testing.T) {
 	t.Run("Test build body with no ATC Object set - no software component and package", func(t *testing.T) {
 		expectedObjectSet := "<obj:objectSet></obj:objectSet>"
@@ -618,7 +691,7 @@ func TestGenerateHTMLDocument(t *testing.T) {
 			</file>
 		</checkstyle>`
 
-		parsedXML := new(Result)
+		parsedXML := new(abaputils.Checkstyle)
 		err := xml.Unmarshal([]byte(bodyString), &parsedXML)
 		if assert.NoError(t, err) {
 			htmlDocumentResult := generateHTMLDocument(parsedXML)
@@ -64,7 +64,7 @@ func runAbapEnvironmentRunAUnitTest(config *abapEnvironmentRunAUnitTestOptions,
 		resp, err = triggerAUnitrun(*config, details, client)
 	}
 	if err == nil {
-		err = fetchAndPersistAUnitResults(resp, details, client, utils, config.AUnitResultsFileName, config.GenerateHTML)
+		err = fetchAndPersistAUnitResults(resp, details, client, utils, config.AUnitResultsFileName, config.GenerateHTML, config.GenerateSARIF)
 	}
 	if err != nil {
 		log.Entry().WithError(err).Fatal("step execution failed")
@@ -136,7 +136,7 @@ func convertAUnitOptions(options *abapEnvironmentRunAUnitTestOptions) abaputils.
 	return subOptions
 }
 
-func fetchAndPersistAUnitResults(resp *http.Response, details abaputils.ConnectionDetailsHTTP, client piperhttp.Sender, utils piperutils.FileUtils, aunitResultFileName string, generateHTML bool) error {
+func fetchAndPersistAUnitResults(resp *http.Response, details abaputils.ConnectionDetailsHTTP, client piperhttp.Sender, utils piperutils.FileUtils, aunitResultFileName string, generateHTML, generateSARIF bool) error {
 	var err error
 	abapEndpoint := details.URL
 	location := resp.Header.Get("Location")
@@ -153,7 +153,7 @@ func fetchAndPersistAUnitResults(resp *http.Response, details abaputils.Connecti
 	}
 	if err == nil {
 		defer resp.Body.Close()
-		err = persistAUnitResult(utils, body, aunitResultFileName, generateHTML)
+		err = persistAUnitResult(utils, body, aunitResultFileName, generateHTML, generateSARIF, abapEndpoint)
 	}
 	if err != nil {
 		return fmt.Errorf("Handling AUnit result failed: %w", err)
@@ -346,7 +346,7 @@ func getAUnitResults(requestType string, details abaputils.ConnectionDetailsHTTP
 	return req, err
 }
 
-func persistAUnitResult(utils piperutils.FileUtils, body []byte, aunitResultFileName string, generateHTML bool) (err error) {
+func persistAUnitResult(utils piperutils.FileUtils, body []byte, aunitResultFileName string, generateHTML, generateSARIF bool, systemURL string) (err error) {
 	if len(body) == 0 {
 		return fmt.Errorf("Parsing AUnit result failed: %w", errors.New("Body is empty, can't parse empty body"))
 	}
@@ -355,7 +355,7 @@ func persistAUnitResult(utils piperutils.FileUtils, body []byte, aunitResultFile
 	log.Entry().Debugf("Response body: %s", responseBody)
 
 	//Optional checks before writing the Results
-	parsedXML := new(AUnitResult)
+	parsedXML := new(abaputils.AUnitResult)
 	if err := xml.Unmarshal([]byte(body), &parsedXML); err != nil {
 		log.Entry().WithError(err).Warning("failed to unmarshal xml response")
 	}
@@ -396,13 +396,21 @@ func persistAUnitResult(utils piperutils.FileUtils, body []byte, aunitResultFile
 			reports = append(reports, piperutils.Path{Target: aUnitResultHTMLFileName, Name: "ATC Results HTML file", Mandatory: true})
 		}
 	}
+	if generateSARIF {
+		aUnitResultSARIFFileName := strings.TrimSuffix(aunitResultFileName, ".xml") + ".sarif"
+		if err := abaputils.WriteSARIF(abaputils.ToSARIF("ABAP Unit", parsedXML.Findings(), systemURL), aUnitResultSARIFFileName, utils); err != nil {
+			return fmt.Errorf("Writing SARIF file failed: %w", err)
+		}
+		log.Entry().Info("Writing " + aUnitResultSARIFFileName + " file was successful")
+		reports = append(reports, piperutils.Path{Target: aUnitResultSARIFFileName, Name: "AUnit Results SARIF file"})
+	}
 	//Persist findings afterwards
 	reports = append(reports, piperutils.Path{Target: aunitResultFileName, Name: "AUnit Results", Mandatory: true})
 	piperutils.PersistReportsAndLinks("abapEnvironmentRunAUnitTest", "", utils, reports, nil)
 	return nil
 }
 
-func generateHTMLDocumentAUnit(parsedXML *AUnitResult) (htmlDocumentString string) {
+func generateHTMLDocumentAUnit(parsedXML *abaputils.AUnitResult) (htmlDocumentString string) {
 	htmlDocumentString = `<!DOCTYPE html><html lang="en" xmlns="http://www.w3.org/1999/xhtml"><head><title>AUnit Results</title><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /><style>table,th,td {border-collapse:collapse;}th,td{padding: 5px;text-align:left;font-size:medium;}</style></head><body><h1 style="text-align:left;font-size:large">AUnit Results</h1><table><tr><th>Run title</th><td style="padding-right: 20px">` + parsedXML.Title + `</td><th>System</th><td style="padding-right: 20px">` + parsedXML.System + `</td><th>Client</th><td style="padding-right: 20px">` + parsedXML.Client + `</td><th>ExecutedBy</th><td style="padding-right: 20px">` + parsedXML.ExecutedBy + `</td><th>Duration</th><td style="padding-right: 20px">` + parsedXML.Time + `s</td><th>Timestamp</th><td style="padding-right: 20px">` + parsedXML.Timestamp + `</td></tr><tr><th>Failures</th><td style="padding-right: 20px">` + parsedXML.Failures + `</td><th>Errors</th><td style="padding-right: 20px">` + parsedXML.Errors + `</td><th>Skipped</th><td style="padding-right: 20px">` + parsedXML.Skipped + `</td><th>Asserts</th><td style="padding-right: 20px">` + parsedXML.Asserts + `</td><th>Tests</th><td style="padding-right: 20px">` + parsedXML.Tests + `</td></tr></table><br><table style="width:100%; border: 1px solid black""><tr style="border: 1px solid black"><th style="border: 1px solid black">Severity</th><th style="border: 1px solid black">File</th><th style="border: 1px solid black">Message</th><th style="border: 1px solid black">Type</th><th style="border: 1px solid black">Text</th></tr>`
 
 	var htmlDocumentStringError, htmlDocumentStringWarning, htmlDocumentStringInfo, htmlDocumentStringDefault string
@@ -511,54 +519,3 @@ type AUnitLink struct {
 	Rel  string `xml:"rel,attr"`
 	Type string `xml:"type,attr"`
 }
-
-//
-//	AUnit Result Structure
-//
-
-type AUnitResult struct {
-	XMLName    xml.Name `xml:"testsuites"`
-	Title      string   `xml:"title,attr"`
-	System     string   `xml:"system,attr"`
-	Client     string   `xml:"client,attr"`
-	ExecutedBy string   `xml:"executedBy,attr"`
-	Time       string   `xml:"time,attr"`
-	Timestamp  string   `xml:"timestamp,attr"`
-	Failures   string   `xml:"failures,attr"`
-	Errors     string   `xml:"errors,attr"`
-	Skipped    string   `xml:"skipped,attr"`
-	Asserts    string   `xml:"asserts,attr"`
-	Tests      string   `xml:"tests,attr"`
-	Testsuite  struct {
-		Tests     string `xml:"tests,attr"`
-		Asserts   string `xml:"asserts,attr"`
-		Skipped   string `xml:"skipped,attr"`
-		Errors    string `xml:"errors,attr"`
-		Failures  string `xml:"failures,attr"`
-		Timestamp string `xml:"timestamp,attr"`
-		Time      string `xml:"time,attr"`
-		Hostname  string `xml:"hostname,attr"`
-		Package   string `xml:"package,attr"`
-		Name      string `xml:"name,attr"`
-		Testcase  []struct {
-			Asserts   string `xml:"asserts,attr"`
-			Time      string `xml:"time,attr"`
-			Name      string `xml:"name,attr"`
-			Classname string `xml:"classname,attr"`
-			Error     []struct {
-				Text    string `xml:",chardata"`
-				Type    string `xml:"type,attr"`
-				Message string `xml:"message,attr"`
-			} `xml:"error"`
-			Failure []struct {
-				Text    string `xml:",chardata"`
-				Type    string `xml:"type,attr"`
-				Message string `xml:"message,attr"`
-			} `xml:"failure"`
-			Skipped []struct {
-				Text    string `xml:",chardata"`
-				Message string `xml:"message,attr"`
-			} `xml:"skipped"`
-		} `xml:"testcase"`
-	} `xml:"testsuite"`
-}
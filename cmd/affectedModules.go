@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/changeimpact"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/maven"
+	"github.com/SAP/jenkins-library/pkg/npm"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+)
+
+// determineChangedFiles is a variable in order to allow mocking the git repository in tests
+var determineChangedFiles = changeimpact.ChangedFiles
+
+// changedFilesForAffectedModules returns the files changed compared to the reference.
+// Without a reference, the changes are compared to the base branch of the pull request or to the commit of the last successful build.
+// In case the changes cannot be determined (e.g. in a shallow clone) ok is false and all modules need to be built.
+func changedFilesForAffectedModules(reference string) (changedFiles []string, ok bool) {
+	if len(reference) == 0 {
+		reference = detectChangedFilesReference()
+	}
+	if len(reference) == 0 {
+		log.Entry().Warn("Building all modules since no changedFilesReference is configured and neither a pull request nor a successful build has been found")
+		return nil, false
+	}
+	changedFiles, err := determineChangedFiles(reference)
+	if err != nil {
+		log.Entry().WithError(err).Warnf("Building all modules since the changes compared to '%v' could not be determined", reference)
+		return nil, false
+	}
+	log.Entry().Infof("%v files changed compared to '%v'", len(changedFiles), reference)
+	return changedFiles, true
+}
+
+// detectChangedFilesReference returns the base branch of the pull request or, for branch builds, the commit of the last successful build
+func detectChangedFilesReference() string {
+	provider, err := orchestrator.GetOrchestratorConfigProvider(nil)
+	if err != nil {
+		log.Entry().WithError(err).Debug("Unable to detect the reference of the changes")
+		return ""
+	}
+	if provider.IsPullRequest() {
+		base := strings.TrimPrefix(provider.PullRequestConfig().Base, "refs/heads/")
+		if len(base) > 0 && base != "n/a" {
+			log.Entry().Infof("Comparing the changes to the base branch '%v' of the pull request", base)
+			return "origin/" + base
+		}
+	}
+	if commit := provider.LastSuccessfulCommit(); len(commit) > 0 {
+		log.Entry().Infof("Comparing the changes to the commit '%v' of the last successful build", commit)
+		return commit
+	}
+	return ""
+}
+
+// affectedMavenProjects returns the directories of the modules affected by the changes compared to the reference
+// relative to the root POM. In case all modules need to be built, buildAll is true.
+func affectedMavenProjects(pomPath, reference string, utils maven.Utils) (projects []string, buildAll bool) {
+	changedFiles, ok := changedFilesForAffectedModules(reference)
+	if !ok {
+		return nil, true
+	}
+	if len(pomPath) == 0 {
+		pomPath = "pom.xml"
+	}
+	rootDir := filepath.Dir(pomPath)
+	modules, err := maven.AffectedModules(rootDir, utils, nil, changedFiles)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Building all modules since the affected modules could not be determined")
+		return nil, true
+	}
+
+	projects = []string{}
+	for _, module := range modules {
+		dir, err := filepath.Rel(rootDir, filepath.Dir(module.PomXMLPath))
+		if err != nil {
+			log.Entry().WithError(err).Warn("Building all modules since the affected modules could not be determined")
+			return nil, true
+		}
+		if dir == "." {
+			log.Entry().Info("Building all modules since the root module is affected by the changes")
+			return nil, true
+		}
+		if dir == "integration-tests" {
+			// integration tests are executed by step mavenExecuteIntegration
+			continue
+		}
+		log.Entry().Infof("Module '%v' is affected by the changes", dir)
+		projects = append(projects, filepath.ToSlash(dir))
+	}
+	return projects, false
+}
+
+// affectedNpmPackages returns the package.json files of the packages affected by the changes compared to the reference.
+// In case the changes cannot be determined, restricted is false and all packages need to be considered.
+func affectedNpmPackages(npmExecutor npm.Executor, reference string, packageJSONFiles, excludeList []string) (affectedPackages []string, restricted bool, err error) {
+	changedFiles, ok := changedFilesForAffectedModules(reference)
+	if !ok {
+		return nil, false, nil
+	}
+	if len(packageJSONFiles) == 0 {
+		if packageJSONFiles, err = npmExecutor.FindPackageJSONFilesWithExcludes(excludeList); err != nil {
+			return nil, false, err
+		}
+	}
+	if affectedPackages, err = npmExecutor.FindAffectedPackageJSONFiles(packageJSONFiles, changedFiles); err != nil {
+		return nil, false, err
+	}
+	for _, packageJSON := range affectedPackages {
+		log.Entry().Infof("Package '%v' is affected by the changes", packageJSON)
+	}
+	return affectedPackages, true, nil
+}
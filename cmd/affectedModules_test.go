@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockChangedFiles replaces the detection of changed files for the duration of the test
+func mockChangedFiles(t *testing.T, changedFiles []string, err error) {
+	original := determineChangedFiles
+	determineChangedFiles = func(reference string) ([]string, error) {
+		return changedFiles, err
+	}
+	t.Cleanup(func() { determineChangedFiles = original })
+}
+
+func TestDetectChangedFilesReference(t *testing.T) {
+	setJenkinsEnv := func(t *testing.T) {
+		t.Setenv("AZURE_HTTP_USER_AGENT", "")
+		t.Setenv("GITHUB_ACTION", "")
+		t.Setenv("GITHUB_ACTIONS", "")
+		t.Setenv("JENKINS_URL", "https://jenkins.example.org")
+		t.Setenv("CHANGE_ID", "")
+		t.Setenv("GIT_PREVIOUS_SUCCESSFUL_COMMIT", "")
+		orchestrator.ResetConfigProvider()
+		t.Cleanup(orchestrator.ResetConfigProvider)
+	}
+
+	t.Run("pull request", func(t *testing.T) {
+		setJenkinsEnv(t)
+		t.Setenv("CHANGE_ID", "42")
+		t.Setenv("CHANGE_TARGET", "main")
+		t.Setenv("GIT_PREVIOUS_SUCCESSFUL_COMMIT", "abcdef42712")
+
+		assert.Equal(t, "origin/main", detectChangedFilesReference())
+	})
+
+	t.Run("last successful build", func(t *testing.T) {
+		setJenkinsEnv(t)
+		t.Setenv("GIT_PREVIOUS_SUCCESSFUL_COMMIT", "abcdef42712")
+
+		assert.Equal(t, "abcdef42712", detectChangedFilesReference())
+	})
+
+	t.Run("first build", func(t *testing.T) {
+		setJenkinsEnv(t)
+
+		assert.Equal(t, "", detectChangedFilesReference())
+	})
+}
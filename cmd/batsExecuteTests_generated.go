@@ -59,6 +59,16 @@ func (i *batsExecuteTestsInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *batsExecuteTestsInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"bats": i.step_data.fields.bats,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 // BatsExecuteTestsCommand This step executes tests using the [Bash Automated Testing System - bats-core](https://github.com/bats-core/bats-core).
 func BatsExecuteTestsCommand() *cobra.Command {
 	const STEP_NAME = "batsExecuteTests"
@@ -129,9 +139,12 @@ func BatsExecuteTestsCommand() *cobra.Command {
 			handler := func() {
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -22,6 +22,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/scm"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
 	"github.com/bmatcuk/doublestar"
@@ -105,10 +106,7 @@ func checkmarxExecuteScan(config checkmarxExecuteScanOptions, _ *telemetry.Custo
 	options := piperHttp.ClientOptions{MaxRetries: config.MaxRetries}
 	client.SetOptions(options)
 	// TODO provide parameter for trusted certs
-	ctx, ghClient, err := piperGithub.NewClientBuilder(config.GithubToken, config.GithubAPIURL).Build()
-	if err != nil {
-		log.Entry().WithError(err).Warning("Failed to get GitHub client")
-	}
+	ctx, ghClient := newScanGitHubClient(config.ScmProvider, config.GithubToken, config.GithubAPIURL, nil)
 	sys, err := checkmarx.NewSystemInstance(client, config.ServerURL, config.Username, config.Password)
 	if err != nil {
 		log.Entry().WithError(err).Fatalf("Failed to create Checkmarx client talking to URL %v", config.ServerURL)
@@ -419,16 +417,20 @@ func verifyCxProjectCompliance(ctx context.Context, config checkmarxExecuteScanO
 		scanReport := checkmarx.CreateCustomReport(results, insecureResults, neutralResults)
 
 		if insecure && config.CreateResultIssue && len(config.GithubToken) > 0 && len(config.GithubAPIURL) > 0 && len(config.Owner) > 0 && len(config.Repository) > 0 {
-			log.Entry().Debug("Creating/updating GitHub issue with check results")
-			gh := reporting.GitHub{
-				Owner:         &config.Owner,
-				Repository:    &config.Repository,
-				Assignees:     &config.Assignees,
-				IssueService:  utils.GetIssueService(),
-				SearchService: utils.GetSearchService(),
+			log.Entry().Debug("Creating/updating issue with check results")
+			provider, err := newScanResultProvider(scm.Options{
+				Provider:   config.ScmProvider,
+				APIURL:     config.GithubAPIURL,
+				Token:      config.GithubToken,
+				Owner:      config.Owner,
+				Repository: config.Repository,
+			}, utils.GetIssueService(), utils.GetSearchService())
+			if err != nil {
+				return err
 			}
-			if err := gh.UploadSingleReport(ctx, scanReport); err != nil {
-				return fmt.Errorf("failed to upload scan results into GitHub: %w", err)
+			issues := reporting.SCM{Provider: provider, Assignees: config.Assignees}
+			if err := issues.UploadSingleReport(ctx, scanReport); err != nil {
+				return fmt.Errorf("failed to upload scan results: %w", err)
 			}
 		}
 
@@ -39,6 +39,7 @@ type checkmarxExecuteScanOptions struct {
 	ProjectName                          string   `json:"projectName,omitempty"`
 	PullRequestName                      string   `json:"pullRequestName,omitempty"`
 	Repository                           string   `json:"repository,omitempty"`
+	ScmProvider                          string   `json:"scmProvider,omitempty" validate:"possible-values=github gitlab bitbucket azure"`
 	ServerURL                            string   `json:"serverUrl,omitempty"`
 	EngineConfigurationID                string   `json:"engineConfigurationID,omitempty"`
 	TeamID                               string   `json:"teamId,omitempty"`
@@ -185,6 +186,62 @@ func (i *checkmarxExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *checkmarxExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"checkmarx": i.step_data.fields.checkmarx,
+		},
+		"checkmarx_data": {
+			"high_issues":                          i.checkmarx_data.fields.high_issues,
+			"high_not_false_positive":              i.checkmarx_data.fields.high_not_false_positive,
+			"high_not_exploitable":                 i.checkmarx_data.fields.high_not_exploitable,
+			"high_confirmed":                       i.checkmarx_data.fields.high_confirmed,
+			"high_urgent":                          i.checkmarx_data.fields.high_urgent,
+			"high_proposed_not_exploitable":        i.checkmarx_data.fields.high_proposed_not_exploitable,
+			"high_to_verify":                       i.checkmarx_data.fields.high_to_verify,
+			"medium_issues":                        i.checkmarx_data.fields.medium_issues,
+			"medium_not_false_positive":            i.checkmarx_data.fields.medium_not_false_positive,
+			"medium_not_exploitable":               i.checkmarx_data.fields.medium_not_exploitable,
+			"medium_confirmed":                     i.checkmarx_data.fields.medium_confirmed,
+			"medium_urgent":                        i.checkmarx_data.fields.medium_urgent,
+			"medium_proposed_not_exploitable":      i.checkmarx_data.fields.medium_proposed_not_exploitable,
+			"medium_to_verify":                     i.checkmarx_data.fields.medium_to_verify,
+			"low_issues":                           i.checkmarx_data.fields.low_issues,
+			"low_not_false_positive":               i.checkmarx_data.fields.low_not_false_positive,
+			"low_not_exploitable":                  i.checkmarx_data.fields.low_not_exploitable,
+			"low_confirmed":                        i.checkmarx_data.fields.low_confirmed,
+			"low_urgent":                           i.checkmarx_data.fields.low_urgent,
+			"low_proposed_not_exploitable":         i.checkmarx_data.fields.low_proposed_not_exploitable,
+			"low_to_verify":                        i.checkmarx_data.fields.low_to_verify,
+			"information_issues":                   i.checkmarx_data.fields.information_issues,
+			"information_not_false_positive":       i.checkmarx_data.fields.information_not_false_positive,
+			"information_not_exploitable":          i.checkmarx_data.fields.information_not_exploitable,
+			"information_confirmed":                i.checkmarx_data.fields.information_confirmed,
+			"information_urgent":                   i.checkmarx_data.fields.information_urgent,
+			"information_proposed_not_exploitable": i.checkmarx_data.fields.information_proposed_not_exploitable,
+			"information_to_verify":                i.checkmarx_data.fields.information_to_verify,
+			"lines_of_code_scanned":                i.checkmarx_data.fields.lines_of_code_scanned,
+			"files_scanned":                        i.checkmarx_data.fields.files_scanned,
+			"initiator_name":                       i.checkmarx_data.fields.initiator_name,
+			"owner":                                i.checkmarx_data.fields.owner,
+			"scan_id":                              i.checkmarx_data.fields.scan_id,
+			"project_id":                           i.checkmarx_data.fields.project_id,
+			"projectName":                          i.checkmarx_data.fields.projectName,
+			"team":                                 i.checkmarx_data.fields.team,
+			"team_full_path_on_report_date":        i.checkmarx_data.fields.team_full_path_on_report_date,
+			"scan_start":                           i.checkmarx_data.fields.scan_start,
+			"scan_time":                            i.checkmarx_data.fields.scan_time,
+			"checkmarx_version":                    i.checkmarx_data.fields.checkmarx_version,
+			"scan_type":                            i.checkmarx_data.fields.scan_type,
+			"preset":                               i.checkmarx_data.fields.preset,
+			"deep_link":                            i.checkmarx_data.fields.deep_link,
+			"report_creation_time":                 i.checkmarx_data.fields.report_creation_time,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type checkmarxExecuteScanReports struct {
 }
 
@@ -309,9 +366,12 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -374,6 +434,7 @@ func addCheckmarxExecuteScanFlags(cmd *cobra.Command, stepConfig *checkmarxExecu
 	cmd.Flags().StringVar(&stepConfig.ProjectName, "projectName", os.Getenv("PIPER_projectName"), "The name of the Checkmarx project to scan into")
 	cmd.Flags().StringVar(&stepConfig.PullRequestName, "pullRequestName", os.Getenv("PIPER_pullRequestName"), "Used to supply the name for the newly created PR project branch when being used in pull request scenarios")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Set the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.ScmProvider, "scmProvider", `github`, "SCM hosting the repository, result issues and pull request comments are created there. `githubApiUrl`, `githubToken`, `owner` and `repository` are used for the selected SCM, e.g. `githubApiUrl` is the GitLab API URL, `owner` the Bitbucket project key or the Azure DevOps project.")
 	cmd.Flags().StringVar(&stepConfig.ServerURL, "serverUrl", os.Getenv("PIPER_serverUrl"), "The URL pointing to the root of the Checkmarx server to be used")
 	cmd.Flags().StringVar(&stepConfig.EngineConfigurationID, "engineConfigurationID", os.Getenv("PIPER_engineConfigurationID"), "The engine configuration ID to be used, if not set explicitly the project's default will be used")
 	cmd.Flags().StringVar(&stepConfig.TeamID, "teamId", os.Getenv("PIPER_teamId"), "The group ID related to your team which can be obtained via the Pipeline Syntax plugin as described in the `Details` section")
@@ -593,6 +654,15 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						Aliases:   []config.Alias{{Name: "githubRepo"}},
 						Default:   os.Getenv("PIPER_repository"),
 					},
+					{
+						Name:        "scmProvider",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `github`,
+					},
 					{
 						Name:        "serverUrl",
 						ResourceRef: []config.ResourceReference{},
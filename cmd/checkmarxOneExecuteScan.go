@@ -22,8 +22,10 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/scm"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
+	"github.com/SAP/jenkins-library/pkg/triage"
 	"github.com/bmatcuk/doublestar"
 	"github.com/google/go-github/v45/github"
 	"github.com/pkg/errors"
@@ -111,6 +113,11 @@ func runStep(config checkmarxOneExecuteScanOptions, influx *checkmarxOneExecuteS
 		}
 	}
 
+	prScan, err := cx1sh.PullRequestScan()
+	if err != nil {
+		return fmt.Errorf("failed to determine pull request: %s", err)
+	}
+
 	err = cx1sh.SetProjectPreset()
 	if err != nil {
 		return fmt.Errorf("failed to set preset: %s", err)
@@ -131,7 +138,12 @@ func runStep(config checkmarxOneExecuteScanOptions, influx *checkmarxOneExecuteS
 
 	if config.VerifyOnly {
 		if len(scans) > 0 {
-			results, err := cx1sh.ParseResults(&scans[0]) // incl report-gen
+			err = cx1sh.SyncAssessments(&scans[0])
+			if err != nil {
+				return fmt.Errorf("failed to synchronize assessments: %s", err)
+			}
+
+			results, _, err := cx1sh.ParseResults(&scans[0]) // incl report-gen
 			if err != nil {
 				return fmt.Errorf("failed to get scan results: %s", err)
 			}
@@ -152,6 +164,10 @@ func runStep(config checkmarxOneExecuteScanOptions, influx *checkmarxOneExecuteS
 	if err != nil {
 		return fmt.Errorf("failed to determine incremental or full scan configuration: %s", err)
 	}
+	if prScan != nil {
+		// pull requests are always scanned incrementally, scheduled full scans are meant for the main branch
+		incremental = true
+	}
 
 	if config.Incremental {
 		log.Entry().Warnf("If you change your file filter pattern it is recommended to run a Full scan instead of an incremental, to ensure full code coverage.")
@@ -180,11 +196,20 @@ func runStep(config checkmarxOneExecuteScanOptions, influx *checkmarxOneExecuteS
 		return fmt.Errorf("failed while polling scan status: %s", err)
 	}
 
-	results, err := cx1sh.ParseResults(scan) // incl report-gen
+	err = cx1sh.SyncAssessments(scan)
+	if err != nil {
+		return fmt.Errorf("failed to synchronize assessments: %s", err)
+	}
+
+	results, scanResults, err := cx1sh.ParseResults(scan) // incl report-gen
 	if err != nil {
 		return fmt.Errorf("failed to get scan results: %s", err)
 	}
-	err = cx1sh.CheckCompliance(scan, &results)
+	if prScan != nil {
+		err = cx1sh.CheckPullRequestCompliance(scan, &results, scanResults, prScan)
+	} else {
+		err = cx1sh.CheckCompliance(scan, &results)
+	}
 	if err != nil {
 		log.SetErrorCategory(log.ErrorCompliance)
 		return fmt.Errorf("project %v not compliant: %s", cx1sh.Project.Name, err)
@@ -197,10 +222,7 @@ func runStep(config checkmarxOneExecuteScanOptions, influx *checkmarxOneExecuteS
 func Authenticate(config checkmarxOneExecuteScanOptions, influx *checkmarxOneExecuteScanInflux) (checkmarxOneExecuteScanHelper, error) {
 	client := &piperHttp.Client{}
 
-	ctx, ghClient, err := piperGithub.NewClientBuilder(config.GithubToken, config.GithubAPIURL).Build()
-	if err != nil {
-		log.Entry().WithError(err).Warning("Failed to get GitHub client")
-	}
+	ctx, ghClient := newScanGitHubClient(config.ScmProvider, config.GithubToken, config.GithubAPIURL, nil)
 	sys, err := checkmarxOne.NewSystemInstance(client, config.ServerURL, config.IamURL, config.Tenant, config.APIKey, config.ClientID, config.ClientSecret)
 	if err != nil {
 		return checkmarxOneExecuteScanHelper{}, fmt.Errorf("failed to create Checkmarx One client talking to URLs %v and %v with tenant %v: %s", config.ServerURL, config.IamURL, config.Tenant, err)
@@ -543,16 +565,20 @@ func (c *checkmarxOneExecuteScanHelper) CheckCompliance(scan *checkmarxOne.Scan,
 		scanReport := checkmarxOne.CreateCustomReport(detailedResults, insecureResults, neutralResults)
 
 		if insecure && c.config.CreateResultIssue && len(c.config.GithubToken) > 0 && len(c.config.GithubAPIURL) > 0 && len(c.config.Owner) > 0 && len(c.config.Repository) > 0 {
-			log.Entry().Debug("Creating/updating GitHub issue with check results")
-			gh := reporting.GitHub{
-				Owner:         &c.config.Owner,
-				Repository:    &c.config.Repository,
-				Assignees:     &c.config.Assignees,
-				IssueService:  c.utils.GetIssueService(),
-				SearchService: c.utils.GetSearchService(),
+			log.Entry().Debug("Creating/updating issue with check results")
+			provider, err := newScanResultProvider(scm.Options{
+				Provider:   c.config.ScmProvider,
+				APIURL:     c.config.GithubAPIURL,
+				Token:      c.config.GithubToken,
+				Owner:      c.config.Owner,
+				Repository: c.config.Repository,
+			}, c.utils.GetIssueService(), c.utils.GetSearchService())
+			if err != nil {
+				return err
 			}
-			if err := gh.UploadSingleReport(c.ctx, scanReport); err != nil {
-				return fmt.Errorf("failed to upload scan results into GitHub: %s", err)
+			issues := reporting.SCM{Provider: provider, Assignees: c.config.Assignees}
+			if err := issues.UploadSingleReport(c.ctx, scanReport); err != nil {
+				return fmt.Errorf("failed to upload scan results: %s", err)
 			}
 		}
 
@@ -581,6 +607,91 @@ func (c *checkmarxOneExecuteScanHelper) CheckCompliance(scan *checkmarxOne.Scan,
 	return nil
 }
 
+// PullRequestScan determines the pull request the pipeline runs for if pull requests are to be scanned incrementally
+func (c *checkmarxOneExecuteScanHelper) PullRequestScan() (*pullRequestScan, error) {
+	if !c.config.PullRequestScan {
+		return nil, nil
+	}
+	prScan, err := newPullRequestScan(c.utils.GetWorkspace())
+	if err != nil || prScan == nil {
+		return nil, err
+	}
+	if len(c.config.PullRequestName) == 0 {
+		c.config.PullRequestName = fmt.Sprintf("PR-%v", prScan.number)
+	}
+	c.config.Incremental = true
+	return prScan, nil
+}
+
+// CheckPullRequestCompliance checks for new findings in the lines changed by the pull request instead of enforcing the thresholds of the project
+func (c *checkmarxOneExecuteScanHelper) CheckPullRequestCompliance(scan *checkmarxOne.Scan, detailedResults *map[string]interface{}, results []checkmarxOne.ScanResult, prScan *pullRequestScan) error {
+	links := []piperutils.Path{{Target: (*detailedResults)["DeepLink"].(string), Name: "Checkmarx One Web UI"}}
+	piperutils.PersistReportsAndLinks("checkmarxOneExecuteScan", c.utils.GetWorkspace(), c.utils, c.reports, links)
+	c.reportToInflux(detailedResults)
+
+	findings := prScan.newFindings("", c.pullRequestFindings(scan, results))
+	log.Entry().Infof("Found %v new findings in the lines changed by pull request %v", len(findings), prScan.number)
+	for _, finding := range findings {
+		log.Entry().Warnf("%v %v in %v:%v", finding.Severity, finding.Category, finding.File, finding.Line)
+	}
+
+	if len(c.config.GithubToken) > 0 && len(c.config.GithubAPIURL) > 0 && len(c.config.Owner) > 0 && len(c.config.Repository) > 0 {
+		provider, err := scm.NewProvider(scm.Options{
+			Provider:   c.config.ScmProvider,
+			APIURL:     c.config.GithubAPIURL,
+			Token:      c.config.GithubToken,
+			Owner:      c.config.Owner,
+			Repository: c.config.Repository,
+		})
+		if err != nil {
+			return err
+		}
+		prScan.comment(c.ctx, provider, "checkmarxOneExecuteScan", findings)
+	}
+
+	if len(findings) > 0 {
+		if c.config.VulnerabilityThresholdResult == "FAILURE" {
+			log.SetErrorCategory(log.ErrorCompliance)
+			return fmt.Errorf("the pull request introduces %v new findings - see the pull request comments or the log for details", len(findings))
+		}
+		log.Entry().Errorf("Checkmarx One scan result set to %v, the pull request introduces %v new findings.", c.config.VulnerabilityThresholdResult, len(findings))
+	} else {
+		log.Entry().Infoln("Checkmarx One scan finished successfully")
+	}
+	return nil
+}
+
+// pullRequestFindings returns a finding for each node of the new SAST results with one of the configured severities
+func (c *checkmarxOneExecuteScanHelper) pullRequestFindings(scan *checkmarxOne.Scan, results []checkmarxOne.ScanResult) []reporting.CodeFinding {
+	findings := []reporting.CodeFinding{}
+	for _, result := range results {
+		if result.Type != "sast" || result.Status != "NEW" || result.State == "NOT_EXPLOITABLE" || !containsSeverity(c.config.PullRequestSeverities, result.Severity) {
+			continue
+		}
+		for _, node := range result.Data.Nodes {
+			findings = append(findings, reporting.CodeFinding{
+				ID:       strconv.FormatInt(result.SimilarityID, 10),
+				Category: result.Data.QueryName,
+				Severity: result.Severity,
+				File:     node.FileName,
+				Line:     node.Line,
+				URL:      fmt.Sprintf("%v/results/%v/%v/sast", c.config.ServerURL, scan.ProjectID, scan.ScanID),
+			})
+		}
+	}
+	return findings
+}
+
+// SyncAssessments synchronizes the assessment file with the states of the scan's SAST results
+func (c *checkmarxOneExecuteScanHelper) SyncAssessments(scan *checkmarxOne.Scan) error {
+	if c.config.AssessmentSync == triage.None {
+		return nil
+	}
+	auditor := checkmarxOne.NewTriageAuditor(c.sys, c.Project.ProjectID, scan.ScanID)
+	_, err := triage.Run(auditor, filepath.Join(c.utils.GetWorkspace(), c.config.AssessmentFile), c.config.AssessmentSync, &piperutils.Files{})
+	return err
+}
+
 func (c *checkmarxOneExecuteScanHelper) GetReportPDF(scan *checkmarxOne.Scan) error {
 	if c.config.GeneratePdfReport {
 		pdfReportName := c.createReportName(c.utils.GetWorkspace(), "Cx1_SASTReport_%v.pdf")
@@ -637,12 +748,12 @@ func (c *checkmarxOneExecuteScanHelper) GetHeaderReportJSON(detailedResults *map
 	return nil
 }
 
-func (c *checkmarxOneExecuteScanHelper) ParseResults(scan *checkmarxOne.Scan) (map[string]interface{}, error) {
+func (c *checkmarxOneExecuteScanHelper) ParseResults(scan *checkmarxOne.Scan) (map[string]interface{}, []checkmarxOne.ScanResult, error) {
 	var detailedResults map[string]interface{}
 
 	scanmeta, err := c.sys.GetScanMetadata(scan.ScanID)
 	if err != nil {
-		return detailedResults, fmt.Errorf("Unable to fetch scan metadata for scan %v: %s", scan.ScanID, err)
+		return detailedResults, nil, fmt.Errorf("Unable to fetch scan metadata for scan %v: %s", scan.ScanID, err)
 	}
 
 	totalResultCount := uint64(0)
@@ -650,19 +761,19 @@ func (c *checkmarxOneExecuteScanHelper) ParseResults(scan *checkmarxOne.Scan) (m
 	scansummary, err := c.sys.GetScanSummary(scan.ScanID)
 	if err != nil {
 		/* TODO: scansummary throws a 404 for 0-result scans, once the bug is fixed put this code back. */
-		// return detailedResults, fmt.Errorf("Unable to fetch scan summary for scan %v: %s", scan.ScanID, err)
+		// return detailedResults, nil, fmt.Errorf("Unable to fetch scan summary for scan %v: %s", scan.ScanID, err)
 	} else {
 		totalResultCount = scansummary.TotalCount()
 	}
 
 	results, err := c.sys.GetScanResults(scan.ScanID, totalResultCount)
 	if err != nil {
-		return detailedResults, fmt.Errorf("Unable to fetch scan results for scan %v: %s", scan.ScanID, err)
+		return detailedResults, nil, fmt.Errorf("Unable to fetch scan results for scan %v: %s", scan.ScanID, err)
 	}
 
 	detailedResults, err = c.getDetailedResults(scan, &scanmeta, &results)
 	if err != nil {
-		return detailedResults, fmt.Errorf("Unable to fetch detailed results for scan %v: %s", scan.ScanID, err)
+		return detailedResults, nil, fmt.Errorf("Unable to fetch detailed results for scan %v: %s", scan.ScanID, err)
 	}
 
 	err = c.GetReportJSON(scan)
@@ -691,7 +802,7 @@ func (c *checkmarxOneExecuteScanHelper) ParseResults(scan *checkmarxOne.Scan) (m
 		c.reports = append(c.reports, piperutils.Path{Target: toolRecordFileName})
 	}
 
-	return detailedResults, nil
+	return detailedResults, results, nil
 }
 
 func (c *checkmarxOneExecuteScanHelper) createReportName(workspace, reportFileNameTemplate string) string {
@@ -44,7 +44,12 @@ type checkmarxOneExecuteScanOptions struct {
 	ScanTags                             string   `json:"scanTags,omitempty"`
 	Branch                               string   `json:"branch,omitempty"`
 	PullRequestName                      string   `json:"pullRequestName,omitempty"`
+	PullRequestScan                      bool     `json:"pullRequestScan,omitempty"`
+	PullRequestSeverities                []string `json:"pullRequestSeverities,omitempty"`
+	AssessmentFile                       string   `json:"assessmentFile,omitempty"`
+	AssessmentSync                       string   `json:"assessmentSync,omitempty" validate:"possible-values=none push pull sync"`
 	Repository                           string   `json:"repository,omitempty"`
+	ScmProvider                          string   `json:"scmProvider,omitempty" validate:"possible-values=github gitlab bitbucket azure"`
 	ServerURL                            string   `json:"serverUrl,omitempty"`
 	IamURL                               string   `json:"iamUrl,omitempty"`
 	Tenant                               string   `json:"tenant,omitempty"`
@@ -193,6 +198,62 @@ func (i *checkmarxOneExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *checkmarxOneExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"checkmarxOne": i.step_data.fields.checkmarxOne,
+		},
+		"checkmarxOne_data": {
+			"high_issues":                          i.checkmarxOne_data.fields.high_issues,
+			"high_not_false_postive":               i.checkmarxOne_data.fields.high_not_false_postive,
+			"high_not_exploitable":                 i.checkmarxOne_data.fields.high_not_exploitable,
+			"high_confirmed":                       i.checkmarxOne_data.fields.high_confirmed,
+			"high_urgent":                          i.checkmarxOne_data.fields.high_urgent,
+			"high_proposed_not_exploitable":        i.checkmarxOne_data.fields.high_proposed_not_exploitable,
+			"high_to_verify":                       i.checkmarxOne_data.fields.high_to_verify,
+			"medium_issues":                        i.checkmarxOne_data.fields.medium_issues,
+			"medium_not_false_postive":             i.checkmarxOne_data.fields.medium_not_false_postive,
+			"medium_not_exploitable":               i.checkmarxOne_data.fields.medium_not_exploitable,
+			"medium_confirmed":                     i.checkmarxOne_data.fields.medium_confirmed,
+			"medium_urgent":                        i.checkmarxOne_data.fields.medium_urgent,
+			"medium_proposed_not_exploitable":      i.checkmarxOne_data.fields.medium_proposed_not_exploitable,
+			"medium_to_verify":                     i.checkmarxOne_data.fields.medium_to_verify,
+			"low_issues":                           i.checkmarxOne_data.fields.low_issues,
+			"low_not_false_postive":                i.checkmarxOne_data.fields.low_not_false_postive,
+			"low_not_exploitable":                  i.checkmarxOne_data.fields.low_not_exploitable,
+			"low_confirmed":                        i.checkmarxOne_data.fields.low_confirmed,
+			"low_urgent":                           i.checkmarxOne_data.fields.low_urgent,
+			"low_proposed_not_exploitable":         i.checkmarxOne_data.fields.low_proposed_not_exploitable,
+			"low_to_verify":                        i.checkmarxOne_data.fields.low_to_verify,
+			"information_issues":                   i.checkmarxOne_data.fields.information_issues,
+			"information_not_false_postive":        i.checkmarxOne_data.fields.information_not_false_postive,
+			"information_not_exploitable":          i.checkmarxOne_data.fields.information_not_exploitable,
+			"information_confirmed":                i.checkmarxOne_data.fields.information_confirmed,
+			"information_urgent":                   i.checkmarxOne_data.fields.information_urgent,
+			"information_proposed_not_exploitable": i.checkmarxOne_data.fields.information_proposed_not_exploitable,
+			"information_to_verify":                i.checkmarxOne_data.fields.information_to_verify,
+			"lines_of_code_scanned":                i.checkmarxOne_data.fields.lines_of_code_scanned,
+			"files_scanned":                        i.checkmarxOne_data.fields.files_scanned,
+			"initiator_name":                       i.checkmarxOne_data.fields.initiator_name,
+			"owner":                                i.checkmarxOne_data.fields.owner,
+			"scan_id":                              i.checkmarxOne_data.fields.scan_id,
+			"project_id":                           i.checkmarxOne_data.fields.project_id,
+			"projectName":                          i.checkmarxOne_data.fields.projectName,
+			"group":                                i.checkmarxOne_data.fields.group,
+			"group_full_path_on_report_date":       i.checkmarxOne_data.fields.group_full_path_on_report_date,
+			"scan_start":                           i.checkmarxOne_data.fields.scan_start,
+			"scan_time":                            i.checkmarxOne_data.fields.scan_time,
+			"tool_version":                         i.checkmarxOne_data.fields.tool_version,
+			"scan_type":                            i.checkmarxOne_data.fields.scan_type,
+			"preset":                               i.checkmarxOne_data.fields.preset,
+			"deep_link":                            i.checkmarxOne_data.fields.deep_link,
+			"report_creation_time":                 i.checkmarxOne_data.fields.report_creation_time,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type checkmarxOneExecuteScanReports struct {
 }
 
@@ -319,9 +380,12 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -389,7 +453,12 @@ func addCheckmarxOneExecuteScanFlags(cmd *cobra.Command, stepConfig *checkmarxOn
 	cmd.Flags().StringVar(&stepConfig.ScanTags, "scanTags", os.Getenv("PIPER_scanTags"), "Used to tag a scan with a JSON string, e.g., {\"key\":\"value\", \"keywithoutvalue\":\"\"}")
 	cmd.Flags().StringVar(&stepConfig.Branch, "branch", os.Getenv("PIPER_branch"), "Used to supply the branch scanned in the repository, or a friendly-name set by the user")
 	cmd.Flags().StringVar(&stepConfig.PullRequestName, "pullRequestName", os.Getenv("PIPER_pullRequestName"), "Used to supply the name for the newly created PR project branch when being used in pull request scenarios. This is supplied by the orchestrator.")
+	cmd.Flags().BoolVar(&stepConfig.PullRequestScan, "pullRequestScan", false, "Scans pull requests incrementally on a branch of the pull request and only reports new findings in the lines changed by the pull request. The findings are commented on the pull request via `scmProvider` if `githubToken` is available and the thresholds are replaced by a check for new findings. The pull request is determined via the orchestrator, the history of its target branch needs to be fetched.")
+	cmd.Flags().StringSliceVar(&stepConfig.PullRequestSeverities, "pullRequestSeverities", []string{`CRITICAL`, `HIGH`, `MEDIUM`}, "Severities of the new findings which are reported in `pullRequestScan` mode.")
+	cmd.Flags().StringVar(&stepConfig.AssessmentFile, "assessmentFile", `hs-assessments.yaml`, "Path to the assessment YAML file which records triage decisions of findings as `ignore` entries with `tool: checkmarxOne`. Used by `assessmentSync`.")
+	cmd.Flags().StringVar(&stepConfig.AssessmentSync, "assessmentSync", `none`, "Synchronizes the assessments of `assessmentFile` with the state of the Checkmarx One SAST results before the results are evaluated. `push` applies the assessments of the file, `pull` adds and updates the file with the assessments made in the scanner and `sync` pushes the file and pulls only assessments missing in the file. Pulled assessments need to be committed to the repository.")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Set the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.ScmProvider, "scmProvider", `github`, "SCM hosting the repository, result issues and pull request comments are created there. `githubApiUrl`, `githubToken`, `owner` and `repository` are used for the selected SCM, e.g. `githubApiUrl` is the GitLab API URL, `owner` the Bitbucket project key or the Azure DevOps project.")
 	cmd.Flags().StringVar(&stepConfig.ServerURL, "serverUrl", os.Getenv("PIPER_serverUrl"), "The URL pointing to the root of the checkmarxOne server to be used")
 	cmd.Flags().StringVar(&stepConfig.IamURL, "iamUrl", os.Getenv("PIPER_iamUrl"), "The URL pointing to the access control root of the checkmarxOne IAM server to be used")
 	cmd.Flags().StringVar(&stepConfig.Tenant, "tenant", os.Getenv("PIPER_tenant"), "The name of the checkmarxOne tenant to be used")
@@ -674,6 +743,42 @@ func checkmarxOneExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_pullRequestName"),
 					},
+					{
+						Name:        "pullRequestScan",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "pullRequestSeverities",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`CRITICAL`, `HIGH`, `MEDIUM`},
+					},
+					{
+						Name:        "assessmentFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `hs-assessments.yaml`,
+					},
+					{
+						Name:        "assessmentSync",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `none`,
+					},
 					{
 						Name: "repository",
 						ResourceRef: []config.ResourceReference{
@@ -688,6 +793,15 @@ func checkmarxOneExecuteScanMetadata() config.StepData {
 						Aliases:   []config.Alias{{Name: "githubRepo"}},
 						Default:   os.Getenv("PIPER_repository"),
 					},
+					{
+						Name:        "scmProvider",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `github`,
+					},
 					{
 						Name:        "serverUrl",
 						ResourceRef: []config.ResourceReference{},
@@ -11,6 +11,7 @@ import (
 
 	checkmarxOne "github.com/SAP/jenkins-library/pkg/checkmarxone"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/pkg/errors"
 )
 
@@ -66,6 +67,10 @@ func (sys *checkmarxOneSystemMock) GetResultsPredicates(SimilarityID int64, Proj
 	return []checkmarxOne.ResultsPredicates{}, nil
 }
 
+func (sys *checkmarxOneSystemMock) AddResultsPredicates(predicates []checkmarxOne.ResultsPredicates) error {
+	return nil
+}
+
 func (sys *checkmarxOneSystemMock) GetScanWorkflow(scanID string) ([]checkmarxOne.WorkflowLog, error) {
 	return []checkmarxOne.WorkflowLog{}, nil
 }
@@ -387,3 +392,27 @@ func TestUpdateProjectTags(t *testing.T) {
 		assert.Equal(t, project.Tags, oldTags) // project's tags must be merged
 	})
 }
+
+func TestPullRequestFindings(t *testing.T) {
+	t.Parallel()
+
+	options := checkmarxOneExecuteScanOptions{ServerURL: "https://cx1.example.com", PullRequestSeverities: []string{"CRITICAL", "HIGH"}}
+	cx1sh := checkmarxOneExecuteScanHelper{nil, options, nil, nil, nil, nil, nil, nil, nil}
+	scan := checkmarxOne.Scan{ScanID: "scan1", ProjectID: "project1"}
+	nodes := []checkmarxOne.ScanResultNodes{{FileName: "/src/db.go", Line: 5}, {FileName: "/src/db.go", Line: 12}}
+	results := []checkmarxOne.ScanResult{
+		{Type: "sast", SimilarityID: 42, Status: "NEW", State: "TO_VERIFY", Severity: "HIGH", Data: checkmarxOne.ScanResultData{QueryName: "SQL_Injection", Nodes: nodes}},
+		{Type: "sast", SimilarityID: 43, Status: "RECURRENT", State: "TO_VERIFY", Severity: "HIGH", Data: checkmarxOne.ScanResultData{Nodes: nodes}},
+		{Type: "sast", SimilarityID: 44, Status: "NEW", State: "NOT_EXPLOITABLE", Severity: "HIGH", Data: checkmarxOne.ScanResultData{Nodes: nodes}},
+		{Type: "sast", SimilarityID: 45, Status: "NEW", State: "TO_VERIFY", Severity: "LOW", Data: checkmarxOne.ScanResultData{Nodes: nodes}},
+		{Type: "kics", SimilarityID: 46, Status: "NEW", State: "TO_VERIFY", Severity: "HIGH"},
+	}
+
+	findings := cx1sh.pullRequestFindings(&scan, results)
+
+	url := "https://cx1.example.com/results/project1/scan1/sast"
+	assert.Equal(t, []reporting.CodeFinding{
+		{ID: "42", Category: "SQL_Injection", Severity: "HIGH", File: "/src/db.go", Line: 5, URL: url},
+		{ID: "42", Category: "SQL_Injection", Severity: "HIGH", File: "/src/db.go", Line: 12, URL: url},
+	}, findings)
+}
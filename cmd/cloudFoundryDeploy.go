@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"regexp"
 	"sort"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/SAP/jenkins-library/pkg/cloudfoundry"
 	"github.com/SAP/jenkins-library/pkg/command"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -40,6 +42,8 @@ var _getVarsOptions = cloudfoundry.GetVarsOptions
 var _getVarsFileOptions = cloudfoundry.GetVarsFileOptions
 var _environ = os.Environ
 var fileUtils cfFileUtil = piperutils.Files{}
+var _cfSmokeTestClient piperhttp.Sender = &piperhttp.Client{}
+var _cfSmokeTestRunner = newCfSmokeTestRunner
 
 // for simplify mocking. Maybe we find a more elegant way (mock for CFUtils)
 func cfLogin(c command.ExecRunner, options cloudfoundry.LoginOptions) error {
@@ -231,20 +235,13 @@ func handleCFNativeDeployment(config *cloudFoundryDeployOptions, command command
 	var err error
 
 	// deploy command will be provided by the prepare functions below
-	if config.DeployType == "blue-green" {
-		return fmt.Errorf("Blue-green deployment type is deprecated for cf native builds." +
-			"Instead set parameter `cfNativeDeployParameters: '--strategy rolling'`. " +
-			"Please refer to the Cloud Foundry documentation for further information: " +
-			"https://docs.cloudfoundry.org/devguide/deploy-apps/rolling-deploy.html." +
-			"Or alternatively, switch to mta build tool. Please refer to mta build tool" +
-			"documentation for further information: https://sap.github.io/cloud-mta-build-tool/configuration/.")
-	} else if config.DeployType == "standard" {
+	if config.DeployType == "blue-green" || config.DeployType == "standard" {
 		deployCommand, deployOptions, err = prepareCfPushCfNativeDeploy(config)
 		if err != nil {
 			return errors.Wrapf(err, "Cannot prepare cf push native deployment. DeployType '%s'", config.DeployType)
 		}
 	} else {
-		return fmt.Errorf("Invalid deploy type received: '%s'. Supported value: standard", config.DeployType)
+		return fmt.Errorf("Invalid deploy type received: '%s'. Supported values: standard, blue-green", config.DeployType)
 	}
 
 	appName, err := getAppName(config)
@@ -276,10 +273,23 @@ func handleCFNativeDeployment(config *cloudFoundryDeployOptions, command command
 
 	log.Entry().Infof("DeployConfig: %v", myDeployConfig)
 
+	if config.DeployType == "blue-green" {
+		routes, err := getAppRoutes(manifestFile)
+		if err != nil {
+			return err
+		}
+		myDeployConfig.AppName = appName
+		return deployCfNativeBlueGreen(myDeployConfig, routes, config, additionalEnvironment, command)
+	}
+
 	return deployCfNative(myDeployConfig, config, additionalEnvironment, command)
 }
 
 func deployCfNative(deployConfig deployConfig, config *cloudFoundryDeployOptions, additionalEnvironment []string, cmd command.ExecRunner) error {
+	return cfDeploy(config, getCfNativeDeployStatement(deployConfig, config), additionalEnvironment, cmd)
+}
+
+func getCfNativeDeployStatement(deployConfig deployConfig, config *cloudFoundryDeployOptions) []string {
 
 	deployStatement := []string{
 		deployConfig.DeployCommand,
@@ -310,7 +320,205 @@ func deployCfNative(deployConfig deployConfig, config *cloudFoundryDeployOptions
 		deployStatement = append(deployStatement, strings.Fields(config.CfNativeDeployParameters)...)
 	}
 
-	return cfDeploy(config, deployStatement, additionalEnvironment, cmd)
+	return deployStatement
+}
+
+// deployCfNativeBlueGreen pushes the application with a temporary name and route, runs the smoke test against it
+// and switches the productive routes to the new application afterwards. The old application is stopped and deleted
+// (or kept stopped in case of keepOldInstance). In case the smoke test fails the new application is removed again.
+func deployCfNativeBlueGreen(deployConfig deployConfig, routes []cloudfoundry.Route, config *cloudFoundryDeployOptions, additionalEnvironment []string, cmd command.ExecRunner) error {
+
+	appName := deployConfig.AppName
+	newAppName := appName + "-new"
+	oldAppName := appName + "-old"
+
+	deployConfig.AppName = newAppName
+	pushStatement := append(getCfNativeDeployStatement(deployConfig, config), "--no-route")
+
+	cf := &cloudfoundry.CFUtils{Exec: cmd}
+
+	// productive routes already mapped to the new app, these must not be deleted on rollback
+	mappedRoutes := []cloudfoundry.Route{}
+
+	rollback := func(cause error) error {
+		log.Entry().WithError(cause).Warningf("Rolling back deployment of app '%s'", newAppName)
+		for _, route := range mappedRoutes {
+			if err := cf.UnmapRoute(newAppName, route); err != nil {
+				log.Entry().WithError(err).Errorf("Rollback failed, please delete app '%s' manually", newAppName)
+				return cause
+			}
+		}
+		if err := cf.DeleteApp(newAppName, true); err != nil {
+			log.Entry().WithError(err).Errorf("Rollback failed, please delete app '%s' manually", newAppName)
+		}
+		return cause
+	}
+
+	return cfDeployWith(config, additionalEnvironment, cmd, func() error {
+
+		// the domain of a route can only be told apart from the host by the domains of the org
+		routes, err := cf.ResolveRoutes(routes)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+		tempRoute := cloudfoundry.Route{Host: newAppName, Domain: routes[0].Domain}
+		if len(routes[0].Host) > 0 {
+			tempRoute.Host = routes[0].Host + "-new"
+		}
+
+		// leftover of a previously failed deployment
+		if cf.AppExists(newAppName) {
+			if err := cf.DeleteApp(newAppName, true); err != nil {
+				return err
+			}
+		}
+
+		if err := cmd.RunExecutable("cf", pushStatement...); err != nil {
+			log.Entry().WithError(err).Errorf("Command '%s' failed.", pushStatement)
+			return rollback(err)
+		}
+
+		if err := cf.MapRoute(newAppName, tempRoute); err != nil {
+			return rollback(err)
+		}
+
+		if err := runCfSmokeTest(config, tempRoute); err != nil {
+			log.SetErrorCategory(log.ErrorTest)
+			return rollback(errors.Wrapf(err, "Smoke test of app '%s' failed, the deployment has been rolled back", newAppName))
+		}
+
+		for _, route := range routes {
+			if err := cf.MapRoute(newAppName, route); err != nil {
+				return rollback(err)
+			}
+			mappedRoutes = append(mappedRoutes, route)
+		}
+
+		if err := cf.UnmapRoute(newAppName, tempRoute); err != nil {
+			return err
+		}
+		if err := cf.DeleteRoute(tempRoute); err != nil {
+			return err
+		}
+
+		if cf.AppExists(appName) {
+			if err := retireCfApp(cf, appName, oldAppName, routes, config.KeepOldInstance); err != nil {
+				return err
+			}
+		} else {
+			log.Entry().Infof("App '%s' does not exist yet, nothing to switch from", appName)
+		}
+
+		return cf.RenameApp(newAppName, appName)
+	})
+}
+
+// retireCfApp removes the routes from the old application and deletes it or keeps it stopped under a different name
+func retireCfApp(cf *cloudfoundry.CFUtils, appName, oldAppName string, routes []cloudfoundry.Route, keepOldInstance bool) error {
+
+	if !keepOldInstance {
+		return cf.DeleteApp(appName, false)
+	}
+
+	for _, route := range routes {
+		if err := cf.UnmapRoute(appName, route); err != nil {
+			return err
+		}
+	}
+	if err := cf.StopApp(appName); err != nil {
+		return err
+	}
+	// an instance kept from the previous deployment is replaced
+	if cf.AppExists(oldAppName) {
+		if err := cf.DeleteApp(oldAppName, false); err != nil {
+			return err
+		}
+	}
+	return cf.RenameApp(appName, oldAppName)
+}
+
+// runCfSmokeTest checks the application available at the route either by calling the smoke test url
+// or by running the smoke test script with the route as first argument.
+func runCfSmokeTest(config *cloudFoundryDeployOptions, route cloudfoundry.Route) error {
+
+	if len(config.SmokeTestURL) == 0 && len(config.SmokeTestScript) > 0 {
+		exists, err := fileUtils.FileExists(config.SmokeTestScript)
+		if err != nil {
+			return errors.Wrapf(err, "Cannot check if smoke test script '%s' exists", config.SmokeTestScript)
+		}
+		if exists {
+			log.Entry().Infof("Running smoke test script '%s' for route '%s'", config.SmokeTestScript, route)
+			if err := fileUtils.Chmod(config.SmokeTestScript, 0755); err != nil {
+				return errors.Wrapf(err, "Cannot make smoke test script '%s' executable", config.SmokeTestScript)
+			}
+			script := config.SmokeTestScript
+			if !strings.Contains(script, "/") {
+				script = "./" + script
+			}
+			// the script gets a runner of its own so that the expected status code does not leak into later cf calls
+			smokeTest := _cfSmokeTestRunner()
+			smokeTest.AppendEnv([]string{fmt.Sprintf("STATUS_CODE=%d", config.SmokeTestStatusCode)})
+			return smokeTest.RunExecutable(script, route.String())
+		}
+		log.Entry().Warningf("Smoke test script '%s' not found, checking the status code of the app instead", config.SmokeTestScript)
+	}
+
+	url := "https://" + route.String()
+	switch {
+	case strings.HasPrefix(config.SmokeTestURL, "http://") || strings.HasPrefix(config.SmokeTestURL, "https://"):
+		url = config.SmokeTestURL
+	case len(config.SmokeTestURL) > 0:
+		url += "/" + strings.TrimPrefix(config.SmokeTestURL, "/")
+	}
+
+	log.Entry().Infof("Running smoke test against '%s'", url)
+	response, err := _cfSmokeTestClient.SendRequest(http.MethodGet, url, nil, nil, nil)
+	if response != nil && response.Body != nil {
+		defer response.Body.Close()
+	}
+	if response == nil {
+		if err == nil {
+			err = fmt.Errorf("no response received")
+		}
+		return errors.Wrapf(err, "Smoke test request to '%s' failed", url)
+	}
+	if response.StatusCode != config.SmokeTestStatusCode {
+		return fmt.Errorf("Smoke test request to '%s' returned status code %d, expected %d", url, response.StatusCode, config.SmokeTestStatusCode)
+	}
+	return nil
+}
+
+func newCfSmokeTestRunner() command.ExecRunner {
+	c := &command.Command{}
+	c.Stdout(log.Writer())
+	c.Stderr(log.Writer())
+	return c
+}
+
+// getAppRoutes returns the routes of the first application declared in the manifest
+func getAppRoutes(manifestFile string) ([]cloudfoundry.Route, error) {
+
+	fileExists, err := fileUtils.FileExists(manifestFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot check if file '%s' exists", manifestFile)
+	}
+	if !fileExists {
+		return nil, fmt.Errorf("Manifest file '%s' not found. Cannot retrieve routes for blue-green deployment", manifestFile)
+	}
+	manifest, err := _getManifest(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := cloudfoundry.GetAppRoutes(manifest, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, fmt.Errorf("No routes declared in manifest '%s'. Blue-green deployment requires the routes of the app", manifestFile)
+	}
+	return routes, nil
 }
 
 func getManifest(name string) (cloudfoundry.Manifest, error) {
@@ -572,6 +780,22 @@ func cfDeploy(
 	additionalEnvironment []string,
 	command command.ExecRunner) error {
 
+	return cfDeployWith(config, additionalEnvironment, command, func() error {
+		err := command.RunExecutable("cf", cfDeployParams...)
+		if err != nil {
+			log.Entry().WithError(err).Errorf("Command '%s' failed.", cfDeployParams)
+		}
+		return err
+	})
+}
+
+// cfDeployWith performs the deployment provided as function within a cf login session
+func cfDeployWith(
+	config *cloudFoundryDeployOptions,
+	additionalEnvironment []string,
+	command command.ExecRunner,
+	deploy func() error) error {
+
 	const cfLogFile = "cf.log"
 	var err error
 	var loginPerformed bool
@@ -613,10 +837,7 @@ func cfDeploy(
 	}
 
 	if err == nil {
-		err = command.RunExecutable("cf", cfDeployParams...)
-		if err != nil {
-			log.Entry().WithError(err).Errorf("Command '%s' failed.", cfDeployParams)
-		}
+		err = deploy()
 	}
 
 	if loginPerformed {
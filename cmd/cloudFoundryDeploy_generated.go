@@ -43,6 +43,9 @@ type cloudFoundryDeployOptions struct {
 	MtaPath                  string                 `json:"mtaPath,omitempty"`
 	Org                      string                 `json:"org,omitempty"`
 	Password                 string                 `json:"password,omitempty"`
+	SmokeTestScript          string                 `json:"smokeTestScript,omitempty"`
+	SmokeTestStatusCode      int                    `json:"smokeTestStatusCode,omitempty"`
+	SmokeTestURL             string                 `json:"smokeTestUrl,omitempty"`
 	Space                    string                 `json:"space,omitempty"`
 	Username                 string                 `json:"username,omitempty"`
 }
@@ -98,6 +101,28 @@ func (i *cloudFoundryDeployInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *cloudFoundryDeployInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"deployment_data": {
+			"artifactUrl": i.deployment_data.fields.artifactURL,
+			"deployTime":  i.deployment_data.fields.deployTime,
+			"commitHash":  i.deployment_data.fields.commitHash,
+			"jobTrigger":  i.deployment_data.fields.jobTrigger,
+		},
+	}
+	tags := map[string]map[string]string{
+		"deployment_data": {
+			"artifactVersion": i.deployment_data.tags.artifactVersion,
+			"deployUser":      i.deployment_data.tags.deployUser,
+			"deployResult":    i.deployment_data.tags.deployResult,
+			"cfApiEndpoint":   i.deployment_data.tags.cfAPIEndpoint,
+			"cfOrg":           i.deployment_data.tags.cfOrg,
+			"cfSpace":         i.deployment_data.tags.cfSpace,
+		},
+	}
+	return fields, tags
+}
+
 // CloudFoundryDeployCommand Deploys an application to Cloud Foundry
 func CloudFoundryDeployCommand() *cobra.Command {
 	const STEP_NAME = "cloudFoundryDeploy"
@@ -172,9 +197,12 @@ func CloudFoundryDeployCommand() *cobra.Command {
 			handler := func() {
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -231,7 +259,7 @@ func addCloudFoundryDeployFlags(cmd *cobra.Command, stepConfig *cloudFoundryDepl
 	cmd.Flags().StringVar(&stepConfig.DeployDockerImage, "deployDockerImage", os.Getenv("PIPER_deployDockerImage"), "Docker image deployments are supported (via manifest file in general)[https://docs.cloudfoundry.org/devguide/deploy-apps/manifest-attributes.html#docker]. If no manifest is used, this parameter defines the image to be deployed. The specified name of the image is passed to the `--docker-image` parameter of the cf CLI and must adhere it's naming pattern (e.g. REPO/IMAGE:TAG). See (cf CLI documentation)[https://docs.cloudfoundry.org/devguide/deploy-apps/push-docker.html] for details. Note: The used Docker registry must be visible for the targeted Cloud Foundry instance.")
 	cmd.Flags().StringVar(&stepConfig.DeployTool, "deployTool", os.Getenv("PIPER_deployTool"), "Defines the tool which should be used for deployment.")
 	cmd.Flags().StringVar(&stepConfig.BuildTool, "buildTool", os.Getenv("PIPER_buildTool"), "Defines the tool which is used for building the artifact. If provided, `deployTool` is automatically derived from it. For MTA projects, `deployTool` defaults to `mtaDeployPlugin`. For other projects `cf_native` will be used.")
-	cmd.Flags().StringVar(&stepConfig.DeployType, "deployType", `standard`, "Defines the type of deployment, for example, `standard` deployment which results in a system downtime, `blue-green` deployment which results in zero downtime for mta deploy tool. - For mta build tool, possible values are `standard`, `blue-green` or `bg-deploy`. - For cf native build tools, possible value is `standard`. To eliminate system downtime, an alternative is to pass '--strategy rolling' to the parameter `cfNativeDeployParameters`.")
+	cmd.Flags().StringVar(&stepConfig.DeployType, "deployType", `standard`, "Defines the type of deployment, for example, `standard` deployment which results in a system downtime, `blue-green` deployment which results in zero downtime. - For mta build tool, possible values are `standard`, `blue-green` or `bg-deploy`. - For cf native build tools, possible values are `standard` and `blue-green`. A `blue-green` deployment pushes the app with a temporary name and route, runs the smoke test (see `smokeTestUrl` and `smokeTestScript`), switches the routes declared in the manifest to the new app and removes the old app. In case the smoke test fails the new app is removed again. Alternatively '--strategy rolling' can be passed to the parameter `cfNativeDeployParameters`.")
 	cmd.Flags().StringVar(&stepConfig.DockerPassword, "dockerPassword", os.Getenv("PIPER_dockerPassword"), "If the specified image in `deployDockerImage` is contained in a Docker registry, which requires authorization, this defines the password to be used.")
 	cmd.Flags().StringVar(&stepConfig.DockerUsername, "dockerUsername", os.Getenv("PIPER_dockerUsername"), "If the specified image in `deployDockerImage` is contained in a Docker registry, which requires authorization, this defines the username to be used.")
 	cmd.Flags().BoolVar(&stepConfig.KeepOldInstance, "keepOldInstance", false, "If this option is set to true the old instance will remain stopped in the Cloud Foundry space.\"")
@@ -245,6 +273,9 @@ func addCloudFoundryDeployFlags(cmd *cobra.Command, stepConfig *cloudFoundryDepl
 	cmd.Flags().StringVar(&stepConfig.MtaPath, "mtaPath", os.Getenv("PIPER_mtaPath"), "Defines the path to *.mtar for deployment with the mtaDeployPlugin")
 	cmd.Flags().StringVar(&stepConfig.Org, "org", os.Getenv("PIPER_org"), "Cloud Foundry target organization.")
 	cmd.Flags().StringVar(&stepConfig.Password, "password", os.Getenv("PIPER_password"), "Password")
+	cmd.Flags().StringVar(&stepConfig.SmokeTestScript, "smokeTestScript", `blueGreenCheckScript.sh`, "Only for blue-green deployments with cf native. Script which checks the new app before the routes are switched. The script receives the temporary route of the app (`host.domain`) as first argument and the expected status code as environment variable `STATUS_CODE`. A non-zero exit code fails the smoke test. If the script does not exist the status code of the app is checked via http.")
+	cmd.Flags().IntVar(&stepConfig.SmokeTestStatusCode, "smokeTestStatusCode", 200, "Only for blue-green deployments with cf native. Expected status code of the smoke test.")
+	cmd.Flags().StringVar(&stepConfig.SmokeTestURL, "smokeTestUrl", os.Getenv("PIPER_smokeTestUrl"), "Only for blue-green deployments with cf native. Path (e.g. `/health`) on the temporary route of the new app or absolute url which is called as smoke test. Takes precedence over `smokeTestScript`.")
 	cmd.Flags().StringVar(&stepConfig.Space, "space", os.Getenv("PIPER_space"), "Cloud Foundry target space")
 	cmd.Flags().StringVar(&stepConfig.Username, "username", os.Getenv("PIPER_username"), "User name used for deployment")
 
@@ -529,6 +560,33 @@ func cloudFoundryDeployMetadata() config.StepData {
 						Aliases:   []config.Alias{},
 						Default:   os.Getenv("PIPER_password"),
 					},
+					{
+						Name:        "smokeTestScript",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS", "GENERAL"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `blueGreenCheckScript.sh`,
+					},
+					{
+						Name:        "smokeTestStatusCode",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS", "GENERAL"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     200,
+					},
+					{
+						Name:        "smokeTestUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS", "GENERAL"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_smokeTestUrl"),
+					},
 					{
 						Name:        "space",
 						ResourceRef: []config.ResourceReference{},
@@ -5,12 +5,16 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/cloudfoundry"
 	"github.com/SAP/jenkins-library/pkg/command"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/yaml"
@@ -55,6 +59,18 @@ func (m manifestMock) WriteManifest() error {
 	return nil
 }
 
+type cfSmokeTestClientMock struct {
+	statusCode int
+	urls       []string
+}
+
+func (c *cfSmokeTestClientMock) SendRequest(method, url string, body io.Reader, header http.Header, cookies []*http.Cookie) (*http.Response, error) {
+	c.urls = append(c.urls, url)
+	return &http.Response{StatusCode: c.statusCode, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func (c *cfSmokeTestClientMock) SetOptions(options piperhttp.ClientOptions) {}
+
 func TestCfDeployment(t *testing.T) {
 
 	defer func() {
@@ -412,36 +428,227 @@ func TestCfDeployment(t *testing.T) {
 		}
 	})
 
-	t.Run("cf native deploy fail when deployType is blue-green", func(t *testing.T) {
+	prepareBlueGreenManifestMocking := func(routes ...string) func() {
+
+		filesMock.AddFile("manifest.yml", []byte("file content does not matter"))
+
+		routeEntries := []interface{}{}
+		for _, route := range routes {
+			routeEntries = append(routeEntries, map[string]interface{}{"route": route})
+		}
+		app := map[string]interface{}{"name": "myApp"}
+		if len(routeEntries) > 0 {
+			app["routes"] = routeEntries
+		}
+
+		_getManifest = func(name string) (cloudfoundry.Manifest, error) {
+			return manifestMock{
+				manifestFileName: "manifest.yml",
+				apps:             []map[string]interface{}{app},
+			}, nil
+		}
+
+		smokeTestClient := &cfSmokeTestClientMock{statusCode: 200}
+		_cfSmokeTestClient = smokeTestClient
+
+		return func() {
+			_ = filesMock.FileRemove("manifest.yml")
+			_getManifest = getManifest
+			_cfSmokeTestClient = &piperhttp.Client{}
+		}
+	}
+
+	domains := map[string]string{"cf domains": `Getting domains in org myOrg as me...
+
+name                  availability   internal   protocols
+cfapps.example.com    shared                    http
+apps.eu.example.com   private                   http
+example.com           private                   http
+`}
+
+	blueGreenPushCalls := []mock.ExecCall{
+		{Exec: "cf", Params: []string{"version"}},
+		{Exec: "cf", Params: []string{"plugins"}},
+		{Exec: "cf", Params: []string{"domains"}},
+		{Exec: "cf", Params: []string{"app", "myApp-new", "--guid"}},
+		{Exec: "cf", Params: []string{"push", "myApp-new", "-f", "manifest.yml", "--no-route"}},
+		{Exec: "cf", Params: []string{"map-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp-new"}},
+	}
+
+	t.Run("cf native blue-green deployment", func(t *testing.T) {
 
 		defer cleanup()
+		defer prepareBlueGreenManifestMocking("myapp.cfapps.example.com", "api.apps.eu.example.com/v1")()
 
 		config.DeployTool = "cf_native"
 		config.DeployType = "blue-green"
-		config.Manifest = ""
-		config.AppName = ""
+		config.SmokeTestStatusCode = 200
+		config.SmokeTestURL = "/health"
 
-		// app name does not need to be set if it can be found in the manifest.yml
-		// manifest name does not need to be set- the default manifest.yml will be used if not set
-		defer prepareDefaultManifestMocking("manifest.yml", []string{"newAppName"})()
+		s := mock.ExecMockRunner{
+			StdoutReturn:        domains,
+			ShouldFailOnCommand: map[string]error{"cf app myApp-new --guid": fmt.Errorf("app not found")},
+		}
 
-		s := mock.ExecMockRunner{}
+		err := runCloudFoundryDeploy(&config, nil, nil, &s)
+
+		if assert.NoError(t, err) {
+			withLoginAndLogout(t, func(t *testing.T) {
+				assert.Equal(t, append(append([]mock.ExecCall{}, blueGreenPushCalls...), []mock.ExecCall{
+					{Exec: "cf", Params: []string{"map-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp"}},
+					{Exec: "cf", Params: []string{"map-route", "myApp-new", "apps.eu.example.com", "--hostname", "api", "--path", "/v1"}},
+					{Exec: "cf", Params: []string{"unmap-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp-new"}},
+					{Exec: "cf", Params: []string{"delete-route", "cfapps.example.com", "--hostname", "myapp-new", "-f"}},
+					{Exec: "cf", Params: []string{"app", "myApp", "--guid"}},
+					{Exec: "cf", Params: []string{"delete", "myApp", "-f"}},
+					{Exec: "cf", Params: []string{"rename", "myApp-new", "myApp"}},
+				}...), s.Calls)
+			})
+			assert.Equal(t, []string{"https://myapp-new.cfapps.example.com/health"}, _cfSmokeTestClient.(*cfSmokeTestClientMock).urls)
+		}
+	})
+
+	t.Run("cf native blue-green deployment keeping the old instance", func(t *testing.T) {
+
+		defer cleanup()
+		defer prepareBlueGreenManifestMocking("myapp.cfapps.example.com")()
+
+		config.DeployTool = "cf_native"
+		config.DeployType = "blue-green"
+		config.SmokeTestStatusCode = 200
+		config.KeepOldInstance = true
+
+		s := mock.ExecMockRunner{
+			StdoutReturn:        domains,
+			ShouldFailOnCommand: map[string]error{"cf app myApp-new --guid": fmt.Errorf("app not found")},
+		}
 
 		err := runCloudFoundryDeploy(&config, nil, nil, &s)
 
-		if assert.EqualError(t, err, "Blue-green deployment type is deprecated for cf native builds."+
-			"Instead set parameter `cfNativeDeployParameters: '--strategy rolling'`. "+
-			"Please refer to the Cloud Foundry documentation for further information: "+
-			"https://docs.cloudfoundry.org/devguide/deploy-apps/rolling-deploy.html."+
-			"Or alternatively, switch to mta build tool. Please refer to mta build tool"+
-			"documentation for further information: https://sap.github.io/cloud-mta-build-tool/configuration/.") {
+		if assert.NoError(t, err) {
+			withLoginAndLogout(t, func(t *testing.T) {
+				assert.Equal(t, append(append([]mock.ExecCall{}, blueGreenPushCalls...), []mock.ExecCall{
+					{Exec: "cf", Params: []string{"map-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp"}},
+					{Exec: "cf", Params: []string{"unmap-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp-new"}},
+					{Exec: "cf", Params: []string{"delete-route", "cfapps.example.com", "--hostname", "myapp-new", "-f"}},
+					{Exec: "cf", Params: []string{"app", "myApp", "--guid"}},
+					{Exec: "cf", Params: []string{"unmap-route", "myApp", "cfapps.example.com", "--hostname", "myapp"}},
+					{Exec: "cf", Params: []string{"stop", "myApp"}},
+					{Exec: "cf", Params: []string{"app", "myApp-old", "--guid"}},
+					{Exec: "cf", Params: []string{"delete", "myApp-old", "-f"}},
+					{Exec: "cf", Params: []string{"rename", "myApp", "myApp-old"}},
+					{Exec: "cf", Params: []string{"rename", "myApp-new", "myApp"}},
+				}...), s.Calls)
+			})
+			assert.Equal(t, []string{"https://myapp-new.cfapps.example.com"}, _cfSmokeTestClient.(*cfSmokeTestClientMock).urls)
+		}
+	})
 
-			t.Run("check shell calls", func(t *testing.T) {
-				noopCfAPICalls(t, s)
+	t.Run("cf native blue-green first deployment with smoke test script", func(t *testing.T) {
+
+		defer cleanup()
+		defer prepareBlueGreenManifestMocking("myapp.cfapps.example.com")()
+		filesMock.AddFile("blueGreenCheckScript.sh", []byte("#!/bin/bash"))
+		defer func() { _ = filesMock.FileRemove("blueGreenCheckScript.sh") }()
+
+		config.DeployTool = "cf_native"
+		config.DeployType = "blue-green"
+		config.SmokeTestScript = "blueGreenCheckScript.sh"
+		config.SmokeTestStatusCode = 200
+
+		s := mock.ExecMockRunner{
+			StdoutReturn: domains,
+			ShouldFailOnCommand: map[string]error{
+				"cf app myApp-new --guid": fmt.Errorf("app not found"),
+				"cf app myApp --guid":     fmt.Errorf("app not found"),
+			},
+		}
+		smokeTest := mock.ExecMockRunner{}
+		_cfSmokeTestRunner = func() command.ExecRunner { return &smokeTest }
+		defer func() { _cfSmokeTestRunner = newCfSmokeTestRunner }()
+
+		err := runCloudFoundryDeploy(&config, nil, nil, &s)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []mock.ExecCall{{Exec: "./blueGreenCheckScript.sh", Params: []string{"myapp-new.cfapps.example.com"}}}, smokeTest.Calls)
+			assert.Contains(t, smokeTest.Env, "STATUS_CODE=200")
+			assert.NotContains(t, s.Env, "STATUS_CODE=200")
+			withLoginAndLogout(t, func(t *testing.T) {
+				assert.Equal(t, append(append([]mock.ExecCall{}, blueGreenPushCalls...), []mock.ExecCall{
+					{Exec: "cf", Params: []string{"map-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp"}},
+					{Exec: "cf", Params: []string{"unmap-route", "myApp-new", "cfapps.example.com", "--hostname", "myapp-new"}},
+					{Exec: "cf", Params: []string{"delete-route", "cfapps.example.com", "--hostname", "myapp-new", "-f"}},
+					{Exec: "cf", Params: []string{"app", "myApp", "--guid"}},
+					{Exec: "cf", Params: []string{"rename", "myApp-new", "myApp"}},
+				}...), s.Calls)
+			})
+			assert.Empty(t, _cfSmokeTestClient.(*cfSmokeTestClientMock).urls)
+		}
+	})
+
+	t.Run("cf native blue-green deployment rolls back on failing smoke test", func(t *testing.T) {
+
+		defer cleanup()
+		defer prepareBlueGreenManifestMocking("myapp.cfapps.example.com")()
+		_cfSmokeTestClient.(*cfSmokeTestClientMock).statusCode = 503
+
+		config.DeployTool = "cf_native"
+		config.DeployType = "blue-green"
+		config.SmokeTestStatusCode = 200
+
+		s := mock.ExecMockRunner{
+			StdoutReturn:        domains,
+			ShouldFailOnCommand: map[string]error{"cf app myApp-new --guid": fmt.Errorf("app not found")},
+		}
+
+		err := runCloudFoundryDeploy(&config, nil, nil, &s)
+
+		if assert.EqualError(t, err, "Smoke test of app 'myApp-new' failed, the deployment has been rolled back: "+
+			"Smoke test request to 'https://myapp-new.cfapps.example.com' returned status code 503, expected 200") {
+			withLoginAndLogout(t, func(t *testing.T) {
+				assert.Equal(t, append(append([]mock.ExecCall{}, blueGreenPushCalls...), []mock.ExecCall{
+					{Exec: "cf", Params: []string{"delete", "myApp-new", "-r", "-f"}},
+				}...), s.Calls)
+			})
+		}
+	})
+
+	t.Run("cf native blue-green deployment with route of unknown domain", func(t *testing.T) {
+
+		defer cleanup()
+		defer prepareBlueGreenManifestMocking("myapp.cfapps.other.com")()
+
+		config.DeployTool = "cf_native"
+		config.DeployType = "blue-green"
+
+		s := mock.ExecMockRunner{StdoutReturn: domains}
+
+		err := runCloudFoundryDeploy(&config, nil, nil, &s)
+
+		if assert.EqualError(t, err, "No domain of the org matches route 'myapp.cfapps.other.com'") {
+			withLoginAndLogout(t, func(t *testing.T) {
+				assert.Equal(t, blueGreenPushCalls[:3], s.Calls)
 			})
 		}
 	})
 
+	t.Run("cf native blue-green deployment without routes", func(t *testing.T) {
+
+		defer cleanup()
+		defer prepareBlueGreenManifestMocking()()
+
+		config.DeployTool = "cf_native"
+		config.DeployType = "blue-green"
+
+		s := mock.ExecMockRunner{}
+
+		err := runCloudFoundryDeploy(&config, nil, nil, &s)
+
+		if assert.EqualError(t, err, "No routes declared in manifest 'manifest.yml'. Blue-green deployment requires the routes of the app") {
+			noopCfAPICalls(t, s)
+		}
+	})
+
 	t.Run("cf native deploy fail when unknown deployType is set", func(t *testing.T) {
 
 		defer cleanup()
@@ -459,7 +666,7 @@ func TestCfDeployment(t *testing.T) {
 
 		err := runCloudFoundryDeploy(&config, nil, nil, &s)
 
-		if assert.EqualError(t, err, "Invalid deploy type received: 'blue'. Supported value: standard") {
+		if assert.EqualError(t, err, "Invalid deploy type received: 'blue'. Supported values: standard, blue-green") {
 
 			t.Run("check shell calls", func(t *testing.T) {
 				noopCfAPICalls(t, s)
@@ -19,6 +19,7 @@ import (
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/syft"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/imdario/mergo"
@@ -344,6 +345,24 @@ func callCnbBuild(config *cnbBuildOptions, telemetryData *telemetry.CustomData,
 
 	buildSummary.Print()
 
+	baseImages := []docker.BaseImage{}
+	for _, baseImage := range commonPipelineEnvironment.container.baseImages {
+		baseImages = append(baseImages, docker.ParseBaseImage(baseImage))
+	}
+	toolRecordFileName, err := docker.PersistBaseImageToolrecord(utils, "./", stepName, commonPipelineEnvironment.container.registryURL, baseImages)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to create toolrecord file")
+	} else if len(toolRecordFileName) > 0 {
+		piperutils.PersistReportsAndLinks(stepName, "", utils, []piperutils.Path{{Target: toolRecordFileName}}, nil)
+	}
+
+	if config.SignImages {
+		err = signCnbImages(config, commonPipelineEnvironment, utils)
+		if err != nil {
+			return err
+		}
+	}
+
 	if config.CreateBOM {
 		log.Entry().Debugf("Creating sbom for %d images\n", len(commonPipelineEnvironment.container.imageNameTags))
 		syftScanner, err := syft.CreateSyftScanner(config.SyftDownloadURL, utils, httpClient)
@@ -617,6 +636,13 @@ func runCnbBuild(config *cnbBuildOptions, telemetry *buildpacks.Telemetry, image
 	commonPipelineEnvironment.container.imageDigests = append(commonPipelineEnvironment.container.imageDigests, digest)
 	imageSummary.ImageRef = fmt.Sprintf("%s@%s", containerImage, digest)
 
+	runImage, err := cnbutils.RunImageFromAnalyzed(utils)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to determine the run image")
+	} else {
+		commonPipelineEnvironment.container.baseImages = append(commonPipelineEnvironment.container.baseImages, runImage.String())
+	}
+
 	if len(config.PreserveFiles) > 0 {
 		if pathType != buildpacks.PathEnumArchive {
 			err = cnbutils.CopyProject(target, source, ignore.CompileIgnoreLines(config.PreserveFiles...), nil, utils, true)
@@ -632,6 +658,22 @@ func runCnbBuild(config *cnbBuildOptions, telemetry *buildpacks.Telemetry, image
 	return nil
 }
 
+// signCnbImages signs the pushed images using the registry credentials of the build
+func signCnbImages(config *cnbBuildOptions, commonPipelineEnvironment *cnbBuildCommonPipelineEnvironment, utils cnbutils.BuildUtils) error {
+	images, err := signing.ImageReferences(commonPipelineEnvironment.container.registryURL, commonPipelineEnvironment.container.imageNameTags, commonPipelineEnvironment.container.imageDigests)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign images")
+	}
+	if err := os.Setenv("DOCKER_CONFIG", filepath.Dir(config.DockerConfigJSON)); err != nil {
+		return errors.Wrap(err, "failed to set DOCKER_CONFIG")
+	}
+	signingConfig := signing.Config{SigningKey: config.SigningKey, SigningKeyPassword: config.SigningKeyPassword, SignatureMode: config.SignatureMode}
+	if err := signing.SignImages(images, signingConfig, utils); err != nil {
+		return errors.Wrap(err, "failed to sign images")
+	}
+	return nil
+}
+
 func expandEnvVars(envVars map[string]any) map[string]any {
 	expandedEnvVars := map[string]any{}
 	for key, value := range envVars {
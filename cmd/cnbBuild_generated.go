@@ -46,6 +46,10 @@ type cnbBuildOptions struct {
 	SyftDownloadURL           string                   `json:"syftDownloadUrl,omitempty"`
 	RunImage                  string                   `json:"runImage,omitempty"`
 	DefaultProcess            string                   `json:"defaultProcess,omitempty"`
+	SignImages                bool                     `json:"signImages,omitempty"`
+	SigningKey                string                   `json:"signingKey,omitempty"`
+	SigningKeyPassword        string                   `json:"signingKeyPassword,omitempty"`
+	SignatureMode             string                   `json:"signatureMode,omitempty" validate:"possible-values=tag referrers"`
 }
 
 type cnbBuildCommonPipelineEnvironment struct {
@@ -56,6 +60,7 @@ type cnbBuildCommonPipelineEnvironment struct {
 		imageNames    []string
 		imageNameTags []string
 		imageDigests  []string
+		baseImages    []string
 	}
 	custom struct {
 		buildSettingsInfo string
@@ -74,6 +79,7 @@ func (p *cnbBuildCommonPipelineEnvironment) persist(path, resourceName string) {
 		{category: "container", name: "imageNames", value: p.container.imageNames},
 		{category: "container", name: "imageNameTags", value: p.container.imageNameTags},
 		{category: "container", name: "imageDigests", value: p.container.imageDigests},
+		{category: "container", name: "baseImages", value: p.container.baseImages},
 		{category: "custom", name: "buildSettingsInfo", value: p.custom.buildSettingsInfo},
 	}
 
@@ -101,6 +107,7 @@ func (p *cnbBuildReports) persist(stepConfig cnbBuildOptions, gcpJsonKeyFilePath
 	log.Entry().Info("Uploading reports to Google Cloud Storage...")
 	content := []gcs.ReportOutputParam{
 		{FilePattern: "**/bom-*.xml", ParamRef: "", StepResultType: "sbom"},
+		{FilePattern: "**/toolrun_cnbBuild_*.json", ParamRef: "", StepResultType: "container-build"},
 	}
 	envVars := []gcs.EnvVar{
 		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
@@ -162,6 +169,8 @@ func CnbBuildCommand() *cobra.Command {
 			}
 			log.RegisterSecret(stepConfig.DockerConfigJSON)
 			log.RegisterSecret(stepConfig.DockerConfigJSONCPE)
+			log.RegisterSecret(stepConfig.SigningKey)
+			log.RegisterSecret(stepConfig.SigningKeyPassword)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
 				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
@@ -201,9 +210,11 @@ func CnbBuildCommand() *cobra.Command {
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -272,6 +283,10 @@ func addCnbBuildFlags(cmd *cobra.Command, stepConfig *cnbBuildOptions) {
 	cmd.Flags().StringVar(&stepConfig.SyftDownloadURL, "syftDownloadUrl", `https://github.com/anchore/syft/releases/download/v1.4.1/syft_1.4.1_linux_amd64.tar.gz`, "Specifies the download url of the Syft Linux amd64 tar binary file. This can be found at https://github.com/anchore/syft/releases/.")
 	cmd.Flags().StringVar(&stepConfig.RunImage, "runImage", os.Getenv("PIPER_runImage"), "Base image from which application images are built. Will be defaulted to the image provided by the builder.")
 	cmd.Flags().StringVar(&stepConfig.DefaultProcess, "defaultProcess", os.Getenv("PIPER_defaultProcess"), "Process that should be started by default. See https://buildpacks.io/docs/app-developer-guide/run-an-app/")
+	cmd.Flags().BoolVar(&stepConfig.SignImages, "signImages", false, "Signs the pushed images with [signingKey](#signingkey). The signatures are compatible with [cosign](https://github.com/sigstore/cosign) and can be verified with `containerVerifySignature`, `cosign verify` or admission controllers like the Sigstore policy-controller.")
+	cmd.Flags().StringVar(&stepConfig.SigningKey, "signingKey", os.Getenv("PIPER_signingKey"), "Path to the PEM encoded private key used for signing the images. Keys created with `cosign generate-key-pair` as well as unencrypted PKCS#8 keys are supported.")
+	cmd.Flags().StringVar(&stepConfig.SigningKeyPassword, "signingKeyPassword", os.Getenv("PIPER_signingKeyPassword"), "Password of the [signingKey](#signingkey).")
+	cmd.Flags().StringVar(&stepConfig.SignatureMode, "signatureMode", `tag`, "Defines where the signatures are stored. `tag` stores them in the tag `sha256-<digest>.sig` of the image repository like cosign does by default, `referrers` stores them as OCI 1.1 artifacts referring to the image.")
 
 	cmd.MarkFlagRequired("containerImageTag")
 	cmd.MarkFlagRequired("containerRegistryUrl")
@@ -289,6 +304,8 @@ func cnbBuildMetadata() config.StepData {
 			Inputs: config.StepInputs{
 				Secrets: []config.StepSecrets{
 					{Name: "dockerConfigJsonCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing Docker config.json (with registry credential(s)) in the following format:\n\n```json\n{\n  \"auths\": {\n    \"$server\": {\n      \"auth\": \"base64($username + ':' + $password)\"\n    }\n  }\n}\n```\n\nExample:\n\n```json\n{\n  \"auths\": {\n    \"example.com\": {\n      \"auth\": \"dXNlcm5hbWU6cGFzc3dvcmQ=\"\n    }\n  }\n}\n```\n", Type: "jenkins"},
+					{Name: "signingKeyCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing the private key used for signing the images.", Type: "jenkins"},
+					{Name: "signingKeyPasswordCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing the password of the private key used for signing the images.", Type: "jenkins"},
 				},
 				Parameters: []config.StepParameters{
 					{
@@ -546,6 +563,64 @@ func cnbBuildMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_defaultProcess"),
 					},
+					{
+						Name:        "signImages",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name: "signingKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signingKeyCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signingKeyVaultSecretName",
+								Type:    "vaultSecretFile",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signingKey"),
+					},
+					{
+						Name: "signingKeyPassword",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signingKeyPasswordCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signingKeyVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signingKeyPassword"),
+					},
+					{
+						Name:        "signatureMode",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `tag`,
+					},
 				},
 			},
 			Containers: []config.Container{
@@ -563,6 +638,7 @@ func cnbBuildMetadata() config.StepData {
 							{"name": "container/imageNames", "type": "[]string"},
 							{"name": "container/imageNameTags", "type": "[]string"},
 							{"name": "container/imageDigests", "type": "[]string"},
+							{"name": "container/baseImages", "type": "[]string"},
 							{"name": "custom/buildSettingsInfo"},
 						},
 					},
@@ -571,6 +647,7 @@ func cnbBuildMetadata() config.StepData {
 						Type: "reports",
 						Parameters: []map[string]interface{}{
 							{"filePattern": "**/bom-*.xml", "type": "sbom"},
+							{"filePattern": "**/toolrun_cnbBuild_*.json", "type": "container-build"},
 						},
 					},
 				},
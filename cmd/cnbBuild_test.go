@@ -13,6 +13,7 @@ import (
 	piperconf "github.com/SAP/jenkins-library/pkg/config"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/fake"
@@ -127,6 +128,9 @@ func TestRunCnbBuild(t *testing.T) {
 		utils := newCnbBuildTestsUtils()
 		utils.FilesMock.AddFile(config.DockerConfigJSON, []byte(`{"auths":{"my-registry":{"auth":"dXNlcjpwYXNz"}}}`))
 		utils.FilesMock.AddFile("project.toml", []byte(projectToml))
+		utils.FilesMock.AddFile("/layers/analyzed.toml", []byte(`[run-image]
+reference = "my-run-image@sha256:f3b7ab1e6e0bc3bd8b5d8e7a7e9e0e68fe5c7e0bd2b34ba48e0ea4c5d8b0e1f2"
+image = "my-run-image"`))
 		addBuilderFiles(&utils)
 
 		err := callCnbBuild(&config, &telemetry.CustomData{}, &utils, &commonPipelineEnvironment, &piperhttp.Client{})
@@ -138,6 +142,8 @@ func TestRunCnbBuild(t *testing.T) {
 		assert.Contains(t, runner.Calls[1].Params, fmt.Sprintf("%s/%s:%s", imageRegistry, config.ContainerImageName, config.ContainerImageTag))
 		assert.Contains(t, runner.Calls[1].Params, "-run-image")
 		assert.Contains(t, runner.Calls[1].Params, "my-run-image")
+		assert.Equal(t, []string{"my-run-image@sha256:f3b7ab1e6e0bc3bd8b5d8e7a7e9e0e68fe5c7e0bd2b34ba48e0ea4c5d8b0e1f2"}, commonPipelineEnvironment.container.baseImages)
+		assert.True(t, utils.HasFile(filepath.Join("toolruns", "toolrun_cnbBuild_all.json")))
 		assert.Contains(t, runner.Calls[1].Params, "-process-type")
 		assert.Contains(t, runner.Calls[1].Params, "my-process")
 		assert.Equal(t, config.ContainerRegistryURL, commonPipelineEnvironment.container.registryURL)
@@ -204,6 +210,49 @@ func TestRunCnbBuild(t *testing.T) {
 		assert.Equal(t, "my-image:0.0.1", commonPipelineEnvironment.container.imageNameTag)
 	})
 
+	t.Run("success case (sign images)", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", "")
+		host, digest := newSigningTestRegistry(t)
+		commonPipelineEnvironment := cnbBuildCommonPipelineEnvironment{}
+		config := cnbBuildOptions{
+			ContainerImageName:   "my-image",
+			ContainerImageTag:    "1.0.0",
+			ContainerRegistryURL: "http://" + host,
+			SignImages:           true,
+			SigningKey:           "cosign.key",
+			SignatureMode:        "referrers",
+		}
+
+		utils := newCnbBuildTestsUtils()
+		utils.FilesMock.AddFile("/layers/report.toml", []byte(fmt.Sprintf("[image]\ndigest = %q", digest)))
+		addSigningTestKeys(t, utils.FilesMock)
+		addBuilderFiles(&utils)
+
+		err := callCnbBuild(&config, &telemetry.CustomData{}, &utils, &commonPipelineEnvironment, &piperhttp.Client{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{digest}, commonPipelineEnvironment.container.imageDigests)
+		assert.NoError(t, signing.VerifyImages([]string{host + "/my-image:1.0.0"}, "cosign.pub", utils.FilesMock))
+	})
+
+	t.Run("error case (sign images without signing key)", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", "")
+		commonPipelineEnvironment := cnbBuildCommonPipelineEnvironment{}
+		config := cnbBuildOptions{
+			ContainerImageName:   "my-image",
+			ContainerImageTag:    "0.0.1",
+			ContainerRegistryURL: fmt.Sprintf("https://%s", imageRegistry),
+			SignImages:           true,
+		}
+
+		utils := newCnbBuildTestsUtils()
+		addBuilderFiles(&utils)
+
+		err := callCnbBuild(&config, &telemetry.CustomData{}, &utils, &commonPipelineEnvironment, &piperhttp.Client{})
+
+		assert.EqualError(t, err, "failed to sign images: no signing key provided")
+	})
+
 	t.Run("success case (registry without https)", func(t *testing.T) {
 		t.Parallel()
 		commonPipelineEnvironment := cnbBuildCommonPipelineEnvironment{}
@@ -14,8 +14,11 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/maven"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/stepcache"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/triage"
 	"github.com/google/shlex"
+	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 )
 
@@ -140,10 +143,9 @@ func runCodeqlExecuteScan(config *codeqlExecuteScanOptions, telemetryData *telem
 
 	var reports []piperutils.Path
 
-	dbCreateCustomFlags := codeql.ParseCustomFlags(config.DatabaseCreateFlags)
-	err := runDatabaseCreate(config, dbCreateCustomFlags, utils)
+	scanTargets, err := getCodeqlScanTargets(config)
 	if err != nil {
-		log.Entry().WithError(err).Error("failed to create codeql database")
+		log.Entry().WithError(err).Error("failed to determine languages to scan")
 		return reports, err
 	}
 
@@ -153,13 +155,25 @@ func runCodeqlExecuteScan(config *codeqlExecuteScanOptions, telemetryData *telem
 		return reports, err
 	}
 
-	dbAnalyzeCustomFlags := codeql.ParseCustomFlags(config.DatabaseAnalyzeFlags)
-	scanReports, err := runDatabaseAnalyze(config, dbAnalyzeCustomFlags, utils)
-	if err != nil {
-		log.Entry().WithError(err).Error("failed to analyze codeql database")
-		return reports, err
+	databaseCache := newCodeqlDatabaseCache(config)
+	sarifReports := []codeql.SarifReport{}
+	for _, target := range scanTargets {
+		scanReports, err := runLanguageScan(target, databaseCache, utils)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, scanReports...)
+		sarifReports = append(sarifReports, codeql.SarifReport{Path: filepath.Join(config.ModulePath, "target", target.reportName+".sarif"), Category: target.category})
+	}
+
+	if len(config.Languages) > 0 {
+		sarifReport := filepath.Join(config.ModulePath, "target", "codeqlReport.sarif")
+		if err := codeql.MergeSarifReports(sarifReports, sarifReport, utils); err != nil {
+			log.Entry().WithError(err).Error("failed to merge sarif reports of the languages")
+			return reports, err
+		}
+		reports = append(reports, piperutils.Path{Target: sarifReport})
 	}
-	reports = append(reports, scanReports...)
 
 	if len(config.CustomCommand) > 0 {
 		err = runCustomCommand(utils, config.CustomCommand)
@@ -176,7 +190,7 @@ func runCodeqlExecuteScan(config *codeqlExecuteScanOptions, telemetryData *telem
 	}
 
 	if len(config.TargetGithubRepoURL) > 0 {
-		err = uploadProjectToGitHub(config, repoInfo)
+		err = uploadProjectToGitHub(config, config.Database, repoInfo)
 		if err != nil {
 			log.Entry().WithError(err).Error("failed to upload project to Github")
 			return reports, err
@@ -186,6 +200,9 @@ func runCodeqlExecuteScan(config *codeqlExecuteScanOptions, telemetryData *telem
 	var scanResults []codeql.CodeqlFindings
 	if !config.UploadResults {
 		log.Entry().Warn("The sarif results will not be uploaded to the repository and compliance report will not be generated as uploadResults is set to false.")
+		if config.AssessmentSync != triage.None {
+			log.Entry().Warn("Assessments are not synchronized as uploadResults is set to false.")
+		}
 	} else {
 		log.Entry().Infof("The sarif results will be uploaded to the repository %s", repoInfo.FullUrl)
 
@@ -200,6 +217,17 @@ func runCodeqlExecuteScan(config *codeqlExecuteScanOptions, telemetryData *telem
 			return reports, err
 		}
 
+		if config.AssessmentSync != triage.None {
+			auditor, err := codeql.NewTriageAuditor(repoInfo.ServerUrl, repoInfo.Owner, repoInfo.Repo, token, repoInfo.AnalyzedRef, []string{})
+			if err != nil {
+				return reports, errors.Wrap(err, "failed to create GitHub client for assessments")
+			}
+			if _, err := triage.Run(auditor, config.AssessmentFile, config.AssessmentSync, utils); err != nil {
+				log.Entry().WithError(err).Error("failed to synchronize assessments")
+				return reports, err
+			}
+		}
+
 		codeqlScanAuditInstance := codeql.NewCodeqlScanAuditInstance(repoInfo.ServerUrl, repoInfo.Owner, repoInfo.Repo, token, []string{})
 		scanResults, err = codeqlScanAuditInstance.GetVulnerabilities(repoInfo.AnalyzedRef)
 		if err != nil {
@@ -242,6 +270,149 @@ func runCodeqlExecuteScan(config *codeqlExecuteScanOptions, telemetryData *telem
 	return reports, nil
 }
 
+// codeqlLanguageConf is an entry of the languages parameter
+type codeqlLanguageConf struct {
+	Language     string `json:"language,omitempty"`
+	BuildCommand string `json:"buildCommand,omitempty"`
+	QuerySuite   string `json:"querySuite,omitempty"`
+}
+
+// codeqlScanTarget holds the configuration of the database and the reports of one language
+type codeqlScanTarget struct {
+	language   string
+	config     *codeqlExecuteScanOptions
+	reportName string
+	category   string
+}
+
+// getCodeqlScanTargets returns one target per entry of the languages parameter or a single target for the step configuration
+func getCodeqlScanTargets(config *codeqlExecuteScanOptions) ([]codeqlScanTarget, error) {
+	if len(config.Languages) == 0 {
+		language := getCodeqlLanguage(config, codeql.ParseCustomFlags(config.DatabaseCreateFlags))
+		return []codeqlScanTarget{{language: language, config: config, reportName: "codeqlReport"}}, nil
+	}
+	if codeql.IsFlagSetByUser(codeql.ParseCustomFlags(config.DatabaseCreateFlags), []string{"--language", "-l"}) {
+		return nil, fmt.Errorf("the flag --language of databaseCreateFlags can't be combined with the languages parameter")
+	}
+	if len(config.TargetGithubRepoURL) > 0 {
+		// the sources of a single database are pushed as one commit, which the uploaded results refer to
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, fmt.Errorf("the parameter targetGithubRepoURL can't be combined with the languages parameter")
+	}
+
+	buildToolLanguage := getLangFromBuildTool(config.BuildTool)
+	rootLanguage := buildToolLanguage
+	if len(rootLanguage) == 0 {
+		rootLanguage = config.Language
+	}
+
+	targets := []codeqlScanTarget{}
+	seen := map[string]bool{}
+	for _, entry := range config.Languages {
+		var conf codeqlLanguageConf
+		if err := mapstructure.Decode(entry, &conf); err != nil {
+			return nil, errors.Wrap(err, "failed to parse languages parameter")
+		}
+		if len(conf.Language) == 0 {
+			return nil, fmt.Errorf("languages: empty language")
+		}
+		if seen[conf.Language] {
+			return nil, fmt.Errorf("languages: language %s is configured more than once", conf.Language)
+		}
+		seen[conf.Language] = true
+
+		languageConfig := *config
+		languageConfig.Language = conf.Language
+		languageConfig.Database = filepath.Join(config.Database, conf.Language)
+		if conf.Language != buildToolLanguage {
+			languageConfig.BuildTool = "custom"
+		}
+		if len(conf.BuildCommand) > 0 || conf.Language != rootLanguage {
+			languageConfig.BuildCommand = conf.BuildCommand
+		}
+		if len(conf.QuerySuite) > 0 {
+			languageConfig.QuerySuite = conf.QuerySuite
+		}
+		targets = append(targets, codeqlScanTarget{
+			language:   conf.Language,
+			config:     &languageConfig,
+			reportName: "codeqlReport-" + conf.Language,
+			category:   codeql.LanguageCategory(conf.Language),
+		})
+	}
+	return targets, nil
+}
+
+// getCodeqlLanguage returns the language the database is created for, which is empty if it can't be determined
+func getCodeqlLanguage(config *codeqlExecuteScanOptions, customFlags map[string]string) string {
+	for _, flag := range []string{"--language", "-l"} {
+		if value, ok := customFlags[flag]; ok {
+			_, language, _ := strings.Cut(value, "=")
+			return language
+		}
+	}
+	if language := getLangFromBuildTool(config.BuildTool); len(language) > 0 {
+		return language
+	}
+	return config.Language
+}
+
+func newCodeqlDatabaseCache(config *codeqlExecuteScanOptions) *codeql.DatabaseCache {
+	if !config.CacheDatabase {
+		return nil
+	}
+	if len(GeneralConfig.StepCacheLocation) == 0 {
+		log.Entry().Warn("Databases are not cached as stepCacheLocation is not configured.")
+		return nil
+	}
+	if len(config.CommitID) == 0 {
+		log.Entry().Warn("Databases are not cached as the analyzed commit is unknown.")
+		return nil
+	}
+	backend, err := stepcache.NewBackend(GeneralConfig.StepCacheLocation, GeneralConfig.GCPJsonKeyFilePath)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Databases are not cached")
+		return nil
+	}
+	return codeql.NewDatabaseCache(backend, config.CommitID)
+}
+
+// runLanguageScan creates the database of a language, or restores it from the cache, and analyzes it
+func runLanguageScan(target codeqlScanTarget, databaseCache *codeql.DatabaseCache, utils codeqlExecuteScanUtils) ([]piperutils.Path, error) {
+	cacheable := databaseCache != nil && len(target.language) > 0
+	restored := false
+	if cacheable {
+		var err error
+		restored, err = databaseCache.Restore(target.language, target.config.Database)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("failed to restore cached database of %s", target.language)
+		}
+	}
+
+	if restored {
+		log.Entry().Infof("Using cached database of %s for commit %s", target.language, target.config.CommitID)
+	} else {
+		dbCreateCustomFlags := codeql.ParseCustomFlags(target.config.DatabaseCreateFlags)
+		if err := runDatabaseCreate(target.config, dbCreateCustomFlags, utils); err != nil {
+			log.Entry().WithError(err).Errorf("failed to create codeql database %s", target.config.Database)
+			return nil, err
+		}
+		if cacheable {
+			if err := databaseCache.Store(target.language, target.config.Database); err != nil {
+				log.Entry().WithError(err).Warnf("failed to cache database of %s", target.language)
+			}
+		}
+	}
+
+	dbAnalyzeCustomFlags := codeql.ParseCustomFlags(target.config.DatabaseAnalyzeFlags)
+	scanReports, err := runDatabaseAnalyze(target, dbAnalyzeCustomFlags, utils)
+	if err != nil {
+		log.Entry().WithError(err).Errorf("failed to analyze codeql database %s", target.config.Database)
+		return nil, err
+	}
+	return scanReports, nil
+}
+
 func runDatabaseCreate(config *codeqlExecuteScanOptions, customFlags map[string]string, utils codeqlExecuteScanUtils) error {
 	cmd, err := prepareCmdForDatabaseCreate(customFlags, config, utils)
 	if err != nil {
@@ -255,12 +426,12 @@ func runDatabaseCreate(config *codeqlExecuteScanOptions, customFlags map[string]
 	return nil
 }
 
-func runDatabaseAnalyze(config *codeqlExecuteScanOptions, customFlags map[string]string, utils codeqlExecuteScanUtils) ([]piperutils.Path, error) {
-	sarifReport, err := executeAnalysis("sarif-latest", "codeqlReport.sarif", customFlags, config, utils)
+func runDatabaseAnalyze(target codeqlScanTarget, customFlags map[string]string, utils codeqlExecuteScanUtils) ([]piperutils.Path, error) {
+	sarifReport, err := executeAnalysis("sarif-latest", target.reportName+".sarif", target.category, customFlags, target.config, utils)
 	if err != nil {
 		return nil, err
 	}
-	csvReport, err := executeAnalysis("csv", "codeqlReport.csv", customFlags, config, utils)
+	csvReport, err := executeAnalysis("csv", target.reportName+".csv", "", customFlags, target.config, utils)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +460,7 @@ func runGithubUploadResults(config *codeqlExecuteScanOptions, repoInfo *codeql.R
 	return url, nil
 }
 
-func executeAnalysis(format, reportName string, customFlags map[string]string, config *codeqlExecuteScanOptions, utils codeqlExecuteScanUtils) ([]piperutils.Path, error) {
+func executeAnalysis(format, reportName, category string, customFlags map[string]string, config *codeqlExecuteScanOptions, utils codeqlExecuteScanUtils) ([]piperutils.Path, error) {
 	moduleTargetPath := filepath.Join(config.ModulePath, "target")
 	report := filepath.Join(moduleTargetPath, reportName)
 	cmd, err := prepareCmdForDatabaseAnalyze(utils, customFlags, config, format, report)
@@ -297,6 +468,9 @@ func executeAnalysis(format, reportName string, customFlags map[string]string, c
 		log.Entry().Errorf("failed to prepare command for codeql database analyze (format=%s)", format)
 		return nil, err
 	}
+	if len(category) > 0 {
+		cmd = codeql.AppendFlagIfNotSetByUser(cmd, []string{"--sarif-category"}, []string{"--sarif-category=" + category}, customFlags)
+	}
 	if err = execute(utils, cmd, GeneralConfig.Verbose); err != nil {
 		log.Entry().Errorf("failed running command codeql database analyze for %s generation", format)
 		return nil, err
@@ -394,7 +568,7 @@ func uploadSarifResults(config *codeqlExecuteScanOptions, token string, repoInfo
 	return nil
 }
 
-func uploadProjectToGitHub(config *codeqlExecuteScanOptions, repoInfo *codeql.RepoInfo) error {
+func uploadProjectToGitHub(config *codeqlExecuteScanOptions, database string, repoInfo *codeql.RepoInfo) error {
 	log.Entry().Infof("DB sources for %s will be uploaded to target GitHub repo: %s", config.Repository, repoInfo.FullUrl)
 
 	hasToken, token := getToken(config)
@@ -404,7 +578,7 @@ func uploadProjectToGitHub(config *codeqlExecuteScanOptions, repoInfo *codeql.Re
 	repoUploader, err := codeql.NewGitUploaderInstance(
 		token,
 		repoInfo.AnalyzedRef,
-		config.Database,
+		database,
 		repoInfo.CommitId,
 		config.Repository,
 		config.TargetGithubRepoURL,
@@ -23,31 +23,35 @@ import (
 )
 
 type codeqlExecuteScanOptions struct {
-	GithubToken                 string `json:"githubToken,omitempty"`
-	BuildTool                   string `json:"buildTool,omitempty" validate:"possible-values=custom maven golang npm pip yarn"`
-	BuildCommand                string `json:"buildCommand,omitempty"`
-	Language                    string `json:"language,omitempty"`
-	ModulePath                  string `json:"modulePath,omitempty"`
-	Database                    string `json:"database,omitempty"`
-	QuerySuite                  string `json:"querySuite,omitempty"`
-	UploadResults               bool   `json:"uploadResults,omitempty"`
-	SarifCheckMaxRetries        int    `json:"sarifCheckMaxRetries,omitempty"`
-	SarifCheckRetryInterval     int    `json:"sarifCheckRetryInterval,omitempty"`
-	TargetGithubRepoURL         string `json:"targetGithubRepoURL,omitempty"`
-	TargetGithubBranchName      string `json:"targetGithubBranchName,omitempty"`
-	Threads                     string `json:"threads,omitempty"`
-	Ram                         string `json:"ram,omitempty"`
-	AnalyzedRef                 string `json:"analyzedRef,omitempty"`
-	Repository                  string `json:"repository,omitempty"`
-	CommitID                    string `json:"commitId,omitempty"`
-	VulnerabilityThresholdTotal int    `json:"vulnerabilityThresholdTotal,omitempty"`
-	CheckForCompliance          bool   `json:"checkForCompliance,omitempty"`
-	ProjectSettingsFile         string `json:"projectSettingsFile,omitempty"`
-	GlobalSettingsFile          string `json:"globalSettingsFile,omitempty"`
-	DatabaseCreateFlags         string `json:"databaseCreateFlags,omitempty"`
-	DatabaseAnalyzeFlags        string `json:"databaseAnalyzeFlags,omitempty"`
-	CustomCommand               string `json:"customCommand,omitempty"`
-	TransformQuerySuite         string `json:"transformQuerySuite,omitempty"`
+	GithubToken                 string                   `json:"githubToken,omitempty"`
+	BuildTool                   string                   `json:"buildTool,omitempty" validate:"possible-values=custom maven golang npm pip yarn"`
+	BuildCommand                string                   `json:"buildCommand,omitempty"`
+	Language                    string                   `json:"language,omitempty"`
+	Languages                   []map[string]interface{} `json:"languages,omitempty"`
+	ModulePath                  string                   `json:"modulePath,omitempty"`
+	Database                    string                   `json:"database,omitempty"`
+	CacheDatabase               bool                     `json:"cacheDatabase,omitempty"`
+	QuerySuite                  string                   `json:"querySuite,omitempty"`
+	UploadResults               bool                     `json:"uploadResults,omitempty"`
+	SarifCheckMaxRetries        int                      `json:"sarifCheckMaxRetries,omitempty"`
+	SarifCheckRetryInterval     int                      `json:"sarifCheckRetryInterval,omitempty"`
+	TargetGithubRepoURL         string                   `json:"targetGithubRepoURL,omitempty"`
+	TargetGithubBranchName      string                   `json:"targetGithubBranchName,omitempty"`
+	Threads                     string                   `json:"threads,omitempty"`
+	Ram                         string                   `json:"ram,omitempty"`
+	AnalyzedRef                 string                   `json:"analyzedRef,omitempty"`
+	Repository                  string                   `json:"repository,omitempty"`
+	CommitID                    string                   `json:"commitId,omitempty"`
+	VulnerabilityThresholdTotal int                      `json:"vulnerabilityThresholdTotal,omitempty"`
+	CheckForCompliance          bool                     `json:"checkForCompliance,omitempty"`
+	AssessmentFile              string                   `json:"assessmentFile,omitempty"`
+	AssessmentSync              string                   `json:"assessmentSync,omitempty" validate:"possible-values=none push pull sync"`
+	ProjectSettingsFile         string                   `json:"projectSettingsFile,omitempty"`
+	GlobalSettingsFile          string                   `json:"globalSettingsFile,omitempty"`
+	DatabaseCreateFlags         string                   `json:"databaseCreateFlags,omitempty"`
+	DatabaseAnalyzeFlags        string                   `json:"databaseAnalyzeFlags,omitempty"`
+	CustomCommand               string                   `json:"customCommand,omitempty"`
+	TransformQuerySuite         string                   `json:"transformQuerySuite,omitempty"`
 }
 
 type codeqlExecuteScanInflux struct {
@@ -105,6 +109,26 @@ func (i *codeqlExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *codeqlExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"codeql": i.step_data.fields.codeql,
+		},
+		"codeql_data": {
+			"repositoryUrl":          i.codeql_data.fields.repositoryURL,
+			"repositoryReferenceUrl": i.codeql_data.fields.repositoryReferenceURL,
+			"codeScanningLink":       i.codeql_data.fields.codeScanningLink,
+			"querySuite":             i.codeql_data.fields.querySuite,
+			"optionalTotal":          i.codeql_data.fields.optionalTotal,
+			"optionalAudited":        i.codeql_data.fields.optionalAudited,
+			"auditAllTotal":          i.codeql_data.fields.auditAllTotal,
+			"auditAllAudited":        i.codeql_data.fields.auditAllAudited,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type codeqlExecuteScanReports struct {
 }
 
@@ -220,9 +244,12 @@ and Java plus Maven.`,
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -273,13 +300,15 @@ func addCodeqlExecuteScanFlags(cmd *cobra.Command, stepConfig *codeqlExecuteScan
 	cmd.Flags().StringVar(&stepConfig.BuildTool, "buildTool", `maven`, "Defines the build tool which is used for building the project.")
 	cmd.Flags().StringVar(&stepConfig.BuildCommand, "buildCommand", os.Getenv("PIPER_buildCommand"), "Command to build the project")
 	cmd.Flags().StringVar(&stepConfig.Language, "language", os.Getenv("PIPER_language"), "The programming language used to analyze.")
+
 	cmd.Flags().StringVar(&stepConfig.ModulePath, "modulePath", `./`, "Allows providing the path for the module to scan")
 	cmd.Flags().StringVar(&stepConfig.Database, "database", `codeqlDB`, "Path to the CodeQL database to create. This directory will be created, and must not already exist.")
+	cmd.Flags().BoolVar(&stepConfig.CacheDatabase, "cacheDatabase", false, "Caches the databases keyed by commit and language in the step cache location (`stepCacheLocation`), so that re-runs and re-analyses with new query packs of the same commit skip the extraction.")
 	cmd.Flags().StringVar(&stepConfig.QuerySuite, "querySuite", os.Getenv("PIPER_querySuite"), "The name of a CodeQL query suite. If omitted, the default query suite for the language of the database being analyzed will be used.")
 	cmd.Flags().BoolVar(&stepConfig.UploadResults, "uploadResults", false, "Allows you to upload codeql SARIF results to your github project. You will need to set githubToken for this.")
 	cmd.Flags().IntVar(&stepConfig.SarifCheckMaxRetries, "sarifCheckMaxRetries", 10, "Maximum number of retries when waiting for the server to finish processing the SARIF upload.")
 	cmd.Flags().IntVar(&stepConfig.SarifCheckRetryInterval, "sarifCheckRetryInterval", 30, "Interval in seconds between retries when waiting for the server to finish processing the SARIF upload.")
-	cmd.Flags().StringVar(&stepConfig.TargetGithubRepoURL, "targetGithubRepoURL", os.Getenv("PIPER_targetGithubRepoURL"), "Target github repo url. Only relevant, if project uses a combination of Piper and non-GitHub SCM.")
+	cmd.Flags().StringVar(&stepConfig.TargetGithubRepoURL, "targetGithubRepoURL", os.Getenv("PIPER_targetGithubRepoURL"), "Target github repo url. Only relevant, if project uses a combination of Piper and non-GitHub SCM. Can't be combined with `languages`.")
 	cmd.Flags().StringVar(&stepConfig.TargetGithubBranchName, "targetGithubBranchName", os.Getenv("PIPER_targetGithubBranchName"), "Target github branch name. Only relevant, if project uses a combination of Piper and non-GitHub SCM.")
 	cmd.Flags().StringVar(&stepConfig.Threads, "threads", `0`, "Use this many threads for the codeql operations.")
 	cmd.Flags().StringVar(&stepConfig.Ram, "ram", `4000`, "Use this much ram (MB) for the codeql operations.")
@@ -288,6 +317,8 @@ func addCodeqlExecuteScanFlags(cmd *cobra.Command, stepConfig *codeqlExecuteScan
 	cmd.Flags().StringVar(&stepConfig.CommitID, "commitId", os.Getenv("PIPER_commitId"), "SHA of commit that was analyzed.")
 	cmd.Flags().IntVar(&stepConfig.VulnerabilityThresholdTotal, "vulnerabilityThresholdTotal", 0, "Threashold for maximum number of allowed vulnerabilities.")
 	cmd.Flags().BoolVar(&stepConfig.CheckForCompliance, "checkForCompliance", false, "If set to true, the piper step checks for compliance based on vulnerability threadholds. Example - If total vulnerabilites are 10 and vulnerabilityThresholdTotal is set as 0, then the steps throws an compliance error.")
+	cmd.Flags().StringVar(&stepConfig.AssessmentFile, "assessmentFile", `hs-assessments.yaml`, "Path to the assessment YAML file which records triage decisions of findings as `ignore` entries with `tool: codeql`. Used by `assessmentSync`.")
+	cmd.Flags().StringVar(&stepConfig.AssessmentSync, "assessmentSync", `none`, "Synchronizes the assessments of `assessmentFile` with the dismissals of the CodeQL alerts on GitHub before the results are evaluated. `push` applies the assessments of the file, `pull` adds and updates the file with the assessments made in the scanner and `sync` pushes the file and pulls only assessments missing in the file. Pulled assessments need to be committed to the repository.")
 	cmd.Flags().StringVar(&stepConfig.ProjectSettingsFile, "projectSettingsFile", os.Getenv("PIPER_projectSettingsFile"), "Path to the mvn settings file that should be used as project settings file.")
 	cmd.Flags().StringVar(&stepConfig.GlobalSettingsFile, "globalSettingsFile", os.Getenv("PIPER_globalSettingsFile"), "Path to the mvn settings file that should be used as global settings file.")
 	cmd.Flags().StringVar(&stepConfig.DatabaseCreateFlags, "databaseCreateFlags", os.Getenv("PIPER_databaseCreateFlags"), "A space-separated string of flags for the 'codeql database create' command.")
@@ -364,6 +395,14 @@ func codeqlExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_language"),
 					},
+					{
+						Name:        "languages",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]map[string]interface{}",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+					},
 					{
 						Name:        "modulePath",
 						ResourceRef: []config.ResourceReference{},
@@ -382,6 +421,15 @@ func codeqlExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `codeqlDB`,
 					},
+					{
+						Name:        "cacheDatabase",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "querySuite",
 						ResourceRef: []config.ResourceReference{},
@@ -514,6 +562,24 @@ func codeqlExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "assessmentFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `hs-assessments.yaml`,
+					},
+					{
+						Name:        "assessmentSync",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `none`,
+					},
 					{
 						Name:        "projectSettingsFile",
 						ResourceRef: []config.ResourceReference{},
@@ -565,6 +565,94 @@ func TestPrepareCmdForDatabaseAnalyze(t *testing.T) {
 	})
 }
 
+func TestGetCodeqlScanTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Single language", func(t *testing.T) {
+		config := &codeqlExecuteScanOptions{BuildTool: "maven", Database: "codeqlDB"}
+		targets, err := getCodeqlScanTargets(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []codeqlScanTarget{{language: "java", config: config, reportName: "codeqlReport"}}, targets)
+	})
+
+	t.Run("Single language set by custom flag", func(t *testing.T) {
+		config := &codeqlExecuteScanOptions{BuildTool: "custom", Database: "codeqlDB", DatabaseCreateFlags: "--language=go"}
+		targets, err := getCodeqlScanTargets(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "go", targets[0].language)
+	})
+
+	t.Run("Language matrix", func(t *testing.T) {
+		config := &codeqlExecuteScanOptions{
+			BuildTool:    "maven",
+			BuildCommand: "mvn clean install",
+			QuerySuite:   "security-extended",
+			Database:     "codeqlDB",
+			Languages: []map[string]interface{}{
+				{"language": "java"},
+				{"language": "javascript", "querySuite": "javascript-security-and-quality.qls"},
+				{"language": "go", "buildCommand": "go build ./..."},
+			},
+		}
+		targets, err := getCodeqlScanTargets(config)
+		assert.NoError(t, err)
+		assert.Len(t, targets, 3)
+
+		assert.Equal(t, "java", targets[0].language)
+		assert.Equal(t, "codeqlReport-java", targets[0].reportName)
+		assert.Equal(t, "/language:java", targets[0].category)
+		assert.Equal(t, filepath.Join("codeqlDB", "java"), targets[0].config.Database)
+		assert.Equal(t, "maven", targets[0].config.BuildTool)
+		assert.Equal(t, "mvn clean install", targets[0].config.BuildCommand)
+		assert.Equal(t, "security-extended", targets[0].config.QuerySuite)
+
+		assert.Equal(t, "custom", targets[1].config.BuildTool)
+		assert.Equal(t, "javascript", targets[1].config.Language)
+		assert.Equal(t, "", targets[1].config.BuildCommand)
+		assert.Equal(t, "javascript-security-and-quality.qls", targets[1].config.QuerySuite)
+
+		assert.Equal(t, "go build ./...", targets[2].config.BuildCommand)
+		assert.Equal(t, "codeqlDB", config.Database, "step configuration must not be modified")
+	})
+
+	t.Run("Invalid matrix", func(t *testing.T) {
+		_, err := getCodeqlScanTargets(&codeqlExecuteScanOptions{Languages: []map[string]interface{}{{"buildCommand": "make"}}})
+		assert.EqualError(t, err, "languages: empty language")
+
+		_, err = getCodeqlScanTargets(&codeqlExecuteScanOptions{Languages: []map[string]interface{}{{"language": "go"}, {"language": "go"}}})
+		assert.EqualError(t, err, "languages: language go is configured more than once")
+
+		_, err = getCodeqlScanTargets(&codeqlExecuteScanOptions{DatabaseCreateFlags: "-l=go", Languages: []map[string]interface{}{{"language": "go"}}})
+		assert.EqualError(t, err, "the flag --language of databaseCreateFlags can't be combined with the languages parameter")
+
+		_, err = getCodeqlScanTargets(&codeqlExecuteScanOptions{TargetGithubRepoURL: "https://github.com/org/repo", Languages: []map[string]interface{}{{"language": "go"}}})
+		assert.EqualError(t, err, "the parameter targetGithubRepoURL can't be combined with the languages parameter")
+	})
+}
+
+func TestRunLanguageScan(t *testing.T) {
+	t.Parallel()
+
+	config := &codeqlExecuteScanOptions{
+		BuildTool:  "maven",
+		ModulePath: "./",
+		Database:   "codeqlDB",
+		Languages:  []map[string]interface{}{{"language": "go", "buildCommand": "go build ./..."}},
+	}
+	targets, err := getCodeqlScanTargets(config)
+	assert.NoError(t, err)
+
+	utils := newCodeqlExecuteScanTestsUtils()
+	reports, err := runLanguageScan(targets[0], nil, utils)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"target/codeqlReport-go.sarif", "target/codeqlReport-go.csv"}, []string{reports[0].Target, reports[1].Target})
+	if assert.Len(t, utils.Calls, 3) {
+		assert.Equal(t, "database create codeqlDB/go --overwrite --source-root . --working-dir ./ --language=go --command=go build ./...", strings.Join(utils.Calls[0].Params, " "))
+		assert.Equal(t, "database analyze --format=sarif-latest --output=target/codeqlReport-go.sarif codeqlDB/go --sarif-category=/language:go", strings.Join(utils.Calls[1].Params, " "))
+		assert.Equal(t, "database analyze --format=csv --output=target/codeqlReport-go.csv codeqlDB/go", strings.Join(utils.Calls[2].Params, " "))
+	}
+}
+
 func TestPrepareCmdForUploadResults(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type containerCheckBaseImageUtils interface {
+	piperutils.FileUtils
+	docker.ImageInfoProvider
+}
+
+type containerCheckBaseImageUtilsBundle struct {
+	*piperutils.Files
+	*docker.Client
+}
+
+func newContainerCheckBaseImageUtils() containerCheckBaseImageUtils {
+	return &containerCheckBaseImageUtilsBundle{
+		Files:  &piperutils.Files{},
+		Client: &docker.Client{},
+	}
+}
+
+func containerCheckBaseImage(config containerCheckBaseImageOptions, telemetryData *telemetry.CustomData) {
+	utils := newContainerCheckBaseImageUtils()
+
+	err := runContainerCheckBaseImage(&config, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runContainerCheckBaseImage(config *containerCheckBaseImageOptions, utils containerCheckBaseImageUtils) error {
+	if len(config.BaseImages) == 0 {
+		log.Entry().Warn("No base images found, make sure the images have been built with kanikoExecute or cnbBuild")
+		return nil
+	}
+
+	if err := setRegistryDockerConfig(config.DockerConfigJSON, utils); err != nil {
+		return err
+	}
+
+	baseImages := piperutils.UniqueStrings(config.BaseImages)
+	sort.Strings(baseImages)
+
+	statuses := []docker.BaseImageStatus{}
+	outdated := []string{}
+	for _, baseImage := range baseImages {
+		status, err := docker.CheckBaseImage(docker.ParseBaseImage(baseImage), utils)
+		switch {
+		case err != nil:
+			log.Entry().WithError(err).Warnf("Base image '%v' could not be checked", baseImage)
+			status.Error = err.Error()
+		case status.Outdated:
+			log.Entry().Warnf("Base image '%v' is outdated, '%v' now points to '%v'", baseImage, status.Reference, status.CurrentDigest)
+			outdated = append(outdated, status.Reference)
+		default:
+			log.Entry().Infof("Base image '%v' is up to date", baseImage)
+		}
+		statuses = append(statuses, status)
+	}
+
+	reports, err := docker.WriteBaseImageReport(statuses, utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create base image report")
+	}
+	piperutils.PersistReportsAndLinks("containerCheckBaseImage", "", utils, reports, nil)
+
+	if len(outdated) > 0 && config.FailOnOutdatedBaseImage {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("newer versions of base images available: %v", strings.Join(outdated, ", "))
+	}
+	return nil
+}
+
+// setRegistryDockerConfig provides the registry credentials to the registry client via DOCKER_CONFIG
+func setRegistryDockerConfig(dockerConfigJSON string, utils piperutils.FileUtils) error {
+	if len(dockerConfigJSON) == 0 {
+		log.Entry().Info("Docker credentials configuration: NONE")
+		return nil
+	}
+	if exists, _ := utils.FileExists(dockerConfigJSON); !exists {
+		log.Entry().Warnf("Docker credentials configuration '%v' does not exist", dockerConfigJSON)
+		return nil
+	}
+	log.Entry().Infof("Docker credentials configuration: %v", dockerConfigJSON)
+
+	dockerConfigDir, err := utils.TempDir("", "docker")
+	if err != nil {
+		return errors.Wrap(err, "unable to create docker config dir")
+	}
+	if _, err := utils.Copy(dockerConfigJSON, filepath.Join(dockerConfigDir, "config.json")); err != nil {
+		return errors.Wrap(err, "unable to copy docker config")
+	}
+	return os.Setenv("DOCKER_CONFIG", dockerConfigDir)
+}
@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type containerCheckBaseImageMockUtils struct {
+	*mock.FilesMock
+	images map[string]v1.Image
+}
+
+func (c *containerCheckBaseImageMockUtils) GetRemoteImageInfo(image string) (v1.Image, error) {
+	img, ok := c.images[image]
+	if !ok {
+		return nil, fmt.Errorf("image %v not found", image)
+	}
+	return img, nil
+}
+
+func newContainerCheckBaseImageTestsUtils(t *testing.T) (*containerCheckBaseImageMockUtils, string) {
+	img, err := random.Image(10, 1)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	utils := &containerCheckBaseImageMockUtils{
+		FilesMock: &mock.FilesMock{},
+		images:    map[string]v1.Image{"alpine:3.19": img, "node:20": img},
+	}
+	return utils, digest.String()
+}
+
+func TestRunContainerCheckBaseImage(t *testing.T) {
+	t.Run("success case - base images up to date", func(t *testing.T) {
+		utils, digest := newContainerCheckBaseImageTestsUtils(t)
+		config := containerCheckBaseImageOptions{
+			BaseImages:              []string{"alpine:3.19@" + digest, "node:20@" + digest, "alpine:3.19@" + digest},
+			FailOnOutdatedBaseImage: true,
+		}
+
+		err := runContainerCheckBaseImage(&config, utils)
+
+		require.NoError(t, err)
+		content, err := utils.FileRead(docker.BaseImageReportFilename)
+		require.NoError(t, err)
+		statuses := []docker.BaseImageStatus{}
+		require.NoError(t, json.Unmarshal(content, &statuses))
+		if assert.Len(t, statuses, 2) {
+			assert.Equal(t, "alpine:3.19", statuses[0].Reference)
+			assert.False(t, statuses[0].Outdated)
+			assert.Equal(t, "node:20", statuses[1].Reference)
+			assert.False(t, statuses[1].Outdated)
+		}
+	})
+
+	t.Run("success case - outdated base image reported", func(t *testing.T) {
+		utils, digest := newContainerCheckBaseImageTestsUtils(t)
+		config := containerCheckBaseImageOptions{BaseImages: []string{"alpine:3.19@sha256:old", "node:20@" + digest}}
+
+		err := runContainerCheckBaseImage(&config, utils)
+
+		require.NoError(t, err)
+		content, err := utils.FileRead(docker.BaseImageReportFilename)
+		require.NoError(t, err)
+		statuses := []docker.BaseImageStatus{}
+		require.NoError(t, json.Unmarshal(content, &statuses))
+		if assert.Len(t, statuses, 2) {
+			assert.True(t, statuses[0].Outdated)
+			assert.Equal(t, digest, statuses[0].CurrentDigest)
+		}
+	})
+
+	t.Run("success case - base image not accessible", func(t *testing.T) {
+		utils, _ := newContainerCheckBaseImageTestsUtils(t)
+		config := containerCheckBaseImageOptions{BaseImages: []string{"my.registry/private:1@sha256:abc"}, FailOnOutdatedBaseImage: true}
+
+		err := runContainerCheckBaseImage(&config, utils)
+
+		require.NoError(t, err)
+		content, err := utils.FileRead(docker.BaseImageReportFilename)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "image my.registry/private:1 not found")
+	})
+
+	t.Run("success case - no base images", func(t *testing.T) {
+		utils, _ := newContainerCheckBaseImageTestsUtils(t)
+
+		err := runContainerCheckBaseImage(&containerCheckBaseImageOptions{}, utils)
+
+		assert.NoError(t, err)
+		assert.False(t, utils.HasFile(docker.BaseImageReportFilename))
+	})
+
+	t.Run("success case - docker config", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", "")
+		utils, digest := newContainerCheckBaseImageTestsUtils(t)
+		utils.AddFile("/path/to/docker.json", []byte(`{"auths":{}}`))
+		config := containerCheckBaseImageOptions{BaseImages: []string{"alpine:3.19@" + digest}, DockerConfigJSON: "/path/to/docker.json"}
+
+		err := runContainerCheckBaseImage(&config, utils)
+
+		require.NoError(t, err)
+		assert.True(t, utils.HasFile(filepath.Join(os.Getenv("DOCKER_CONFIG"), "config.json")))
+	})
+
+	t.Run("error case - outdated base image", func(t *testing.T) {
+		utils, digest := newContainerCheckBaseImageTestsUtils(t)
+		config := containerCheckBaseImageOptions{BaseImages: []string{"alpine:3.19@sha256:old", "node:20@" + digest}, FailOnOutdatedBaseImage: true}
+
+		err := runContainerCheckBaseImage(&config, utils)
+
+		assert.EqualError(t, err, "newer versions of base images available: alpine:3.19")
+	})
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/imagescan"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/toolrecord"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+)
+
+type containerExecuteScanUtils interface {
+	piperutils.FileUtils
+	piperDocker.Download
+}
+
+type containerExecuteScanUtilsBundle struct {
+	*piperutils.Files
+	*piperDocker.Client
+}
+
+func newContainerExecuteScanUtils(config *containerExecuteScanOptions) containerExecuteScanUtils {
+	dClient := &piperDocker.Client{}
+	dClient.SetOptions(piperDocker.ClientOptions{ImageName: config.ScanImage, RegistryURL: config.ContainerRegistryURL, ImageFormat: "legacy"})
+	return &containerExecuteScanUtilsBundle{
+		Files:  &piperutils.Files{},
+		Client: dClient,
+	}
+}
+
+func containerExecuteScan(config containerExecuteScanOptions, telemetryData *telemetry.CustomData, influx *containerExecuteScanInflux) {
+	utils := newContainerExecuteScanUtils(&config)
+
+	influx.step_data.fields.container_scan = false
+	if err := runContainerExecuteScan(&config, utils, influx); err != nil {
+		log.Entry().WithError(err).Fatal("Container image scan failed")
+	}
+	influx.step_data.fields.container_scan = true
+}
+
+func runContainerExecuteScan(config *containerExecuteScanOptions, utils containerExecuteScanUtils, influx *containerExecuteScanInflux) error {
+	scanner, err := imagescan.NewScanner(config.Scanner, imagescan.Options{DatabasePath: config.DatabasePath})
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	if err := setRegistryDockerConfig(config.DockerConfigJSON, utils); err != nil {
+		return err
+	}
+
+	cachePath, err := utils.TempDir("", "containerScan")
+	if err != nil {
+		return errors.Wrap(err, "failed to create download directory")
+	}
+	defer func() { _ = utils.RemoveAll(cachePath) }()
+
+	image, err := downloadScanImage(config.ScanImage, cachePath, utils)
+	if err != nil {
+		return err
+	}
+
+	log.Entry().Infof("Scanning image '%v' with scanner '%v'", config.ScanImage, scanner.Name())
+	result, err := scanner.Scan(image)
+	if err != nil {
+		return errors.Wrapf(err, "failed to scan image '%v'", config.ScanImage)
+	}
+
+	findings := []osv.Finding{}
+	excluded := 0
+	for _, finding := range result.Findings {
+		if finding.Matches(config.ExcludeVulnerabilities) {
+			log.Entry().Debugf("Ignoring excluded vulnerability %v of %v", finding.Vulnerability.ID, finding.Package.Purl())
+			excluded++
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	result.Findings = findings
+
+	counts := osv.CountBySeverity(findings)
+	influx.container_scan_data.fields.packages = len(result.Packages)
+	influx.container_scan_data.fields.vulnerabilities = len(findings)
+	influx.container_scan_data.fields.critical_vulnerabilities = counts[osv.SeverityCritical]
+	influx.container_scan_data.fields.high_vulnerabilities = counts[osv.SeverityHigh]
+	influx.container_scan_data.fields.medium_vulnerabilities = counts[osv.SeverityMedium]
+	influx.container_scan_data.fields.low_vulnerabilities = counts[osv.SeverityLow]
+	influx.container_scan_data.fields.excluded_vulnerabilities = excluded
+
+	reports := writeContainerScanReports(config, scanner.Name(), result, utils)
+
+	toolRecordFileName, err := createToolRecordContainerScan(utils, "./", config, scanner.Name(), result)
+	if err != nil {
+		// do not fail until the framework is well established
+		log.Entry().WithError(err).Warning("TR_CONTAINER_SCAN: Failed to create toolrecord file")
+	} else {
+		reports = append(reports, piperutils.Path{Target: toolRecordFileName})
+	}
+
+	piperutils.PersistReportsAndLinks("containerExecuteScan", "", utils, reports, nil)
+
+	for _, severity := range osv.Severities {
+		if counts[severity] > 0 {
+			log.Entry().Infof("%v vulnerabilities with severity %v found", counts[severity], severity)
+		}
+	}
+	if severe := osv.SevereFindings(findings, config.FailOnSeverities); len(severe) > 0 {
+		for _, finding := range severe {
+			log.Entry().Errorf("%v (%v) affects %v", finding.Vulnerability.ID, finding.Severity, finding.Package.Purl())
+		}
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v vulnerabilities with severity %v found in image '%v'", len(severe), strings.Join(config.FailOnSeverities, ", "), config.ScanImage)
+	}
+	return nil
+}
+
+// downloadScanImage downloads the image as tarball so that its layers are only fetched once from the registry
+func downloadScanImage(scanImage, cachePath string, utils containerExecuteScanUtils) (v1.Image, error) {
+	tarFilePath := filepath.Join(cachePath, "image.tar")
+	log.Entry().Infof("Downloading image '%v'", scanImage)
+	if _, err := utils.DownloadImage(scanImage, tarFilePath); err != nil {
+		return nil, errors.Wrapf(err, "failed to download image '%v'", scanImage)
+	}
+	image, err := tarball.ImageFromPath(tarFilePath, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load image '%v'", scanImage)
+	}
+	return image, nil
+}
+
+func writeContainerScanReports(config *containerExecuteScanOptions, scannerName string, result *imagescan.Result, utils containerExecuteScanUtils) []piperutils.Path {
+	reports := []piperutils.Path{}
+
+	scanReport := imagescan.CreateCustomReport(config.ScanImage, scannerName, config.DatabasePath, result, config.FailOnSeverities)
+	paths, err := imagescan.WriteCustomReports(scanReport, config.ScanImage, utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create custom HTML report")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	paths, err = imagescan.WriteSarifFile(scannerName, result.Findings, utils)
+	if err != nil {
+		log.Entry().WithError(err).Warning("failed to create SARIF file")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	sbom, err := imagescan.CreateCycloneSBOM(config.ScanImage, scannerName, result)
+	if err == nil {
+		paths, err = imagescan.WriteCycloneSBOM(sbom, utils)
+	}
+	if err != nil {
+		log.Entry().WithError(err).Warning("failed to create CycloneDX SBOM")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	return reports
+}
+
+// create toolrecord file for the container image scan
+func createToolRecordContainerScan(utils containerExecuteScanUtils, workspace string, config *containerExecuteScanOptions, scannerName string, result *imagescan.Result) (string, error) {
+	record := toolrecord.New(utils, workspace, "container_scan", config.ScanImage)
+	if err := record.AddKeyData("image", config.ScanImage, "Container image", ""); err != nil {
+		return "", err
+	}
+	if err := record.AddContext("scanner", scannerName); err != nil {
+		return "", err
+	}
+	if err := record.AddContext("operatingSystem", result.OS.String()); err != nil {
+		return "", err
+	}
+	if err := record.AddContext("packages", len(result.Packages)); err != nil {
+		return "", err
+	}
+	if err := record.AddContext("vulnerabilities", len(result.Findings)); err != nil {
+		return "", err
+	}
+	if err := record.Persist(); err != nil {
+		return "", err
+	}
+	return record.GetFileName(), nil
+}
@@ -0,0 +1,427 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/cobra"
+)
+
+type containerExecuteScanOptions struct {
+	ScanImage              string   `json:"scanImage,omitempty"`
+	ContainerRegistryURL   string   `json:"containerRegistryUrl,omitempty"`
+	DockerConfigJSON       string   `json:"dockerConfigJSON,omitempty"`
+	Scanner                string   `json:"scanner,omitempty" validate:"possible-values=offline"`
+	DatabasePath           string   `json:"databasePath,omitempty"`
+	FailOnSeverities       []string `json:"failOnSeverities,omitempty" validate:"possible-values=CRITICAL HIGH MEDIUM LOW UNKNOWN"`
+	ExcludeVulnerabilities []string `json:"excludeVulnerabilities,omitempty"`
+}
+
+type containerExecuteScanInflux struct {
+	step_data struct {
+		fields struct {
+			container_scan bool
+		}
+		tags struct {
+		}
+	}
+	container_scan_data struct {
+		fields struct {
+			packages                 int
+			vulnerabilities          int
+			critical_vulnerabilities int
+			high_vulnerabilities     int
+			medium_vulnerabilities   int
+			low_vulnerabilities      int
+			excluded_vulnerabilities int
+		}
+		tags struct {
+		}
+	}
+}
+
+func (i *containerExecuteScanInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "step_data", name: "container_scan", value: i.step_data.fields.container_scan},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "packages", value: i.container_scan_data.fields.packages},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "vulnerabilities", value: i.container_scan_data.fields.vulnerabilities},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "critical_vulnerabilities", value: i.container_scan_data.fields.critical_vulnerabilities},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "high_vulnerabilities", value: i.container_scan_data.fields.high_vulnerabilities},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "medium_vulnerabilities", value: i.container_scan_data.fields.medium_vulnerabilities},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "low_vulnerabilities", value: i.container_scan_data.fields.low_vulnerabilities},
+		{valType: config.InfluxField, measurement: "container_scan_data", name: "excluded_vulnerabilities", value: i.container_scan_data.fields.excluded_vulnerabilities},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Influx environment")
+	}
+}
+
+func (i *containerExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"container_scan": i.step_data.fields.container_scan,
+		},
+		"container_scan_data": {
+			"packages":                 i.container_scan_data.fields.packages,
+			"vulnerabilities":          i.container_scan_data.fields.vulnerabilities,
+			"critical_vulnerabilities": i.container_scan_data.fields.critical_vulnerabilities,
+			"high_vulnerabilities":     i.container_scan_data.fields.high_vulnerabilities,
+			"medium_vulnerabilities":   i.container_scan_data.fields.medium_vulnerabilities,
+			"low_vulnerabilities":      i.container_scan_data.fields.low_vulnerabilities,
+			"excluded_vulnerabilities": i.container_scan_data.fields.excluded_vulnerabilities,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
+type containerExecuteScanReports struct {
+}
+
+func (p *containerExecuteScanReports) persist(stepConfig containerExecuteScanOptions, gcpJsonKeyFilePath string, gcsBucketId string, gcsFolderPath string, gcsSubFolder string) {
+	if gcsBucketId == "" {
+		log.Entry().Info("persisting reports to GCS is disabled, because gcsBucketId is empty")
+		return
+	}
+	log.Entry().Info("Uploading reports to Google Cloud Storage...")
+	content := []gcs.ReportOutputParam{
+		{FilePattern: "**/piper_container_vulnerability_report.html", ParamRef: "", StepResultType: "container-scan"},
+		{FilePattern: "**/piper_container_vulnerability.sarif", ParamRef: "", StepResultType: "container-scan"},
+		{FilePattern: "**/piper_container_sbom.xml", ParamRef: "", StepResultType: "container-scan"},
+		{FilePattern: "**/toolrun_container_scan_*.json", ParamRef: "", StepResultType: "container-scan"},
+	}
+	envVars := []gcs.EnvVar{
+		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+	}
+	gcsClient, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+	if err != nil {
+		log.Entry().Errorf("creation of GCS client failed: %v", err)
+		return
+	}
+	defer gcsClient.Close()
+	structVal := reflect.ValueOf(&stepConfig).Elem()
+	inputParameters := map[string]string{}
+	for i := 0; i < structVal.NumField(); i++ {
+		field := structVal.Type().Field(i)
+		if field.Type.String() == "string" {
+			paramName := strings.Split(field.Tag.Get("json"), ",")
+			paramValue, _ := structVal.Field(i).Interface().(string)
+			inputParameters[paramName[0]] = paramValue
+		}
+	}
+	if err := gcs.PersistReportsToGCS(gcsClient, content, inputParameters, gcsFolderPath, gcsBucketId, gcsSubFolder, doublestar.Glob, os.Stat); err != nil {
+		log.Entry().Errorf("failed to persist reports: %v", err)
+	}
+}
+
+// ContainerExecuteScanCommand Scans a container image for known vulnerabilities of its operating system packages and language artifacts.
+func ContainerExecuteScanCommand() *cobra.Command {
+	const STEP_NAME = "containerExecuteScan"
+
+	metadata := containerExecuteScanMetadata()
+	var stepConfig containerExecuteScanOptions
+	var startTime time.Time
+	var influx containerExecuteScanInflux
+	var reports containerExecuteScanReports
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createContainerExecuteScanCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Scans a container image for known vulnerabilities of its operating system packages and language artifacts.",
+		Long: `This step downloads a container image and scans the content of all its layers for known vulnerabilities.
+The scan is performed by a pluggable scanner which is selected via [scanner](#scanner).
+
+The scanner ` + "`" + `offline` + "`" + ` does not require a scan backend nor a license and can therefore also be used in air-gapped environments.
+It creates an inventory of the image from
+
+* the package databases of the operating system: dpkg (Debian, Ubuntu), apk (Alpine), rpm in Berkeley DB, NDB and sqlite format (Rocky Linux, AlmaLinux, Azure Linux, Mariner) and the rpm manifest of distroless images
+* Java archives (` + "`" + `*.jar` + "`" + `, ` + "`" + `*.war` + "`" + `, ` + "`" + `*.ear` + "`" + ` including nested archives) via their ` + "`" + `pom.properties` + "`" + `
+* npm packages installed into ` + "`" + `node_modules` + "`" + `
+* Python packages installed as ` + "`" + `*.dist-info` + "`" + ` or ` + "`" + `*.egg-info` + "`" + `
+* the build information of Go binaries
+
+and matches it against a local snapshot of the [OSV database](https://osv.dev) configured via [databasePath](#databasepath).
+The packages of operating systems without an OSV ecosystem (e.g. Red Hat Enterprise Linux) are part of the inventory but cannot be matched against vulnerabilities, the step logs a warning in this case.
+
+The step creates an HTML report, a SARIF file, a CycloneDX SBOM and a toolrecord file and fails in case vulnerabilities with one of the configured severities are found.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+			log.RegisterSecret(stepConfig.DockerConfigJSON)
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
+				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			containerExecuteScan(stepConfig, &stepTelemetryData, &influx)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addContainerExecuteScanFlags(createContainerExecuteScanCmd, &stepConfig)
+	return createContainerExecuteScanCmd
+}
+
+func addContainerExecuteScanFlags(cmd *cobra.Command, stepConfig *containerExecuteScanOptions) {
+	cmd.Flags().StringVar(&stepConfig.ScanImage, "scanImage", os.Getenv("PIPER_scanImage"), "The reference to the container image to be scanned, e.g. `my-image:1.0.0`.")
+	cmd.Flags().StringVar(&stepConfig.ContainerRegistryURL, "containerRegistryUrl", os.Getenv("PIPER_containerRegistryUrl"), "Url of the container registry the image is pulled from - typically provided by the build step.")
+	cmd.Flags().StringVar(&stepConfig.DockerConfigJSON, "dockerConfigJSON", os.Getenv("PIPER_dockerConfigJSON"), "Path to the file `.docker/config.json` - this is typically provided by your CI/CD system. You can find more details about the Docker credentials in the [Docker documentation](https://docs.docker.com/engine/reference/commandline/login/).")
+	cmd.Flags().StringVar(&stepConfig.Scanner, "scanner", `offline`, "The scanner used to scan the image.")
+	cmd.Flags().StringVar(&stepConfig.DatabasePath, "databasePath", os.Getenv("PIPER_databasePath"), "For `scanner: offline`: Path to the local OSV database snapshot. This can be a directory containing OSV JSON files and/or zip archives or a single file.")
+	cmd.Flags().StringSliceVar(&stepConfig.FailOnSeverities, "failOnSeverities", []string{`CRITICAL`, `HIGH`}, "List of vulnerability severities which cause the step to fail. Set to an empty list in order to only report the vulnerabilities.")
+	cmd.Flags().StringSliceVar(&stepConfig.ExcludeVulnerabilities, "excludeVulnerabilities", []string{}, "List of vulnerability identifiers (OSV IDs or aliases like CVE identifiers) which are ignored, e.g. because they have been assessed as not relevant.")
+
+	cmd.MarkFlagRequired("scanImage")
+}
+
+// retrieve step metadata
+func containerExecuteScanMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "containerExecuteScan",
+			Aliases:     []config.Alias{},
+			Description: "Scans a container image for known vulnerabilities of its operating system packages and language artifacts.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Secrets: []config.StepSecrets{
+					{Name: "dockerConfigJsonCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing Docker config.json (with registry credential(s)). You can find more details about the Docker credentials in the [Docker documentation](https://docs.docker.com/engine/reference/commandline/login/).", Type: "jenkins"},
+				},
+				Parameters: []config.StepParameters{
+					{
+						Name: "scanImage",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "container/imageNameTag",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: true,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_scanImage"),
+					},
+					{
+						Name: "containerRegistryUrl",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "container/registryUrl",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_containerRegistryUrl"),
+					},
+					{
+						Name: "dockerConfigJSON",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/dockerConfigJSON",
+							},
+
+							{
+								Name: "dockerConfigJsonCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "dockerConfigFileVaultSecretName",
+								Type:    "vaultSecretFile",
+								Default: "docker-config",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_dockerConfigJSON"),
+					},
+					{
+						Name:        "scanner",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `offline`,
+					},
+					{
+						Name:        "databasePath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_databasePath"),
+					},
+					{
+						Name:        "failOnSeverities",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`CRITICAL`, `HIGH`},
+					},
+					{
+						Name:        "excludeVulnerabilities",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"name": "step_data", "fields": []map[string]string{{"name": "container_scan"}}},
+							{"name": "container_scan_data", "fields": []map[string]string{{"name": "packages"}, {"name": "vulnerabilities"}, {"name": "critical_vulnerabilities"}, {"name": "high_vulnerabilities"}, {"name": "medium_vulnerabilities"}, {"name": "low_vulnerabilities"}, {"name": "excluded_vulnerabilities"}}},
+						},
+					},
+					{
+						Name: "reports",
+						Type: "reports",
+						Parameters: []map[string]interface{}{
+							{"filePattern": "**/piper_container_vulnerability_report.html", "type": "container-scan"},
+							{"filePattern": "**/piper_container_vulnerability.sarif", "type": "container-scan"},
+							{"filePattern": "**/piper_container_sbom.xml", "type": "container-scan"},
+							{"filePattern": "**/toolrun_container_scan_*.json", "type": "container-scan"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
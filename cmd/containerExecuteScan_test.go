@@ -0,0 +1,152 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/imagescan"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const containerScanOpensslEntry = `{
+  "id": "DSA-5532-1",
+  "aliases": ["CVE-2023-5363"],
+  "affected": [{
+    "package": {"ecosystem": "Debian:12", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.11-1~deb12u2"}]}]
+  }],
+  "database_specific": {"severity": "HIGH"}
+}`
+
+type containerExecuteScanMockUtils struct {
+	*piperutils.Files
+	image       v1.Image
+	downloadErr error
+}
+
+func (u *containerExecuteScanMockUtils) DownloadImage(imageSource, targetFile string) (v1.Image, error) {
+	if u.downloadErr != nil {
+		return nil, u.downloadErr
+	}
+	ref, err := name.ParseReference(imageSource)
+	if err != nil {
+		return nil, err
+	}
+	return u.image, tarball.WriteToFile(targetFile, ref, u.image)
+}
+
+func (u *containerExecuteScanMockUtils) DownloadImageContent(imageSource, targetDir string) (v1.Image, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (u *containerExecuteScanMockUtils) GetRemoteImageInfo(imageSource string) (v1.Image, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func newContainerExecuteScanTestsUtils(t *testing.T) *containerExecuteScanMockUtils {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	require.NoError(t, os.MkdirAll("osv-db", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("osv-db", "DSA-5532-1.json"), []byte(containerScanOpensslEntry), 0644))
+
+	image, err := crane.Image(map[string][]byte{
+		"etc/os-release":      []byte("ID=debian\nVERSION_ID=\"12\"\n"),
+		"var/lib/dpkg/status": []byte("Package: libssl3\nStatus: install ok installed\nSource: openssl\nVersion: 3.0.11-1~deb12u1\n"),
+	})
+	require.NoError(t, err)
+	return &containerExecuteScanMockUtils{Files: &piperutils.Files{}, image: image}
+}
+
+func TestRunContainerExecuteScan(t *testing.T) {
+	t.Run("success case - vulnerabilities below threshold", func(t *testing.T) {
+		utils := newContainerExecuteScanTestsUtils(t)
+		config := containerExecuteScanOptions{
+			ScanImage:        "my-image:1.0.0",
+			Scanner:          imagescan.ScannerOffline,
+			DatabasePath:     "osv-db",
+			FailOnSeverities: []string{"CRITICAL"},
+		}
+		influx := containerExecuteScanInflux{}
+
+		err := runContainerExecuteScan(&config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, influx.container_scan_data.fields.packages)
+		assert.Equal(t, 1, influx.container_scan_data.fields.vulnerabilities)
+		assert.Equal(t, 1, influx.container_scan_data.fields.high_vulnerabilities)
+		for _, report := range []string{
+			filepath.Join(imagescan.ReportsDirectory, "piper_container_vulnerability_report.html"),
+			filepath.Join(imagescan.ReportsDirectory, "piper_container_vulnerability.sarif"),
+			filepath.Join(imagescan.ReportsDirectory, "piper_container_sbom.xml"),
+			filepath.Join("toolruns", "toolrun_container_scan_all.json"),
+		} {
+			assert.FileExists(t, report)
+		}
+	})
+
+	t.Run("success case - excluded vulnerability", func(t *testing.T) {
+		utils := newContainerExecuteScanTestsUtils(t)
+		config := containerExecuteScanOptions{
+			ScanImage:              "my-image:1.0.0",
+			Scanner:                imagescan.ScannerOffline,
+			DatabasePath:           "osv-db",
+			FailOnSeverities:       []string{"CRITICAL", "HIGH"},
+			ExcludeVulnerabilities: []string{"CVE-2023-5363"},
+		}
+		influx := containerExecuteScanInflux{}
+
+		err := runContainerExecuteScan(&config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, influx.container_scan_data.fields.vulnerabilities)
+		assert.Equal(t, 1, influx.container_scan_data.fields.excluded_vulnerabilities)
+	})
+
+	t.Run("error case - severe vulnerabilities", func(t *testing.T) {
+		utils := newContainerExecuteScanTestsUtils(t)
+		config := containerExecuteScanOptions{
+			ScanImage:        "my-image:1.0.0",
+			Scanner:          imagescan.ScannerOffline,
+			DatabasePath:     "osv-db",
+			FailOnSeverities: []string{"CRITICAL", "HIGH"},
+		}
+
+		err := runContainerExecuteScan(&config, utils, &containerExecuteScanInflux{})
+
+		assert.EqualError(t, err, "1 vulnerabilities with severity CRITICAL, HIGH found in image 'my-image:1.0.0'")
+	})
+
+	t.Run("error case - download failed", func(t *testing.T) {
+		utils := newContainerExecuteScanTestsUtils(t)
+		utils.downloadErr = fmt.Errorf("unauthorized")
+		config := containerExecuteScanOptions{ScanImage: "my-image:1.0.0", Scanner: imagescan.ScannerOffline, DatabasePath: "osv-db"}
+
+		err := runContainerExecuteScan(&config, utils, &containerExecuteScanInflux{})
+
+		assert.EqualError(t, err, "failed to download image 'my-image:1.0.0': unauthorized")
+	})
+
+	t.Run("error case - unsupported scanner", func(t *testing.T) {
+		utils := newContainerExecuteScanTestsUtils(t)
+		config := containerExecuteScanOptions{ScanImage: "my-image:1.0.0", Scanner: "other"}
+
+		err := runContainerExecuteScan(&config, utils, &containerExecuteScanInflux{})
+
+		assert.EqualError(t, err, "scanner 'other' is not supported")
+	})
+}
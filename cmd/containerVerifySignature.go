@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/signing"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+func containerVerifySignature(config containerVerifySignatureOptions, telemetryData *telemetry.CustomData) {
+	fileUtils := &piperutils.Files{}
+
+	err := runContainerVerifySignature(&config, fileUtils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runContainerVerifySignature(config *containerVerifySignatureOptions, fileUtils piperutils.FileUtils) error {
+	images := config.Images
+	if len(images) == 0 && len(config.ImageNameTags) > 0 {
+		var err error
+		if images, err = signing.ImageReferences(config.ContainerRegistryURL, config.ImageNameTags, config.ImageDigests); err != nil {
+			return err
+		}
+	}
+	if len(images) == 0 {
+		log.Entry().Warn("No images found, make sure the images have been pushed by kanikoExecute, cnbBuild or imagePushToRegistry")
+		return nil
+	}
+
+	if err := setRegistryDockerConfig(config.DockerConfigJSON, fileUtils); err != nil {
+		return err
+	}
+
+	return signing.VerifyImages(images, config.SignatureVerificationKey, fileUtils)
+}
@@ -0,0 +1,107 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/signing"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSigningTestRegistry starts an in-memory registry and pushes a random image as my-image:1.0.0
+// It returns the host of the registry and the digest of the image.
+func newSigningTestRegistry(t *testing.T) (string, string) {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	ref, err := name.ParseReference(host + "/my-image:1.0.0")
+	require.NoError(t, err)
+	img, err := random.Image(100, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return host, digest.String()
+}
+
+// addSigningTestKeys adds an unencrypted private key as cosign.key and the matching public key as cosign.pub
+func addSigningTestKeys(t *testing.T, fileUtils *mock.FilesMock) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	fileUtils.AddFile("cosign.key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey}))
+	fileUtils.AddFile("cosign.pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+}
+
+func TestRunContainerVerifySignature(t *testing.T) {
+	fileUtils := &mock.FilesMock{}
+	addSigningTestKeys(t, fileUtils)
+	signingConfig := signing.Config{SigningKey: "cosign.key", SignatureMode: signing.ModeTag}
+
+	t.Run("success case - images from commonPipelineEnvironment", func(t *testing.T) {
+		host, digest := newSigningTestRegistry(t)
+		require.NoError(t, signing.SignImages([]string{host + "/my-image@" + digest}, signingConfig, fileUtils))
+		config := containerVerifySignatureOptions{
+			ContainerRegistryURL:     "http://" + host,
+			ImageNameTags:            []string{"my-image:1.0.0"},
+			ImageDigests:             []string{digest},
+			SignatureVerificationKey: "cosign.pub",
+		}
+
+		err := runContainerVerifySignature(&config, fileUtils)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case - configured images", func(t *testing.T) {
+		host, _ := newSigningTestRegistry(t)
+		require.NoError(t, signing.SignImages([]string{host + "/my-image:1.0.0"}, signingConfig, fileUtils))
+		config := containerVerifySignatureOptions{
+			Images:                   []string{host + "/my-image:1.0.0"},
+			SignatureVerificationKey: "cosign.pub",
+		}
+
+		err := runContainerVerifySignature(&config, fileUtils)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case - no images", func(t *testing.T) {
+		config := containerVerifySignatureOptions{SignatureVerificationKey: "cosign.pub"}
+
+		err := runContainerVerifySignature(&config, fileUtils)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error case - unsigned image", func(t *testing.T) {
+		host, _ := newSigningTestRegistry(t)
+		config := containerVerifySignatureOptions{
+			Images:                   []string{host + "/my-image:1.0.0"},
+			SignatureVerificationKey: "cosign.pub",
+		}
+
+		err := runContainerVerifySignature(&config, fileUtils)
+
+		assert.EqualError(t, err, "signature verification failed for images: "+host+"/my-image:1.0.0")
+	})
+}
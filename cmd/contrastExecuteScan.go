@@ -10,6 +10,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/triage"
 	"github.com/pkg/errors"
 )
 
@@ -83,6 +84,14 @@ func runContrastExecuteScan(config *contrastExecuteScanOptions, telemetryData *t
 		return nil, err
 	}
 
+	if config.AssessmentSync != triage.None {
+		auditor := contrast.NewTriageAuditor(config.Server, config.OrganizationID, config.ApplicationID, config.UserAPIKey, auth)
+		if _, err := triage.Run(auditor, config.AssessmentFile, config.AssessmentSync, utils); err != nil {
+			log.Entry().Errorf("error while synchronizing assessments")
+			return nil, err
+		}
+	}
+
 	findings, err := contrastInstance.GetVulnerabilities()
 	if err != nil {
 		log.Entry().Errorf("error while getting vulns")
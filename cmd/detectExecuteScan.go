@@ -15,7 +15,6 @@ import (
 	bd "github.com/SAP/jenkins-library/pkg/blackduck"
 	"github.com/SAP/jenkins-library/pkg/command"
 	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
-	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/golang"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
@@ -24,6 +23,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/orchestrator"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/scm"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
 	"github.com/SAP/jenkins-library/pkg/versioning"
@@ -71,6 +71,17 @@ func (d *detectUtilsBundle) GetSearchService() *github.SearchService {
 	return d.search
 }
 
+func detectScmOptions(config detectExecuteScanOptions) scm.Options {
+	return scm.Options{
+		Provider:     config.ScmProvider,
+		APIURL:       config.GithubAPIURL,
+		Token:        config.GithubToken,
+		Owner:        config.Owner,
+		Repository:   config.Repository,
+		TrustedCerts: config.CustomTLSCertificateLinks,
+	}
+}
+
 func (d *detectUtilsBundle) GetProvider() orchestrator.ConfigProvider {
 	return d.provider
 }
@@ -147,12 +158,7 @@ const configPath = ".pipeline/*"
 func detectExecuteScan(config detectExecuteScanOptions, _ *telemetry.CustomData, influx *detectExecuteScanInflux) {
 	influx.step_data.fields.detect = false
 
-	ctx, client, err := piperGithub.
-		NewClientBuilder(config.GithubToken, config.GithubAPIURL).
-		WithTrustedCerts(config.CustomTLSCertificateLinks).Build()
-	if err != nil {
-		log.Entry().WithError(err).Warning("Failed to get GitHub client")
-	}
+	ctx, client := newScanGitHubClient(config.ScmProvider, config.GithubToken, config.GithubAPIURL, config.CustomTLSCertificateLinks)
 
 	// Log config and workspace content for debug purpose
 	if log.IsVerbose() {
@@ -756,15 +762,14 @@ func postScanChecksAndReporting(ctx context.Context, config detectExecuteScanOpt
 			log.Entry().Warning("Couldn't read file of report of rapid scan, error: ", err)
 			return nil
 		}
-		_, _, err = utils.GetIssueService().CreateComment(ctx,
-			config.Owner,
-			config.Repository,
-			issueNumber,
-			&github.IssueComment{
-				Body: &commentBody,
-			})
+		scmProvider, err := newScanResultProvider(detectScmOptions(config), utils.GetIssueService(), utils.GetSearchService())
 		if err != nil {
-			log.Entry().Warning("Can send request to github ", err)
+			log.Entry().Warning("Can not create SCM provider ", err)
+			return nil
+		}
+		// the comment is updated on subsequent scans of the pull request
+		if err := scmProvider.UpsertPullRequestComment(ctx, issueNumber, "detectExecuteScan", commentBody); err != nil {
+			log.Entry().Warning("Can not comment on pull request ", err)
 			return nil
 		}
 
@@ -786,15 +791,14 @@ func postScanChecksAndReporting(ctx context.Context, config detectExecuteScanOpt
 		log.Entry().Debugf("Creating result issues for %v alert(s)", len(vulns.Items))
 		issueDetails := make([]reporting.IssueDetail, len(vulns.Items))
 		piperutils.CopyAtoB(vulns.Items, issueDetails)
-		gh := reporting.GitHub{
-			Owner:         &config.Owner,
-			Repository:    &config.Repository,
-			Assignees:     &config.Assignees,
-			IssueService:  utils.GetIssueService(),
-			SearchService: utils.GetSearchService(),
-		}
-		if err := gh.UploadMultipleReports(ctx, &issueDetails); err != nil {
+		scmProvider, err := newScanResultProvider(detectScmOptions(config), utils.GetIssueService(), utils.GetSearchService())
+		if err != nil {
 			errorsOccured = append(errorsOccured, fmt.Sprint(err))
+		} else {
+			issues := reporting.SCM{Provider: scmProvider, Assignees: config.Assignees}
+			if err := issues.UploadMultipleReports(ctx, &issueDetails); err != nil {
+				errorsOccured = append(errorsOccured, fmt.Sprint(err))
+			}
 		}
 	}
 
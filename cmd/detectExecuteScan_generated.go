@@ -63,6 +63,7 @@ type detectExecuteScanOptions struct {
 	GithubAPIURL                    string   `json:"githubApiUrl,omitempty"`
 	Owner                           string   `json:"owner,omitempty"`
 	Repository                      string   `json:"repository,omitempty"`
+	ScmProvider                     string   `json:"scmProvider,omitempty" validate:"possible-values=github gitlab bitbucket azure"`
 	Assignees                       []string `json:"assignees,omitempty"`
 	CustomTLSCertificateLinks       []string `json:"customTlsCertificateLinks,omitempty"`
 	FailOnSevereVulnerabilities     bool     `json:"failOnSevereVulnerabilities,omitempty"`
@@ -129,6 +130,23 @@ func (i *detectExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *detectExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"detect": i.step_data.fields.detect,
+		},
+		"detect_data": {
+			"vulnerabilities":       i.detect_data.fields.vulnerabilities,
+			"major_vulnerabilities": i.detect_data.fields.major_vulnerabilities,
+			"minor_vulnerabilities": i.detect_data.fields.minor_vulnerabilities,
+			"components":            i.detect_data.fields.components,
+			"policy_violations":     i.detect_data.fields.policy_violations,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type detectExecuteScanReports struct {
 }
 
@@ -251,9 +269,12 @@ Please configure your BlackDuck server Url using the serverUrl parameter and the
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -340,6 +361,7 @@ func addDetectExecuteScanFlags(cmd *cobra.Command, stepConfig *detectExecuteScan
 	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API URL.")
 	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Set the GitHub organization.")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Set the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.ScmProvider, "scmProvider", `github`, "SCM hosting the repository, result issues and pull request comments are created there. `githubApiUrl`, `githubToken`, `owner` and `repository` are used for the selected SCM, e.g. `githubApiUrl` is the GitLab API URL, `owner` the Bitbucket project key or the Azure DevOps project.")
 	cmd.Flags().StringSliceVar(&stepConfig.Assignees, "assignees", []string{``}, "Defines the assignees for the Github Issue created/updated with the results of the scan as a list of login names.")
 	cmd.Flags().StringSliceVar(&stepConfig.CustomTLSCertificateLinks, "customTlsCertificateLinks", []string{}, "List of download links to custom TLS certificates. This is required to ensure trusted connections to instances with repositories (like nexus) when publish flag is set to true.")
 	cmd.Flags().BoolVar(&stepConfig.FailOnSevereVulnerabilities, "failOnSevereVulnerabilities", true, "Whether to fail the step on severe vulnerabilties or not")
@@ -783,6 +805,15 @@ func detectExecuteScanMetadata() config.StepData {
 						Aliases:   []config.Alias{{Name: "githubRepo"}},
 						Default:   os.Getenv("PIPER_repository"),
 					},
+					{
+						Name:        "scmProvider",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `github`,
+					},
 					{
 						Name:        "assignees",
 						ResourceRef: []config.ResourceReference{},
@@ -31,8 +31,10 @@ import (
 	"github.com/SAP/jenkins-library/pkg/maven"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/scm"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
+	"github.com/SAP/jenkins-library/pkg/triage"
 	"github.com/SAP/jenkins-library/pkg/versioning"
 
 	piperGithub "github.com/SAP/jenkins-library/pkg/github"
@@ -114,10 +116,7 @@ var execInPath = exec.LookPath
 
 func fortifyExecuteScan(config fortifyExecuteScanOptions, telemetryData *telemetry.CustomData, influx *fortifyExecuteScanInflux) {
 	// TODO provide parameter for trusted certs
-	ctx, client, err := piperGithub.NewClientBuilder(config.GithubToken, config.GithubAPIURL).Build()
-	if err != nil {
-		log.Entry().WithError(err).Warning("Failed to get GitHub client")
-	}
+	ctx, client := newScanGitHubClient(config.ScmProvider, config.GithubToken, config.GithubAPIURL, nil)
 	auditStatus := map[string]string{}
 	sys := fortify.NewSystemInstance(config.ServerURL, config.APIEndpoint, config.AuthToken, config.Proxy, time.Minute*15)
 	utils := newFortifyUtilsBundle(client)
@@ -199,6 +198,17 @@ func runFortifyScan(ctx context.Context, config fortifyExecuteScanOptions, sys f
 		return reports, fmt.Errorf("Failed to load project version %v: %w", fortifyProjectVersion, err)
 	}
 
+	var prScan *pullRequestScan
+	if config.PullRequestScan {
+		prScan, err = newPullRequestScan(".")
+		if err != nil {
+			return reports, fmt.Errorf("Failed to determine pull request: %w", err)
+		}
+		if prScan != nil && len(config.PullRequestName) == 0 {
+			config.PullRequestName = fmt.Sprintf("PR-%v", prScan.number)
+		}
+	}
+
 	if len(config.PullRequestName) > 0 {
 		fortifyProjectVersion = config.PullRequestName
 		projectVersion, err = sys.LookupOrCreateProjectVersionDetailsForPullRequest(project.ID, projectVersion, fortifyProjectVersion)
@@ -241,6 +251,9 @@ func runFortifyScan(ctx context.Context, config fortifyExecuteScanOptions, sys f
 	}
 
 	if config.VerifyOnly {
+		if err := syncFortifyAssessments(config, sys, utils, projectVersion.ID); err != nil {
+			return reports, err
+		}
 		log.Entry().Infof("Starting audit status check on project %v with version %v and project version ID %v", fortifyProjectName, fortifyProjectVersion, projectVersion.ID)
 		paths, err := verifyFFProjectCompliance(ctx, config, utils, sys, project, projectVersion, filterSet, influx, auditStatus)
 		reports = append(reports, paths...)
@@ -248,7 +261,12 @@ func runFortifyScan(ctx context.Context, config fortifyExecuteScanOptions, sys f
 	}
 
 	log.Entry().Infof("Scanning and uploading to project %v with version %v and projectVersionId %v", fortifyProjectName, fortifyProjectVersion, projectVersion.ID)
-	buildLabel := fmt.Sprintf("%v/repos/%v/%v/commits/%v", config.GithubAPIURL, config.Owner, config.Repository, config.CommitID)
+	buildLabel := scm.CommitURL(scm.Options{
+		Provider:   config.ScmProvider,
+		APIURL:     config.GithubAPIURL,
+		Owner:      config.Owner,
+		Repository: config.Repository,
+	}, config.CommitID)
 
 	// Create sourceanalyzer command based on configuration
 	buildID := uuid.New().String()
@@ -310,6 +328,10 @@ func runFortifyScan(ctx context.Context, config fortifyExecuteScanOptions, sys f
 		return reports, err
 	}
 
+	if err := syncFortifyAssessments(config, sys, utils, projectVersion.ID); err != nil {
+		return reports, err
+	}
+
 	// SARIF conversion done after latest FPR is processed, but before the compliance is checked
 	if config.ConvertToSarif {
 		resultFilePath := fmt.Sprintf("%vtarget/result.fpr", config.ModulePath)
@@ -333,12 +355,99 @@ func runFortifyScan(ctx context.Context, config fortifyExecuteScanOptions, sys f
 		reports = append(reports, paths...)
 	}
 
+	if prScan != nil {
+		log.Entry().Infof("Checking new issues of pull request %v in project version %v with ID %v", prScan.number, fortifyProjectVersion, projectVersion.ID)
+		return reports, verifyFFPullRequestCompliance(ctx, config, sys, project, projectVersion, prScan, influx)
+	}
+
 	log.Entry().Infof("Starting audit status check on project %v with version %v and project version ID %v", fortifyProjectName, fortifyProjectVersion, projectVersion.ID)
 	paths, err := verifyFFProjectCompliance(ctx, config, utils, sys, project, projectVersion, filterSet, influx, auditStatus)
 	reports = append(reports, paths...)
 	return reports, err
 }
 
+// syncFortifyAssessments synchronizes the assessment file with the suppressions of the project version's issues
+func syncFortifyAssessments(config fortifyExecuteScanOptions, sys fortify.System, utils fortifyUtils, projectVersionID int64) error {
+	if config.AssessmentSync == triage.None {
+		return nil
+	}
+	log.Entry().Infof("Synchronizing assessments of '%v' with project version ID %v", config.AssessmentFile, projectVersionID)
+	_, err := triage.Run(fortify.NewTriageAuditor(sys, projectVersionID), config.AssessmentFile, config.AssessmentSync, utils)
+	return errors.Wrap(err, "failed to synchronize assessments")
+}
+
+// verifyFFPullRequestCompliance checks for new issues in the lines changed by the pull request instead of the audit status of the project version
+func verifyFFPullRequestCompliance(ctx context.Context, config fortifyExecuteScanOptions, sys fortify.System, project *models.Project, projectVersion *models.ProjectVersion, prScan *pullRequestScan, influx *fortifyExecuteScanInflux) error {
+	issues, err := sys.GetAllIssueDetails(projectVersion.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch issues of project version ID %v", projectVersion.ID)
+	}
+	findings := prScan.newFindings(config.ModulePath, pullRequestFindingsFortify(config, projectVersion.ID, issues))
+	log.Entry().Infof("Found %v new issues in the lines changed by pull request %v", len(findings), prScan.number)
+	for _, finding := range findings {
+		log.Entry().Warnf("%v %v in %v:%v", finding.Severity, finding.Category, finding.File, finding.Line)
+	}
+
+	influx.fortify_data.fields.projectID = project.ID
+	influx.fortify_data.fields.projectName = *project.Name
+	influx.fortify_data.fields.projectVersion = *projectVersion.Name
+	influx.fortify_data.fields.projectVersionID = projectVersion.ID
+	influx.fortify_data.fields.violations = len(findings)
+
+	if len(config.GithubToken) > 0 && len(config.GithubAPIURL) > 0 && len(config.Owner) > 0 && len(config.Repository) > 0 {
+		provider, err := scm.NewProvider(scm.Options{
+			Provider:   config.ScmProvider,
+			APIURL:     config.GithubAPIURL,
+			Token:      config.GithubToken,
+			Owner:      config.Owner,
+			Repository: config.Repository,
+		})
+		if err != nil {
+			return err
+		}
+		prScan.comment(ctx, provider, "fortifyExecuteScan", findings)
+	}
+
+	if len(findings) > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("fortify scan failed, the pull request introduces %v new issues. For details check the pull request comments or the log", len(findings))
+	}
+	return nil
+}
+
+// pullRequestFindingsFortify returns the issues found by the latest scan with one of the configured priorities
+func pullRequestFindingsFortify(config fortifyExecuteScanOptions, projectVersionID int64, issues []*models.ProjectVersionIssue) []reporting.CodeFinding {
+	findings := []reporting.CodeFinding{}
+	for _, issue := range issues {
+		if issue.Removed != nil && *issue.Removed || issue.Suppressed != nil && *issue.Suppressed {
+			continue
+		}
+		// issues already known from the master branch version the pull request version is based on are updated by the scan, not new
+		if issue.ScanStatus == nil || (*issue.ScanStatus != "NEW" && *issue.ScanStatus != "REINTRODUCED") {
+			continue
+		}
+		if issue.Friority == nil || !containsSeverity(config.PullRequestSeverities, *issue.Friority) || issue.FullFileName == nil || issue.LineNumber == nil {
+			continue
+		}
+		findings = append(findings, reporting.CodeFinding{
+			ID:       stringValueOrEmpty(issue.IssueInstanceID),
+			Category: stringValueOrEmpty(issue.IssueName),
+			Severity: *issue.Friority,
+			File:     *issue.FullFileName,
+			Line:     int(*issue.LineNumber),
+			URL:      fmt.Sprintf("%v/html/ssc/index.jsp#!/version/%v/audit?q=%%5Binstance%%20id%%5D%%3A%v", config.ServerURL, projectVersionID, stringValueOrEmpty(issue.IssueInstanceID)),
+		})
+	}
+	return findings
+}
+
+func stringValueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
 func classifyErrorOnLookup(err error) {
 	if strings.Contains(err.Error(), "connect: connection refused") || strings.Contains(err.Error(), "net/http: TLS handshake timeout") {
 		log.SetErrorCategory(log.ErrorService)
@@ -395,19 +504,23 @@ func verifyFFProjectCompliance(ctx context.Context, config fortifyExecuteScanOpt
 	}
 	reports = append(reports, paths...)
 
-	log.Entry().Debug("Checking whether GitHub issue creation/update is active")
+	log.Entry().Debug("Checking whether issue creation/update is active")
 	log.Entry().Debugf("%v, %v, %v, %v, %v, %v", config.CreateResultIssue, numberOfViolations > 0, len(config.GithubToken) > 0, len(config.GithubAPIURL) > 0, len(config.Owner) > 0, len(config.Repository) > 0)
 	if config.CreateResultIssue && numberOfViolations > 0 && len(config.GithubToken) > 0 && len(config.GithubAPIURL) > 0 && len(config.Owner) > 0 && len(config.Repository) > 0 {
-		log.Entry().Debug("Creating/updating GitHub issue with scan results")
-		gh := reporting.GitHub{
-			Owner:         &config.Owner,
-			Repository:    &config.Repository,
-			Assignees:     &config.Assignees,
-			IssueService:  utils.GetIssueService(),
-			SearchService: utils.GetSearchService(),
+		log.Entry().Debug("Creating/updating issue with scan results")
+		provider, err := newScanResultProvider(scm.Options{
+			Provider:   config.ScmProvider,
+			APIURL:     config.GithubAPIURL,
+			Token:      config.GithubToken,
+			Owner:      config.Owner,
+			Repository: config.Repository,
+		}, utils.GetIssueService(), utils.GetSearchService())
+		if err != nil {
+			return reports, err
 		}
-		if err := gh.UploadSingleReport(ctx, scanReport); err != nil {
-			return reports, fmt.Errorf("failed to upload scan results into GitHub: %w", err)
+		issues := reporting.SCM{Provider: provider, Assignees: config.Assignees}
+		if err := issues.UploadSingleReport(ctx, scanReport); err != nil {
+			return reports, fmt.Errorf("failed to upload scan results: %w", err)
 		}
 	}
 
@@ -1115,17 +1228,19 @@ func scanProject(config *fortifyExecuteScanOptions, command fortifyUtils, buildI
 
 func determinePullRequestMerge(config fortifyExecuteScanOptions) (string, string) {
 	author := ""
-	// TODO provide parameter for trusted certs
-	ctx, client, err := piperGithub.NewClientBuilder(config.GithubToken, config.GithubAPIURL).Build()
-	if err == nil && ctx != nil && client != nil {
-		prID, author, err := determinePullRequestMergeGithub(ctx, config, client.PullRequests)
-		if err != nil {
-			log.Entry().WithError(err).Warn("Failed to get PR metadata via GitHub client")
+	if scm.IsGitHub(config.ScmProvider) {
+		// TODO provide parameter for trusted certs
+		ctx, client, err := piperGithub.NewClientBuilder(config.GithubToken, config.GithubAPIURL).Build()
+		if err == nil && ctx != nil && client != nil {
+			prID, author, err := determinePullRequestMergeGithub(ctx, config, client.PullRequests)
+			if err != nil {
+				log.Entry().WithError(err).Warn("Failed to get PR metadata via GitHub client")
+			} else {
+				return prID, author
+			}
 		} else {
-			return prID, author
+			log.Entry().WithError(err).Warn("Failed to instantiate GitHub client to get PR metadata")
 		}
-	} else {
-		log.Entry().WithError(err).Warn("Failed to instantiate GitHub client to get PR metadata")
 	}
 
 	log.Entry().Infof("Trying to determine PR ID in commit message: %v", config.CommitMessage)
@@ -46,6 +46,7 @@ type fortifyExecuteScanOptions struct {
 	GithubAPIURL                    string   `json:"githubApiUrl,omitempty"`
 	Owner                           string   `json:"owner,omitempty"`
 	Repository                      string   `json:"repository,omitempty"`
+	ScmProvider                     string   `json:"scmProvider,omitempty" validate:"possible-values=github gitlab bitbucket azure"`
 	Memory                          string   `json:"memory,omitempty"`
 	UpdateRulePack                  bool     `json:"updateRulePack,omitempty"`
 	ReportDownloadEndpoint          string   `json:"reportDownloadEndpoint,omitempty"`
@@ -75,6 +76,10 @@ type fortifyExecuteScanOptions struct {
 	ReportTemplateID                int      `json:"reportTemplateId,omitempty"`
 	FilterSetTitle                  string   `json:"filterSetTitle,omitempty"`
 	PullRequestName                 string   `json:"pullRequestName,omitempty"`
+	PullRequestScan                 bool     `json:"pullRequestScan,omitempty"`
+	PullRequestSeverities           []string `json:"pullRequestSeverities,omitempty"`
+	AssessmentFile                  string   `json:"assessmentFile,omitempty"`
+	AssessmentSync                  string   `json:"assessmentSync,omitempty" validate:"possible-values=none push pull sync"`
 	PullRequestMessageRegex         string   `json:"pullRequestMessageRegex,omitempty"`
 	BuildTool                       string   `json:"buildTool,omitempty"`
 	ProjectSettingsFile             string   `json:"projectSettingsFile,omitempty"`
@@ -155,6 +160,33 @@ func (i *fortifyExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *fortifyExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"fortify": i.step_data.fields.fortify,
+		},
+		"fortify_data": {
+			"projectID":         i.fortify_data.fields.projectID,
+			"projectName":       i.fortify_data.fields.projectName,
+			"projectVersion":    i.fortify_data.fields.projectVersion,
+			"projectVersionId":  i.fortify_data.fields.projectVersionID,
+			"violations":        i.fortify_data.fields.violations,
+			"corporateTotal":    i.fortify_data.fields.corporateTotal,
+			"corporateAudited":  i.fortify_data.fields.corporateAudited,
+			"auditAllTotal":     i.fortify_data.fields.auditAllTotal,
+			"auditAllAudited":   i.fortify_data.fields.auditAllAudited,
+			"spotChecksTotal":   i.fortify_data.fields.spotChecksTotal,
+			"spotChecksAudited": i.fortify_data.fields.spotChecksAudited,
+			"spotChecksGap":     i.fortify_data.fields.spotChecksGap,
+			"suspicious":        i.fortify_data.fields.suspicious,
+			"exploitable":       i.fortify_data.fields.exploitable,
+			"suppressed":        i.fortify_data.fields.suppressed,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type fortifyExecuteScanReports struct {
 }
 
@@ -281,9 +313,12 @@ Besides triggering a scan the step verifies the results after they have been upl
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -353,6 +388,7 @@ func addFortifyExecuteScanFlags(cmd *cobra.Command, stepConfig *fortifyExecuteSc
 	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API URL.")
 	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Set the GitHub organization.")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Set the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.ScmProvider, "scmProvider", `github`, "SCM hosting the repository, result issues and pull request comments are created there. `githubApiUrl`, `githubToken`, `owner` and `repository` are used for the selected SCM, e.g. `githubApiUrl` is the GitLab API URL, `owner` the Bitbucket project key or the Azure DevOps project.")
 	cmd.Flags().StringVar(&stepConfig.Memory, "memory", `-Xmx4G -Xms512M`, "The amount of memory granted to the translate/scan executions")
 	cmd.Flags().BoolVar(&stepConfig.UpdateRulePack, "updateRulePack", true, "Whether the rule pack shall be updated and pulled from Fortify SSC before scanning or not")
 	cmd.Flags().StringVar(&stepConfig.ReportDownloadEndpoint, "reportDownloadEndpoint", `/transfer/reportDownload.html`, "Fortify SSC endpoint for Report downloads")
@@ -382,6 +418,10 @@ func addFortifyExecuteScanFlags(cmd *cobra.Command, stepConfig *fortifyExecuteSc
 	cmd.Flags().IntVar(&stepConfig.ReportTemplateID, "reportTemplateId", 18, "Report template ID to be used for generating the Fortify report")
 	cmd.Flags().StringVar(&stepConfig.FilterSetTitle, "filterSetTitle", `SAP`, "Title of the filter set to use for analysing the results")
 	cmd.Flags().StringVar(&stepConfig.PullRequestName, "pullRequestName", os.Getenv("PIPER_pullRequestName"), "The name of the pull request branch which will trigger creation of a new version in Fortify SSC based on the master branch version")
+	cmd.Flags().BoolVar(&stepConfig.PullRequestScan, "pullRequestScan", false, "Scans pull requests into a project version of the pull request based on the master branch version and only reports new findings in the lines changed by the pull request. The findings are commented on the pull request via `scmProvider` if `githubToken` is available and the audit status check is replaced by a check for new findings. The pull request is determined via the orchestrator, the history of its target branch needs to be fetched.")
+	cmd.Flags().StringSliceVar(&stepConfig.PullRequestSeverities, "pullRequestSeverities", []string{`Critical`, `High`}, "Friorities of the new findings which are reported in `pullRequestScan` mode.")
+	cmd.Flags().StringVar(&stepConfig.AssessmentFile, "assessmentFile", `hs-assessments.yaml`, "Path to the assessment YAML file which records triage decisions of findings as `ignore` entries with `tool: fortify`. Used by `assessmentSync`.")
+	cmd.Flags().StringVar(&stepConfig.AssessmentSync, "assessmentSync", `none`, "Synchronizes the assessments of `assessmentFile` with the suppression of the Fortify issues before the results are evaluated. `push` applies the assessments of the file, `pull` adds and updates the file with the assessments made in the scanner and `sync` pushes the file and pulls only assessments missing in the file. Pulled assessments need to be committed to the repository.")
 	cmd.Flags().StringVar(&stepConfig.PullRequestMessageRegex, "pullRequestMessageRegex", `.*Merge pull request #(\\d+) from.*`, "Regex used to identify the PR-XXX reference within the merge commit message")
 	cmd.Flags().StringVar(&stepConfig.BuildTool, "buildTool", `maven`, "Scan type used for the step which can be `'maven'`, `'pip'` or `'gradle'`")
 	cmd.Flags().StringVar(&stepConfig.ProjectSettingsFile, "projectSettingsFile", os.Getenv("PIPER_projectSettingsFile"), "Path to the mvn settings file that should be used as project settings file.")
@@ -694,6 +734,15 @@ func fortifyExecuteScanMetadata() config.StepData {
 						Aliases:   []config.Alias{{Name: "githubRepo"}},
 						Default:   os.Getenv("PIPER_repository"),
 					},
+					{
+						Name:        "scmProvider",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `github`,
+					},
 					{
 						Name:        "memory",
 						ResourceRef: []config.ResourceReference{},
@@ -956,6 +1005,42 @@ func fortifyExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_pullRequestName"),
 					},
+					{
+						Name:        "pullRequestScan",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "pullRequestSeverities",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`Critical`, `High`},
+					},
+					{
+						Name:        "assessmentFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `hs-assessments.yaml`,
+					},
+					{
+						Name:        "assessmentSync",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `none`,
+					},
 					{
 						Name:        "pullRequestMessageRegex",
 						ResourceRef: []config.ResourceReference{},
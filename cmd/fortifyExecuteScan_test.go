@@ -22,6 +22,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/fortify"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/SAP/jenkins-library/pkg/versioning"
 
 	"github.com/google/go-github/v45/github"
@@ -345,6 +346,10 @@ func (f *fortifyMock) GetIssueComments(parentId int64) ([]*models.IssueAuditComm
 	return []*models.IssueAuditComment{{Comment: &comment}}, nil
 }
 
+func (f *fortifyMock) AuditIssue(projectVersionID int64, issue *models.ProjectVersionIssue, suppressed bool, comment string) error {
+	return nil
+}
+
 func (f *fortifyMock) UploadResultFile(endpoint, file string, projectVersionID int64) error {
 	return nil
 }
@@ -633,6 +638,78 @@ func TestAnalyseUnauditedIssues(t *testing.T) {
 	assert.Equal(t, 3, len(spotChecksCountByCategory))
 }
 
+func TestPullRequestFindingsFortify(t *testing.T) {
+	config := fortifyExecuteScanOptions{ServerURL: "https://fortify.example.com", PullRequestSeverities: []string{"Critical", "High"}}
+	issue := func(id, scanStatus, friority string, suppressed bool) *models.ProjectVersionIssue {
+		name := "SQL Injection"
+		file := "src/main/java/Db.java"
+		line := int32(12)
+		return &models.ProjectVersionIssue{IssueInstanceID: &id, IssueName: &name, ScanStatus: &scanStatus, Friority: &friority, Suppressed: &suppressed, FullFileName: &file, LineNumber: &line}
+	}
+	issues := []*models.ProjectVersionIssue{
+		issue("new", "NEW", "Critical", false),
+		issue("updated", "UPDATED", "Critical", false),
+		issue("suppressed", "NEW", "High", true),
+		issue("low", "NEW", "Low", false),
+		issue("reintroduced", "REINTRODUCED", "High", false),
+	}
+
+	findings := pullRequestFindingsFortify(config, 4711, issues)
+
+	assert.Equal(t, []reporting.CodeFinding{
+		{ID: "new", Category: "SQL Injection", Severity: "Critical", File: "src/main/java/Db.java", Line: 12, URL: "https://fortify.example.com/html/ssc/index.jsp#!/version/4711/audit?q=%5Binstance%20id%5D%3Anew"},
+		{ID: "reintroduced", Category: "SQL Injection", Severity: "High", File: "src/main/java/Db.java", Line: 12, URL: "https://fortify.example.com/html/ssc/index.jsp#!/version/4711/audit?q=%5Binstance%20id%5D%3Areintroduced"},
+	}, findings)
+}
+
+func TestSyncFortifyAssessments(t *testing.T) {
+	assessments := []byte(`ignore:
+  - tool: fortify
+    vulnerability: 7F3A
+    status: notRelevant
+    analysis: falsePositive
+`)
+
+	t.Run("disabled", func(t *testing.T) {
+		utils := newFortifyTestUtilsBundle()
+		config := fortifyExecuteScanOptions{AssessmentFile: "hs-assessments.yaml", AssessmentSync: "none"}
+		assert.NoError(t, syncFortifyAssessments(config, &fortifyMock{}, &utils, 4711))
+	})
+
+	t.Run("push unknown issue", func(t *testing.T) {
+		utils := newFortifyTestUtilsBundle()
+		utils.AddFile("hs-assessments.yaml", assessments)
+		config := fortifyExecuteScanOptions{AssessmentFile: "hs-assessments.yaml", AssessmentSync: "push"}
+		assert.NoError(t, syncFortifyAssessments(config, &fortifyMock{}, &utils, 4711))
+		assert.False(t, utils.HasWrittenFile("hs-assessments.yaml"))
+	})
+
+	t.Run("invalid direction", func(t *testing.T) {
+		utils := newFortifyTestUtilsBundle()
+		config := fortifyExecuteScanOptions{AssessmentFile: "hs-assessments.yaml", AssessmentSync: "both"}
+		assert.EqualError(t, syncFortifyAssessments(config, &fortifyMock{}, &utils, 4711), "failed to synchronize assessments: invalid assessment sync direction 'both', supported are none, push, pull and sync")
+	})
+}
+
+func TestVerifyFFPullRequestCompliance(t *testing.T) {
+	config := fortifyExecuteScanOptions{PullRequestSeverities: []string{"High"}}
+	sys := &fortifyMock{}
+	projectName := "theProject"
+	versionName := "PR-42"
+	project := &models.Project{ID: 64, Name: &projectName}
+	projectVersion := &models.ProjectVersion{ID: 4711, Name: &versionName}
+	influx := fortifyExecuteScanInflux{}
+
+	t.Run("no new issues", func(t *testing.T) {
+		// the issues of the mock have no scan status
+		err := verifyFFPullRequestCompliance(context.Background(), config, sys, project, projectVersion, &pullRequestScan{number: 42}, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PR-42", influx.fortify_data.fields.projectVersion)
+		assert.Equal(t, 0, influx.fortify_data.fields.violations)
+	})
+}
+
 func TestAnalyseUnauditedIssuesWithWrongConfig(t *testing.T) {
 	config := fortifyExecuteScanOptions{SpotCheckMinimumUnit: "float"}
 	spotChecksCountByCategory := []fortify.SpotChecksAuditCount{}
@@ -892,6 +969,16 @@ func TestDeterminePullRequestMerge(t *testing.T) {
 		assert.Equal(t, "0", match, "Expected different result")
 		assert.Equal(t, "", authorString, "Expected different result")
 	})
+
+	t.Run("other SCM", func(t *testing.T) {
+		config := config
+		config.ScmProvider = "gitlab"
+		config.GithubAPIURL = "https://gitlab.example.com/api/v4"
+		config.CommitMessage = "Merge pull request #2462 from branch f-test"
+		match, authorString := determinePullRequestMerge(config)
+		assert.Equal(t, "2462", match, "Expected different result")
+		assert.Equal(t, "", authorString, "Expected different result")
+	})
 }
 
 func TestDeterminePullRequestMergeGithub(t *testing.T) {
@@ -62,6 +62,16 @@ func (i *gaugeExecuteTestsInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *gaugeExecuteTestsInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"gauge": i.step_data.fields.gauge,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type gaugeExecuteTestsReports struct {
 }
 
@@ -182,9 +192,12 @@ You can use the [sample projects](https://github.com/getgauge/gauge-mvn-archetyp
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -13,9 +13,11 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/SAP/jenkins-library/pkg/abaputils"
 	"github.com/SAP/jenkins-library/pkg/command"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/pkg/errors"
 )
@@ -611,13 +613,13 @@ func runAUnitTest(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.
 	return response, nil
 }
 
-func parseUnitResult(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.Sender, aUnitRunResult *runResult) (parsedResult checkstyle, err error) {
+func parseUnitResult(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.Sender, aUnitRunResult *runResult) (parsedResult abaputils.Checkstyle, err error) {
 
 	log.Entry().Info("parse ABAP Unit Result started")
 
 	var fileName string
-	var aUnitFile file
-	var aUnitError checkstyleError
+	var aUnitFile abaputils.CheckstyleFile
+	var aUnitError abaputils.CheckstyleError
 
 	parsedResult.Version = "1.0"
 
@@ -649,7 +651,7 @@ func parseUnitResult(config *gctsExecuteABAPQualityChecksOptions, client piperht
 			}
 
 			aUnitFile.Error = append(aUnitFile.Error, aUnitError)
-			aUnitError = checkstyleError{}
+			aUnitError = abaputils.CheckstyleError{}
 			log.Entry().Error("there is a syntax error", aUnitFile)
 		}
 
@@ -703,7 +705,7 @@ func parseUnitResult(config *gctsExecuteABAPQualityChecksOptions, client piperht
 					}
 
 					aUnitFile.Error = append(aUnitFile.Error, aUnitError)
-					aUnitError = checkstyleError{}
+					aUnitError = abaputils.CheckstyleError{}
 
 				} else {
 
@@ -727,7 +729,7 @@ func parseUnitResult(config *gctsExecuteABAPQualityChecksOptions, client piperht
 			return parsedResult, errors.Wrap(err, "parse AUnit Result failed")
 		}
 		parsedResult.File = append(parsedResult.File, aUnitFile)
-		aUnitFile = file{}
+		aUnitFile = abaputils.CheckstyleFile{}
 
 	}
 
@@ -822,11 +824,41 @@ func executeATCCheck(config *gctsExecuteABAPQualityChecksOptions, client piperht
 		return errors.Wrap(err, "execution of ATC Checks failed")
 	}
 
+	if err := handleATCFindings(config, atcRes, &piperutils.Files{}); err != nil {
+		return errors.Wrap(err, "execution of ATC Checks failed")
+	}
+
 	log.Entry().Info("execute ATC Checks finished.", atcRes.Text)
 
 	return nil
 
 }
+
+// handleATCFindings applies the baseline to the ATC findings and writes the SARIF file if requested
+func handleATCFindings(config *gctsExecuteABAPQualityChecksOptions, atcRes abaputils.Checkstyle, fileUtils piperutils.FileUtils) error {
+
+	findings := atcRes.Findings()
+	if err := abaputils.ApplyBaseline(findings, config.BaselineFile, config.UpdateBaseline, fileUtils); err != nil {
+		return err
+	}
+
+	atcFailure = false
+	for _, f := range findings {
+		if !f.Suppressed && !config.UpdateBaseline && f.Severity == "error" {
+			atcFailure = true
+		}
+	}
+
+	if config.GenerateSARIF {
+		atcResultsSARIFFileName := strings.TrimSuffix(config.AtcResultsFileName, ".xml") + ".sarif"
+		if err := abaputils.WriteSARIF(abaputils.ToSARIF("ABAP Test Cockpit", findings, config.Host), atcResultsSARIFFileName, fileUtils); err != nil {
+			return err
+		}
+		log.Entry().Info("Writing " + atcResultsSARIFFileName + " file was successful")
+	}
+
+	return nil
+}
 func startATCRun(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.Sender, xml []byte, worklistID string) (err error) {
 
 	log.Entry().Info("ATC Run started")
@@ -949,13 +981,13 @@ func getWorklist(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.S
 	return worklistID, nil
 }
 
-func parseATCCheckResult(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.Sender, response *worklist) (atcResults checkstyle, error error) {
+func parseATCCheckResult(config *gctsExecuteABAPQualityChecksOptions, client piperhttp.Sender, response *worklist) (atcResults abaputils.Checkstyle, error error) {
 
 	log.Entry().Info("parse ATC Check Result started")
 
-	var atcFile file
+	var atcFile abaputils.CheckstyleFile
 	var subObject string
-	var aTCUnitError checkstyleError
+	var aTCUnitError abaputils.CheckstyleError
 
 	atcResults.Version = "1.0"
 
@@ -1025,7 +1057,7 @@ func parseATCCheckResult(config *gctsExecuteABAPQualityChecksOptions, client pip
 				aTCUnitError.Message = html.UnescapeString(atcworklist.CheckTitle + " " + atcworklist.MessageTitle)
 				log.Entry().Info("message: ", aTCUnitError.Message)
 				atcFile.Error = append(atcFile.Error, aTCUnitError)
-				aTCUnitError = checkstyleError{}
+				aTCUnitError = abaputils.CheckstyleError{}
 			}
 
 			if atcFile.Error[0].Message != "" {
@@ -1042,7 +1074,7 @@ func parseATCCheckResult(config *gctsExecuteABAPQualityChecksOptions, client pip
 					return atcResults, errors.Wrap(err, "conversion of ATC check results to CheckStyle has failed")
 				}
 				atcResults.File = append(atcResults.File, atcFile)
-				atcFile = file{}
+				atcFile = abaputils.CheckstyleFile{}
 
 			}
 
@@ -1966,24 +1998,3 @@ type historyResponse struct {
 	Result    []history `xml:"result"`
 	Exception string    `json:"exception"`
 }
-
-type checkstyleError struct {
-	Text     string `xml:",chardata"`
-	Message  string `xml:"message,attr"`
-	Source   string `xml:"source,attr"`
-	Line     string `xml:"line,attr"`
-	Severity string `xml:"severity,attr"`
-}
-
-type file struct {
-	Text  string            `xml:",chardata"`
-	Name  string            `xml:"name,attr"`
-	Error []checkstyleError `xml:"error"`
-}
-
-type checkstyle struct {
-	XMLName xml.Name `xml:"checkstyle"`
-	Text    string   `xml:",chardata"`
-	Version string   `xml:"version,attr"`
-	File    []file   `xml:"file"`
-}
@@ -10,7 +10,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/abaputils"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -1401,6 +1403,71 @@ func TestParseATCCheckResultSuccess(t *testing.T) {
 
 }
 
+func TestHandleATCFindings(t *testing.T) {
+
+	atcRes := abaputils.Checkstyle{
+		Version: "1.0",
+		File: []abaputils.CheckstyleFile{{
+			Name: "/var/jenkins_home/workspace/myFirstPipeline//objects/CLAS/ZCL_GCTS/CPRI ZCL_GCTS.abap",
+			Error: []abaputils.CheckstyleError{
+				{Line: "20", Severity: "error", Source: "ZCL_GCTS", Message: "Package Check (Remote-enabled) Package Violation - Error"},
+			},
+		}},
+	}
+
+	t.Run("new finding fails and is written as SARIF", func(t *testing.T) {
+
+		config := gctsExecuteABAPQualityChecksOptions{Host: "http://testHost.com:50000", AtcResultsFileName: "ATCResults.xml", GenerateSARIF: true}
+		files := &mock.FilesMock{}
+
+		err := handleATCFindings(&config, atcRes, files)
+
+		if assert.NoError(t, err) {
+			assert.True(t, atcFailure)
+			assert.True(t, files.HasWrittenFile("ATCResults.sarif"))
+		}
+	})
+
+	t.Run("finding contained in the baseline is suppressed", func(t *testing.T) {
+
+		config := gctsExecuteABAPQualityChecksOptions{AtcResultsFileName: "ATCResults.xml", BaselineFile: "atc-baseline.json"}
+		files := &mock.FilesMock{}
+		assert.NoError(t, abaputils.WriteBaseline(abaputils.NewBaseline(atcRes.Findings()), "atc-baseline.json", files))
+
+		err := handleATCFindings(&config, atcRes, files)
+
+		if assert.NoError(t, err) {
+			assert.False(t, atcFailure)
+			assert.False(t, files.HasWrittenFile("ATCResults.sarif"))
+		}
+	})
+
+	t.Run("update baseline", func(t *testing.T) {
+
+		config := gctsExecuteABAPQualityChecksOptions{AtcResultsFileName: "ATCResults.xml", BaselineFile: "atc-baseline.json", UpdateBaseline: true}
+		files := &mock.FilesMock{}
+
+		err := handleATCFindings(&config, atcRes, files)
+
+		if assert.NoError(t, err) {
+			assert.False(t, atcFailure)
+			baseline, err := abaputils.ReadBaseline("atc-baseline.json", files)
+			if assert.NoError(t, err) {
+				assert.Len(t, baseline.Findings, 1)
+			}
+		}
+	})
+
+	t.Run("update baseline without baseline file", func(t *testing.T) {
+
+		config := gctsExecuteABAPQualityChecksOptions{AtcResultsFileName: "ATCResults.xml", UpdateBaseline: true}
+
+		err := handleATCFindings(&config, atcRes, &mock.FilesMock{})
+
+		assert.EqualError(t, err, "Updating the baseline requires the parameter baselineFile")
+	})
+}
+
 func TestParseATCCheckResultFailure(t *testing.T) {
 
 	config := gctsExecuteABAPQualityChecksOptions{
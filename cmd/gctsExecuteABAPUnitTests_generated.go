@@ -29,6 +29,9 @@ type gctsExecuteABAPUnitTestsOptions struct {
 	Commit               string                 `json:"commit,omitempty"`
 	Workspace            string                 `json:"workspace,omitempty"`
 	AtcResultsFileName   string                 `json:"atcResultsFileName,omitempty"`
+	GenerateSARIF        bool                   `json:"generateSARIF,omitempty"`
+	BaselineFile         string                 `json:"baselineFile,omitempty"`
+	UpdateBaseline       bool                   `json:"updateBaseline,omitempty"`
 	AUnitResultsFileName string                 `json:"aUnitResultsFileName,omitempty"`
 	QueryParameters      map[string]interface{} `json:"queryParameters,omitempty"`
 	SkipSSLVerification  bool                   `json:"skipSSLVerification,omitempty"`
@@ -104,9 +107,11 @@ func GctsExecuteABAPUnitTestsCommand() *cobra.Command {
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -165,6 +170,9 @@ func addGctsExecuteABAPUnitTestsFlags(cmd *cobra.Command, stepConfig *gctsExecut
 	cmd.Flags().StringVar(&stepConfig.Commit, "commit", os.Getenv("PIPER_commit"), "ID of the commit that triggered the pipeline or any other commit used to calculate the object scope. Specifying a commit is mandatory for the `remoteChangedObjects` and `remoteChangedPackages` scopes.")
 	cmd.Flags().StringVar(&stepConfig.Workspace, "workspace", os.Getenv("PIPER_workspace"), "Absolute path to the directory that contains the source code that your CI/CD tool checks out. For example, in Jenkins, the workspace parameter is `/var/jenkins_home/workspace/<jobName>/`. As an alternative, you can use Jenkins's predefined environmental variable `WORKSPACE`.")
 	cmd.Flags().StringVar(&stepConfig.AtcResultsFileName, "atcResultsFileName", `ATCResults.xml`, "Specifies an output file name for the results of the ATC checks.")
+	cmd.Flags().BoolVar(&stepConfig.GenerateSARIF, "generateSARIF", false, "Specifies whether the ATC results should also be generated as SARIF file, e.g. for the upload to GitHub code scanning. The file name is derived from `atcResultsFileName`.")
+	cmd.Flags().StringVar(&stepConfig.BaselineFile, "baselineFile", os.Getenv("PIPER_baselineFile"), "Specifies a JSON file containing known ATC findings. Findings contained in the baseline are reported as suppressed and are not logged as ATC issues, so that only new findings are reported.")
+	cmd.Flags().BoolVar(&stepConfig.UpdateBaseline, "updateBaseline", false, "Specifies whether the baseline file should be (re-)created with all findings of the current ATC run. ATC findings are not reported as issues in this case.")
 	cmd.Flags().StringVar(&stepConfig.AUnitResultsFileName, "aUnitResultsFileName", `AUnitResults.xml`, "Specifies an output file name for the results of the ABAP Unit tests.")
 
 	cmd.Flags().BoolVar(&stepConfig.SkipSSLVerification, "skipSSLVerification", false, "Skip the verification of SSL (Secure Socket Layer) certificates when using HTTPS. This parameter is **not recommended** for productive environments.")
@@ -311,6 +319,33 @@ func gctsExecuteABAPUnitTestsMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `ATCResults.xml`,
 					},
+					{
+						Name:        "generateSARIF",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "baselineFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_baselineFile"),
+					},
+					{
+						Name:        "updateBaseline",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "aUnitResultsFileName",
 						ResourceRef: []config.ResourceReference{},
@@ -221,6 +221,8 @@ func getConfigWithFlagValues(cmd *cobra.Command) (config.StepConfig, error) {
 			flagValues = config.AvailableFlagValues(cmd, &paramFilter)
 		}
 
+		myConfig.SetInterpolationContextProvider(getInterpolationContext)
+
 		stepConfig, err = myConfig.GetStepConfig(flagValues, GeneralConfig.ParametersJSON, customConfig, defaultConfig, GeneralConfig.IgnoreCustomDefaults, paramFilter, metadata, resourceParams, GeneralConfig.StageName, metadata.Metadata.Name)
 		if err != nil {
 			return stepConfig, errors.Wrap(err, "getting step config failed")
@@ -97,9 +97,11 @@ The linter is parsing the Dockerfile into an abstract syntax tree (AST) and perf
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -132,10 +134,15 @@ The linter is parsing the Dockerfile into an abstract syntax tree (AST) and perf
 					}
 				}
 			}
+			// registered before the handler so that cached outputs are restored after the handler persisted the output resources
+			stepCache := NewStepCache(STEP_NAME, &metadata, stepConfig)
+			defer stepCache.Finish(&stepTelemetryData)
 			log.DeferExitHandler(handler)
 			defer handler()
 			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
-			hadolintExecute(stepConfig, &stepTelemetryData)
+			if !stepCache.Hit() {
+				hadolintExecute(stepConfig, &stepTelemetryData)
+			}
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -262,6 +269,9 @@ func hadolintExecuteMetadata() config.StepData {
 			Containers: []config.Container{
 				{Name: "hadolint", Image: "hadolint/hadolint:latest-alpine"},
 			},
+			Cache: &config.StepCache{
+				Inputs: []string{"**/Dockerfile*", ".hadolint.yaml"},
+			},
 		},
 	}
 	return theMetaData
@@ -9,6 +9,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/kubernetes"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/versioning"
 )
@@ -71,6 +72,12 @@ func helmExecute(config helmExecuteOptions, telemetryData *telemetry.CustomData,
 		helmConfig.PublishVersion = artifactInfo.Version
 	}
 
+	if config.VerifyImageSignatures {
+		if err := setRegistryDockerConfig(config.DockerConfigJSON, utils); err != nil {
+			log.Entry().WithError(err).Fatal("failed to provide registry credentials for the signature verification")
+		}
+	}
+
 	helmExecutor := kubernetes.NewHelmExecutor(helmConfig, utils, GeneralConfig.Verbose, log.Writer())
 
 	// error situations should stop execution through log.Entry().Fatal() call which leads to an os.Exit(1) in the end
@@ -86,6 +93,11 @@ func runHelmExecute(config helmExecuteOptions, helmExecutor kubernetes.HelmExecu
 			log.Entry().WithError(err).Fatalf("failed to parse/render template: %v", err)
 		}
 	}
+	if config.VerifyImageSignatures && (config.HelmCommand == "upgrade" || config.HelmCommand == "install") {
+		if err := verifyHelmImageSignatures(config, utils); err != nil {
+			return err
+		}
+	}
 	switch config.HelmCommand {
 	case "upgrade":
 		if err := helmExecutor.RunHelmUpgrade(); err != nil {
@@ -126,6 +138,19 @@ func runHelmExecute(config helmExecuteOptions, helmExecutor kubernetes.HelmExecu
 	return nil
 }
 
+// verifyHelmImageSignatures makes sure that only signed images are deployed
+func verifyHelmImageSignatures(config helmExecuteOptions, utils fileHandler) error {
+	if len(config.ImageNameTags) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no images found for signature verification, please provide imageNameTags or disable verifyImageSignatures")
+	}
+	images, err := signing.ImageReferences(config.ContainerRegistryURL, config.ImageNameTags, config.ImageDigests)
+	if err != nil {
+		return fmt.Errorf("failed to determine the images to be verified: %w", err)
+	}
+	return signing.VerifyImages(images, config.SignatureVerificationKey, utils)
+}
+
 func runHelmExecuteDefault(config helmExecuteOptions, helmExecutor kubernetes.HelmExecutor, commonPipelineEnvironment *helmExecuteCommonPipelineEnvironment) error {
 	if len(config.Dependency) > 0 {
 		if err := helmExecutor.RunHelmDependency(); err != nil {
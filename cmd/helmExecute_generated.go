@@ -36,6 +36,11 @@ type helmExecuteOptions struct {
 	KubeConfig                string   `json:"kubeConfig,omitempty"`
 	KubeContext               string   `json:"kubeContext,omitempty"`
 	Namespace                 string   `json:"namespace,omitempty"`
+	VerifyImageSignatures     bool     `json:"verifyImageSignatures,omitempty"`
+	SignatureVerificationKey  string   `json:"signatureVerificationKey,omitempty"`
+	ContainerRegistryURL      string   `json:"containerRegistryUrl,omitempty"`
+	ImageNameTags             []string `json:"imageNameTags,omitempty"`
+	ImageDigests              []string `json:"imageDigests,omitempty"`
 	DockerConfigJSON          string   `json:"dockerConfigJSON,omitempty"`
 	HelmCommand               string   `json:"helmCommand,omitempty" validate:"possible-values=upgrade lint install test uninstall dependency publish"`
 	AppVersion                string   `json:"appVersion,omitempty"`
@@ -138,6 +143,7 @@ Note: piper supports only helm3 version, since helm2 is deprecated.`,
 			log.RegisterSecret(stepConfig.SourceRepositoryUser)
 			log.RegisterSecret(stepConfig.SourceRepositoryPassword)
 			log.RegisterSecret(stepConfig.KubeConfig)
+			log.RegisterSecret(stepConfig.SignatureVerificationKey)
 			log.RegisterSecret(stepConfig.DockerConfigJSON)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
@@ -177,9 +183,11 @@ Note: piper supports only helm3 version, since helm2 is deprecated.`,
 			handler := func() {
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -243,6 +251,11 @@ func addHelmExecuteFlags(cmd *cobra.Command, stepConfig *helmExecuteOptions) {
 	cmd.Flags().StringVar(&stepConfig.KubeConfig, "kubeConfig", os.Getenv("PIPER_kubeConfig"), "Defines the path to the \"kubeconfig\" file.")
 	cmd.Flags().StringVar(&stepConfig.KubeContext, "kubeContext", os.Getenv("PIPER_kubeContext"), "Defines the context to use from the \"kubeconfig\" file.")
 	cmd.Flags().StringVar(&stepConfig.Namespace, "namespace", `default`, "Defines the target Kubernetes namespace for the deployment.")
+	cmd.Flags().BoolVar(&stepConfig.VerifyImageSignatures, "verifyImageSignatures", false, "Verifies the signatures of the images before they are deployed, see step [containerVerifySignature](containerVerifySignature.md). Images without a valid signature are not deployed by the commands `upgrade` and `install`. The step fails if no images are provided via [imageNameTags](#imagenametags).")
+	cmd.Flags().StringVar(&stepConfig.SignatureVerificationKey, "signatureVerificationKey", os.Getenv("PIPER_signatureVerificationKey"), "Path to the file containing the PEM encoded public keys used to verify the image signatures if [verifyImageSignatures](#verifyimagesignatures) is activated.")
+	cmd.Flags().StringVar(&stepConfig.ContainerRegistryURL, "containerRegistryUrl", os.Getenv("PIPER_containerRegistryUrl"), "Url of the container registry the images have been pushed to. Used for the signature verification if [verifyImageSignatures](#verifyimagesignatures) is activated.")
+	cmd.Flags().StringSliceVar(&stepConfig.ImageNameTags, "imageNameTags", []string{}, "List of images names and tags to be deployed. Used for the signature verification if [verifyImageSignatures](#verifyimagesignatures) is activated.")
+	cmd.Flags().StringSliceVar(&stepConfig.ImageDigests, "imageDigests", []string{}, "List of image digests belonging to [imageNameTags](#imagenametags). Used for the signature verification if [verifyImageSignatures](#verifyimagesignatures) is activated.")
 	cmd.Flags().StringVar(&stepConfig.DockerConfigJSON, "dockerConfigJSON", os.Getenv("PIPER_dockerConfigJSON"), "Path to the file `.docker/config.json` - this is typically provided by your CI/CD system. You can find more details about the Docker credentials in the [Docker documentation](https://docs.docker.com/engine/reference/commandline/login/).")
 	cmd.Flags().StringVar(&stepConfig.HelmCommand, "helmCommand", os.Getenv("PIPER_helmCommand"), "Helm: defines the command `upgrade`, `lint`, `install`, `test`, `uninstall`, `dependency`, `publish`.")
 	cmd.Flags().StringVar(&stepConfig.AppVersion, "appVersion", os.Getenv("PIPER_appVersion"), "set the appVersion on the chart to this version")
@@ -276,6 +289,7 @@ func helmExecuteMetadata() config.StepData {
 					{Name: "dockerConfigJsonCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing Docker config.json (with registry credential(s)).", Type: "jenkins"},
 					{Name: "sourceRepositoryCredentialsId", Description: "Jenkins 'Username Password' credentials ID containing username and password for the Helm Repository authentication (source repo)", Type: "jenkins"},
 					{Name: "targetRepositoryCredentialsId", Description: "Jenkins 'Username Password' credentials ID containing username and password for the Helm Repository authentication (target repo)", Type: "jenkins"},
+					{Name: "signatureVerificationKeyCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing the public keys used to verify the image signatures.", Type: "jenkins"},
 				},
 				Resources: []config.StepResources{
 					{Name: "deployDescriptor", Type: "stash"},
@@ -528,6 +542,77 @@ func helmExecuteMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "helmDeploymentNamespace"}},
 						Default:     `default`,
 					},
+					{
+						Name:        "verifyImageSignatures",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name: "signatureVerificationKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signatureVerificationKeyCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signatureVerificationKeyVaultSecretName",
+								Type:    "vaultSecretFile",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signatureVerificationKey"),
+					},
+					{
+						Name: "containerRegistryUrl",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "container/registryUrl",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_containerRegistryUrl"),
+					},
+					{
+						Name: "imageNameTags",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "container/imageNameTags",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "[]string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   []string{},
+					},
+					{
+						Name: "imageDigests",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "container/imageDigests",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "[]string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   []string{},
+					},
 					{
 						Name: "dockerConfigJSON",
 						ResourceRef: []config.ResourceReference{
@@ -12,6 +12,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/kubernetes/mocks"
 	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -410,6 +411,63 @@ func TestRunHelmDefaultCommand(t *testing.T) {
 
 }
 
+func TestRunHelmVerifyImageSignatures(t *testing.T) {
+	cpe := helmExecuteCommonPipelineEnvironment{}
+	fileUtils := &mock.FilesMock{}
+	addSigningTestKeys(t, fileUtils)
+
+	t.Run("signed image is deployed", func(t *testing.T) {
+		host, digest := newSigningTestRegistry(t)
+		require.NoError(t, signing.SignImages([]string{host + "/my-image:1.0.0"}, signing.Config{SigningKey: "cosign.key", SignatureMode: signing.ModeTag}, fileUtils))
+		config := helmExecuteOptions{
+			HelmCommand:              "upgrade",
+			ContainerRegistryURL:     "http://" + host,
+			ImageNameTags:            []string{"my-image:1.0.0"},
+			ImageDigests:             []string{digest},
+			VerifyImageSignatures:    true,
+			SignatureVerificationKey: "cosign.pub",
+		}
+		helmExecute := &mocks.HelmExecutor{}
+		helmExecute.On("RunHelmUpgrade").Return(nil)
+
+		err := runHelmExecute(config, helmExecute, fileUtils, &cpe)
+
+		assert.NoError(t, err)
+		helmExecute.AssertCalled(t, "RunHelmUpgrade")
+	})
+
+	t.Run("unsigned image is not deployed", func(t *testing.T) {
+		host, _ := newSigningTestRegistry(t)
+		config := helmExecuteOptions{
+			HelmCommand:              "install",
+			ContainerRegistryURL:     "http://" + host,
+			ImageNameTags:            []string{"my-image:1.0.0"},
+			VerifyImageSignatures:    true,
+			SignatureVerificationKey: "cosign.pub",
+		}
+		helmExecute := &mocks.HelmExecutor{}
+
+		err := runHelmExecute(config, helmExecute, fileUtils, &cpe)
+
+		assert.EqualError(t, err, "signature verification failed for images: "+host+"/my-image:1.0.0")
+		helmExecute.AssertNotCalled(t, "RunHelmInstall")
+	})
+
+	t.Run("verification without images", func(t *testing.T) {
+		config := helmExecuteOptions{
+			HelmCommand:              "install",
+			VerifyImageSignatures:    true,
+			SignatureVerificationKey: "cosign.pub",
+		}
+		helmExecute := &mocks.HelmExecutor{}
+
+		err := runHelmExecute(config, helmExecute, fileUtils, &cpe)
+
+		assert.EqualError(t, err, "no images found for signature verification, please provide imageNameTags or disable verifyImageSignatures")
+		helmExecute.AssertNotCalled(t, "RunHelmInstall")
+	})
+}
+
 func TestParseAndRenderCPETemplate(t *testing.T) {
 	commonPipelineEnvironment := "commonPipelineEnvironment"
 	valuesYaml := []byte(`
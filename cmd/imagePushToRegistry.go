@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -14,6 +15,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/docker"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 )
 
@@ -110,6 +112,21 @@ func runImagePushToRegistry(config *imagePushToRegistryOptions, telemetryData *t
 		return errors.Wrap(err, "failed to handle credentials for target registry")
 	}
 
+	if err := pushImages(config, utils); err != nil {
+		return err
+	}
+
+	if config.SignImages {
+		signingConfig := signing.Config{SigningKey: config.SigningKey, SigningKeyPassword: config.SigningKeyPassword, SignatureMode: config.SignatureMode}
+		if err := signing.SignImages(targetImageReferences(config), signingConfig, utils); err != nil {
+			return errors.Wrap(err, "failed to sign images")
+		}
+	}
+
+	return nil
+}
+
+func pushImages(config *imagePushToRegistryOptions, utils imagePushToRegistryUtils) error {
 	if config.PushLocalDockerImage {
 		if err := pushLocalImageToTargetRegistry(config, utils); err != nil {
 			return errors.Wrapf(err, "failed to push local image to %q", config.TargetRegistryURL)
@@ -136,6 +153,36 @@ func runImagePushToRegistry(config *imagePushToRegistryOptions, telemetryData *t
 	return nil
 }
 
+// targetImageReferences returns the images written to the target registry.
+// Images pushed with a tag and as latest share the same digest and are therefore only returned once.
+func targetImageReferences(config *imagePushToRegistryOptions) []string {
+	images := []string{}
+	switch {
+	case config.UseImageNameTags && !config.PushLocalDockerImage:
+		for i, sourceImageNameTag := range config.SourceImageNameTags {
+			if len(config.TargetImageNameTags) == 0 {
+				images = append(images, fmt.Sprintf("%s/%s", config.TargetRegistryURL, sourceImageNameTag))
+			} else {
+				images = append(images, fmt.Sprintf("%s/%s", config.TargetRegistryURL, config.TargetImageNameTags[i]))
+			}
+		}
+	default:
+		for _, trgImage := range config.TargetImages {
+			targetImage, ok := trgImage.(string)
+			if !ok {
+				continue
+			}
+			if config.TargetImageTag != "" {
+				images = append(images, fmt.Sprintf("%s/%s:%s", config.TargetRegistryURL, targetImage, config.TargetImageTag))
+			} else if config.TagLatest {
+				images = append(images, fmt.Sprintf("%s/%s", config.TargetRegistryURL, targetImage))
+			}
+		}
+		sort.Strings(images)
+	}
+	return images
+}
+
 func handleCredentialsForPrivateRegistry(dockerConfigJsonPath, registry, username, password string, utils imagePushToRegistryUtils) error {
 	if len(dockerConfigJsonPath) == 0 {
 		if len(registry) == 0 || len(username) == 0 || len(password) == 0 {
@@ -35,6 +35,10 @@ type imagePushToRegistryOptions struct {
 	PushLocalDockerImage   bool                   `json:"pushLocalDockerImage,omitempty"`
 	LocalDockerImagePath   string                 `json:"localDockerImagePath,omitempty" validate:"required_if=PushLocalDockerImage true"`
 	TargetArchitecture     string                 `json:"targetArchitecture,omitempty"`
+	SignImages             bool                   `json:"signImages,omitempty"`
+	SigningKey             string                 `json:"signingKey,omitempty"`
+	SigningKeyPassword     string                 `json:"signingKeyPassword,omitempty"`
+	SignatureMode          string                 `json:"signatureMode,omitempty" validate:"possible-values=tag referrers"`
 }
 
 // ImagePushToRegistryCommand Allows you to copy a Docker image from a source container registry  to a destination container registry.
@@ -77,6 +81,8 @@ Currently the imagePushToRegistry only supports copying a local image or image f
 			log.RegisterSecret(stepConfig.TargetRegistryUser)
 			log.RegisterSecret(stepConfig.TargetRegistryPassword)
 			log.RegisterSecret(stepConfig.DockerConfigJSON)
+			log.RegisterSecret(stepConfig.SigningKey)
+			log.RegisterSecret(stepConfig.SigningKeyPassword)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
 				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
@@ -114,9 +120,11 @@ Currently the imagePushToRegistry only supports copying a local image or image f
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -181,6 +189,10 @@ func addImagePushToRegistryFlags(cmd *cobra.Command, stepConfig *imagePushToRegi
 	cmd.Flags().BoolVar(&stepConfig.PushLocalDockerImage, "pushLocalDockerImage", false, "Defines if the local image should be pushed to registry")
 	cmd.Flags().StringVar(&stepConfig.LocalDockerImagePath, "localDockerImagePath", os.Getenv("PIPER_localDockerImagePath"), "If the `localDockerImagePath` is a directory, it will be read as an OCI image layout. Otherwise, `localDockerImagePath` is assumed to be a docker-style tarball.")
 	cmd.Flags().StringVar(&stepConfig.TargetArchitecture, "targetArchitecture", os.Getenv("PIPER_targetArchitecture"), "Specifies the targetArchitecture in the form os/arch[/variant][:osversion] (e.g. linux/amd64). All OS and architectures of the specified image will be copied if it is a multi-platform image. To only push a single platform to the target registry use this parameter")
+	cmd.Flags().BoolVar(&stepConfig.SignImages, "signImages", false, "Signs the pushed images with [signingKey](#signingkey). The signatures are compatible with [cosign](https://github.com/sigstore/cosign) and can be verified with `containerVerifySignature`, `cosign verify` or admission controllers like the Sigstore policy-controller.")
+	cmd.Flags().StringVar(&stepConfig.SigningKey, "signingKey", os.Getenv("PIPER_signingKey"), "Path to the PEM encoded private key used for signing the images. Keys created with `cosign generate-key-pair` as well as unencrypted PKCS#8 keys are supported.")
+	cmd.Flags().StringVar(&stepConfig.SigningKeyPassword, "signingKeyPassword", os.Getenv("PIPER_signingKeyPassword"), "Password of the [signingKey](#signingkey).")
+	cmd.Flags().StringVar(&stepConfig.SignatureMode, "signatureMode", `tag`, "Defines where the signatures are stored. `tag` stores them in the tag `sha256-<digest>.sig` of the image repository like cosign does by default, `referrers` stores them as OCI 1.1 artifacts referring to the image.")
 
 	cmd.MarkFlagRequired("targetRegistryUrl")
 	cmd.MarkFlagRequired("targetRegistryUser")
@@ -197,6 +209,10 @@ func imagePushToRegistryMetadata() config.StepData {
 		},
 		Spec: config.StepSpec{
 			Inputs: config.StepInputs{
+				Secrets: []config.StepSecrets{
+					{Name: "signingKeyCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing the private key used for signing the images.", Type: "jenkins"},
+					{Name: "signingKeyPasswordCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing the password of the private key used for signing the images.", Type: "jenkins"},
+				},
 				Resources: []config.StepResources{
 					{Name: "source", Type: "stash"},
 				},
@@ -427,6 +443,64 @@ func imagePushToRegistryMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_targetArchitecture"),
 					},
+					{
+						Name:        "signImages",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name: "signingKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signingKeyCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signingKeyVaultSecretName",
+								Type:    "vaultSecretFile",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signingKey"),
+					},
+					{
+						Name: "signingKeyPassword",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signingKeyPasswordCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signingKeyVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signingKeyPassword"),
+					},
+					{
+						Name:        "signatureMode",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `tag`,
+					},
 				},
 			},
 			Containers: []config.Container{
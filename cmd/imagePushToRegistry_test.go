@@ -238,3 +238,37 @@ func TestMapSourceTargetImages(t *testing.T) {
 	got := mapSourceTargetImages(sourceImages)
 	assert.Equal(t, got, expected)
 }
+
+func TestTargetImageReferences(t *testing.T) {
+	t.Run("target images", func(t *testing.T) {
+		config := &imagePushToRegistryOptions{
+			TargetRegistryURL: "target.registry",
+			TargetImages:      map[string]any{"img1": "target1", "img2": "target2"},
+			TargetImageTag:    "1.0.0",
+			TagLatest:         true,
+		}
+		got := targetImageReferences(config)
+		assert.Equal(t, []string{"target.registry/target1:1.0.0", "target.registry/target2:1.0.0"}, got)
+	})
+
+	t.Run("latest only", func(t *testing.T) {
+		config := &imagePushToRegistryOptions{
+			TargetRegistryURL: "target.registry",
+			TargetImages:      map[string]any{"img1": "target1"},
+			TagLatest:         true,
+		}
+		got := targetImageReferences(config)
+		assert.Equal(t, []string{"target.registry/target1"}, got)
+	})
+
+	t.Run("imageNameTags", func(t *testing.T) {
+		config := &imagePushToRegistryOptions{
+			TargetRegistryURL:   "target.registry",
+			SourceImageNameTags: []string{"img1:1.0.0", "img2:1.0.0"},
+			TargetImageNameTags: []string{"target1:1.0.0", "target2:1.0.0"},
+			UseImageNameTags:    true,
+		}
+		got := targetImageReferences(config)
+		assert.Equal(t, []string{"target.registry/target1:1.0.0", "target.registry/target2:1.0.0"}, got)
+	})
+}
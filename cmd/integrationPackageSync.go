@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"github.com/SAP/jenkins-library/pkg/cpi"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+func integrationPackageSync(config integrationPackageSyncOptions, telemetryData *telemetry.CustomData) {
+	httpClient := &piperhttp.Client{}
+
+	err := runIntegrationPackageSync(&config, httpClient)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runIntegrationPackageSync(config *integrationPackageSyncOptions, httpClient piperhttp.Sender) error {
+	serviceKey, err := cpi.ReadCpiServiceKey(config.APIServiceKey)
+	if err != nil {
+		return err
+	}
+	client, err := cpi.NewPackageClient(serviceKey, httpClient)
+	if err != nil {
+		return err
+	}
+
+	switch config.Mode {
+	case "download":
+		return downloadIntegrationPackage(config, client)
+	case "diff":
+		return diffIntegrationPackage(config, client)
+	case "push":
+		return pushIntegrationPackage(config, client)
+	}
+	log.SetErrorCategory(log.ErrorConfiguration)
+	return errors.Errorf("unsupported mode '%v', supported values: download, diff, push", config.Mode)
+}
+
+func downloadIntegrationPackage(config *integrationPackageSyncOptions, client *cpi.PackageClient) error {
+	tenantArtifacts, err := fetchTenantArtifacts(config.IntegrationPackageID, client)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range tenantArtifacts {
+		log.Entry().Infof("Storing %v in %v", artifact.Artifact.Describe(), config.Path)
+		if err := cpi.WritePackageArtifact(config.Path, artifact); err != nil {
+			return err
+		}
+	}
+
+	repoArtifacts, err := cpi.ReadPackageArtifacts(config.Path)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	for _, artifact := range repoArtifacts {
+		if _, found := findPackageArtifact(tenantArtifacts, artifact.Artifact.ID); !found {
+			log.Entry().Warnf("The %v in %v does not exist in integration package %v", artifact.Artifact.Describe(), config.Path, config.IntegrationPackageID)
+		}
+	}
+	log.Entry().Infof("Downloaded %v artifacts of integration package %v", len(tenantArtifacts), config.IntegrationPackageID)
+	return nil
+}
+
+func diffIntegrationPackage(config *integrationPackageSyncOptions, client *cpi.PackageClient) error {
+	tenantArtifacts, err := fetchTenantArtifacts(config.IntegrationPackageID, client)
+	if err != nil {
+		return err
+	}
+	repoArtifacts, err := cpi.ReadPackageArtifacts(config.Path)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	changes := 0
+	for _, repoArtifact := range repoArtifacts {
+		tenantArtifact, found := findPackageArtifact(tenantArtifacts, repoArtifact.Artifact.ID)
+		if !found {
+			log.Entry().Infof("The %v does not exist on the tenant", repoArtifact.Artifact.Describe())
+			changes++
+			continue
+		}
+		diff := cpi.DiffArtifact(tenantArtifact, repoArtifact)
+		if diff.HasChanges() {
+			log.Entry().Infof("The %v differs from the tenant:\n%v", repoArtifact.Artifact.Describe(), diff)
+			changes++
+		}
+	}
+	for _, tenantArtifact := range tenantArtifacts {
+		if _, found := findPackageArtifact(repoArtifacts, tenantArtifact.Artifact.ID); !found {
+			log.Entry().Infof("The %v exists only on the tenant", tenantArtifact.Artifact.Describe())
+			changes++
+		}
+	}
+
+	if changes == 0 {
+		log.Entry().Infof("Integration package %v on the tenant is in sync with %v", config.IntegrationPackageID, config.Path)
+		return nil
+	}
+	if config.FailOnDiff {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return errors.Errorf("%v artifacts of integration package %v differ between tenant and repository", changes, config.IntegrationPackageID)
+	}
+	return nil
+}
+
+func pushIntegrationPackage(config *integrationPackageSyncOptions, client *cpi.PackageClient) error {
+	tenantArtifacts, err := fetchTenantArtifacts(config.IntegrationPackageID, client)
+	if err != nil {
+		return err
+	}
+	repoArtifacts, err := cpi.ReadPackageArtifacts(config.Path)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	if len(repoArtifacts) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Errorf("no artifacts found in %v", config.Path)
+	}
+
+	for _, repoArtifact := range repoArtifacts {
+		tenantArtifact, found := findPackageArtifact(tenantArtifacts, repoArtifact.Artifact.ID)
+		if err := pushPackageArtifact(config, client, tenantArtifact, repoArtifact, found); err != nil {
+			return err
+		}
+	}
+	for _, tenantArtifact := range tenantArtifacts {
+		if _, found := findPackageArtifact(repoArtifacts, tenantArtifact.Artifact.ID); !found {
+			log.Entry().Warnf("The %v exists only on the tenant and is left untouched", tenantArtifact.Artifact.Describe())
+		}
+	}
+	return nil
+}
+
+func pushPackageArtifact(config *integrationPackageSyncOptions, client *cpi.PackageClient, tenantArtifact, repoArtifact cpi.PackageArtifact, onTenant bool) error {
+	artifact := repoArtifact.Artifact
+	artifactType, err := artifact.ArtifactType()
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	diff := cpi.DiffArtifact(tenantArtifact, repoArtifact)
+	if !artifactType.Configurable {
+		if len(repoArtifact.Configuration) > 0 {
+			log.Entry().Warnf("The %v has no externalized parameters, its configuration is skipped", artifact.Describe())
+		}
+		diff.Configuration = nil
+	}
+
+	changed := !onTenant || diff.ContentChanged()
+	if changed {
+		content, err := repoArtifact.Zip()
+		if err != nil {
+			return err
+		}
+		if !onTenant {
+			log.Entry().Infof("Creating %v", artifact.Describe())
+			err = client.CreateArtifact(config.IntegrationPackageID, artifact, content)
+		} else {
+			log.Entry().Infof("Updating %v", artifact.Describe())
+			err = client.UpdateArtifact(config.IntegrationPackageID, artifact, content)
+		}
+		if err != nil {
+			log.SetErrorCategory(log.ErrorService)
+			return errors.Wrapf(err, "failed to upload %v", artifact.Describe())
+		}
+
+		if artifactType.Configurable {
+			// the content defines the externalized parameters, so the configuration has to be compared against the uploaded state
+			configuration, err := client.GetConfigurations(artifact.ID)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorService)
+				return errors.Wrapf(err, "failed to get configuration of %v", artifact.Describe())
+			}
+			tenantArtifact.Configuration = cpi.ConfigurationValues(configuration)
+			diff.Configuration = cpi.DiffArtifact(tenantArtifact, repoArtifact).Configuration
+		}
+	}
+
+	for _, parameter := range diff.Configuration {
+		if _, exists := tenantArtifact.Configuration[parameter.Key]; !exists {
+			log.Entry().Warnf("Parameter %v is not externalized in %v and is skipped", parameter.Key, artifact.Describe())
+			continue
+		}
+		log.Entry().Infof("Updating parameter %v of %v", parameter.Key, artifact.Describe())
+		if err := client.UpdateConfiguration(artifact.ID, parameter.Key, parameter.RepoValue); err != nil {
+			log.SetErrorCategory(log.ErrorService)
+			return errors.Wrapf(err, "failed to update parameter %v of %v", parameter.Key, artifact.Describe())
+		}
+		changed = true
+	}
+
+	if !changed {
+		log.Entry().Infof("The %v is up to date", artifact.Describe())
+		return nil
+	}
+	if !config.Deploy {
+		log.Entry().Infof("The %v has been changed in the design time only, since deploy is disabled", artifact.Describe())
+		return nil
+	}
+	log.Entry().Infof("Deploying %v", artifact.Describe())
+	if err := client.DeployArtifact(artifact); err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return errors.Wrapf(err, "failed to deploy %v", artifact.Describe())
+	}
+	return nil
+}
+
+func fetchTenantArtifacts(packageID string, client *cpi.PackageClient) ([]cpi.PackageArtifact, error) {
+	artifacts, err := client.GetArtifacts(packageID)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return nil, errors.Wrapf(err, "failed to get artifacts of integration package %v", packageID)
+	}
+	unpacked := []cpi.PackageArtifact{}
+	for _, artifact := range artifacts {
+		content, err := client.DownloadArtifact(artifact)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorService)
+			return nil, errors.Wrapf(err, "failed to download %v", artifact.Describe())
+		}
+		var configuration []cpi.ConfigurationParameter
+		if artifactType, _ := artifact.ArtifactType(); artifactType.Configurable {
+			if configuration, err = client.GetConfigurations(artifact.ID); err != nil {
+				log.SetErrorCategory(log.ErrorService)
+				return nil, errors.Wrapf(err, "failed to get configuration of %v", artifact.Describe())
+			}
+		}
+		a, err := cpi.UnpackArtifact(artifact, content, configuration)
+		if err != nil {
+			return nil, err
+		}
+		unpacked = append(unpacked, a)
+	}
+	return unpacked, nil
+}
+
+func findPackageArtifact(artifacts []cpi.PackageArtifact, artifactID string) (cpi.PackageArtifact, bool) {
+	for _, artifact := range artifacts {
+		if artifact.Artifact.ID == artifactID {
+			return artifact, true
+		}
+	}
+	return cpi.PackageArtifact{}, false
+}
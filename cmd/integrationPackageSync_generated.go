@@ -0,0 +1,247 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type integrationPackageSyncOptions struct {
+	APIServiceKey        string `json:"apiServiceKey,omitempty"`
+	IntegrationPackageID string `json:"integrationPackageId,omitempty"`
+	Path                 string `json:"path,omitempty"`
+	Mode                 string `json:"mode,omitempty" validate:"possible-values=download diff push"`
+	FailOnDiff           bool   `json:"failOnDiff,omitempty"`
+	Deploy               bool   `json:"deploy,omitempty"`
+}
+
+// IntegrationPackageSyncCommand Synchronize an integration package between SAP Integration Suite and a git repository
+func IntegrationPackageSyncCommand() *cobra.Command {
+	const STEP_NAME = "integrationPackageSync"
+
+	metadata := integrationPackageSyncMetadata()
+	var stepConfig integrationPackageSyncOptions
+	var startTime time.Time
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createIntegrationPackageSyncCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Synchronize an integration package between SAP Integration Suite and a git repository",
+		Long: `With this step you can keep the integration flows, value mappings, message mappings and script collections of an integration package of SAP Cloud Integration in a git repository.
+
+* ` + "`" + `download` + "`" + ` stores every artifact of the package in an unpacked, git-friendly layout below ` + "`" + `path` + "`" + `, integration flows together with their externalized parameters.
+* ` + "`" + `diff` + "`" + ` compares the state of the tenant with the state in the repository and logs the differences.
+* ` + "`" + `push` + "`" + ` updates the tenant to the state of the repository. Artifacts which do not exist yet are created, changed artifacts are updated and changed externalized parameters are set in one run.
+  Created and changed artifacts are deployed afterwards, unless ` + "`" + `deploy` + "`" + ` is disabled. Value mappings cannot be updated by the API, they are deleted and created again.
+
+Every artifact is stored in the directory ` + "`" + `<path>/<artifact id>` + "`" + `, which contains the file ` + "`" + `artifact.json` + "`" + ` with id, name and type, the file ` + "`" + `configuration.json` + "`" + ` with the values of the externalized parameters and the unpacked content of the artifact in the folder ` + "`" + `content` + "`" + `.
+The type is one of ` + "`" + `IntegrationFlow` + "`" + `, ` + "`" + `ValueMapping` + "`" + `, ` + "`" + `MessageMapping` + "`" + ` and ` + "`" + `ScriptCollection` + "`" + `, artifacts without type are integration flows. The step fails for artifacts of other types.
+Learn more about the SAP Cloud Integration remote API for integration packages [here](https://api.sap.com/api/IntegrationContent/resource).`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+			log.RegisterSecret(stepConfig.APIServiceKey)
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			integrationPackageSync(stepConfig, &stepTelemetryData)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addIntegrationPackageSyncFlags(createIntegrationPackageSyncCmd, &stepConfig)
+	return createIntegrationPackageSyncCmd
+}
+
+func addIntegrationPackageSyncFlags(cmd *cobra.Command, stepConfig *integrationPackageSyncOptions) {
+	cmd.Flags().StringVar(&stepConfig.APIServiceKey, "apiServiceKey", os.Getenv("PIPER_apiServiceKey"), "Service key JSON string to access the Process Integration Runtime service instance of plan 'api'")
+	cmd.Flags().StringVar(&stepConfig.IntegrationPackageID, "integrationPackageId", os.Getenv("PIPER_integrationPackageId"), "Specifies the ID of the integration package")
+	cmd.Flags().StringVar(&stepConfig.Path, "path", `integrationPackage`, "Specifies the directory inside the repository containing the artifacts of the package")
+	cmd.Flags().StringVar(&stepConfig.Mode, "mode", `diff`, "Specifies the direction of the synchronization")
+	cmd.Flags().BoolVar(&stepConfig.FailOnDiff, "failOnDiff", false, "In mode `diff` the step fails in case the tenant differs from the repository. Can be used to detect changes done directly on the tenant.")
+	cmd.Flags().BoolVar(&stepConfig.Deploy, "deploy", true, "In mode `push` the artifacts which have been created or changed are deployed to the runtime of the tenant.")
+
+	cmd.MarkFlagRequired("apiServiceKey")
+	cmd.MarkFlagRequired("integrationPackageId")
+}
+
+// retrieve step metadata
+func integrationPackageSyncMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "integrationPackageSync",
+			Aliases:     []config.Alias{},
+			Description: "Synchronize an integration package between SAP Integration Suite and a git repository",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Secrets: []config.StepSecrets{
+					{Name: "cpiApiServiceKeyCredentialsId", Description: "Jenkins secret text credential ID containing the service key to the Process Integration Runtime service instance of plan 'api'", Type: "jenkins"},
+				},
+				Parameters: []config.StepParameters{
+					{
+						Name: "apiServiceKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "cpiApiServiceKeyCredentialsId",
+								Param: "apiServiceKey",
+								Type:  "secret",
+							},
+						},
+						Scope:     []string{"PARAMETERS"},
+						Type:      "string",
+						Mandatory: true,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_apiServiceKey"),
+					},
+					{
+						Name:        "integrationPackageId",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "GENERAL", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   true,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_integrationPackageId"),
+					},
+					{
+						Name:        "path",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "GENERAL", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `integrationPackage`,
+					},
+					{
+						Name:        "mode",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `diff`,
+					},
+					{
+						Name:        "failOnDiff",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "deploy",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
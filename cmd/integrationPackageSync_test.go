@@ -0,0 +1,314 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	b64 "encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/cpi"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const packageSyncServiceKey = `{
+	"oauth": {
+		"url": "https://demo",
+		"clientid": "demouser",
+		"clientsecret": "******",
+		"tokenurl": "https://demo/oauth/token"
+	}
+}`
+
+type tenantArtifactMock struct {
+	name          string
+	entitySet     string
+	content       []byte
+	configuration map[string]string
+}
+
+// packageTenantMock simulates the design time of an integration package on the tenant
+type packageTenantMock struct {
+	artifacts map[string]*tenantArtifactMock
+	requests  []string
+	options   piperhttp.ClientOptions
+}
+
+var (
+	artifactsURLPattern = regexp.MustCompile(`^https://demo/api/v1/IntegrationPackages\('CICD'\)/(\w+)$`)
+	createURLPattern    = regexp.MustCompile(`^https://demo/api/v1/(\w+)$`)
+	artifactURLPattern  = regexp.MustCompile(`(\w+)\(Id='([^']+)',Version='active'\)(.*)`)
+	deployURLPattern    = regexp.MustCompile(`/Deploy(\w+)\?Id='([^']+)'&Version='active'$`)
+)
+
+func (c *packageTenantMock) SetOptions(options piperhttp.ClientOptions) {
+	c.options = options
+}
+
+func (c *packageTenantMock) SendRequest(method string, url string, r io.Reader, header http.Header, cookies []*http.Cookie) (*http.Response, error) {
+	if c.options.Token == "" {
+		return packageTenantResponse(http.StatusOK, map[string]string{"access_token": "demotoken"})
+	}
+	payload := map[string]string{}
+	if r != nil {
+		data, _ := io.ReadAll(r)
+		_ = json.Unmarshal(data, &payload)
+	}
+
+	if match := artifactsURLPattern.FindStringSubmatch(url); method == http.MethodGet && match != nil {
+		results := []cpi.DesigntimeArtifact{}
+		for _, id := range sortedArtifactIDs(c.artifacts) {
+			if c.artifacts[id].entitySet == match[1] {
+				results = append(results, cpi.DesigntimeArtifact{ID: id, Name: c.artifacts[id].name, Version: "1.0.0", PackageID: "CICD"})
+			}
+		}
+		return packageTenantResponse(http.StatusOK, map[string]interface{}{"d": map[string]interface{}{"results": results}})
+	}
+	if match := createURLPattern.FindStringSubmatch(url); method == http.MethodPost && match != nil {
+		c.requests = append(c.requests, fmt.Sprintf("create %v", payload["Id"]))
+		content, _ := b64.StdEncoding.DecodeString(payload["ArtifactContent"])
+		configuration := map[string]string{}
+		if match[1] == "IntegrationDesigntimeArtifacts" {
+			configuration["host"] = ""
+		}
+		c.artifacts[payload["Id"]] = &tenantArtifactMock{name: payload["Name"], entitySet: match[1], content: content, configuration: configuration}
+		return packageTenantResponse(http.StatusCreated, nil)
+	}
+	if match := deployURLPattern.FindStringSubmatch(url); method == http.MethodPost && match != nil {
+		if c.artifacts[match[2]] == nil || c.artifacts[match[2]].entitySet != match[1]+"s" {
+			return packageTenantResponse(http.StatusNotFound, nil)
+		}
+		c.requests = append(c.requests, fmt.Sprintf("deploy %v", match[2]))
+		return packageTenantResponse(http.StatusAccepted, nil)
+	}
+
+	match := artifactURLPattern.FindStringSubmatch(url)
+	if match == nil || c.artifacts[match[2]] == nil || c.artifacts[match[2]].entitySet != match[1] {
+		return packageTenantResponse(http.StatusNotFound, nil)
+	}
+	artifact := c.artifacts[match[2]]
+	match = match[1:]
+	switch {
+	case method == http.MethodDelete && match[2] == "":
+		c.requests = append(c.requests, fmt.Sprintf("delete %v", match[1]))
+		delete(c.artifacts, match[1])
+		return packageTenantResponse(http.StatusOK, nil)
+	case method == http.MethodGet && match[2] == "/$value":
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(artifact.content))}, nil
+	case method == http.MethodGet && match[2] == "/Configurations":
+		results := []cpi.ConfigurationParameter{}
+		for key, value := range artifact.configuration {
+			results = append(results, cpi.ConfigurationParameter{ParameterKey: key, ParameterValue: value, DataType: "xsd:string"})
+		}
+		return packageTenantResponse(http.StatusOK, map[string]interface{}{"d": map[string]interface{}{"results": results}})
+	case method == http.MethodPut && match[2] == "":
+		c.requests = append(c.requests, fmt.Sprintf("update %v", match[1]))
+		artifact.content, _ = b64.StdEncoding.DecodeString(payload["ArtifactContent"])
+		return packageTenantResponse(http.StatusOK, nil)
+	case method == http.MethodPut:
+		key := regexp.MustCompile(`/\$links/Configurations\('([^']+)'\)`).FindStringSubmatch(match[2])[1]
+		c.requests = append(c.requests, fmt.Sprintf("configure %v %v=%v", match[1], key, payload["ParameterValue"]))
+		artifact.configuration[key] = payload["ParameterValue"]
+		return packageTenantResponse(http.StatusAccepted, nil)
+	}
+	return packageTenantResponse(http.StatusNotFound, nil)
+}
+
+func packageTenantResponse(statusCode int, body interface{}) (*http.Response, error) {
+	data := []byte{}
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	return &http.Response{StatusCode: statusCode, Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func sortedArtifactIDs(artifacts map[string]*tenantArtifactMock) []string {
+	ids := []string{}
+	for _, id := range []string{"flow1", "flow2", "flow3", "mapping1", "scripts1"} {
+		if artifacts[id] != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func packageSyncZip(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func newPackageTenantMock(t *testing.T) *packageTenantMock {
+	return &packageTenantMock{artifacts: map[string]*tenantArtifactMock{
+		"flow1":    {name: "Flow 1", entitySet: "IntegrationDesigntimeArtifacts", content: packageSyncZip(t, map[string]string{"src/flow.xml": "<flow1/>\n"}), configuration: map[string]string{"host": "a.example.com"}},
+		"flow2":    {name: "Flow 2", entitySet: "IntegrationDesigntimeArtifacts", content: packageSyncZip(t, map[string]string{"src/flow.xml": "<flow2/>\n"}), configuration: map[string]string{}},
+		"mapping1": {name: "Mapping 1", entitySet: "ValueMappingDesigntimeArtifacts", content: packageSyncZip(t, map[string]string{"value_mapping.xml": "<vm1/>\n"})},
+	}}
+}
+
+func TestRunIntegrationPackageSync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("download", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		tenant := newPackageTenantMock(t)
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Path: dir, Mode: "download"}
+
+		err := runIntegrationPackageSync(&config, tenant)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer demotoken", tenant.options.Token)
+		content, err := os.ReadFile(filepath.Join(dir, "flow1", "content", "src", "flow.xml"))
+		assert.NoError(t, err)
+		assert.Equal(t, "<flow1/>\n", string(content))
+		configuration, err := os.ReadFile(filepath.Join(dir, "flow1", "configuration.json"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"host": "a.example.com"}`, string(configuration))
+		assert.FileExists(t, filepath.Join(dir, "flow2", "artifact.json"))
+		assert.Empty(t, tenant.requests)
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		tenant := newPackageTenantMock(t)
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Path: dir, Mode: "download"}
+		require.NoError(t, runIntegrationPackageSync(&config, tenant))
+
+		config.Mode = "diff"
+		config.FailOnDiff = true
+		assert.NoError(t, runIntegrationPackageSync(&config, tenant))
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flow2", "content", "src", "flow.xml"), []byte("<changed/>\n"), 0644))
+		err := runIntegrationPackageSync(&config, tenant)
+		assert.EqualError(t, err, "1 artifacts of integration package CICD differ between tenant and repository")
+
+		config.FailOnDiff = false
+		assert.NoError(t, runIntegrationPackageSync(&config, tenant))
+	})
+
+	t.Run("push", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		tenant := newPackageTenantMock(t)
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Path: dir, Mode: "download"}
+		require.NoError(t, runIntegrationPackageSync(&config, tenant))
+
+		// change configuration of flow1, content of flow2 and mapping1, add flow3 and scripts1
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flow1", "configuration.json"), []byte(`{"host": "b.example.com", "unknown": "x"}`), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flow2", "content", "src", "flow.xml"), []byte("<changed/>\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "mapping1", "content", "value_mapping.xml"), []byte("<changed/>\n"), 0644))
+		require.NoError(t, cpi.WritePackageArtifact(dir, cpi.PackageArtifact{
+			Artifact:      cpi.DesigntimeArtifact{ID: "flow3", Name: "Flow 3"},
+			Files:         map[string][]byte{"src/flow.xml": []byte("<flow3/>\n")},
+			Configuration: map[string]string{"host": "c.example.com"},
+		}))
+		require.NoError(t, cpi.WritePackageArtifact(dir, cpi.PackageArtifact{
+			Artifact: cpi.DesigntimeArtifact{ID: "scripts1", Name: "Scripts 1", Type: "ScriptCollection"},
+			Files:    map[string][]byte{"src/main/resources/script/util.groovy": []byte("def util() {}\n")},
+		}))
+
+		config.Mode = "push"
+		config.Deploy = true
+		err := runIntegrationPackageSync(&config, tenant)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"configure flow1 host=b.example.com", "deploy flow1",
+			"update flow2", "deploy flow2",
+			"create flow3", "configure flow3 host=c.example.com", "deploy flow3",
+			"delete mapping1", "create mapping1", "deploy mapping1",
+			"create scripts1", "deploy scripts1",
+		}, tenant.requests)
+		assert.Equal(t, "Flow 3", tenant.artifacts["flow3"].name)
+		assert.Equal(t, "ScriptCollectionDesigntimeArtifacts", tenant.artifacts["scripts1"].entitySet)
+		assert.Equal(t, "ValueMappingDesigntimeArtifacts", tenant.artifacts["mapping1"].entitySet)
+
+		// a second push does not change anything
+		tenant.requests = nil
+		assert.NoError(t, runIntegrationPackageSync(&config, tenant))
+		assert.Empty(t, tenant.requests)
+
+		config.Mode = "diff"
+		config.FailOnDiff = true
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flow1", "configuration.json"), []byte(`{"host": "b.example.com"}`), 0644))
+		assert.NoError(t, runIntegrationPackageSync(&config, tenant))
+	})
+
+	t.Run("push without deployment", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		tenant := newPackageTenantMock(t)
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Path: dir, Mode: "download"}
+		require.NoError(t, runIntegrationPackageSync(&config, tenant))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flow2", "content", "src", "flow.xml"), []byte("<changed/>\n"), 0644))
+
+		config.Mode = "push"
+		err := runIntegrationPackageSync(&config, tenant)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"update flow2"}, tenant.requests)
+	})
+
+	t.Run("push unsupported artifact type", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		require.NoError(t, cpi.WritePackageArtifact(dir, cpi.PackageArtifact{
+			Artifact: cpi.DesigntimeArtifact{ID: "api1", Name: "API 1", Type: "RestApi"},
+			Files:    map[string][]byte{"api.json": []byte("{}")},
+		}))
+		tenant := newPackageTenantMock(t)
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Path: dir, Mode: "push", Deploy: true}
+
+		err := runIntegrationPackageSync(&config, tenant)
+
+		assert.ErrorContains(t, err, "artifact api1 has the unsupported type 'RestApi', supported types: IntegrationFlow, ValueMapping, MessageMapping, ScriptCollection")
+		assert.Empty(t, tenant.requests)
+	})
+
+	t.Run("push without artifacts", func(t *testing.T) {
+		t.Parallel()
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Path: t.TempDir(), Mode: "push"}
+
+		err := runIntegrationPackageSync(&config, newPackageTenantMock(t))
+
+		assert.Contains(t, err.Error(), "no artifacts found in")
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		t.Parallel()
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "UNKNOWN", Path: t.TempDir(), Mode: "download"}
+
+		err := runIntegrationPackageSync(&config, newPackageTenantMock(t))
+
+		assert.EqualError(t, err, "failed to get artifacts of integration package UNKNOWN: HTTP GET request to https://demo/api/v1/IntegrationPackages('UNKNOWN')/IntegrationDesigntimeArtifacts failed, Response Status code: 404")
+	})
+
+	t.Run("unsupported mode", func(t *testing.T) {
+		t.Parallel()
+		config := integrationPackageSyncOptions{APIServiceKey: packageSyncServiceKey, IntegrationPackageID: "CICD", Mode: "sync"}
+
+		err := runIntegrationPackageSync(&config, newPackageTenantMock(t))
+
+		assert.EqualError(t, err, "unsupported mode 'sync', supported values: download, diff, push")
+	})
+}
@@ -2,8 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 
@@ -11,13 +22,48 @@ import (
 	"github.com/SAP/jenkins-library/pkg/certutils"
 	"github.com/SAP/jenkins-library/pkg/command"
 	"github.com/SAP/jenkins-library/pkg/docker"
+	pipergit "github.com/SAP/jenkins-library/pkg/git"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/syft"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 )
 
+// kanikoCommitTime provides the time of the current commit used as SOURCE_DATE_EPOCH for reproducible builds
+var kanikoCommitTime = func() (time.Time, error) {
+	repo, err := pipergit.PlainOpen(".")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return pipergit.CommitTime(repo, "HEAD")
+}
+
+// kanikoRemoteImage provides the information about an image in a registry required for the base image digest and the build report
+var kanikoRemoteImage = func(image string, options ...remote.Option) (v1.Image, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing image reference")
+	}
+	return remote.Image(ref, options...)
+}
+
+// kanikoRegistryAuth provides the credentials of the Docker config.json prepared for kaniko, so that private registries are accessible
+func kanikoRegistryAuth(fileUtils piperutils.FileUtils) remote.Option {
+	dockerConfig, err := fileUtils.FileRead("/kaniko/.docker/config.json")
+	if err != nil {
+		return remote.WithAuthFromKeychain(authn.DefaultKeychain)
+	}
+	keychain, err := docker.ConfigKeychain(dockerConfig)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to read registry credentials from '/kaniko/.docker/config.json'")
+		return remote.WithAuthFromKeychain(authn.DefaultKeychain)
+	}
+	return remote.WithAuthFromKeychain(keychain)
+}
+
 func kanikoExecute(config kanikoExecuteOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *kanikoExecuteCommonPipelineEnvironment) {
 	// for command execution use Command
 	c := command.Command{
@@ -134,6 +180,17 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 	}
 	commonPipelineEnvironment.custom.buildSettingsInfo = buildSettingsInfo
 
+	featureOptions, sourceDateEpoch, err := kanikoFeatureOptions(config, execRunner)
+	if err != nil {
+		return err
+	}
+	config.BuildOptions = append(config.BuildOptions, featureOptions...)
+
+	var report *docker.BuildReport
+	if config.CreateBuildReport {
+		report = &docker.BuildReport{Images: []docker.ImageBuildReport{}}
+	}
+
 	switch {
 	case config.ContainerMultiImageBuild:
 		log.Entry().Debugf("Multi-image build activated for image name '%v'", config.ContainerImageName)
@@ -170,7 +227,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 			log.Entry().Debugf("Building image '%v' using file '%v'", image, file)
 			containerImageNameAndTag := fmt.Sprintf("%v:%v", image, containerImageTag)
 			buildOpts := append(config.BuildOptions, "--destination", fmt.Sprintf("%v/%v", containerRegistry, containerImageNameAndTag))
-			if err = runKaniko(file, buildOpts, config.ReadImageDigest, execRunner, fileUtils, commonPipelineEnvironment); err != nil {
+			if err = runKaniko(file, buildOpts, config.ReadImageDigest, config.PinBaseImage, execRunner, fileUtils, commonPipelineEnvironment, report); err != nil {
 				return fmt.Errorf("failed to build image '%v' using '%v': %w", image, file, err)
 			}
 			commonPipelineEnvironment.container.imageNames = append(commonPipelineEnvironment.container.imageNames, image)
@@ -184,6 +241,9 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 			containerImageNameAndTag := fmt.Sprintf("%v:%v", config.ContainerImageName, containerImageTag)
 			commonPipelineEnvironment.container.imageNameTag = containerImageNameAndTag
 		}
+		if err := finishKanikoBuild(config, report, sourceDateEpoch, commonPipelineEnvironment, fileUtils); err != nil {
+			return err
+		}
 		if config.CreateBOM {
 			// Syft for multi image, generates bom-docker-(1/2/3).xml
 			return syft.GenerateSBOM(config.SyftDownloadURL, "/kaniko/.docker", execRunner, fileUtils, httpClient, commonPipelineEnvironment.container.registryURL, commonPipelineEnvironment.container.imageNameTags)
@@ -231,7 +291,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 					dockerfilePath = entry.DockerfilePath
 				}
 
-				if err = runKaniko(dockerfilePath, buildOptions, config.ReadImageDigest, execRunner, fileUtils, commonPipelineEnvironment); err != nil {
+				if err = runKaniko(dockerfilePath, buildOptions, config.ReadImageDigest, config.PinBaseImage, execRunner, fileUtils, commonPipelineEnvironment, report); err != nil {
 					return fmt.Errorf("multipleImages: failed to build image '%v' using '%v': %w", entry.ContainerImageName, config.DockerfilePath, err)
 				}
 
@@ -262,7 +322,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 					dockerfilePath = entry.DockerfilePath
 				}
 
-				if err = runKaniko(dockerfilePath, buildOptions, config.ReadImageDigest, execRunner, fileUtils, commonPipelineEnvironment); err != nil {
+				if err = runKaniko(dockerfilePath, buildOptions, config.ReadImageDigest, config.PinBaseImage, execRunner, fileUtils, commonPipelineEnvironment, report); err != nil {
 					return fmt.Errorf("multipleImages: failed to build image '%v' using '%v': %w", containerImageName, config.DockerfilePath, err)
 				}
 
@@ -281,6 +341,9 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 		commonPipelineEnvironment.container.imageNameTag = containerImageNameAndTag
 		commonPipelineEnvironment.container.registryURL = config.ContainerRegistryURL
 
+		if err := finishKanikoBuild(config, report, sourceDateEpoch, commonPipelineEnvironment, fileUtils); err != nil {
+			return err
+		}
 		if config.CreateBOM {
 			// Syft for multi image, generates bom-docker-(1/2/3).xml
 			return syft.GenerateSBOM(config.SyftDownloadURL, "/kaniko/.docker", execRunner, fileUtils, httpClient, commonPipelineEnvironment.container.registryURL, commonPipelineEnvironment.container.imageNameTags)
@@ -356,7 +419,10 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 		config.BuildOptions = append(config.BuildOptions, "--no-push")
 	}
 
-	if err = runKaniko(config.DockerfilePath, config.BuildOptions, config.ReadImageDigest, execRunner, fileUtils, commonPipelineEnvironment); err != nil {
+	if err = runKaniko(config.DockerfilePath, config.BuildOptions, config.ReadImageDigest, config.PinBaseImage, execRunner, fileUtils, commonPipelineEnvironment, report); err != nil {
+		return err
+	}
+	if err := finishKanikoBuild(config, report, sourceDateEpoch, commonPipelineEnvironment, fileUtils); err != nil {
 		return err
 	}
 
@@ -368,7 +434,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 	return nil
 }
 
-func runKaniko(dockerFilepath string, buildOptions []string, readDigest bool, execRunner command.ExecRunner, fileUtils piperutils.FileUtils, commonPipelineEnvironment *kanikoExecuteCommonPipelineEnvironment) error {
+func runKaniko(dockerFilepath string, buildOptions []string, readDigest, pinBaseImage bool, execRunner command.ExecRunner, fileUtils piperutils.FileUtils, commonPipelineEnvironment *kanikoExecuteCommonPipelineEnvironment, report *docker.BuildReport) error {
 	cwd, err := fileUtils.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current working directory: %w", err)
@@ -379,6 +445,16 @@ func runKaniko(dockerFilepath string, buildOptions []string, readDigest bool, ex
 	kanikoOpts := []string{"--dockerfile", dockerFilepath, "--context", "dir://" + cwd}
 	kanikoOpts = append(kanikoOpts, buildOptions...)
 
+	// the base image is resolved before the build and pinned on request, so that the recorded digest is the one the image is built on
+	baseImage, baseImageArg := kanikoBaseImage(dockerFilepath, buildOptions, fileUtils)
+	if pinBaseImage && len(baseImage.Digest) > 0 && !strings.Contains(baseImage.Reference, "@") {
+		if len(baseImageArg) > 0 {
+			kanikoOpts = append(kanikoOpts, "--build-arg", baseImageArg+"="+baseImage.String())
+		} else {
+			log.Entry().Infof("Base image '%v' is not given by a build argument and can't be pinned, its digest has been resolved right before the build", baseImage.Reference)
+		}
+	}
+
 	tmpDir, err := fileUtils.TempDir("", "*-kanikoExecute")
 	if err != nil {
 		return fmt.Errorf("failed to create tmp dir for kanikoExecute: %w", err)
@@ -400,13 +476,14 @@ func runKaniko(dockerFilepath string, buildOptions []string, readDigest bool, ex
 		return errors.Wrap(err, "execution of '/kaniko/executor' failed")
 	}
 
+	digestStr := ""
 	if b, err := fileUtils.FileExists(digestFilePath); err == nil && b {
 		digest, err := fileUtils.FileRead(digestFilePath)
 		if err != nil {
 			return errors.Wrap(err, "error while reading image digest")
 		}
 
-		digestStr := string(digest)
+		digestStr = string(digest)
 
 		log.Entry().Debugf("image digest: %s", digestStr)
 
@@ -414,6 +491,252 @@ func runKaniko(dockerFilepath string, buildOptions []string, readDigest bool, ex
 		commonPipelineEnvironment.container.imageDigests = append(commonPipelineEnvironment.container.imageDigests, digestStr)
 	}
 
+	if len(baseImage.Reference) > 0 {
+		commonPipelineEnvironment.container.baseImages = append(commonPipelineEnvironment.container.baseImages, baseImage.String())
+	}
+
+	if report != nil {
+		report.Images = append(report.Images, kanikoImageReport(dockerFilepath, buildOptions, digestStr, baseImage, fileUtils))
+	}
+
+	return nil
+}
+
+// kanikoFeatureOptions translates the parameters for caching, reproducible builds and build arguments into kaniko options.
+// It returns the SOURCE_DATE_EPOCH used for reproducible builds, 0 otherwise.
+func kanikoFeatureOptions(config *kanikoExecuteOptions, execRunner command.ExecRunner) ([]string, int64, error) {
+	options := []string{}
+	if config.Cache {
+		options = append(options, "--cache=true")
+		if len(config.CacheRepo) > 0 {
+			options = append(options, "--cache-repo="+config.CacheRepo)
+		}
+		if len(config.CacheTTL) > 0 {
+			options = append(options, "--cache-ttl="+config.CacheTTL)
+		}
+	}
+
+	if len(config.BuildArgs) > 0 {
+		buildArgs, err := kanikoBuildArgs(config.BuildArgs)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, 0, err
+		}
+		for _, buildArg := range buildArgs {
+			options = append(options, "--build-arg", buildArg)
+		}
+	}
+
+	var sourceDateEpoch int64
+	if config.Reproducible {
+		var err error
+		if sourceDateEpoch, err = kanikoSourceDateEpoch(); err != nil {
+			return nil, 0, err
+		}
+		log.Entry().Infof("Building reproducible image with SOURCE_DATE_EPOCH=%v", sourceDateEpoch)
+		options = append(options, "--reproducible", "--build-arg", fmt.Sprintf("SOURCE_DATE_EPOCH=%v", sourceDateEpoch))
+		execRunner.AppendEnv([]string{fmt.Sprintf("SOURCE_DATE_EPOCH=%v", sourceDateEpoch)})
+	}
+	return options, sourceDateEpoch, nil
+}
+
+// kanikoBuildArgs resolves references to the commonPipelineEnvironment in the values of the build arguments
+func kanikoBuildArgs(buildArgs map[string]interface{}) ([]string, error) {
+	cpe := piperenv.CPEMap{}
+	if err := cpe.LoadFromDisk(path.Join(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")); err != nil {
+		log.Entry().Warning("failed to load values from commonPipelineEnvironment")
+	}
+
+	names := make([]string, 0, len(buildArgs))
+	for name := range buildArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []string{}
+	for _, name := range names {
+		value, err := cpe.ParseTemplate(fmt.Sprint(buildArgs[name]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve build argument '%v'", name)
+		}
+		result = append(result, fmt.Sprintf("%v=%v", name, value.String()))
+	}
+	return result, nil
+}
+
+// kanikoSourceDateEpoch returns the SOURCE_DATE_EPOCH from the environment or the time of the current commit
+func kanikoSourceDateEpoch() (int64, error) {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); len(epoch) > 0 {
+		sourceDateEpoch, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return 0, errors.Wrapf(err, "invalid SOURCE_DATE_EPOCH '%v'", epoch)
+		}
+		return sourceDateEpoch, nil
+	}
+	commitTime, err := kanikoCommitTime()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to determine commit time for SOURCE_DATE_EPOCH")
+	}
+	return commitTime.Unix(), nil
+}
+
+// kanikoBaseImage determines the base image of the final stage of the Dockerfile, the digest it currently points to
+// and the build argument it is given by, if any.
+// Errors are only logged since the base image information must not fail an otherwise successful build.
+func kanikoBaseImage(dockerfilePath string, buildOptions []string, fileUtils piperutils.FileUtils) (docker.BaseImage, string) {
+	buildArgs := map[string]string{}
+	contextSubPath := ""
+	customPlatform := ""
+	for i := 0; i < len(buildOptions); i++ {
+		if platform, ok := strings.CutPrefix(buildOptions[i], "--custom-platform="); ok {
+			customPlatform = platform
+			continue
+		}
+		if i+1 == len(buildOptions) {
+			break
+		}
+		switch buildOptions[i] {
+		case "--build-arg":
+			name, value, _ := strings.Cut(buildOptions[i+1], "=")
+			buildArgs[name] = value
+		case "--context-sub-path":
+			contextSubPath = buildOptions[i+1]
+		case "--custom-platform":
+			customPlatform = buildOptions[i+1]
+		}
+	}
+
+	dockerfile, err := fileUtils.FileRead(filepath.Join(contextSubPath, dockerfilePath))
+	if err != nil {
+		dockerfile, err = fileUtils.FileRead(dockerfilePath)
+	}
+	if err != nil {
+		log.Entry().WithError(err).Warnf("failed to read '%v' to determine the base image", dockerfilePath)
+		return docker.BaseImage{}, ""
+	}
+
+	baseStage := docker.DockerfileBaseStage(dockerfile, buildArgs)
+	baseImage := docker.BaseImage{Reference: baseStage.BaseImage}
+	if len(baseImage.Reference) == 0 {
+		return baseImage, ""
+	}
+	platform, err := kanikoBaseImagePlatform(baseStage.Platform, customPlatform)
+	if err != nil {
+		log.Entry().WithError(err).Warnf("failed to determine the platform of base image '%v'", baseImage.Reference)
+		return baseImage, ""
+	}
+	if img, err := kanikoRemoteImage(baseImage.Reference, remote.WithPlatform(platform), kanikoRegistryAuth(fileUtils)); err != nil {
+		log.Entry().WithError(err).Warnf("failed to retrieve base image '%v'", baseImage.Reference)
+	} else if digest, err := img.Digest(); err == nil {
+		baseImage.Digest = digest.String()
+	}
+	log.Entry().Infof("Base image: %v (%v)", baseImage, platform)
+	return baseImage, baseStage.BaseImageArg
+}
+
+// kanikoBaseImagePlatform returns the platform kaniko pulls the base image for: the platform of the stage,
+// the custom platform of the build or the platform kaniko is running on, in this order
+func kanikoBaseImagePlatform(stagePlatform, customPlatform string) (v1.Platform, error) {
+	for _, platform := range []string{stagePlatform, customPlatform} {
+		// platforms depending on automatic platform arguments like $BUILDPLATFORM remain unresolved
+		if len(platform) > 0 && !strings.Contains(platform, "$") {
+			parsed, err := v1.ParsePlatform(platform)
+			if err != nil {
+				return v1.Platform{}, err
+			}
+			return *parsed, nil
+		}
+	}
+	return v1.Platform{OS: "linux", Architecture: runtime.GOARCH}, nil
+}
+
+// kanikoImageReport collects the layers of the built image.
+// Errors are only logged since the report must not fail an otherwise successful build.
+func kanikoImageReport(dockerfilePath string, buildOptions []string, digest string, baseImage docker.BaseImage, fileUtils piperutils.FileUtils) docker.ImageBuildReport {
+	imageReport := docker.ImageBuildReport{
+		Dockerfile:      dockerfilePath,
+		Digest:          digest,
+		BaseImage:       baseImage.Reference,
+		BaseImageDigest: baseImage.Digest,
+		Layers:          []docker.LayerInfo{},
+	}
+	for i := 0; i+1 < len(buildOptions); i++ {
+		if buildOptions[i] == "--destination" {
+			imageReport.Image = buildOptions[i+1]
+			break
+		}
+	}
+
+	if len(imageReport.Image) == 0 {
+		log.Entry().Info("Image has not been pushed, layers are not contained in the build report")
+		return imageReport
+	}
+	image, err := kanikoRemoteImage(imageReport.Image, kanikoRegistryAuth(fileUtils))
+	if err != nil {
+		log.Entry().WithError(err).Warnf("failed to retrieve image '%v' for the build report", imageReport.Image)
+		return imageReport
+	}
+	if len(imageReport.Digest) == 0 {
+		if imageDigest, err := image.Digest(); err == nil {
+			imageReport.Digest = imageDigest.String()
+		}
+	}
+	if imageReport.Layers, imageReport.Size, err = docker.ImageLayers(image); err != nil {
+		log.Entry().WithError(err).Warnf("failed to retrieve layers of image '%v' for the build report", imageReport.Image)
+		imageReport.Layers = []docker.LayerInfo{}
+	}
+	for _, layer := range imageReport.Layers {
+		log.Entry().Infof("%v: %v bytes %v", layer.Digest, layer.Size, layer.CreatedBy)
+	}
+	return imageReport
+}
+
+// finishKanikoBuild writes the build report, records the base images in a toolrecord and signs the pushed images
+func finishKanikoBuild(config *kanikoExecuteOptions, report *docker.BuildReport, sourceDateEpoch int64, commonPipelineEnvironment *kanikoExecuteCommonPipelineEnvironment, fileUtils piperutils.FileUtils) error {
+	reports := []piperutils.Path{}
+	baseImages := []docker.BaseImage{}
+	for _, baseImage := range commonPipelineEnvironment.container.baseImages {
+		baseImages = append(baseImages, docker.ParseBaseImage(baseImage))
+	}
+	toolRecordFileName, err := docker.PersistBaseImageToolrecord(fileUtils, "./", "kanikoExecute", commonPipelineEnvironment.container.registryURL, baseImages)
+	if err != nil {
+		// do not fail - the image has been built successfully
+		log.Entry().WithError(err).Warning("failed to create toolrecord file")
+	} else if len(toolRecordFileName) > 0 {
+		reports = append(reports, piperutils.Path{Target: toolRecordFileName})
+	}
+
+	if report != nil {
+		for i := range report.Images {
+			report.Images[i].SourceDateEpoch = sourceDateEpoch
+		}
+		buildReports, err := docker.WriteBuildReport(*report, fileUtils)
+		if err != nil {
+			// do not fail - the image has been built successfully
+			log.Entry().WithError(err).Warning("failed to create build report")
+		}
+		reports = append(reports, buildReports...)
+	}
+	if len(reports) > 0 {
+		piperutils.PersistReportsAndLinks("kanikoExecute", "", fileUtils, reports, nil)
+	}
+
+	if !config.SignImages {
+		return nil
+	}
+	if len(commonPipelineEnvironment.container.imageNameTags) == 0 {
+		log.Entry().Warn("No images have been pushed, signing is skipped")
+		return nil
+	}
+	images, err := signing.ImageReferences(commonPipelineEnvironment.container.registryURL, commonPipelineEnvironment.container.imageNameTags, commonPipelineEnvironment.container.imageDigests)
+	if err != nil {
+		return err
+	}
+	signingConfig := signing.Config{SigningKey: config.SigningKey, SigningKeyPassword: config.SigningKeyPassword, SignatureMode: config.SignatureMode}
+	if err := signing.SignImages(images, signingConfig, fileUtils); err != nil {
+		return errors.Wrap(err, "failed to sign images")
+	}
 	return nil
 }
 
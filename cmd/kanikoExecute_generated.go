@@ -24,6 +24,12 @@ import (
 
 type kanikoExecuteOptions struct {
 	BuildOptions                     []string                 `json:"buildOptions,omitempty"`
+	BuildArgs                        map[string]interface{}   `json:"buildArgs,omitempty"`
+	Cache                            bool                     `json:"cache,omitempty"`
+	CacheRepo                        string                   `json:"cacheRepo,omitempty"`
+	CacheTTL                         string                   `json:"cacheTTL,omitempty"`
+	Reproducible                     bool                     `json:"reproducible,omitempty"`
+	CreateBuildReport                bool                     `json:"createBuildReport,omitempty"`
 	BuildSettingsInfo                string                   `json:"buildSettingsInfo,omitempty"`
 	ContainerBuildOptions            string                   `json:"containerBuildOptions,omitempty"`
 	ContainerImage                   string                   `json:"containerImage,omitempty"`
@@ -42,8 +48,13 @@ type kanikoExecuteOptions struct {
 	DockerfilePath                   string                   `json:"dockerfilePath,omitempty"`
 	TargetArchitectures              []string                 `json:"targetArchitectures,omitempty"`
 	ReadImageDigest                  bool                     `json:"readImageDigest,omitempty"`
+	PinBaseImage                     bool                     `json:"pinBaseImage,omitempty"`
 	CreateBOM                        bool                     `json:"createBOM,omitempty"`
 	SyftDownloadURL                  string                   `json:"syftDownloadUrl,omitempty"`
+	SignImages                       bool                     `json:"signImages,omitempty"`
+	SigningKey                       string                   `json:"signingKey,omitempty"`
+	SigningKeyPassword               string                   `json:"signingKeyPassword,omitempty"`
+	SignatureMode                    string                   `json:"signatureMode,omitempty" validate:"possible-values=tag referrers"`
 }
 
 type kanikoExecuteCommonPipelineEnvironment struct {
@@ -54,6 +65,7 @@ type kanikoExecuteCommonPipelineEnvironment struct {
 		imageNames    []string
 		imageNameTags []string
 		imageDigests  []string
+		baseImages    []string
 	}
 	custom struct {
 		buildSettingsInfo string
@@ -72,6 +84,7 @@ func (p *kanikoExecuteCommonPipelineEnvironment) persist(path, resourceName stri
 		{category: "container", name: "imageNames", value: p.container.imageNames},
 		{category: "container", name: "imageNameTags", value: p.container.imageNameTags},
 		{category: "container", name: "imageDigests", value: p.container.imageDigests},
+		{category: "container", name: "baseImages", value: p.container.baseImages},
 		{category: "custom", name: "buildSettingsInfo", value: p.custom.buildSettingsInfo},
 	}
 
@@ -99,6 +112,8 @@ func (p *kanikoExecuteReports) persist(stepConfig kanikoExecuteOptions, gcpJsonK
 	log.Entry().Info("Uploading reports to Google Cloud Storage...")
 	content := []gcs.ReportOutputParam{
 		{FilePattern: "**/bom-*.xml", ParamRef: "", StepResultType: "sbom"},
+		{FilePattern: "**/container_build_report.json", ParamRef: "", StepResultType: "container-build"},
+		{FilePattern: "**/toolrun_kanikoExecute_*.json", ParamRef: "", StepResultType: "container-build"},
 	}
 	envVars := []gcs.EnvVar{
 		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
@@ -207,7 +222,37 @@ Following Dockerfiles are available in the repository:
 Following final image names will be built:
 
 * ` + "`" + `myImage-sub1` + "`" + `
-* ` + "`" + `myImage-sub2` + "`" + ``,
+* ` + "`" + `myImage-sub2` + "`" + `
+
+### Layer caching
+
+With [cache](#cache) activated, kaniko pushes the layers created by ` + "`" + `RUN` + "`" + ` and ` + "`" + `COPY` + "`" + ` instructions to the repository
+[cacheRepo](#cacherepo) and reuses them in subsequent builds as long as the instruction and its inputs do not change.
+This speeds up builds spending most of their time on dependency layers, also for the intermediate stages of multi-stage builds.
+
+### Reproducible builds
+
+With [reproducible](#reproducible) activated, timestamps are stripped from the image and ` + "`" + `SOURCE_DATE_EPOCH` + "`" + ` is set to the commit time of the
+current commit. It is passed to the build as environment variable and as build argument, so that tools within the build can use it as well.
+
+### Build report
+
+With [createBuildReport](#createbuildreport) activated, the file ` + "`" + `container_build_report.json` + "`" + ` is created listing the layers of each pushed image
+together with their size as well as the base image of the Dockerfile and its digest.
+
+### Base images
+
+The base image of the final stage of each Dockerfile is recorded together with its digest in the commonPipelineEnvironment (` + "`" + `container/baseImages` + "`" + `)
+and in the toolrecord file ` + "`" + `toolrun_kanikoExecute_all.json` + "`" + `. Use the step ` + "`" + `containerCheckBaseImage` + "`" + ` to detect when a newer version of a base image is available.
+The digest is resolved before the build for the platform of the stage, the custom platform of the build or the platform kaniko is running on, using the registry credentials of the step.
+With [pinBaseImage](#pinbaseimage) activated and the base image given by a build argument (e.g. ` + "`" + `FROM ${BASE_IMAGE}` + "`" + `), the build argument is pinned to this digest,
+so that the recorded digest is the one the image is built on.
+
+### Image signing
+
+If ` + "`" + `signImages` + "`" + ` is activated, the pushed images are signed with the private key provided via ` + "`" + `signingKey` + "`" + `.
+Keys generated with ` + "`" + `cosign generate-key-pair` + "`" + ` as well as unencrypted PEM encoded EC, RSA and ed25519 keys are supported.
+The signatures are compatible with [cosign](https://github.com/sigstore/cosign) and can be verified with ` + "`" + `cosign verify --key cosign.pub <image>` + "`" + ` or the step ` + "`" + `containerVerifySignature` + "`" + `.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -225,6 +270,8 @@ Following final image names will be built:
 				return err
 			}
 			log.RegisterSecret(stepConfig.DockerConfigJSON)
+			log.RegisterSecret(stepConfig.SigningKey)
+			log.RegisterSecret(stepConfig.SigningKeyPassword)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
 				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
@@ -264,9 +311,11 @@ Following final image names will be built:
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -314,6 +363,12 @@ Following final image names will be built:
 
 func addKanikoExecuteFlags(cmd *cobra.Command, stepConfig *kanikoExecuteOptions) {
 	cmd.Flags().StringSliceVar(&stepConfig.BuildOptions, "buildOptions", []string{`--skip-tls-verify-pull`, `--ignore-path=/workspace`, `--ignore-path=/busybox`}, "Defines a list of build options for the [kaniko](https://github.com/GoogleContainerTools/kaniko) build.")
+
+	cmd.Flags().BoolVar(&stepConfig.Cache, "cache", false, "Activates caching of layers in the registry repository defined via `cacheRepo`.")
+	cmd.Flags().StringVar(&stepConfig.CacheRepo, "cacheRepo", os.Getenv("PIPER_cacheRepo"), "Defines the repository layers are cached in, e.g. `my.docker.registry/myImage/cache`. If not provided, kaniko derives it from the destination of the image.")
+	cmd.Flags().StringVar(&stepConfig.CacheTTL, "cacheTTL", os.Getenv("PIPER_cacheTTL"), "Defines how long cached layers are used, e.g. `168h`. If not provided, the kaniko default of two weeks applies.")
+	cmd.Flags().BoolVar(&stepConfig.Reproducible, "reproducible", false, "Creates reproducible images by stripping timestamps and setting `SOURCE_DATE_EPOCH` to the commit time of the current commit. An existing `SOURCE_DATE_EPOCH` environment variable takes precedence.")
+	cmd.Flags().BoolVar(&stepConfig.CreateBuildReport, "createBuildReport", false, "Creates a report listing the layers and their sizes as well as the base image digest of each built image.")
 	cmd.Flags().StringVar(&stepConfig.BuildSettingsInfo, "buildSettingsInfo", os.Getenv("PIPER_buildSettingsInfo"), "Build settings info is typically filled by the step automatically to create information about the build settings that were used during the mta build. This information is typically used for compliance related processes.")
 	cmd.Flags().StringVar(&stepConfig.ContainerBuildOptions, "containerBuildOptions", os.Getenv("PIPER_containerBuildOptions"), "Deprected, please use buildOptions. Defines the build options for the [kaniko](https://github.com/GoogleContainerTools/kaniko) build.")
 	cmd.Flags().StringVar(&stepConfig.ContainerImage, "containerImage", os.Getenv("PIPER_containerImage"), "Defines the full name of the Docker image to be created including registry, image name and tag like `my.docker.registry/path/myImageName:myTag`. If `containerImage` is not provided, then `containerImageName` or `--destination` (via buildOptions) should be provided.")
@@ -332,8 +387,13 @@ func addKanikoExecuteFlags(cmd *cobra.Command, stepConfig *kanikoExecuteOptions)
 	cmd.Flags().StringVar(&stepConfig.DockerfilePath, "dockerfilePath", `Dockerfile`, "Defines the location of the Dockerfile relative to the Jenkins workspace.")
 	cmd.Flags().StringSliceVar(&stepConfig.TargetArchitectures, "targetArchitectures", []string{``}, "Defines the target architectures for which the build should run using OS and architecture separated by a comma. (EXPERIMENTAL)")
 	cmd.Flags().BoolVar(&stepConfig.ReadImageDigest, "readImageDigest", false, "")
+	cmd.Flags().BoolVar(&stepConfig.PinBaseImage, "pinBaseImage", false, "Pins the base image of the final stage to the digest resolved before the build, so that the recorded base image digest is the one the image is built on. Requires the base image to be given by a build argument, e.g. `FROM ${BASE_IMAGE}`.")
 	cmd.Flags().BoolVar(&stepConfig.CreateBOM, "createBOM", false, "Creates the bill of materials (BOM) using Syft and stores it in a file in CycloneDX 1.4 format.")
 	cmd.Flags().StringVar(&stepConfig.SyftDownloadURL, "syftDownloadUrl", `https://github.com/anchore/syft/releases/download/v1.4.1/syft_1.4.1_linux_amd64.tar.gz`, "Specifies the download url of the Syft Linux amd64 tar binary file. This can be found at https://github.com/anchore/syft/releases/.")
+	cmd.Flags().BoolVar(&stepConfig.SignImages, "signImages", false, "Signs the pushed images with [signingKey](#signingkey). The signatures are compatible with [cosign](https://github.com/sigstore/cosign) and can be verified with `containerVerifySignature`, `cosign verify` or admission controllers like the Sigstore policy-controller.")
+	cmd.Flags().StringVar(&stepConfig.SigningKey, "signingKey", os.Getenv("PIPER_signingKey"), "Path to the PEM encoded private key used for signing the images. Keys created with `cosign generate-key-pair` as well as unencrypted PKCS#8 keys are supported.")
+	cmd.Flags().StringVar(&stepConfig.SigningKeyPassword, "signingKeyPassword", os.Getenv("PIPER_signingKeyPassword"), "Password of the [signingKey](#signingkey).")
+	cmd.Flags().StringVar(&stepConfig.SignatureMode, "signatureMode", `tag`, "Defines where the signatures are stored. `tag` stores them in the tag `sha256-<digest>.sig` of the image repository like cosign does by default, `referrers` stores them as OCI 1.1 artifacts referring to the image.")
 
 }
 
@@ -349,6 +409,8 @@ func kanikoExecuteMetadata() config.StepData {
 			Inputs: config.StepInputs{
 				Secrets: []config.StepSecrets{
 					{Name: "dockerConfigJsonCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing Docker config.json (with registry credential(s)). You can create it like explained in the [protocodeExecuteScan Prerequisites section](https://www.project-piper.io/steps/protecodeExecuteScan/#prerequisites).", Type: "jenkins"},
+					{Name: "signingKeyCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing the private key used for signing the images.", Type: "jenkins"},
+					{Name: "signingKeyPasswordCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing the password of the private key used for signing the images.", Type: "jenkins"},
 				},
 				Parameters: []config.StepParameters{
 					{
@@ -360,6 +422,59 @@ func kanikoExecuteMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     []string{`--skip-tls-verify-pull`, `--ignore-path=/workspace`, `--ignore-path=/busybox`},
 					},
+					{
+						Name:        "buildArgs",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "map[string]interface{}",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+					},
+					{
+						Name:        "cache",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "cacheRepo",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_cacheRepo"),
+					},
+					{
+						Name:        "cacheTTL",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_cacheTTL"),
+					},
+					{
+						Name:        "reproducible",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "createBuildReport",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name: "buildSettingsInfo",
 						ResourceRef: []config.ResourceReference{
@@ -557,6 +672,15 @@ func kanikoExecuteMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "pinBaseImage",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "STEPS", "STAGES", "PARAMETERS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "createBOM",
 						ResourceRef: []config.ResourceReference{},
@@ -575,6 +699,64 @@ func kanikoExecuteMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `https://github.com/anchore/syft/releases/download/v1.4.1/syft_1.4.1_linux_amd64.tar.gz`,
 					},
+					{
+						Name:        "signImages",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name: "signingKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signingKeyCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signingKeyVaultSecretName",
+								Type:    "vaultSecretFile",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signingKey"),
+					},
+					{
+						Name: "signingKeyPassword",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signingKeyPasswordCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signingKeyVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signingKeyPassword"),
+					},
+					{
+						Name:        "signatureMode",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `tag`,
+					},
 				},
 			},
 			Containers: []config.Container{
@@ -592,6 +774,7 @@ func kanikoExecuteMetadata() config.StepData {
 							{"name": "container/imageNames", "type": "[]string"},
 							{"name": "container/imageNameTags", "type": "[]string"},
 							{"name": "container/imageDigests", "type": "[]string"},
+							{"name": "container/baseImages", "type": "[]string"},
 							{"name": "custom/buildSettingsInfo"},
 						},
 					},
@@ -600,6 +783,8 @@ func kanikoExecuteMetadata() config.StepData {
 						Type: "reports",
 						Parameters: []map[string]interface{}{
 							{"filePattern": "**/bom-*.xml", "type": "sbom"},
+							{"filePattern": "**/container_build_report.json", "type": "container-build"},
+							{"filePattern": "**/toolrun_kanikoExecute_*.json", "type": "container-build"},
 						},
 					},
 				},
@@ -5,18 +5,28 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/SAP/jenkins-library/pkg/docker"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type kanikoMockClient struct {
@@ -863,3 +873,245 @@ func TestRunKanikoExecute(t *testing.T) {
 		assert.Contains(t, fmt.Sprint(err), "multipleImages: empty contextSubPath")
 	})
 }
+
+func TestRunKanikoExecuteBuildFeatures(t *testing.T) {
+	openFileBak := configOptions.OpenFile
+	defer func() {
+		configOptions.OpenFile = openFileBak
+	}()
+	configOptions.OpenFile = configOpenFileMock
+
+	commitTimeBak := kanikoCommitTime
+	remoteImageBak := kanikoRemoteImage
+	defer func() {
+		kanikoCommitTime = commitTimeBak
+		kanikoRemoteImage = remoteImageBak
+	}()
+	kanikoCommitTime = func() (time.Time, error) {
+		return time.Unix(1709294400, 0), nil
+	}
+
+	t.Run("success case - cache, build args and reproducible build", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "")
+		envRootPathBak := GeneralConfig.EnvRootPath
+		defer func() { GeneralConfig.EnvRootPath = envRootPathBak }()
+		GeneralConfig.EnvRootPath = t.TempDir()
+		require.NoError(t, piperenv.SetResourceParameter(GeneralConfig.EnvRootPath, "commonPipelineEnvironment", "artifactVersion", "1.2.3"))
+
+		config := &kanikoExecuteOptions{
+			BuildOptions:   []string{"--skip-tls-verify-pull"},
+			ContainerImage: "myImage:tag",
+			DockerfilePath: "Dockerfile",
+			Cache:          true,
+			CacheRepo:      "my.registry/myImage/cache",
+			CacheTTL:       "168h",
+			BuildArgs:      map[string]interface{}{"VERSION": `{{cpe "artifactVersion"}}`, "STATIC": "value"},
+			Reproducible:   true,
+		}
+		execRunner := &mock.ExecMockRunner{Env: []string{"HTTPS_PROXY=http://proxy.example.com"}}
+		fileUtils := &mock.FilesMock{}
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, execRunner, &kanikoMockClient{}, fileUtils)
+
+		require.NoError(t, err)
+		cwd, _ := fileUtils.Getwd()
+		assert.Equal(t, []string{"--dockerfile", "Dockerfile", "--context", "dir://" + cwd, "--skip-tls-verify-pull",
+			"--cache=true", "--cache-repo=my.registry/myImage/cache", "--cache-ttl=168h",
+			"--build-arg", "STATIC=value", "--build-arg", "VERSION=1.2.3",
+			"--reproducible", "--build-arg", "SOURCE_DATE_EPOCH=1709294400",
+			"--destination", "myImage:tag"}, execRunner.Calls[0].Params)
+		assert.Equal(t, []string{"HTTPS_PROXY=http://proxy.example.com", "SOURCE_DATE_EPOCH=1709294400"}, execRunner.Env)
+		assert.False(t, fileUtils.HasFile(docker.BuildReportFilename))
+	})
+
+	t.Run("success case - SOURCE_DATE_EPOCH from environment", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "42")
+		config := &kanikoExecuteOptions{DockerfilePath: "Dockerfile", Reproducible: true}
+		execRunner := &mock.ExecMockRunner{}
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, execRunner, &kanikoMockClient{}, &mock.FilesMock{})
+
+		require.NoError(t, err)
+		assert.Contains(t, execRunner.Calls[0].Params, "SOURCE_DATE_EPOCH=42")
+	})
+
+	t.Run("success case - build report", func(t *testing.T) {
+		baseImage, err := random.Image(100, 1)
+		require.NoError(t, err)
+		builtImage, err := random.Image(100, 3)
+		require.NoError(t, err)
+		requestedImages := []string{}
+		kanikoRemoteImage = func(image string, options ...remote.Option) (v1.Image, error) {
+			requestedImages = append(requestedImages, image)
+			if image == "alpine:3.19" {
+				return baseImage, nil
+			}
+			return builtImage, nil
+		}
+
+		config := &kanikoExecuteOptions{
+			ContainerImage:    "my.registry/myImage:tag",
+			DockerfilePath:    "Dockerfile",
+			BuildOptions:      []string{"--build-arg", "BASE=alpine:3.19"},
+			CreateBuildReport: true,
+			PinBaseImage:      true,
+		}
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("Dockerfile", []byte("ARG BASE=ubuntu\nFROM golang AS build\nFROM ${BASE}\nCOPY --from=build /app /app\n"))
+		execRunner := &mock.ExecMockRunner{}
+
+		err = runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, execRunner, &kanikoMockClient{}, fileUtils)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alpine:3.19", "my.registry/myImage:tag"}, requestedImages)
+		baseDigest, _ := baseImage.Digest()
+		cwd, _ := fileUtils.Getwd()
+		assert.Equal(t, []string{"--dockerfile", "Dockerfile", "--context", "dir://" + cwd, "--build-arg", "BASE=alpine:3.19",
+			"--destination", "my.registry/myImage:tag", "--build-arg", "BASE=alpine:3.19@" + baseDigest.String()}, execRunner.Calls[0].Params, "base image is pinned to the recorded digest")
+		content, err := fileUtils.FileRead(docker.BuildReportFilename)
+		require.NoError(t, err)
+		report := docker.BuildReport{}
+		require.NoError(t, json.Unmarshal(content, &report))
+		if assert.Len(t, report.Images, 1) {
+			imageDigest, _ := builtImage.Digest()
+			assert.Equal(t, "my.registry/myImage:tag", report.Images[0].Image)
+			assert.Equal(t, imageDigest.String(), report.Images[0].Digest)
+			assert.Equal(t, "alpine:3.19", report.Images[0].BaseImage)
+			assert.Equal(t, baseDigest.String(), report.Images[0].BaseImageDigest)
+			assert.Len(t, report.Images[0].Layers, 3)
+		}
+	})
+
+	t.Run("success case - base image recorded", func(t *testing.T) {
+		baseImage, err := random.Image(100, 1)
+		require.NoError(t, err)
+		baseDigest, err := baseImage.Digest()
+		require.NoError(t, err)
+		kanikoRemoteImage = func(image string, options ...remote.Option) (v1.Image, error) {
+			return baseImage, nil
+		}
+		config := &kanikoExecuteOptions{ContainerRegistryURL: "https://my.registry", ContainerImageName: "myImage", ContainerImageTag: "tag", DockerfilePath: "Dockerfile"}
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("Dockerfile", []byte("ARG BASE=alpine:3.19\nFROM ${BASE}\n"))
+		cpe := &kanikoExecuteCommonPipelineEnvironment{}
+		execRunner := &mock.ExecMockRunner{}
+
+		err = runKanikoExecute(config, &telemetry.CustomData{}, cpe, execRunner, &kanikoMockClient{}, fileUtils)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alpine:3.19@" + baseDigest.String()}, cpe.container.baseImages)
+		assert.NotContains(t, execRunner.Calls[0].Params, "--build-arg", "base image is pinned on request only")
+		toolRecord, err := fileUtils.FileRead(filepath.Join("toolruns", "toolrun_kanikoExecute_all.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(toolRecord), `"ToolInstance":"https://my.registry"`)
+		assert.Contains(t, string(toolRecord), "alpine:3.19@"+baseDigest.String())
+	})
+
+	t.Run("success case - sign images", func(t *testing.T) {
+		kanikoRemoteImage = func(image string, options ...remote.Option) (v1.Image, error) {
+			return nil, fmt.Errorf("unauthorized")
+		}
+		host, _ := newSigningTestRegistry(t)
+		config := &kanikoExecuteOptions{ContainerRegistryURL: "http://" + host, ContainerImageName: "my-image", ContainerImageTag: "1.0.0", DockerfilePath: "Dockerfile", SignImages: true, SigningKey: "cosign.key", SignatureMode: "tag"}
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("Dockerfile", []byte("FROM alpine:3.19\n"))
+		addSigningTestKeys(t, fileUtils)
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, &mock.ExecMockRunner{}, &kanikoMockClient{}, fileUtils)
+
+		require.NoError(t, err)
+		assert.NoError(t, signing.VerifyImages([]string{host + "/my-image:1.0.0"}, "cosign.pub", fileUtils))
+	})
+
+	t.Run("error case - sign images without signing key", func(t *testing.T) {
+		kanikoRemoteImage = func(image string, options ...remote.Option) (v1.Image, error) {
+			return nil, fmt.Errorf("unauthorized")
+		}
+		config := &kanikoExecuteOptions{ContainerImage: "my.registry/myImage:tag", DockerfilePath: "Dockerfile", SignImages: true}
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("Dockerfile", []byte("FROM alpine:3.19\n"))
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, &mock.ExecMockRunner{}, &kanikoMockClient{}, fileUtils)
+
+		assert.EqualError(t, err, "failed to sign images: no signing key provided")
+	})
+
+	t.Run("success case - build report without registry access", func(t *testing.T) {
+		kanikoRemoteImage = func(image string, options ...remote.Option) (v1.Image, error) {
+			return nil, fmt.Errorf("unauthorized")
+		}
+		config := &kanikoExecuteOptions{ContainerImage: "my.registry/myImage:tag", DockerfilePath: "Dockerfile", CreateBuildReport: true}
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("Dockerfile", []byte("FROM alpine:3.19\n"))
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, &mock.ExecMockRunner{}, &kanikoMockClient{}, fileUtils)
+
+		require.NoError(t, err)
+		assert.True(t, fileUtils.HasFile(docker.BuildReportFilename))
+	})
+
+	t.Run("error case - invalid build argument template", func(t *testing.T) {
+		config := &kanikoExecuteOptions{DockerfilePath: "Dockerfile", BuildArgs: map[string]interface{}{"VERSION": `{{cpe "artifactVersion"`}}
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, &mock.ExecMockRunner{}, &kanikoMockClient{}, &mock.FilesMock{})
+
+		assert.ErrorContains(t, err, "failed to resolve build argument 'VERSION'")
+	})
+
+	t.Run("error case - commit time not available", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "")
+		kanikoCommitTime = func() (time.Time, error) {
+			return time.Time{}, fmt.Errorf("repository does not exist")
+		}
+		config := &kanikoExecuteOptions{DockerfilePath: "Dockerfile", Reproducible: true}
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &kanikoExecuteCommonPipelineEnvironment{}, &mock.ExecMockRunner{}, &kanikoMockClient{}, &mock.FilesMock{})
+
+		assert.EqualError(t, err, "failed to determine commit time for SOURCE_DATE_EPOCH: repository does not exist")
+	})
+}
+
+func TestKanikoBaseImagePlatform(t *testing.T) {
+	tests := []struct {
+		name           string
+		stagePlatform  string
+		customPlatform string
+		expected       v1.Platform
+	}{
+		{name: "platform of the stage", stagePlatform: "linux/arm64", customPlatform: "linux/amd64", expected: v1.Platform{OS: "linux", Architecture: "arm64"}},
+		{name: "custom platform", stagePlatform: "$BUILDPLATFORM", customPlatform: "linux/arm/v7", expected: v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		{name: "platform of kaniko", expected: v1.Platform{OS: "linux", Architecture: runtime.GOARCH}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			platform, err := kanikoBaseImagePlatform(test.stagePlatform, test.customPlatform)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, platform)
+		})
+	}
+}
+
+func TestKanikoBaseImage(t *testing.T) {
+	remoteImageBak := kanikoRemoteImage
+	defer func() { kanikoRemoteImage = remoteImageBak }()
+
+	baseImage, err := random.Image(100, 1)
+	require.NoError(t, err)
+	baseDigest, err := baseImage.Digest()
+	require.NoError(t, err)
+	var options []remote.Option
+	kanikoRemoteImage = func(image string, opts ...remote.Option) (v1.Image, error) {
+		options = opts
+		return baseImage, nil
+	}
+	fileUtils := &mock.FilesMock{}
+	fileUtils.AddFile("Dockerfile", []byte("ARG BASE\nFROM --platform=linux/arm64 ${BASE} AS final\n"))
+	fileUtils.AddFile("/kaniko/.docker/config.json", []byte(`{"auths": {"https://my.registry": {"auth": "dXNlcjpwYXNzd29yZA=="}}}`))
+
+	image, arg := kanikoBaseImage("Dockerfile", []string{"--build-arg", "BASE=my.registry/base:1.0", "--custom-platform=linux/amd64"}, fileUtils)
+
+	assert.Equal(t, docker.BaseImage{Reference: "my.registry/base:1.0", Digest: baseDigest.String()}, image)
+	assert.Equal(t, "BASE", arg)
+	assert.Len(t, options, 2, "base image is retrieved for the platform of the stage with the registry credentials of the step")
+}
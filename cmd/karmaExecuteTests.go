@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/command"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/npm"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 )
 
@@ -21,6 +24,9 @@ func runKarma(config karmaExecuteTestsOptions, command command.ExecRunner) {
 	installCommandTokens := tokenize(config.InstallCommand)
 	runCommandTokens := tokenize(config.RunCommand)
 	modulePaths := config.Modules
+	if config.AffectedModulesOnly {
+		modulePaths = affectedKarmaModules(&config, &piperutils.Files{})
+	}
 
 	if GeneralConfig.Verbose {
 		runCommandTokens = append(runCommandTokens, "--", "--log-level", "DEBUG")
@@ -49,6 +55,31 @@ func runKarma(config karmaExecuteTestsOptions, command command.ExecRunner) {
 	}
 }
 
+// affectedKarmaModules returns the modules whose package is affected by the changes compared to changedFilesReference
+func affectedKarmaModules(config *karmaExecuteTestsOptions, fileUtils piperutils.FileUtils) []string {
+	changedFiles, ok := changedFilesForAffectedModules(config.ChangedFilesReference)
+	if !ok {
+		return config.Modules
+	}
+	packageJSONFiles := []string{}
+	for _, module := range config.Modules {
+		packageJSONFiles = append(packageJSONFiles, filepath.Join(module, "package.json"))
+	}
+	affectedPackages, err := npm.AffectedPackages(packageJSONFiles, changedFiles, fileUtils)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Testing all modules since the affected modules could not be determined")
+		return config.Modules
+	}
+	modules := []string{}
+	for _, packageJSON := range affectedPackages {
+		modules = append(modules, filepath.Dir(packageJSON))
+	}
+	if len(modules) == 0 {
+		log.Entry().Info("No module is affected by the changes, skipping the tests")
+	}
+	return modules
+}
+
 func tokenize(command string) []string {
 	return strings.Split(command, " ")
 }
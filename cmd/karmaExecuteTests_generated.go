@@ -21,9 +21,11 @@ import (
 )
 
 type karmaExecuteTestsOptions struct {
-	InstallCommand string   `json:"installCommand,omitempty"`
-	Modules        []string `json:"modules,omitempty"`
-	RunCommand     string   `json:"runCommand,omitempty"`
+	InstallCommand        string   `json:"installCommand,omitempty"`
+	Modules               []string `json:"modules,omitempty"`
+	RunCommand            string   `json:"runCommand,omitempty"`
+	AffectedModulesOnly   bool     `json:"affectedModulesOnly,omitempty"`
+	ChangedFilesReference string   `json:"changedFilesReference,omitempty"`
 }
 
 type karmaExecuteTestsReports struct {
@@ -148,9 +150,11 @@ In the Docker network, the containers can be referenced by the values provided i
 			handler := func() {
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -200,6 +204,8 @@ func addKarmaExecuteTestsFlags(cmd *cobra.Command, stepConfig *karmaExecuteTests
 	cmd.Flags().StringVar(&stepConfig.InstallCommand, "installCommand", `npm install --quiet`, "The command that is executed to install the test tool.")
 	cmd.Flags().StringSliceVar(&stepConfig.Modules, "modules", []string{`.`}, "Define the paths of the modules to execute tests on.")
 	cmd.Flags().StringVar(&stepConfig.RunCommand, "runCommand", `npm run karma`, "The command that is executed to start the tests.")
+	cmd.Flags().BoolVar(&stepConfig.AffectedModulesOnly, "affectedModulesOnly", false, "Whether only the modules affected by the changes compared to `changedFilesReference` are considered, i.e. the modules containing changed files and the modules depending on them. In case the changes cannot be determined, e.g. in a shallow clone, all modules are considered.")
+	cmd.Flags().StringVar(&stepConfig.ChangedFilesReference, "changedFilesReference", os.Getenv("PIPER_changedFilesReference"), "Git reference (e.g. `origin/main` or the commit of the last successful build) the changes of the current commit are compared to in order to determine the affected modules. If not configured, the changes are compared to the base branch of the pull request or to the commit of the last successful build as provided by the orchestrator.")
 
 	cmd.MarkFlagRequired("installCommand")
 	cmd.MarkFlagRequired("modules")
@@ -251,6 +257,24 @@ func karmaExecuteTestsMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `npm run karma`,
 					},
+					{
+						Name:        "affectedModulesOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "changedFilesReference",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_changedFilesReference"),
+					},
 				},
 			},
 			Containers: []config.Container{
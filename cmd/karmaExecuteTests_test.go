@@ -63,3 +63,23 @@ func TestRunKarma(t *testing.T) {
 		assert.True(t, hasFailed, "expected command to exit with fatal")
 	})
 }
+
+func TestAffectedKarmaModules(t *testing.T) {
+	files := &mock.FilesMock{}
+	files.AddFile("ui/package.json", []byte(`{"name": "ui", "dependencies": {"lib": "file:../lib"}}`))
+	files.AddFile("lib/package.json", []byte(`{"name": "lib"}`))
+	files.AddFile("admin/package.json", []byte(`{"name": "admin"}`))
+	config := karmaExecuteTestsOptions{Modules: []string{"./ui", "./lib", "./admin"}, AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+
+	t.Run("affected modules", func(t *testing.T) {
+		mockChangedFiles(t, []string{"lib/index.js"}, nil)
+
+		assert.Equal(t, []string{"ui", "lib"}, affectedKarmaModules(&config, files))
+	})
+
+	t.Run("changes cannot be determined", func(t *testing.T) {
+		mockChangedFiles(t, nil, errors.New("shallow clone"))
+
+		assert.Equal(t, config.Modules, affectedKarmaModules(&config, files))
+	})
+}
@@ -17,6 +17,7 @@ import (
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/kubernetes"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/pkg/errors"
 	"helm.sh/helm/v3/pkg/cli/values"
@@ -36,6 +37,12 @@ func kubernetesDeploy(config kubernetesDeployOptions, telemetryData *telemetry.C
 func runKubernetesDeploy(config kubernetesDeployOptions, telemetryData *telemetry.CustomData, utils kubernetes.DeployUtils, stdout io.Writer) error {
 	telemetryData.DeployTool = config.DeployTool
 
+	if config.VerifyImageSignatures {
+		if err := verifyDeploymentImageSignatures(config, utils); err != nil {
+			return err
+		}
+	}
+
 	if config.DeployTool == "helm" || config.DeployTool == "helm3" {
 		err := runHelmDeploy(config, utils, stdout)
 		// download and execute teardown script
@@ -57,6 +64,32 @@ func runKubernetesDeploy(config kubernetesDeployOptions, telemetryData *telemetr
 	return fmt.Errorf("Failed to execute deployments")
 }
 
+// verifyDeploymentImageSignatures makes sure that only signed images are deployed
+func verifyDeploymentImageSignatures(config kubernetesDeployOptions, utils kubernetes.DeployUtils) error {
+	var images []string
+	var err error
+	switch {
+	case len(config.ImageNames) > 0:
+		images, err = signing.ImageReferences(config.ContainerRegistryURL, config.ImageNameTags, config.ImageDigests)
+	case len(config.Image) > 0:
+		images, err = signing.ImageReferences(config.ContainerRegistryURL, []string{config.Image}, nil)
+	case len(config.ContainerImageName) > 0 && len(config.ContainerImageTag) > 0:
+		images, err = signing.ImageReferences(config.ContainerRegistryURL, []string{fmt.Sprintf("%v:%v", config.ContainerImageName, config.ContainerImageTag)}, nil)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to determine the images to be verified")
+	}
+	if len(images) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.New("no images found for signature verification, please provide imageNames and imageNameTags, image or containerImageName and containerImageTag or disable verifyImageSignatures")
+	}
+
+	if err := setRegistryDockerConfig(config.DockerConfigJSON, utils); err != nil {
+		return err
+	}
+	return signing.VerifyImages(images, config.SignatureVerificationKey, utils)
+}
+
 func runHelmDeploy(config kubernetesDeployOptions, utils kubernetes.DeployUtils, stdout io.Writer) error {
 	if len(config.ChartPath) <= 0 {
 		return fmt.Errorf("chart path has not been set, please configure chartPath parameter")
@@ -50,6 +50,8 @@ type kubernetesDeployOptions struct {
 	KubeToken                  string                 `json:"kubeToken,omitempty"`
 	Namespace                  string                 `json:"namespace,omitempty"`
 	TillerNamespace            string                 `json:"tillerNamespace,omitempty"`
+	VerifyImageSignatures      bool                   `json:"verifyImageSignatures,omitempty"`
+	SignatureVerificationKey   string                 `json:"signatureVerificationKey,omitempty"`
 	DockerConfigJSON           string                 `json:"dockerConfigJSON,omitempty"`
 	DeployCommand              string                 `json:"deployCommand,omitempty" validate:"possible-values=apply replace"`
 	SetupScript                string                 `json:"setupScript,omitempty"`
@@ -110,6 +112,7 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 			log.RegisterSecret(stepConfig.GithubToken)
 			log.RegisterSecret(stepConfig.KubeConfig)
 			log.RegisterSecret(stepConfig.KubeToken)
+			log.RegisterSecret(stepConfig.SignatureVerificationKey)
 			log.RegisterSecret(stepConfig.DockerConfigJSON)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
@@ -148,9 +151,11 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -230,6 +235,8 @@ func addKubernetesDeployFlags(cmd *cobra.Command, stepConfig *kubernetesDeployOp
 	cmd.Flags().StringVar(&stepConfig.KubeToken, "kubeToken", os.Getenv("PIPER_kubeToken"), "Contains the id_token used by kubectl for authentication. Consider using kubeConfig parameter instead.")
 	cmd.Flags().StringVar(&stepConfig.Namespace, "namespace", `default`, "Defines the target Kubernetes namespace for the deployment.")
 	cmd.Flags().StringVar(&stepConfig.TillerNamespace, "tillerNamespace", os.Getenv("PIPER_tillerNamespace"), "Defines optional tiller namespace for deployments using helm.")
+	cmd.Flags().BoolVar(&stepConfig.VerifyImageSignatures, "verifyImageSignatures", false, "Verifies the signatures of the images before they are deployed, see step [containerVerifySignature](containerVerifySignature.md). Images without a valid signature are not deployed. The step fails if no images to be verified are configured.")
+	cmd.Flags().StringVar(&stepConfig.SignatureVerificationKey, "signatureVerificationKey", os.Getenv("PIPER_signatureVerificationKey"), "Path to the file containing the PEM encoded public keys used to verify the image signatures if [verifyImageSignatures](#verifyimagesignatures) is activated.")
 	cmd.Flags().StringVar(&stepConfig.DockerConfigJSON, "dockerConfigJSON", `.pipeline/docker/config.json`, "Path to the file `.docker/config.json` - this is typically provided by your CI/CD system. You can find more details about the Docker credentials in the [Docker documentation](https://docs.docker.com/engine/reference/commandline/login/).")
 	cmd.Flags().StringVar(&stepConfig.DeployCommand, "deployCommand", `apply`, "Only for `deployTool: kubectl`: defines the command `apply` or `replace`. The default is `apply`.")
 	cmd.Flags().StringVar(&stepConfig.SetupScript, "setupScript", os.Getenv("PIPER_setupScript"), "HTTP location of setup script")
@@ -257,6 +264,7 @@ func kubernetesDeployMetadata() config.StepData {
 					{Name: "dockerCredentialsId", Type: "jenkins"},
 					{Name: "dockerConfigJsonCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing Docker config.json (with registry credential(s)).", Type: "jenkins"},
 					{Name: "githubTokenCredentialsId", Description: "Jenkins credentials ID containing the github token.", Type: "jenkins"},
+					{Name: "signatureVerificationKeyCredentialsId", Description: "Jenkins 'Secret file' credentials ID containing the public keys used to verify the image signatures.", Type: "jenkins"},
 				},
 				Resources: []config.StepResources{
 					{Name: "deployDescriptor", Type: "stash"},
@@ -654,6 +662,35 @@ func kubernetesDeployMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "helmTillerNamespace"}},
 						Default:     os.Getenv("PIPER_tillerNamespace"),
 					},
+					{
+						Name:        "verifyImageSignatures",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name: "signatureVerificationKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "signatureVerificationKeyCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "signatureVerificationKeyVaultSecretName",
+								Type:    "vaultSecretFile",
+								Default: "image-signing",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_signatureVerificationKey"),
+					},
 					{
 						Name: "dockerConfigJSON",
 						ResourceRef: []config.ResourceReference{
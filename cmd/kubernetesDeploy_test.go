@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/signing"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 )
 
@@ -1080,6 +1081,68 @@ func TestRunKubernetesDeploy(t *testing.T) {
 		}, mockUtils.Calls[0].Params, "Wrong upgrade parameters")
 	})
 
+	t.Run("test helm v3 - verify image signatures", func(t *testing.T) {
+		host, digest := newSigningTestRegistry(t)
+		opts := kubernetesDeployOptions{
+			ContainerRegistryURL:     "http://" + host,
+			ChartPath:                "path/to/chart",
+			DeploymentName:           "deploymentName",
+			DeployTool:               "helm3",
+			ImageNames:               []string{"my-image"},
+			ImageNameTags:            []string{"my-image:1.0.0"},
+			ImageDigests:             []string{digest},
+			Namespace:                "deploymentNamespace",
+			VerifyImageSignatures:    true,
+			SignatureVerificationKey: "cosign.pub",
+		}
+		mockUtils := newKubernetesDeployMockUtils()
+		addSigningTestKeys(t, mockUtils.FilesMock)
+		require.NoError(t, signing.SignImages([]string{host + "/my-image:1.0.0"}, signing.Config{SigningKey: "cosign.key", SignatureMode: signing.ModeTag}, mockUtils.FilesMock))
+
+		err := runKubernetesDeploy(opts, &telemetry.CustomData{}, mockUtils, &bytes.Buffer{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(mockUtils.Calls), "Wrong number of upgrade commands")
+	})
+
+	t.Run("test helm v3 - unsigned image is not deployed", func(t *testing.T) {
+		host, _ := newSigningTestRegistry(t)
+		opts := kubernetesDeployOptions{
+			ContainerRegistryURL:     "http://" + host,
+			ChartPath:                "path/to/chart",
+			DeploymentName:           "deploymentName",
+			DeployTool:               "helm3",
+			Image:                    "my-image:1.0.0",
+			Namespace:                "deploymentNamespace",
+			VerifyImageSignatures:    true,
+			SignatureVerificationKey: "cosign.pub",
+		}
+		mockUtils := newKubernetesDeployMockUtils()
+		addSigningTestKeys(t, mockUtils.FilesMock)
+
+		err := runKubernetesDeploy(opts, &telemetry.CustomData{}, mockUtils, &bytes.Buffer{})
+
+		assert.EqualError(t, err, "signature verification failed for images: "+host+"/my-image:1.0.0")
+		assert.Empty(t, mockUtils.Calls)
+	})
+
+	t.Run("test helm v3 - verification without images", func(t *testing.T) {
+		opts := kubernetesDeployOptions{
+			ChartPath:                "path/to/chart",
+			DeploymentName:           "deploymentName",
+			DeployTool:               "helm3",
+			Namespace:                "deploymentNamespace",
+			VerifyImageSignatures:    true,
+			SignatureVerificationKey: "cosign.pub",
+		}
+		mockUtils := newKubernetesDeployMockUtils()
+
+		err := runKubernetesDeploy(opts, &telemetry.CustomData{}, mockUtils, &bytes.Buffer{})
+
+		assert.ErrorContains(t, err, "no images found for signature verification")
+		assert.Empty(t, mockUtils.Calls)
+	})
+
 	t.Run("test helm - use extensions", func(t *testing.T) {
 		opts := kubernetesDeployOptions{
 			ContainerRegistryURL:    "https://my.registry:55555",
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/SAP/jenkins-library/pkg/licensing"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type licensePolicyCheckUtils interface {
+	piperutils.FileUtils
+}
+
+type licensePolicyCheckUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newLicensePolicyCheckUtils() licensePolicyCheckUtils {
+	return &licensePolicyCheckUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+}
+
+func licensePolicyCheck(config licensePolicyCheckOptions, telemetryData *telemetry.CustomData, influx *licensePolicyCheckInflux) {
+	utils := newLicensePolicyCheckUtils()
+
+	influx.step_data.fields.licensePolicyCheck = false
+	if err := runLicensePolicyCheck(&config, utils, influx); err != nil {
+		log.Entry().WithError(err).Fatal("License policy check failed")
+	}
+	influx.step_data.fields.licensePolicyCheck = true
+}
+
+func runLicensePolicyCheck(config *licensePolicyCheckOptions, utils licensePolicyCheckUtils, influx *licensePolicyCheckInflux) error {
+	policy, err := loadLicensePolicy(config, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	components, err := collectLicenseComponents(config, utils)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		log.Entry().Warnf("No components found in files matching %v %v", config.SbomFiles, config.ComponentFiles)
+	}
+
+	evaluations := []licensing.Evaluation{}
+	for _, component := range components {
+		evaluation := policy.Evaluate(component)
+		log.Entry().Debugf("%v: %v (%v)", component.ID(), evaluation.Decision, evaluation.Reason)
+		evaluations = append(evaluations, evaluation)
+	}
+
+	counts := licensing.CountByDecision(evaluations)
+	influx.license_data.fields.components = len(evaluations)
+	influx.license_data.fields.allowed = counts[licensing.Allowed]
+	influx.license_data.fields.review = counts[licensing.Review]
+	influx.license_data.fields.denied = counts[licensing.Denied]
+
+	policyName := config.PolicyFile
+	if len(policyName) == 0 {
+		policyName = "step configuration"
+	}
+	scanReport := licensing.CreateCustomReport(evaluations, policyName)
+	reports, err := licensing.WriteCustomReports(scanReport, evaluations, utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create license policy reports")
+	}
+	piperutils.PersistReportsAndLinks("licensePolicyCheck", "", utils, reports, nil)
+
+	for _, evaluation := range evaluations {
+		switch evaluation.Decision {
+		case licensing.Denied:
+			log.Entry().Errorf("%v: %v", evaluation.Component.ID(), evaluation.Reason)
+		case licensing.Review:
+			log.Entry().Warnf("%v: %v", evaluation.Component.ID(), evaluation.Reason)
+		}
+	}
+	log.Entry().Infof("%v components checked: %v allowed, %v require a review, %v denied",
+		len(evaluations), counts[licensing.Allowed], counts[licensing.Review], counts[licensing.Denied])
+
+	if config.FailOnDenied && counts[licensing.Denied] > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v components with denied licenses found", counts[licensing.Denied])
+	}
+	if config.FailOnReview && counts[licensing.Review] > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v components with licenses requiring a review found", counts[licensing.Review])
+	}
+	return nil
+}
+
+func loadLicensePolicy(config *licensePolicyCheckOptions, utils licensePolicyCheckUtils) (licensing.Policy, error) {
+	policy := licensing.Policy{}
+	if len(config.PolicyFile) > 0 {
+		var err error
+		if policy, err = licensing.LoadPolicy(config.PolicyFile, utils); err != nil {
+			return policy, err
+		}
+	}
+	policy = policy.Merge(licensing.Policy{
+		Allow:   config.AllowedLicenses,
+		Deny:    config.DeniedLicenses,
+		Review:  config.ReviewLicenses,
+		Unknown: config.UnknownLicenses,
+	})
+	if len(policy.Allow)+len(policy.Deny)+len(policy.Review) == 0 {
+		return policy, fmt.Errorf("no license policy defined, please provide a policyFile or configure allowedLicenses, deniedLicenses or reviewLicenses")
+	}
+	return policy, nil
+}
+
+func collectLicenseComponents(config *licensePolicyCheckOptions, utils licensePolicyCheckUtils) ([]licensing.Component, error) {
+	components := []licensing.Component{}
+	seen := map[string]bool{}
+	for _, input := range []struct {
+		patterns []string
+		read     func(string, piperutils.FileUtils) ([]licensing.Component, error)
+	}{
+		{patterns: config.SbomFiles, read: licensing.ReadSBOM},
+		{patterns: config.ComponentFiles, read: licensing.ReadComponents},
+	} {
+		for _, pattern := range input.patterns {
+			matches, err := utils.Glob(pattern)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return nil, errors.Wrapf(err, "invalid pattern '%v'", pattern)
+			}
+			matches, err = piperutils.ExcludeFiles(matches, config.Excludes)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return nil, err
+			}
+			for _, match := range matches {
+				if seen[match] {
+					continue
+				}
+				seen[match] = true
+				log.Entry().Infof("Reading components from '%v'", match)
+				fileComponents, err := input.read(match, utils)
+				if err != nil {
+					log.SetErrorCategory(log.ErrorConfiguration)
+					return nil, err
+				}
+				components = append(components, fileComponents...)
+			}
+		}
+	}
+	return components, nil
+}
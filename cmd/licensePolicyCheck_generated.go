@@ -0,0 +1,430 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/cobra"
+)
+
+type licensePolicyCheckOptions struct {
+	SbomFiles       []string `json:"sbomFiles,omitempty"`
+	ComponentFiles  []string `json:"componentFiles,omitempty"`
+	Excludes        []string `json:"excludes,omitempty"`
+	PolicyFile      string   `json:"policyFile,omitempty"`
+	AllowedLicenses []string `json:"allowedLicenses,omitempty"`
+	DeniedLicenses  []string `json:"deniedLicenses,omitempty"`
+	ReviewLicenses  []string `json:"reviewLicenses,omitempty"`
+	UnknownLicenses string   `json:"unknownLicenses,omitempty" validate:"possible-values=allow review deny"`
+	FailOnDenied    bool     `json:"failOnDenied,omitempty"`
+	FailOnReview    bool     `json:"failOnReview,omitempty"`
+}
+
+type licensePolicyCheckInflux struct {
+	step_data struct {
+		fields struct {
+			licensePolicyCheck bool
+		}
+		tags struct {
+		}
+	}
+	license_data struct {
+		fields struct {
+			components int
+			allowed    int
+			review     int
+			denied     int
+		}
+		tags struct {
+		}
+	}
+}
+
+func (i *licensePolicyCheckInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "step_data", name: "licensePolicyCheck", value: i.step_data.fields.licensePolicyCheck},
+		{valType: config.InfluxField, measurement: "license_data", name: "components", value: i.license_data.fields.components},
+		{valType: config.InfluxField, measurement: "license_data", name: "allowed", value: i.license_data.fields.allowed},
+		{valType: config.InfluxField, measurement: "license_data", name: "review", value: i.license_data.fields.review},
+		{valType: config.InfluxField, measurement: "license_data", name: "denied", value: i.license_data.fields.denied},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Influx environment")
+	}
+}
+
+func (i *licensePolicyCheckInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"licensePolicyCheck": i.step_data.fields.licensePolicyCheck,
+		},
+		"license_data": {
+			"components": i.license_data.fields.components,
+			"allowed":    i.license_data.fields.allowed,
+			"review":     i.license_data.fields.review,
+			"denied":     i.license_data.fields.denied,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
+type licensePolicyCheckReports struct {
+}
+
+func (p *licensePolicyCheckReports) persist(stepConfig licensePolicyCheckOptions, gcpJsonKeyFilePath string, gcsBucketId string, gcsFolderPath string, gcsSubFolder string) {
+	if gcsBucketId == "" {
+		log.Entry().Info("persisting reports to GCS is disabled, because gcsBucketId is empty")
+		return
+	}
+	log.Entry().Info("Uploading reports to Google Cloud Storage...")
+	content := []gcs.ReportOutputParam{
+		{FilePattern: "**/piper_license_policy_report.html", ParamRef: "", StepResultType: "license"},
+		{FilePattern: "**/piper_license_policy_violations.md", ParamRef: "", StepResultType: "license"},
+	}
+	envVars := []gcs.EnvVar{
+		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+	}
+	gcsClient, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+	if err != nil {
+		log.Entry().Errorf("creation of GCS client failed: %v", err)
+		return
+	}
+	defer gcsClient.Close()
+	structVal := reflect.ValueOf(&stepConfig).Elem()
+	inputParameters := map[string]string{}
+	for i := 0; i < structVal.NumField(); i++ {
+		field := structVal.Type().Field(i)
+		if field.Type.String() == "string" {
+			paramName := strings.Split(field.Tag.Get("json"), ",")
+			paramValue, _ := structVal.Field(i).Interface().(string)
+			inputParameters[paramName[0]] = paramValue
+		}
+	}
+	if err := gcs.PersistReportsToGCS(gcsClient, content, inputParameters, gcsFolderPath, gcsBucketId, gcsSubFolder, doublestar.Glob, os.Stat); err != nil {
+		log.Entry().Errorf("failed to persist reports: %v", err)
+	}
+}
+
+// LicensePolicyCheckCommand Checks the licenses of the components of a project against a license policy.
+func LicensePolicyCheckCommand() *cobra.Command {
+	const STEP_NAME = "licensePolicyCheck"
+
+	metadata := licensePolicyCheckMetadata()
+	var stepConfig licensePolicyCheckOptions
+	var startTime time.Time
+	var influx licensePolicyCheckInflux
+	var reports licensePolicyCheckReports
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createLicensePolicyCheckCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Checks the licenses of the components of a project against a license policy.",
+		Long: `This step evaluates the licenses of all components listed in CycloneDX SBOMs or JSON component lists against a license policy.
+The evaluation happens locally and therefore leads to the same result no matter which build tool or scanner provided the components.
+
+Licenses are expected as [SPDX license expressions](https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/).
+For compound expressions the licensee may choose the most favorable license of an ` + "`" + `OR` + "`" + ` expression, whereas all licenses of an ` + "`" + `AND` + "`" + ` expression need to be acceptable.
+
+The policy can be provided as YAML file via ` + "`" + `policyFile` + "`" + ` and/or via the step parameters. A policy file looks like this:
+
+` + "`" + `` + "`" + `` + "`" + `yaml
+allow:
+  - MIT
+  - Apache-2.0
+  - BSD-*
+  - GPL-2.0-only WITH Classpath-exception-2.0
+review:
+  - LGPL-*
+deny:
+  - GPL-*
+  - AGPL-*
+# decision for licenses which are not listed and for components without license information
+unknown: review
+exceptions:
+  - component: pkg:maven/org.mariadb.jdbc/mariadb-java-client
+    licenses:
+      - LGPL-2.1-or-later
+    reason: approved by legal
+` + "`" + `` + "`" + `` + "`" + `
+
+Denied licenses take precedence over licenses requiring review which take precedence over allowed licenses.
+Component exceptions match against the package URL (with or without version) or the name of the component.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
+				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			licensePolicyCheck(stepConfig, &stepTelemetryData, &influx)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addLicensePolicyCheckFlags(createLicensePolicyCheckCmd, &stepConfig)
+	return createLicensePolicyCheckCmd
+}
+
+func addLicensePolicyCheckFlags(cmd *cobra.Command, stepConfig *licensePolicyCheckOptions) {
+	cmd.Flags().StringSliceVar(&stepConfig.SbomFiles, "sbomFiles", []string{`**/bom-*.xml`}, "List of glob patterns of the CycloneDX SBOMs (XML or JSON) containing the components to be checked.")
+	cmd.Flags().StringSliceVar(&stepConfig.ComponentFiles, "componentFiles", []string{}, "List of glob patterns of JSON component lists for scanners which don't create CycloneDX SBOMs. See the step documentation for the format.")
+	cmd.Flags().StringSliceVar(&stepConfig.Excludes, "excludes", []string{`**/node_modules/**`}, "List of glob patterns of files which are excluded from the check.")
+	cmd.Flags().StringVar(&stepConfig.PolicyFile, "policyFile", os.Getenv("PIPER_policyFile"), "Path to the license policy in YAML format.")
+	cmd.Flags().StringSliceVar(&stepConfig.AllowedLicenses, "allowedLicenses", []string{}, "Licenses which are allowed in addition to the ones defined in the `policyFile`. Wildcards like `BSD-*` are supported.")
+	cmd.Flags().StringSliceVar(&stepConfig.DeniedLicenses, "deniedLicenses", []string{}, "Licenses which are denied in addition to the ones defined in the `policyFile`. Wildcards like `GPL-*` are supported.")
+	cmd.Flags().StringSliceVar(&stepConfig.ReviewLicenses, "reviewLicenses", []string{}, "Licenses which require a review in addition to the ones defined in the `policyFile`. Wildcards like `LGPL-*` are supported.")
+	cmd.Flags().StringVar(&stepConfig.UnknownLicenses, "unknownLicenses", os.Getenv("PIPER_unknownLicenses"), "Decision for licenses which are not listed and for components without license information. Overrides the `unknown` setting of the `policyFile`, if none is defined the components require a review.")
+	cmd.Flags().BoolVar(&stepConfig.FailOnDenied, "failOnDenied", true, "Whether the step fails in case components with denied licenses are found.")
+	cmd.Flags().BoolVar(&stepConfig.FailOnReview, "failOnReview", false, "Whether the step fails in case components with licenses requiring a review are found.")
+
+}
+
+// retrieve step metadata
+func licensePolicyCheckMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "licensePolicyCheck",
+			Aliases:     []config.Alias{},
+			Description: "Checks the licenses of the components of a project against a license policy.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Parameters: []config.StepParameters{
+					{
+						Name:        "sbomFiles",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/bom-*.xml`},
+					},
+					{
+						Name:        "componentFiles",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "excludes",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/node_modules/**`},
+					},
+					{
+						Name:        "policyFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_policyFile"),
+					},
+					{
+						Name:        "allowedLicenses",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "deniedLicenses",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "reviewLicenses",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "unknownLicenses",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_unknownLicenses"),
+					},
+					{
+						Name:        "failOnDenied",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+					{
+						Name:        "failOnReview",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"name": "step_data", "fields": []map[string]string{{"name": "licensePolicyCheck"}}},
+							{"name": "license_data", "fields": []map[string]string{{"name": "components"}, {"name": "allowed"}, {"name": "review"}, {"name": "denied"}}},
+						},
+					},
+					{
+						Name: "reports",
+						Type: "reports",
+						Parameters: []map[string]interface{}{
+							{"filePattern": "**/piper_license_policy_report.html", "type": "license"},
+							{"filePattern": "**/piper_license_policy_violations.md", "type": "license"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
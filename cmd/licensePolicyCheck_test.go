@@ -0,0 +1,125 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/licensing"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const licenseTestSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [
+    {"type": "library", "name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21", "licenses": [{"license": {"id": "MIT"}}]},
+    {"type": "library", "name": "dual", "version": "1.0.0", "purl": "pkg:npm/dual@1.0.0", "licenses": [{"expression": "GPL-3.0-only OR Apache-2.0"}]},
+    {"type": "library", "name": "copyleft", "version": "2.0.0", "purl": "pkg:npm/copyleft@2.0.0", "licenses": [{"license": {"id": "AGPL-3.0-only"}}]},
+    {"type": "library", "name": "unknown", "version": "0.1.0", "purl": "pkg:npm/unknown@0.1.0"}
+  ]
+}`
+
+func newLicensePolicyCheckTestFiles() *mock.FilesMock {
+	files := &mock.FilesMock{}
+	files.AddFile("bom-npm.json", []byte(licenseTestSBOM))
+	files.AddFile("licensePolicy.yml", []byte(`allow:
+  - MIT
+  - Apache-2.0
+deny:
+  - GPL-*
+  - AGPL-*
+`))
+	return files
+}
+
+func TestRunLicensePolicyCheck(t *testing.T) {
+	t.Run("denied licenses", func(t *testing.T) {
+		files := newLicensePolicyCheckTestFiles()
+		config := licensePolicyCheckOptions{
+			SbomFiles:    []string{"**/bom-*.json"},
+			PolicyFile:   "licensePolicy.yml",
+			FailOnDenied: true,
+		}
+		influx := licensePolicyCheckInflux{}
+
+		err := runLicensePolicyCheck(&config, files, &influx)
+
+		assert.EqualError(t, err, "1 components with denied licenses found")
+		assert.Equal(t, 4, influx.license_data.fields.components)
+		assert.Equal(t, 2, influx.license_data.fields.allowed)
+		assert.Equal(t, 1, influx.license_data.fields.review)
+		assert.Equal(t, 1, influx.license_data.fields.denied)
+		assert.True(t, files.HasWrittenFile(filepath.Join(licensing.ReportsDirectory, "piper_license_policy_report.html")))
+		assert.True(t, files.HasWrittenFile(filepath.Join(licensing.ReportsDirectory, "piper_license_policy_violations.md")))
+		assert.True(t, files.HasWrittenFile("licensePolicyCheck_reports.json"))
+	})
+
+	t.Run("exception and additional licenses from configuration", func(t *testing.T) {
+		files := newLicensePolicyCheckTestFiles()
+		files.AddFile("licensePolicy.yml", []byte(`allow: [MIT, Apache-2.0]
+deny: [GPL-*, AGPL-*]
+exceptions:
+  - component: pkg:npm/copyleft
+    reason: internal tool only
+`))
+		config := licensePolicyCheckOptions{
+			SbomFiles:       []string{"**/bom-*.json"},
+			PolicyFile:      "licensePolicy.yml",
+			UnknownLicenses: "deny",
+			FailOnDenied:    true,
+		}
+
+		err := runLicensePolicyCheck(&config, files, &licensePolicyCheckInflux{})
+
+		// the component without license information is denied now
+		assert.EqualError(t, err, "1 components with denied licenses found")
+
+		config.UnknownLicenses = "allow"
+		assert.NoError(t, runLicensePolicyCheck(&config, files, &licensePolicyCheckInflux{}))
+	})
+
+	t.Run("fail on review", func(t *testing.T) {
+		files := newLicensePolicyCheckTestFiles()
+		config := licensePolicyCheckOptions{
+			SbomFiles:       []string{"**/bom-*.json"},
+			AllowedLicenses: []string{"MIT", "Apache-2.0"},
+			ReviewLicenses:  []string{"AGPL-*"},
+			FailOnDenied:    true,
+			FailOnReview:    true,
+		}
+
+		err := runLicensePolicyCheck(&config, files, &licensePolicyCheckInflux{})
+
+		assert.EqualError(t, err, "2 components with licenses requiring a review found")
+	})
+
+	t.Run("scanner components", func(t *testing.T) {
+		files := newLicensePolicyCheckTestFiles()
+		files.AddFile("scanner-components.json", []byte(`{"components": [{"name": "copyleft", "version": "3.0.0", "license": "GPL-2.0-only"}]}`))
+		config := licensePolicyCheckOptions{
+			SbomFiles:      []string{"**/bom-*.json"},
+			ComponentFiles: []string{"scanner-components.json"},
+			PolicyFile:     "licensePolicy.yml",
+		}
+		influx := licensePolicyCheckInflux{}
+
+		err := runLicensePolicyCheck(&config, files, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, influx.license_data.fields.components)
+		assert.Equal(t, 2, influx.license_data.fields.denied)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		files := newLicensePolicyCheckTestFiles()
+		config := licensePolicyCheckOptions{SbomFiles: []string{"**/bom-*.json"}}
+
+		err := runLicensePolicyCheck(&config, files, &licensePolicyCheckInflux{})
+
+		assert.EqualError(t, err, "no license policy defined, please provide a policyFile or configure allowedLicenses, deniedLicenses or reviewLicenses")
+	})
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/policy"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type manifestPolicyCheckUtils interface {
+	command.ExecRunner
+	piperutils.FileUtils
+}
+
+type manifestPolicyCheckUtilsBundle struct {
+	*command.Command
+	*piperutils.Files
+}
+
+func newManifestPolicyCheckUtils() manifestPolicyCheckUtils {
+	utils := manifestPolicyCheckUtilsBundle{
+		Command: &command.Command{},
+		Files:   &piperutils.Files{},
+	}
+	// Reroute command output to logging framework
+	utils.Stdout(log.Writer())
+	utils.Stderr(log.Writer())
+	return &utils
+}
+
+func manifestPolicyCheck(config manifestPolicyCheckOptions, telemetryData *telemetry.CustomData, influx *manifestPolicyCheckInflux) {
+	utils := newManifestPolicyCheckUtils()
+
+	influx.step_data.fields.manifest_policy_check = false
+	if err := runManifestPolicyCheck(context.Background(), &config, utils, influx); err != nil {
+		log.Entry().WithError(err).Fatal("Manifest policy check failed")
+	}
+	influx.step_data.fields.manifest_policy_check = true
+}
+
+func runManifestPolicyCheck(ctx context.Context, config *manifestPolicyCheckOptions, utils manifestPolicyCheckUtils, influx *manifestPolicyCheckInflux) error {
+	resources, err := loadPolicyResources(config, utils)
+	if err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no resources to check, please configure manifests, dockerfiles, chartPath or kustomizeDirectories")
+	}
+
+	rules, err := loadPolicyRules(ctx, config, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	log.Entry().Infof("Checking %v resources against %v rules", len(resources), len(rules))
+	violations, err := policy.Evaluate(ctx, rules, resources, config.DisabledRules)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	counts := policy.CountBySeverity(violations)
+	influx.manifest_policy_data.fields.resources = len(resources)
+	influx.manifest_policy_data.fields.errors = counts[policy.SeverityError]
+	influx.manifest_policy_data.fields.warnings = counts[policy.SeverityWarning]
+
+	reports := writeManifestPolicyReports(resources, rules, violations, utils)
+	piperutils.PersistReportsAndLinks("manifestPolicyCheck", "", utils, reports, nil)
+
+	for _, violation := range violations {
+		if violation.Severity == policy.SeverityError {
+			log.Entry().Errorf("[%v] %v", violation.RuleID, violation.Message)
+		} else {
+			log.Entry().Warnf("[%v] %v", violation.RuleID, violation.Message)
+		}
+	}
+	if counts[policy.SeverityError] > 0 && config.FailOnViolations {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v policy violations with severity error found", counts[policy.SeverityError])
+	}
+	return nil
+}
+
+// loadPolicyResources renders the helm chart and the kustomizations and loads them together with the manifests and Dockerfiles
+func loadPolicyResources(config *manifestPolicyCheckOptions, utils manifestPolicyCheckUtils) ([]policy.Resource, error) {
+	resources := []policy.Resource{}
+
+	renderedFiles := []string{}
+	if len(config.ChartPath) > 0 {
+		rendered, err := renderHelmChart(config, utils)
+		if err != nil {
+			return nil, err
+		}
+		renderedFiles = append(renderedFiles, rendered)
+	}
+	for _, directory := range config.KustomizeDirectories {
+		rendered, err := renderKustomization(directory, utils)
+		if err != nil {
+			return nil, err
+		}
+		renderedFiles = append(renderedFiles, rendered)
+	}
+
+	manifests, err := globPolicyFiles(config.Manifests, utils)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range append(renderedFiles, manifests...) {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read manifest %v", file)
+		}
+		fileResources, err := policy.LoadManifests(file, content)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, err
+		}
+		resources = append(resources, fileResources...)
+	}
+
+	dockerfiles, err := globPolicyFiles(config.Dockerfiles, utils)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range dockerfiles {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read Dockerfile %v", file)
+		}
+		resource, err := policy.LoadDockerfile(file, content)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// renderHelmChart renders the chart into a file of the reports directory so that violations can be located
+func renderHelmChart(config *manifestPolicyCheckOptions, utils manifestPolicyCheckUtils) (string, error) {
+	releaseName := config.DeploymentName
+	if len(releaseName) == 0 {
+		releaseName = filepath.Base(config.ChartPath)
+	}
+	params := []string{"template", releaseName, config.ChartPath, "--namespace", config.Namespace}
+	for _, values := range config.HelmValues {
+		params = append(params, "--values", values)
+	}
+	log.Entry().Infof("Rendering helm chart %v", config.ChartPath)
+	return renderManifests("helm", params, "helm_template.yaml", utils)
+}
+
+func renderKustomization(directory string, utils manifestPolicyCheckUtils) (string, error) {
+	log.Entry().Infof("Rendering kustomization %v", directory)
+	name := strings.ReplaceAll(filepath.ToSlash(filepath.Clean(directory)), "/", "_")
+	return renderManifests("kubectl", []string{"kustomize", directory}, fmt.Sprintf("kustomize_%v.yaml", name), utils)
+}
+
+func renderManifests(executable string, params []string, fileName string, utils manifestPolicyCheckUtils) (string, error) {
+	output := bytes.Buffer{}
+	utils.Stdout(&output)
+	defer utils.Stdout(log.Writer())
+	if err := utils.RunExecutable(executable, params...); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", errors.Wrapf(err, "failed to render manifests via '%v %v'", executable, strings.Join(params, " "))
+	}
+	if err := utils.MkdirAll(policy.ReportsDirectory, 0777); err != nil {
+		return "", errors.Wrap(err, "failed to create report directory")
+	}
+	path := filepath.Join(policy.ReportsDirectory, fileName)
+	if err := utils.FileWrite(path, output.Bytes(), 0666); err != nil {
+		return "", errors.Wrap(err, "failed to write rendered manifests")
+	}
+	return path, nil
+}
+
+func loadPolicyRules(ctx context.Context, config *manifestPolicyCheckOptions, utils manifestPolicyCheckUtils) ([]policy.Rule, error) {
+	rules := policy.BuiltinRules()
+
+	regoFiles, err := globPolicyFiles(config.RegoPolicies, utils)
+	if err != nil {
+		return nil, err
+	}
+	if len(regoFiles) > 0 {
+		modules := map[string][]byte{}
+		for _, file := range regoFiles {
+			if modules[file], err = utils.FileRead(file); err != nil {
+				return nil, errors.Wrapf(err, "failed to read Rego policy %v", file)
+			}
+		}
+		regoRules, err := policy.RegoRules(ctx, modules)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, regoRules...)
+	}
+
+	for _, file := range config.CelRules {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CEL rules %v", file)
+		}
+		celRules, err := policy.CELRules(file, content)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, celRules...)
+	}
+	return rules, nil
+}
+
+func globPolicyFiles(patterns []string, utils manifestPolicyCheckUtils) ([]string, error) {
+	files := []string{}
+	for _, pattern := range patterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "invalid pattern '%v'", pattern)
+		}
+		if len(matches) == 0 {
+			log.Entry().Warnf("No files match the pattern '%v'", pattern)
+		}
+		files = append(files, matches...)
+	}
+	return piperutils.UniqueStrings(files), nil
+}
+
+func writeManifestPolicyReports(resources []policy.Resource, rules []policy.Rule, violations []policy.Violation, utils manifestPolicyCheckUtils) []piperutils.Path {
+	reports := []piperutils.Path{}
+
+	paths, err := policy.WriteCustomReports(policy.CreateCustomReport(resources, rules, violations), utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create markdown report")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	paths, err = policy.WriteSarifFile(policy.CreateSarifResultFile(rules, violations), utils)
+	if err != nil {
+		log.Entry().WithError(err).Warning("failed to create SARIF file")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	return reports
+}
@@ -0,0 +1,436 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/cobra"
+)
+
+type manifestPolicyCheckOptions struct {
+	Manifests            []string `json:"manifests,omitempty"`
+	Dockerfiles          []string `json:"dockerfiles,omitempty"`
+	ChartPath            string   `json:"chartPath,omitempty"`
+	HelmValues           []string `json:"helmValues,omitempty"`
+	DeploymentName       string   `json:"deploymentName,omitempty"`
+	Namespace            string   `json:"namespace,omitempty"`
+	KustomizeDirectories []string `json:"kustomizeDirectories,omitempty"`
+	RegoPolicies         []string `json:"regoPolicies,omitempty"`
+	CelRules             []string `json:"celRules,omitempty"`
+	DisabledRules        []string `json:"disabledRules,omitempty"`
+	FailOnViolations     bool     `json:"failOnViolations,omitempty"`
+}
+
+type manifestPolicyCheckInflux struct {
+	step_data struct {
+		fields struct {
+			manifest_policy_check bool
+		}
+		tags struct {
+		}
+	}
+	manifest_policy_data struct {
+		fields struct {
+			resources int
+			errors    int
+			warnings  int
+		}
+		tags struct {
+		}
+	}
+}
+
+func (i *manifestPolicyCheckInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "step_data", name: "manifest_policy_check", value: i.step_data.fields.manifest_policy_check},
+		{valType: config.InfluxField, measurement: "manifest_policy_data", name: "resources", value: i.manifest_policy_data.fields.resources},
+		{valType: config.InfluxField, measurement: "manifest_policy_data", name: "errors", value: i.manifest_policy_data.fields.errors},
+		{valType: config.InfluxField, measurement: "manifest_policy_data", name: "warnings", value: i.manifest_policy_data.fields.warnings},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Influx environment")
+	}
+}
+
+func (i *manifestPolicyCheckInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"manifest_policy_check": i.step_data.fields.manifest_policy_check,
+		},
+		"manifest_policy_data": {
+			"resources": i.manifest_policy_data.fields.resources,
+			"errors":    i.manifest_policy_data.fields.errors,
+			"warnings":  i.manifest_policy_data.fields.warnings,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
+type manifestPolicyCheckReports struct {
+}
+
+func (p *manifestPolicyCheckReports) persist(stepConfig manifestPolicyCheckOptions, gcpJsonKeyFilePath string, gcsBucketId string, gcsFolderPath string, gcsSubFolder string) {
+	if gcsBucketId == "" {
+		log.Entry().Info("persisting reports to GCS is disabled, because gcsBucketId is empty")
+		return
+	}
+	log.Entry().Info("Uploading reports to Google Cloud Storage...")
+	content := []gcs.ReportOutputParam{
+		{FilePattern: "**/piper_manifest_policy_report.md", ParamRef: "", StepResultType: "manifest-policy"},
+		{FilePattern: "**/piper_manifest_policy.sarif", ParamRef: "", StepResultType: "manifest-policy"},
+	}
+	envVars := []gcs.EnvVar{
+		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+	}
+	gcsClient, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+	if err != nil {
+		log.Entry().Errorf("creation of GCS client failed: %v", err)
+		return
+	}
+	defer gcsClient.Close()
+	structVal := reflect.ValueOf(&stepConfig).Elem()
+	inputParameters := map[string]string{}
+	for i := 0; i < structVal.NumField(); i++ {
+		field := structVal.Type().Field(i)
+		if field.Type.String() == "string" {
+			paramName := strings.Split(field.Tag.Get("json"), ",")
+			paramValue, _ := structVal.Field(i).Interface().(string)
+			inputParameters[paramName[0]] = paramValue
+		}
+	}
+	if err := gcs.PersistReportsToGCS(gcsClient, content, inputParameters, gcsFolderPath, gcsBucketId, gcsSubFolder, doublestar.Glob, os.Stat); err != nil {
+		log.Entry().Errorf("failed to persist reports: %v", err)
+	}
+}
+
+// ManifestPolicyCheckCommand Checks Kubernetes manifests and Dockerfiles against built-in rules as well as Rego and CEL policies.
+func ManifestPolicyCheckCommand() *cobra.Command {
+	const STEP_NAME = "manifestPolicyCheck"
+
+	metadata := manifestPolicyCheckMetadata()
+	var stepConfig manifestPolicyCheckOptions
+	var startTime time.Time
+	var influx manifestPolicyCheckInflux
+	var reports manifestPolicyCheckReports
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createManifestPolicyCheckCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Checks Kubernetes manifests and Dockerfiles against built-in rules as well as Rego and CEL policies.",
+		Long: `This step evaluates the resources which are going to be deployed against policies before they are deployed, e.g. before ` + "`" + `helmExecute` + "`" + ` runs ` + "`" + `helm upgrade` + "`" + `.
+It checks
+
+* the output of ` + "`" + `helm template` + "`" + ` for the chart configured via [chartPath](#chartpath) and [helmValues](#helmvalues), i.e. the same parameters ` + "`" + `helmExecute` + "`" + ` uses,
+* the output of ` + "`" + `kubectl kustomize` + "`" + ` for the [kustomizeDirectories](#kustomizedirectories),
+* plain YAML manifests matching [manifests](#manifests), e.g. rendered by a previous step,
+* and Dockerfiles matching [dockerfiles](#dockerfiles).
+
+The built-in rules are:
+
+| Rule | Severity | Description |
+| --- | --- | --- |
+| ` + "`" + `k8s-privileged-container` + "`" + ` | error | Containers must not run privileged |
+| ` + "`" + `k8s-resource-limits` + "`" + ` | error | Containers must define CPU and memory limits |
+| ` + "`" + `k8s-image-latest-tag` + "`" + ` | error | Container images must not use the latest tag |
+| ` + "`" + `k8s-image-digest` + "`" + ` | warning | Container images should be pinned by digest |
+| ` + "`" + `k8s-host-namespaces` + "`" + ` | error | Pods must not share the network, PID or IPC namespace of the host |
+| ` + "`" + `dockerfile-image-latest-tag` + "`" + ` | error | Base images must not use the latest tag |
+| ` + "`" + `dockerfile-image-digest` + "`" + ` | warning | Base images should be pinned by digest |
+| ` + "`" + `dockerfile-root-user` + "`" + ` | warning | Images should not run as root |
+
+Additional rules can be provided as [Rego](https://www.openpolicyagent.org/docs/latest/policy-language/) policies via [regoPolicies](#regopolicies)
+and as [CEL](https://github.com/google/cel-spec) expressions via [celRules](#celrules).
+
+The step creates a markdown report and a SARIF file and fails if a rule with severity ` + "`" + `error` + "`" + ` is violated.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
+				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			manifestPolicyCheck(stepConfig, &stepTelemetryData, &influx)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addManifestPolicyCheckFlags(createManifestPolicyCheckCmd, &stepConfig)
+	return createManifestPolicyCheckCmd
+}
+
+func addManifestPolicyCheckFlags(cmd *cobra.Command, stepConfig *manifestPolicyCheckOptions) {
+	cmd.Flags().StringSliceVar(&stepConfig.Manifests, "manifests", []string{}, "List of glob patterns of YAML files containing Kubernetes manifests, e.g. `k8s/**/*.yaml`. Files may contain multiple documents and lists.")
+	cmd.Flags().StringSliceVar(&stepConfig.Dockerfiles, "dockerfiles", []string{}, "List of glob patterns of Dockerfiles, e.g. `**/Dockerfile`.")
+	cmd.Flags().StringVar(&stepConfig.ChartPath, "chartPath", os.Getenv("PIPER_chartPath"), "Path of the helm chart which is rendered via `helm template` in order to check the resulting manifests.")
+	cmd.Flags().StringSliceVar(&stepConfig.HelmValues, "helmValues", []string{}, "List of helm values as YAML file reference or URL (as per helm parameter description for `-f` / `--values`) used to render the chart.")
+	cmd.Flags().StringVar(&stepConfig.DeploymentName, "deploymentName", os.Getenv("PIPER_deploymentName"), "Release name used to render the chart. Defaults to the name of the chart directory.")
+	cmd.Flags().StringVar(&stepConfig.Namespace, "namespace", `default`, "Kubernetes namespace used to render the chart.")
+	cmd.Flags().StringSliceVar(&stepConfig.KustomizeDirectories, "kustomizeDirectories", []string{}, "List of kustomization directories which are rendered via `kubectl kustomize` in order to check the resulting manifests.")
+	cmd.Flags().StringSliceVar(&stepConfig.RegoPolicies, "regoPolicies", []string{}, "List of glob patterns of Rego policy files. Like in conftest each package may define the rules `deny` and `warn` producing messages, either as strings or as objects with the field `msg`. The manifest or Dockerfile is provided as `input`.")
+	cmd.Flags().StringSliceVar(&stepConfig.CelRules, "celRules", []string{}, "List of YAML files defining rules as CEL expressions on the variable `object`. Each entry of the list `rules` has the fields `id`, `expression`, and optionally `description`, `severity` (`error` or `warning`), `match` and `message`.")
+	cmd.Flags().StringSliceVar(&stepConfig.DisabledRules, "disabledRules", []string{}, "List of rule IDs which are not evaluated, e.g. `k8s-image-digest`.")
+	cmd.Flags().BoolVar(&stepConfig.FailOnViolations, "failOnViolations", true, "Whether the step fails if rules with severity `error` are violated.")
+
+}
+
+// retrieve step metadata
+func manifestPolicyCheckMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "manifestPolicyCheck",
+			Aliases:     []config.Alias{},
+			Description: "Checks Kubernetes manifests and Dockerfiles against built-in rules as well as Rego and CEL policies.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Parameters: []config.StepParameters{
+					{
+						Name:        "manifests",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "dockerfiles",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "chartPath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "helmChartPath"}},
+						Default:     os.Getenv("PIPER_chartPath"),
+					},
+					{
+						Name:        "helmValues",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "deploymentName",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "helmDeploymentName"}},
+						Default:     os.Getenv("PIPER_deploymentName"),
+					},
+					{
+						Name:        "namespace",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "helmDeploymentNamespace"}},
+						Default:     `default`,
+					},
+					{
+						Name:        "kustomizeDirectories",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "regoPolicies",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "celRules",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "disabledRules",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "failOnViolations",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+				},
+			},
+			Containers: []config.Container{
+				{Image: "dtzar/helm-kubectl:3", WorkingDir: "/config", Options: []config.Option{{Name: "-u", Value: "0"}}},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"name": "step_data", "fields": []map[string]string{{"name": "manifest_policy_check"}}},
+							{"name": "manifest_policy_data", "fields": []map[string]string{{"name": "resources"}, {"name": "errors"}, {"name": "warnings"}}},
+						},
+					},
+					{
+						Name: "reports",
+						Type: "reports",
+						Parameters: []map[string]interface{}{
+							{"filePattern": "**/piper_manifest_policy_report.md", "type": "manifest-policy"},
+							{"filePattern": "**/piper_manifest_policy.sarif", "type": "manifest-policy"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
@@ -0,0 +1,192 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type manifestPolicyCheckMockUtils struct {
+	*mock.ExecMockRunner
+	*mock.FilesMock
+}
+
+func newManifestPolicyCheckTestsUtils() manifestPolicyCheckMockUtils {
+	return manifestPolicyCheckMockUtils{
+		ExecMockRunner: &mock.ExecMockRunner{},
+		FilesMock:      &mock.FilesMock{},
+	}
+}
+
+const compliantDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: registry.example.com/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+        resources:
+          limits:
+            cpu: 500m
+            memory: 256Mi
+`
+
+const privilegedPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox:latest
+    securityContext:
+      privileged: true
+`
+
+func TestRunManifestPolicyCheck(t *testing.T) {
+	t.Run("success case - compliant manifests", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.AddFile("k8s/deployment.yaml", []byte(compliantDeployment))
+		config := manifestPolicyCheckOptions{Manifests: []string{"k8s/*.yaml"}, FailOnViolations: true}
+		influx := manifestPolicyCheckInflux{}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, influx.manifest_policy_data.fields.resources)
+		assert.Equal(t, 0, influx.manifest_policy_data.fields.errors)
+		assert.True(t, utils.HasWrittenFile("policycheck/piper_manifest_policy_report.md"))
+		assert.True(t, utils.HasWrittenFile("policycheck/piper_manifest_policy.sarif"))
+	})
+
+	t.Run("error case - violations", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.AddFile("k8s/pod.yaml", []byte(privilegedPod))
+		config := manifestPolicyCheckOptions{Manifests: []string{"k8s/*.yaml"}, FailOnViolations: true}
+		influx := manifestPolicyCheckInflux{}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &influx)
+
+		assert.EqualError(t, err, "3 policy violations with severity error found")
+		assert.Equal(t, 3, influx.manifest_policy_data.fields.errors)
+		assert.True(t, utils.HasWrittenFile("policycheck/piper_manifest_policy_report.md"))
+	})
+
+	t.Run("success case - violations without failing", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.AddFile("k8s/pod.yaml", []byte(privilegedPod))
+		config := manifestPolicyCheckOptions{Manifests: []string{"k8s/*.yaml"}, DisabledRules: []string{"k8s-privileged-container"}}
+		influx := manifestPolicyCheckInflux{}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, influx.manifest_policy_data.fields.errors)
+	})
+
+	t.Run("success case - helm chart", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.StdoutReturn = map[string]string{
+			"helm template my-app charts/my-app --namespace prod --values values-prod.yaml": "---\n# Source: my-app/templates/deployment.yaml\n" + compliantDeployment,
+		}
+		config := manifestPolicyCheckOptions{ChartPath: "charts/my-app", HelmValues: []string{"values-prod.yaml"}, DeploymentName: "my-app", Namespace: "prod", FailOnViolations: true}
+		influx := manifestPolicyCheckInflux{}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &influx)
+
+		assert.NoError(t, err)
+		require.Len(t, utils.Calls, 1)
+		assert.Equal(t, "helm", utils.Calls[0].Exec)
+		assert.True(t, utils.HasWrittenFile("policycheck/helm_template.yaml"))
+		assert.Equal(t, 1, influx.manifest_policy_data.fields.resources)
+	})
+
+	t.Run("success case - kustomize, Dockerfile, Rego and CEL", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.StdoutReturn = map[string]string{"kubectl kustomize overlays/prod": compliantDeployment}
+		utils.AddFile("Dockerfile", []byte("FROM golang:1.22\nUSER 1000\n"))
+		utils.AddFile("policies/labels.rego", []byte(`package piper.labels
+
+warn[msg] {
+	input.kind == "Deployment"
+	not input.metadata.labels.team
+	msg := "team label is missing"
+}
+`))
+		utils.AddFile("policies/cel.yaml", []byte(`rules:
+- id: replicas
+  severity: error
+  match: object.kind == "Deployment"
+  expression: has(object.spec.replicas) && object.spec.replicas >= 2
+  message: at least two replicas are required
+`))
+		config := manifestPolicyCheckOptions{
+			KustomizeDirectories: []string{"overlays/prod"},
+			Dockerfiles:          []string{"Dockerfile"},
+			RegoPolicies:         []string{"policies/*.rego"},
+			CelRules:             []string{"policies/cel.yaml"},
+			FailOnViolations:     true,
+		}
+		influx := manifestPolicyCheckInflux{}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &influx)
+
+		assert.EqualError(t, err, "1 policy violations with severity error found")
+		assert.True(t, utils.HasWrittenFile("policycheck/kustomize_overlays_prod.yaml"))
+		assert.Equal(t, 2, influx.manifest_policy_data.fields.resources)
+		assert.Equal(t, 1, influx.manifest_policy_data.fields.errors)
+		// missing team label and Dockerfile base image not pinned by digest
+		assert.Equal(t, 2, influx.manifest_policy_data.fields.warnings)
+	})
+
+	t.Run("error case - no inputs", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		config := manifestPolicyCheckOptions{FailOnViolations: true}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &manifestPolicyCheckInflux{})
+
+		assert.EqualError(t, err, "no resources to check, please configure manifests, dockerfiles, chartPath or kustomizeDirectories")
+	})
+
+	t.Run("error case - helm template fails", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.ShouldFailOnCommand = map[string]error{"helm template my-app charts/my-app --namespace default": fmt.Errorf("chart not found")}
+		config := manifestPolicyCheckOptions{ChartPath: "charts/my-app", Namespace: "default"}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &manifestPolicyCheckInflux{})
+
+		assert.EqualError(t, err, "failed to render manifests via 'helm template my-app charts/my-app --namespace default': chart not found")
+	})
+
+	t.Run("error case - invalid Rego policy", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.AddFile("k8s/deployment.yaml", []byte(compliantDeployment))
+		utils.AddFile("policies/broken.rego", []byte("package broken\n\ndeny[msg] {\n"))
+		config := manifestPolicyCheckOptions{Manifests: []string{"k8s/*.yaml"}, RegoPolicies: []string{"policies/*.rego"}}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &manifestPolicyCheckInflux{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("error case - invalid CEL rule", func(t *testing.T) {
+		utils := newManifestPolicyCheckTestsUtils()
+		utils.AddFile("k8s/deployment.yaml", []byte(compliantDeployment))
+		utils.AddFile("policies/broken.yaml", []byte("rules:\n- id: broken\n  expression: object.kind ==\n"))
+		config := manifestPolicyCheckOptions{Manifests: []string{"k8s/*.yaml"}, CelRules: []string{"policies/broken.yaml"}}
+
+		err := runManifestPolicyCheck(context.Background(), &config, utils, &manifestPolicyCheckInflux{})
+
+		assert.Error(t, err)
+	})
+}
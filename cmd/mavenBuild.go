@@ -96,8 +96,20 @@ func runMavenBuild(config *mavenBuildOptions, telemetryData *telemetry.CustomDat
 		flags = append(flags, "--activate-profiles", strings.Join(config.Profiles, ","))
 	}
 
+	var projects []string
+	if config.AffectedModulesOnly {
+		var buildAll bool
+		projects, buildAll = affectedMavenProjects(config.PomPath, config.ChangedFilesReference, utils)
+		if !buildAll && len(projects) == 0 {
+			log.Entry().Info("No maven module is affected by the changes, skipping the build")
+			return nil
+		}
+	}
+
 	exists, _ := utils.FileExists("integration-tests/pom.xml")
-	if exists {
+	if len(projects) > 0 {
+		flags = append(flags, "--projects", strings.Join(projects, ","), "--also-make")
+	} else if exists {
 		flags = append(flags, "-pl", "!integration-tests")
 	}
 
@@ -199,6 +211,10 @@ func runMavenBuild(config *mavenBuildOptions, telemetryData *telemetry.CustomDat
 			if (len(config.AltDeploymentRepositoryID) > 0) && (len(config.AltDeploymentRepositoryURL) > 0) {
 				deployFlags = append(deployFlags, "-DaltDeploymentRepository="+config.AltDeploymentRepositoryID+"::default::"+config.AltDeploymentRepositoryURL)
 			}
+			if len(projects) > 0 {
+				// dependencies of the affected modules are unchanged and therefore not deployed again
+				deployFlags = append(deployFlags, "--projects", strings.Join(projects, ","))
+			}
 
 			downloadClient := &piperhttp.Client{}
 			downloadClient.SetOptions(piperhttp.ClientOptions{})
@@ -42,6 +42,8 @@ type mavenBuildOptions struct {
 	BuildSettingsInfo               string   `json:"buildSettingsInfo,omitempty"`
 	DeployFlags                     []string `json:"deployFlags,omitempty"`
 	CreateBuildArtifactsMetadata    bool     `json:"createBuildArtifactsMetadata,omitempty"`
+	AffectedModulesOnly             bool     `json:"affectedModulesOnly,omitempty"`
+	ChangedFilesReference           string   `json:"changedFilesReference,omitempty"`
 }
 
 type mavenBuildCommonPipelineEnvironment struct {
@@ -224,9 +226,11 @@ general:
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -259,10 +263,15 @@ general:
 					}
 				}
 			}
+			// registered before the handler so that cached outputs are restored after the handler persisted the output resources
+			stepCache := NewStepCache(STEP_NAME, &metadata, stepConfig)
+			defer stepCache.Finish(&stepTelemetryData)
 			log.DeferExitHandler(handler)
 			defer handler()
 			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
-			mavenBuild(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
+			if !stepCache.Hit() {
+				mavenBuild(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
+			}
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -292,6 +301,8 @@ func addMavenBuildFlags(cmd *cobra.Command, stepConfig *mavenBuildOptions) {
 	cmd.Flags().StringVar(&stepConfig.BuildSettingsInfo, "buildSettingsInfo", os.Getenv("PIPER_buildSettingsInfo"), "build settings info is typically filled by the step automatically to create information about the build settings that were used during the maven build . This information is typically used for compliance related processes.")
 	cmd.Flags().StringSliceVar(&stepConfig.DeployFlags, "deployFlags", []string{`-Dmaven.main.skip=true`, `-Dmaven.test.skip=true`, `-Dmaven.install.skip=true`}, "maven deploy flags that will be used when publish is detected.")
 	cmd.Flags().BoolVar(&stepConfig.CreateBuildArtifactsMetadata, "createBuildArtifactsMetadata", false, "metadata about the artifacts that are build and published , this metadata is generally used by steps downstream in the pipeline")
+	cmd.Flags().BoolVar(&stepConfig.AffectedModulesOnly, "affectedModulesOnly", false, "Whether only the modules affected by the changes compared to `changedFilesReference` are considered, i.e. the modules containing changed files and the modules depending on them. In case the changes cannot be determined, e.g. in a shallow clone, all modules are considered.")
+	cmd.Flags().StringVar(&stepConfig.ChangedFilesReference, "changedFilesReference", os.Getenv("PIPER_changedFilesReference"), "Git reference (e.g. `origin/main` or the commit of the last successful build) the changes of the current commit are compared to in order to determine the affected modules. If not configured, the changes are compared to the base branch of the pull request or to the commit of the last successful build as provided by the orchestrator.")
 
 }
 
@@ -539,11 +550,34 @@ func mavenBuildMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "affectedModulesOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "changedFilesReference",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_changedFilesReference"),
+					},
 				},
 			},
 			Containers: []config.Container{
 				{Name: "mvn", Image: "maven:3.6-jdk-8"},
 			},
+			Cache: &config.StepCache{
+				Inputs:   []string{"**/pom.xml", "**/src/**", ".mvn/**"},
+				Outputs:  []string{"**/target/*.jar", "**/target/*.war", "**/target/*.pom", "**/target/bom-maven.xml", "**/target/classes/**", "**/target/test-classes/**", "**/target/surefire-reports/**", "**/target/failsafe-reports/**", "**/target/*.exec", "**/target/site/jacoco*/**"},
+				Excludes: []string{"**/target/**/src/**"},
+			},
 			Outputs: config.StepOutputs{
 				Resources: []config.StepResources{
 					{
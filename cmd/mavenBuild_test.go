@@ -4,10 +4,12 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/config"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/stretchr/testify/assert"
 )
@@ -126,6 +128,80 @@ func TestMavenBuild(t *testing.T) {
 
 }
 
+func TestMavenBuildAffectedModules(t *testing.T) {
+	SetConfigOptions(ConfigCommandOptions{
+		OpenFile: config.OpenPiperFile,
+	})
+	addReactor := func(utils *mavenMockUtils) {
+		utils.AddFile("pom.xml", []byte(`<project><groupId>com.example</groupId><artifactId>root</artifactId><modules><module>core</module><module>app</module><module>tools</module><module>integration-tests</module></modules></project>`))
+		utils.AddFile("core/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>core</artifactId></project>`))
+		utils.AddFile("app/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>app</artifactId><dependencies><dependency><groupId>com.example</groupId><artifactId>core</artifactId></dependency></dependencies></project>`))
+		utils.AddFile("tools/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>tools</artifactId></project>`))
+		utils.AddFile("integration-tests/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>integration-tests</artifactId><dependencies><dependency><groupId>com.example</groupId><artifactId>app</artifactId></dependency></dependencies></project>`))
+	}
+
+	t.Run("build affected modules and their dependents", func(t *testing.T) {
+		mockChangedFiles(t, []string{"core/src/main/java/Core.java"}, nil)
+		mockedUtils := newMavenMockUtils()
+		addReactor(&mockedUtils)
+		config := mavenBuildOptions{AffectedModulesOnly: true, ChangedFilesReference: "origin/main", Publish: true}
+
+		err := runMavenBuild(&config, nil, &mockedUtils, &cpe)
+
+		assert.NoError(t, err)
+		if assert.Len(t, mockedUtils.Calls, 2) {
+			assert.Contains(t, mockedUtils.Calls[0].Params, "--projects")
+			assert.Contains(t, mockedUtils.Calls[0].Params, "core,app")
+			assert.Contains(t, mockedUtils.Calls[0].Params, "--also-make")
+			assert.NotContains(t, mockedUtils.Calls[0].Params, "!integration-tests")
+			assert.Contains(t, mockedUtils.Calls[1].Params, "deploy")
+			assert.Contains(t, mockedUtils.Calls[1].Params, "core,app")
+			assert.NotContains(t, mockedUtils.Calls[1].Params, "--also-make")
+		}
+	})
+
+	t.Run("skip build without affected modules", func(t *testing.T) {
+		mockChangedFiles(t, []string{"integration-tests/src/test/java/IT.java"}, nil)
+		mockedUtils := newMavenMockUtils()
+		addReactor(&mockedUtils)
+		config := mavenBuildOptions{AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+
+		err := runMavenBuild(&config, nil, &mockedUtils, &cpe)
+
+		assert.NoError(t, err)
+		assert.Empty(t, mockedUtils.Calls)
+	})
+
+	t.Run("build all modules in case the root is affected", func(t *testing.T) {
+		mockChangedFiles(t, []string{"pom.xml"}, nil)
+		mockedUtils := newMavenMockUtils()
+		addReactor(&mockedUtils)
+		config := mavenBuildOptions{AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+
+		err := runMavenBuild(&config, nil, &mockedUtils, &cpe)
+
+		assert.NoError(t, err)
+		if assert.Len(t, mockedUtils.Calls, 1) {
+			assert.NotContains(t, mockedUtils.Calls[0].Params, "--projects")
+			assert.Contains(t, mockedUtils.Calls[0].Params, "!integration-tests")
+		}
+	})
+
+	t.Run("build all modules in case the changes cannot be determined", func(t *testing.T) {
+		mockChangedFiles(t, nil, errors.New("object not found"))
+		mockedUtils := newMavenMockUtils()
+		addReactor(&mockedUtils)
+		config := mavenBuildOptions{AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+
+		err := runMavenBuild(&config, nil, &mockedUtils, &cpe)
+
+		assert.NoError(t, err)
+		if assert.Len(t, mockedUtils.Calls, 1) {
+			assert.NotContains(t, mockedUtils.Calls[0].Params, "--projects")
+		}
+	})
+}
+
 func createTempFile(t *testing.T, dir string, filename string, content string) string {
 	filePath := filepath.Join(dir, filename)
 	err := os.WriteFile(filePath, []byte(content), 0666)
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/maven"
@@ -24,6 +25,16 @@ func runMavenStaticCodeChecks(config *mavenExecuteStaticCodeChecksOptions, telem
 		return nil
 	}
 
+	var projects []string
+	if config.AffectedModulesOnly {
+		var checkAll bool
+		projects, checkAll = affectedMavenProjects("", config.ChangedFilesReference, utils)
+		if !checkAll && len(projects) == 0 {
+			log.Entry().Info("No maven module is affected by the changes, skipping the static code checks")
+			return nil
+		}
+	}
+
 	if config.InstallArtifacts {
 		err := maven.InstallMavenArtifacts(&maven.EvaluateOptions{
 			M2Path:              config.M2Path,
@@ -45,6 +56,11 @@ func runMavenStaticCodeChecks(config *mavenExecuteStaticCodeChecksOptions, telem
 		}
 	}
 
+	if len(projects) > 0 {
+		// the unchanged dependencies of the affected modules are resolved from the local repository, see installArtifacts
+		defines = append(defines, "--projects", strings.Join(projects, ","))
+	}
+
 	if config.SpotBugs {
 		spotBugsMavenParameters := getSpotBugsMavenParameters(config)
 		defines = append(defines, spotBugsMavenParameters.Defines...)
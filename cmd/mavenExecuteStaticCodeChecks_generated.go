@@ -30,6 +30,8 @@ type mavenExecuteStaticCodeChecksOptions struct {
 	M2Path                       string   `json:"m2Path,omitempty"`
 	LogSuccessfulMavenTransfers  bool     `json:"logSuccessfulMavenTransfers,omitempty"`
 	InstallArtifacts             bool     `json:"installArtifacts,omitempty"`
+	AffectedModulesOnly          bool     `json:"affectedModulesOnly,omitempty"`
+	ChangedFilesReference        string   `json:"changedFilesReference,omitempty"`
 }
 
 // MavenExecuteStaticCodeChecksCommand Execute static code checks for Maven based projects. The plugins SpotBugs and PMD are used.
@@ -107,9 +109,11 @@ For PMD the failure priority and the max allowed violations are configurable via
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -142,10 +146,15 @@ For PMD the failure priority and the max allowed violations are configurable via
 					}
 				}
 			}
+			// registered before the handler so that cached outputs are restored after the handler persisted the output resources
+			stepCache := NewStepCache(STEP_NAME, &metadata, stepConfig)
+			defer stepCache.Finish(&stepTelemetryData)
 			log.DeferExitHandler(handler)
 			defer handler()
 			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
-			mavenExecuteStaticCodeChecks(stepConfig, &stepTelemetryData)
+			if !stepCache.Hit() {
+				mavenExecuteStaticCodeChecks(stepConfig, &stepTelemetryData)
+			}
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -169,6 +178,8 @@ func addMavenExecuteStaticCodeChecksFlags(cmd *cobra.Command, stepConfig *mavenE
 	cmd.Flags().StringVar(&stepConfig.M2Path, "m2Path", os.Getenv("PIPER_m2Path"), "Path to the location of the local repository that should be used.")
 	cmd.Flags().BoolVar(&stepConfig.LogSuccessfulMavenTransfers, "logSuccessfulMavenTransfers", false, "Configures maven to log successful downloads. This is set to `false` by default to reduce the noise in build logs.")
 	cmd.Flags().BoolVar(&stepConfig.InstallArtifacts, "installArtifacts", false, "If enabled, it will install all artifacts to the local maven repository to make them available before running the static code checks. This is required if any maven module has dependencies to other modules in the repository and they were not installed before.")
+	cmd.Flags().BoolVar(&stepConfig.AffectedModulesOnly, "affectedModulesOnly", false, "Whether only the modules affected by the changes compared to `changedFilesReference` are checked, i.e. the modules containing changed files and the modules depending on them. In case the changes cannot be determined, e.g. in a shallow clone, all modules are checked.")
+	cmd.Flags().StringVar(&stepConfig.ChangedFilesReference, "changedFilesReference", os.Getenv("PIPER_changedFilesReference"), "Git reference (e.g. `origin/main` or the commit of the last successful build) the changes of the current commit are compared to in order to determine the affected modules. If not configured, the changes are compared to the base branch of the pull request or to the commit of the last successful build as provided by the orchestrator.")
 
 }
 
@@ -300,11 +311,33 @@ func mavenExecuteStaticCodeChecksMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "affectedModulesOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "changedFilesReference",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_changedFilesReference"),
+					},
 				},
 			},
 			Containers: []config.Container{
 				{Name: "mvn", Image: "maven:3.6-jdk-8"},
 			},
+			Cache: &config.StepCache{
+				Inputs:  []string{"**/pom.xml", "**/src/**"},
+				Outputs: []string{"**/target/spotbugsXml.xml", "**/target/pmd.xml", "**/target/pmd-*.xml"},
+			},
 		},
 	}
 	return theMetaData
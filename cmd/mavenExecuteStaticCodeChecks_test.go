@@ -69,6 +69,41 @@ func TestRunMavenStaticCodeChecks(t *testing.T) {
 	})
 }
 
+func TestRunMavenStaticCodeChecksAffectedModules(t *testing.T) {
+	addReactor := func(utils *mavenStaticCodeChecksTestUtilsBundle) {
+		utils.AddFile("pom.xml", []byte(`<project><groupId>com.example</groupId><artifactId>root</artifactId><modules><module>core</module><module>app</module><module>integration-tests</module></modules></project>`))
+		utils.AddFile("integration-tests/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>integration-tests</artifactId></project>`))
+		utils.AddFile("core/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>core</artifactId></project>`))
+		utils.AddFile("app/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>app</artifactId></project>`))
+	}
+	config := mavenExecuteStaticCodeChecksOptions{Pmd: true, AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+
+	t.Run("check affected modules only", func(t *testing.T) {
+		mockChangedFiles(t, []string{"app/src/main/java/App.java"}, nil)
+		utils := newMavenStaticCodeChecksTestUtilsBundle()
+		addReactor(&utils)
+
+		err := runMavenStaticCodeChecks(&config, nil, utils)
+
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 1) {
+			assert.Contains(t, utils.Calls[0].Params, "--projects")
+			assert.Contains(t, utils.Calls[0].Params, "app")
+		}
+	})
+
+	t.Run("skip checks without affected modules", func(t *testing.T) {
+		mockChangedFiles(t, []string{"integration-tests/src/test/java/IT.java"}, nil)
+		utils := newMavenStaticCodeChecksTestUtilsBundle()
+		addReactor(&utils)
+
+		err := runMavenStaticCodeChecks(&config, nil, utils)
+
+		assert.NoError(t, err)
+		assert.Empty(t, utils.Calls)
+	})
+}
+
 func TestGetPmdMavenParameters(t *testing.T) {
 	t.Run("should return maven options with max allowed violations and failrure priority", func(t *testing.T) {
 		config := mavenExecuteStaticCodeChecksOptions{
@@ -51,8 +51,11 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"cloudFoundryDeploy":                        cloudFoundryDeployMetadata(),
 		"cnbBuild":                                  cnbBuildMetadata(),
 		"codeqlExecuteScan":                         codeqlExecuteScanMetadata(),
+		"containerCheckBaseImage":                   containerCheckBaseImageMetadata(),
+		"containerExecuteScan":                      containerExecuteScanMetadata(),
 		"containerExecuteStructureTests":            containerExecuteStructureTestsMetadata(),
 		"containerSaveImage":                        containerSaveImageMetadata(),
+		"containerVerifySignature":                  containerVerifySignatureMetadata(),
 		"contrastExecuteScan":                       contrastExecuteScanMetadata(),
 		"credentialdiggerScan":                      credentialdiggerScanMetadata(),
 		"detectExecuteScan":                         detectExecuteScanMetadata(),
@@ -88,12 +91,15 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"integrationArtifactUnDeploy":               integrationArtifactUnDeployMetadata(),
 		"integrationArtifactUpdateConfiguration":    integrationArtifactUpdateConfigurationMetadata(),
 		"integrationArtifactUpload":                 integrationArtifactUploadMetadata(),
+		"integrationPackageSync":                    integrationPackageSyncMetadata(),
 		"isChangeInDevelopment":                     isChangeInDevelopmentMetadata(),
 		"jsonApplyPatch":                            jsonApplyPatchMetadata(),
 		"kanikoExecute":                             kanikoExecuteMetadata(),
 		"karmaExecuteTests":                         karmaExecuteTestsMetadata(),
 		"kubernetesDeploy":                          kubernetesDeployMetadata(),
+		"licensePolicyCheck":                        licensePolicyCheckMetadata(),
 		"malwareExecuteScan":                        malwareExecuteScanMetadata(),
+		"manifestPolicyCheck":                       manifestPolicyCheckMetadata(),
 		"mavenBuild":                                mavenBuildMetadata(),
 		"mavenExecute":                              mavenExecuteMetadata(),
 		"mavenExecuteIntegration":                   mavenExecuteIntegrationMetadata(),
@@ -103,13 +109,18 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"nexusUpload":                               nexusUploadMetadata(),
 		"npmExecuteLint":                            npmExecuteLintMetadata(),
 		"npmExecuteScripts":                         npmExecuteScriptsMetadata(),
+		"osvExecuteScan":                            osvExecuteScanMetadata(),
 		"pipelineCreateScanSummary":                 pipelineCreateScanSummaryMetadata(),
+		"pipelineTriggerRemote":                     pipelineTriggerRemoteMetadata(),
 		"protecodeExecuteScan":                      protecodeExecuteScanMetadata(),
 		"pythonBuild":                               pythonBuildMetadata(),
+		"secretExecuteScan":                         secretExecuteScanMetadata(),
 		"shellExecute":                              shellExecuteMetadata(),
 		"sonarExecuteScan":                          sonarExecuteScanMetadata(),
 		"terraformExecute":                          terraformExecuteMetadata(),
+		"testResultsAggregate":                      testResultsAggregateMetadata(),
 		"tmsExport":                                 tmsExportMetadata(),
+		"tmsPromote":                                tmsPromoteMetadata(),
 		"tmsUpload":                                 tmsUploadMetadata(),
 		"transportRequestDocIDFromGit":              transportRequestDocIDFromGitMetadata(),
 		"transportRequestReqIDFromGit":              transportRequestReqIDFromGitMetadata(),
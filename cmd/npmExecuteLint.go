@@ -86,6 +86,28 @@ func runNpmExecuteLint(npmExecutor npm.Executor, utils lintUtils, config *npmExe
 	packageJSONFiles := npmExecutor.FindPackageJSONFiles()
 	packagesWithLintScript, _ := npmExecutor.FindPackageJSONFilesWithScript(packageJSONFiles, config.RunScript)
 
+	var packagesList []string
+	if config.AffectedModulesOnly {
+		affectedPackages, restricted, err := affectedNpmPackages(npmExecutor, config.ChangedFilesReference, packageJSONFiles, nil)
+		if err != nil {
+			return err
+		}
+		if restricted {
+			if len(affectedPackages) == 0 {
+				log.Entry().Info("No package is affected by the changes, skipping linting")
+				return nil
+			}
+			packageJSONFiles = affectedPackages
+			if len(packagesWithLintScript) > 0 {
+				if packagesWithLintScript, _ = npmExecutor.FindPackageJSONFilesWithScript(affectedPackages, config.RunScript); len(packagesWithLintScript) == 0 {
+					log.Entry().Infof("None of the affected packages defines the script '%v', skipping linting", config.RunScript)
+					return nil
+				}
+				packagesList = packagesWithLintScript
+			}
+		}
+	}
+
 	if len(packagesWithLintScript) > 0 {
 		if config.Install {
 			err := npmExecutor.InstallAllDependencies(packagesWithLintScript)
@@ -94,7 +116,7 @@ func runNpmExecuteLint(npmExecutor npm.Executor, utils lintUtils, config *npmExe
 			}
 		}
 
-		err := runLintScript(npmExecutor, config.RunScript, config.FailOnError)
+		err := runLintScript(npmExecutor, config.RunScript, config.FailOnError, packagesList)
 		if err != nil {
 			return err
 		}
@@ -115,11 +137,11 @@ func runNpmExecuteLint(npmExecutor npm.Executor, utils lintUtils, config *npmExe
 	return nil
 }
 
-func runLintScript(npmExecutor npm.Executor, runScript string, failOnError bool) error {
+func runLintScript(npmExecutor npm.Executor, runScript string, failOnError bool, packagesList []string) error {
 	runScripts := []string{runScript}
 	runOptions := []string{"--silent"}
 
-	err := npmExecutor.RunScriptsInAllPackages(runScripts, runOptions, nil, false, nil, nil)
+	err := npmExecutor.RunScriptsInAllPackages(runScripts, runOptions, nil, false, nil, packagesList)
 	if err != nil {
 		if failOnError {
 			return fmt.Errorf("%s script execution failed with error: %w. This might be the result of severe linting findings, or some other issue while executing the script. Please examine the linting results in the UI, the cilint.xml file, if available, or the log above. ", runScript, err)
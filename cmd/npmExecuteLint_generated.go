@@ -17,12 +17,14 @@ import (
 )
 
 type npmExecuteLintOptions struct {
-	Install            bool   `json:"install,omitempty"`
-	RunScript          string `json:"runScript,omitempty"`
-	FailOnError        bool   `json:"failOnError,omitempty"`
-	DefaultNpmRegistry string `json:"defaultNpmRegistry,omitempty"`
-	OutputFormat       string `json:"outputFormat,omitempty"`
-	OutputFileName     string `json:"outputFileName,omitempty"`
+	Install               bool   `json:"install,omitempty"`
+	RunScript             string `json:"runScript,omitempty"`
+	FailOnError           bool   `json:"failOnError,omitempty"`
+	DefaultNpmRegistry    string `json:"defaultNpmRegistry,omitempty"`
+	OutputFormat          string `json:"outputFormat,omitempty"`
+	OutputFileName        string `json:"outputFileName,omitempty"`
+	AffectedModulesOnly   bool   `json:"affectedModulesOnly,omitempty"`
+	ChangedFilesReference string `json:"changedFilesReference,omitempty"`
 }
 
 // NpmExecuteLintCommand Execute ci-lint script on all npm packages in a project or execute default linting
@@ -94,9 +96,11 @@ either use ESLint configurations present in the project or use the provided gene
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -129,10 +133,15 @@ either use ESLint configurations present in the project or use the provided gene
 					}
 				}
 			}
+			// registered before the handler so that cached outputs are restored after the handler persisted the output resources
+			stepCache := NewStepCache(STEP_NAME, &metadata, stepConfig)
+			defer stepCache.Finish(&stepTelemetryData)
 			log.DeferExitHandler(handler)
 			defer handler()
 			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
-			npmExecuteLint(stepConfig, &stepTelemetryData)
+			if !stepCache.Hit() {
+				npmExecuteLint(stepConfig, &stepTelemetryData)
+			}
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -149,6 +158,8 @@ func addNpmExecuteLintFlags(cmd *cobra.Command, stepConfig *npmExecuteLintOption
 	cmd.Flags().StringVar(&stepConfig.DefaultNpmRegistry, "defaultNpmRegistry", os.Getenv("PIPER_defaultNpmRegistry"), "URL of the npm registry to use. Defaults to https://registry.npmjs.org/")
 	cmd.Flags().StringVar(&stepConfig.OutputFormat, "outputFormat", `checkstyle`, "eslint output format, e.g. stylish, checkstyle")
 	cmd.Flags().StringVar(&stepConfig.OutputFileName, "outputFileName", `defaultlint.xml`, "name of the output file. There might be a 'N_' prefix where 'N' is a number. When the empty string is provided, we will print to console")
+	cmd.Flags().BoolVar(&stepConfig.AffectedModulesOnly, "affectedModulesOnly", false, "Whether only the packages affected by the changes compared to `changedFilesReference` are linted, i.e. the packages containing changed files and the packages depending on them. In case the changes cannot be determined, e.g. in a shallow clone, all packages are linted.")
+	cmd.Flags().StringVar(&stepConfig.ChangedFilesReference, "changedFilesReference", os.Getenv("PIPER_changedFilesReference"), "Git reference (e.g. `origin/main` or the commit of the last successful build) the changes of the current commit are compared to in order to determine the affected packages. If not configured, the changes are compared to the base branch of the pull request or to the commit of the last successful build as provided by the orchestrator.")
 
 }
 
@@ -217,11 +228,34 @@ func npmExecuteLintMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "npm/outputFormat"}},
 						Default:     `defaultlint.xml`,
 					},
+					{
+						Name:        "affectedModulesOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "changedFilesReference",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_changedFilesReference"),
+					},
 				},
 			},
 			Containers: []config.Container{
 				{Name: "node", Image: "node:lts-buster"},
 			},
+			Cache: &config.StepCache{
+				Inputs:   []string{"**/package.json", "**/package-lock.json", "**/.eslintrc*", "**/*.js", "**/*.jsx", "**/*.ts", "**/*.tsx"},
+				Outputs:  []string{"*lint.xml", "**/cilint.xml"},
+				Excludes: []string{"**/node_modules/**"},
+			},
 		},
 	}
 	return theMetaData
@@ -454,3 +454,43 @@ func TestNpmExecuteLint(t *testing.T) {
 	})
 
 }
+
+func TestNpmExecuteLintAffectedPackages(t *testing.T) {
+	config := npmExecuteLintOptions{RunScript: "ci-lint", AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+	prepareUtils := func() (mockLintUtilsBundle, npm.Execute) {
+		lintUtils := newLintMockUtilsBundle()
+		lintUtils.AddFile("packages/app/package.json", []byte(`{"name": "app", "scripts": {"ci-lint": "eslint ."}}`))
+		lintUtils.AddFile("packages/cli/package.json", []byte(`{"name": "cli", "scripts": {"ci-lint": "eslint ."}}`))
+		lintUtils.AddFile("packages/docs/package.json", []byte(`{"name": "docs"}`))
+		npmUtils := newNpmMockUtilsBundle()
+		npmUtils.FilesMock = lintUtils.FilesMock
+		npmUtils.execRunner = lintUtils.execRunner
+		return lintUtils, npm.Execute{Utils: &npmUtils, Options: npm.ExecutorOptions{}}
+	}
+
+	t.Run("lint affected packages only", func(t *testing.T) {
+		mockChangedFiles(t, []string{"packages/cli/index.js"}, nil)
+		lintUtils, npmExecutor := prepareUtils()
+
+		err := runNpmExecuteLint(&npmExecutor, &lintUtils, &config)
+
+		assert.NoError(t, err)
+		lintCalls := 0
+		for _, call := range lintUtils.execRunner.Calls {
+			if call.Exec == "npm" && len(call.Params) > 1 && call.Params[1] == "ci-lint" {
+				lintCalls++
+			}
+		}
+		assert.Equal(t, 1, lintCalls)
+	})
+
+	t.Run("skip affected packages without lint script", func(t *testing.T) {
+		mockChangedFiles(t, []string{"packages/docs/README.md"}, nil)
+		lintUtils, npmExecutor := prepareUtils()
+
+		err := runNpmExecuteLint(&npmExecutor, &lintUtils, &config)
+
+		assert.NoError(t, err)
+		assert.Empty(t, lintUtils.execRunner.Calls)
+	})
+}
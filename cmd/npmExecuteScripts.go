@@ -63,9 +63,30 @@ func runNpmExecuteScripts(npmExecutor npm.Executor, config *npmExecuteScriptsOpt
 		}
 	}
 
-	err := npmExecutor.RunScriptsInAllPackages(config.RunScripts, nil, config.ScriptOptions, config.VirtualFrameBuffer, config.BuildDescriptorExcludeList, config.BuildDescriptorList)
-	if err != nil {
-		return err
+	packagesList := config.BuildDescriptorList
+	runScripts := config.RunScripts
+	if config.AffectedModulesOnly {
+		affectedPackages, restricted, err := affectedNpmPackages(npmExecutor, config.ChangedFilesReference, config.BuildDescriptorList, config.BuildDescriptorExcludeList)
+		if err != nil {
+			return err
+		}
+		if restricted {
+			if len(affectedPackages) == 0 {
+				log.Entry().Info("No package is affected by the changes, skipping the execution of scripts and publishing")
+				return nil
+			}
+			packagesList = affectedPackages
+			if runScripts, err = scriptsOfPackages(npmExecutor, runScripts, packagesList); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(runScripts) > 0 {
+		err := npmExecutor.RunScriptsInAllPackages(runScripts, nil, config.ScriptOptions, config.VirtualFrameBuffer, config.BuildDescriptorExcludeList, packagesList)
+		if err != nil {
+			return err
+		}
 	}
 
 	log.Entry().Debugf("creating build settings information...")
@@ -91,8 +112,8 @@ func runNpmExecuteScripts(npmExecutor npm.Executor, config *npmExecuteScriptsOpt
 	buildCoordinates := []versioning.Coordinates{}
 
 	if config.Publish {
-		if len(config.BuildDescriptorList) > 0 {
-			err = npmExecutor.PublishAllPackages(config.BuildDescriptorList, config.RepositoryURL, config.RepositoryUsername, config.RepositoryPassword, config.PackBeforePublish, &buildCoordinates)
+		if len(packagesList) > 0 {
+			err = npmExecutor.PublishAllPackages(packagesList, config.RepositoryURL, config.RepositoryUsername, config.RepositoryPassword, config.PackBeforePublish, &buildCoordinates)
 			if err != nil {
 				return err
 			}
@@ -124,3 +145,20 @@ func runNpmExecuteScripts(npmExecutor npm.Executor, config *npmExecuteScriptsOpt
 
 	return nil
 }
+
+// scriptsOfPackages returns the scripts which are defined in at least one of the packages
+func scriptsOfPackages(npmExecutor npm.Executor, scripts []string, packageJSONFiles []string) ([]string, error) {
+	definedScripts := []string{}
+	for _, script := range scripts {
+		packagesWithScript, err := npmExecutor.FindPackageJSONFilesWithScript(packageJSONFiles, script)
+		if err != nil {
+			return nil, err
+		}
+		if len(packagesWithScript) == 0 {
+			log.Entry().Infof("Skipping script '%v' since none of the affected packages defines it", script)
+			continue
+		}
+		definedScripts = append(definedScripts, script)
+	}
+	return definedScripts, nil
+}
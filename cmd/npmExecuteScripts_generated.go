@@ -39,6 +39,8 @@ type npmExecuteScriptsOptions struct {
 	PackBeforePublish            bool     `json:"packBeforePublish,omitempty"`
 	Production                   bool     `json:"production,omitempty"`
 	CreateBuildArtifactsMetadata bool     `json:"createBuildArtifactsMetadata,omitempty"`
+	AffectedModulesOnly          bool     `json:"affectedModulesOnly,omitempty"`
+	ChangedFilesReference        string   `json:"changedFilesReference,omitempty"`
 }
 
 type npmExecuteScriptsCommonPipelineEnvironment struct {
@@ -128,6 +130,17 @@ func NpmExecuteScriptsCommand() *cobra.Command {
 		Short: "Execute npm run scripts on all npm packages in a project",
 		Long: `Execute npm run scripts in all package json files, if they implement the scripts.
 
+### package managers
+The package manager is detected per package.json from the ` + "`" + `packageManager` + "`" + ` field and the lock file
+(` + "`" + `package-lock.json` + "`" + `, ` + "`" + `yarn.lock` + "`" + ` or ` + "`" + `pnpm-lock.yaml` + "`" + `) of the package or of the workspace it belongs to.
+Besides npm and yarn 1, pnpm and yarn 2+ (including Plug'n'Play) are supported:
+
+* Dependencies of workspaces are installed once from the workspace root with ` + "`" + `pnpm install --frozen-lockfile` + "`" + ` or ` + "`" + `yarn install --immutable` + "`" + `.
+* Scripts are executed with ` + "`" + `pnpm run` + "`" + ` or ` + "`" + `yarn run` + "`" + ` in the directory of the respective package.
+* Packages are packed with ` + "`" + `pnpm pack` + "`" + ` or ` + "`" + `yarn pack` + "`" + ` before publishing, so that ` + "`" + `workspace:` + "`" + ` dependencies are replaced with the actual versions.
+  The tarball is published with the registry credentials configured for the step.
+* The BOM of yarn 2+ projects is created with the CycloneDX yarn plugin. The BOM of pnpm projects is created with cdxgen and converted into the XML format of all other npm BOMs (` + "`" + `bom-npm.xml` + "`" + `).
+
 ### build with depedencies from a private repository
 if your build has scoped/unscoped dependencies from a private repository you can include a .npmrc into the source code
 repository as below (replace the ` + "`" + `@privateScope:registry` + "`" + ` value(s) with a valid private repo url) :
@@ -199,9 +212,11 @@ and are exposed are environment variables that must be present in the environmen
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -264,6 +279,8 @@ func addNpmExecuteScriptsFlags(cmd *cobra.Command, stepConfig *npmExecuteScripts
 	cmd.Flags().BoolVar(&stepConfig.PackBeforePublish, "packBeforePublish", false, "used for executing npm pack first, followed by npm publish. This two step maybe required in two cases. case 1) When building multiple npm packages (multiple package.json) please keep this parameter true and also see `buildDescriptorList` or  `buildDescriptorExcludeList` to choose which package(s) to publish. case 2)when you are building a single npm (single `package.json` in your repo) / multiple npm (multiple package.json) scoped package(s) and have npm dependencies from the same scope.")
 	cmd.Flags().BoolVar(&stepConfig.Production, "production", false, "used for omitting installation of dev. dependencies if true")
 	cmd.Flags().BoolVar(&stepConfig.CreateBuildArtifactsMetadata, "createBuildArtifactsMetadata", false, "metadata about the artifacts that are build and published , this metadata is generally used by steps downstream in the pipeline")
+	cmd.Flags().BoolVar(&stepConfig.AffectedModulesOnly, "affectedModulesOnly", false, "Whether only the packages affected by the changes compared to `changedFilesReference` are considered, i.e. the packages containing changed files and the packages depending on them. In case the changes cannot be determined, e.g. in a shallow clone, all packages are considered.")
+	cmd.Flags().StringVar(&stepConfig.ChangedFilesReference, "changedFilesReference", os.Getenv("PIPER_changedFilesReference"), "Git reference (e.g. `origin/main` or the commit of the last successful build) the changes of the current commit are compared to in order to determine the affected packages. If not configured, the changes are compared to the base branch of the pull request or to the commit of the last successful build as provided by the orchestrator.")
 
 }
 
@@ -460,6 +477,24 @@ func npmExecuteScriptsMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "affectedModulesOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "changedFilesReference",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_changedFilesReference"),
+					},
 				},
 			},
 			Containers: []config.Container{
@@ -212,3 +212,62 @@ func TestNpmExecuteScripts(t *testing.T) {
 	})
 
 }
+
+func TestNpmExecuteScriptsAffectedPackages(t *testing.T) {
+	cpe := npmExecuteScriptsCommonPipelineEnvironment{}
+	SetConfigOptions(ConfigCommandOptions{
+		OpenFile: config.OpenPiperFile,
+	})
+	prepareUtils := func() NpmMockUtilsBundle {
+		utils := newNpmMockUtilsBundle()
+		utils.AddFile("packages/utils/package.json", []byte(`{"name": "utils", "scripts": {"ci-build": "tsc", "ci-test": "jest"}}`))
+		utils.AddFile("packages/app/package.json", []byte(`{"name": "app", "dependencies": {"utils": "*"}, "scripts": {"ci-build": "tsc"}}`))
+		utils.AddFile("packages/cli/package.json", []byte(`{"name": "cli", "scripts": {"ci-build": "tsc", "ci-test": "jest"}}`))
+		return utils
+	}
+	scriptCalls := func(utils NpmMockUtilsBundle) []string {
+		calls := []string{}
+		for _, call := range utils.execRunner.Calls {
+			if len(call.Params) > 1 && call.Params[0] == "run" {
+				calls = append(calls, call.Params[1])
+			}
+		}
+		return calls
+	}
+
+	t.Run("run scripts in affected packages only", func(t *testing.T) {
+		mockChangedFiles(t, []string{"packages/utils/src/index.ts"}, nil)
+		utils := prepareUtils()
+		cfg := npmExecuteScriptsOptions{RunScripts: []string{"ci-build", "ci-test"}, AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+		npmExecutor := npm.Execute{Utils: &utils, Options: npm.ExecutorOptions{}}
+
+		err := runNpmExecuteScripts(&npmExecutor, &cfg, &cpe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ci-build", "ci-build", "ci-test"}, scriptCalls(utils))
+	})
+
+	t.Run("skip scripts not defined in affected packages", func(t *testing.T) {
+		mockChangedFiles(t, []string{"packages/app/src/index.ts"}, nil)
+		utils := prepareUtils()
+		cfg := npmExecuteScriptsOptions{RunScripts: []string{"ci-build", "ci-test"}, AffectedModulesOnly: true, ChangedFilesReference: "origin/main"}
+		npmExecutor := npm.Execute{Utils: &utils, Options: npm.ExecutorOptions{}}
+
+		err := runNpmExecuteScripts(&npmExecutor, &cfg, &cpe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ci-build"}, scriptCalls(utils))
+	})
+
+	t.Run("no affected packages", func(t *testing.T) {
+		mockChangedFiles(t, []string{"README.md"}, nil)
+		utils := prepareUtils()
+		cfg := npmExecuteScriptsOptions{RunScripts: []string{"ci-build"}, AffectedModulesOnly: true, ChangedFilesReference: "origin/main", Publish: true}
+		npmExecutor := npm.Execute{Utils: &utils, Options: npm.ExecutorOptions{}}
+
+		err := runNpmExecuteScripts(&npmExecutor, &cfg, &cpe)
+
+		assert.NoError(t, err)
+		assert.Empty(t, utils.execRunner.Calls)
+	})
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/toolrecord"
+	"github.com/pkg/errors"
+)
+
+type osvExecuteScanUtils interface {
+	piperutils.FileUtils
+}
+
+type osvExecuteScanUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newOsvExecuteScanUtils() osvExecuteScanUtils {
+	return &osvExecuteScanUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+}
+
+func osvExecuteScan(config osvExecuteScanOptions, telemetryData *telemetry.CustomData, influx *osvExecuteScanInflux) {
+	utils := newOsvExecuteScanUtils()
+
+	influx.step_data.fields.osv = false
+	if err := runOsvExecuteScan(&config, utils, influx); err != nil {
+		log.Entry().WithError(err).Fatal("OSV scan failed")
+	}
+	influx.step_data.fields.osv = true
+}
+
+func runOsvExecuteScan(config *osvExecuteScanOptions, utils osvExecuteScanUtils, influx *osvExecuteScanInflux) error {
+	db, err := osv.LoadDatabase(config.DatabasePath)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	log.Entry().Infof("Loaded %v vulnerabilities from OSV database '%v'", db.Size(), config.DatabasePath)
+
+	packages, err := collectOsvPackages(config, utils)
+	if err != nil {
+		return err
+	}
+	if len(packages) == 0 {
+		log.Entry().Warnf("No dependencies found in files matching %v", config.ScanFiles)
+	}
+
+	findings := []osv.Finding{}
+	excluded := 0
+	for _, finding := range db.Scan(packages) {
+		if finding.Matches(config.ExcludeVulnerabilities) {
+			log.Entry().Debugf("Ignoring excluded vulnerability %v of %v", finding.Vulnerability.ID, finding.Package.Purl())
+			excluded++
+			continue
+		}
+		findings = append(findings, finding)
+	}
+
+	counts := osv.CountBySeverity(findings)
+	influx.osv_data.fields.packages = len(packages)
+	influx.osv_data.fields.vulnerabilities = len(findings)
+	influx.osv_data.fields.critical_vulnerabilities = counts[osv.SeverityCritical]
+	influx.osv_data.fields.high_vulnerabilities = counts[osv.SeverityHigh]
+	influx.osv_data.fields.medium_vulnerabilities = counts[osv.SeverityMedium]
+	influx.osv_data.fields.low_vulnerabilities = counts[osv.SeverityLow]
+	influx.osv_data.fields.excluded_vulnerabilities = excluded
+
+	reports := []piperutils.Path{}
+	scanReport := osv.CreateCustomReport(config.DatabasePath, packages, findings, config.FailOnSeverities)
+	paths, err := osv.WriteCustomReports(scanReport, config.DatabasePath, utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create custom HTML report")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	paths, err = osv.WriteSarifFile(osv.CreateSarifResultFile(findings), utils)
+	if err != nil {
+		log.Entry().WithError(err).Warning("failed to create SARIF file")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	toolRecordFileName, err := createToolRecordOsv(utils, "./", config, len(packages), findings)
+	if err != nil {
+		// do not fail until the framework is well established
+		log.Entry().WithError(err).Warning("TR_OSV: Failed to create toolrecord file")
+	} else {
+		reports = append(reports, piperutils.Path{Target: toolRecordFileName})
+	}
+
+	piperutils.PersistReportsAndLinks("osvExecuteScan", "", utils, reports, nil)
+
+	for _, severity := range osv.Severities {
+		if counts[severity] > 0 {
+			log.Entry().Infof("%v vulnerabilities with severity %v found", counts[severity], severity)
+		}
+	}
+	if severe := osv.SevereFindings(findings, config.FailOnSeverities); len(severe) > 0 {
+		for _, finding := range severe {
+			log.Entry().Errorf("%v (%v) affects %v", finding.Vulnerability.ID, finding.Severity, finding.Package.Purl())
+		}
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v vulnerabilities with severity %v found", len(severe), strings.Join(config.FailOnSeverities, ", "))
+	}
+	return nil
+}
+
+func collectOsvPackages(config *osvExecuteScanOptions, utils osvExecuteScanUtils) ([]osv.Package, error) {
+	packages := []osv.Package{}
+	for _, pattern := range config.ScanFiles {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "invalid pattern '%v'", pattern)
+		}
+		matches, err = piperutils.ExcludeFiles(matches, config.Excludes)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, err
+		}
+		for _, match := range matches {
+			if isDir, _ := utils.DirExists(match); isDir {
+				continue
+			}
+			log.Entry().Infof("Reading dependencies from '%v'", match)
+			filePackages, err := osv.ReadPackages(match)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return nil, err
+			}
+			packages = append(packages, filePackages...)
+		}
+	}
+	return osv.Deduplicate(packages), nil
+}
+
+// create toolrecord file for the OSV scan
+func createToolRecordOsv(utils osvExecuteScanUtils, workspace string, config *osvExecuteScanOptions, packages int, findings []osv.Finding) (string, error) {
+	record := toolrecord.New(utils, workspace, "osv", config.DatabasePath)
+	if err := record.AddKeyData("database", config.DatabasePath, "OSV database", ""); err != nil {
+		return "", err
+	}
+	if err := record.AddContext("packages", packages); err != nil {
+		return "", err
+	}
+	if err := record.AddContext("vulnerabilities", len(findings)); err != nil {
+		return "", err
+	}
+	if err := record.Persist(); err != nil {
+		return "", err
+	}
+	return record.GetFileName(), nil
+}
@@ -0,0 +1,371 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/cobra"
+)
+
+type osvExecuteScanOptions struct {
+	DatabasePath           string   `json:"databasePath,omitempty"`
+	ScanFiles              []string `json:"scanFiles,omitempty"`
+	Excludes               []string `json:"excludes,omitempty"`
+	FailOnSeverities       []string `json:"failOnSeverities,omitempty" validate:"possible-values=CRITICAL HIGH MEDIUM LOW UNKNOWN"`
+	ExcludeVulnerabilities []string `json:"excludeVulnerabilities,omitempty"`
+}
+
+type osvExecuteScanInflux struct {
+	step_data struct {
+		fields struct {
+			osv bool
+		}
+		tags struct {
+		}
+	}
+	osv_data struct {
+		fields struct {
+			packages                 int
+			vulnerabilities          int
+			critical_vulnerabilities int
+			high_vulnerabilities     int
+			medium_vulnerabilities   int
+			low_vulnerabilities      int
+			excluded_vulnerabilities int
+		}
+		tags struct {
+		}
+	}
+}
+
+func (i *osvExecuteScanInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "step_data", name: "osv", value: i.step_data.fields.osv},
+		{valType: config.InfluxField, measurement: "osv_data", name: "packages", value: i.osv_data.fields.packages},
+		{valType: config.InfluxField, measurement: "osv_data", name: "vulnerabilities", value: i.osv_data.fields.vulnerabilities},
+		{valType: config.InfluxField, measurement: "osv_data", name: "critical_vulnerabilities", value: i.osv_data.fields.critical_vulnerabilities},
+		{valType: config.InfluxField, measurement: "osv_data", name: "high_vulnerabilities", value: i.osv_data.fields.high_vulnerabilities},
+		{valType: config.InfluxField, measurement: "osv_data", name: "medium_vulnerabilities", value: i.osv_data.fields.medium_vulnerabilities},
+		{valType: config.InfluxField, measurement: "osv_data", name: "low_vulnerabilities", value: i.osv_data.fields.low_vulnerabilities},
+		{valType: config.InfluxField, measurement: "osv_data", name: "excluded_vulnerabilities", value: i.osv_data.fields.excluded_vulnerabilities},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Influx environment")
+	}
+}
+
+func (i *osvExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"osv": i.step_data.fields.osv,
+		},
+		"osv_data": {
+			"packages":                 i.osv_data.fields.packages,
+			"vulnerabilities":          i.osv_data.fields.vulnerabilities,
+			"critical_vulnerabilities": i.osv_data.fields.critical_vulnerabilities,
+			"high_vulnerabilities":     i.osv_data.fields.high_vulnerabilities,
+			"medium_vulnerabilities":   i.osv_data.fields.medium_vulnerabilities,
+			"low_vulnerabilities":      i.osv_data.fields.low_vulnerabilities,
+			"excluded_vulnerabilities": i.osv_data.fields.excluded_vulnerabilities,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
+type osvExecuteScanReports struct {
+}
+
+func (p *osvExecuteScanReports) persist(stepConfig osvExecuteScanOptions, gcpJsonKeyFilePath string, gcsBucketId string, gcsFolderPath string, gcsSubFolder string) {
+	if gcsBucketId == "" {
+		log.Entry().Info("persisting reports to GCS is disabled, because gcsBucketId is empty")
+		return
+	}
+	log.Entry().Info("Uploading reports to Google Cloud Storage...")
+	content := []gcs.ReportOutputParam{
+		{FilePattern: "**/piper_osv_vulnerability_report.html", ParamRef: "", StepResultType: "osv"},
+		{FilePattern: "**/piper_osv_vulnerability.sarif", ParamRef: "", StepResultType: "osv"},
+		{FilePattern: "**/toolrun_osv_*.json", ParamRef: "", StepResultType: "osv"},
+	}
+	envVars := []gcs.EnvVar{
+		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+	}
+	gcsClient, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+	if err != nil {
+		log.Entry().Errorf("creation of GCS client failed: %v", err)
+		return
+	}
+	defer gcsClient.Close()
+	structVal := reflect.ValueOf(&stepConfig).Elem()
+	inputParameters := map[string]string{}
+	for i := 0; i < structVal.NumField(); i++ {
+		field := structVal.Type().Field(i)
+		if field.Type.String() == "string" {
+			paramName := strings.Split(field.Tag.Get("json"), ",")
+			paramValue, _ := structVal.Field(i).Interface().(string)
+			inputParameters[paramName[0]] = paramValue
+		}
+	}
+	if err := gcs.PersistReportsToGCS(gcsClient, content, inputParameters, gcsFolderPath, gcsBucketId, gcsSubFolder, doublestar.Glob, os.Stat); err != nil {
+		log.Entry().Errorf("failed to persist reports: %v", err)
+	}
+}
+
+// OsvExecuteScanCommand Scans the dependencies of a project for known vulnerabilities using a local snapshot of the OSV database.
+func OsvExecuteScanCommand() *cobra.Command {
+	const STEP_NAME = "osvExecuteScan"
+
+	metadata := osvExecuteScanMetadata()
+	var stepConfig osvExecuteScanOptions
+	var startTime time.Time
+	var influx osvExecuteScanInflux
+	var reports osvExecuteScanReports
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createOsvExecuteScanCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Scans the dependencies of a project for known vulnerabilities using a local snapshot of the OSV database.",
+		Long: `This step checks the open source dependencies of your project against a local snapshot of the [OSV database](https://osv.dev).
+It does not require a connection to a scan backend nor a license and can therefore also be used in air-gapped environments.
+
+The dependencies are read from the following files which are detected by their name:
+
+* CycloneDX SBOMs in XML or JSON format, e.g. ` + "`" + `bom-maven.xml` + "`" + ` created by the build steps
+* ` + "`" + `go.sum` + "`" + `
+* ` + "`" + `package-lock.json` + "`" + ` and ` + "`" + `npm-shrinkwrap.json` + "`" + `
+* all other files are treated as output of ` + "`" + `mvn dependency:tree -DoutputFile=<file>` + "`" + `
+
+The OSV database snapshot can consist of single OSV JSON files as well as the zip archives which are provided per ecosystem,
+e.g. [npm/all.zip](https://osv-vulnerabilities.storage.googleapis.com/npm/all.zip).
+
+The step creates an HTML report, a SARIF file and a toolrecord file and fails in case vulnerabilities with one of the configured severities are found.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
+				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			osvExecuteScan(stepConfig, &stepTelemetryData, &influx)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addOsvExecuteScanFlags(createOsvExecuteScanCmd, &stepConfig)
+	return createOsvExecuteScanCmd
+}
+
+func addOsvExecuteScanFlags(cmd *cobra.Command, stepConfig *osvExecuteScanOptions) {
+	cmd.Flags().StringVar(&stepConfig.DatabasePath, "databasePath", os.Getenv("PIPER_databasePath"), "Path to the local OSV database snapshot. This can be a directory containing OSV JSON files and/or zip archives or a single file.")
+	cmd.Flags().StringSliceVar(&stepConfig.ScanFiles, "scanFiles", []string{`**/bom-*.xml`, `**/go.sum`, `**/package-lock.json`}, "List of glob patterns of the files containing the dependencies to be scanned.")
+	cmd.Flags().StringSliceVar(&stepConfig.Excludes, "excludes", []string{`**/node_modules/**`}, "List of glob patterns of files which are excluded from the scan.")
+	cmd.Flags().StringSliceVar(&stepConfig.FailOnSeverities, "failOnSeverities", []string{`CRITICAL`, `HIGH`}, "List of vulnerability severities which cause the step to fail. Set to an empty list in order to only report the vulnerabilities.")
+	cmd.Flags().StringSliceVar(&stepConfig.ExcludeVulnerabilities, "excludeVulnerabilities", []string{}, "List of vulnerability identifiers (OSV IDs or aliases like CVE identifiers) which are ignored, e.g. because they have been assessed as not relevant.")
+
+	cmd.MarkFlagRequired("databasePath")
+}
+
+// retrieve step metadata
+func osvExecuteScanMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "osvExecuteScan",
+			Aliases:     []config.Alias{},
+			Description: "Scans the dependencies of a project for known vulnerabilities using a local snapshot of the OSV database.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Parameters: []config.StepParameters{
+					{
+						Name:        "databasePath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   true,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_databasePath"),
+					},
+					{
+						Name:        "scanFiles",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/bom-*.xml`, `**/go.sum`, `**/package-lock.json`},
+					},
+					{
+						Name:        "excludes",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/node_modules/**`},
+					},
+					{
+						Name:        "failOnSeverities",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`CRITICAL`, `HIGH`},
+					},
+					{
+						Name:        "excludeVulnerabilities",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"name": "step_data", "fields": []map[string]string{{"name": "osv"}}},
+							{"name": "osv_data", "fields": []map[string]string{{"name": "packages"}, {"name": "vulnerabilities"}, {"name": "critical_vulnerabilities"}, {"name": "high_vulnerabilities"}, {"name": "medium_vulnerabilities"}, {"name": "low_vulnerabilities"}, {"name": "excluded_vulnerabilities"}}},
+						},
+					},
+					{
+						Name: "reports",
+						Type: "reports",
+						Parameters: []map[string]interface{}{
+							{"filePattern": "**/piper_osv_vulnerability_report.html", "type": "osv"},
+							{"filePattern": "**/piper_osv_vulnerability.sarif", "type": "osv"},
+							{"filePattern": "**/toolrun_osv_*.json", "type": "osv"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
@@ -0,0 +1,125 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const osvLodashEntry = `{
+  "id": "GHSA-35jh-r3h4-6jhm",
+  "aliases": ["CVE-2021-23337"],
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+  }],
+  "database_specific": {"severity": "HIGH"}
+}`
+
+const osvPackageLock = `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.20"}
+  }
+}`
+
+func prepareOsvWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	require.NoError(t, os.MkdirAll(filepath.Join("osv-db"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("osv-db", "GHSA-35jh-r3h4-6jhm.json"), []byte(osvLodashEntry), 0644))
+	require.NoError(t, os.WriteFile("package-lock.json", []byte(osvPackageLock), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join("node_modules", "a"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("node_modules", "a", "package-lock.json"), []byte("{"), 0644))
+}
+
+func TestRunOsvExecuteScan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		prepareOsvWorkspace(t)
+		config := osvExecuteScanOptions{
+			DatabasePath:     "osv-db",
+			ScanFiles:        []string{"**/package-lock.json"},
+			Excludes:         []string{"**/node_modules/**"},
+			FailOnSeverities: []string{"CRITICAL"},
+		}
+		influx := osvExecuteScanInflux{}
+
+		err := runOsvExecuteScan(&config, &piperutils.Files{}, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, influx.osv_data.fields.packages)
+		assert.Equal(t, 1, influx.osv_data.fields.vulnerabilities)
+		assert.Equal(t, 1, influx.osv_data.fields.high_vulnerabilities)
+		assert.FileExists(t, filepath.Join(osv.ReportsDirectory, "piper_osv_vulnerability_report.html"))
+		assert.FileExists(t, filepath.Join(osv.ReportsDirectory, "piper_osv_vulnerability.sarif"))
+		toolRecords, _ := filepath.Glob(filepath.Join("toolruns", "toolrun_osv_*.json"))
+		assert.Len(t, toolRecords, 1)
+		assert.FileExists(t, "osvExecuteScan_reports.json")
+	})
+
+	t.Run("severe vulnerabilities", func(t *testing.T) {
+		prepareOsvWorkspace(t)
+		config := osvExecuteScanOptions{
+			DatabasePath:     "osv-db",
+			ScanFiles:        []string{"**/package-lock.json"},
+			Excludes:         []string{"**/node_modules/**"},
+			FailOnSeverities: []string{"CRITICAL", "HIGH"},
+		}
+
+		err := runOsvExecuteScan(&config, &piperutils.Files{}, &osvExecuteScanInflux{})
+
+		assert.EqualError(t, err, "1 vulnerabilities with severity CRITICAL, HIGH found")
+	})
+
+	t.Run("excluded vulnerabilities", func(t *testing.T) {
+		prepareOsvWorkspace(t)
+		config := osvExecuteScanOptions{
+			DatabasePath:           "osv-db",
+			ScanFiles:              []string{"**/package-lock.json"},
+			Excludes:               []string{"**/node_modules/**"},
+			FailOnSeverities:       []string{"HIGH"},
+			ExcludeVulnerabilities: []string{"CVE-2021-23337"},
+		}
+		influx := osvExecuteScanInflux{}
+
+		err := runOsvExecuteScan(&config, &piperutils.Files{}, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, influx.osv_data.fields.vulnerabilities)
+		assert.Equal(t, 1, influx.osv_data.fields.excluded_vulnerabilities)
+	})
+
+	t.Run("invalid dependency file", func(t *testing.T) {
+		prepareOsvWorkspace(t)
+		config := osvExecuteScanOptions{
+			DatabasePath: "osv-db",
+			ScanFiles:    []string{"**/package-lock.json"},
+		}
+
+		err := runOsvExecuteScan(&config, &piperutils.Files{}, &osvExecuteScanInflux{})
+
+		assert.ErrorContains(t, err, "failed to parse file 'node_modules/a/package-lock.json'")
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		prepareOsvWorkspace(t)
+		config := osvExecuteScanOptions{DatabasePath: "missing"}
+
+		err := runOsvExecuteScan(&config, &piperutils.Files{}, &osvExecuteScanInflux{})
+
+		assert.ErrorContains(t, err, "failed to access OSV database 'missing'")
+	})
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/ado"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/jenkins"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/remotepipeline"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type pipelineTriggerRemoteUtils interface {
+	NewRemotePipeline(ctx context.Context, config *pipelineTriggerRemoteOptions) (remotepipeline.Pipeline, error)
+}
+
+type pipelineTriggerRemoteUtilsBundle struct{}
+
+// NewRemotePipeline connects to the platform of the downstream pipeline
+func (p *pipelineTriggerRemoteUtilsBundle) NewRemotePipeline(ctx context.Context, config *pipelineTriggerRemoteOptions) (remotepipeline.Pipeline, error) {
+	switch config.Platform {
+	case "jenkins":
+		instance, err := jenkins.Instance(ctx, &http.Client{}, config.JenkinsURL, config.JenkinsUsername, config.JenkinsToken)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to Jenkins %v", config.JenkinsURL)
+		}
+		return &remotepipeline.Jenkins{Instance: instance, JobName: config.JobName}, nil
+	case "github":
+		_, client, err := piperGithub.NewClientBuilder(config.GithubToken, config.GithubAPIURL).Build()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get GitHub client")
+		}
+		return &remotepipeline.GitHubActions{
+			Actions:    client.Actions,
+			Downloader: &piperhttp.Client{},
+			Owner:      config.Owner,
+			Repository: config.Repository,
+			Workflow:   config.Workflow,
+			Ref:        config.Ref,
+		}, nil
+	case "azure":
+		client, err := ado.NewClient(ctx, config.AdoOrganization, config.AdoPersonalAccessToken)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to Azure DevOps organization %v", config.AdoOrganization)
+		}
+		return &remotepipeline.AzurePipelines{Client: client, Project: config.AdoProject, PipelineID: config.AdoPipelineID, Ref: config.Ref}, nil
+	}
+	return nil, fmt.Errorf("platform '%v' is not supported", config.Platform)
+}
+
+func pipelineTriggerRemote(config pipelineTriggerRemoteOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *pipelineTriggerRemoteCommonPipelineEnvironment) {
+	utils := &pipelineTriggerRemoteUtilsBundle{}
+
+	if err := runPipelineTriggerRemote(context.Background(), &config, utils, commonPipelineEnvironment); err != nil {
+		log.Entry().WithError(err).Fatal("Remote pipeline failed")
+	}
+}
+
+func runPipelineTriggerRemote(ctx context.Context, config *pipelineTriggerRemoteOptions, utils pipelineTriggerRemoteUtils, commonPipelineEnvironment *pipelineTriggerRemoteCommonPipelineEnvironment) error {
+	pipeline, err := utils.NewRemotePipeline(ctx, config)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	parameters := map[string]string{}
+	for key, value := range config.Parameters {
+		parameters[key] = fmt.Sprint(value)
+	}
+
+	run, err := pipeline.Trigger(ctx, parameters)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return err
+	}
+	commonPipelineEnvironment.custom.remotePipelineURL = run.URL()
+	log.Entry().Infof("Triggered remote pipeline %v", run.URL())
+
+	if err := remotepipeline.WaitForRun(ctx, run, time.Duration(config.Timeout)*time.Minute, time.Duration(config.PollInterval)*time.Second); err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return err
+	}
+	result := run.Result()
+	commonPipelineEnvironment.custom.remotePipelineResult = result
+	log.Entry().Infof("Remote pipeline %v finished with result %v", run.URL(), result)
+
+	for _, artifact := range config.Artifacts {
+		log.Entry().Infof("Fetching artifact '%v' into %v", artifact, config.ArtifactsDirectory)
+		if err := run.DownloadArtifact(ctx, artifact, config.ArtifactsDirectory); err != nil {
+			if result != remotepipeline.ResultSuccess {
+				// failed pipelines may not have produced all artifacts, the result is reported instead
+				log.Entry().WithError(err).Warnf("Failed to fetch artifact '%v'", artifact)
+				continue
+			}
+			return errors.Wrapf(err, "failed to fetch artifact '%v' of remote pipeline %v", artifact, run.URL())
+		}
+	}
+
+	if config.PropagateResult && result != remotepipeline.ResultSuccess {
+		log.SetErrorCategory(log.ErrorTest)
+		return fmt.Errorf("remote pipeline %v finished with result %v", run.URL(), result)
+	}
+	return nil
+}
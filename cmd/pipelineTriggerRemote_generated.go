@@ -0,0 +1,510 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type pipelineTriggerRemoteOptions struct {
+	Platform               string                 `json:"platform,omitempty" validate:"possible-values=jenkins github azure"`
+	Parameters             map[string]interface{} `json:"parameters,omitempty"`
+	Ref                    string                 `json:"ref,omitempty" validate:"required_if=Platform github"`
+	Timeout                int                    `json:"timeout,omitempty"`
+	PollInterval           int                    `json:"pollInterval,omitempty"`
+	PropagateResult        bool                   `json:"propagateResult,omitempty"`
+	Artifacts              []string               `json:"artifacts,omitempty"`
+	ArtifactsDirectory     string                 `json:"artifactsDirectory,omitempty"`
+	JenkinsURL             string                 `json:"jenkinsUrl,omitempty" validate:"required_if=Platform jenkins"`
+	JobName                string                 `json:"jobName,omitempty" validate:"required_if=Platform jenkins"`
+	JenkinsUsername        string                 `json:"jenkinsUsername,omitempty"`
+	JenkinsToken           string                 `json:"jenkinsToken,omitempty"`
+	GithubAPIURL           string                 `json:"githubApiUrl,omitempty"`
+	GithubToken            string                 `json:"githubToken,omitempty" validate:"required_if=Platform github"`
+	Owner                  string                 `json:"owner,omitempty" validate:"required_if=Platform github"`
+	Repository             string                 `json:"repository,omitempty" validate:"required_if=Platform github"`
+	Workflow               string                 `json:"workflow,omitempty" validate:"required_if=Platform github"`
+	AdoOrganization        string                 `json:"adoOrganization,omitempty" validate:"required_if=Platform azure"`
+	AdoPersonalAccessToken string                 `json:"adoPersonalAccessToken,omitempty" validate:"required_if=Platform azure"`
+	AdoProject             string                 `json:"adoProject,omitempty" validate:"required_if=Platform azure"`
+	AdoPipelineID          int                    `json:"adoPipelineId,omitempty" validate:"required_if=Platform azure"`
+}
+
+type pipelineTriggerRemoteCommonPipelineEnvironment struct {
+	custom struct {
+		remotePipelineResult string
+		remotePipelineURL    string
+	}
+}
+
+func (p *pipelineTriggerRemoteCommonPipelineEnvironment) persist(path, resourceName string) {
+	content := []struct {
+		category string
+		name     string
+		value    interface{}
+	}{
+		{category: "custom", name: "remotePipelineResult", value: p.custom.remotePipelineResult},
+		{category: "custom", name: "remotePipelineUrl", value: p.custom.remotePipelineURL},
+	}
+
+	errCount := 0
+	for _, param := range content {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(param.category, param.name), param.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting piper environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Piper environment")
+	}
+}
+
+// PipelineTriggerRemoteCommand Triggers a downstream pipeline on Jenkins, GitHub Actions or Azure Pipelines and waits for its result
+func PipelineTriggerRemoteCommand() *cobra.Command {
+	const STEP_NAME = "pipelineTriggerRemote"
+
+	metadata := pipelineTriggerRemoteMetadata()
+	var stepConfig pipelineTriggerRemoteOptions
+	var startTime time.Time
+	var commonPipelineEnvironment pipelineTriggerRemoteCommonPipelineEnvironment
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createPipelineTriggerRemoteCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Triggers a downstream pipeline on Jenkins, GitHub Actions or Azure Pipelines and waits for its result",
+		Long: `This step starts a downstream pipeline with parameters and waits until it is finished, e.g. integration test suites which are maintained in separate pipelines.
+The following platforms are supported:
+
+* **jenkins**: a build of the job ` + "`" + `jobName` + "`" + ` is triggered, parameters are passed as build parameters.
+* **github**: the workflow ` + "`" + `workflow` + "`" + ` with a ` + "`" + `workflow_dispatch` + "`" + ` trigger is dispatched on ` + "`" + `ref` + "`" + `, parameters are passed as workflow inputs.
+* **azure**: a run of the pipeline ` + "`" + `adoPipelineId` + "`" + ` is queued on ` + "`" + `ref` + "`" + `, parameters are passed as queue time variables.
+
+The step fails if the downstream pipeline does not finish within ` + "`" + `timeout` + "`" + ` or, unless ` + "`" + `propagateResult` + "`" + ` is disabled, if it does not succeed.
+Artifacts listed in ` + "`" + `artifacts` + "`" + ` are fetched into ` + "`" + `artifactsDirectory` + "`" + `: archived files of Jenkins builds by their file name, workflow artifacts of GitHub Actions and published pipeline artifacts of Azure Pipelines by their name.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+			log.RegisterSecret(stepConfig.JenkinsUsername)
+			log.RegisterSecret(stepConfig.JenkinsToken)
+			log.RegisterSecret(stepConfig.GithubToken)
+			log.RegisterSecret(stepConfig.AdoPersonalAccessToken)
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			pipelineTriggerRemote(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addPipelineTriggerRemoteFlags(createPipelineTriggerRemoteCmd, &stepConfig)
+	return createPipelineTriggerRemoteCmd
+}
+
+func addPipelineTriggerRemoteFlags(cmd *cobra.Command, stepConfig *pipelineTriggerRemoteOptions) {
+	cmd.Flags().StringVar(&stepConfig.Platform, "platform", os.Getenv("PIPER_platform"), "Platform of the downstream pipeline.")
+
+	cmd.Flags().StringVar(&stepConfig.Ref, "ref", os.Getenv("PIPER_ref"), "Branch or tag the downstream pipeline runs on. Mandatory for GitHub Actions, Azure Pipelines use the default branch of the pipeline if not set.")
+	cmd.Flags().IntVar(&stepConfig.Timeout, "timeout", 60, "Time in minutes to wait for the downstream pipeline to finish.")
+	cmd.Flags().IntVar(&stepConfig.PollInterval, "pollInterval", 30, "Interval in seconds in which the state of the downstream pipeline is checked.")
+	cmd.Flags().BoolVar(&stepConfig.PropagateResult, "propagateResult", true, "Fails the step if the downstream pipeline does not succeed.")
+	cmd.Flags().StringSliceVar(&stepConfig.Artifacts, "artifacts", []string{}, "Names of the artifacts which are fetched from the downstream pipeline once it is finished.")
+	cmd.Flags().StringVar(&stepConfig.ArtifactsDirectory, "artifactsDirectory", `remotePipelineArtifacts`, "Directory in the workspace into which the artifacts are fetched.")
+	cmd.Flags().StringVar(&stepConfig.JenkinsURL, "jenkinsUrl", os.Getenv("PIPER_jenkinsUrl"), "URL of the remote Jenkins.")
+	cmd.Flags().StringVar(&stepConfig.JobName, "jobName", os.Getenv("PIPER_jobName"), "Full name of the Jenkins job, e.g. `folder/integration-tests`.")
+	cmd.Flags().StringVar(&stepConfig.JenkinsUsername, "jenkinsUsername", os.Getenv("PIPER_jenkinsUsername"), "User of the remote Jenkins.")
+	cmd.Flags().StringVar(&stepConfig.JenkinsToken, "jenkinsToken", os.Getenv("PIPER_jenkinsToken"), "API token of the user of the remote Jenkins.")
+	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API URL.")
+	cmd.Flags().StringVar(&stepConfig.GithubToken, "githubToken", os.Getenv("PIPER_githubToken"), "GitHub personal access token with the scope 'repo' or a token with the permission 'actions:write'.")
+	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Owner of the GitHub repository containing the workflow.")
+	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Name of the GitHub repository containing the workflow.")
+	cmd.Flags().StringVar(&stepConfig.Workflow, "workflow", os.Getenv("PIPER_workflow"), "File name of the GitHub Actions workflow, e.g. `integration.yml`.")
+	cmd.Flags().StringVar(&stepConfig.AdoOrganization, "adoOrganization", os.Getenv("PIPER_adoOrganization"), "The Azure DevOps organization name.")
+	cmd.Flags().StringVar(&stepConfig.AdoPersonalAccessToken, "adoPersonalAccessToken", os.Getenv("PIPER_adoPersonalAccessToken"), "The Azure DevOps personal access token with the scope 'Build (read and execute)'.")
+	cmd.Flags().StringVar(&stepConfig.AdoProject, "adoProject", os.Getenv("PIPER_adoProject"), "The Azure DevOps project ID. Project name also can be used.")
+	cmd.Flags().IntVar(&stepConfig.AdoPipelineID, "adoPipelineId", 0, "The Azure DevOps pipeline ID. Also called as definition ID.")
+
+	cmd.MarkFlagRequired("platform")
+}
+
+// retrieve step metadata
+func pipelineTriggerRemoteMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "pipelineTriggerRemote",
+			Aliases:     []config.Alias{},
+			Description: "Triggers a downstream pipeline on Jenkins, GitHub Actions or Azure Pipelines and waits for its result",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Secrets: []config.StepSecrets{
+					{Name: "jenkinsCredentialsId", Description: "Jenkins 'Username with password' credentials ID containing the user and the API token of the remote Jenkins.", Type: "jenkins"},
+					{Name: "githubTokenCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing token to authenticate to GitHub.", Type: "jenkins"},
+					{Name: "adoPersonalAccessTokenCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing the Azure DevOps personal access token.", Type: "jenkins"},
+				},
+				Parameters: []config.StepParameters{
+					{
+						Name:        "platform",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   true,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_platform"),
+					},
+					{
+						Name:        "parameters",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "map[string]interface{}",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+					},
+					{
+						Name:        "ref",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_ref"),
+					},
+					{
+						Name:        "timeout",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     60,
+					},
+					{
+						Name:        "pollInterval",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     30,
+					},
+					{
+						Name:        "propagateResult",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+					{
+						Name:        "artifacts",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "artifactsDirectory",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `remotePipelineArtifacts`,
+					},
+					{
+						Name:        "jenkinsUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_jenkinsUrl"),
+					},
+					{
+						Name:        "jobName",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_jobName"),
+					},
+					{
+						Name: "jenkinsUsername",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "jenkinsCredentialsId",
+								Param: "username",
+								Type:  "secret",
+							},
+
+							{
+								Name:    "jenkinsVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "jenkins",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_jenkinsUsername"),
+					},
+					{
+						Name: "jenkinsToken",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "jenkinsCredentialsId",
+								Param: "password",
+								Type:  "secret",
+							},
+
+							{
+								Name:    "jenkinsVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "jenkins",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_jenkinsToken"),
+					},
+					{
+						Name:        "githubApiUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `https://api.github.com`,
+					},
+					{
+						Name: "githubToken",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "githubTokenCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "githubVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "github",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "access_token"}},
+						Default:   os.Getenv("PIPER_githubToken"),
+					},
+					{
+						Name: "owner",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "github/owner",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubOrg"}},
+						Default:   os.Getenv("PIPER_owner"),
+					},
+					{
+						Name: "repository",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "github/repository",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubRepo"}},
+						Default:   os.Getenv("PIPER_repository"),
+					},
+					{
+						Name:        "workflow",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_workflow"),
+					},
+					{
+						Name:        "adoOrganization",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_adoOrganization"),
+					},
+					{
+						Name: "adoPersonalAccessToken",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "adoPersonalAccessTokenCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "azureDevOpsVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "azure-dev-ops",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_adoPersonalAccessToken"),
+					},
+					{
+						Name:        "adoProject",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_adoProject"),
+					},
+					{
+						Name:        "adoPipelineId",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     0,
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "commonPipelineEnvironment",
+						Type: "piperEnvironment",
+						Parameters: []map[string]interface{}{
+							{"name": "custom/remotePipelineResult"},
+							{"name": "custom/remotePipelineUrl"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
@@ -0,0 +1,20 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineTriggerRemoteCommand(t *testing.T) {
+	t.Parallel()
+
+	testCmd := PipelineTriggerRemoteCommand()
+
+	// only high level testing performed - details are tested in step generation procedure
+	assert.Equal(t, "pipelineTriggerRemote", testCmd.Use, "command name incorrect")
+
+}
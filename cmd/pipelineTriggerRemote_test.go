@@ -0,0 +1,153 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/remotepipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+type remotePipelineMock struct {
+	parameters map[string]string
+	run        *remoteRunMock
+	triggerErr error
+}
+
+func (p *remotePipelineMock) Trigger(ctx context.Context, parameters map[string]string) (remotepipeline.Run, error) {
+	p.parameters = parameters
+	if p.triggerErr != nil {
+		return nil, p.triggerErr
+	}
+	return p.run, nil
+}
+
+type remoteRunMock struct {
+	result     string
+	artifacts  map[string]string
+	downloaded []string
+}
+
+func (r *remoteRunMock) Refresh(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (r *remoteRunMock) Result() string {
+	return r.result
+}
+
+func (r *remoteRunMock) URL() string {
+	return "https://ci.example.com/job/integration-tests/7/"
+}
+
+func (r *remoteRunMock) DownloadArtifact(ctx context.Context, name, targetDir string) error {
+	if _, ok := r.artifacts[name]; !ok {
+		return fmt.Errorf("failed to fetch artifact: Artifact '%v' not found", name)
+	}
+	r.downloaded = append(r.downloaded, targetDir+"/"+name)
+	return nil
+}
+
+type pipelineTriggerRemoteMockUtils struct {
+	pipeline *remotePipelineMock
+}
+
+func (p *pipelineTriggerRemoteMockUtils) NewRemotePipeline(ctx context.Context, config *pipelineTriggerRemoteOptions) (remotepipeline.Pipeline, error) {
+	if p.pipeline == nil {
+		return nil, fmt.Errorf("platform '%v' is not supported", config.Platform)
+	}
+	return p.pipeline, nil
+}
+
+func TestRunPipelineTriggerRemote(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		run := &remoteRunMock{result: remotepipeline.ResultSuccess, artifacts: map[string]string{"results.xml": ""}}
+		utils := &pipelineTriggerRemoteMockUtils{pipeline: &remotePipelineMock{run: run}}
+		config := pipelineTriggerRemoteOptions{
+			Platform:           "jenkins",
+			Parameters:         map[string]interface{}{"environment": "staging", "parallel": 4},
+			Timeout:            1,
+			Artifacts:          []string{"results.xml"},
+			ArtifactsDirectory: "remotePipelineArtifacts",
+			PropagateResult:    true,
+		}
+		cpe := pipelineTriggerRemoteCommonPipelineEnvironment{}
+
+		err := runPipelineTriggerRemote(ctx, &config, utils, &cpe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"environment": "staging", "parallel": "4"}, utils.pipeline.parameters)
+		assert.Equal(t, []string{"remotePipelineArtifacts/results.xml"}, run.downloaded)
+		assert.Equal(t, remotepipeline.ResultSuccess, cpe.custom.remotePipelineResult)
+		assert.Equal(t, "https://ci.example.com/job/integration-tests/7/", cpe.custom.remotePipelineURL)
+	})
+
+	t.Run("downstream failure", func(t *testing.T) {
+		t.Parallel()
+		run := &remoteRunMock{result: remotepipeline.ResultFailure, artifacts: map[string]string{"results.xml": ""}}
+		utils := &pipelineTriggerRemoteMockUtils{pipeline: &remotePipelineMock{run: run}}
+		config := pipelineTriggerRemoteOptions{Platform: "github", Timeout: 1, Artifacts: []string{"results.xml", "coverage"}, ArtifactsDirectory: "out", PropagateResult: true}
+		cpe := pipelineTriggerRemoteCommonPipelineEnvironment{}
+
+		err := runPipelineTriggerRemote(ctx, &config, utils, &cpe)
+
+		assert.EqualError(t, err, "remote pipeline https://ci.example.com/job/integration-tests/7/ finished with result FAILURE")
+		assert.Equal(t, []string{"out/results.xml"}, run.downloaded)
+		assert.Equal(t, remotepipeline.ResultFailure, cpe.custom.remotePipelineResult)
+	})
+
+	t.Run("downstream failure not propagated", func(t *testing.T) {
+		t.Parallel()
+		run := &remoteRunMock{result: remotepipeline.ResultUnstable}
+		utils := &pipelineTriggerRemoteMockUtils{pipeline: &remotePipelineMock{run: run}}
+		config := pipelineTriggerRemoteOptions{Platform: "azure", Timeout: 1}
+		cpe := pipelineTriggerRemoteCommonPipelineEnvironment{}
+
+		err := runPipelineTriggerRemote(ctx, &config, utils, &cpe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, remotepipeline.ResultUnstable, cpe.custom.remotePipelineResult)
+	})
+
+	t.Run("missing artifact", func(t *testing.T) {
+		t.Parallel()
+		run := &remoteRunMock{result: remotepipeline.ResultSuccess}
+		utils := &pipelineTriggerRemoteMockUtils{pipeline: &remotePipelineMock{run: run}}
+		config := pipelineTriggerRemoteOptions{Platform: "jenkins", Timeout: 1, Artifacts: []string{"results.xml"}, ArtifactsDirectory: "out"}
+		cpe := pipelineTriggerRemoteCommonPipelineEnvironment{}
+
+		err := runPipelineTriggerRemote(ctx, &config, utils, &cpe)
+
+		assert.EqualError(t, err, "failed to fetch artifact 'results.xml' of remote pipeline https://ci.example.com/job/integration-tests/7/: failed to fetch artifact: Artifact 'results.xml' not found")
+	})
+
+	t.Run("trigger error", func(t *testing.T) {
+		t.Parallel()
+		utils := &pipelineTriggerRemoteMockUtils{pipeline: &remotePipelineMock{triggerErr: fmt.Errorf("failed to trigger job 'tests': forbidden")}}
+		config := pipelineTriggerRemoteOptions{Platform: "jenkins"}
+		cpe := pipelineTriggerRemoteCommonPipelineEnvironment{}
+
+		err := runPipelineTriggerRemote(ctx, &config, utils, &cpe)
+
+		assert.EqualError(t, err, "failed to trigger job 'tests': forbidden")
+		assert.Empty(t, cpe.custom.remotePipelineURL)
+	})
+
+	t.Run("unsupported platform", func(t *testing.T) {
+		t.Parallel()
+		utils := &pipelineTriggerRemoteMockUtils{}
+		config := pipelineTriggerRemoteOptions{Platform: "gitlab"}
+
+		err := runPipelineTriggerRemote(ctx, &config, utils, &pipelineTriggerRemoteCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "platform 'gitlab' is not supported")
+	})
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/config/interpolation"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/orchestrator"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
@@ -48,6 +50,7 @@ type GeneralConfigOptions struct {
 	GCSFolderPath        string
 	GCSBucketId          string
 	GCSSubFolder         string
+	StepCacheLocation    string
 }
 
 // HookConfiguration contains the configuration for supported hooks, so far Sentry and Splunk are supported.
@@ -58,6 +61,7 @@ type HookConfiguration struct {
 	PendoConfig       PendoConfiguration       `json:"pendo,omitempty"`
 	OIDCConfig        OIDCConfiguration        `json:"oidc,omitempty"`
 	TrustEngineConfig TrustEngineConfiguration `json:"trustengine,omitempty"`
+	MetricsConfig     MetricsConfiguration     `json:"metrics,omitempty"`
 }
 
 type GCPPubSubConfiguration struct {
@@ -93,6 +97,14 @@ type OIDCConfiguration struct {
 	RoleID string `json:",roleID,omitempty"`
 }
 
+// MetricsConfiguration defines the targets the metrics of each step are exported to
+type MetricsConfiguration struct {
+	OpenMetricsDirectory string            `json:"openMetricsDirectory,omitempty"`
+	PushgatewayURL       string            `json:"pushgatewayUrl,omitempty"`
+	PushgatewayJob       string            `json:"pushgatewayJob,omitempty"`
+	Labels               map[string]string `json:"labels,omitempty"`
+}
+
 type TrustEngineConfiguration struct {
 	ServerURL           string `json:"baseURL,omitempty"`
 	TokenEndPoint       string `json:"tokenEndPoint,omitempty"`
@@ -128,6 +140,14 @@ func Execute() {
 	rootCmd.AddCommand(UiVeri5ExecuteTestsCommand())
 	rootCmd.AddCommand(SonarExecuteScanCommand())
 	rootCmd.AddCommand(KubernetesDeployCommand())
+	rootCmd.AddCommand(LicensePolicyCheckCommand())
+	rootCmd.AddCommand(TestResultsAggregateCommand())
+	rootCmd.AddCommand(ContainerCheckBaseImageCommand())
+	rootCmd.AddCommand(ContainerVerifySignatureCommand())
+	rootCmd.AddCommand(ContainerExecuteScanCommand())
+	rootCmd.AddCommand(PipelineTriggerRemoteCommand())
+	rootCmd.AddCommand(SecretExecuteScanCommand())
+	rootCmd.AddCommand(ManifestPolicyCheckCommand())
 	rootCmd.AddCommand(HelmExecuteCommand())
 	rootCmd.AddCommand(XsDeployCommand())
 	rootCmd.AddCommand(GithubCheckBranchProtectionCommand())
@@ -151,6 +171,7 @@ func Execute() {
 	rootCmd.AddCommand(CredentialdiggerScanCommand())
 	rootCmd.AddCommand(MtaBuildCommand())
 	rootCmd.AddCommand(ProtecodeExecuteScanCommand())
+	rootCmd.AddCommand(OsvExecuteScanCommand())
 	rootCmd.AddCommand(MavenExecuteCommand())
 	rootCmd.AddCommand(CloudFoundryCreateServiceKeyCommand())
 	rootCmd.AddCommand(MavenBuildCommand())
@@ -199,6 +220,7 @@ func Execute() {
 	rootCmd.AddCommand(IntegrationArtifactDownloadCommand())
 	rootCmd.AddCommand(AbapEnvironmentAssembleConfirmCommand())
 	rootCmd.AddCommand(IntegrationArtifactUploadCommand())
+	rootCmd.AddCommand(IntegrationPackageSyncCommand())
 	rootCmd.AddCommand(IntegrationArtifactTriggerIntegrationTestCommand())
 	rootCmd.AddCommand(IntegrationArtifactUnDeployCommand())
 	rootCmd.AddCommand(IntegrationArtifactResourceCommand())
@@ -230,6 +252,7 @@ func Execute() {
 	rootCmd.AddCommand(ApiProviderListCommand())
 	rootCmd.AddCommand(TmsUploadCommand())
 	rootCmd.AddCommand(TmsExportCommand())
+	rootCmd.AddCommand(TmsPromoteCommand())
 	rootCmd.AddCommand(IntegrationArtifactTransportCommand())
 	rootCmd.AddCommand(AscAppUploadCommand())
 	rootCmd.AddCommand(AbapLandscapePortalUpdateAddOnProductCommand())
@@ -269,6 +292,7 @@ func addRootFlags(rootCmd *cobra.Command) {
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.GCSFolderPath, "gcsFolderPath", "", "GCS folder path. One of the components of GCS target folder")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.GCSBucketId, "gcsBucketId", "", "Bucket name for Google Cloud Storage")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.GCSSubFolder, "gcsSubFolder", "", "Used to logically separate results of the same step result type")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.StepCacheLocation, "stepCacheLocation", "", "Location of the step result cache, a local directory, gs://<bucket>/<folder> or s3://<bucket>/<folder>")
 
 }
 
@@ -301,6 +325,19 @@ func AccessTokensFromEnvJSON(env string) []string {
 	return accessTokens
 }
 
+// getInterpolationContext provides the data which expressions in configuration values can refer to
+func getInterpolationContext() interpolation.Context {
+	ctx := interpolation.Context{StageName: GeneralConfig.StageName}
+	if err := ctx.CPE.LoadFromDisk(path.Join(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")); err != nil {
+		log.Entry().WithError(err).Debug("Common pipeline environment not available for config expressions")
+	}
+	if provider, err := orchestrator.GetOrchestratorConfigProvider(nil); err == nil {
+		ctx.Branch = provider.Branch()
+		ctx.Orchestrator = provider.OrchestratorType()
+	}
+	return ctx
+}
+
 // initStageName initializes GeneralConfig.StageName from either GeneralConfig.ParametersJSON
 // or the environment variable (orchestrator specific), unless it has been provided as command line option.
 // Log output needs to be suppressed via outputToLog by the getConfig step.
@@ -389,6 +426,8 @@ func PrepareConfig(cmd *cobra.Command, metadata *config.StepData, stepName strin
 	GeneralConfig.TrustEngineToken = os.Getenv("PIPER_trustEngineToken")
 	myConfig.SetTrustEngineToken(GeneralConfig.TrustEngineToken)
 
+	myConfig.SetInterpolationContextProvider(getInterpolationContext)
+
 	if len(GeneralConfig.StepConfigJSON) != 0 {
 		// ignore config & defaults in favor of passed stepConfigJSON
 		stepConfig = config.GetStepConfigWithJSON(flagValues, GeneralConfig.StepConfigJSON, filters)
@@ -465,6 +504,9 @@ func PrepareConfig(cmd *cobra.Command, metadata *config.StepData, stepName strin
 	if GeneralConfig.GCSSubFolder == "" {
 		GeneralConfig.GCSSubFolder, _ = stepConfig.Config["gcsSubFolder"].(string)
 	}
+	if GeneralConfig.StepCacheLocation == "" {
+		GeneralConfig.StepCacheLocation, _ = stepConfig.Config["stepCacheLocation"].(string)
+	}
 	return nil
 }
 
@@ -100,6 +100,24 @@ func (i *protecodeExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *protecodeExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"protecode": i.step_data.fields.protecode,
+		},
+		"protecode_data": {
+			"excluded_vulnerabilities":   i.protecode_data.fields.excluded_vulnerabilities,
+			"historical_vulnerabilities": i.protecode_data.fields.historical_vulnerabilities,
+			"major_vulnerabilities":      i.protecode_data.fields.major_vulnerabilities,
+			"minor_vulnerabilities":      i.protecode_data.fields.minor_vulnerabilities,
+			"triaged_vulnerabilities":    i.protecode_data.fields.triaged_vulnerabilities,
+			"vulnerabilities":            i.protecode_data.fields.vulnerabilities,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type protecodeExecuteScanReports struct {
 }
 
@@ -221,9 +239,12 @@ BDBA (Protecode) uses a combination of static binary analysis techniques to X-ra
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	piperGit "github.com/SAP/jenkins-library/pkg/git"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/scm"
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+)
+
+// pullRequestScan limits the results of a static code analysis to the lines changed by the pull request the pipeline runs for
+type pullRequestScan struct {
+	number  int
+	changes piperGit.LineChanges
+}
+
+// newPullRequestScan determines the pull request via the orchestrator, it returns nil if the pipeline does not run for a pull request
+func newPullRequestScan(repositoryPath string) (*pullRequestScan, error) {
+	provider, err := orchestrator.GetOrchestratorConfigProvider(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine the orchestrator")
+	}
+	if !provider.IsPullRequest() {
+		log.Entry().Info("Pipeline does not run for a pull request, scanning the full project")
+		return nil, nil
+	}
+	repository, err := piperGit.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open git repository")
+	}
+	return pullRequestScanFor(provider.PullRequestConfig(), repository)
+}
+
+func pullRequestScanFor(config orchestrator.PullRequestConfig, repository *git.Repository) (*pullRequestScan, error) {
+	number, err := strconv.Atoi(config.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pull request number '%v'", config.Key)
+	}
+	// Azure DevOps provides the full reference of the target branch
+	base := strings.TrimPrefix(config.Base, "refs/heads/")
+	var changes piperGit.LineChanges
+	// CI systems usually only fetch the target branch as remote branch
+	for _, ref := range []string{"origin/" + base, base} {
+		if changes, err = piperGit.ChangedLines(repository, ref, "HEAD"); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, errors.Wrapf(err, "failed to determine the lines changed by pull request %v, please make sure that the history of the target branch '%v' is fetched", number, base)
+	}
+	log.Entry().Infof("Pull request %v changes %v files", number, len(changes))
+	return &pullRequestScan{number: number, changes: changes}, nil
+}
+
+// newFindings returns the findings located in lines changed by the pull request, only the first location of a finding in the changed lines is kept.
+// The files of the findings are expected relative to the given directory of the repository.
+func (p *pullRequestScan) newFindings(directory string, findings []reporting.CodeFinding) []reporting.CodeFinding {
+	newFindings := []reporting.CodeFinding{}
+	seen := map[string]bool{}
+	for _, finding := range findings {
+		finding.File = strings.TrimPrefix(path.Join(filepath.ToSlash(directory), filepath.ToSlash(finding.File)), "/")
+		if !seen[finding.ID] && p.changes.Contains(finding.File, finding.Line) {
+			seen[finding.ID] = true
+			newFindings = append(newFindings, finding)
+		}
+	}
+	return newFindings
+}
+
+// comment reports the findings as review comments on the pull request, failures are only logged to not hide the findings
+func (p *pullRequestScan) comment(ctx context.Context, provider scm.Provider, tool string, findings []reporting.CodeFinding) {
+	issues := reporting.SCM{Provider: provider}
+	if err := issues.UploadPullRequestFindings(ctx, p.number, tool, findings); err != nil {
+		log.Entry().WithError(err).Warning("failed to comment findings on the pull request")
+	}
+}
+
+func containsSeverity(severities []string, severity string) bool {
+	for _, s := range severities {
+		if strings.EqualFold(s, severity) {
+			return true
+		}
+	}
+	return false
+}
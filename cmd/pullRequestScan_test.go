@@ -0,0 +1,74 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	piperGit "github.com/SAP/jenkins-library/pkg/git"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestScanFor(t *testing.T) {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	commit := func(name, content string) plumbing.Hash {
+		f, err := fs.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		_, err = worktree.Add(name)
+		require.NoError(t, err)
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "me", Email: "me@example.org"}})
+		require.NoError(t, err)
+		return hash
+	}
+	base := commit("src/main.go", "package main\n")
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Create: true, Branch: plumbing.NewBranchReferenceName("main"), Hash: base}))
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Create: true, Branch: plumbing.NewBranchReferenceName("feature")}))
+	commit("src/main.go", "package main\n\nfunc main() {}\n")
+
+	t.Run("success case", func(t *testing.T) {
+		prScan, err := pullRequestScanFor(orchestrator.PullRequestConfig{Branch: "feature", Base: "refs/heads/main", Key: "42"}, repository)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &pullRequestScan{number: 42, changes: piperGit.LineChanges{"src/main.go": {2, 3}}}, prScan)
+	})
+
+	t.Run("error case - target branch not fetched", func(t *testing.T) {
+		_, err := pullRequestScanFor(orchestrator.PullRequestConfig{Branch: "feature", Base: "develop", Key: "42"}, repository)
+
+		assert.ErrorContains(t, err, "failed to determine the lines changed by pull request 42, please make sure that the history of the target branch 'develop' is fetched")
+	})
+
+	t.Run("error case - invalid number", func(t *testing.T) {
+		_, err := pullRequestScanFor(orchestrator.PullRequestConfig{Base: "main", Key: "n/a"}, repository)
+
+		assert.EqualError(t, err, "invalid pull request number 'n/a'")
+	})
+}
+
+func TestPullRequestScanNewFindings(t *testing.T) {
+	prScan := pullRequestScan{number: 42, changes: piperGit.LineChanges{"module/src/db.go": {10, 11}}}
+	findings := []reporting.CodeFinding{
+		{ID: "1", File: "/src/db.go", Line: 5},
+		{ID: "1", File: "/src/db.go", Line: 10},
+		{ID: "1", File: "/src/db.go", Line: 11},
+		{ID: "2", File: "src/other.go", Line: 10},
+	}
+
+	assert.Equal(t, []reporting.CodeFinding{{ID: "1", File: "module/src/db.go", Line: 10}}, prScan.newFindings("./module", findings))
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+
+	pipergit "github.com/SAP/jenkins-library/pkg/git"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/secretscan"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type secretExecuteScanUtils interface {
+	piperutils.FileUtils
+}
+
+type secretExecuteScanUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newSecretExecuteScanUtils() secretExecuteScanUtils {
+	return &secretExecuteScanUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+}
+
+func secretExecuteScan(config secretExecuteScanOptions, telemetryData *telemetry.CustomData, influx *secretExecuteScanInflux) {
+	utils := newSecretExecuteScanUtils()
+
+	influx.step_data.fields.secret_scan = false
+	if err := runSecretExecuteScan(&config, utils, influx); err != nil {
+		log.Entry().WithError(err).Fatal("Secret scan failed")
+	}
+	influx.step_data.fields.secret_scan = true
+}
+
+func runSecretExecuteScan(config *secretExecuteScanOptions, utils secretExecuteScanUtils, influx *secretExecuteScanInflux) error {
+	allowlist, err := secretscan.ReadAllowlist(config.AllowlistFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	scanner := secretscan.NewScanner(config.Excludes)
+	findings := []secretscan.Finding{}
+	summary := secretscan.Summary{}
+
+	if config.ScanWorkingTree {
+		log.Entry().Info("Scanning the working tree for secrets")
+		treeFindings, files, err := scanner.ScanDirectory(".")
+		if err != nil {
+			return err
+		}
+		summary.Directory = "."
+		summary.Files = files
+		findings = append(findings, treeFindings...)
+	}
+
+	if len(config.HistoryFrom) > 0 {
+		log.Entry().Infof("Scanning the git history from '%v' to '%v' for secrets", config.HistoryFrom, config.HistoryTo)
+		repo, err := pipergit.PlainOpen(".")
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrap(err, "failed to open git repository")
+		}
+		historyFindings, commits, err := scanner.ScanHistory(repo, config.HistoryFrom, config.HistoryTo)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrap(err, "failed to scan git history")
+		}
+		summary.HistoryRange = fmt.Sprintf("%v..%v", config.HistoryFrom, config.HistoryTo)
+		summary.Commits = commits
+		findings = append(findings, historyFindings...)
+	}
+
+	// a secret contained in the working tree is usually also contained in the history, report it only once
+	findings = allowlist.Apply(secretscan.Deduplicate(findings))
+	unsuppressed := secretscan.CountUnsuppressed(findings)
+
+	influx.secret_scan_data.fields.files = summary.Files
+	influx.secret_scan_data.fields.commits = summary.Commits
+	influx.secret_scan_data.fields.secrets = unsuppressed
+	influx.secret_scan_data.fields.suppressed_secrets = len(findings) - unsuppressed
+
+	reports := writeSecretScanReports(summary, scanner.Rules, findings, utils)
+	piperutils.PersistReportsAndLinks("secretExecuteScan", "", utils, reports, nil)
+
+	for _, finding := range findings {
+		if finding.Suppressed {
+			continue
+		}
+		location := fmt.Sprintf("%v:%v", finding.File, finding.Line)
+		if len(finding.Commit) > 0 {
+			location = fmt.Sprintf("%v in commit %v", location, finding.Commit)
+		}
+		log.Entry().Warnf("%v %v found at %v (fingerprint %v)", finding.Description, finding.Secret, location, finding.Fingerprint)
+	}
+	if unsuppressed > 0 && config.FailOnSecrets {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v secrets found, revoke them or add their fingerprints to the allowlist '%v'", unsuppressed, config.AllowlistFile)
+	}
+	log.Entry().Infof("%v secrets found, %v of them suppressed", len(findings), len(findings)-unsuppressed)
+	return nil
+}
+
+func writeSecretScanReports(summary secretscan.Summary, rules []secretscan.Rule, findings []secretscan.Finding, utils secretExecuteScanUtils) []piperutils.Path {
+	reports := []piperutils.Path{}
+
+	paths, err := secretscan.WriteCustomReports(secretscan.CreateCustomReport(summary, findings), utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create custom HTML report")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	paths, err = secretscan.WriteSarifFile(secretscan.CreateSarifResultFile(rules, findings), utils)
+	if err != nil {
+		log.Entry().WithError(err).Warning("failed to create SARIF file")
+	} else {
+		reports = append(reports, paths...)
+	}
+
+	return reports
+}
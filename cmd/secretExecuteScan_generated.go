@@ -0,0 +1,380 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/cobra"
+)
+
+type secretExecuteScanOptions struct {
+	ScanWorkingTree bool     `json:"scanWorkingTree,omitempty"`
+	HistoryFrom     string   `json:"historyFrom,omitempty"`
+	HistoryTo       string   `json:"historyTo,omitempty"`
+	Excludes        []string `json:"excludes,omitempty"`
+	AllowlistFile   string   `json:"allowlistFile,omitempty"`
+	FailOnSecrets   bool     `json:"failOnSecrets,omitempty"`
+}
+
+type secretExecuteScanInflux struct {
+	step_data struct {
+		fields struct {
+			secret_scan bool
+		}
+		tags struct {
+		}
+	}
+	secret_scan_data struct {
+		fields struct {
+			files              int
+			commits            int
+			secrets            int
+			suppressed_secrets int
+		}
+		tags struct {
+		}
+	}
+}
+
+func (i *secretExecuteScanInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "step_data", name: "secret_scan", value: i.step_data.fields.secret_scan},
+		{valType: config.InfluxField, measurement: "secret_scan_data", name: "files", value: i.secret_scan_data.fields.files},
+		{valType: config.InfluxField, measurement: "secret_scan_data", name: "commits", value: i.secret_scan_data.fields.commits},
+		{valType: config.InfluxField, measurement: "secret_scan_data", name: "secrets", value: i.secret_scan_data.fields.secrets},
+		{valType: config.InfluxField, measurement: "secret_scan_data", name: "suppressed_secrets", value: i.secret_scan_data.fields.suppressed_secrets},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Influx environment")
+	}
+}
+
+func (i *secretExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"secret_scan": i.step_data.fields.secret_scan,
+		},
+		"secret_scan_data": {
+			"files":              i.secret_scan_data.fields.files,
+			"commits":            i.secret_scan_data.fields.commits,
+			"secrets":            i.secret_scan_data.fields.secrets,
+			"suppressed_secrets": i.secret_scan_data.fields.suppressed_secrets,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
+type secretExecuteScanReports struct {
+}
+
+func (p *secretExecuteScanReports) persist(stepConfig secretExecuteScanOptions, gcpJsonKeyFilePath string, gcsBucketId string, gcsFolderPath string, gcsSubFolder string) {
+	if gcsBucketId == "" {
+		log.Entry().Info("persisting reports to GCS is disabled, because gcsBucketId is empty")
+		return
+	}
+	log.Entry().Info("Uploading reports to Google Cloud Storage...")
+	content := []gcs.ReportOutputParam{
+		{FilePattern: "**/piper_secret_scan_report.html", ParamRef: "", StepResultType: "secret-scan"},
+		{FilePattern: "**/piper_secret_scan.sarif", ParamRef: "", StepResultType: "secret-scan"},
+	}
+	envVars := []gcs.EnvVar{
+		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+	}
+	gcsClient, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+	if err != nil {
+		log.Entry().Errorf("creation of GCS client failed: %v", err)
+		return
+	}
+	defer gcsClient.Close()
+	structVal := reflect.ValueOf(&stepConfig).Elem()
+	inputParameters := map[string]string{}
+	for i := 0; i < structVal.NumField(); i++ {
+		field := structVal.Type().Field(i)
+		if field.Type.String() == "string" {
+			paramName := strings.Split(field.Tag.Get("json"), ",")
+			paramValue, _ := structVal.Field(i).Interface().(string)
+			inputParameters[paramName[0]] = paramValue
+		}
+	}
+	if err := gcs.PersistReportsToGCS(gcsClient, content, inputParameters, gcsFolderPath, gcsBucketId, gcsSubFolder, doublestar.Glob, os.Stat); err != nil {
+		log.Entry().Errorf("failed to persist reports: %v", err)
+	}
+}
+
+// SecretExecuteScanCommand Scans the working tree and the git history for hard-coded secrets like cloud keys, tokens and private keys.
+func SecretExecuteScanCommand() *cobra.Command {
+	const STEP_NAME = "secretExecuteScan"
+
+	metadata := secretExecuteScanMetadata()
+	var stepConfig secretExecuteScanOptions
+	var startTime time.Time
+	var influx secretExecuteScanInflux
+	var reports secretExecuteScanReports
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createSecretExecuteScanCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Scans the working tree and the git history for hard-coded secrets like cloud keys, tokens and private keys.",
+		Long: `This step detects hard-coded secrets with a built-in rule set. It does not require an external tool, a database or a container and is fast enough to run in every pull request.
+
+The rules cover
+
+* cloud keys: AWS access keys, Google Cloud API keys and Azure storage account keys
+* tokens: GitHub, GitLab, Slack, npm, Stripe and JSON Web Tokens
+* private keys in PEM and OpenSSH format
+* passwords in URLs and generic assignments to variables named like secrets (e.g. ` + "`" + `password` + "`" + `, ` + "`" + `token` + "`" + `, ` + "`" + `apiKey` + "`" + `)
+
+Passwords and generic secrets are only reported if their value has a high entropy, which filters out placeholders and example values.
+
+The step scans the files of the working tree and, if [historyFrom](#historyfrom) is set, the lines added by the commits between [historyFrom](#historyfrom) and [historyTo](#historyto).
+This way secrets are also detected if they have been removed again by a later commit of a pull request.
+
+Each finding has a fingerprint which depends on the rule, the file and the secret, but not on the line or the commit.
+Accepted findings, e.g. test fixtures or revoked secrets remaining in the history, are suppressed by adding their fingerprint to the [allowlistFile](#allowlistfile):
+
+` + "`" + `` + "`" + `` + "`" + `yaml
+suppressions:
+  - fingerprint: 3f1c9d0e4b7a2c65d8e0f1a2b3c4d5e6
+    justification: revoked token, still contained in the history
+` + "`" + `` + "`" + `` + "`" + `
+
+The step creates an HTML report and a SARIF file, secrets are redacted in both. It fails if secrets which are not suppressed are found.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
+				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			secretExecuteScan(stepConfig, &stepTelemetryData, &influx)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addSecretExecuteScanFlags(createSecretExecuteScanCmd, &stepConfig)
+	return createSecretExecuteScanCmd
+}
+
+func addSecretExecuteScanFlags(cmd *cobra.Command, stepConfig *secretExecuteScanOptions) {
+	cmd.Flags().BoolVar(&stepConfig.ScanWorkingTree, "scanWorkingTree", true, "Whether to scan the files of the working tree.")
+	cmd.Flags().StringVar(&stepConfig.HistoryFrom, "historyFrom", os.Getenv("PIPER_historyFrom"), "Git reference (e.g. `origin/main` or the commit of the last successful build) from which on the git history is scanned. Only the lines added by commits reachable from [historyTo](#historyto) but not from this reference are scanned. The history is not scanned if the reference is not provided.")
+	cmd.Flags().StringVar(&stepConfig.HistoryTo, "historyTo", `HEAD`, "Git reference up to which the git history is scanned.")
+	cmd.Flags().StringSliceVar(&stepConfig.Excludes, "excludes", []string{}, "List of glob patterns of files which are not scanned, relative to the root of the repository, e.g. `node_modules/**`.")
+	cmd.Flags().StringVar(&stepConfig.AllowlistFile, "allowlistFile", `.secretscan-allowlist.yml`, "Path to the YAML file containing the fingerprints of suppressed findings. The file is optional.")
+	cmd.Flags().BoolVar(&stepConfig.FailOnSecrets, "failOnSecrets", true, "Whether the step fails if secrets are found which are not suppressed.")
+
+}
+
+// retrieve step metadata
+func secretExecuteScanMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "secretExecuteScan",
+			Aliases:     []config.Alias{},
+			Description: "Scans the working tree and the git history for hard-coded secrets like cloud keys, tokens and private keys.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Parameters: []config.StepParameters{
+					{
+						Name:        "scanWorkingTree",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+					{
+						Name:        "historyFrom",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_historyFrom"),
+					},
+					{
+						Name:        "historyTo",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `HEAD`,
+					},
+					{
+						Name:        "excludes",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "allowlistFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.secretscan-allowlist.yml`,
+					},
+					{
+						Name:        "failOnSecrets",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"name": "step_data", "fields": []map[string]string{{"name": "secret_scan"}}},
+							{"name": "secret_scan_data", "fields": []map[string]string{{"name": "files"}, {"name": "commits"}, {"name": "secrets"}, {"name": "suppressed_secrets"}}},
+						},
+					},
+					{
+						Name: "reports",
+						Type: "reports",
+						Parameters: []map[string]interface{}{
+							{"filePattern": "**/piper_secret_scan_report.html", "type": "secret-scan"},
+							{"filePattern": "**/piper_secret_scan.sarif", "type": "secret-scan"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
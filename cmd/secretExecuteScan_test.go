@@ -0,0 +1,122 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/secretscan"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assembled at runtime so that the sources are not flagged themselves
+var secretScanTestKey = "AKIA" + "QYLPMN5HHHFPZAM2"
+
+func newSecretExecuteScanTestsUtils(t *testing.T, files map[string]string) secretExecuteScanUtils {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
+	}
+	return &secretExecuteScanUtilsBundle{Files: &piperutils.Files{}}
+}
+
+func TestRunSecretExecuteScan(t *testing.T) {
+	t.Run("success case - no secrets", func(t *testing.T) {
+		utils := newSecretExecuteScanTestsUtils(t, map[string]string{"src/main.go": "package main\n"})
+		config := secretExecuteScanOptions{ScanWorkingTree: true, FailOnSecrets: true, AllowlistFile: ".secretscan-allowlist.yml"}
+		influx := secretExecuteScanInflux{}
+
+		err := runSecretExecuteScan(&config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, influx.secret_scan_data.fields.files)
+		assert.Equal(t, 0, influx.secret_scan_data.fields.secrets)
+		assert.FileExists(t, filepath.Join("secretscan", "piper_secret_scan_report.html"))
+		assert.FileExists(t, filepath.Join("secretscan", "piper_secret_scan.sarif"))
+	})
+
+	t.Run("error case - secret found", func(t *testing.T) {
+		utils := newSecretExecuteScanTestsUtils(t, map[string]string{"deploy.properties": "aws.key=" + secretScanTestKey + "\n"})
+		config := secretExecuteScanOptions{ScanWorkingTree: true, FailOnSecrets: true, AllowlistFile: ".secretscan-allowlist.yml"}
+		influx := secretExecuteScanInflux{}
+
+		err := runSecretExecuteScan(&config, utils, &influx)
+
+		assert.EqualError(t, err, "1 secrets found, revoke them or add their fingerprints to the allowlist '.secretscan-allowlist.yml'")
+		assert.Equal(t, 1, influx.secret_scan_data.fields.secrets)
+	})
+
+	t.Run("success case - secret suppressed", func(t *testing.T) {
+		utils := newSecretExecuteScanTestsUtils(t, map[string]string{
+			"deploy.properties": "aws.key=" + secretScanTestKey + "\n",
+			// fingerprint of the key in deploy.properties
+			".secretscan-allowlist.yml": "suppressions:\n  - fingerprint: " + secretScanFingerprint(t, "deploy.properties", "aws.key="+secretScanTestKey) + "\n",
+		})
+		config := secretExecuteScanOptions{ScanWorkingTree: true, FailOnSecrets: true, AllowlistFile: ".secretscan-allowlist.yml"}
+		influx := secretExecuteScanInflux{}
+
+		err := runSecretExecuteScan(&config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, influx.secret_scan_data.fields.secrets)
+		assert.Equal(t, 1, influx.secret_scan_data.fields.suppressed_secrets)
+	})
+
+	t.Run("success case - secret in history not failing", func(t *testing.T) {
+		newSecretExecuteScanTestsUtils(t, nil)
+		repo, err := git.PlainInit(".", false)
+		require.NoError(t, err)
+		worktree, err := repo.Worktree()
+		require.NoError(t, err)
+		commit := func(content string) {
+			require.NoError(t, os.WriteFile("deploy.properties", []byte(content), 0644))
+			_, err := worktree.Add("deploy.properties")
+			require.NoError(t, err)
+			_, err = worktree.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "me", Email: "me@example.org"}})
+			require.NoError(t, err)
+		}
+		commit("aws.key=\n")
+		head, err := repo.Head()
+		require.NoError(t, err)
+		commit("aws.key=" + secretScanTestKey + "\n")
+		commit("aws.key=${AWS_KEY}\n")
+		config := secretExecuteScanOptions{ScanWorkingTree: true, HistoryFrom: head.Hash().String(), HistoryTo: "HEAD", AllowlistFile: ".secretscan-allowlist.yml"}
+		influx := secretExecuteScanInflux{}
+
+		err = runSecretExecuteScan(&config, &secretExecuteScanUtilsBundle{Files: &piperutils.Files{}}, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, influx.secret_scan_data.fields.commits)
+		assert.Equal(t, 1, influx.secret_scan_data.fields.secrets)
+	})
+
+	t.Run("error case - unknown history reference", func(t *testing.T) {
+		utils := newSecretExecuteScanTestsUtils(t, nil)
+		_, err := git.PlainInit(".", false)
+		require.NoError(t, err)
+		config := secretExecuteScanOptions{HistoryFrom: "origin/main", HistoryTo: "HEAD"}
+
+		err = runSecretExecuteScan(&config, utils, &secretExecuteScanInflux{})
+
+		assert.ErrorContains(t, err, "failed to scan git history")
+	})
+}
+
+func secretScanFingerprint(t *testing.T, file, line string) string {
+	findings := secretscan.NewScanner(nil).ScanLine(file, 1, line)
+	require.Len(t, findings, 1)
+	return findings[0].Fingerprint
+}
@@ -140,6 +140,23 @@ func (i *sonarExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *sonarExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"sonar": i.step_data.fields.sonar,
+		},
+		"sonarqube_data": {
+			"blocker_issues":  i.sonarqube_data.fields.blocker_issues,
+			"critical_issues": i.sonarqube_data.fields.critical_issues,
+			"major_issues":    i.sonarqube_data.fields.major_issues,
+			"minor_issues":    i.sonarqube_data.fields.minor_issues,
+			"info_issues":     i.sonarqube_data.fields.info_issues,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 // SonarExecuteScanCommand Executes the Sonar scanner
 func SonarExecuteScanCommand() *cobra.Command {
 	const STEP_NAME = "sonarExecuteScan"
@@ -214,9 +231,12 @@ func SonarExecuteScanCommand() *cobra.Command {
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/signing"
+	"github.com/SAP/jenkins-library/pkg/stepcache"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+)
+
+// StepCache skips a step run if none of its inputs changed since a recorded run and restores the recorded outputs instead.
+// Caching is only active for steps with a cache definition in their metadata and if stepCacheLocation is configured.
+type StepCache struct {
+	stepName  string
+	metadata  *config.StepData
+	workspace string
+	envRoot   string
+	cache     *stepcache.Cache
+	hit       bool
+}
+
+// NewStepCache prepares the cache for a step run and looks up a recorded run with the same inputs
+func NewStepCache(stepName string, metadata *config.StepData, stepConfig interface{}) *StepCache {
+	if len(GeneralConfig.StepCacheLocation) == 0 {
+		return &StepCache{}
+	}
+	backend, err := stepcache.NewBackend(GeneralConfig.StepCacheLocation, GeneralConfig.GCPJsonKeyFilePath)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Step cache disabled")
+		return &StepCache{}
+	}
+	if metadata.Spec.Cache == nil {
+		return &StepCache{}
+	}
+	dockerImage, err := GetDockerImageValue(stepName)
+	if err != nil {
+		log.Entry().WithError(err).Debug("Failed to read the configured docker image of the step")
+	}
+	tools, err := toolVersion(metadata, dockerImage, resolveImageDigest)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Step cache disabled")
+		return &StepCache{}
+	}
+	return newStepCache(stepName, metadata, stepConfig, tools, backend, ".", GeneralConfig.EnvRootPath)
+}
+
+func newStepCache(stepName string, metadata *config.StepData, stepConfig interface{}, tools string, backend stepcache.Backend, workspace, envRoot string) *StepCache {
+	s := &StepCache{stepName: stepName, metadata: metadata, workspace: workspace, envRoot: envRoot}
+	if metadata.Spec.Cache == nil {
+		return s
+	}
+	key, err := stepcache.Fingerprint(workspace, stepcache.Inputs{
+		StepName:        stepName,
+		Config:          cacheRelevantConfig(metadata, stepConfig),
+		FilePatterns:    metadata.Spec.Cache.Inputs,
+		ExcludePatterns: metadata.Spec.Cache.Excludes,
+		ToolVersion:     tools,
+	})
+	if err != nil {
+		log.Entry().WithError(err).Warn("Step cache disabled, failed to compute fingerprint of step inputs")
+		return s
+	}
+	s.cache = stepcache.New(backend, key)
+	s.hit, err = s.cache.Lookup()
+	if err != nil {
+		log.Entry().WithError(err).Warn("Step cache lookup failed")
+	}
+	if s.hit {
+		log.Entry().Infof("Inputs of step %s did not change since a recorded run (fingerprint %s), skipping execution", stepName, key)
+	} else {
+		log.Entry().Debugf("No recorded run of step %s found for fingerprint %s", stepName, key)
+	}
+	return s
+}
+
+// Hit tells whether a recorded run exists for the inputs of the current step run
+func (s *StepCache) Hit() bool {
+	return s.hit
+}
+
+// Finish restores the recorded outputs for a cache hit or records the outputs of a successful step run
+func (s *StepCache) Finish(telemetryData *telemetry.CustomData) {
+	if s.cache == nil {
+		return
+	}
+	if s.hit {
+		if err := s.cache.Restore(s.workspace); err != nil {
+			log.Entry().WithError(err).Warn("Failed to restore outputs of recorded step run")
+			return
+		}
+		log.Entry().Infof("Outputs of step %s restored from cache", s.stepName)
+		return
+	}
+	if telemetryData.ErrorCode != "0" {
+		return
+	}
+	if err := s.cache.Store(s.workspace, s.outputPatterns(), s.metadata.Spec.Cache.Excludes); err != nil {
+		log.Entry().WithError(err).Warn("Failed to record step outputs in cache")
+	}
+}
+
+// outputPatterns collects all files a step run produced: its output resources, reports and links as well as additional cache outputs
+func (s *StepCache) outputPatterns() []string {
+	patterns := append([]string{}, s.metadata.Spec.Cache.Outputs...)
+	for _, resource := range s.metadata.Spec.Outputs.Resources {
+		for _, param := range resource.Parameters {
+			switch resource.Type {
+			case "piperEnvironment":
+				if name, ok := param["name"].(string); ok {
+					patterns = append(patterns, path.Join(s.envRoot, resource.Name, name), path.Join(s.envRoot, resource.Name, name+".json"))
+				}
+			case "influx":
+				if name, ok := param["name"].(string); ok {
+					patterns = append(patterns, path.Join(s.envRoot, resource.Name, name, "**"))
+				}
+			case "reports":
+				if filePattern, ok := param["filePattern"].(string); ok {
+					patterns = append(patterns, filePattern)
+				}
+			}
+		}
+	}
+
+	for _, stepResults := range []string{fmt.Sprintf("%v_reports.json", s.stepName), fmt.Sprintf("%v_links.json", s.stepName)} {
+		patterns = append(patterns, stepResults)
+		content, err := os.ReadFile(filepath.Join(s.workspace, stepResults))
+		if err != nil {
+			continue
+		}
+		var paths []piperutils.Path
+		if err := json.Unmarshal(content, &paths); err != nil {
+			log.Entry().WithError(err).Debugf("Failed to read %v", stepResults)
+			continue
+		}
+		for _, p := range paths {
+			if len(p.Target) > 0 && !strings.Contains(p.Target, "://") {
+				patterns = append(patterns, filepath.ToSlash(filepath.Clean(p.Target)))
+			}
+		}
+	}
+	return patterns
+}
+
+// cacheRelevantConfig provides the resolved step configuration without secrets, so that rotating credentials keeps the cache valid
+func cacheRelevantConfig(metadata *config.StepData, stepConfig interface{}) map[string]interface{} {
+	relevant := map[string]interface{}{}
+	content, err := json.Marshal(stepConfig)
+	if err != nil {
+		return relevant
+	}
+	_ = json.Unmarshal(content, &relevant)
+	for _, param := range metadata.Spec.Inputs.Parameters {
+		if param.Secret {
+			delete(relevant, param.Name)
+		}
+	}
+	return relevant
+}
+
+// toolVersion identifies the tools a step runs with by the digests of its effective container images and the piper version.
+// The configured dockerImage takes precedence over the images of the step metadata. Tags are resolved to digests,
+// so that a moving tag like maven:3 changes the fingerprint once a new image is published.
+func toolVersion(metadata *config.StepData, dockerImage string, resolveDigest func(string) (string, error)) (string, error) {
+	images := []string{}
+	if len(dockerImage) > 0 {
+		images = append(images, dockerImage)
+	} else {
+		for _, container := range metadata.Spec.Containers {
+			images = append(images, container.Image)
+		}
+	}
+	for i, image := range images {
+		if len(image) == 0 {
+			continue
+		}
+		digest, err := resolveDigest(image)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to identify the step image '%s'", image)
+		}
+		images[i] = digest
+	}
+	return fmt.Sprintf("%s@%s", strings.Join(images, ","), GitCommit), nil
+}
+
+func resolveImageDigest(image string) (string, error) {
+	digest, err := signing.ResolveDigest(image, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
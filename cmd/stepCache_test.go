@@ -0,0 +1,154 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/stepcache"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stepCacheTestOptions struct {
+	DockerFile string `json:"dockerFile,omitempty"`
+	Password   string `json:"password,omitempty"`
+}
+
+func TestStepCache(t *testing.T) {
+	t.Parallel()
+
+	metadata := config.StepData{
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Parameters: []config.StepParameters{
+					{Name: "dockerFile"},
+					{Name: "password", Secret: true},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{Name: "commonPipelineEnvironment", Type: "piperEnvironment", Parameters: []map[string]interface{}{{"name": "custom/lintResult"}}},
+					{Name: "reports", Type: "reports", Parameters: []map[string]interface{}{{"filePattern": "**/lint.xml", "type": "lint"}}},
+				},
+			},
+			Containers: []config.Container{{Name: "lint", Image: "lint:1.0"}},
+			Cache:      &config.StepCache{Inputs: []string{"Dockerfile"}, Outputs: []string{"extra.txt"}},
+		},
+	}
+	backend := &stepcache.DirectoryBackend{Path: filepath.Join(t.TempDir(), "cache")}
+
+	writeFile := func(t *testing.T, dir, name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	// first run records the outputs
+	workspace := t.TempDir()
+	writeFile(t, workspace, "Dockerfile", "FROM scratch")
+	s := newStepCache("lintStep", &metadata, stepCacheTestOptions{DockerFile: "Dockerfile", Password: "secret1"}, "lint@sha256:1", backend, workspace, ".pipeline")
+	assert.False(t, s.Hit())
+	writeFile(t, workspace, "reports/lint.xml", "<lint/>")
+	writeFile(t, workspace, "extra.txt", "extra")
+	writeFile(t, workspace, "linked.html", "<html/>")
+	writeFile(t, workspace, "lintStep_reports.json", `[{"target":"linked.html"}]`)
+	writeFile(t, workspace, ".pipeline/commonPipelineEnvironment/custom/lintResult", "clean")
+	s.Finish(&telemetry.CustomData{ErrorCode: "0"})
+
+	t.Run("hit restores outputs", func(t *testing.T) {
+		restoreWorkspace := t.TempDir()
+		writeFile(t, restoreWorkspace, "Dockerfile", "FROM scratch")
+		// secrets are not considered for the fingerprint
+		s := newStepCache("lintStep", &metadata, stepCacheTestOptions{DockerFile: "Dockerfile", Password: "secret2"}, "lint@sha256:1", backend, restoreWorkspace, ".pipeline")
+		assert.True(t, s.Hit())
+		s.Finish(&telemetry.CustomData{ErrorCode: "0"})
+
+		for name, content := range map[string]string{
+			"reports/lint.xml":      "<lint/>",
+			"extra.txt":             "extra",
+			"linked.html":           "<html/>",
+			"lintStep_reports.json": `[{"target":"linked.html"}]`,
+			".pipeline/commonPipelineEnvironment/custom/lintResult": "clean",
+		} {
+			restored, err := os.ReadFile(filepath.Join(restoreWorkspace, name))
+			assert.NoError(t, err, name)
+			assert.Equal(t, content, string(restored), name)
+		}
+	})
+
+	t.Run("miss on changed inputs", func(t *testing.T) {
+		otherWorkspace := t.TempDir()
+		writeFile(t, otherWorkspace, "Dockerfile", "FROM alpine")
+		s := newStepCache("lintStep", &metadata, stepCacheTestOptions{DockerFile: "Dockerfile"}, "lint@sha256:1", backend, otherWorkspace, ".pipeline")
+		assert.False(t, s.Hit())
+	})
+
+	t.Run("miss on changed image", func(t *testing.T) {
+		otherWorkspace := t.TempDir()
+		writeFile(t, otherWorkspace, "Dockerfile", "FROM scratch")
+		s := newStepCache("lintStep", &metadata, stepCacheTestOptions{DockerFile: "Dockerfile", Password: "secret1"}, "lint@sha256:2", backend, otherWorkspace, ".pipeline")
+		assert.False(t, s.Hit())
+	})
+
+	t.Run("failed runs are not recorded", func(t *testing.T) {
+		otherWorkspace := t.TempDir()
+		writeFile(t, otherWorkspace, "Dockerfile", "FROM ubuntu")
+		s := newStepCache("lintStep", &metadata, stepCacheTestOptions{DockerFile: "Dockerfile"}, "lint@sha256:1", backend, otherWorkspace, ".pipeline")
+		s.Finish(&telemetry.CustomData{ErrorCode: "1"})
+
+		s = newStepCache("lintStep", &metadata, stepCacheTestOptions{DockerFile: "Dockerfile"}, "lint@sha256:1", backend, otherWorkspace, ".pipeline")
+		assert.False(t, s.Hit())
+	})
+
+	t.Run("disabled without cache definition", func(t *testing.T) {
+		s := newStepCache("lintStep", &config.StepData{}, stepCacheTestOptions{}, "", backend, t.TempDir(), ".pipeline")
+		assert.False(t, s.Hit())
+		s.Finish(&telemetry.CustomData{ErrorCode: "0"})
+	})
+}
+
+func TestToolVersion(t *testing.T) {
+	t.Parallel()
+
+	metadata := &config.StepData{Spec: config.StepSpec{Containers: []config.Container{{Name: "mvn", Image: "maven:3-openjdk-17"}}}}
+	digests := map[string]string{
+		"maven:3-openjdk-17":       "index.docker.io/library/maven@sha256:aaa",
+		"my.registry/maven:custom": "my.registry/maven@sha256:bbb",
+	}
+	resolve := func(image string) (string, error) {
+		if digest, ok := digests[image]; ok {
+			return digest, nil
+		}
+		return "", fmt.Errorf("image not found")
+	}
+
+	t.Run("metadata images", func(t *testing.T) {
+		version, err := toolVersion(metadata, "", resolve)
+		assert.NoError(t, err)
+		assert.Equal(t, "index.docker.io/library/maven@sha256:aaa@"+GitCommit, version)
+	})
+
+	t.Run("configured docker image", func(t *testing.T) {
+		version, err := toolVersion(metadata, "my.registry/maven:custom", resolve)
+		assert.NoError(t, err)
+		assert.Equal(t, "my.registry/maven@sha256:bbb@"+GitCommit, version)
+	})
+
+	t.Run("moving tag", func(t *testing.T) {
+		before, _ := toolVersion(metadata, "", resolve)
+		digests["maven:3-openjdk-17"] = "index.docker.io/library/maven@sha256:ccc"
+		after, _ := toolVersion(metadata, "", resolve)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("unresolvable image", func(t *testing.T) {
+		_, err := toolVersion(metadata, "unknown:latest", resolve)
+		assert.EqualError(t, err, "failed to identify the step image 'unknown:latest': image not found")
+	})
+}
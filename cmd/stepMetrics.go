@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/metrics"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+// ExportStepMetrics exports the influx data of the step together with duration, result and error category
+// to the targets configured in the metrics hook, e.g. an OpenMetrics file or a Prometheus Pushgateway.
+func ExportStepMetrics(stepName string, telemetryData *telemetry.CustomData, fields map[string]map[string]interface{}, tags map[string]map[string]string) {
+	exportStepMetrics(metrics.NewExporters(metrics.Options{
+		OpenMetricsDirectory: GeneralConfig.HookConfig.MetricsConfig.OpenMetricsDirectory,
+		PushgatewayURL:       GeneralConfig.HookConfig.MetricsConfig.PushgatewayURL,
+		PushgatewayJob:       GeneralConfig.HookConfig.MetricsConfig.PushgatewayJob,
+	}), stepName, telemetryData, fields, tags)
+}
+
+func exportStepMetrics(exporters []metrics.Exporter, stepName string, telemetryData *telemetry.CustomData, fields map[string]map[string]interface{}, tags map[string]map[string]string) {
+	if len(exporters) == 0 {
+		return
+	}
+	duration, _ := strconv.ParseInt(telemetryData.Duration, 10, 64)
+	stepMetrics := metrics.StepMetrics{
+		Step:          stepName,
+		Duration:      time.Duration(duration) * time.Millisecond,
+		Success:       telemetryData.ErrorCode == "0",
+		ErrorCategory: telemetryData.ErrorCategory,
+		Fields:        fields,
+		Tags:          tags,
+		Labels:        GeneralConfig.HookConfig.MetricsConfig.Labels,
+	}
+	for _, exporter := range exporters {
+		// metrics must not break the pipeline
+		if err := exporter.Export(stepMetrics); err != nil {
+			log.Entry().WithError(err).Warn("Failed to export step metrics")
+		}
+	}
+}
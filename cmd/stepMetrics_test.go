@@ -0,0 +1,66 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/metrics"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/stretchr/testify/assert"
+)
+
+type metricsExporterMock struct {
+	exported []metrics.StepMetrics
+	err      error
+}
+
+func (m *metricsExporterMock) Export(stepMetrics metrics.StepMetrics) error {
+	m.exported = append(m.exported, stepMetrics)
+	return m.err
+}
+
+func TestExportStepMetrics(t *testing.T) {
+	defer func() { GeneralConfig.HookConfig.MetricsConfig = MetricsConfiguration{} }()
+	GeneralConfig.HookConfig.MetricsConfig = MetricsConfiguration{Labels: map[string]string{"repository": "my-repo"}}
+	fields := map[string]map[string]interface{}{"step_data": {"mavenBuild": true}}
+	tags := map[string]map[string]string{"step_data": {"build_tool": "maven"}}
+
+	t.Run("successful step", func(t *testing.T) {
+		exporter := &metricsExporterMock{}
+
+		exportStepMetrics([]metrics.Exporter{exporter}, "mavenBuild", &telemetry.CustomData{Duration: "2500", ErrorCode: "0"}, fields, tags)
+
+		assert.Equal(t, []metrics.StepMetrics{{
+			Step:     "mavenBuild",
+			Duration: 2500 * time.Millisecond,
+			Success:  true,
+			Fields:   fields,
+			Tags:     tags,
+			Labels:   map[string]string{"repository": "my-repo"},
+		}}, exporter.exported)
+	})
+
+	t.Run("failed step", func(t *testing.T) {
+		exporter := &metricsExporterMock{}
+
+		exportStepMetrics([]metrics.Exporter{exporter}, "mavenBuild", &telemetry.CustomData{Duration: "100", ErrorCode: "1", ErrorCategory: "build"}, nil, nil)
+
+		if assert.Len(t, exporter.exported, 1) {
+			assert.False(t, exporter.exported[0].Success)
+			assert.Equal(t, "build", exporter.exported[0].ErrorCategory)
+		}
+	})
+
+	t.Run("export errors are ignored", func(t *testing.T) {
+		failing := &metricsExporterMock{err: fmt.Errorf("connection refused")}
+		exporter := &metricsExporterMock{}
+
+		exportStepMetrics([]metrics.Exporter{failing, exporter}, "mavenBuild", &telemetry.CustomData{ErrorCode: "0"}, nil, nil)
+
+		assert.Len(t, exporter.exported, 1)
+	})
+}
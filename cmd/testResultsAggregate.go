@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/testresults"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+type testResultsAggregateUtils interface {
+	piperutils.FileUtils
+}
+
+type testResultsAggregateUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newTestResultsAggregateUtils() testResultsAggregateUtils {
+	utils := testResultsAggregateUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func testResultsAggregate(config testResultsAggregateOptions, telemetryData *telemetry.CustomData, influx *testResultsAggregateInflux) {
+	utils := newTestResultsAggregateUtils()
+
+	influx.step_data.fields.testResultsAggregate = false
+	if err := runTestResultsAggregate(&config, utils, influx); err != nil {
+		log.Entry().WithError(err).Fatal("Test result aggregation failed")
+	}
+	influx.step_data.fields.testResultsAggregate = true
+}
+
+func runTestResultsAggregate(config *testResultsAggregateOptions, utils testResultsAggregateUtils, influx *testResultsAggregateInflux) error {
+	results := testresults.NewResults()
+	results.GoModulePath = goModulePath(utils)
+
+	testResultFiles, err := findAggregationFiles(config.TestResultFiles, config.Excludes, utils)
+	if err != nil {
+		return err
+	}
+	coverageFiles, err := findAggregationFiles(config.CoverageFiles, config.Excludes, utils)
+	if err != nil {
+		return err
+	}
+	if len(testResultFiles) == 0 {
+		log.Entry().Warnf("No test results found in files matching %v", config.TestResultFiles)
+	}
+	if len(coverageFiles) == 0 {
+		log.Entry().Warnf("No coverage reports found in files matching %v", config.CoverageFiles)
+	}
+	for _, file := range append(testResultFiles, coverageFiles...) {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read '%v'", file)
+		}
+		format, err := results.Add(file, content)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Ignoring '%v'", file)
+			continue
+		}
+		log.Entry().Infof("Read %v results from '%v'", format, file)
+	}
+
+	var changedFiles []string
+	if len(config.ChangedFilesReference) > 0 {
+		if changedFiles, err = determineChangedFiles(config.ChangedFilesReference); err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "failed to determine files changed compared to '%v'", config.ChangedFilesReference)
+		}
+		log.Entry().Infof("%v files changed compared to '%v'", len(changedFiles), config.ChangedFilesReference)
+	}
+
+	report := testresults.NewReport(results, changedFiles)
+	report.Gates = evaluateTestResultGates(config, report)
+
+	influx.test_data.fields.tests = report.Tests.Total
+	influx.test_data.fields.passed = report.Tests.Passed
+	influx.test_data.fields.failed = report.Tests.Failed + report.Tests.Errors
+	influx.test_data.fields.skipped = report.Tests.Skipped
+	influx.test_data.fields.passRate = report.PassRate
+	influx.test_data.fields.lineCoverage = report.LineRate
+	influx.test_data.fields.branchCoverage = report.BranchRate
+	if report.ChangedFilesCoverage != nil {
+		influx.test_data.fields.changedFilesLineCoverage = report.ChangedFilesCoverage.LineRate()
+		influx.test_data.fields.changedFilesBranchCoverage = report.ChangedFilesCoverage.BranchRate()
+	}
+
+	reports, err := testresults.WriteReports(report, utils)
+	if err != nil {
+		// do not fail - consider failing later on
+		log.Entry().WithError(err).Warning("failed to create test result reports")
+	}
+	piperutils.PersistReportsAndLinks("testResultsAggregate", "", utils, reports, nil)
+
+	for _, t := range report.FailedTests {
+		log.Entry().Errorf("%v: %v %v", t.ID(), t.Status, t.Message)
+	}
+	log.Entry().Infof("%v tests: %v passed, %v failed, %v skipped (pass rate %.2f%%), line coverage %.2f%%, branch coverage %.2f%%",
+		report.Tests.Total, report.Tests.Passed, report.Tests.Failed+report.Tests.Errors, report.Tests.Skipped, report.PassRate, report.LineRate, report.BranchRate)
+
+	violations := []string{}
+	for _, gate := range report.Gates {
+		if gate.Passed {
+			log.Entry().Infof("Threshold met: %v", gate)
+			continue
+		}
+		log.Entry().Warnf("Threshold not met: %v", gate)
+		violations = append(violations, gate.String())
+	}
+	if len(violations) > 0 && config.FailOnThresholdViolation {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("thresholds not met: %v", strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+func evaluateTestResultGates(config *testResultsAggregateOptions, report testresults.Report) []testresults.Gate {
+	gates := []testresults.Gate{}
+	if config.MinPassRate > 0 {
+		passRate := report.PassRate
+		if report.Tests.Executed() == 0 {
+			log.Entry().Warn("No executed tests found")
+			passRate = 0
+		}
+		gates = append(gates, testresults.NewGate("pass rate", passRate, config.MinPassRate))
+	}
+	if config.MinLineCoverage > 0 {
+		gates = append(gates, testresults.NewGate("line coverage", report.LineRate, config.MinLineCoverage))
+	}
+	if config.MinBranchCoverage > 0 {
+		if report.Coverage.Branches > 0 {
+			gates = append(gates, testresults.NewGate("branch coverage", report.BranchRate, config.MinBranchCoverage))
+		} else {
+			log.Entry().Info("Coverage reports do not contain branch information, branch coverage is not checked")
+		}
+	}
+
+	if config.MinChangedFilesLineCoverage+config.MinChangedFilesBranchCoverage > 0 && report.ChangedFilesCoverage == nil {
+		log.Entry().Warn("Coverage of changed files is not checked since no changedFilesReference is configured")
+		return gates
+	}
+	if changed := report.ChangedFilesCoverage; changed != nil {
+		if changed.Lines == 0 {
+			log.Entry().Info("No coverage information for changed files found, coverage of changed files is not checked")
+			return gates
+		}
+		if config.MinChangedFilesLineCoverage > 0 {
+			gates = append(gates, testresults.NewGate("changed files line coverage", changed.LineRate(), config.MinChangedFilesLineCoverage))
+		}
+		if config.MinChangedFilesBranchCoverage > 0 && changed.Branches > 0 {
+			gates = append(gates, testresults.NewGate("changed files branch coverage", changed.BranchRate(), config.MinChangedFilesBranchCoverage))
+		}
+	}
+	return gates
+}
+
+func findAggregationFiles(patterns, excludes []string, utils testResultsAggregateUtils) ([]string, error) {
+	files := []string{}
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "invalid pattern '%v'", pattern)
+		}
+		matches, err = piperutils.ExcludeFiles(matches, excludes)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, err
+		}
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+// goModulePath returns the module path of the go.mod in the working directory, which is used to relate Go coverage profiles to the sources
+func goModulePath(utils testResultsAggregateUtils) string {
+	if exists, _ := utils.FileExists("go.mod"); !exists {
+		return ""
+	}
+	content, err := utils.FileRead("go.mod")
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to read go.mod")
+		return ""
+	}
+	return modfile.ModulePath(content)
+}
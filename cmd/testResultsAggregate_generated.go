@@ -0,0 +1,431 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/gcp"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/cobra"
+)
+
+type testResultsAggregateOptions struct {
+	TestResultFiles               []string `json:"testResultFiles,omitempty"`
+	CoverageFiles                 []string `json:"coverageFiles,omitempty"`
+	Excludes                      []string `json:"excludes,omitempty"`
+	MinPassRate                   int      `json:"minPassRate,omitempty"`
+	MinLineCoverage               int      `json:"minLineCoverage,omitempty"`
+	MinBranchCoverage             int      `json:"minBranchCoverage,omitempty"`
+	ChangedFilesReference         string   `json:"changedFilesReference,omitempty"`
+	MinChangedFilesLineCoverage   int      `json:"minChangedFilesLineCoverage,omitempty"`
+	MinChangedFilesBranchCoverage int      `json:"minChangedFilesBranchCoverage,omitempty"`
+	FailOnThresholdViolation      bool     `json:"failOnThresholdViolation,omitempty"`
+}
+
+type testResultsAggregateInflux struct {
+	step_data struct {
+		fields struct {
+			testResultsAggregate bool
+		}
+		tags struct {
+		}
+	}
+	test_data struct {
+		fields struct {
+			tests                      int
+			passed                     int
+			failed                     int
+			skipped                    int
+			passRate                   float64
+			lineCoverage               float64
+			branchCoverage             float64
+			changedFilesLineCoverage   float64
+			changedFilesBranchCoverage float64
+		}
+		tags struct {
+		}
+	}
+}
+
+func (i *testResultsAggregateInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "step_data", name: "testResultsAggregate", value: i.step_data.fields.testResultsAggregate},
+		{valType: config.InfluxField, measurement: "test_data", name: "tests", value: i.test_data.fields.tests},
+		{valType: config.InfluxField, measurement: "test_data", name: "passed", value: i.test_data.fields.passed},
+		{valType: config.InfluxField, measurement: "test_data", name: "failed", value: i.test_data.fields.failed},
+		{valType: config.InfluxField, measurement: "test_data", name: "skipped", value: i.test_data.fields.skipped},
+		{valType: config.InfluxField, measurement: "test_data", name: "passRate", value: i.test_data.fields.passRate},
+		{valType: config.InfluxField, measurement: "test_data", name: "lineCoverage", value: i.test_data.fields.lineCoverage},
+		{valType: config.InfluxField, measurement: "test_data", name: "branchCoverage", value: i.test_data.fields.branchCoverage},
+		{valType: config.InfluxField, measurement: "test_data", name: "changedFilesLineCoverage", value: i.test_data.fields.changedFilesLineCoverage},
+		{valType: config.InfluxField, measurement: "test_data", name: "changedFilesBranchCoverage", value: i.test_data.fields.changedFilesBranchCoverage},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Error("failed to persist Influx environment")
+	}
+}
+
+func (i *testResultsAggregateInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"testResultsAggregate": i.step_data.fields.testResultsAggregate,
+		},
+		"test_data": {
+			"tests":                      i.test_data.fields.tests,
+			"passed":                     i.test_data.fields.passed,
+			"failed":                     i.test_data.fields.failed,
+			"skipped":                    i.test_data.fields.skipped,
+			"passRate":                   i.test_data.fields.passRate,
+			"lineCoverage":               i.test_data.fields.lineCoverage,
+			"branchCoverage":             i.test_data.fields.branchCoverage,
+			"changedFilesLineCoverage":   i.test_data.fields.changedFilesLineCoverage,
+			"changedFilesBranchCoverage": i.test_data.fields.changedFilesBranchCoverage,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
+type testResultsAggregateReports struct {
+}
+
+func (p *testResultsAggregateReports) persist(stepConfig testResultsAggregateOptions, gcpJsonKeyFilePath string, gcsBucketId string, gcsFolderPath string, gcsSubFolder string) {
+	if gcsBucketId == "" {
+		log.Entry().Info("persisting reports to GCS is disabled, because gcsBucketId is empty")
+		return
+	}
+	log.Entry().Info("Uploading reports to Google Cloud Storage...")
+	content := []gcs.ReportOutputParam{
+		{FilePattern: "**/piper_test_results.md", ParamRef: "", StepResultType: "junit"},
+		{FilePattern: "**/piper_test_results.json", ParamRef: "", StepResultType: "junit"},
+	}
+	envVars := []gcs.EnvVar{
+		{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+	}
+	gcsClient, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+	if err != nil {
+		log.Entry().Errorf("creation of GCS client failed: %v", err)
+		return
+	}
+	defer gcsClient.Close()
+	structVal := reflect.ValueOf(&stepConfig).Elem()
+	inputParameters := map[string]string{}
+	for i := 0; i < structVal.NumField(); i++ {
+		field := structVal.Type().Field(i)
+		if field.Type.String() == "string" {
+			paramName := strings.Split(field.Tag.Get("json"), ",")
+			paramValue, _ := structVal.Field(i).Interface().(string)
+			inputParameters[paramName[0]] = paramValue
+		}
+	}
+	if err := gcs.PersistReportsToGCS(gcsClient, content, inputParameters, gcsFolderPath, gcsBucketId, gcsSubFolder, doublestar.Glob, os.Stat); err != nil {
+		log.Entry().Errorf("failed to persist reports: %v", err)
+	}
+}
+
+// TestResultsAggregateCommand Aggregates test results and code coverage of all build and test steps and checks them against thresholds.
+func TestResultsAggregateCommand() *cobra.Command {
+	const STEP_NAME = "testResultsAggregate"
+
+	metadata := testResultsAggregateMetadata()
+	var stepConfig testResultsAggregateOptions
+	var startTime time.Time
+	var influx testResultsAggregateInflux
+	var reports testResultsAggregateReports
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createTestResultsAggregateCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Aggregates test results and code coverage of all build and test steps and checks them against thresholds.",
+		Long: `This step collects the test results and coverage reports created by previous build and test steps
+(e.g. ` + "`" + `mavenBuild` + "`" + `, ` + "`" + `golangBuild` + "`" + `, ` + "`" + `npmExecuteScripts` + "`" + `, ` + "`" + `karmaExecuteTests` + "`" + `) and merges them into one result.
+
+Supported formats are:
+
+* JUnit XML for test results
+* Cobertura XML, JaCoCo XML and Go coverage profiles (` + "`" + `go test -coverprofile` + "`" + `) for code coverage
+
+The format of a file is detected from its content. Coverage of the same source file contained in several reports is merged line by line,
+a line is considered covered as soon as one of the reports covers it.
+
+The aggregated result can be checked against a minimum pass rate as well as a minimum line and branch coverage.
+In addition, the coverage of the files changed compared to ` + "`" + `changedFilesReference` + "`" + ` (e.g. the target branch of a pull request)
+can be checked separately. This allows to require a high coverage for new code without enforcing it for legacy code.
+
+A summary is written as markdown and as JSON report. A threshold of ` + "`" + `0` + "`" + ` disables the respective check.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 || len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if err = log.RegisterANSHookIfConfigured(GeneralConfig.CorrelationID); err != nil {
+				log.Entry().WithError(err).Warn("failed to set up SAP Alert Notification Service log hook")
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			vaultClient := config.GlobalVaultClient()
+			if vaultClient != nil {
+				defer vaultClient.MustRevokeToken()
+			}
+
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
+				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.Dsn,
+						GeneralConfig.HookConfig.SplunkConfig.Token,
+						GeneralConfig.HookConfig.SplunkConfig.Index,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint) > 0 {
+					splunkClient.Initialize(GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblEndpoint,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblToken,
+						GeneralConfig.HookConfig.SplunkConfig.ProdCriblIndex,
+						GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if GeneralConfig.HookConfig.GCPPubSubConfig.Enabled {
+					err := gcp.NewGcpPubsubClient(
+						vaultClient,
+						GeneralConfig.HookConfig.GCPPubSubConfig.ProjectNumber,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityPool,
+						GeneralConfig.HookConfig.GCPPubSubConfig.IdentityProvider,
+						GeneralConfig.CorrelationID,
+						GeneralConfig.HookConfig.OIDCConfig.RoleID,
+					).Publish(GeneralConfig.HookConfig.GCPPubSubConfig.Topic, telemetryClient.GetDataBytes())
+					if err != nil {
+						log.Entry().WithError(err).Warn("event publish failed")
+					}
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME, GeneralConfig.HookConfig.PendoConfig.Token)
+			testResultsAggregate(stepConfig, &stepTelemetryData, &influx)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addTestResultsAggregateFlags(createTestResultsAggregateCmd, &stepConfig)
+	return createTestResultsAggregateCmd
+}
+
+func addTestResultsAggregateFlags(cmd *cobra.Command, stepConfig *testResultsAggregateOptions) {
+	cmd.Flags().StringSliceVar(&stepConfig.TestResultFiles, "testResultFiles", []string{`**/TEST-*.xml`}, "List of glob patterns of the JUnit XML files containing the test results.")
+	cmd.Flags().StringSliceVar(&stepConfig.CoverageFiles, "coverageFiles", []string{`**/cobertura-coverage.xml`, `**/jacoco.xml`, `**/cover.out`}, "List of glob patterns of the coverage reports in Cobertura XML, JaCoCo XML or Go coverage profile format.")
+	cmd.Flags().StringSliceVar(&stepConfig.Excludes, "excludes", []string{`**/node_modules/**`}, "List of glob patterns of files which are excluded.")
+	cmd.Flags().IntVar(&stepConfig.MinPassRate, "minPassRate", 0, "Minimum percentage of passed tests among the executed (not skipped) tests.")
+	cmd.Flags().IntVar(&stepConfig.MinLineCoverage, "minLineCoverage", 0, "Minimum line coverage in percent across all source files.")
+	cmd.Flags().IntVar(&stepConfig.MinBranchCoverage, "minBranchCoverage", 0, "Minimum branch coverage in percent across all source files. Reports without branch information (e.g. Go coverage profiles) are not considered.")
+	cmd.Flags().StringVar(&stepConfig.ChangedFilesReference, "changedFilesReference", os.Getenv("PIPER_changedFilesReference"), "Git reference (e.g. `origin/main`) the changes of the current commit are compared to in order to determine the changed files. The coverage of changed files is only determined if the reference is provided.")
+	cmd.Flags().IntVar(&stepConfig.MinChangedFilesLineCoverage, "minChangedFilesLineCoverage", 0, "Minimum line coverage in percent of the changed files. Requires `changedFilesReference`.")
+	cmd.Flags().IntVar(&stepConfig.MinChangedFilesBranchCoverage, "minChangedFilesBranchCoverage", 0, "Minimum branch coverage in percent of the changed files. Requires `changedFilesReference`.")
+	cmd.Flags().BoolVar(&stepConfig.FailOnThresholdViolation, "failOnThresholdViolation", true, "Whether the step fails in case a threshold is not met. If set to `false`, violations are reported as warnings only.")
+
+}
+
+// retrieve step metadata
+func testResultsAggregateMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "testResultsAggregate",
+			Aliases:     []config.Alias{},
+			Description: "Aggregates test results and code coverage of all build and test steps and checks them against thresholds.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Parameters: []config.StepParameters{
+					{
+						Name:        "testResultFiles",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/TEST-*.xml`},
+					},
+					{
+						Name:        "coverageFiles",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/cobertura-coverage.xml`, `**/jacoco.xml`, `**/cover.out`},
+					},
+					{
+						Name:        "excludes",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/node_modules/**`},
+					},
+					{
+						Name:        "minPassRate",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     0,
+					},
+					{
+						Name:        "minLineCoverage",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     0,
+					},
+					{
+						Name:        "minBranchCoverage",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     0,
+					},
+					{
+						Name:        "changedFilesReference",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_changedFilesReference"),
+					},
+					{
+						Name:        "minChangedFilesLineCoverage",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     0,
+					},
+					{
+						Name:        "minChangedFilesBranchCoverage",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     0,
+					},
+					{
+						Name:        "failOnThresholdViolation",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     true,
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"name": "step_data", "fields": []map[string]string{{"name": "testResultsAggregate"}}},
+							{"name": "test_data", "fields": []map[string]string{{"name": "tests"}, {"name": "passed"}, {"name": "failed"}, {"name": "skipped"}, {"name": "passRate"}, {"name": "lineCoverage"}, {"name": "branchCoverage"}, {"name": "changedFilesLineCoverage"}, {"name": "changedFilesBranchCoverage"}}},
+						},
+					},
+					{
+						Name: "reports",
+						Type: "reports",
+						Parameters: []map[string]interface{}{
+							{"filePattern": "**/piper_test_results.md", "type": "junit"},
+							{"filePattern": "**/piper_test_results.json", "type": "junit"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
@@ -0,0 +1,137 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/testresults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResultsAggregateMockUtils struct {
+	*mock.FilesMock
+}
+
+func newTestResultsAggregateTestsUtils() testResultsAggregateMockUtils {
+	utils := testResultsAggregateMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	utils.AddFile("go.mod", []byte("module github.com/example/project\n\ngo 1.22\n"))
+	utils.AddFile("TEST-go.xml", []byte(`<testsuites><testsuite name="pkg/a"><testcase classname="pkg/a" name="TestA"/><testcase classname="pkg/a" name="TestB"><failure message="wrong result"/></testcase></testsuite></testsuites>`))
+	utils.AddFile("cover.out", []byte("mode: set\ngithub.com/example/project/pkg/a/a.go:3.1,6.2 3 1\ngithub.com/example/project/pkg/b/b.go:3.1,4.2 1 0\n"))
+	utils.AddFile("target/site/jacoco/jacoco.xml", []byte(`<report name="app"><package name="com/example"><sourcefile name="Main.java"><line nr="1" mi="0" ci="2" mb="1" cb="1"/><line nr="2" mi="1" ci="0" mb="0" cb="0"/></sourcefile></package></report>`))
+	utils.AddFile("node_modules/lib/cobertura-coverage.xml", []byte(`<coverage><packages><package><classes><class filename="lib.js"><lines><line number="1" hits="0"/></lines></class></classes></package></packages></coverage>`))
+	return utils
+}
+
+func defaultTestResultsAggregateOptions() testResultsAggregateOptions {
+	return testResultsAggregateOptions{
+		TestResultFiles:          []string{"**/TEST-*.xml"},
+		CoverageFiles:            []string{"**/cobertura-coverage.xml", "**/jacoco.xml", "**/cover.out"},
+		Excludes:                 []string{"**/node_modules/**"},
+		FailOnThresholdViolation: true,
+	}
+}
+
+func TestRunTestResultsAggregate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		utils := newTestResultsAggregateTestsUtils()
+		// changed files are only determined if a reference is configured
+		mockChangedFiles(t, nil, errors.New("unexpected detection of changed files"))
+		config := defaultTestResultsAggregateOptions()
+		config.MinLineCoverage = 60
+		config.MinBranchCoverage = 50
+		influx := testResultsAggregateInflux{}
+
+		err := runTestResultsAggregate(&config, utils, &influx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, influx.test_data.fields.tests)
+		assert.Equal(t, 1, influx.test_data.fields.failed)
+		assert.Equal(t, float64(50), influx.test_data.fields.passRate)
+		// 4 lines of a.go and 1 line of Main.java out of 5 + 1 + 2 lines, cobertura report in node_modules is excluded
+		assert.Equal(t, float64(62.5), influx.test_data.fields.lineCoverage)
+		assert.Equal(t, float64(50), influx.test_data.fields.branchCoverage)
+		assert.True(t, utils.HasFile(filepath.Join(testresults.ReportsDirectory, "piper_test_results.md")))
+		assert.True(t, utils.HasFile(filepath.Join(testresults.ReportsDirectory, "piper_test_results.json")))
+	})
+
+	t.Run("thresholds not met", func(t *testing.T) {
+		utils := newTestResultsAggregateTestsUtils()
+		config := defaultTestResultsAggregateOptions()
+		config.MinPassRate = 100
+		config.MinLineCoverage = 80
+
+		err := runTestResultsAggregate(&config, utils, &testResultsAggregateInflux{})
+
+		assert.EqualError(t, err, "thresholds not met: pass rate of 50.00% (threshold 100%), line coverage of 62.50% (threshold 80%)")
+	})
+
+	t.Run("thresholds not met without failing", func(t *testing.T) {
+		utils := newTestResultsAggregateTestsUtils()
+		config := defaultTestResultsAggregateOptions()
+		config.MinPassRate = 100
+		config.FailOnThresholdViolation = false
+
+		err := runTestResultsAggregate(&config, utils, &testResultsAggregateInflux{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("changed files", func(t *testing.T) {
+		utils := newTestResultsAggregateTestsUtils()
+		mockChangedFiles(t, []string{"pkg/b/b.go", "README.md"}, nil)
+		config := defaultTestResultsAggregateOptions()
+		config.ChangedFilesReference = "origin/main"
+		config.MinLineCoverage = 50
+		config.MinChangedFilesLineCoverage = 80
+		influx := testResultsAggregateInflux{}
+
+		err := runTestResultsAggregate(&config, utils, &influx)
+
+		assert.EqualError(t, err, "thresholds not met: changed files line coverage of 0.00% (threshold 80%)")
+		assert.Equal(t, float64(0), influx.test_data.fields.changedFilesLineCoverage)
+	})
+
+	t.Run("no coverage of changed files", func(t *testing.T) {
+		utils := newTestResultsAggregateTestsUtils()
+		mockChangedFiles(t, []string{"README.md"}, nil)
+		config := defaultTestResultsAggregateOptions()
+		config.ChangedFilesReference = "origin/main"
+		config.MinChangedFilesLineCoverage = 80
+
+		err := runTestResultsAggregate(&config, utils, &testResultsAggregateInflux{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("git failure", func(t *testing.T) {
+		utils := newTestResultsAggregateTestsUtils()
+		mockChangedFiles(t, nil, errors.New("unknown revision"))
+		config := defaultTestResultsAggregateOptions()
+		config.ChangedFilesReference = "origin/main"
+
+		err := runTestResultsAggregate(&config, utils, &testResultsAggregateInflux{})
+
+		assert.EqualError(t, err, "failed to determine files changed compared to 'origin/main': unknown revision")
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		utils := testResultsAggregateMockUtils{FilesMock: &mock.FilesMock{}}
+		config := defaultTestResultsAggregateOptions()
+		config.MinPassRate = 90
+		config.MinLineCoverage = 50
+		config.MinBranchCoverage = 50
+
+		err := runTestResultsAggregate(&config, utils, &testResultsAggregateInflux{})
+
+		require.Error(t, err)
+		assert.Equal(t, "thresholds not met: pass rate of 0.00% (threshold 90%), line coverage of 0.00% (threshold 50%)", err.Error())
+	})
+}
@@ -63,6 +63,16 @@ func (i *tmsExportInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *tmsExportInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"tms": i.step_data.fields.tms,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 // TmsExportCommand This step allows you to export an MTA file (multi-target application archive) and multiple MTA extension descriptors into a TMS (SAP Cloud Transport Management service) landscape for further TMS-controlled distribution through a TMS-configured landscape.
 func TmsExportCommand() *cobra.Command {
 	const STEP_NAME = "tmsExport"
@@ -142,9 +152,12 @@ For more information, see [official documentation of SAP Cloud Transport Managem
 			handler := func() {
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/tms"
+)
+
+func tmsPromote(promoteConfig tmsPromoteOptions, telemetryData *telemetry.CustomData, influx *tmsPromoteInflux) {
+	config := convertPromoteOptions(promoteConfig)
+	communicationInstance := tms.SetupCommunication(config)
+
+	err := runTmsPromote(promoteConfig, communicationInstance, time.Sleep)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("Failed to run tmsPromote step")
+	}
+	influx.step_data.fields.tms = true
+}
+
+func runTmsPromote(promoteConfig tmsPromoteOptions, communicationInstance tms.CommunicationInterface, sleep func(time.Duration)) error {
+	if len(promoteConfig.Nodes) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no nodes configured for the promotion of transport request %v", promoteConfig.TransportRequestID)
+	}
+
+	nodes, err := communicationInstance.GetNodes()
+	if err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	route := []tms.Node{}
+	for _, nodeName := range promoteConfig.Nodes {
+		node, found := findTmsNode(nodes, nodeName)
+		if !found {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return fmt.Errorf("node %v does not exist. Please check node names provided in 'nodes' parameter", nodeName)
+		}
+		route = append(route, node)
+	}
+
+	transportRequestId := int64(promoteConfig.TransportRequestID)
+	for i, node := range route {
+		inQueue, err := isInImportQueue(communicationInstance, node, transportRequestId)
+		if err != nil {
+			return err
+		}
+		if !inQueue {
+			if i == 0 {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return fmt.Errorf("transport request %v is not contained in the import queue of node %v", transportRequestId, node.Name)
+			}
+			if err := forwardTransportRequest(promoteConfig, communicationInstance, route[i-1], node, transportRequestId); err != nil {
+				return err
+			}
+		}
+
+		if err := importTransportRequest(promoteConfig, communicationInstance, node, transportRequestId, sleep); err != nil {
+			return err
+		}
+	}
+
+	log.Entry().Infof("Transport request %v promoted successfully to nodes %v", transportRequestId, promoteConfig.Nodes)
+	return nil
+}
+
+func findTmsNode(nodes []tms.Node, nodeName string) (tms.Node, bool) {
+	for _, node := range nodes {
+		if node.Name == nodeName {
+			return node, true
+		}
+	}
+	return tms.Node{}, false
+}
+
+func isInImportQueue(communicationInstance tms.CommunicationInterface, node tms.Node, transportRequestId int64) (bool, error) {
+	transportRequests, err := communicationInstance.GetTransportRequests(node.Id)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return false, fmt.Errorf("failed to get transport requests of node %v: %w", node.Name, err)
+	}
+	for _, transportRequest := range transportRequests {
+		if transportRequest.Id == transportRequestId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func forwardTransportRequest(promoteConfig tmsPromoteOptions, communicationInstance tms.CommunicationInterface, from, to tms.Node, transportRequestId int64) error {
+	log.Entry().Infof("Forwarding transport request %v from node %v", transportRequestId, from.Name)
+	if err := communicationInstance.ForwardTransportRequests(from.Id, []int64{transportRequestId}, promoteConfig.NamedUser); err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return fmt.Errorf("failed to forward transport request %v from node %v: %w", transportRequestId, from.Name, err)
+	}
+	inQueue, err := isInImportQueue(communicationInstance, to, transportRequestId)
+	if err != nil {
+		return err
+	}
+	if !inQueue {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("transport request %v has not been forwarded to node %v, please check that %v is a follow-on node of %v", transportRequestId, to.Name, to.Name, from.Name)
+	}
+	return nil
+}
+
+const tmsMinPollInterval = time.Second
+
+func importTransportRequest(promoteConfig tmsPromoteOptions, communicationInstance tms.CommunicationInterface, node tms.Node, transportRequestId int64, sleep func(time.Duration)) error {
+	transportRequest, err := communicationInstance.GetTransportRequest(node.Id, transportRequestId)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorService)
+		return fmt.Errorf("failed to get status of transport request %v in node %v: %w", transportRequestId, node.Name, err)
+	}
+	if transportRequest.IsImported() {
+		log.Entry().Infof("Transport request %v has already been imported into node %v", transportRequestId, node.Name)
+		return nil
+	}
+
+	if transportRequest.Status != tms.TR_STATUS_RUNNING {
+		log.Entry().Infof("Importing transport request %v into node %v", transportRequestId, node.Name)
+		if err := communicationInstance.ImportTransportRequests(node.Id, []int64{transportRequestId}, promoteConfig.NamedUser); err != nil {
+			log.SetErrorCategory(log.ErrorService)
+			return fmt.Errorf("failed to import transport request %v into node %v: %w", transportRequestId, node.Name, err)
+		}
+	}
+
+	pollInterval := time.Duration(promoteConfig.PollInterval) * time.Second
+	if pollInterval < tmsMinPollInterval {
+		// the waited time is accumulated from the interval, without a minimum the timeout would never be reached
+		log.Entry().Warnf("Poll interval %v is too short, using %v instead", pollInterval, tmsMinPollInterval)
+		pollInterval = tmsMinPollInterval
+	}
+	timeout := time.Duration(promoteConfig.ImportTimeout) * time.Second
+	for waited := time.Duration(0); ; waited += pollInterval {
+		transportRequest, err = communicationInstance.GetTransportRequest(node.Id, transportRequestId)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorService)
+			return fmt.Errorf("failed to get status of transport request %v in node %v: %w", transportRequestId, node.Name, err)
+		}
+		if transportRequest.IsImportFinished() {
+			break
+		}
+		if waited >= timeout {
+			log.SetErrorCategory(log.ErrorService)
+			return fmt.Errorf("import of transport request %v into node %v did not finish within %v", transportRequestId, node.Name, timeout)
+		}
+		log.Entry().Infof("Import of transport request %v into node %v has status '%v', waiting %v", transportRequestId, node.Name, transportRequest.Status, pollInterval)
+		sleep(pollInterval)
+	}
+
+	switch {
+	case transportRequest.Status == tms.TR_STATUS_SUCCEEDED:
+		log.Entry().Infof("Transport request %v imported successfully into node %v", transportRequestId, node.Name)
+		return nil
+	case transportRequest.Status == tms.TR_STATUS_WARNING && !promoteConfig.FailOnWarning:
+		logImportLog(communicationInstance, node, transportRequestId)
+		log.Entry().Warnf("Transport request %v imported into node %v with warnings", transportRequestId, node.Name)
+		return nil
+	}
+
+	logImportLog(communicationInstance, node, transportRequestId)
+	log.SetErrorCategory(log.ErrorService)
+	return fmt.Errorf("import of transport request %v into node %v finished with status '%v'", transportRequestId, node.Name, transportRequest.Status)
+}
+
+func logImportLog(communicationInstance tms.CommunicationInterface, node tms.Node, transportRequestId int64) {
+	logs, err := communicationInstance.GetTransportRequestLogs(node.Id, transportRequestId)
+	if err != nil {
+		log.Entry().WithError(err).Warnf("Failed to get import log of transport request %v in node %v", transportRequestId, node.Name)
+		return
+	}
+	log.Entry().Infof("### START OF TMS IMPORT LOG (node %v) ###", node.Name)
+	for _, entry := range logs {
+		message := fmt.Sprintf("%v [%v] %v", entry.CreatedAt, entry.Level, entry.Message)
+		switch entry.Level {
+		case "ERROR", "FATAL":
+			log.Entry().Error(message)
+		case "WARNING":
+			log.Entry().Warn(message)
+		default:
+			log.Entry().Info(message)
+		}
+	}
+	log.Entry().Infof("### END OF TMS IMPORT LOG (node %v) ###", node.Name)
+}
+
+func convertPromoteOptions(promoteConfig tmsPromoteOptions) tms.Options {
+	var config tms.Options
+	config.ServiceKey = promoteConfig.ServiceKey
+	config.NamedUser = promoteConfig.NamedUser
+	config.Proxy = promoteConfig.Proxy
+	config.Verbose = GeneralConfig.Verbose
+	return config
+}
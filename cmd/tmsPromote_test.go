@@ -0,0 +1,245 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/tms"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_NODE_ID = 1
+const PROD_NODE_ID = 2
+const TRANSPORT_REQUEST_ID = 555
+
+func (cim *communicationInstanceMock) GetTransportRequests(nodeId int64) ([]tms.TransportRequest, error) {
+	return cim.transportRequests[nodeId], nil
+}
+
+func (cim *communicationInstanceMock) GetTransportRequest(nodeId, transportRequestId int64) (tms.TransportRequest, error) {
+	for i, transportRequest := range cim.transportRequests[nodeId] {
+		if transportRequest.Id != transportRequestId {
+			continue
+		}
+		if pending := cim.pendingStatuses[nodeId]; len(pending) > 0 {
+			cim.transportRequests[nodeId][i].Status = pending[0]
+			cim.pendingStatuses[nodeId] = pending[1:]
+		}
+		return cim.transportRequests[nodeId][i], nil
+	}
+	return tms.TransportRequest{}, errors.New("Transport request not found")
+}
+
+func (cim *communicationInstanceMock) ImportTransportRequests(nodeId int64, transportRequestIds []int64, namedUser string) error {
+	if namedUser != NAMED_USER {
+		return errors.New(INVALID_INPUT_MSG)
+	}
+	cim.transportRequestActions = append(cim.transportRequestActions, fmt.Sprintf("import %v into %v", transportRequestIds, nodeId))
+	if cim.isErrorOnImportTransportRequests {
+		return errors.New("Something went wrong on importing transport requests")
+	}
+	statuses, ok := cim.importStatuses[nodeId]
+	if !ok {
+		statuses = []string{tms.TR_STATUS_SUCCEEDED}
+	}
+	cim.pendingStatuses[nodeId] = statuses
+	return nil
+}
+
+func (cim *communicationInstanceMock) ForwardTransportRequests(nodeId int64, transportRequestIds []int64, namedUser string) error {
+	cim.transportRequestActions = append(cim.transportRequestActions, fmt.Sprintf("forward %v from %v", transportRequestIds, nodeId))
+	if followOnNode, ok := cim.followOnNodes[nodeId]; ok {
+		for _, id := range transportRequestIds {
+			cim.transportRequests[followOnNode] = append(cim.transportRequests[followOnNode], tms.TransportRequest{Id: id, Status: tms.TR_STATUS_INITIAL})
+		}
+	}
+	return nil
+}
+
+func (cim *communicationInstanceMock) GetTransportRequestLogs(nodeId, transportRequestId int64) ([]tms.TransportRequestLog, error) {
+	return cim.transportRequestLogs, nil
+}
+
+func newPromoteCommunicationInstanceMock() *communicationInstanceMock {
+	return &communicationInstanceMock{
+		getNodesResponse: []tms.Node{{Id: TEST_NODE_ID, Name: "TEST"}, {Id: PROD_NODE_ID, Name: "PROD"}},
+		transportRequests: map[int64][]tms.TransportRequest{
+			TEST_NODE_ID: {{Id: TRANSPORT_REQUEST_ID, Status: tms.TR_STATUS_INITIAL}},
+		},
+		importStatuses:  map[int64][]string{},
+		pendingStatuses: map[int64][]string{},
+		followOnNodes:   map[int64]int64{TEST_NODE_ID: PROD_NODE_ID},
+	}
+}
+
+func TestRunTmsPromote(t *testing.T) {
+	t.Parallel()
+
+	noSleep := func(time.Duration) {}
+
+	t.Run("happy path: import into first node, forward and import into second node", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.importStatuses[TEST_NODE_ID] = []string{tms.TR_STATUS_RUNNING, tms.TR_STATUS_RUNNING, tms.TR_STATUS_SUCCEEDED}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST", "PROD"}, NamedUser: NAMED_USER, PollInterval: 10, ImportTimeout: 60}
+		sleeps := 0
+
+		// test
+		err := runTmsPromote(config, communicationInstance, func(d time.Duration) {
+			assert.Equal(t, 10*time.Second, d)
+			sleeps++
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sleeps)
+		assert.Equal(t, []string{"import [555] into 1", "forward [555] from 1", "import [555] into 2"}, communicationInstance.transportRequestActions)
+	})
+
+	t.Run("happy path: transport request already imported into first node", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.transportRequests[TEST_NODE_ID][0].Status = tms.TR_STATUS_SUCCEEDED
+		communicationInstance.transportRequests[PROD_NODE_ID] = []tms.TransportRequest{{Id: TRANSPORT_REQUEST_ID, Status: tms.TR_STATUS_INITIAL}}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST", "PROD"}, NamedUser: NAMED_USER}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"import [555] into 2"}, communicationInstance.transportRequestActions)
+	})
+
+	t.Run("error path: import fails", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.importStatuses[TEST_NODE_ID] = []string{tms.TR_STATUS_ERROR}
+		communicationInstance.transportRequestLogs = []tms.TransportRequestLog{{Level: "ERROR", Message: "Deployment of MTA failed"}}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST", "PROD"}, NamedUser: NAMED_USER}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "import of transport request 555 into node TEST finished with status 'error'")
+		assert.Equal(t, []string{"import [555] into 1"}, communicationInstance.transportRequestActions)
+	})
+
+	t.Run("error path: import finishes with warnings", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.importStatuses[TEST_NODE_ID] = []string{tms.TR_STATUS_WARNING}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST"}, NamedUser: NAMED_USER, FailOnWarning: true}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "import of transport request 555 into node TEST finished with status 'warning'")
+	})
+
+	t.Run("error path: import does not finish in time", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.importStatuses[TEST_NODE_ID] = []string{tms.TR_STATUS_RUNNING, tms.TR_STATUS_RUNNING, tms.TR_STATUS_RUNNING, tms.TR_STATUS_RUNNING}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST"}, NamedUser: NAMED_USER, PollInterval: 10, ImportTimeout: 20}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "import of transport request 555 into node TEST did not finish within 20s")
+	})
+
+	t.Run("error path: import does not finish in time without poll interval", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.importStatuses[TEST_NODE_ID] = []string{tms.TR_STATUS_RUNNING}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST"}, NamedUser: NAMED_USER, PollInterval: 0, ImportTimeout: 3}
+		sleeps := []time.Duration{}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, func(d time.Duration) { sleeps = append(sleeps, d) })
+
+		// assert
+		assert.EqualError(t, err, "import of transport request 555 into node TEST did not finish within 3s")
+		assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, sleeps)
+	})
+
+	t.Run("error path: transport request not in import queue of first node", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		config := tmsPromoteOptions{TransportRequestID: 666, Nodes: []string{"TEST", "PROD"}, NamedUser: NAMED_USER}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "transport request 666 is not contained in the import queue of node TEST")
+		assert.Empty(t, communicationInstance.transportRequestActions)
+	})
+
+	t.Run("error path: node is not a follow-on node", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.followOnNodes = map[int64]int64{}
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST", "PROD"}, NamedUser: NAMED_USER}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "transport request 555 has not been forwarded to node PROD, please check that PROD is a follow-on node of TEST")
+	})
+
+	t.Run("error path: unknown node", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST", "QA"}, NamedUser: NAMED_USER}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "node QA does not exist. Please check node names provided in 'nodes' parameter")
+	})
+
+	t.Run("error path: import request fails", func(t *testing.T) {
+		t.Parallel()
+
+		// init
+		communicationInstance := newPromoteCommunicationInstanceMock()
+		communicationInstance.isErrorOnImportTransportRequests = true
+		config := tmsPromoteOptions{TransportRequestID: TRANSPORT_REQUEST_ID, Nodes: []string{"TEST"}, NamedUser: NAMED_USER}
+
+		// test
+		err := runTmsPromote(config, communicationInstance, noSleep)
+
+		// assert
+		assert.EqualError(t, err, "failed to import transport request 555 into node TEST: Something went wrong on importing transport requests")
+	})
+}
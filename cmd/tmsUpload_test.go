@@ -71,6 +71,13 @@ type communicationInstanceMock struct {
 	isErrorOnUploadFile                   bool
 	isErrorOnUploadFileToNode             bool
 	isErrorOnExportFileToNode             bool
+	transportRequests                     map[int64][]tms.TransportRequest
+	importStatuses                        map[int64][]string
+	pendingStatuses                       map[int64][]string
+	followOnNodes                         map[int64]int64
+	transportRequestLogs                  []tms.TransportRequestLog
+	transportRequestActions               []string
+	isErrorOnImportTransportRequests      bool
 }
 
 func (cim *communicationInstanceMock) GetNodes() ([]tms.Node, error) {
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/scm"
+	"github.com/google/go-github/v45/github"
 )
 
 // Deprecated: Please use piperutils.Files{} instead
@@ -32,3 +36,33 @@ func logWorkspaceContent() {
 	})
 
 }
+
+// newScanGitHubClient creates the GitHub client of a scan step.
+// No client is created if the results are reported to another SCM, since its token and API URL do not belong to GitHub.
+func newScanGitHubClient(provider, token, apiURL string, trustedCerts []string) (context.Context, *github.Client) {
+	if !scm.IsGitHub(provider) {
+		return context.Background(), nil
+	}
+	ctx, client, err := piperGithub.NewClientBuilder(token, apiURL).WithTrustedCerts(trustedCerts).Build()
+	if err != nil {
+		log.Entry().WithError(err).Warning("Failed to get GitHub client")
+	}
+	return ctx, client
+}
+
+// newScanResultProvider returns the SCM provider used by scan steps for result issues and pull request comments.
+// For GitHub the services of the step utils are reused so that they share the client of the step.
+func newScanResultProvider(options scm.Options, issues *github.IssuesService, search *github.SearchService) (scm.Provider, error) {
+	if !scm.IsGitHub(options.Provider) {
+		return scm.NewProvider(options)
+	}
+	provider := &scm.GitHub{Owner: options.Owner, Repository: options.Repository}
+	// avoid typed nil values in the interfaces
+	if issues != nil {
+		provider.Issues = issues
+	}
+	if search != nil {
+		provider.Search = search
+	}
+	return provider, nil
+}
@@ -0,0 +1,62 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/scm"
+	"github.com/google/go-github/v45/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScanResultProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GitHub reuses services of the step", func(t *testing.T) {
+		issues := &github.IssuesService{}
+		provider, err := newScanResultProvider(scm.Options{Owner: "owner", Repository: "repo"}, issues, nil)
+
+		assert.NoError(t, err)
+		if assert.IsType(t, &scm.GitHub{}, provider) {
+			gh := provider.(*scm.GitHub)
+			assert.Equal(t, "owner", gh.Owner)
+			assert.Same(t, issues, gh.Issues)
+			assert.Nil(t, gh.Search)
+		}
+	})
+
+	t.Run("other SCM", func(t *testing.T) {
+		provider, err := newScanResultProvider(scm.Options{Provider: "gitlab", APIURL: "https://gitlab.example.com/api/v4"}, nil, nil)
+
+		assert.NoError(t, err)
+		assert.IsType(t, &scm.GitLab{}, provider)
+	})
+
+	t.Run("unsupported SCM", func(t *testing.T) {
+		_, err := newScanResultProvider(scm.Options{Provider: "svn"}, nil, nil)
+
+		assert.EqualError(t, err, "SCM provider 'svn' is not supported")
+	})
+}
+
+func TestNewScanGitHubClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GitHub", func(t *testing.T) {
+		ctx, client := newScanGitHubClient("", "token", "https://github.example.com/api/v3", nil)
+
+		assert.NotNil(t, ctx)
+		if assert.NotNil(t, client) {
+			assert.Equal(t, "https://github.example.com/api/v3/", client.BaseURL.String())
+		}
+	})
+
+	t.Run("other SCM", func(t *testing.T) {
+		ctx, client := newScanGitHubClient("gitlab", "token", "https://gitlab.example.com/api/v4", nil)
+
+		assert.NotNil(t, ctx)
+		assert.Nil(t, client)
+	})
+}
@@ -12,7 +12,6 @@ import (
 	"time"
 
 	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
-	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	ws "github.com/SAP/jenkins-library/pkg/whitesource"
 
@@ -23,6 +22,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/npm"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/scm"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
 	"github.com/SAP/jenkins-library/pkg/versioning"
@@ -114,6 +114,17 @@ func (w *whitesourceUtilsBundle) GetSearchService() *github.SearchService {
 	return w.search
 }
 
+func whitesourceScmOptions(config *ScanOptions) scm.Options {
+	return scm.Options{
+		Provider:     config.ScmProvider,
+		APIURL:       config.GithubAPIURL,
+		Token:        config.GithubToken,
+		Owner:        config.Owner,
+		Repository:   config.Repository,
+		TrustedCerts: config.CustomTLSCertificateLinks,
+	}
+}
+
 func newWhitesourceUtils(config *ScanOptions, client *github.Client) *whitesourceUtilsBundle {
 	utils := whitesourceUtilsBundle{
 		Client:  &piperhttp.Client{},
@@ -142,12 +153,7 @@ func newWhitesourceScan(config *ScanOptions) *ws.Scan {
 }
 
 func whitesourceExecuteScan(config ScanOptions, _ *telemetry.CustomData, commonPipelineEnvironment *whitesourceExecuteScanCommonPipelineEnvironment, influx *whitesourceExecuteScanInflux) {
-	ctx, client, err := piperGithub.
-		NewClientBuilder(config.GithubToken, config.GithubAPIURL).
-		WithTrustedCerts(config.CustomTLSCertificateLinks).Build()
-	if err != nil {
-		log.Entry().WithError(err).Warning("Failed to get GitHub client")
-	}
+	ctx, client := newScanGitHubClient(config.ScmProvider, config.GithubToken, config.GithubAPIURL, config.CustomTLSCertificateLinks)
 	if log.IsVerbose() {
 		logConfigInVerboseModeForWhitesource(config)
 		logWorkspaceContent()
@@ -599,15 +605,13 @@ func checkPolicyViolations(ctx context.Context, config *ScanOptions, scan *ws.Sc
 			log.Entry().Debugf("Creating result issues for %v alert(s)", policyViolationCount)
 			issueDetails := make([]reporting.IssueDetail, len(allAlerts))
 			piperutils.CopyAtoB(allAlerts, issueDetails)
-			gh := reporting.GitHub{
-				Owner:         &config.Owner,
-				Repository:    &config.Repository,
-				Assignees:     &config.Assignees,
-				IssueService:  utils.GetIssueService(),
-				SearchService: utils.GetSearchService(),
+			provider, err := newScanResultProvider(whitesourceScmOptions(config), utils.GetIssueService(), utils.GetSearchService())
+			if err != nil {
+				return policyReport, err
 			}
-			if err := gh.UploadMultipleReports(ctx, &issueDetails); err != nil {
-				return policyReport, fmt.Errorf("failed to upload reports to GitHub for %v policy violations: %w", policyViolationCount, err)
+			issues := reporting.SCM{Provider: provider, Assignees: config.Assignees}
+			if err := issues.UploadMultipleReports(ctx, &issueDetails); err != nil {
+				return policyReport, fmt.Errorf("failed to upload reports for %v policy violations: %w", policyViolationCount, err)
 			}
 		}
 		return policyReport, fmt.Errorf("%v policy violation(s) found", policyViolationCount)
@@ -746,16 +750,14 @@ func reportGitHubIssuesAndCreateReports(
 		log.Entry().Debugf("Creating result issues for %v alert(s)", vulnerabilitiesCount)
 		issueDetails := make([]reporting.IssueDetail, len(allAlerts))
 		piperutils.CopyAtoB(allAlerts, issueDetails)
-		gh := reporting.GitHub{
-			Owner:         &config.Owner,
-			Repository:    &config.Repository,
-			Assignees:     &config.Assignees,
-			IssueService:  utils.GetIssueService(),
-			SearchService: utils.GetSearchService(),
-		}
-
-		if err := gh.UploadMultipleReports(ctx, &issueDetails); err != nil {
+		provider, err := newScanResultProvider(whitesourceScmOptions(config), utils.GetIssueService(), utils.GetSearchService())
+		if err != nil {
 			errorsOccured = append(errorsOccured, fmt.Sprint(err))
+		} else {
+			issues := reporting.SCM{Provider: provider, Assignees: config.Assignees}
+			if err := issues.UploadMultipleReports(ctx, &issueDetails); err != nil {
+				errorsOccured = append(errorsOccured, fmt.Sprint(err))
+			}
 		}
 	}
 
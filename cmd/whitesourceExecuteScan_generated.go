@@ -78,6 +78,7 @@ type whitesourceExecuteScanOptions struct {
 	GithubAPIURL                         string   `json:"githubApiUrl,omitempty"`
 	Owner                                string   `json:"owner,omitempty"`
 	Repository                           string   `json:"repository,omitempty"`
+	ScmProvider                          string   `json:"scmProvider,omitempty" validate:"possible-values=github gitlab bitbucket azure"`
 	Assignees                            []string `json:"assignees,omitempty"`
 	CustomTLSCertificateLinks            []string `json:"customTlsCertificateLinks,omitempty"`
 	PrivateModules                       string   `json:"privateModules,omitempty"`
@@ -160,6 +161,22 @@ func (i *whitesourceExecuteScanInflux) persist(path, resourceName string) {
 	}
 }
 
+func (i *whitesourceExecuteScanInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"step_data": {
+			"whitesource": i.step_data.fields.whitesource,
+		},
+		"whitesource_data": {
+			"vulnerabilities":       i.whitesource_data.fields.vulnerabilities,
+			"major_vulnerabilities": i.whitesource_data.fields.major_vulnerabilities,
+			"minor_vulnerabilities": i.whitesource_data.fields.minor_vulnerabilities,
+			"policy_violations":     i.whitesource_data.fields.policy_violations,
+		},
+	}
+	tags := map[string]map[string]string{}
+	return fields, tags
+}
+
 type whitesourceExecuteScanReports struct {
 }
 
@@ -293,9 +310,12 @@ The step uses the so-called Mend Unified Agent. For details please refer to the
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
+				measurements, tags := influx.metrics()
+				ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -397,6 +417,7 @@ func addWhitesourceExecuteScanFlags(cmd *cobra.Command, stepConfig *whitesourceE
 	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API URL.")
 	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Set the GitHub organization.")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Set the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.ScmProvider, "scmProvider", `github`, "SCM hosting the repository, result issues and pull request comments are created there. `githubApiUrl`, `githubToken`, `owner` and `repository` are used for the selected SCM, e.g. `githubApiUrl` is the GitLab API URL, `owner` the Bitbucket project key or the Azure DevOps project.")
 	cmd.Flags().StringSliceVar(&stepConfig.Assignees, "assignees", []string{``}, "Defines the assignees for the Github Issue created/updated with the results of the scan as a list of login names.")
 	cmd.Flags().StringSliceVar(&stepConfig.CustomTLSCertificateLinks, "customTlsCertificateLinks", []string{}, "List of download links to custom TLS certificates. This is required to ensure trusted connections to instances with repositories (like nexus) when publish flag is set to true.")
 	cmd.Flags().StringVar(&stepConfig.PrivateModules, "privateModules", os.Getenv("PIPER_privateModules"), "Tells go which modules shall be considered to be private (by setting [GOPRIVATE](https://pkg.go.dev/cmd/go#hdr-Configuration_for_downloading_non_public_code)).")
@@ -1036,6 +1057,15 @@ func whitesourceExecuteScanMetadata() config.StepData {
 						Aliases:   []config.Alias{{Name: "githubRepo"}},
 						Default:   os.Getenv("PIPER_repository"),
 					},
+					{
+						Name:        "scmProvider",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `github`,
+					},
 					{
 						Name:        "assignees",
 						ResourceRef: []config.ResourceReference{},
@@ -0,0 +1,119 @@
+package abaputils
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// Baseline contains known findings which shall not fail the build
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry identifies a known finding. The line is not part of the identity, so the entry survives changes of the surrounding code.
+type BaselineEntry struct {
+	ObjectType  string `json:"objectType,omitempty"`
+	ObjectName  string `json:"objectName"`
+	Rule        string `json:"rule,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Fingerprint identifies the finding independent of its line
+func (f Finding) Fingerprint() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(f.ObjectType+"|"+f.ObjectName+"|"+f.Rule+"|"+f.Message)))
+}
+
+// NewBaseline creates a baseline containing the findings
+func NewBaseline(findings []Finding) Baseline {
+	baseline := Baseline{Findings: []BaselineEntry{}}
+	for _, f := range findings {
+		baseline.Findings = append(baseline.Findings, BaselineEntry{ObjectType: f.ObjectType, ObjectName: f.ObjectName, Rule: f.Rule, Message: f.Message, Fingerprint: f.Fingerprint()})
+	}
+	sort.SliceStable(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.ObjectName != b.ObjectName {
+			return a.ObjectName < b.ObjectName
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return baseline
+}
+
+// ReadBaseline reads the baseline from a JSON file
+func ReadBaseline(fileName string, fileUtils piperutils.FileUtils) (Baseline, error) {
+	baseline := Baseline{}
+	content, err := fileUtils.FileRead(fileName)
+	if err != nil {
+		return baseline, errors.Wrapf(err, "failed to read baseline file %v", fileName)
+	}
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return baseline, errors.Wrapf(err, "failed to parse baseline file %v", fileName)
+	}
+	return baseline, nil
+}
+
+// WriteBaseline writes the baseline as JSON file
+func WriteBaseline(baseline Baseline, fileName string, fileUtils piperutils.FileUtils) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize baseline")
+	}
+	if err := fileUtils.FileWrite(fileName, append(content, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write baseline file %v", fileName)
+	}
+	return nil
+}
+
+// Apply marks the findings contained in the baseline as suppressed and returns the number of suppressed findings.
+// A baseline entry suppresses one finding only, so additional occurrences of a known finding are reported as new.
+func (b Baseline) Apply(findings []Finding) int {
+	known := map[string]int{}
+	for _, entry := range b.Findings {
+		known[entry.Fingerprint]++
+	}
+	suppressed := 0
+	for i := range findings {
+		fingerprint := findings[i].Fingerprint()
+		if known[fingerprint] > 0 {
+			known[fingerprint]--
+			findings[i].Suppressed = true
+			suppressed++
+		}
+	}
+	return suppressed
+}
+
+// ApplyBaseline marks the findings known in the baseline file as suppressed or, with updateBaseline, stores all findings as new baseline
+func ApplyBaseline(findings []Finding, baselineFile string, updateBaseline bool, fileUtils piperutils.FileUtils) error {
+	if len(baselineFile) == 0 {
+		if updateBaseline {
+			return errors.New("Updating the baseline requires the parameter baselineFile")
+		}
+		return nil
+	}
+	if updateBaseline {
+		if err := WriteBaseline(NewBaseline(findings), baselineFile, fileUtils); err != nil {
+			return err
+		}
+		log.Entry().Infof("Baseline %s updated with %d findings", baselineFile, len(findings))
+		return nil
+	}
+	if exists, _ := fileUtils.FileExists(baselineFile); !exists {
+		log.Entry().Warnf("Baseline file %s does not exist, all findings are treated as new findings", baselineFile)
+		return nil
+	}
+	baseline, err := ReadBaseline(baselineFile, fileUtils)
+	if err != nil {
+		return err
+	}
+	suppressed := baseline.Apply(findings)
+	log.Entry().Infof("%d of %d findings are contained in the baseline %s", suppressed, len(findings), baselineFile)
+	return nil
+}
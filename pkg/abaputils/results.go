@@ -0,0 +1,196 @@
+package abaputils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Checkstyle is the checkstyle representation of ATC findings or AUnit results
+type Checkstyle struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Text    string           `xml:",chardata"`
+	Version string           `xml:"version,attr"`
+	File    []CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile contains the findings of a checked file
+type CheckstyleFile struct {
+	Text  string            `xml:",chardata"`
+	Name  string            `xml:"name,attr"`
+	Error []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single finding with message
+type CheckstyleError struct {
+	Text     string `xml:",chardata"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+	Line     string `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+}
+
+// AUnitResult is the JUnit representation of an AUnit run
+type AUnitResult struct {
+	XMLName    xml.Name `xml:"testsuites"`
+	Title      string   `xml:"title,attr"`
+	System     string   `xml:"system,attr"`
+	Client     string   `xml:"client,attr"`
+	ExecutedBy string   `xml:"executedBy,attr"`
+	Time       string   `xml:"time,attr"`
+	Timestamp  string   `xml:"timestamp,attr"`
+	Failures   string   `xml:"failures,attr"`
+	Errors     string   `xml:"errors,attr"`
+	Skipped    string   `xml:"skipped,attr"`
+	Asserts    string   `xml:"asserts,attr"`
+	Tests      string   `xml:"tests,attr"`
+	Testsuite  struct {
+		Tests     string `xml:"tests,attr"`
+		Asserts   string `xml:"asserts,attr"`
+		Skipped   string `xml:"skipped,attr"`
+		Errors    string `xml:"errors,attr"`
+		Failures  string `xml:"failures,attr"`
+		Timestamp string `xml:"timestamp,attr"`
+		Time      string `xml:"time,attr"`
+		Hostname  string `xml:"hostname,attr"`
+		Package   string `xml:"package,attr"`
+		Name      string `xml:"name,attr"`
+		Testcase  []struct {
+			Asserts   string `xml:"asserts,attr"`
+			Time      string `xml:"time,attr"`
+			Name      string `xml:"name,attr"`
+			Classname string `xml:"classname,attr"`
+			Error     []struct {
+				Text    string `xml:",chardata"`
+				Type    string `xml:"type,attr"`
+				Message string `xml:"message,attr"`
+			} `xml:"error"`
+			Failure []struct {
+				Text    string `xml:",chardata"`
+				Type    string `xml:"type,attr"`
+				Message string `xml:"message,attr"`
+			} `xml:"failure"`
+			Skipped []struct {
+				Text    string `xml:",chardata"`
+				Message string `xml:"message,attr"`
+			} `xml:"skipped"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+// Finding is a tool independent representation of an ATC finding or a failed AUnit test
+type Finding struct {
+	ObjectName string
+	ObjectType string
+	File       string
+	Line       int
+	Severity   string
+	Rule       string
+	Message    string
+	// Suppressed is set for findings contained in the baseline
+	Suppressed bool
+}
+
+// Findings returns the findings of all checked files
+func (c Checkstyle) Findings() []Finding {
+	findings := []Finding{}
+	for _, file := range c.File {
+		objectName, objectType := ParseObject(file.Name)
+		for _, e := range file.Error {
+			line, _ := strconv.Atoi(strings.TrimSpace(e.Line))
+			findings = append(findings, Finding{
+				ObjectName: objectName,
+				ObjectType: objectType,
+				File:       file.Name,
+				Line:       line,
+				Severity:   e.Severity,
+				Rule:       e.Source,
+				Message:    e.Message,
+			})
+		}
+	}
+	return findings
+}
+
+// Findings returns a finding with severity error for every failed or erroneous test
+func (r AUnitResult) Findings() []Finding {
+	findings := []Finding{}
+	for _, testcase := range r.Testsuite.Testcase {
+		rule := testcase.Classname + "/" + testcase.Name
+		for _, e := range testcase.Error {
+			findings = append(findings, Finding{ObjectName: strings.ToUpper(testcase.Classname), ObjectType: "CLAS", File: testcase.Classname, Severity: "error", Rule: rule, Message: strings.TrimSpace(e.Message + " " + e.Text)})
+		}
+		for _, f := range testcase.Failure {
+			findings = append(findings, Finding{ObjectName: strings.ToUpper(testcase.Classname), ObjectType: "CLAS", File: testcase.Classname, Severity: "error", Rule: rule, Message: strings.TrimSpace(f.Message + " " + f.Text)})
+		}
+	}
+	return findings
+}
+
+// adtObjectPaths maps ABAP object types to the ADT resource of the object, %s is replaced by the lower case object name
+var adtObjectPaths = map[string]string{
+	"CLAS": "/sap/bc/adt/oo/classes/%s/source/main",
+	"INTF": "/sap/bc/adt/oo/interfaces/%s/source/main",
+	"PROG": "/sap/bc/adt/programs/programs/%s/source/main",
+	"FUGR": "/sap/bc/adt/functions/groups/%s",
+	"DDLS": "/sap/bc/adt/ddic/ddl/sources/%s/source/main",
+	"DCLS": "/sap/bc/adt/acm/dcl/sources/%s/source/main",
+	"BDEF": "/sap/bc/adt/bo/behaviordefinitions/%s/source/main",
+	"TABL": "/sap/bc/adt/ddic/tables/%s/source/main",
+	"DEVC": "/sap/bc/adt/packages/%s",
+}
+
+// ParseObject derives name and type of the ABAP object from the file name of a finding.
+// Supported are abapGit file names (zcl_example.clas.abap), gCTS object paths (objects/CLAS/ZCL_EXAMPLE/...) and ADT resource URIs.
+func ParseObject(fileName string) (objectName, objectType string) {
+	name := strings.ReplaceAll(fileName, "\\", "/")
+
+	if strings.Contains(name, "/sap/bc/adt/") {
+		resource := name[strings.Index(name, "/sap/bc/adt/"):]
+		for t, p := range adtObjectPaths {
+			prefix := strings.TrimSuffix(strings.Split(p, "%s")[0], "/") + "/"
+			if strings.HasPrefix(resource, prefix) {
+				segment := strings.Split(strings.TrimPrefix(resource, prefix), "/")[0]
+				segment = strings.Split(segment, "#")[0]
+				return strings.ToUpper(segment), t
+			}
+		}
+	}
+
+	if i := strings.Index(name, "objects/"); i >= 0 {
+		segments := strings.Split(name[i+len("objects/"):], "/")
+		if len(segments) >= 2 {
+			return strings.ToUpper(segments[1]), strings.ToUpper(segments[0])
+		}
+	}
+
+	parts := strings.Split(path.Base(name), ".")
+	if len(parts) >= 3 && len(parts[1]) == 4 {
+		return strings.ToUpper(strings.ReplaceAll(parts[0], "#", "/")), strings.ToUpper(parts[1])
+	}
+	return fileName, ""
+}
+
+// ADTObjectURI returns the ADT resource of the object of the finding or an empty string for unsupported object types
+func (f Finding) ADTObjectURI() string {
+	pattern, ok := adtObjectPaths[f.ObjectType]
+	if !ok || len(f.ObjectName) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(pattern, strings.ToLower(strings.ReplaceAll(f.ObjectName, "/", "%2f")))
+}
+
+// ADTLink returns a link to the finding in ABAP Development Tools, systemURL is the URL of the ABAP system
+func (f Finding) ADTLink(systemURL string) string {
+	uri := f.ADTObjectURI()
+	if len(uri) == 0 || len(systemURL) == 0 {
+		return ""
+	}
+	link := strings.TrimSuffix(systemURL, "/") + uri
+	if f.Line > 0 {
+		link += fmt.Sprintf("#start=%d,0", f.Line)
+	}
+	return link
+}
@@ -0,0 +1,117 @@
+//go:build unit
+// +build unit
+
+package abaputils
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseObject(t *testing.T) {
+	tests := []struct {
+		fileName     string
+		expectedName string
+		expectedType string
+	}{
+		{"src/zcl_example.clas.abap", "ZCL_EXAMPLE", "CLAS"},
+		{"src/zcl_example.clas.testclasses.abap", "ZCL_EXAMPLE", "CLAS"},
+		{"src/#dmo#if_example.intf.abap", "/DMO/IF_EXAMPLE", "INTF"},
+		{"/var/jenkins_home/workspace/pipeline//objects/CLAS/ZCL_GCTS/CPRI ZCL_GCTS.abap", "ZCL_GCTS", "CLAS"},
+		{"/sap/bc/adt/oo/classes/zcl_example/source/main", "ZCL_EXAMPLE", "CLAS"},
+		{"/sap/bc/adt/ddic/ddl/sources/zi_example/source/main#start=3,1", "ZI_EXAMPLE", "DDLS"},
+		{"testFile", "testFile", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.fileName, func(t *testing.T) {
+			name, objectType := ParseObject(test.fileName)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedType, objectType)
+		})
+	}
+}
+
+func TestCheckstyleFindings(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+		<checkstyle version="1.0">
+			<file name="src/zcl_example.clas.abap">
+				<error message="Unused variable" source="Extended Program Check" line="12" severity="warning"/>
+				<error message="Missing authority check" source="Security Check" line="" severity="error"/>
+			</file>
+		</checkstyle>`
+	result := Checkstyle{}
+	assert.NoError(t, xml.Unmarshal([]byte(body), &result))
+
+	findings := result.Findings()
+
+	assert.Equal(t, []Finding{
+		{ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", File: "src/zcl_example.clas.abap", Line: 12, Severity: "warning", Rule: "Extended Program Check", Message: "Unused variable"},
+		{ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", File: "src/zcl_example.clas.abap", Line: 0, Severity: "error", Rule: "Security Check", Message: "Missing authority check"},
+	}, findings)
+}
+
+func TestAUnitResultFindings(t *testing.T) {
+	body := `<?xml version="1.0" encoding="utf-8"?><testsuites title="My AUnit run" system="TST" client="100"><testsuite name="" tests="2"><testcase classname="zcl_example" name="execute"><failure message="Assert failed" type="Assert Failure">Expected 1</failure></testcase><testcase classname="zcl_example" name="skip"><skipped message="skipped"/></testcase></testsuite></testsuites>`
+	result := AUnitResult{}
+	assert.NoError(t, xml.Unmarshal([]byte(body), &result))
+
+	findings := result.Findings()
+
+	assert.Equal(t, []Finding{{ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", File: "zcl_example", Severity: "error", Rule: "zcl_example/execute", Message: "Assert failed Expected 1"}}, findings)
+}
+
+func TestADTLink(t *testing.T) {
+	finding := Finding{ObjectName: "/DMO/CL_EXAMPLE", ObjectType: "CLAS", Line: 7}
+
+	assert.Equal(t, "https://example.com/sap/bc/adt/oo/classes/%2fdmo%2fcl_example/source/main#start=7,0", finding.ADTLink("https://example.com/"))
+	assert.Empty(t, finding.ADTLink(""))
+	assert.Empty(t, Finding{ObjectName: "ZUNKNOWN", ObjectType: "XYZ"}.ADTLink("https://example.com"))
+}
+
+func TestBaseline(t *testing.T) {
+	known := Finding{ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", Line: 12, Severity: "warning", Rule: "Extended Program Check", Message: "Unused variable"}
+	baseline := NewBaseline([]Finding{known})
+
+	moved := known
+	moved.Line = 20
+	findings := []Finding{moved, moved, {ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", Severity: "error", Rule: "Security Check", Message: "Missing authority check"}}
+
+	suppressed := baseline.Apply(findings)
+
+	assert.Equal(t, 1, suppressed)
+	assert.True(t, findings[0].Suppressed, "known finding in a different line is suppressed")
+	assert.False(t, findings[1].Suppressed, "second occurrence of a known finding is new")
+	assert.False(t, findings[2].Suppressed)
+}
+
+func TestToSARIF(t *testing.T) {
+	findings := []Finding{
+		{ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", File: "src/zcl_example.clas.abap", Line: 12, Severity: "warning", Rule: "Extended Program Check", Message: "Unused variable", Suppressed: true},
+		{ObjectName: "ZCL_EXAMPLE", ObjectType: "CLAS", File: "src/zcl_example.clas.abap", Line: 3, Severity: "error", Rule: "Security Check", Message: "Missing authority check"},
+		{ObjectName: "ZCL_OTHER", ObjectType: "CLAS", File: "src/zcl_other.clas.abap", Line: 5, Severity: "info", Rule: "Extended Program Check", Message: "Obsolete statement"},
+	}
+
+	sarif := ToSARIF("ABAP Test Cockpit", findings, "https://example.com")
+
+	run := sarif.Runs[0]
+	assert.Equal(t, "ABAP Test Cockpit", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, 2)
+	if assert.Len(t, run.Results, 3) {
+		first := run.Results[0]
+		assert.Equal(t, "warning", first.Level)
+		assert.Equal(t, "src/zcl_example.clas.abap", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 12, first.Locations[0].PhysicalLocation.Region.StartLine)
+		assert.Equal(t, "CLAS/ZCL_EXAMPLE", first.Locations[0].PhysicalLocation.LogicalLocations[0].FullyQualifiedName)
+		assert.Equal(t, "https://example.com/sap/bc/adt/oo/classes/zcl_example/source/main#start=12,0", first.RelatedLocations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, "external", first.Suppressions[0].Kind)
+		assert.Equal(t, findings[0].Fingerprint(), first.PartialFingerprints.AbapFindingHash)
+
+		assert.Equal(t, "error", run.Results[1].Level)
+		assert.Equal(t, 1, run.Results[1].RuleIndex)
+		assert.Empty(t, run.Results[1].Suppressions)
+		assert.Equal(t, "note", run.Results[2].Level)
+		assert.Equal(t, 0, run.Results[2].RuleIndex)
+	}
+}
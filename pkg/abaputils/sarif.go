@@ -0,0 +1,87 @@
+package abaputils
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// ToSARIF converts the findings into SARIF. The object of a finding is added as logical location and,
+// in case systemURL is provided, a link to the object in ABAP Development Tools as related location.
+// Suppressed findings are reported with an external suppression.
+func ToSARIF(toolName string, findings []Finding, systemURL string) format.SARIF {
+	sarif := format.SARIF{
+		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []format.Runs{{Results: []format.Results{}}},
+	}
+	tool := format.Tool{Driver: format.Driver{Name: toolName, InformationUri: "https://www.project-piper.io"}}
+
+	ruleIndices := map[string]int{}
+	for _, f := range findings {
+		ruleID := f.Rule
+		if len(ruleID) == 0 {
+			ruleID = "unknown"
+		}
+		index, known := ruleIndices[ruleID]
+		if !known {
+			index = len(tool.Driver.Rules)
+			ruleIndices[ruleID] = index
+			tool.Driver.Rules = append(tool.Driver.Rules, format.SarifRule{
+				ID:               ruleID,
+				Name:             ruleID,
+				ShortDescription: &format.Message{Text: ruleID},
+			})
+		}
+
+		location := format.Location{PhysicalLocation: format.PhysicalLocation{
+			ArtifactLocation: format.ArtifactLocation{URI: filepath.ToSlash(f.File)},
+			Region:           format.Region{StartLine: f.Line},
+			LogicalLocations: []format.LogicalLocation{{FullyQualifiedName: f.ObjectType + "/" + f.ObjectName, Name: f.ObjectName, Kind: f.ObjectType}},
+		}}
+		result := format.Results{
+			RuleID:              ruleID,
+			RuleIndex:           index,
+			Level:               sarifLevel(f.Severity),
+			Message:             &format.Message{Text: f.Message},
+			Locations:           []format.Location{location},
+			PartialFingerprints: format.PartialFingerprints{AbapFindingHash: f.Fingerprint()},
+			Properties:          &format.SarifProperties{ToolSeverity: f.Severity, UnifiedAuditState: "new"},
+		}
+		if link := f.ADTLink(systemURL); len(link) > 0 {
+			result.RelatedLocations = []format.RelatedLocation{{ID: 1, PhysicalLocation: format.RelatedPhysicalLocation{ArtifactLocation: format.ArtifactLocation{URI: link}}}}
+		}
+		if f.Suppressed {
+			result.Suppressions = []format.Suppression{{Kind: "external", Status: "accepted", Justification: "Finding is contained in the baseline"}}
+			result.Properties.UnifiedAuditState = "notRelevant"
+		}
+		sarif.Runs[0].Results = append(sarif.Runs[0].Results, result)
+	}
+	sarif.Runs[0].Tool = tool
+	return sarif
+}
+
+// WriteSARIF writes the SARIF result as JSON file
+func WriteSARIF(sarif format.SARIF, fileName string, fileUtils piperutils.FileUtils) error {
+	content, err := json.Marshal(sarif)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal SARIF json file")
+	}
+	if err := fileUtils.FileWrite(fileName, content, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write SARIF file %v", fileName)
+	}
+	return nil
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	}
+	return "note"
+}
@@ -86,14 +86,9 @@ func NewBuildClient(organization string, personalAccessToken string, project str
 		return nil, errors.New("error: project must not be empty")
 	}
 
-	organizationUrl := fmt.Sprintf("%s/%s", azureUrl, organization)
-	// Create a connection to your organization
-	connection := azuredevops.NewPatConnection(organizationUrl, personalAccessToken)
-
 	ctx := context.Background()
 
-	// Create a client to interact with the Core area
-	buildClient, err := build.NewClient(ctx, connection)
+	buildClient, err := NewClient(ctx, organization, personalAccessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -107,3 +102,12 @@ func NewBuildClient(organization string, personalAccessToken string, project str
 
 	return buildClientImpl, nil
 }
+
+// NewClient creates a client to interact with the Build area of the organization
+func NewClient(ctx context.Context, organization string, personalAccessToken string) (build.Client, error) {
+	organizationUrl := fmt.Sprintf("%s/%s", azureUrl, organization)
+	// Create a connection to your organization
+	connection := azuredevops.NewPatConnection(organizationUrl, personalAccessToken)
+
+	return build.NewClient(ctx, connection)
+}
@@ -0,0 +1,94 @@
+package changeimpact
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/git"
+	"github.com/pkg/errors"
+)
+
+// Module is a part of the repository which is built on its own, e.g. a Maven module or an npm workspace package
+type Module struct {
+	// ID identifies the module, e.g. groupId:artifactId of a Maven module or the name of an npm package
+	ID string
+	// Dir is the directory of the module relative to the repository root
+	Dir string
+	// Dependencies contains the IDs of the modules the module depends on, IDs of modules outside of the repository are ignored
+	Dependencies []string
+}
+
+// ChangedFiles returns the files changed in the current commit compared to the reference, e.g. the target branch of a pull request
+// or the commit of the last successful build. The working directory needs to be the root of the git repository.
+func ChangedFiles(reference string) ([]string, error) {
+	repo, err := git.PlainOpen(".")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open git repository")
+	}
+	return git.ChangedFiles(repo, reference, "HEAD")
+}
+
+// Affected returns the modules containing one of the changed files as well as all modules depending on them directly or transitively.
+// A changed file belongs to the module with the most specific directory only. The order of the modules is kept.
+func Affected(modules []Module, changedFiles []string) []Module {
+	affected := map[string]bool{}
+	for _, file := range changedFiles {
+		if owner := owningModule(modules, file); owner != nil {
+			affected[owner.ID] = true
+		}
+	}
+
+	dependents := map[string][]string{}
+	for _, m := range modules {
+		for _, dependency := range m.Dependencies {
+			dependents[dependency] = append(dependents[dependency], m.ID)
+		}
+	}
+	queue := []string{}
+	for id := range affected {
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[id] {
+			if !affected[dependent] {
+				affected[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	result := []Module{}
+	for _, m := range modules {
+		if affected[m.ID] {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func owningModule(modules []Module, file string) *Module {
+	file = normalize(file)
+	var owner *Module
+	ownerDepth := -1
+	for i, m := range modules {
+		dir := normalize(m.Dir)
+		if dir != "." && !strings.HasPrefix(file, dir+"/") {
+			continue
+		}
+		depth := 0
+		if dir != "." {
+			depth = strings.Count(dir, "/") + 1
+		}
+		if depth > ownerDepth {
+			owner, ownerDepth = &modules[i], depth
+		}
+	}
+	return owner
+}
+
+func normalize(file string) string {
+	return path.Clean(filepath.ToSlash(file))
+}
@@ -0,0 +1,45 @@
+//go:build unit
+// +build unit
+
+package changeimpact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffected(t *testing.T) {
+	modules := []Module{
+		{ID: "root", Dir: "."},
+		{ID: "ui", Dir: "packages/ui", Dependencies: []string{"utils"}},
+		{ID: "utils", Dir: "packages/utils"},
+		{ID: "app", Dir: "apps/app", Dependencies: []string{"ui", "react"}},
+		{ID: "app-e2e", Dir: "apps/app/e2e", Dependencies: []string{"app"}},
+		{ID: "docs", Dir: "docs"},
+	}
+	ids := func(modules []Module) []string {
+		result := []string{}
+		for _, m := range modules {
+			result = append(result, m.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		changedFiles []string
+		expected     []string
+	}{
+		{"transitive dependents", []string{"packages/utils/index.js"}, []string{"ui", "utils", "app", "app-e2e"}},
+		{"most specific directory", []string{"apps/app/e2e/login.spec.js"}, []string{"app-e2e"}},
+		{"similar directory names", []string{"packages/ui-legacy/index.js"}, []string{"root"}},
+		{"root module", []string{"README.md"}, []string{"root"}},
+		{"no changes", nil, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ids(Affected(modules, test.changedFiles)))
+		})
+	}
+}
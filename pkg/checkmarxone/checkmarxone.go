@@ -305,6 +305,7 @@ type System interface {
 	GetScanResults(scanID string, limit uint64) ([]ScanResult, error)
 	GetScanSummary(scanID string) (ScanSummary, error)
 	GetResultsPredicates(SimilarityID int64, ProjectID string) ([]ResultsPredicates, error)
+	AddResultsPredicates(predicates []ResultsPredicates) error
 	GetScanWorkflow(scanID string) ([]WorkflowLog, error)
 	GetLastScans(projectID string, limit int) ([]Scan, error)
 	GetLastScansByStatus(projectID string, limit int, status []string) ([]Scan, error)
@@ -1312,6 +1313,34 @@ func (sys *SystemInstance) GetResultsPredicates(SimilarityID int64, ProjectID st
 	return Predicates.PredicateHistoryPerProject[0].Predicates, err
 }
 
+// AddResultsPredicates changes the state, severity and comment of SAST results
+func (sys *SystemInstance) AddResultsPredicates(predicates []ResultsPredicates) error {
+	sys.logger.Debugf("Adding %d results predicates", len(predicates))
+
+	type predicate struct {
+		SimilarityID string `json:"similarityId"`
+		ProjectID    string `json:"projectId"`
+		Severity     string `json:"severity"`
+		State        string `json:"state"`
+		Comment      string `json:"comment"`
+	}
+	body := []predicate{}
+	for _, p := range predicates {
+		body = append(body, predicate{SimilarityID: fmt.Sprintf("%d", p.SimilarityID), ProjectID: p.ProjectID, Severity: p.Severity, State: p.State, Comment: p.Comment})
+	}
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	_, err = sendRequest(sys, http.MethodPost, "/sast-results-predicates", bytes.NewReader(jsonBody), nil, []int{})
+	if err != nil {
+		sys.logger.Errorf("Error while adding results predicates: %s", err)
+		return err
+	}
+	return nil
+}
+
 // RequestNewReport triggers the generation of a  report for a specific scan addressed by scanID
 func (sys *SystemInstance) RequestNewReport(scanID, projectID, branch, reportType string) (string, error) {
 	jsonData := map[string]interface{}{
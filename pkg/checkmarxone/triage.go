@@ -0,0 +1,127 @@
+package checkmarxOne
+
+import (
+	"strconv"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/pkg/errors"
+)
+
+// result states of Checkmarx One SAST results
+const (
+	StateToVerify               = "TO_VERIFY"
+	StateNotExploitable         = "NOT_EXPLOITABLE"
+	StateProposedNotExploitable = "PROPOSED_NOT_EXPLOITABLE"
+	StateConfirmed              = "CONFIRMED"
+	StateUrgent                 = "URGENT"
+)
+
+// TriageAuditor synchronizes assessments with the state of the SAST results of a project
+type TriageAuditor struct {
+	sys       System
+	projectID string
+	scanID    string
+	results   map[string]ScanResult
+}
+
+// NewTriageAuditor creates an auditor for the SAST results of the scan of a project
+func NewTriageAuditor(sys System, projectID, scanID string) *TriageAuditor {
+	return &TriageAuditor{sys: sys, projectID: projectID, scanID: scanID}
+}
+
+// Tool returns the tool name of Checkmarx One assessments
+func (t *TriageAuditor) Tool() string {
+	return "checkmarxOne"
+}
+
+// Findings returns the SAST results of the scan identified by their similarity ID, which is stable across scans and projects
+func (t *TriageAuditor) Findings() ([]triage.Finding, error) {
+	limit := uint64(0)
+	if summary, err := t.sys.GetScanSummary(t.scanID); err == nil {
+		limit = summary.TotalCount()
+	}
+	results, err := t.sys.GetScanResults(t.scanID, limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get results of scan %v", t.scanID)
+	}
+
+	t.results = map[string]ScanResult{}
+	findings := []triage.Finding{}
+	for _, result := range results {
+		id := strconv.FormatInt(result.SimilarityID, 10)
+		if _, ok := t.results[id]; ok || result.Type != "sast" {
+			continue
+		}
+		t.results[id] = result
+
+		finding := triage.Finding{ID: id, Assessment: resultAssessment(result.State)}
+		if finding.Assessment != nil {
+			predicates, err := t.sys.GetResultsPredicates(result.SimilarityID, t.projectID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get predicates of result %v", id)
+			}
+			finding.Assessment.Justification = latestComment(predicates)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// Assess adds a predicate with the state of the assessment to the result of the finding
+func (t *TriageAuditor) Assess(finding triage.Finding, assessment format.Assessment) (bool, error) {
+	result, ok := t.results[finding.ID]
+	if !ok {
+		return false, errors.Errorf("result %v is not part of scan %v", finding.ID, t.scanID)
+	}
+	state := resultState(assessment)
+	if state == result.State || (state == StateConfirmed && result.State == StateUrgent) {
+		return false, nil
+	}
+
+	predicate := ResultsPredicates{
+		SimilarityID: result.SimilarityID,
+		ProjectID:    t.projectID,
+		Severity:     result.Severity,
+		State:        state,
+		Comment:      assessment.Justification,
+	}
+	if err := t.sys.AddResultsPredicates([]ResultsPredicates{predicate}); err != nil {
+		return false, errors.Wrapf(err, "failed to set state %v of result %v", state, finding.ID)
+	}
+	return true, nil
+}
+
+func resultAssessment(state string) *format.Assessment {
+	switch state {
+	case StateNotExploitable:
+		return &format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable}
+	case StateProposedNotExploitable:
+		return &format.Assessment{Status: format.InProcess}
+	case StateConfirmed, StateUrgent:
+		return &format.Assessment{Status: format.Relevant}
+	}
+	return nil
+}
+
+func resultState(assessment format.Assessment) string {
+	switch assessment.Status {
+	case format.NotRelevant:
+		return StateNotExploitable
+	case format.InProcess:
+		return StateProposedNotExploitable
+	case format.Relevant:
+		return StateConfirmed
+	}
+	return StateToVerify
+}
+
+func latestComment(predicates []ResultsPredicates) string {
+	latest := ResultsPredicates{}
+	for _, predicate := range predicates {
+		if predicate.Comment != "" && predicate.CreatedAt >= latest.CreatedAt {
+			latest = predicate
+		}
+	}
+	return latest.Comment
+}
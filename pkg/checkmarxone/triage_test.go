@@ -0,0 +1,110 @@
+//go:build unit
+// +build unit
+
+package checkmarxOne
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/stretchr/testify/assert"
+)
+
+// routingSenderMock answers requests by the first response whose key is contained in the called url
+type routingSenderMock struct {
+	senderMock
+	responses map[string]string
+	requests  []string
+}
+
+func (sm *routingSenderMock) SendRequest(method, url string, body io.Reader, header http.Header, cookies []*http.Cookie) (*http.Response, error) {
+	request := method + " " + url
+	if body != nil {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(body)
+		request += " " + buf.String()
+	}
+	sm.requests = append(sm.requests, request)
+	for key, response := range sm.responses {
+		if strings.Contains(url, key) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(response))}, nil
+		}
+	}
+	return &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader(""))}, fmt.Errorf("http error 404")
+}
+
+func newTriageSystem(responses map[string]string) (*SystemInstance, *routingSenderMock) {
+	client := &routingSenderMock{responses: responses}
+	logger := log.Entry().WithField("package", "SAP/jenkins-library/pkg/checkmarxOne_test")
+	return &SystemInstance{serverURL: "https://cx1.server.com", iamURL: "https://cx1iam.server.com", tenant: "tenant", client: client, logger: logger}, client
+}
+
+func TestTriageAuditorFindings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		sys, _ := newTriageSystem(map[string]string{
+			"/scan-summary/": `{"scansSummaries": [{"scanId": "scan1"}], "totalCount": 1}`,
+			"/results/": `{"results": [
+				{"type": "sast", "similarityId": "-101", "state": "TO_VERIFY", "severity": "HIGH"},
+				{"type": "sast", "similarityId": "-101", "state": "TO_VERIFY", "severity": "HIGH"},
+				{"type": "sast", "similarityId": "202", "state": "NOT_EXPLOITABLE", "severity": "MEDIUM"},
+				{"type": "sast", "similarityId": "303", "state": "URGENT", "severity": "HIGH"},
+				{"type": "sca", "similarityId": "404", "state": "NOT_EXPLOITABLE", "severity": "HIGH"}
+			], "totalCount": 5}`,
+			"/sast-results-predicates/202": `{"predicateHistoryPerProject": [{"projectId": "project1", "similarityId": "202", "predicates": [
+				{"state": "TO_VERIFY", "comment": "initial", "createdAt": "2024-01-01T10:00:00Z"},
+				{"state": "NOT_EXPLOITABLE", "comment": "validated by the framework", "createdAt": "2024-02-01T10:00:00Z"}
+			], "totalCount": 2}], "totalCount": 1}`,
+			"/sast-results-predicates/303": `{"predicateHistoryPerProject": [], "totalCount": 0}`,
+		})
+		auditor := NewTriageAuditor(sys, "project1", "scan1")
+
+		findings, err := auditor.Findings()
+		assert.NoError(t, err)
+		assert.Equal(t, []triage.Finding{
+			{ID: "-101"},
+			{ID: "202", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable, Justification: "validated by the framework"}},
+			{ID: "303", Assessment: &format.Assessment{Status: format.Relevant}},
+		}, findings)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		sys, _ := newTriageSystem(map[string]string{})
+		_, err := NewTriageAuditor(sys, "project1", "scan1").Findings()
+		assert.ErrorContains(t, err, "failed to get results of scan scan1")
+	})
+}
+
+func TestTriageAuditorAssess(t *testing.T) {
+	t.Parallel()
+
+	sys, client := newTriageSystem(map[string]string{
+		"/results/":                `{"results": [{"type": "sast", "similarityId": "-101", "state": "TO_VERIFY", "severity": "HIGH"}, {"type": "sast", "similarityId": "303", "state": "URGENT", "severity": "HIGH"}], "totalCount": 2}`,
+		"/sast-results-predicates": `{}`,
+	})
+	auditor := NewTriageAuditor(sys, "project1", "scan1")
+	findings, err := auditor.Findings()
+	assert.NoError(t, err)
+
+	changed, err := auditor.Assess(findings[0], format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive, Justification: "input is validated"})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Contains(t, client.requests, `POST https://cx1.server.com/api/sast-results-predicates [{"similarityId":"-101","projectId":"project1","severity":"HIGH","state":"NOT_EXPLOITABLE","comment":"input is validated"}]`)
+
+	requests := len(client.requests)
+	changed, err = auditor.Assess(findings[1], format.Assessment{Status: format.Relevant})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Len(t, client.requests, requests)
+
+	_, err = auditor.Assess(triage.Finding{ID: "999"}, format.Assessment{Status: format.Relevant})
+	assert.EqualError(t, err, "result 999 is not part of scan scan1")
+}
@@ -0,0 +1,207 @@
+package cloudfoundry
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// Route is an http route of an application consisting of host, domain and an optional path.
+// The host is empty for routes on the domain itself.
+type Route struct {
+	Host   string
+	Domain string
+	Path   string
+}
+
+// ParseRoute splits a route like 'myapp.cfapps.example.com/api' into host, domain and path.
+// The domain is taken from the first dot, use ResolveRoutes to split the route at a domain of the org.
+func ParseRoute(route string) (Route, error) {
+	r := Route{}
+	value := strings.TrimPrefix(strings.TrimPrefix(route, "https://"), "http://")
+	if i := strings.Index(value, "/"); i >= 0 {
+		r.Path = strings.TrimSuffix(value[i:], "/")
+		value = value[:i]
+	}
+	if strings.Contains(value, ":") {
+		return r, fmt.Errorf("Route '%s' is not supported. Only http routes without port can be used", route)
+	}
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return r, fmt.Errorf("Invalid route '%s'. Expected format is 'host.domain[/path]'", route)
+	}
+	r.Host = parts[0]
+	r.Domain = parts[1]
+	return r, nil
+}
+
+// String returns the route in the format used inside manifests
+func (r Route) String() string {
+	return r.hostname() + r.Path
+}
+
+func (r Route) hostname() string {
+	if len(r.Host) == 0 {
+		return r.Domain
+	}
+	return r.Host + "." + r.Domain
+}
+
+// options returns the cf cli arguments identifying the route
+func (r Route) options() []string {
+	options := []string{r.Domain}
+	if len(r.Host) > 0 {
+		options = append(options, "--hostname", r.Host)
+	}
+	if len(r.Path) > 0 {
+		options = append(options, "--path", r.Path)
+	}
+	return options
+}
+
+// GetAppRoutes returns the routes declared for the application with the given index in the manifest
+func GetAppRoutes(m Manifest, index int) ([]Route, error) {
+	routes := []Route{}
+	hasRoutes, err := m.ApplicationHasProperty(index, "routes")
+	if err != nil || !hasRoutes {
+		return routes, err
+	}
+	value, err := m.GetApplicationProperty(index, "routes")
+	if err != nil {
+		return routes, err
+	}
+	entries, ok := value.([]interface{})
+	if !ok {
+		return routes, fmt.Errorf("Routes of application with index %d in manifest '%s' have wrong type", index, m.GetFileName())
+	}
+	for _, entry := range entries {
+		e, ok := entry.(map[string]interface{})
+		if !ok {
+			return routes, fmt.Errorf("Route entry '%v' in manifest '%s' has wrong type", entry, m.GetFileName())
+		}
+		name, ok := e["route"].(string)
+		if !ok {
+			return routes, fmt.Errorf("Route entry '%v' in manifest '%s' does not contain a route", entry, m.GetFileName())
+		}
+		route, err := ParseRoute(name)
+		if err != nil {
+			return routes, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// GetDomains returns the names of the domains available in the targeted org
+func (cf *CFUtils) GetDomains() ([]string, error) {
+	var output bytes.Buffer
+	runner := cf.runner()
+	stdout := runner.GetStdout()
+	runner.Stdout(&output)
+	err := cf.run("domains")
+	runner.Stdout(stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := []string{}
+	headerFound := false
+	for _, line := range strings.Split(output.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if !headerFound {
+			headerFound = fields[0] == "name"
+			continue
+		}
+		domains = append(domains, fields[0])
+	}
+	return domains, nil
+}
+
+// ResolveRoutes splits the routes into host and domain based on the domains of the targeted org.
+// The longest matching domain wins, e.g. 'myapp.apps.example.com' belongs to domain 'apps.example.com' rather than 'example.com'.
+func (cf *CFUtils) ResolveRoutes(routes []Route) ([]Route, error) {
+	domains, err := cf.GetDomains()
+	if err != nil {
+		return nil, err
+	}
+	resolved := []Route{}
+	for _, route := range routes {
+		hostname := route.hostname()
+		domain := ""
+		for _, candidate := range domains {
+			if (hostname == candidate || strings.HasSuffix(hostname, "."+candidate)) && len(candidate) > len(domain) {
+				domain = candidate
+			}
+		}
+		if len(domain) == 0 {
+			return nil, fmt.Errorf("No domain of the org matches route '%s'", route)
+		}
+		resolved = append(resolved, Route{Host: strings.TrimSuffix(strings.TrimSuffix(hostname, domain), "."), Domain: domain, Path: route.Path})
+	}
+	return resolved, nil
+}
+
+// AppExists checks if an application with the given name exists in the targeted space
+func (cf *CFUtils) AppExists(appName string) bool {
+	return cf.runner().RunExecutable("cf", "app", appName, "--guid") == nil
+}
+
+// MapRoute maps the route to the application
+func (cf *CFUtils) MapRoute(appName string, route Route) error {
+	log.Entry().Infof("Mapping route '%s' to app '%s'", route, appName)
+	return cf.run(append([]string{"map-route", appName}, route.options()...)...)
+}
+
+// UnmapRoute removes the route from the application
+func (cf *CFUtils) UnmapRoute(appName string, route Route) error {
+	log.Entry().Infof("Unmapping route '%s' from app '%s'", route, appName)
+	return cf.run(append([]string{"unmap-route", appName}, route.options()...)...)
+}
+
+// DeleteRoute deletes the route from the targeted space
+func (cf *CFUtils) DeleteRoute(route Route) error {
+	log.Entry().Infof("Deleting route '%s'", route)
+	return cf.run(append(append([]string{"delete-route"}, route.options()...), "-f")...)
+}
+
+// StopApp stops the application
+func (cf *CFUtils) StopApp(appName string) error {
+	log.Entry().Infof("Stopping app '%s'", appName)
+	return cf.run("stop", appName)
+}
+
+// DeleteApp deletes the application, with deleteRoutes the routes mapped to the application are deleted as well
+func (cf *CFUtils) DeleteApp(appName string, deleteRoutes bool) error {
+	log.Entry().Infof("Deleting app '%s'", appName)
+	params := []string{"delete", appName}
+	if deleteRoutes {
+		params = append(params, "-r")
+	}
+	return cf.run(append(params, "-f")...)
+}
+
+// RenameApp renames the application
+func (cf *CFUtils) RenameApp(appName, newName string) error {
+	log.Entry().Infof("Renaming app '%s' to '%s'", appName, newName)
+	return cf.run("rename", appName, newName)
+}
+
+func (cf *CFUtils) run(params ...string) error {
+	if err := cf.runner().RunExecutable("cf", params...); err != nil {
+		return fmt.Errorf("Command 'cf %s' failed: %w", strings.Join(params, " "), err)
+	}
+	return nil
+}
+
+func (cf *CFUtils) runner() command.ExecRunner {
+	if cf.Exec == nil {
+		cf.Exec = &command.Command{}
+	}
+	return cf.Exec
+}
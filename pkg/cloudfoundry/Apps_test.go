@@ -0,0 +1,157 @@
+//go:build unit
+// +build unit
+
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoute(t *testing.T) {
+
+	t.Run("route with host and domain", func(t *testing.T) {
+		route, err := ParseRoute("myapp.cfapps.example.com")
+		if assert.NoError(t, err) {
+			assert.Equal(t, Route{Host: "myapp", Domain: "cfapps.example.com"}, route)
+			assert.Equal(t, "myapp.cfapps.example.com", route.String())
+		}
+	})
+
+	t.Run("route with path and scheme", func(t *testing.T) {
+		route, err := ParseRoute("https://myapp.example.com/api/v1/")
+		if assert.NoError(t, err) {
+			assert.Equal(t, Route{Host: "myapp", Domain: "example.com", Path: "/api/v1"}, route)
+			assert.Equal(t, []string{"example.com", "--hostname", "myapp", "--path", "/api/v1"}, route.options())
+		}
+	})
+
+	t.Run("tcp route", func(t *testing.T) {
+		_, err := ParseRoute("tcp.example.com:1024")
+		assert.EqualError(t, err, "Route 'tcp.example.com:1024' is not supported. Only http routes without port can be used")
+	})
+
+	t.Run("route without domain", func(t *testing.T) {
+		_, err := ParseRoute("localhost")
+		assert.EqualError(t, err, "Invalid route 'localhost'. Expected format is 'host.domain[/path]'")
+	})
+}
+
+func TestGetAppRoutes(t *testing.T) {
+
+	_readFile = func(filename string) ([]byte, error) {
+		return []byte(`applications:
+- name: first
+  routes:
+  - route: first.example.com
+  - route: api.example.com/first
+- name: second
+`), nil
+	}
+	defer cleanup()
+
+	manifest, err := ReadManifest("manifest.yml")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("app with routes", func(t *testing.T) {
+		routes, err := GetAppRoutes(manifest, 0)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []Route{
+				{Host: "first", Domain: "example.com"},
+				{Host: "api", Domain: "example.com", Path: "/first"},
+			}, routes)
+		}
+	})
+
+	t.Run("app without routes", func(t *testing.T) {
+		routes, err := GetAppRoutes(manifest, 1)
+		if assert.NoError(t, err) {
+			assert.Empty(t, routes)
+		}
+	})
+}
+
+func TestAppCommands(t *testing.T) {
+
+	m := &mock.ExecMockRunner{}
+	cf := CFUtils{Exec: m}
+	route := Route{Host: "myapp", Domain: "example.com"}
+
+	t.Run("app exists", func(t *testing.T) {
+		defer loginMockCleanup(m)
+		m.ShouldFailOnCommand = map[string]error{"cf app missing --guid": fmt.Errorf("app missing not found")}
+
+		assert.True(t, cf.AppExists("existing"))
+		assert.False(t, cf.AppExists("missing"))
+	})
+
+	t.Run("route and app commands", func(t *testing.T) {
+		defer loginMockCleanup(m)
+
+		assert.NoError(t, cf.MapRoute("myapp", route))
+		assert.NoError(t, cf.UnmapRoute("myapp", route))
+		assert.NoError(t, cf.DeleteRoute(route))
+		assert.NoError(t, cf.StopApp("myapp"))
+		assert.NoError(t, cf.DeleteApp("myapp", true))
+		assert.NoError(t, cf.RenameApp("myapp-new", "myapp"))
+
+		assert.Equal(t, []mock.ExecCall{
+			{Exec: "cf", Params: []string{"map-route", "myapp", "example.com", "--hostname", "myapp"}},
+			{Exec: "cf", Params: []string{"unmap-route", "myapp", "example.com", "--hostname", "myapp"}},
+			{Exec: "cf", Params: []string{"delete-route", "example.com", "--hostname", "myapp", "-f"}},
+			{Exec: "cf", Params: []string{"stop", "myapp"}},
+			{Exec: "cf", Params: []string{"delete", "myapp", "-r", "-f"}},
+			{Exec: "cf", Params: []string{"rename", "myapp-new", "myapp"}},
+		}, m.Calls)
+	})
+
+	t.Run("failing command", func(t *testing.T) {
+		defer loginMockCleanup(m)
+		m.ShouldFailOnCommand = map[string]error{"cf stop myapp": fmt.Errorf("app not running")}
+
+		assert.EqualError(t, cf.StopApp("myapp"), "Command 'cf stop myapp' failed: app not running")
+	})
+}
+
+func TestResolveRoutes(t *testing.T) {
+
+	m := &mock.ExecMockRunner{StdoutReturn: map[string]string{"cf domains": `Getting domains in org myOrg as me...
+
+name                  availability   internal   protocols
+cfapps.example.com    shared                    http
+apps.internal         shared         true       http
+example.com           private                   http
+`}}
+	cf := CFUtils{Exec: m}
+
+	t.Run("longest domain wins", func(t *testing.T) {
+		routes := []Route{
+			{Host: "myapp", Domain: "cfapps.example.com"},
+			{Host: "www", Domain: "example.com", Path: "/docs"},
+			{Host: "example", Domain: "com"},
+		}
+
+		resolved, err := cf.ResolveRoutes(routes)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []Route{
+				{Host: "myapp", Domain: "cfapps.example.com"},
+				{Host: "www", Domain: "example.com", Path: "/docs"},
+				{Domain: "example.com"},
+			}, resolved)
+			assert.Equal(t, "example.com", resolved[2].String())
+			assert.Equal(t, []string{"example.com"}, resolved[2].options())
+		}
+	})
+
+	t.Run("unknown domain", func(t *testing.T) {
+		_, err := cf.ResolveRoutes([]Route{{Host: "myapp", Domain: "other.com"}})
+
+		assert.EqualError(t, err, "No domain of the org matches route 'myapp.other.com'")
+	})
+}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 
 	"github.com/BurntSushi/toml"
+	"github.com/SAP/jenkins-library/pkg/docker"
 	"github.com/buildpacks/lifecycle/platform/files"
 )
 
-const reportFile = "/layers/report.toml"
+const (
+	reportFile   = "/layers/report.toml"
+	analyzedFile = "/layers/analyzed.toml"
+)
 
 func DigestFromReport(utils BuildUtils) (string, error) {
 	report := files.Report{}
@@ -28,3 +32,29 @@ func DigestFromReport(utils BuildUtils) (string, error) {
 
 	return report.Image.Digest, nil
 }
+
+// RunImageFromAnalyzed returns the run image the application image has been built on as recorded by the lifecycle
+func RunImageFromAnalyzed(utils BuildUtils) (docker.BaseImage, error) {
+	analyzed := files.Analyzed{}
+
+	data, err := utils.FileRead(analyzedFile)
+	if err != nil {
+		return docker.BaseImage{}, err
+	}
+
+	err = toml.Unmarshal(data, &analyzed)
+	if err != nil {
+		return docker.BaseImage{}, err
+	}
+
+	if analyzed.RunImage == nil || analyzed.RunImage.Reference == "" {
+		return docker.BaseImage{}, fmt.Errorf("run image is empty")
+	}
+
+	// the reference is resolved to the digest, the image holds the name the run image has been provided with
+	runImage := docker.ParseBaseImage(analyzed.RunImage.Reference)
+	if analyzed.RunImage.Image != "" {
+		runImage.Reference = analyzed.RunImage.Image
+	}
+	return runImage, nil
+}
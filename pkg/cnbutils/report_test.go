@@ -49,3 +49,30 @@ digest = "sha256:52eac630560210e5ae13eb10797c4246d6f02d425f32b9430ca00bde697c79e
 		assert.EqualError(t, err, "toml: line 1: expected '.' or '=', but got '{' instead")
 	})
 }
+
+func TestRunImageFromAnalyzed(t *testing.T) {
+	t.Run("return the run image from the analyzed.toml", func(t *testing.T) {
+		mockUtils := &cnbutils.MockUtils{
+			FilesMock: &mock.FilesMock{},
+		}
+		mockUtils.AddFile("/layers/analyzed.toml", []byte(`[run-image]
+reference = "index.docker.io/paketobuildpacks/run-jammy-base@sha256:52eac630560210e5ae13eb10797c4246d6f02d425f32b9430ca00bde697c79ec"
+image = "paketobuildpacks/run-jammy-base:latest"
+`))
+
+		runImage, err := cnbutils.RunImageFromAnalyzed(mockUtils)
+		assert.NoError(t, err)
+		assert.Equal(t, "paketobuildpacks/run-jammy-base:latest", runImage.Reference)
+		assert.Equal(t, "sha256:52eac630560210e5ae13eb10797c4246d6f02d425f32b9430ca00bde697c79ec", runImage.Digest)
+	})
+
+	t.Run("fails if run image is empty", func(t *testing.T) {
+		mockUtils := &cnbutils.MockUtils{
+			FilesMock: &mock.FilesMock{},
+		}
+		mockUtils.AddFile("/layers/analyzed.toml", []byte(``))
+
+		_, err := cnbutils.RunImageFromAnalyzed(mockUtils)
+		assert.EqualError(t, err, "run image is empty")
+	})
+}
@@ -0,0 +1,51 @@
+package codeql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/stepcache"
+	"github.com/pkg/errors"
+)
+
+// DatabaseCache keeps finalized CodeQL databases in a step cache backend keyed by commit and language,
+// so that re-runs and re-analyses of the same commit skip the extraction.
+type DatabaseCache struct {
+	backend  stepcache.Backend
+	commitID string
+}
+
+// NewDatabaseCache creates a cache for the databases of a commit
+func NewDatabaseCache(backend stepcache.Backend, commitID string) *DatabaseCache {
+	return &DatabaseCache{backend: backend, commitID: commitID}
+}
+
+// Restore replaces the database directory with the cached database of the language and reports whether one was cached
+func (c *DatabaseCache) Restore(language, database string) (bool, error) {
+	cache := stepcache.New(c.backend, c.key(language))
+	found, err := cache.Lookup()
+	if err != nil || !found {
+		return false, err
+	}
+	if err := os.RemoveAll(database); err != nil {
+		return false, errors.Wrapf(err, "failed to remove database %s", database)
+	}
+	if err := cache.Restore(database); err != nil {
+		return false, err
+	}
+	if exists, _ := piperutils.FileExists(filepath.Join(database, CodeqlDatabaseYml)); !exists {
+		return false, fmt.Errorf("cached database of %s is incomplete, %s is missing", language, CodeqlDatabaseYml)
+	}
+	return true, nil
+}
+
+// Store records the finalized database of the language, the logs of the database are not recorded
+func (c *DatabaseCache) Store(language, database string) error {
+	return stepcache.New(c.backend, c.key(language)).Store(database, []string{"**"}, []string{"log/**"})
+}
+
+func (c *DatabaseCache) key(language string) string {
+	return fmt.Sprintf("codeql-database-%s-%s", c.commitID, language)
+}
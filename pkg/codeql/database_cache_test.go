@@ -0,0 +1,59 @@
+//go:build unit
+// +build unit
+
+package codeql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/stepcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	backend := &stepcache.DirectoryBackend{Path: filepath.Join(dir, "cache")}
+	database := filepath.Join(dir, "codeqlDB", "java")
+	require.NoError(t, os.MkdirAll(filepath.Join(database, "db-java"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(database, "log"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(database, CodeqlDatabaseYml), []byte("primaryLanguage: java"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(database, "db-java", "default.dbscheme"), []byte("scheme"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(database, "log", "database-create.log"), []byte("log"), 0o644))
+
+	cache := NewDatabaseCache(backend, "a1b2c3")
+	found, err := cache.Restore("java", database)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, cache.Store("java", database))
+	assert.FileExists(t, filepath.Join(dir, "cache", "codeql-database-a1b2c3-java.tar.gz"))
+
+	t.Run("restore", func(t *testing.T) {
+		restored := filepath.Join(dir, "restored")
+		require.NoError(t, os.MkdirAll(restored, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(restored, "stale"), []byte("stale"), 0o644))
+
+		found, err := cache.Restore("java", restored)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.FileExists(t, filepath.Join(restored, CodeqlDatabaseYml))
+		assert.FileExists(t, filepath.Join(restored, "db-java", "default.dbscheme"))
+		assert.NoFileExists(t, filepath.Join(restored, "log", "database-create.log"))
+		assert.NoFileExists(t, filepath.Join(restored, "stale"))
+	})
+
+	t.Run("other commit and language", func(t *testing.T) {
+		found, err := NewDatabaseCache(backend, "d4e5f6").Restore("java", filepath.Join(dir, "other"))
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		found, err = cache.Restore("go", filepath.Join(dir, "other"))
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
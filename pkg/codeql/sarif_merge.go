@@ -0,0 +1,66 @@
+package codeql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// SarifReport references the SARIF report of the analysis of one language
+type SarifReport struct {
+	Path string
+	// Category distinguishes the results of the analysis in code scanning, e.g. /language:java
+	Category string
+}
+
+// LanguageCategory returns the SARIF category of a language as used by the CodeQL action
+func LanguageCategory(language string) string {
+	return "/language:" + language
+}
+
+// MergeSarifReports combines the runs of several SARIF reports into one report which can be uploaded at once.
+// Runs keep the automation details of their analysis, runs without automation details get the category of their report,
+// so that code scanning keeps the results of the different analyses apart.
+func MergeSarifReports(reports []SarifReport, target string, utils piperutils.FileUtils) error {
+	if len(reports) == 0 {
+		return fmt.Errorf("no SARIF reports to merge")
+	}
+
+	var merged map[string]interface{}
+	runs := []interface{}{}
+	for _, report := range reports {
+		content, err := utils.FileRead(report.Path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read SARIF report %s", report.Path)
+		}
+		sarif := map[string]interface{}{}
+		if err := json.Unmarshal(content, &sarif); err != nil {
+			return errors.Wrapf(err, "failed to parse SARIF report %s", report.Path)
+		}
+		reportRuns, _ := sarif["runs"].([]interface{})
+		for _, run := range reportRuns {
+			if details, ok := run.(map[string]interface{}); ok && len(report.Category) > 0 {
+				if _, exists := details["automationDetails"]; !exists {
+					details["automationDetails"] = map[string]interface{}{"id": strings.TrimSuffix(report.Category, "/") + "/"}
+				}
+			}
+			runs = append(runs, run)
+		}
+		if merged == nil {
+			merged = sarif
+		}
+	}
+	merged["runs"] = runs
+
+	content, err := json.Marshal(merged)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal merged SARIF report")
+	}
+	if err := utils.FileWrite(target, content, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write SARIF report %s", target)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package codeql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSarifReports(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		utils := &mock.FilesMock{}
+		utils.AddFile("target/codeqlReport-java.sarif", []byte(`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "CodeQL"}}, "results": [{"ruleId": "java/sql-injection"}], "automationDetails": {"id": "/language:java/"}}]}`))
+		utils.AddFile("target/codeqlReport-go.sarif", []byte(`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "CodeQL"}}, "results": []}]}`))
+
+		err := MergeSarifReports([]SarifReport{
+			{Path: "target/codeqlReport-java.sarif", Category: LanguageCategory("java")},
+			{Path: "target/codeqlReport-go.sarif", Category: LanguageCategory("go")},
+		}, "target/codeqlReport.sarif", utils)
+		assert.NoError(t, err)
+
+		content, err := utils.FileRead("target/codeqlReport.sarif")
+		require.NoError(t, err)
+		merged := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(content, &merged))
+		assert.Equal(t, "2.1.0", merged["version"])
+		runs := merged["runs"].([]interface{})
+		assert.Len(t, runs, 2)
+		assert.Equal(t, map[string]interface{}{"id": "/language:java/"}, runs[0].(map[string]interface{})["automationDetails"])
+		assert.Equal(t, map[string]interface{}{"id": "/language:go/"}, runs[1].(map[string]interface{})["automationDetails"])
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		utils := &mock.FilesMock{}
+		utils.AddFile("target/codeqlReport-java.sarif", []byte(`not json`))
+
+		err := MergeSarifReports([]SarifReport{{Path: "target/codeqlReport-java.sarif"}}, "target/codeqlReport.sarif", utils)
+		assert.ErrorContains(t, err, "failed to parse SARIF report target/codeqlReport-java.sarif")
+	})
+
+	t.Run("missing report", func(t *testing.T) {
+		err := MergeSarifReports([]SarifReport{{Path: "target/codeqlReport-java.sarif"}}, "target/codeqlReport.sarif", &mock.FilesMock{})
+		assert.ErrorContains(t, err, "failed to read SARIF report target/codeqlReport-java.sarif")
+
+		err = MergeSarifReports(nil, "target/codeqlReport.sarif", &mock.FilesMock{})
+		assert.EqualError(t, err, "no SARIF reports to merge")
+	})
+}
@@ -0,0 +1,150 @@
+package codeql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+)
+
+// dismissal reasons of GitHub code scanning alerts
+const (
+	dismissedFalsePositive = "false positive"
+	dismissedWontFix       = "won't fix"
+	dismissedUsedInTests   = "used in tests"
+)
+
+// githubRequestService sends requests for code scanning endpoints which are not covered by the GitHub client
+type githubRequestService interface {
+	NewRequest(method, urlStr string, body interface{}) (*http.Request, error)
+	Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error)
+}
+
+type codeqlAlert struct {
+	Number           int     `json:"number"`
+	State            string  `json:"state"`
+	DismissedReason  *string `json:"dismissed_reason,omitempty"`
+	DismissedComment *string `json:"dismissed_comment,omitempty"`
+}
+
+type codeqlAlertUpdate struct {
+	State            string `json:"state"`
+	DismissedReason  string `json:"dismissed_reason,omitempty"`
+	DismissedComment string `json:"dismissed_comment,omitempty"`
+}
+
+// TriageAuditor synchronizes assessments with the dismissals of CodeQL alerts
+type TriageAuditor struct {
+	ctx         context.Context
+	client      githubRequestService
+	owner       string
+	repository  string
+	analyzedRef string
+}
+
+// NewTriageAuditor creates an auditor for the CodeQL alerts of the analyzed ref
+func NewTriageAuditor(serverUrl, owner, repository, token, analyzedRef string, trustedCerts []string) (*TriageAuditor, error) {
+	ctx, client, err := piperGithub.
+		NewClientBuilder(token, getApiUrl(serverUrl)).
+		WithTrustedCerts(trustedCerts).Build()
+	if err != nil {
+		return nil, err
+	}
+	return &TriageAuditor{ctx: ctx, client: client, owner: owner, repository: repository, analyzedRef: analyzedRef}, nil
+}
+
+// Tool returns the tool name of CodeQL assessments
+func (t *TriageAuditor) Tool() string {
+	return "codeql"
+}
+
+// Findings returns the open and dismissed CodeQL alerts identified by their alert number
+func (t *TriageAuditor) Findings() ([]triage.Finding, error) {
+	findings := []triage.Finding{}
+	for page := 1; page != 0; {
+		query := url.Values{
+			"tool_name": {codeqlToolName},
+			"ref":       {t.analyzedRef},
+			"per_page":  {strconv.Itoa(perPageCount)},
+			"page":      {strconv.Itoa(page)},
+		}
+		path := fmt.Sprintf("repos/%v/%v/code-scanning/alerts?%v", t.owner, t.repository, query.Encode())
+		request, err := t.client.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		alerts := []codeqlAlert{}
+		response, err := t.client.Do(t.ctx, request, &alerts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list code scanning alerts")
+		}
+		page = response.NextPage
+
+		for _, alert := range alerts {
+			if alert.State != auditStateOpen && alert.State != auditStateDismissed {
+				continue
+			}
+			findings = append(findings, triage.Finding{ID: strconv.Itoa(alert.Number), Assessment: alertAssessment(alert)})
+		}
+	}
+	return findings, nil
+}
+
+// Assess dismisses or reopens the alert of the finding
+func (t *TriageAuditor) Assess(finding triage.Finding, assessment format.Assessment) (bool, error) {
+	update := alertUpdate(assessment)
+	if finding.Assessment == nil && update.State == auditStateOpen {
+		return false, nil
+	}
+	if finding.Assessment != nil && update == alertUpdate(*finding.Assessment) {
+		return false, nil
+	}
+
+	request, err := t.client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%v/%v/code-scanning/alerts/%v", t.owner, t.repository, finding.ID), update)
+	if err != nil {
+		return false, err
+	}
+	if _, err := t.client.Do(t.ctx, request, nil); err != nil {
+		return false, errors.Wrapf(err, "failed to update code scanning alert %v", finding.ID)
+	}
+	return true, nil
+}
+
+func alertAssessment(alert codeqlAlert) *format.Assessment {
+	if alert.State != auditStateDismissed {
+		return nil
+	}
+	assessment := format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive}
+	if alert.DismissedReason != nil {
+		switch *alert.DismissedReason {
+		case dismissedWontFix:
+			assessment.Status = format.Relevant
+			assessment.Analysis = format.RiskAccepted
+		case dismissedUsedInTests:
+			assessment.Analysis = format.NotUsed
+		}
+	}
+	if alert.DismissedComment != nil {
+		assessment.Justification = *alert.DismissedComment
+	}
+	return &assessment
+}
+
+func alertUpdate(assessment format.Assessment) codeqlAlertUpdate {
+	switch {
+	case assessment.Status == format.NotRelevant && assessment.Analysis == format.NotUsed:
+		return codeqlAlertUpdate{State: auditStateDismissed, DismissedReason: dismissedUsedInTests, DismissedComment: assessment.Justification}
+	case assessment.Status == format.NotRelevant:
+		return codeqlAlertUpdate{State: auditStateDismissed, DismissedReason: dismissedFalsePositive, DismissedComment: assessment.Justification}
+	case assessment.Status == format.Relevant && assessment.Analysis == format.RiskAccepted:
+		return codeqlAlertUpdate{State: auditStateDismissed, DismissedReason: dismissedWontFix, DismissedComment: assessment.Justification}
+	}
+	return codeqlAlertUpdate{State: auditStateOpen}
+}
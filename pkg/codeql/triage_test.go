@@ -0,0 +1,125 @@
+//go:build unit
+// +build unit
+
+package codeql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/google/go-github/v45/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTriageAuditorMock(t *testing.T, handler http.HandlerFunc) *TriageAuditor {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+	return &TriageAuditor{ctx: context.Background(), client: client, owner: "owner", repository: "repo", analyzedRef: "refs/heads/main"}
+}
+
+func TestTriageAuditorFindings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var query url.Values
+		auditor := newTriageAuditorMock(t, func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			assert.Equal(t, "/repos/owner/repo/code-scanning/alerts", r.URL.Path)
+			w.Write([]byte(`[
+				{"number": 1, "state": "open"},
+				{"number": 2, "state": "dismissed", "dismissed_reason": "false positive", "dismissed_comment": "sanitized"},
+				{"number": 3, "state": "dismissed", "dismissed_reason": "won't fix"},
+				{"number": 4, "state": "dismissed", "dismissed_reason": "used in tests"},
+				{"number": 5, "state": "fixed"}
+			]`))
+		})
+
+		findings, err := auditor.Findings()
+		assert.NoError(t, err)
+		assert.Equal(t, "CodeQL", query.Get("tool_name"))
+		assert.Equal(t, "refs/heads/main", query.Get("ref"))
+		assert.Equal(t, []triage.Finding{
+			{ID: "1"},
+			{ID: "2", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive, Justification: "sanitized"}},
+			{ID: "3", Assessment: &format.Assessment{Status: format.Relevant, Analysis: format.RiskAccepted}},
+			{ID: "4", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.NotUsed}},
+		}, findings)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		auditor := newTriageAuditorMock(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+		_, err := auditor.Findings()
+		assert.ErrorContains(t, err, "failed to list code scanning alerts")
+	})
+}
+
+func TestTriageAuditorAssess(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dismiss open alert", func(t *testing.T) {
+		var update map[string]string
+		auditor := newTriageAuditorMock(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/repos/owner/repo/code-scanning/alerts/1", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &update))
+			w.Write([]byte(`{}`))
+		})
+
+		changed, err := auditor.Assess(triage.Finding{ID: "1"}, format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable, Justification: "input is a constant"})
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]string{"state": "dismissed", "dismissed_reason": "false positive", "dismissed_comment": "input is a constant"}, update)
+	})
+
+	t.Run("reopen dismissed alert", func(t *testing.T) {
+		var update map[string]string
+		auditor := newTriageAuditorMock(t, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &update))
+			w.Write([]byte(`{}`))
+		})
+
+		changed, err := auditor.Assess(triage.Finding{ID: "2", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive}}, format.Assessment{Status: format.Relevant})
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]string{"state": "open"}, update)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		auditor := newTriageAuditorMock(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL)
+		})
+
+		changed, err := auditor.Assess(triage.Finding{ID: "1"}, format.Assessment{Status: format.InProcess})
+		assert.NoError(t, err)
+		assert.False(t, changed)
+
+		changed, err = auditor.Assess(triage.Finding{ID: "3", Assessment: &format.Assessment{Status: format.Relevant, Analysis: format.RiskAccepted, Justification: "legacy"}},
+			format.Assessment{Status: format.Relevant, Analysis: format.RiskAccepted, Justification: "legacy"})
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		auditor := newTriageAuditorMock(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+		_, err := auditor.Assess(triage.Finding{ID: "1"}, format.Assessment{Status: format.NotRelevant})
+		assert.ErrorContains(t, err, "failed to update code scanning alert 1")
+	})
+}
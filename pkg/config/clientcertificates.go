@@ -0,0 +1,126 @@
+package config
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/vault"
+	"github.com/pkg/errors"
+)
+
+const clientCertificatesKey = "clientCertificates"
+
+// ClientCertificate configures the certificate HTTP clients present to a host which requires mutual TLS.
+// The certificate is loaded from a PKCS#12 archive, from PEM files or is issued by a Vault PKI role.
+type ClientCertificate struct {
+	Host            string `json:"host"`
+	Pkcs12File      string `json:"pkcs12File,omitempty"`
+	Pkcs12Password  string `json:"pkcs12Password,omitempty"`
+	CertificateFile string `json:"certificateFile,omitempty"`
+	KeyFile         string `json:"keyFile,omitempty"`
+	VaultPkiPath    string `json:"vaultPkiPath,omitempty"`
+	CommonName      string `json:"commonName,omitempty"`
+	TTL             string `json:"ttl,omitempty"`
+}
+
+func (s *StepConfig) mixinClientCertificateConfig(configs ...map[string]interface{}) {
+	for _, config := range configs {
+		s.mixIn(config, []string{clientCertificatesKey}, StepData{})
+	}
+}
+
+// resolveClientCertificates loads the configured client certificates and registers them for all HTTP clients of the step
+func resolveClientCertificates(config map[string]interface{}, client VaultClient) error {
+	if config[clientCertificatesKey] == nil {
+		return nil
+	}
+	certificates, err := getClientCertificates(config)
+	if err != nil {
+		return err
+	}
+	for _, certificate := range certificates {
+		tlsCertificate, err := loadClientCertificate(certificate, client)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load client certificate for host '%v'", certificate.Host)
+		}
+		log.Entry().Debugf("Using client certificate for host '%v'", certificate.Host)
+		piperhttp.RegisterClientCertificate(certificate.Host, tlsCertificate)
+	}
+	return nil
+}
+
+func getClientCertificates(config map[string]interface{}) ([]ClientCertificate, error) {
+	var certificates []ClientCertificate
+	raw, err := json.Marshal(config[clientCertificatesKey])
+	if err == nil {
+		err = json.Unmarshal(raw, &certificates)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid configuration of '%v'", clientCertificatesKey)
+	}
+	for _, certificate := range certificates {
+		if certificate.Host == "" {
+			return nil, fmt.Errorf("invalid configuration of '%v': host is missing", clientCertificatesKey)
+		}
+	}
+	return certificates, nil
+}
+
+func loadClientCertificate(certificate ClientCertificate, client VaultClient) (tls.Certificate, error) {
+	switch {
+	case certificate.Pkcs12File != "":
+		log.RegisterSecret(certificate.Pkcs12Password)
+		data, err := os.ReadFile(certificate.Pkcs12File)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		return piperhttp.LoadPKCS12Certificate(data, certificate.Pkcs12Password)
+	case certificate.CertificateFile != "" && certificate.KeyFile != "":
+		certificates, err := os.ReadFile(certificate.CertificateFile)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		key, err := os.ReadFile(certificate.KeyFile)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		return piperhttp.LoadPEMCertificate(certificates, key)
+	case certificate.VaultPkiPath != "":
+		if client == nil {
+			return tls.Certificate{}, errors.New("Vault is not configured")
+		}
+		return issueVaultClientCertificate(certificate, client)
+	}
+	return tls.Certificate{}, errors.New("neither pkcs12File, certificateFile and keyFile nor vaultPkiPath are configured")
+}
+
+// issueVaultClientCertificate requests a certificate from a Vault PKI role, the private key is only kept in memory
+func issueVaultClientCertificate(certificate ClientCertificate, client VaultClient) (tls.Certificate, error) {
+	commonName := certificate.CommonName
+	if commonName == "" {
+		commonName = certificate.Host
+	}
+	data := map[string]interface{}{"common_name": commonName}
+	if certificate.TTL != "" {
+		data["ttl"] = certificate.TTL
+	}
+	secret, err := client.GetDynamicSecret(certificate.VaultPkiPath, data)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	log.RegisterSecret(secret.Data["private_key"])
+	if vaultLeaseManager == nil {
+		vaultLeaseManager = vault.NewLeaseManager(client)
+	}
+	vaultLeaseManager.Track(secret)
+
+	chain := secret.Data["certificate"]
+	if issuingCA := secret.Data["issuing_ca"]; issuingCA != "" {
+		chain += "\n" + issuingCA
+	}
+	return piperhttp.LoadPEMCertificate([]byte(chain), []byte(secret.Data["private_key"]))
+}
@@ -0,0 +1,102 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config/mocks"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateClientCertificate(t *testing.T) (pemCert, pemKey []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "piper"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+}
+
+func TestResolveClientCertificates(t *testing.T) {
+	pemCert, pemKey := generateClientCertificate(t)
+
+	t.Run("PEM files", func(t *testing.T) {
+		defer piperhttp.ResetClientCertificates()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "client.crt"), pemCert, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "client.key"), pemKey, 0600))
+		config := map[string]interface{}{clientCertificatesKey: []interface{}{
+			map[string]interface{}{
+				"host":            "*.mesh.example.com",
+				"certificateFile": filepath.Join(dir, "client.crt"),
+				"keyFile":         filepath.Join(dir, "client.key"),
+			},
+		}}
+
+		assert.NoError(t, resolveClientCertificates(config, nil))
+	})
+
+	t.Run("Vault PKI", func(t *testing.T) {
+		defer piperhttp.ResetClientCertificates()
+		vaultMock := &mocks.VaultClient{}
+		vaultMock.On("GetDynamicSecret", "pki/issue/pipeline", map[string]interface{}{"common_name": "api.example.com", "ttl": "1h"}).
+			Return(&vault.DynamicSecret{Data: map[string]string{"certificate": string(pemCert), "private_key": string(pemKey)}}, nil)
+		config := map[string]interface{}{clientCertificatesKey: []interface{}{
+			map[string]interface{}{"host": "api.example.com", "vaultPkiPath": "pki/issue/pipeline", "ttl": "1h"},
+		}}
+
+		assert.NoError(t, resolveClientCertificates(config, vaultMock))
+		vaultMock.AssertExpectations(t)
+		RevokeVaultLeases()
+	})
+
+	t.Run("Vault not configured", func(t *testing.T) {
+		config := map[string]interface{}{clientCertificatesKey: []interface{}{
+			map[string]interface{}{"host": "api.example.com", "vaultPkiPath": "pki/issue/pipeline"},
+		}}
+
+		err := resolveClientCertificates(config, nil)
+		assert.EqualError(t, err, "failed to load client certificate for host 'api.example.com': Vault is not configured")
+	})
+
+	t.Run("missing host", func(t *testing.T) {
+		config := map[string]interface{}{clientCertificatesKey: []interface{}{
+			map[string]interface{}{"pkcs12File": "client.p12"},
+		}}
+
+		err := resolveClientCertificates(config, nil)
+		assert.EqualError(t, err, "invalid configuration of 'clientCertificates': host is missing")
+	})
+
+	t.Run("no certificate source", func(t *testing.T) {
+		config := map[string]interface{}{clientCertificatesKey: []interface{}{
+			map[string]interface{}{"host": "api.example.com", "certificateFile": "client.crt"},
+		}}
+
+		err := resolveClientCertificates(config, nil)
+		assert.EqualError(t, err, "failed to load client certificate for host 'api.example.com': neither pkcs12File, certificateFile and keyFile nor vaultPkiPath are configured")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/SAP/jenkins-library/pkg/config/interpolation"
 	"github.com/SAP/jenkins-library/pkg/trustengine"
 
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
@@ -23,17 +24,18 @@ import (
 
 // Config defines the structure of the config files
 type Config struct {
-	CustomDefaults           []string                          `json:"customDefaults,omitempty"`
-	General                  map[string]interface{}            `json:"general"`
-	Stages                   map[string]map[string]interface{} `json:"stages"`
-	Steps                    map[string]map[string]interface{} `json:"steps"`
-	Hooks                    map[string]interface{}            `json:"hooks,omitempty"`
-	defaults                 PipelineDefaults
-	initialized              bool
-	accessTokens             map[string]string
-	openFile                 func(s string, t map[string]string) (io.ReadCloser, error)
-	vaultCredentials         VaultCredentials
-	trustEngineConfiguration trustengine.Configuration
+	CustomDefaults               []string                          `json:"customDefaults,omitempty"`
+	General                      map[string]interface{}            `json:"general"`
+	Stages                       map[string]map[string]interface{} `json:"stages"`
+	Steps                        map[string]map[string]interface{} `json:"steps"`
+	Hooks                        map[string]interface{}            `json:"hooks,omitempty"`
+	defaults                     PipelineDefaults
+	initialized                  bool
+	accessTokens                 map[string]string
+	openFile                     func(s string, t map[string]string) (io.ReadCloser, error)
+	vaultCredentials             VaultCredentials
+	trustEngineConfiguration     trustengine.Configuration
+	interpolationContextProvider func() interpolation.Context
 }
 
 // StepConfig defines the structure for merged step configuration
@@ -206,6 +208,7 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		}
 		reportingConfig.ApplyAliasConfig(ReportingParameters.Parameters, []StepSecrets{}, ReportingParameters.getStepFilters(), stageName, stepName, []Alias{})
 		stepConfig.mixinReportingConfig(reportingConfig.General, reportingConfig.Steps[stepName], reportingConfig.Stages[stageName])
+		stepConfig.mixinClientCertificateConfig(def.General)
 
 		stepConfig.mixInHookConfig(def.Hooks, metadata)
 	}
@@ -271,11 +274,13 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 	}
 	reportingConfig.ApplyAliasConfig(ReportingParameters.Parameters, []StepSecrets{}, ReportingParameters.getStepFilters(), stageName, stepName, []Alias{})
 	stepConfig.mixinReportingConfig(reportingConfig.General, reportingConfig.Steps[stepName], reportingConfig.Stages[stageName])
+	stepConfig.mixinClientCertificateConfig(c.General)
 
 	// check whether vault should be skipped
+	var vaultClient VaultClient
 	if skip, ok := stepConfig.Config["skipVault"].(bool); !ok || !skip {
 		// Revocation of Vault token will happen at the of each step execution (see _generated.go part)
-		vaultClient, err := GetVaultClientFromConfig(stepConfig.Config, c.vaultCredentials)
+		vaultClient, err = GetVaultClientFromConfig(stepConfig.Config, c.vaultCredentials)
 		if err != nil {
 			return StepConfig{}, err
 		}
@@ -295,6 +300,15 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		resolveAllTrustEngineReferences(&stepConfig, append(parameters, ReportingParameters.Parameters...), c.trustEngineConfiguration, trustengineClient)
 	}
 
+	// derive values from each other once all sources have been merged
+	if err := stepConfig.resolveExpressions(c.interpolationContextProvider, c.General, c.Steps[stepName], c.Stages[stageName]); err != nil {
+		return StepConfig{}, err
+	}
+
+	if err := resolveClientCertificates(stepConfig.Config, vaultClient); err != nil {
+		return StepConfig{}, err
+	}
+
 	// finally do the condition evaluation post processing
 	for _, p := range parameters {
 		if len(p.Conditions) > 0 {
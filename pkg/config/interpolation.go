@@ -0,0 +1,35 @@
+package config
+
+import (
+	"github.com/SAP/jenkins-library/pkg/config/interpolation"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+const resolveConfigExpressions = "resolveConfigExpressions"
+
+// SetInterpolationContextProvider sets the provider of the data sources which are available to expressions in configuration values.
+// The provider is only called if expressions are enabled via resolveConfigExpressions.
+func (c *Config) SetInterpolationContextProvider(provider func() interpolation.Context) {
+	c.interpolationContextProvider = provider
+}
+
+// resolveExpressions interpolates all string values of the step configuration if enabled via resolveConfigExpressions.
+// Values may refer to each other, to the environment, to the common pipeline environment and to the pipeline run.
+func (s *StepConfig) resolveExpressions(contextProvider func() interpolation.Context, configs ...map[string]interface{}) error {
+	for _, config := range configs {
+		s.mixIn(config, []string{resolveConfigExpressions}, StepData{})
+	}
+	if enabled, _ := s.Config[resolveConfigExpressions].(bool); !enabled {
+		return nil
+	}
+	log.Entry().Debug("Resolving expressions in step configuration")
+	ctx := interpolation.Context{}
+	if contextProvider != nil {
+		ctx = contextProvider()
+	}
+	if err := interpolation.ResolveMapWithContext(s.Config, ctx); err != nil {
+		return errors.Wrap(err, "failed to resolve expressions in step configuration")
+	}
+	return nil
+}
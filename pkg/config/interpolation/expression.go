@@ -0,0 +1,447 @@
+package interpolation
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+)
+
+// Context holds the data sources an expression can refer to in addition to the interpolated map itself.
+//
+// Supported expressions inside of $( ... ):
+//
+//	key                          value of key in the lookup map
+//	key:-fallback                fallback if key is missing or empty, fallback may be quoted or contain $( ... )
+//	env:NAME                     environment variable NAME
+//	cpe:path                     common pipeline environment value, e.g. cpe:artifactVersion
+//	git:name, custom:name        same as the git and cpecustom functions of piperenv.CPEMap.ParseTemplate
+//	pipeline:branch              branch, orchestrator or stage of the current pipeline run
+//	lower(x), upper(x)           case conversion
+//	replace(x, "old", "new")     replaces all occurrences of old
+//	trimPrefix(x, "p"), trimSuffix(x, "s")
+//	imageDigest("name"), imageTag("name")
+//	eq(a, b), ne(a, b), hasPrefix(a, "p"), matches(a, "release/*")
+//	if(condition, then, else)
+type Context struct {
+	// LookupEnv is used for env references, os.LookupEnv is used if not set
+	LookupEnv func(string) (string, bool)
+	// CPE is used for cpe, git and custom references
+	CPE piperenv.CPEMap
+	// Branch, Orchestrator and StageName are used for pipeline references
+	Branch       string
+	Orchestrator string
+	StageName    string
+}
+
+// UnresolvedError is returned when an expression references a value which is not available.
+type UnresolvedError struct {
+	Reference string
+}
+
+func (e *UnresolvedError) Error() string {
+	return fmt.Sprintf("unresolved reference '%s'", e.Reference)
+}
+
+type node interface {
+	eval(r *resolver) (string, error)
+}
+
+type literal struct {
+	value string
+}
+
+type reference struct {
+	scope string
+	name  string
+}
+
+type call struct {
+	function string
+	args     []node
+}
+
+type fallback struct {
+	operand node
+	// raw holds the unquoted fallback text which may again contain expressions
+	raw    string
+	quoted bool
+}
+
+type resolver struct {
+	lookupMap map[string]interface{}
+	ctx       Context
+	depth     int
+}
+
+func (l literal) eval(r *resolver) (string, error) {
+	return l.value, nil
+}
+
+func (ref reference) eval(r *resolver) (string, error) {
+	switch ref.scope {
+	case "":
+		value, ok := r.lookupMap[ref.name]
+		if !ok || value == nil {
+			return "", &UnresolvedError{Reference: ref.name}
+		}
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Sprint(value), nil
+		}
+		// values taken from the map may contain further expressions
+		return r.nested().resolve(str)
+	case "env":
+		lookupEnv := r.ctx.LookupEnv
+		if lookupEnv == nil {
+			lookupEnv = os.LookupEnv
+		}
+		if value, ok := lookupEnv(ref.name); ok {
+			return value, nil
+		}
+	case "cpe", "git":
+		if value, ok := r.ctx.CPE.LookupTemplateValue(ref.scope, ref.name); ok {
+			return value, nil
+		}
+	case "custom":
+		if value, ok := r.ctx.CPE.LookupTemplateValue("cpecustom", ref.name); ok {
+			return value, nil
+		}
+	case "pipeline":
+		var value string
+		switch ref.name {
+		case "branch":
+			value = r.ctx.Branch
+		case "orchestrator":
+			value = r.ctx.Orchestrator
+		case "stage":
+			value = r.ctx.StageName
+		default:
+			return "", fmt.Errorf("unknown pipeline property '%s', supported are branch, orchestrator and stage", ref.name)
+		}
+		if len(value) > 0 {
+			return value, nil
+		}
+	default:
+		return "", fmt.Errorf("unknown reference scope '%s'", ref.scope)
+	}
+	return "", &UnresolvedError{Reference: fmt.Sprintf("%s:%s", ref.scope, ref.name)}
+}
+
+func (f fallback) eval(r *resolver) (string, error) {
+	value, err := f.operand.eval(r)
+	if _, unresolved := err.(*UnresolvedError); err != nil && !unresolved {
+		return "", err
+	}
+	if err == nil && len(value) > 0 {
+		return value, nil
+	}
+	if f.quoted {
+		return f.raw, nil
+	}
+	return r.resolve(f.raw)
+}
+
+func (c call) eval(r *resolver) (string, error) {
+	if c.function == "if" {
+		if len(c.args) != 3 {
+			return "", fmt.Errorf("function 'if' expects 3 arguments but got %d", len(c.args))
+		}
+		condition, err := c.args[0].eval(r)
+		if err != nil {
+			return "", err
+		}
+		truthy, err := strconv.ParseBool(condition)
+		if err != nil {
+			return "", fmt.Errorf("condition of function 'if' must evaluate to true or false but got '%s'", condition)
+		}
+		// only the selected branch is evaluated so that the other one may contain unresolved references
+		if truthy {
+			return c.args[1].eval(r)
+		}
+		return c.args[2].eval(r)
+	}
+
+	fn, ok := functions[c.function]
+	if !ok {
+		return "", fmt.Errorf("unknown function '%s'", c.function)
+	}
+	if len(c.args) != fn.arity {
+		return "", fmt.Errorf("function '%s' expects %d argument(s) but got %d", c.function, fn.arity, len(c.args))
+	}
+	args := make([]string, 0, len(c.args))
+	for _, arg := range c.args {
+		value, err := arg.eval(r)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, value)
+	}
+	return fn.apply(r, args)
+}
+
+type function struct {
+	arity int
+	apply func(r *resolver, args []string) (string, error)
+}
+
+var functions = map[string]function{
+	"lower": {1, func(r *resolver, args []string) (string, error) { return strings.ToLower(args[0]), nil }},
+	"upper": {1, func(r *resolver, args []string) (string, error) { return strings.ToUpper(args[0]), nil }},
+	"replace": {3, func(r *resolver, args []string) (string, error) {
+		return strings.ReplaceAll(args[0], args[1], args[2]), nil
+	}},
+	"trimPrefix": {2, func(r *resolver, args []string) (string, error) { return strings.TrimPrefix(args[0], args[1]), nil }},
+	"trimSuffix": {2, func(r *resolver, args []string) (string, error) { return strings.TrimSuffix(args[0], args[1]), nil }},
+	"eq":         {2, func(r *resolver, args []string) (string, error) { return strconv.FormatBool(args[0] == args[1]), nil }},
+	"ne":         {2, func(r *resolver, args []string) (string, error) { return strconv.FormatBool(args[0] != args[1]), nil }},
+	"hasPrefix": {2, func(r *resolver, args []string) (string, error) {
+		return strconv.FormatBool(strings.HasPrefix(args[0], args[1])), nil
+	}},
+	"matches": {2, func(r *resolver, args []string) (string, error) {
+		matched, err := path.Match(args[1], args[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern '%s': %w", args[1], err)
+		}
+		return strconv.FormatBool(matched), nil
+	}},
+	"imageDigest": {1, func(r *resolver, args []string) (string, error) { return cpeFunction(r, "imageDigest", args[0]) }},
+	"imageTag":    {1, func(r *resolver, args []string) (string, error) { return cpeFunction(r, "imageTag", args[0]) }},
+}
+
+func cpeFunction(r *resolver, name, arg string) (string, error) {
+	if value, ok := r.ctx.CPE.LookupTemplateValue(name, arg); ok {
+		return value, nil
+	}
+	return "", &UnresolvedError{Reference: fmt.Sprintf("%s(%s)", name, arg)}
+}
+
+func (r *resolver) nested() *resolver {
+	return &resolver{lookupMap: r.lookupMap, ctx: r.ctx, depth: r.depth + 1}
+}
+
+// resolve replaces every $( ... ) expression inside of str with its value
+func (r *resolver) resolve(str string) (string, error) {
+	if !strings.Contains(str, "$(") {
+		return str, nil
+	}
+	if r.depth >= maxLookupDepth {
+		return "", fmt.Errorf("expression could not be resolved with a depth of %d, '%s' is still left to resolve", r.depth, str)
+	}
+
+	var result strings.Builder
+	rest := str
+	for {
+		start := strings.Index(rest, "$(")
+		if start < 0 {
+			result.WriteString(rest)
+			return result.String(), nil
+		}
+		result.WriteString(rest[:start])
+		end, err := closingParenthesis(rest, start+1)
+		if err != nil {
+			return "", fmt.Errorf("invalid expression '%s': %w", rest[start:], err)
+		}
+		expression := rest[start : end+1]
+		ast, err := parse(rest[start+2 : end])
+		if err != nil {
+			return "", fmt.Errorf("invalid expression '%s': %w", expression, err)
+		}
+		value, err := ast.eval(r)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve expression '%s': %w", expression, err)
+		}
+		result.WriteString(value)
+		rest = rest[end+1:]
+	}
+}
+
+// closingParenthesis returns the index of the parenthesis closing the one at position open
+func closingParenthesis(str string, open int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := open; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("missing closing parenthesis")
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func parse(expression string) (node, error) {
+	p := &parser{input: expression}
+	n, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", p.input[p.pos:], p.pos)
+	}
+	return n, nil
+}
+
+func (p *parser) expression() (node, error) {
+	operand, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !strings.HasPrefix(p.input[p.pos:], ":-") {
+		return operand, nil
+	}
+	p.pos += 2
+	p.skipSpaces()
+	if p.pos < len(p.input) && (p.input[p.pos] == '"' || p.input[p.pos] == '\'') {
+		value, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return fallback{operand: operand, raw: value, quoted: true}, nil
+	}
+	return fallback{operand: operand, raw: strings.TrimSpace(p.raw())}, nil
+}
+
+func (p *parser) operand() (node, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	if c := p.input[p.pos]; c == '"' || c == '\'' {
+		value, err := p.quoted()
+		return literal{value: value}, err
+	}
+
+	name := p.name()
+	if len(name) == 0 {
+		return nil, fmt.Errorf("unexpected '%c' at position %d", p.input[p.pos], p.pos)
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		args, err := p.arguments()
+		if err != nil {
+			return nil, fmt.Errorf("function '%s': %w", name, err)
+		}
+		return call{function: name, args: args}, nil
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == ':' && !strings.HasPrefix(p.input[p.pos:], ":-") {
+		p.pos++
+		scoped := p.name()
+		if len(scoped) == 0 {
+			return nil, fmt.Errorf("missing name after '%s:'", name)
+		}
+		return reference{scope: name, name: scoped}, nil
+	}
+	return reference{name: name}, nil
+}
+
+func (p *parser) arguments() ([]node, error) {
+	args := []node{}
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == ')' {
+		p.pos++
+		return args, nil
+	}
+	for {
+		arg, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		p.skipSpaces()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		switch p.input[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return args, nil
+		default:
+			return nil, fmt.Errorf("unexpected '%c' at position %d", p.input[p.pos], p.pos)
+		}
+	}
+}
+
+func (p *parser) name() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("._/-", c) >= 0) {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) quoted() (string, error) {
+	quote := p.input[p.pos]
+	end := strings.IndexByte(p.input[p.pos+1:], quote)
+	if end < 0 {
+		return "", fmt.Errorf("missing closing quote")
+	}
+	value := p.input[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return value, nil
+}
+
+// raw consumes unquoted fallback text up to the end of the current argument
+func (p *parser) raw() string {
+	start := p.pos
+	depth := 0
+	var quote byte
+	for ; p.pos < len(p.input); p.pos++ {
+		c := p.input[p.pos]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return p.input[start:p.pos]
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return p.input[start:p.pos]
+			}
+		}
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
@@ -0,0 +1,115 @@
+//go:build unit
+// +build unit
+
+package interpolation
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveStringWithContext(t *testing.T) {
+	t.Parallel()
+
+	lookupMap := map[string]interface{}{
+		"name":     "My-App",
+		"empty":    "",
+		"nested":   "$(name)-suffix",
+		"replicas": 3,
+	}
+	ctx := Context{
+		LookupEnv: func(key string) (string, bool) {
+			if key == "HOME" {
+				return "/home/piper", true
+			}
+			return "", false
+		},
+		CPE: piperenv.CPEMap{
+			"artifactVersion":         "1.2.3",
+			"git/commitId":            "abcdef",
+			"custom/region":           "eu10",
+			"container/imageNameTags": []interface{}{"my-app:1.2.3"},
+		},
+		Branch:       "release/1.2",
+		Orchestrator: "Jenkins",
+	}
+
+	tt := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "no expression", input: "plain value", expected: "plain value"},
+		{name: "lookup", input: "$(name)/$(replicas)", expected: "My-App/3"},
+		{name: "recursive lookup", input: "$(nested)", expected: "My-App-suffix"},
+		{name: "fallback for missing key", input: "$(missing:-default)", expected: "default"},
+		{name: "fallback for empty key", input: "$(empty:-default)", expected: "default"},
+		{name: "fallback not used", input: "$(name:-default)", expected: "My-App"},
+		{name: "fallback containing colon", input: "$(missing:-https://example.org)", expected: "https://example.org"},
+		{name: "quoted fallback", input: `$(missing:-"a, b")`, expected: "a, b"},
+		{name: "nested fallback", input: "$(missing:-$(name))", expected: "My-App"},
+		{name: "env", input: "$(env:HOME)/.m2", expected: "/home/piper/.m2"},
+		{name: "cpe", input: "$(cpe:artifactVersion)-$(git:commitId)-$(custom:region)", expected: "1.2.3-abcdef-eu10"},
+		{name: "image tag", input: `$(imageTag("my-app"))`, expected: "1.2.3"},
+		{name: "lower", input: "$(lower(name))", expected: "my-app"},
+		{name: "upper", input: "$(upper(name))", expected: "MY-APP"},
+		{name: "replace", input: `$(replace(pipeline:branch, "/", "-"))`, expected: "release-1.2"},
+		{name: "trimPrefix", input: `$(trimPrefix(pipeline:branch, "release/"))`, expected: "1.2"},
+		{name: "nested functions", input: `$(lower(replace(name, "-", "_")))`, expected: "my_app"},
+		{name: "if true", input: `$(if(eq(pipeline:orchestrator, "Jenkins"), "jenkins", missing))`, expected: "jenkins"},
+		{name: "if false", input: `$(if(matches(pipeline:branch, "main"), missing, "other"))`, expected: "other"},
+		{name: "if on branch pattern", input: `$(if(matches(pipeline:branch, "release/*"), "prod", "dev"))`, expected: "prod"},
+		{name: "function with fallback", input: `$(lower(missing:-ABC))`, expected: "abc"},
+	}
+
+	for _, test := range tt {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			resolved, err := ResolveStringWithContext(test.input, lookupMap, ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, resolved)
+		})
+	}
+}
+
+func TestResolveStringWithContextErrors(t *testing.T) {
+	t.Parallel()
+
+	lookupMap := map[string]interface{}{
+		"loop1": "$(loop2)",
+		"loop2": "$(loop1)",
+	}
+	ctx := Context{LookupEnv: func(string) (string, bool) { return "", false }}
+
+	tt := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{name: "missing key", input: "a/$(missing)", expectedError: "failed to resolve expression '$(missing)': unresolved reference 'missing'"},
+		{name: "missing env", input: "$(env:NOT_SET)", expectedError: "unresolved reference 'env:NOT_SET'"},
+		{name: "missing cpe", input: "$(cpe:artifactVersion)", expectedError: "unresolved reference 'cpe:artifactVersion'"},
+		{name: "missing branch", input: "$(pipeline:branch)", expectedError: "unresolved reference 'pipeline:branch'"},
+		{name: "unknown pipeline property", input: "$(pipeline:foo)", expectedError: "unknown pipeline property 'foo'"},
+		{name: "unknown scope", input: "$(foo:bar)", expectedError: "unknown reference scope 'foo'"},
+		{name: "unknown function", input: "$(foo(bar))", expectedError: "unknown function 'foo'"},
+		{name: "wrong arity", input: `$(replace("a"))`, expectedError: "function 'replace' expects 3 argument(s) but got 1"},
+		{name: "invalid condition", input: `$(if("yes", "a", "b"))`, expectedError: "condition of function 'if' must evaluate to true or false but got 'yes'"},
+		{name: "unterminated", input: "$(name", expectedError: "invalid expression '$(name': missing closing parenthesis"},
+		{name: "unterminated quote", input: `$(lower("abc))`, expectedError: "missing closing parenthesis"},
+		{name: "syntax error", input: "$(name other)", expectedError: "invalid expression '$(name other)': unexpected 'other' at position 5"},
+		{name: "loop", input: "$(loop1)", expectedError: "expression could not be resolved with a depth of 10"},
+	}
+
+	for _, test := range tt {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ResolveStringWithContext(test.input, lookupMap, ctx)
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
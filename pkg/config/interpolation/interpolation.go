@@ -31,6 +31,61 @@ func ResolveMap(config map[string]interface{}) bool {
 	return true
 }
 
+// ResolveMapWithContext interpolates every string value of a map using the expressions described at Context.
+// Nested maps and lists are interpolated as well, references to other properties are looked up in the map itself.
+func ResolveMapWithContext(config map[string]interface{}, ctx Context) error {
+	resolved := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		resolvedValue, err := resolveValue(value, config, ctx)
+		if err != nil {
+			return err
+		}
+		resolved[key] = resolvedValue
+	}
+	for key, value := range resolved {
+		config[key] = value
+	}
+	return nil
+}
+
+func resolveValue(value interface{}, lookupMap map[string]interface{}, ctx Context) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return ResolveStringWithContext(v, lookupMap, ctx)
+	case []string:
+		resolved := make([]string, 0, len(v))
+		for _, item := range v {
+			resolvedItem, err := ResolveStringWithContext(item, lookupMap, ctx)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, resolvedItem)
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			resolvedItem, err := resolveValue(item, lookupMap, ctx)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, resolvedItem)
+		}
+		return resolved, nil
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolvedItem, err := resolveValue(item, lookupMap, ctx)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = resolvedItem
+		}
+		return resolved, nil
+	}
+	return value, nil
+}
+
 func resolveString(str string, lookupMap map[string]interface{}, n int) (string, bool) {
 	matches := lookupRegex.FindAllStringSubmatch(str, -1)
 	if len(matches) == 0 {
@@ -55,6 +110,7 @@ func resolveString(str string, lookupMap map[string]interface{}, n int) (string,
 
 // ResolveString takes a string and replaces all references inside of it with values from the given lookupMap.
 // This is being done recursively until the maxLookupDepth is reached.
+// Only plain property references are resolved, expressions are only supported by ResolveStringWithContext.
 func ResolveString(str string, lookupMap map[string]interface{}) (string, bool) {
 	return resolveString(str, lookupMap, 0)
 }
@@ -69,3 +125,10 @@ func setupCaptureGroups(captureGroupsList []string) map[string]int {
 	}
 	return groups
 }
+
+// ResolveStringWithContext takes a string and replaces all expressions inside of it.
+// An error is returned if an expression is invalid or references a value which is not available.
+func ResolveStringWithContext(str string, lookupMap map[string]interface{}, ctx Context) (string, error) {
+	r := &resolver{lookupMap: lookupMap, ctx: ctx}
+	return r.resolve(str)
+}
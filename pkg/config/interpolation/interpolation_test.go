@@ -45,4 +45,48 @@ func TestResolveMap(t *testing.T) {
 		assert.False(t, ok)
 	})
 
+	t.Run("That expressions are not evaluated", func(t *testing.T) {
+		testMap := map[string]interface{}{
+			"path": "secrets/$(group-name)/$(env:HOME)/$(cat token)",
+		}
+		ok := ResolveMap(testMap)
+		assert.True(t, ok)
+
+		assert.Equal(t, "secrets/$(group-name)/$(env:HOME)/$(cat token)", testMap["path"])
+	})
+
+}
+
+func TestResolveMapWithContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("That values are derived from each other", func(t *testing.T) {
+		testMap := map[string]interface{}{
+			"appName":  "my-app",
+			"hostname": `$(appName)-$(if(eq(pipeline:branch, "main"), "prod", "dev"))`,
+			"replicas": 2,
+			"cloudFoundry": map[string]interface{}{
+				"space": "$(appName)-space",
+			},
+			"tags": []interface{}{"$(appName)", 1},
+		}
+
+		err := ResolveMapWithContext(testMap, Context{Branch: "main"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"space": "my-app-space"}, testMap["cloudFoundry"])
+		assert.Equal(t, []interface{}{"my-app", 1}, testMap["tags"])
+		assert.Equal(t, "my-app-prod", testMap["hostname"])
+		assert.Equal(t, 2, testMap["replicas"])
+	})
+
+	t.Run("That unresolved references produce an error", func(t *testing.T) {
+		testMap := map[string]interface{}{
+			"hostname": "$(appName)",
+		}
+
+		err := ResolveMapWithContext(testMap, Context{})
+		assert.EqualError(t, err, "failed to resolve expression '$(appName)': unresolved reference 'appName'")
+		assert.Equal(t, "$(appName)", testMap["hostname"])
+	})
 }
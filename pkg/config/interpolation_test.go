@@ -0,0 +1,78 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/config/interpolation"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStepConfigWithExpressions(t *testing.T) {
+	metadata := StepData{
+		Spec: StepSpec{
+			Inputs: StepInputs{
+				Parameters: []StepParameters{
+					{Name: "appName", Scope: []string{"GENERAL", "STEPS", "STAGES", "PARAMETERS"}},
+					{Name: "hostname", Scope: []string{"GENERAL", "STEPS", "STAGES", "PARAMETERS"}},
+					{Name: "version", Scope: []string{"GENERAL", "STEPS", "STAGES", "PARAMETERS"}},
+				},
+			},
+		},
+	}
+	ctx := interpolation.Context{
+		CPE:    piperenv.CPEMap{"artifactVersion": "1.2.3"},
+		Branch: "main",
+	}
+
+	t.Run("expressions are resolved when enabled", func(t *testing.T) {
+		myConfig := `general:
+  resolveConfigExpressions: true
+  appName: my-app
+steps:
+  testStep:
+    hostname: $(appName)-$(if(eq(pipeline:branch, "main"), "prod", "dev"))
+    version: $(cpe:artifactVersion)
+`
+		c := Config{}
+		c.SetInterpolationContextProvider(func() interpolation.Context { return ctx })
+		stepConfig, err := c.GetStepConfig(nil, "", io.NopCloser(strings.NewReader(myConfig)), nil, false, metadata.GetParameterFilters(), metadata, nil, "stage1", "testStep")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "my-app-prod", stepConfig.Config["hostname"])
+		assert.Equal(t, "1.2.3", stepConfig.Config["version"])
+	})
+
+	t.Run("expressions are kept when not enabled", func(t *testing.T) {
+		myConfig := `steps:
+  testStep:
+    hostname: $(appName)
+`
+		c := Config{}
+		c.SetInterpolationContextProvider(func() interpolation.Context {
+			t.Fatal("context must only be loaded if expressions are enabled")
+			return ctx
+		})
+		stepConfig, err := c.GetStepConfig(nil, "", io.NopCloser(strings.NewReader(myConfig)), nil, false, metadata.GetParameterFilters(), metadata, nil, "stage1", "testStep")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "$(appName)", stepConfig.Config["hostname"])
+	})
+
+	t.Run("unresolved references fail", func(t *testing.T) {
+		myConfig := `steps:
+  testStep:
+    resolveConfigExpressions: true
+    hostname: $(appName)
+`
+		c := Config{}
+		_, err := c.GetStepConfig(nil, "", io.NopCloser(strings.NewReader(myConfig)), nil, false, metadata.GetParameterFilters(), metadata, nil, "stage1", "testStep")
+
+		assert.EqualError(t, err, "failed to resolve expressions in step configuration: failed to resolve expression '$(appName)': unresolved reference 'appName'")
+	})
+}
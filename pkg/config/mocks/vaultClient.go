@@ -2,7 +2,13 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	vault "github.com/SAP/jenkins-library/pkg/vault"
+)
 
 // VaultClient is an autogenerated mock type for the VaultClient type
 type VaultClient struct {
@@ -17,6 +23,65 @@ func (_m *VaultClient) EXPECT() *VaultClient_Expecter {
 	return &VaultClient_Expecter{mock: &_m.Mock}
 }
 
+// GetDynamicSecret provides a mock function with given fields: _a0, _a1
+func (_m *VaultClient) GetDynamicSecret(_a0 string, _a1 map[string]interface{}) (*vault.DynamicSecret, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDynamicSecret")
+	}
+
+	var r0 *vault.DynamicSecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) (*vault.DynamicSecret, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) *vault.DynamicSecret); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*vault.DynamicSecret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, map[string]interface{}) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VaultClient_GetDynamicSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDynamicSecret'
+type VaultClient_GetDynamicSecret_Call struct {
+	*mock.Call
+}
+
+// GetDynamicSecret is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 map[string]interface{}
+func (_e *VaultClient_Expecter) GetDynamicSecret(_a0 interface{}, _a1 interface{}) *VaultClient_GetDynamicSecret_Call {
+	return &VaultClient_GetDynamicSecret_Call{Call: _e.mock.On("GetDynamicSecret", _a0, _a1)}
+}
+
+func (_c *VaultClient_GetDynamicSecret_Call) Run(run func(_a0 string, _a1 map[string]interface{})) *VaultClient_GetDynamicSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *VaultClient_GetDynamicSecret_Call) Return(_a0 *vault.DynamicSecret, _a1 error) *VaultClient_GetDynamicSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VaultClient_GetDynamicSecret_Call) RunAndReturn(run func(string, map[string]interface{}) (*vault.DynamicSecret, error)) *VaultClient_GetDynamicSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetKvSecret provides a mock function with given fields: _a0
 func (_m *VaultClient) GetKvSecret(_a0 string) (map[string]string, error) {
 	ret := _m.Called(_a0)
@@ -163,6 +228,109 @@ func (_c *VaultClient_MustRevokeToken_Call) RunAndReturn(run func()) *VaultClien
 	return _c
 }
 
+// RenewLease provides a mock function with given fields: _a0, _a1
+func (_m *VaultClient) RenewLease(_a0 string, _a1 time.Duration) (time.Duration, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewLease")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (time.Duration, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) time.Duration); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VaultClient_RenewLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewLease'
+type VaultClient_RenewLease_Call struct {
+	*mock.Call
+}
+
+// RenewLease is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 time.Duration
+func (_e *VaultClient_Expecter) RenewLease(_a0 interface{}, _a1 interface{}) *VaultClient_RenewLease_Call {
+	return &VaultClient_RenewLease_Call{Call: _e.mock.On("RenewLease", _a0, _a1)}
+}
+
+func (_c *VaultClient_RenewLease_Call) Run(run func(_a0 string, _a1 time.Duration)) *VaultClient_RenewLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *VaultClient_RenewLease_Call) Return(_a0 time.Duration, _a1 error) *VaultClient_RenewLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VaultClient_RenewLease_Call) RunAndReturn(run func(string, time.Duration) (time.Duration, error)) *VaultClient_RenewLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeLease provides a mock function with given fields: _a0
+func (_m *VaultClient) RevokeLease(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VaultClient_RevokeLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeLease'
+type VaultClient_RevokeLease_Call struct {
+	*mock.Call
+}
+
+// RevokeLease is a helper method to define mock.On call
+//   - _a0 string
+func (_e *VaultClient_Expecter) RevokeLease(_a0 interface{}) *VaultClient_RevokeLease_Call {
+	return &VaultClient_RevokeLease_Call{Call: _e.mock.On("RevokeLease", _a0)}
+}
+
+func (_c *VaultClient_RevokeLease_Call) Run(run func(_a0 string)) *VaultClient_RevokeLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *VaultClient_RevokeLease_Call) Return(_a0 error) *VaultClient_RevokeLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *VaultClient_RevokeLease_Call) RunAndReturn(run func(string) error) *VaultClient_RevokeLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewVaultClient creates a new instance of VaultClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewVaultClient(t interface {
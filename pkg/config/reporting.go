@@ -35,6 +35,9 @@ var ReportingParameters = ReportingParams{
 		{
 			Name: "gcsSubFolder",
 		},
+		{
+			Name: "stepCacheLocation",
+		},
 	},
 }
 
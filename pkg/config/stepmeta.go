@@ -35,6 +35,7 @@ type StepSpec struct {
 	Outputs    StepOutputs `json:"outputs,omitempty"`
 	Containers []Container `json:"containers,omitempty"`
 	Sidecars   []Container `json:"sidecars,omitempty"`
+	Cache      *StepCache  `json:"cache,omitempty"`
 }
 
 // StepInputs defines the spec details for a step, like step inputs, containers, sidecars, ...
@@ -104,6 +105,14 @@ type StepOutputs struct {
 	Resources []StepResources `json:"resources,omitempty"`
 }
 
+// StepCache defines the files which determine the result of a step and the files it produces in addition to its output resources.
+// Steps defining a cache can be skipped if none of their inputs changed since a recorded run.
+type StepCache struct {
+	Inputs   []string `json:"inputs,omitempty"`
+	Outputs  []string `json:"outputs,omitempty"`
+	Excludes []string `json:"excludes,omitempty"`
+}
+
 // Container defines an execution container
 type Container struct {
 	//ToDo: check dockerOptions, dockerVolumeBind, containerPortMappings, sidecarOptions, sidecarVolumeBind
@@ -466,6 +475,12 @@ func getFilterForResourceReferences(params []StepParameters) []string {
 		if reference == nil {
 			reference = param.GetReference("vaultSecretFile")
 		}
+		if reference == nil {
+			reference = param.GetReference(RefTypeVaultDynamicSecret)
+		}
+		if reference == nil {
+			reference = param.GetReference(RefTypeVaultDynamicSecretFile)
+		}
 		if reference == nil {
 			return filter
 		}
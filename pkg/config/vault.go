@@ -1,10 +1,12 @@
 package config
 
 import (
+	"net/url"
 	"os"
 	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config/interpolation"
 	"github.com/SAP/jenkins-library/pkg/log"
@@ -33,6 +35,11 @@ const (
 	vaultTestCredentialEnvPrefixDefault = "PIPER_TESTCREDENTIAL_"
 	VaultCredentialEnvPrefixDefault     = "PIPER_VAULTCREDENTIAL_"
 	vaultSecretName                     = ".+VaultSecretName$"
+
+	// RefTypeVaultDynamicSecret references a credential generated by a Vault secrets engine, e.g. database, aws, azure or pki
+	RefTypeVaultDynamicSecret = "vaultDynamicSecret"
+	// RefTypeVaultDynamicSecretFile references a credential generated by a Vault secrets engine which is provided as file
+	RefTypeVaultDynamicSecretFile = "vaultDynamicSecretFile"
 )
 
 var (
@@ -66,6 +73,9 @@ var (
 
 	// VaultSecretFileDirectory holds the directory for the current step run to temporarily store secret files fetched from vault
 	VaultSecretFileDirectory = ""
+
+	// vaultLeaseManager keeps the leases of dynamic secrets requested for the current step run
+	vaultLeaseManager *vault.LeaseManager
 )
 
 // VaultCredentials hold all the auth information needed to fetch configuration from vault
@@ -80,6 +90,9 @@ type VaultClient interface {
 	GetKvSecret(string) (map[string]string, error)
 	MustRevokeToken()
 	GetOIDCTokenByValidation(string) (string, error)
+	GetDynamicSecret(string, map[string]interface{}) (*vault.DynamicSecret, error)
+	RenewLease(string, time.Duration) (time.Duration, error)
+	RevokeLease(string) error
 }
 
 // globalVaultClient is supposed to be used in the steps code.
@@ -145,6 +158,8 @@ func GetVaultClientFromConfig(config map[string]interface{}, creds VaultCredenti
 }
 
 func resolveAllVaultReferences(config *StepConfig, client VaultClient, params []StepParameters) {
+	// parameters referencing the same engine path, e.g. username and password, need to share one credential
+	dynamicSecrets := map[string]*vault.DynamicSecret{}
 	for _, param := range params {
 		if ref := param.GetReference("vaultSecret"); ref != nil {
 			resolveVaultReference(ref, config, client, param)
@@ -152,7 +167,96 @@ func resolveAllVaultReferences(config *StepConfig, client VaultClient, params []
 		if ref := param.GetReference("vaultSecretFile"); ref != nil {
 			resolveVaultReference(ref, config, client, param)
 		}
+		if ref := param.GetReference(RefTypeVaultDynamicSecret); ref != nil {
+			resolveVaultDynamicReference(ref, config, client, param, dynamicSecrets)
+		}
+		if ref := param.GetReference(RefTypeVaultDynamicSecretFile); ref != nil {
+			resolveVaultDynamicReference(ref, config, client, param, dynamicSecrets)
+		}
+	}
+}
+
+func resolveVaultDynamicReference(ref *ResourceReference, config *StepConfig, client VaultClient, param StepParameters, dynamicSecrets map[string]*vault.DynamicSecret) {
+	vaultDisableOverwrite, _ := config.Config["vaultDisableOverwrite"].(bool)
+	if paramValue, _ := config.Config[param.Name].(string); vaultDisableOverwrite && paramValue != "" {
+		log.Entry().Debugf("Not fetching '%s' from Vault since it has already been set", param.Name)
+		return
+	}
+
+	enginePath := ref.Default
+	if providedPath, ok := config.Config[ref.Name].(string); ok && providedPath != "" {
+		enginePath = providedPath
+	}
+	if enginePath == "" {
+		return
+	}
+	enginePath, ok := interpolation.ResolveString(enginePath, config.Config)
+	if !ok {
+		log.Entry().Debugf("Not fetching '%s' from Vault since path '%s' cannot be resolved", param.Name, enginePath)
+		return
+	}
+
+	log.Entry().Infof("Resolving '%s' from dynamic secret '%s'", param.Name, enginePath)
+
+	secret, ok := dynamicSecrets[enginePath]
+	if !ok {
+		requestPath, data, err := splitDynamicSecretPath(enginePath)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Invalid dynamic secret path '%s'", enginePath)
+			return
+		}
+		secret, err = client.GetDynamicSecret(requestPath, data)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Couldn't request dynamic secret at '%s'", requestPath)
+			return
+		}
+		for _, value := range secret.Data {
+			log.RegisterSecret(value)
+		}
+		if vaultLeaseManager == nil {
+			vaultLeaseManager = vault.NewLeaseManager(client)
+		}
+		vaultLeaseManager.Track(secret)
+		dynamicSecrets[enginePath] = secret
+	}
+
+	field := ref.Param
+	if field == "" {
+		field = param.Name
+	}
+	value, ok := secret.Data[field]
+	if !ok {
+		log.Entry().Warnf("  failed, dynamic secret did not contain a field name '%s'", field)
+		return
+	}
+
+	if ref.Type == RefTypeVaultDynamicSecretFile {
+		filePath, err := createTemporarySecretFile(param.Name, value)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Couldn't create temporary secret file for '%s'", param.Name)
+			return
+		}
+		value = filePath
 	}
+	config.Config[param.Name] = value
+	log.Entry().Info("  succeeded")
+}
+
+// splitDynamicSecretPath separates the query of a path like 'pki/issue/web?common_name=app.example.com' into the request data
+func splitDynamicSecretPath(enginePath string) (string, map[string]interface{}, error) {
+	requestPath, query, found := strings.Cut(enginePath, "?")
+	if !found {
+		return requestPath, nil, nil
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", nil, err
+	}
+	data := make(map[string]interface{}, len(values))
+	for key := range values {
+		data[key] = values.Get(key)
+	}
+	return requestPath, data, nil
 }
 
 func resolveVaultReference(ref *ResourceReference, config *StepConfig, client VaultClient, param StepParameters) {
@@ -445,6 +549,17 @@ func RemoveVaultSecretFiles() {
 	}
 }
 
+// RevokeVaultLeases revokes the leases of all dynamic secrets which have been requested during execution
+func RevokeVaultLeases() {
+	if vaultLeaseManager == nil {
+		return
+	}
+	if err := vaultLeaseManager.RevokeAll(); err != nil {
+		log.Entry().WithError(err).Warn("Vault credentials stay valid until their leases expire")
+	}
+	vaultLeaseManager = nil
+}
+
 func createTemporarySecretFile(namePattern string, content string) (string, error) {
 	if VaultSecretFileDirectory == "" {
 		var err error
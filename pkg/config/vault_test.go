@@ -10,10 +10,12 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
 	"github.com/SAP/jenkins-library/pkg/config/mocks"
+	"github.com/SAP/jenkins-library/pkg/vault"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -183,6 +185,78 @@ func TestVaultSecretFiles(t *testing.T) {
 	})
 }
 
+func TestVaultDynamicSecrets(t *testing.T) {
+	const pathOverrideKey = "databaseVaultDynamicSecretPath"
+	dynamicParam := func(name, refType, field string) StepParameters {
+		param := stepParam(name, refType, pathOverrideKey, "database/creds/$(databaseRole)")
+		param.ResourceRef[0].Param = field
+		return param
+	}
+
+	t.Run("username and password share one lease", func(t *testing.T) {
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{"databaseRole": "readonly"}}
+		stepParams := []StepParameters{
+			dynamicParam("dbUser", RefTypeVaultDynamicSecret, "username"),
+			dynamicParam("dbPassword", RefTypeVaultDynamicSecret, "password"),
+		}
+		vaultMock.On("GetDynamicSecret", "database/creds/readonly", map[string]interface{}(nil)).Return(&vault.DynamicSecret{
+			Data:          map[string]string{"username": "v-readonly", "password": "secret"},
+			LeaseID:       "database/creds/readonly/abc",
+			LeaseDuration: time.Hour,
+		}, nil).Once()
+		vaultMock.On("RevokeLease", "database/creds/readonly/abc").Return(nil).Once()
+
+		resolveAllVaultReferences(&stepConfig, vaultMock, stepParams)
+		assert.Equal(t, "v-readonly", stepConfig.Config["dbUser"])
+		assert.Equal(t, "secret", stepConfig.Config["dbPassword"])
+
+		RevokeVaultLeases()
+		vaultMock.AssertExpectations(t)
+		assert.Nil(t, vaultLeaseManager)
+	})
+
+	t.Run("certificate file with request data", func(t *testing.T) {
+		VaultSecretFileDirectory = ""
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{pathOverrideKey: "pki/issue/web?common_name=app.example.com"}}
+		stepParams := []StepParameters{dynamicParam("certificateFile", RefTypeVaultDynamicSecretFile, "certificate")}
+		vaultMock.On("GetDynamicSecret", "pki/issue/web", map[string]interface{}{"common_name": "app.example.com"}).
+			Return(&vault.DynamicSecret{Data: map[string]string{"certificate": "CERT"}}, nil)
+
+		resolveAllVaultReferences(&stepConfig, vaultMock, stepParams)
+		defer RemoveVaultSecretFiles()
+		content, err := os.ReadFile(stepConfig.Config["certificateFile"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, "CERT", string(content))
+
+		// certificates without lease do not need to be revoked
+		RevokeVaultLeases()
+		vaultMock.AssertNotCalled(t, "RevokeLease", mock.Anything)
+	})
+
+	t.Run("unresolved path is skipped", func(t *testing.T) {
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{}}
+		stepParams := []StepParameters{dynamicParam("dbUser", RefTypeVaultDynamicSecret, "username")}
+
+		resolveAllVaultReferences(&stepConfig, vaultMock, stepParams)
+		assert.NotContains(t, stepConfig.Config, "dbUser")
+		vaultMock.AssertNotCalled(t, "GetDynamicSecret", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error is not fatal", func(t *testing.T) {
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{"databaseRole": "readonly"}}
+		stepParams := []StepParameters{dynamicParam("dbUser", RefTypeVaultDynamicSecret, "username")}
+		vaultMock.On("GetDynamicSecret", "database/creds/readonly", map[string]interface{}(nil)).Return(nil, fmt.Errorf("permission denied"))
+
+		resolveAllVaultReferences(&stepConfig, vaultMock, stepParams)
+		assert.NotContains(t, stepConfig.Config, "dbUser")
+		RevokeVaultLeases()
+	})
+}
+
 func TestMixinVault(t *testing.T) {
 	vaultServerUrl := "https://testServer"
 	vaultPath := "testPath"
@@ -28,8 +28,10 @@ type VulnerabilitiesResponse struct {
 }
 
 type Vulnerability struct {
-	Severity string `json:"severity"`
-	Status   string `json:"status"`
+	Id        string `json:"id"`
+	Severity  string `json:"severity"`
+	Status    string `json:"status"`
+	SubStatus string `json:"subStatus"`
 }
 
 type ApplicationResponse struct {
@@ -1,6 +1,7 @@
 package contrast
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -27,7 +28,7 @@ func NewContrastHttpClient(apiKey, auth string) *ContrastHttpClientInstance {
 }
 
 func (c *ContrastHttpClientInstance) ExecuteRequest(url string, params map[string]string, dest interface{}) error {
-	req, err := newHttpRequest(url, c.apiKey, c.auth, params)
+	req, err := newHttpRequest(http.MethodGet, url, c.apiKey, c.auth, params, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to create request")
 	}
@@ -49,8 +50,38 @@ func (c *ContrastHttpClientInstance) ExecuteRequest(url string, params map[strin
 	return nil
 }
 
-func newHttpRequest(url, apiKey, auth string, params map[string]string) (*http.Request, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// SendRequest sends the payload as JSON with the given method and decodes the response into dest unless it is nil
+func (c *ContrastHttpClientInstance) SendRequest(method, url string, payload, dest interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body")
+	}
+	req, err := newHttpRequest(method, url, c.apiKey, c.auth, nil, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	log.Entry().Debugf("%s call request to: %s", method, url)
+	response, err := performRequest(req)
+	if response != nil && response.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to perform request, status code: %v and status %v", response.StatusCode, response.Status)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to perform request")
+	}
+	defer response.Body.Close()
+	if dest == nil {
+		return nil
+	}
+	if err := parseJsonResponse(response, dest); err != nil {
+		return errors.Wrap(err, "failed to parse JSON response")
+	}
+	return nil
+}
+
+func newHttpRequest(method, url, apiKey, auth string, params map[string]string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,142 @@
+package contrast
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/pkg/errors"
+)
+
+// statuses of Contrast vulnerabilities as listed and as marked
+const (
+	StatusSuspicious  = "SUSPICIOUS"
+	StatusConfirmed   = "CONFIRMED"
+	StatusNotAProblem = "NOT_A_PROBLEM"
+
+	markReported    = "Reported"
+	markSuspicious  = "Suspicious"
+	markConfirmed   = "Confirmed"
+	markNotAProblem = "NotAProblem"
+)
+
+// reasons of Contrast vulnerabilities which are not a problem
+const (
+	subStatusFalsePositive   = "False Positive"
+	subStatusInternalControl = "Goes through an internal security control"
+	subStatusOther           = "Other"
+)
+
+type contrastTriageClient interface {
+	ContrastHttpClient
+	SendRequest(method, url string, payload, dest interface{}) error
+}
+
+type markRequest struct {
+	Traces    []string `json:"traces"`
+	Status    string   `json:"status"`
+	SubStatus string   `json:"substatus,omitempty"`
+	Note      string   `json:"note,omitempty"`
+}
+
+// TriageAuditor synchronizes assessments with the status of Contrast vulnerabilities
+type TriageAuditor struct {
+	client             contrastTriageClient
+	vulnerabilitiesUrl string
+	markUrl            string
+}
+
+// NewTriageAuditor creates an auditor for the vulnerabilities of a Contrast application
+func NewTriageAuditor(server, organizationID, applicationID, apiKey, auth string) *TriageAuditor {
+	return &TriageAuditor{
+		client:             NewContrastHttpClient(apiKey, auth),
+		vulnerabilitiesUrl: fmt.Sprintf("%s/api/v4/organizations/%s/applications/%s/vulnerabilities", server, organizationID, applicationID),
+		markUrl:            fmt.Sprintf("%s/Contrast/api/ng/%s/orgtraces/mark", server, organizationID),
+	}
+}
+
+// Tool returns the tool name of Contrast assessments
+func (t *TriageAuditor) Tool() string {
+	return "contrast"
+}
+
+// Findings returns the vulnerabilities of the application identified by their vulnerability ID
+func (t *TriageAuditor) Findings() ([]triage.Finding, error) {
+	findings := []triage.Finding{}
+	for page := startPage; ; page++ {
+		params := map[string]string{
+			"page": fmt.Sprintf("%d", page),
+			"size": fmt.Sprintf("%d", pageSize),
+		}
+		var vulnsResponse VulnerabilitiesResponse
+		if err := t.client.ExecuteRequest(t.vulnerabilitiesUrl, params, &vulnsResponse); err != nil {
+			return nil, err
+		}
+		for _, vuln := range vulnsResponse.Vulnerabilities {
+			findings = append(findings, triage.Finding{ID: vuln.Id, Assessment: vulnerabilityAssessment(vuln)})
+		}
+		if vulnsResponse.Empty || vulnsResponse.Last {
+			return findings, nil
+		}
+	}
+}
+
+// Assess marks the vulnerability of the finding with the status of the assessment
+func (t *TriageAuditor) Assess(finding triage.Finding, assessment format.Assessment) (bool, error) {
+	mark := vulnerabilityMark(assessment)
+	current := markRequest{Status: markReported}
+	if finding.Assessment != nil {
+		current = vulnerabilityMark(*finding.Assessment)
+	}
+	if mark.Status == current.Status && mark.SubStatus == current.SubStatus {
+		return false, nil
+	}
+
+	mark.Traces = []string{finding.ID}
+	if err := t.client.SendRequest(http.MethodPut, t.markUrl, mark, nil); err != nil {
+		return false, errors.Wrapf(err, "failed to mark vulnerability %v as %v", finding.ID, mark.Status)
+	}
+	return true, nil
+}
+
+func vulnerabilityAssessment(vuln Vulnerability) *format.Assessment {
+	switch vuln.Status {
+	case StatusConfirmed:
+		return &format.Assessment{Status: format.Relevant}
+	case StatusSuspicious:
+		return &format.Assessment{Status: format.InProcess}
+	case StatusNotAProblem:
+		assessment := format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable}
+		switch {
+		case strings.EqualFold(vuln.SubStatus, subStatusFalsePositive):
+			assessment.Analysis = format.FalsePositive
+		case strings.EqualFold(vuln.SubStatus, subStatusInternalControl):
+			assessment.Analysis = format.Mitigated
+		case !strings.EqualFold(vuln.SubStatus, subStatusOther):
+			assessment.Justification = vuln.SubStatus
+		}
+		return &assessment
+	}
+	return nil
+}
+
+func vulnerabilityMark(assessment format.Assessment) markRequest {
+	switch assessment.Status {
+	case format.Relevant:
+		return markRequest{Status: markConfirmed, Note: assessment.Justification}
+	case format.InProcess:
+		return markRequest{Status: markSuspicious, Note: assessment.Justification}
+	case format.NotRelevant:
+		mark := markRequest{Status: markNotAProblem, SubStatus: subStatusOther, Note: assessment.Justification}
+		switch assessment.Analysis {
+		case format.FalsePositive, format.WronglyReported:
+			mark.SubStatus = subStatusFalsePositive
+		case format.Mitigated:
+			mark.SubStatus = subStatusInternalControl
+		}
+		return mark
+	}
+	return markRequest{Status: markReported}
+}
@@ -0,0 +1,104 @@
+//go:build unit
+// +build unit
+
+package contrast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/stretchr/testify/assert"
+)
+
+type contrastTriageClientMock struct {
+	pages   [][]Vulnerability
+	marks   []markRequest
+	failing bool
+}
+
+func (c *contrastTriageClientMock) ExecuteRequest(url string, params map[string]string, dest interface{}) error {
+	if c.failing {
+		return fmt.Errorf("request failed")
+	}
+	vulns := dest.(*VulnerabilitiesResponse)
+	var page int
+	fmt.Sscanf(params["page"], "%d", &page)
+	vulns.Vulnerabilities = c.pages[page]
+	vulns.Last = page == len(c.pages)-1
+	return nil
+}
+
+func (c *contrastTriageClientMock) SendRequest(method, url string, payload, dest interface{}) error {
+	if c.failing {
+		return fmt.Errorf("request failed")
+	}
+	c.marks = append(c.marks, payload.(markRequest))
+	return nil
+}
+
+func TestTriageAuditorFindings(t *testing.T) {
+	t.Parallel()
+
+	client := &contrastTriageClientMock{pages: [][]Vulnerability{
+		{
+			{Id: "AAAA-1", Status: StatusReported},
+			{Id: "AAAA-2", Status: StatusConfirmed},
+		},
+		{
+			{Id: "AAAA-3", Status: StatusSuspicious},
+			{Id: "AAAA-4", Status: StatusNotAProblem, SubStatus: "False Positive"},
+			{Id: "AAAA-5", Status: StatusNotAProblem, SubStatus: "Attack is defended by an external control"},
+			{Id: "AAAA-6", Status: StatusNotAProblem, SubStatus: "Other"},
+		},
+	}}
+	auditor := &TriageAuditor{client: client}
+
+	findings, err := auditor.Findings()
+	assert.NoError(t, err)
+	assert.Equal(t, []triage.Finding{
+		{ID: "AAAA-1"},
+		{ID: "AAAA-2", Assessment: &format.Assessment{Status: format.Relevant}},
+		{ID: "AAAA-3", Assessment: &format.Assessment{Status: format.InProcess}},
+		{ID: "AAAA-4", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive}},
+		{ID: "AAAA-5", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable, Justification: "Attack is defended by an external control"}},
+		{ID: "AAAA-6", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable}},
+	}, findings)
+
+	client.failing = true
+	_, err = auditor.Findings()
+	assert.EqualError(t, err, "request failed")
+}
+
+func TestTriageAuditorAssess(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mark as not a problem", func(t *testing.T) {
+		client := &contrastTriageClientMock{}
+		auditor := &TriageAuditor{client: client}
+
+		changed, err := auditor.Assess(triage.Finding{ID: "AAAA-1"}, format.Assessment{Status: format.NotRelevant, Analysis: format.Mitigated, Justification: "escaped by the template engine"})
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []markRequest{{Traces: []string{"AAAA-1"}, Status: "NotAProblem", SubStatus: "Goes through an internal security control", Note: "escaped by the template engine"}}, client.marks)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		client := &contrastTriageClientMock{}
+		auditor := &TriageAuditor{client: client}
+
+		changed, err := auditor.Assess(triage.Finding{ID: "AAAA-4", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive}},
+			format.Assessment{Status: format.NotRelevant, Analysis: format.WronglyReported, Justification: "dead code"})
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, client.marks)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		auditor := &TriageAuditor{client: &contrastTriageClientMock{failing: true}}
+
+		_, err := auditor.Assess(triage.Finding{ID: "AAAA-1"}, format.Assessment{Status: format.Relevant})
+		assert.EqualError(t, err, "failed to mark vulnerability AAAA-1 as Confirmed: request failed")
+	})
+}
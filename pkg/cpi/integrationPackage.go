@@ -0,0 +1,266 @@
+package cpi
+
+import (
+	"bytes"
+	b64 "encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// ArtifactType is a kind of design time artifact an integration package consists of
+type ArtifactType struct {
+	// Name identifies the type in the artifact.json of the repository
+	Name string
+	// EntitySet is the OData entity set of the type in the remote API
+	EntitySet string
+	// Description is the human readable name of the type
+	Description string
+	// Updatable is false for types which can only be replaced by deleting and recreating them
+	Updatable bool
+	// Configurable is true for types with externalized parameters
+	Configurable bool
+}
+
+// Supported artifact types of integration packages
+var (
+	IntegrationFlow  = ArtifactType{Name: "IntegrationFlow", EntitySet: "IntegrationDesigntimeArtifacts", Description: "integration flow", Updatable: true, Configurable: true}
+	ValueMapping     = ArtifactType{Name: "ValueMapping", EntitySet: "ValueMappingDesigntimeArtifacts", Description: "value mapping"}
+	MessageMapping   = ArtifactType{Name: "MessageMapping", EntitySet: "MessageMappingDesigntimeArtifacts", Description: "message mapping", Updatable: true}
+	ScriptCollection = ArtifactType{Name: "ScriptCollection", EntitySet: "ScriptCollectionDesigntimeArtifacts", Description: "script collection", Updatable: true}
+	ArtifactTypes    = []ArtifactType{IntegrationFlow, ValueMapping, MessageMapping, ScriptCollection}
+)
+
+// DesigntimeArtifact describes an artifact in the design time of an integration package
+type DesigntimeArtifact struct {
+	ID          string `json:"Id"`
+	Name        string `json:"Name"`
+	Version     string `json:"Version,omitempty"`
+	PackageID   string `json:"PackageId,omitempty"`
+	Description string `json:"Description,omitempty"`
+	// Type is the name of the artifact type, artifacts without type are integration flows
+	Type string `json:"Type,omitempty"`
+}
+
+// ArtifactType returns the type of the artifact
+func (a DesigntimeArtifact) ArtifactType() (ArtifactType, error) {
+	if len(a.Type) == 0 {
+		return IntegrationFlow, nil
+	}
+	for _, artifactType := range ArtifactTypes {
+		if artifactType.Name == a.Type {
+			return artifactType, nil
+		}
+	}
+	names := []string{}
+	for _, artifactType := range ArtifactTypes {
+		names = append(names, artifactType.Name)
+	}
+	return ArtifactType{}, errors.Errorf("artifact %v has the unsupported type '%v', supported types: %v", a.ID, a.Type, strings.Join(names, ", "))
+}
+
+// Describe returns the type and id of the artifact for log and error messages, e.g. "value mapping VM_Countries"
+func (a DesigntimeArtifact) Describe() string {
+	artifactType, err := a.ArtifactType()
+	if err != nil {
+		return "artifact " + a.ID
+	}
+	return artifactType.Description + " " + a.ID
+}
+
+// ConfigurationParameter is an externalized parameter of an integration flow
+type ConfigurationParameter struct {
+	ParameterKey   string `json:"ParameterKey"`
+	ParameterValue string `json:"ParameterValue"`
+	DataType       string `json:"DataType,omitempty"`
+}
+
+// PackageClient provides access to the design time artifacts of integration packages
+type PackageClient struct {
+	host       string
+	httpClient piperhttp.Sender
+}
+
+// NewPackageClient fetches a bearer token with the given service key and prepares the http client for the OData API calls
+func NewPackageClient(serviceKey ServiceKey, httpClient piperhttp.Sender) (*PackageClient, error) {
+	tokenParameters := TokenParameters{TokenURL: serviceKey.OAuth.OAuthTokenProviderURL, Username: serviceKey.OAuth.ClientID, Password: serviceKey.OAuth.ClientSecret, Client: httpClient}
+	token, err := CommonUtils.GetBearerToken(tokenParameters)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch Bearer Token")
+	}
+	httpClient.SetOptions(piperhttp.ClientOptions{Token: fmt.Sprintf("Bearer %s", token)})
+	return &PackageClient{host: serviceKey.OAuth.Host, httpClient: httpClient}, nil
+}
+
+// GetArtifacts lists the artifacts of all supported types of the package
+func (c *PackageClient) GetArtifacts(packageID string) ([]DesigntimeArtifact, error) {
+	artifacts := []DesigntimeArtifact{}
+	for _, artifactType := range ArtifactTypes {
+		var response struct {
+			D struct {
+				Results []DesigntimeArtifact `json:"results"`
+			} `json:"d"`
+		}
+		requestURL := fmt.Sprintf("%s/api/v1/IntegrationPackages('%s')/%s", c.host, url.PathEscape(packageID), artifactType.EntitySet)
+		body, err := c.send(http.MethodGet, requestURL, nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %vs of integration package %v", artifactType.Description, packageID)
+		}
+		for _, artifact := range response.D.Results {
+			artifact.Type = artifactType.Name
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts, nil
+}
+
+// DownloadArtifact returns the zip archive of the active version of the artifact
+func (c *PackageClient) DownloadArtifact(artifact DesigntimeArtifact) ([]byte, error) {
+	artifactURL, err := c.artifactURL(artifact)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(http.MethodGet, artifactURL+"/$value", nil, http.StatusOK)
+}
+
+// GetConfigurations returns the externalized parameters of the integration flow
+func (c *PackageClient) GetConfigurations(artifactID string) ([]ConfigurationParameter, error) {
+	var response struct {
+		D struct {
+			Results []ConfigurationParameter `json:"results"`
+		} `json:"d"`
+	}
+	body, err := c.send(http.MethodGet, c.integrationFlowURL(artifactID)+"/Configurations", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse configuration of integration flow %v", artifactID)
+	}
+	return response.D.Results, nil
+}
+
+// CreateArtifact creates a new artifact in the package from the zip archive
+func (c *PackageClient) CreateArtifact(packageID string, artifact DesigntimeArtifact, content []byte) error {
+	artifactType, err := artifact.ArtifactType()
+	if err != nil {
+		return err
+	}
+	payload := map[string]string{
+		"Name":            artifact.Name,
+		"Id":              artifact.ID,
+		"PackageId":       packageID,
+		"ArtifactContent": b64.StdEncoding.EncodeToString(content),
+	}
+	_, err = c.sendJSON(http.MethodPost, fmt.Sprintf("%s/api/v1/%s", c.host, artifactType.EntitySet), payload, http.StatusCreated)
+	return err
+}
+
+// UpdateArtifact replaces the content of the active version of the artifact.
+// Artifacts of types which cannot be updated are deleted and created again.
+func (c *PackageClient) UpdateArtifact(packageID string, artifact DesigntimeArtifact, content []byte) error {
+	artifactType, err := artifact.ArtifactType()
+	if err != nil {
+		return err
+	}
+	if !artifactType.Updatable {
+		if err := c.DeleteArtifact(artifact); err != nil {
+			return err
+		}
+		return c.CreateArtifact(packageID, artifact, content)
+	}
+	payload := map[string]string{
+		"Name":            artifact.Name,
+		"ArtifactContent": b64.StdEncoding.EncodeToString(content),
+	}
+	artifactURL, err := c.artifactURL(artifact)
+	if err != nil {
+		return err
+	}
+	_, err = c.sendJSON(http.MethodPut, artifactURL, payload, http.StatusOK)
+	return err
+}
+
+// DeleteArtifact removes the artifact from the design time
+func (c *PackageClient) DeleteArtifact(artifact DesigntimeArtifact) error {
+	artifactURL, err := c.artifactURL(artifact)
+	if err != nil {
+		return err
+	}
+	_, err = c.send(http.MethodDelete, artifactURL, nil, http.StatusOK)
+	return err
+}
+
+// DeployArtifact triggers the deployment of the active version of the artifact to the runtime
+func (c *PackageClient) DeployArtifact(artifact DesigntimeArtifact) error {
+	artifactType, err := artifact.ArtifactType()
+	if err != nil {
+		return err
+	}
+	// e.g. DeployValueMappingDesigntimeArtifact for the entity set ValueMappingDesigntimeArtifacts
+	requestURL := fmt.Sprintf("%s/api/v1/Deploy%s?Id='%s'&Version='active'", c.host, strings.TrimSuffix(artifactType.EntitySet, "s"), url.QueryEscape(artifact.ID))
+	_, err = c.send(http.MethodPost, requestURL, nil, http.StatusAccepted)
+	return err
+}
+
+// UpdateConfiguration sets the value of an externalized parameter of the integration flow
+func (c *PackageClient) UpdateConfiguration(artifactID, key, value string) error {
+	payload := map[string]string{"ParameterValue": value}
+	requestURL := fmt.Sprintf("%s/$links/Configurations('%s')", c.integrationFlowURL(artifactID), url.PathEscape(key))
+	_, err := c.sendJSON(http.MethodPut, requestURL, payload, http.StatusAccepted)
+	return err
+}
+
+func (c *PackageClient) artifactURL(artifact DesigntimeArtifact) (string, error) {
+	artifactType, err := artifact.ArtifactType()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/api/v1/%s(Id='%s',Version='active')", c.host, artifactType.EntitySet, url.PathEscape(artifact.ID)), nil
+}
+
+func (c *PackageClient) integrationFlowURL(artifactID string) string {
+	return fmt.Sprintf("%s/api/v1/%s(Id='%s',Version='active')", c.host, IntegrationFlow.EntitySet, url.PathEscape(artifactID))
+}
+
+func (c *PackageClient) sendJSON(method, requestURL string, payload interface{}, expectedStatusCode int) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request payload")
+	}
+	return c.send(method, requestURL, bytes.NewBuffer(body), expectedStatusCode)
+}
+
+func (c *PackageClient) send(method, requestURL string, body io.Reader, expectedStatusCode int) ([]byte, error) {
+	header := make(http.Header)
+	header.Add("Accept", "application/json")
+	if body != nil {
+		header.Add("Content-Type", "application/json")
+	}
+	response, httpErr := c.httpClient.SendRequest(method, requestURL, body, header, nil)
+	if response != nil && response.Body != nil {
+		defer response.Body.Close()
+	}
+	if response == nil {
+		return nil, errors.Errorf("did not retrieve a HTTP response: %v", httpErr)
+	}
+	responseBody, readErr := io.ReadAll(response.Body)
+	if readErr != nil {
+		return nil, errors.Wrapf(readErr, "HTTP response body could not be read, Response status code: %v", response.StatusCode)
+	}
+	if httpErr != nil || response.StatusCode != expectedStatusCode {
+		log.Entry().Errorf("a HTTP error occurred! Response body: %v, Response status code: %v", string(responseBody), response.StatusCode)
+		return nil, errors.Errorf("HTTP %v request to %v failed, Response Status code: %v", method, requestURL, response.StatusCode)
+	}
+	return responseBody, nil
+}
@@ -0,0 +1,323 @@
+package cpi
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	artifactDescriptorFile    = "artifact.json"
+	artifactConfigurationFile = "configuration.json"
+	artifactContentDirectory  = "content"
+)
+
+// PackageArtifact is the unpacked representation of an artifact of an integration package as it is stored inside a git repository
+type PackageArtifact struct {
+	Artifact      DesigntimeArtifact
+	Files         map[string][]byte
+	Configuration map[string]string
+}
+
+// UnpackArtifact extracts the zip archive of an artifact and combines it with the externalized parameters of integration flows
+func UnpackArtifact(artifact DesigntimeArtifact, content []byte, configuration []ConfigurationParameter) (PackageArtifact, error) {
+	unpacked := PackageArtifact{
+		Artifact:      DesigntimeArtifact{ID: artifact.ID, Name: artifact.Name, Type: artifact.Type},
+		Files:         map[string][]byte{},
+		Configuration: ConfigurationValues(configuration),
+	}
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return unpacked, errors.Wrapf(err, "failed to open content of %v", artifact.Describe())
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		name, err := cleanArtifactPath(file.Name)
+		if err != nil {
+			return unpacked, errors.Wrapf(err, "invalid content of %v", artifact.Describe())
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return unpacked, errors.Wrapf(err, "failed to read %v of %v", file.Name, artifact.Describe())
+		}
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return unpacked, errors.Wrapf(err, "failed to read %v of %v", file.Name, artifact.Describe())
+		}
+		unpacked.Files[name] = data
+	}
+	return unpacked, nil
+}
+
+// ConfigurationValues maps the keys of the externalized parameters to their values
+func ConfigurationValues(configuration []ConfigurationParameter) map[string]string {
+	values := map[string]string{}
+	for _, parameter := range configuration {
+		values[parameter.ParameterKey] = parameter.ParameterValue
+	}
+	return values
+}
+
+// Zip packs the files of the artifact into a zip archive.
+// Entries are sorted and carry a fixed timestamp, so the same content always results in the same archive.
+func (a PackageArtifact) Zip() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, name := range sortedKeys(a.Files) {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		header.Modified = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to add %v to content of %v", name, a.Artifact.Describe())
+		}
+		if _, err := w.Write(a.Files[name]); err != nil {
+			return nil, errors.Wrapf(err, "failed to add %v to content of %v", name, a.Artifact.Describe())
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to create content of %v", a.Artifact.Describe())
+	}
+	return buffer.Bytes(), nil
+}
+
+// WritePackageArtifact stores the artifact in the directory <dir>/<artifact id>.
+// Files which are no longer part of the artifact are removed.
+func WritePackageArtifact(dir string, a PackageArtifact) error {
+	artifactDir := filepath.Join(dir, a.Artifact.ID)
+	contentDir := filepath.Join(artifactDir, artifactContentDirectory)
+	if err := os.RemoveAll(contentDir); err != nil {
+		return errors.Wrapf(err, "failed to clean up directory %v", contentDir)
+	}
+	for name, data := range a.Files {
+		target := filepath.Join(contentDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return errors.Wrapf(err, "failed to create directory for %v", target)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return errors.Wrapf(err, "failed to write %v", target)
+		}
+	}
+	if err := writeJSONFile(filepath.Join(artifactDir, artifactDescriptorFile), a.Artifact); err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(artifactDir, artifactConfigurationFile), a.Configuration)
+}
+
+// ReadPackageArtifacts reads all artifacts stored below the directory.
+// Every sub directory containing an artifact.json is treated as artifact, the type defaults to integration flow.
+func ReadPackageArtifacts(dir string) ([]PackageArtifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []PackageArtifact{}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read directory %v", dir)
+	}
+	artifacts := []PackageArtifact{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		artifactDir := filepath.Join(dir, entry.Name())
+		descriptor := filepath.Join(artifactDir, artifactDescriptorFile)
+		if _, err := os.Stat(descriptor); err != nil {
+			continue
+		}
+		a := PackageArtifact{Files: map[string][]byte{}, Configuration: map[string]string{}}
+		if err := readJSONFile(descriptor, &a.Artifact); err != nil {
+			return nil, err
+		}
+		if len(a.Artifact.ID) == 0 {
+			return nil, errors.Errorf("%v does not contain the id of the artifact", descriptor)
+		}
+		if _, err := a.Artifact.ArtifactType(); err != nil {
+			return nil, errors.Wrapf(err, "invalid %v", descriptor)
+		}
+		if err := readJSONFile(filepath.Join(artifactDir, artifactConfigurationFile), &a.Configuration); err != nil && !os.IsNotExist(errors.Cause(err)) {
+			return nil, err
+		}
+		contentDir := filepath.Join(artifactDir, artifactContentDirectory)
+		err := filepath.Walk(contentDir, func(p string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			name, err := filepath.Rel(contentDir, p)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			a.Files[filepath.ToSlash(name)] = data
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read content of %v", a.Artifact.Describe())
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, nil
+}
+
+// FileDiff describes the difference of a single file of an artifact
+type FileDiff struct {
+	Path   string
+	Status string
+	Diff   string
+}
+
+// ConfigurationDiff describes a changed externalized parameter of an integration flow
+type ConfigurationDiff struct {
+	Key         string
+	TenantValue string
+	RepoValue   string
+}
+
+// ArtifactDiff lists the differences between the tenant and the repository state of an artifact
+type ArtifactDiff struct {
+	ArtifactID    string
+	Files         []FileDiff
+	Configuration []ConfigurationDiff
+}
+
+// file diff states
+const (
+	FileAdded    = "added"
+	FileRemoved  = "removed"
+	FileModified = "modified"
+)
+
+// HasChanges returns true in case content or configuration differ
+func (d ArtifactDiff) HasChanges() bool {
+	return len(d.Files) > 0 || len(d.Configuration) > 0
+}
+
+// ContentChanged returns true in case files of the artifact differ
+func (d ArtifactDiff) ContentChanged() bool {
+	return len(d.Files) > 0
+}
+
+// String renders the difference like a unified diff with the tenant state as origin
+func (d ArtifactDiff) String() string {
+	var sb strings.Builder
+	for _, file := range d.Files {
+		sb.WriteString(fmt.Sprintf("%v: %v\n", file.Status, file.Path))
+		sb.WriteString(file.Diff)
+	}
+	for _, parameter := range d.Configuration {
+		sb.WriteString(fmt.Sprintf("configuration %v: '%v' -> '%v'\n", parameter.Key, parameter.TenantValue, parameter.RepoValue))
+	}
+	return sb.String()
+}
+
+// DiffArtifact compares the tenant state of an artifact with the state stored in the repository
+func DiffArtifact(tenant, repo PackageArtifact) ArtifactDiff {
+	diff := ArtifactDiff{ArtifactID: repo.Artifact.ID}
+	if len(diff.ArtifactID) == 0 {
+		diff.ArtifactID = tenant.Artifact.ID
+	}
+	for _, name := range sortedKeys(mergeKeys(tenant.Files, repo.Files)) {
+		tenantData, onTenant := tenant.Files[name]
+		repoData, inRepo := repo.Files[name]
+		switch {
+		case !onTenant:
+			diff.Files = append(diff.Files, FileDiff{Path: name, Status: FileAdded, Diff: textDiff(name, nil, repoData)})
+		case !inRepo:
+			diff.Files = append(diff.Files, FileDiff{Path: name, Status: FileRemoved, Diff: textDiff(name, tenantData, nil)})
+		case !bytes.Equal(tenantData, repoData):
+			diff.Files = append(diff.Files, FileDiff{Path: name, Status: FileModified, Diff: textDiff(name, tenantData, repoData)})
+		}
+	}
+	for _, key := range sortedKeys(repo.Configuration) {
+		if tenant.Configuration[key] != repo.Configuration[key] {
+			diff.Configuration = append(diff.Configuration, ConfigurationDiff{Key: key, TenantValue: tenant.Configuration[key], RepoValue: repo.Configuration[key]})
+		}
+	}
+	return diff
+}
+
+func textDiff(name string, tenantData, repoData []byte) string {
+	if !utf8.Valid(tenantData) || !utf8.Valid(repoData) {
+		return fmt.Sprintf("Binary file %v differs\n", name)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(tenantData)),
+		B:        difflib.SplitLines(string(repoData)),
+		FromFile: path.Join("tenant", name),
+		ToFile:   path.Join("repo", name),
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Sprintf("File %v differs\n", name)
+	}
+	return diff
+}
+
+func cleanArtifactPath(name string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errors.Errorf("%s: illegal file path", name)
+	}
+	return cleaned, nil
+}
+
+func writeJSONFile(file string, content interface{}) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize %v", file)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %v", file)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %v", file)
+	}
+	return nil
+}
+
+func readJSONFile(file string, content interface{}) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %v", file)
+	}
+	if err := json.Unmarshal(data, content); err != nil {
+		return errors.Wrapf(err, "failed to parse %v", file)
+	}
+	return nil
+}
+
+func mergeKeys(a, b map[string][]byte) map[string][]byte {
+	merged := map[string][]byte{}
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
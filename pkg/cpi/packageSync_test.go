@@ -0,0 +1,160 @@
+//go:build unit
+// +build unit
+
+package cpi
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createZip(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestUnpackArtifact(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		content := createZip(t, map[string]string{
+			"META-INF/MANIFEST.MF":                      "Manifest-Version: 1.0\n",
+			"src/main/resources/scenarioflows/flow.xml": "<flow/>\n",
+		})
+
+		artifact, err := UnpackArtifact(DesigntimeArtifact{ID: "flow1", Name: "Flow 1", Version: "1.0.3"}, content, []ConfigurationParameter{{ParameterKey: "host", ParameterValue: "example.com"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, DesigntimeArtifact{ID: "flow1", Name: "Flow 1"}, artifact.Artifact)
+		assert.Equal(t, map[string]string{"host": "example.com"}, artifact.Configuration)
+		assert.Equal(t, []byte("<flow/>\n"), artifact.Files["src/main/resources/scenarioflows/flow.xml"])
+		assert.Len(t, artifact.Files, 2)
+	})
+
+	t.Run("illegal path", func(t *testing.T) {
+		content := createZip(t, map[string]string{"../evil.sh": "rm -rf /"})
+
+		_, err := UnpackArtifact(DesigntimeArtifact{ID: "flow1"}, content, nil)
+
+		assert.EqualError(t, err, "invalid content of integration flow flow1: ../evil.sh: illegal file path")
+	})
+
+	t.Run("no zip", func(t *testing.T) {
+		_, err := UnpackArtifact(DesigntimeArtifact{ID: "flow1"}, []byte("no zip"), nil)
+
+		assert.Contains(t, err.Error(), "failed to open content of integration flow flow1")
+	})
+}
+
+func TestPackageArtifactZip(t *testing.T) {
+	artifact := PackageArtifact{Artifact: DesigntimeArtifact{ID: "flow1"}, Files: map[string][]byte{"b.txt": []byte("b"), "a/a.txt": []byte("a")}}
+
+	first, err := artifact.Zip()
+	require.NoError(t, err)
+	second, err := artifact.Zip()
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	unpacked, err := UnpackArtifact(artifact.Artifact, first, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, artifact.Files, unpacked.Files)
+}
+
+func TestWriteAndReadPackageArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	artifact := PackageArtifact{
+		Artifact:      DesigntimeArtifact{ID: "flow1", Name: "Flow 1"},
+		Files:         map[string][]byte{"META-INF/MANIFEST.MF": []byte("manifest"), "src/flow.xml": []byte("<flow/>")},
+		Configuration: map[string]string{"host": "example.com"},
+	}
+	// outdated content of a previous download
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "flow1", "content", "old"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flow1", "content", "old", "removed.xml"), []byte("old"), 0644))
+	// directories without artifact.json are ignored
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+
+	require.NoError(t, WritePackageArtifact(dir, artifact))
+	artifacts, err := ReadPackageArtifacts(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []PackageArtifact{artifact}, artifacts)
+	assert.FileExists(t, filepath.Join(dir, "flow1", "artifact.json"))
+	assert.FileExists(t, filepath.Join(dir, "flow1", "configuration.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "flow1", "content", "old", "removed.xml"))
+
+	t.Run("missing directory", func(t *testing.T) {
+		artifacts, err := ReadPackageArtifacts(filepath.Join(dir, "missing"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, artifacts)
+	})
+
+	t.Run("artifact types", func(t *testing.T) {
+		dir := t.TempDir()
+		mapping := PackageArtifact{
+			Artifact:      DesigntimeArtifact{ID: "mapping1", Name: "Mapping 1", Type: "ValueMapping"},
+			Files:         map[string][]byte{"value_mapping.xml": []byte("<vm/>")},
+			Configuration: map[string]string{},
+		}
+		require.NoError(t, WritePackageArtifact(dir, mapping))
+
+		artifacts, err := ReadPackageArtifacts(dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []PackageArtifact{mapping}, artifacts)
+		artifactType, err := artifacts[0].Artifact.ArtifactType()
+		assert.NoError(t, err)
+		assert.Equal(t, ValueMapping, artifactType)
+	})
+
+	t.Run("unsupported artifact type", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, WritePackageArtifact(dir, PackageArtifact{Artifact: DesigntimeArtifact{ID: "api1", Type: "RestApi"}}))
+
+		_, err := ReadPackageArtifacts(dir)
+
+		assert.ErrorContains(t, err, "artifact api1 has the unsupported type 'RestApi'")
+	})
+}
+
+func TestDiffArtifact(t *testing.T) {
+	tenant := PackageArtifact{
+		Artifact:      DesigntimeArtifact{ID: "flow1"},
+		Files:         map[string][]byte{"flow.xml": []byte("<a/>\n<b/>\n"), "removed.txt": []byte("x\n"), "same.txt": []byte("same\n")},
+		Configuration: map[string]string{"host": "a.example.com", "port": "443"},
+	}
+	repo := PackageArtifact{
+		Artifact:      DesigntimeArtifact{ID: "flow1"},
+		Files:         map[string][]byte{"flow.xml": []byte("<a/>\n<c/>\n"), "added.bin": {0xff, 0xfe}, "same.txt": []byte("same\n")},
+		Configuration: map[string]string{"host": "b.example.com", "port": "443"},
+	}
+
+	diff := DiffArtifact(tenant, repo)
+
+	assert.True(t, diff.HasChanges())
+	assert.True(t, diff.ContentChanged())
+	if assert.Len(t, diff.Files, 3) {
+		assert.Equal(t, FileDiff{Path: "added.bin", Status: FileAdded, Diff: "Binary file added.bin differs\n"}, diff.Files[0])
+		assert.Equal(t, "flow.xml", diff.Files[1].Path)
+		assert.Equal(t, FileModified, diff.Files[1].Status)
+		assert.Contains(t, diff.Files[1].Diff, "--- tenant/flow.xml\n+++ repo/flow.xml\n")
+		assert.Contains(t, diff.Files[1].Diff, "-<b/>\n+<c/>\n")
+		assert.Equal(t, FileRemoved, diff.Files[2].Status)
+	}
+	assert.Equal(t, []ConfigurationDiff{{Key: "host", TenantValue: "a.example.com", RepoValue: "b.example.com"}}, diff.Configuration)
+	assert.Contains(t, diff.String(), "configuration host: 'a.example.com' -> 'b.example.com'")
+
+	assert.False(t, DiffArtifact(tenant, tenant).HasChanges())
+}
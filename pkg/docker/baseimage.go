@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/toolrecord"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+)
+
+// BaseImageReportFilename is the name of the file the result of the base image check is written to
+const BaseImageReportFilename = "piper_base_image_report.json"
+
+// BaseImage identifies the image a container image has been built from
+type BaseImage struct {
+	// Reference is the reference used in the build, typically containing a tag, e.g. alpine:3.19
+	Reference string `json:"reference"`
+	// Digest is the digest the reference pointed to at build time
+	Digest string `json:"digest,omitempty"`
+}
+
+// ParseBaseImage parses the string representation of a base image, i.e. the reference optionally followed by @ and the digest
+func ParseBaseImage(value string) BaseImage {
+	reference, digest, _ := strings.Cut(strings.TrimSpace(value), "@")
+	return BaseImage{Reference: reference, Digest: digest}
+}
+
+// String returns the reference and the digest separated by @, which is the format the base images are stored in the commonPipelineEnvironment
+func (b BaseImage) String() string {
+	if len(b.Digest) == 0 {
+		return b.Reference
+	}
+	return fmt.Sprintf("%v@%v", b.Reference, b.Digest)
+}
+
+// ImageInfoProvider provides information about images in a registry without downloading them
+type ImageInfoProvider interface {
+	GetRemoteImageInfo(string) (v1.Image, error)
+}
+
+// BaseImageStatus is the result of comparing a base image with the current state of the registry
+type BaseImageStatus struct {
+	BaseImage
+	CurrentDigest string `json:"currentDigest,omitempty"`
+	Outdated      bool   `json:"outdated"`
+	// Error holds the reason why the base image could not be checked
+	Error string `json:"error,omitempty"`
+}
+
+// CheckBaseImage determines whether the tag of the base image points to a different digest than at build time
+func CheckBaseImage(baseImage BaseImage, images ImageInfoProvider) (BaseImageStatus, error) {
+	status := BaseImageStatus{BaseImage: baseImage}
+	if len(baseImage.Digest) == 0 {
+		return status, fmt.Errorf("no digest recorded for base image '%v'", baseImage.Reference)
+	}
+	img, err := images.GetRemoteImageInfo(baseImage.Reference)
+	if err != nil {
+		return status, errors.Wrapf(err, "failed to retrieve base image '%v'", baseImage.Reference)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return status, errors.Wrapf(err, "failed to retrieve digest of base image '%v'", baseImage.Reference)
+	}
+	status.CurrentDigest = digest.String()
+	status.Outdated = status.CurrentDigest != baseImage.Digest
+	return status, nil
+}
+
+// PersistBaseImageToolrecord writes a toolrecord for the build tool containing the base images the images have been built from.
+// It returns the name of the toolrecord file, which is empty if there are no base images.
+func PersistBaseImageToolrecord(fileUtils piperutils.FileUtils, workspace, toolName, registryURL string, baseImages []BaseImage) (string, error) {
+	if len(baseImages) == 0 {
+		return "", nil
+	}
+	toolInstance := registryURL
+	if len(toolInstance) == 0 {
+		toolInstance = toolName
+	}
+	record := toolrecord.New(fileUtils, workspace, toolName, toolInstance)
+	for _, baseImage := range baseImages {
+		if err := record.AddKeyData("baseImage", baseImage.String(), baseImage.Reference, ""); err != nil {
+			return "", err
+		}
+	}
+	if err := record.AddContext("baseImages", baseImages); err != nil {
+		return "", err
+	}
+	if err := record.Persist(); err != nil {
+		return "", err
+	}
+	return record.GetFileName(), nil
+}
+
+// WriteBaseImageReport writes the result of the base image check as JSON file
+func WriteBaseImageReport(statuses []BaseImageStatus, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	content, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal base image report")
+	}
+	if err := fileUtils.FileWrite(BaseImageReportFilename, content, 0666); err != nil {
+		return nil, fmt.Errorf("failed to write base image report: %w", err)
+	}
+	return []piperutils.Path{{Name: "Base Image Report", Target: BaseImageReportFilename}}, nil
+}
@@ -0,0 +1,114 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type imageInfoMock struct {
+	images map[string]v1.Image
+}
+
+func (i *imageInfoMock) GetRemoteImageInfo(reference string) (v1.Image, error) {
+	img, ok := i.images[reference]
+	if !ok {
+		return nil, fmt.Errorf("image %v not found", reference)
+	}
+	return img, nil
+}
+
+func TestParseBaseImage(t *testing.T) {
+	baseImage := ParseBaseImage("alpine:3.19@sha256:abc")
+	assert.Equal(t, BaseImage{Reference: "alpine:3.19", Digest: "sha256:abc"}, baseImage)
+	assert.Equal(t, "alpine:3.19@sha256:abc", baseImage.String())
+
+	baseImage = ParseBaseImage(" my.registry:5000/node:20 ")
+	assert.Equal(t, BaseImage{Reference: "my.registry:5000/node:20"}, baseImage)
+	assert.Equal(t, "my.registry:5000/node:20", baseImage.String())
+}
+
+func TestCheckBaseImage(t *testing.T) {
+	img, err := random.Image(10, 1)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	images := &imageInfoMock{images: map[string]v1.Image{"alpine:3.19": img}}
+
+	t.Run("up to date", func(t *testing.T) {
+		status, err := CheckBaseImage(BaseImage{Reference: "alpine:3.19", Digest: digest.String()}, images)
+
+		if assert.NoError(t, err) {
+			assert.False(t, status.Outdated)
+			assert.Equal(t, digest.String(), status.CurrentDigest)
+		}
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		status, err := CheckBaseImage(BaseImage{Reference: "alpine:3.19", Digest: "sha256:old"}, images)
+
+		if assert.NoError(t, err) {
+			assert.True(t, status.Outdated)
+			assert.Equal(t, digest.String(), status.CurrentDigest)
+		}
+	})
+
+	t.Run("error - no digest", func(t *testing.T) {
+		_, err := CheckBaseImage(BaseImage{Reference: "alpine:3.19"}, images)
+
+		assert.EqualError(t, err, "no digest recorded for base image 'alpine:3.19'")
+	})
+
+	t.Run("error - image not found", func(t *testing.T) {
+		_, err := CheckBaseImage(BaseImage{Reference: "alpine:3.18", Digest: "sha256:old"}, images)
+
+		assert.EqualError(t, err, "failed to retrieve base image 'alpine:3.18': image alpine:3.18 not found")
+	})
+}
+
+func TestPersistBaseImageToolrecord(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fileUtils := mock.FilesMock{}
+		baseImages := []BaseImage{{Reference: "alpine:3.19", Digest: "sha256:abc"}}
+
+		fileName, err := PersistBaseImageToolrecord(&fileUtils, "workspace", "kanikoExecute", "", baseImages)
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("workspace", "toolruns", "toolrun_kanikoExecute_all.json"), fileName)
+		content, err := fileUtils.FileRead(fileName)
+		require.NoError(t, err)
+		record := struct {
+			ToolInstance string
+			Keys         []struct{ Name, Value string }
+			Context      map[string]interface{}
+		}{}
+		require.NoError(t, json.Unmarshal(content, &record))
+		assert.Equal(t, "kanikoExecute", record.ToolInstance)
+		if assert.Len(t, record.Keys, 1) {
+			assert.Equal(t, "baseImage", record.Keys[0].Name)
+			assert.Equal(t, "alpine:3.19@sha256:abc", record.Keys[0].Value)
+		}
+		assert.Contains(t, record.Context, "baseImages")
+	})
+
+	t.Run("no base images", func(t *testing.T) {
+		fileUtils := mock.FilesMock{}
+
+		fileName, err := PersistBaseImageToolrecord(&fileUtils, "workspace", "kanikoExecute", "", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, fileName)
+		exists, _ := fileUtils.DirExists(filepath.Join("workspace", "toolruns"))
+		assert.False(t, exists)
+	})
+}
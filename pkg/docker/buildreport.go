@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+)
+
+// BuildReportFilename is the name of the file the container build report is written to
+const BuildReportFilename = "container_build_report.json"
+
+// BuildReport describes the images created by a container build
+type BuildReport struct {
+	Images []ImageBuildReport `json:"images"`
+}
+
+// ImageBuildReport describes a single image of a container build
+type ImageBuildReport struct {
+	Image           string      `json:"image,omitempty"`
+	Digest          string      `json:"digest,omitempty"`
+	Dockerfile      string      `json:"dockerfile"`
+	BaseImage       string      `json:"baseImage,omitempty"`
+	BaseImageDigest string      `json:"baseImageDigest,omitempty"`
+	SourceDateEpoch int64       `json:"sourceDateEpoch,omitempty"`
+	Size            int64       `json:"size"`
+	Layers          []LayerInfo `json:"layers"`
+}
+
+// LayerInfo describes a layer of an image together with the instruction which created it
+type LayerInfo struct {
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// ImageLayers returns the layers of the image and their total (compressed) size.
+// The instruction creating a layer is taken from the history of the image configuration.
+func ImageLayers(img v1.Image) ([]LayerInfo, int64, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to read image layers")
+	}
+	createdBy := []string{}
+	if configFile, err := img.ConfigFile(); err == nil && configFile != nil {
+		for _, history := range configFile.History {
+			if !history.EmptyLayer {
+				createdBy = append(createdBy, history.CreatedBy)
+			}
+		}
+	}
+	// the history is not reliable if it does not match the layers, e.g. for images built without history
+	if len(createdBy) != len(layers) {
+		createdBy = nil
+	}
+
+	result := []LayerInfo{}
+	var total int64
+	for i, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to read digest of layer %v", i)
+		}
+		size, err := layer.Size()
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to read size of layer %v", digest)
+		}
+		info := LayerInfo{Digest: digest.String(), Size: size}
+		if createdBy != nil {
+			info.CreatedBy = createdBy[i]
+		}
+		result = append(result, info)
+		total += size
+	}
+	return result, total, nil
+}
+
+// WriteBuildReport writes the build report as JSON file
+func WriteBuildReport(report BuildReport, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal build report")
+	}
+	if err := fileUtils.FileWrite(BuildReportFilename, content, 0666); err != nil {
+		return nil, fmt.Errorf("failed to write build report: %w", err)
+	}
+	return []piperutils.Path{{Name: "Container Build Report", Target: BuildReportFilename}}, nil
+}
@@ -0,0 +1,68 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageLayers(t *testing.T) {
+	t.Run("with history", func(t *testing.T) {
+		layer1, err := random.Layer(100, "application/vnd.oci.image.layer.v1.tar+gzip")
+		require.NoError(t, err)
+		layer2, err := random.Layer(200, "application/vnd.oci.image.layer.v1.tar+gzip")
+		require.NoError(t, err)
+		img, err := mutate.Append(empty.Image,
+			mutate.Addendum{Layer: layer1, History: v1.History{CreatedBy: "COPY package.json ."}},
+			mutate.Addendum{History: v1.History{CreatedBy: "ENV NODE_ENV=production", EmptyLayer: true}},
+			mutate.Addendum{Layer: layer2, History: v1.History{CreatedBy: "RUN npm ci"}},
+		)
+		require.NoError(t, err)
+
+		layers, size, err := ImageLayers(img)
+
+		require.NoError(t, err)
+		if assert.Len(t, layers, 2) {
+			assert.Equal(t, "COPY package.json .", layers[0].CreatedBy)
+			assert.Equal(t, "RUN npm ci", layers[1].CreatedBy)
+			assert.Equal(t, layers[0].Size+layers[1].Size, size)
+		}
+	})
+
+	t.Run("random image", func(t *testing.T) {
+		img, err := random.Image(100, 3)
+		require.NoError(t, err)
+
+		layers, _, err := ImageLayers(img)
+
+		require.NoError(t, err)
+		if assert.Len(t, layers, 3) {
+			assert.Contains(t, layers[0].Digest, "sha256:")
+		}
+	})
+}
+
+func TestWriteBuildReport(t *testing.T) {
+	fileUtils := &mock.FilesMock{}
+	report := BuildReport{Images: []ImageBuildReport{{Image: "my.registry/app:1.0", BaseImage: "alpine:3.19", BaseImageDigest: "sha256:abc", Layers: []LayerInfo{{Digest: "sha256:def", Size: 42}}, Size: 42}}}
+
+	paths, err := WriteBuildReport(report, fileUtils)
+
+	require.NoError(t, err)
+	assert.Equal(t, BuildReportFilename, paths[0].Target)
+	content, err := fileUtils.FileRead(BuildReportFilename)
+	require.NoError(t, err)
+	written := BuildReport{}
+	require.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, report, written)
+}
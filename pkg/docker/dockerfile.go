@@ -0,0 +1,127 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Stage is a build stage of a Dockerfile
+type Stage struct {
+	Name      string
+	BaseImage string
+	Platform  string
+	// BaseImageArg is the argument the base image is given by if the FROM instruction consists of a single variable
+	BaseImageArg string
+}
+
+var dockerfileArgReference = regexp.MustCompile(`^\$(?:\{(\w+)(?::-[^}]*)?\}|(\w+))$`)
+
+// DockerfileStages returns the build stages of a Dockerfile in the order of their definition.
+// Variables in FROM instructions are resolved from the ARG instructions preceding the first FROM,
+// buildArgs take precedence over their default values.
+func DockerfileStages(content []byte, buildArgs map[string]string) []Stage {
+	args := map[string]string{}
+	stages := []Stage{}
+	for _, instruction := range dockerfileInstructions(content) {
+		fields := strings.Fields(instruction)
+		if len(fields) < 2 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "ARG":
+			if len(stages) > 0 {
+				continue
+			}
+			name, value, _ := strings.Cut(fields[1], "=")
+			if buildArg, ok := buildArgs[name]; ok {
+				value = buildArg
+			}
+			args[name] = strings.Trim(value, `"'`)
+		case "FROM":
+			stage := Stage{}
+			fields = fields[1:]
+			for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+				if platform, ok := strings.CutPrefix(fields[0], "--platform="); ok {
+					stage.Platform = expandDockerfileArgs(platform, args)
+				}
+				fields = fields[1:]
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			stage.BaseImage = expandDockerfileArgs(fields[0], args)
+			if match := dockerfileArgReference.FindStringSubmatch(fields[0]); match != nil {
+				stage.BaseImageArg = match[1] + match[2]
+			}
+			if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+				stage.Name = fields[2]
+			}
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
+
+// DockerfileBaseImage returns the external image the final stage of a Dockerfile is based on.
+// Base images referring to previous stages are resolved, an empty string is returned for images built from scratch.
+func DockerfileBaseImage(content []byte, buildArgs map[string]string) string {
+	return DockerfileBaseStage(content, buildArgs).BaseImage
+}
+
+// DockerfileBaseStage returns the stage which defines the external image the final stage of a Dockerfile is based on.
+// An empty stage is returned for images built from scratch.
+func DockerfileBaseStage(content []byte, buildArgs map[string]string) Stage {
+	stages := DockerfileStages(content, buildArgs)
+	if len(stages) == 0 {
+		return Stage{}
+	}
+	baseStage := stages[len(stages)-1]
+	for i := len(stages) - 2; i >= 0; i-- {
+		if strings.EqualFold(stages[i].Name, baseStage.BaseImage) {
+			baseStage = stages[i]
+		}
+	}
+	if strings.EqualFold(baseStage.BaseImage, "scratch") {
+		return Stage{}
+	}
+	return baseStage
+}
+
+// dockerfileInstructions returns the instructions of a Dockerfile with line continuations joined and comments removed
+func dockerfileInstructions(content []byte) []string {
+	instructions := []string{}
+	current := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if continued, ok := strings.CutSuffix(line, `\`); ok {
+			current += continued + " "
+			continue
+		}
+		if instruction := strings.TrimSpace(current + line); len(instruction) > 0 {
+			instructions = append(instructions, instruction)
+		}
+		current = ""
+	}
+	if instruction := strings.TrimSpace(current); len(instruction) > 0 {
+		instructions = append(instructions, instruction)
+	}
+	return instructions
+}
+
+// expandDockerfileArgs replaces $NAME, ${NAME} and ${NAME:-default} with the values of the arguments
+func expandDockerfileArgs(value string, args map[string]string) string {
+	return os.Expand(value, func(name string) string {
+		name, defaultValue, hasDefault := strings.Cut(name, ":-")
+		if arg := args[name]; len(arg) > 0 || !hasDefault {
+			return arg
+		}
+		return defaultValue
+	})
+}
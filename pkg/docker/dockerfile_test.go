@@ -0,0 +1,64 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMultiStageDockerfile = `# syntax=docker/dockerfile:1
+ARG GO_VERSION=1.22
+ARG BASE=gcr.io/distroless/static:nonroot
+
+FROM --platform=$BUILDPLATFORM golang:${GO_VERSION} AS build
+ARG GO_VERSION=1.21
+RUN go build \
+    -o /app .
+
+FROM build AS test
+RUN go test ./...
+
+FROM ${BASE:-alpine:3.19} as final
+COPY --from=build /app /app
+`
+
+func TestDockerfileStages(t *testing.T) {
+	stages := DockerfileStages([]byte(testMultiStageDockerfile), map[string]string{"GO_VERSION": "1.23"})
+
+	assert.Equal(t, []Stage{
+		{Name: "build", BaseImage: "golang:1.23", Platform: ""},
+		{Name: "test", BaseImage: "build"},
+		{Name: "final", BaseImage: "gcr.io/distroless/static:nonroot", BaseImageArg: "BASE"},
+	}, stages)
+}
+
+func TestDockerfileBaseStage(t *testing.T) {
+	assert.Equal(t, Stage{Name: "final", BaseImage: "gcr.io/distroless/static:nonroot", BaseImageArg: "BASE"}, DockerfileBaseStage([]byte(testMultiStageDockerfile), nil))
+	assert.Equal(t, Stage{Name: "deps", BaseImage: "node:20", BaseImageArg: "NODE"}, DockerfileBaseStage([]byte("ARG NODE=node:20\nFROM $NODE AS deps\nFROM deps\n"), nil))
+	assert.Equal(t, Stage{BaseImage: "alpine:3.19"}, DockerfileBaseStage([]byte("ARG VERSION=3.19\nFROM alpine:${VERSION}\n"), nil))
+	assert.Equal(t, Stage{}, DockerfileBaseStage([]byte("FROM scratch\n"), nil))
+}
+
+func TestDockerfileBaseImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		buildArgs  map[string]string
+		expected   string
+	}{
+		{name: "multi-stage", dockerfile: testMultiStageDockerfile, expected: "gcr.io/distroless/static:nonroot"},
+		{name: "default of variable", dockerfile: "ARG BASE\nFROM ${BASE:-alpine:3.19}\n", expected: "alpine:3.19"},
+		{name: "build argument", dockerfile: testMultiStageDockerfile, buildArgs: map[string]string{"BASE": "ubuntu:24.04"}, expected: "ubuntu:24.04"},
+		{name: "final stage based on previous stage", dockerfile: "FROM node:20 AS deps\nRUN npm ci\nFROM deps\nCOPY . .\n", expected: "node:20"},
+		{name: "scratch", dockerfile: "FROM golang AS build\nFROM scratch\n", expected: ""},
+		{name: "no FROM", dockerfile: "# empty\n", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DockerfileBaseImage([]byte(test.dockerfile), test.buildArgs))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"bytes"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+)
+
+// configKeychain provides the credentials of a Docker config.json which is not located in the default location
+type configKeychain struct {
+	config *configfile.ConfigFile
+}
+
+// ConfigKeychain creates a keychain providing the credentials contained in the given Docker config.json content.
+// Registries without credentials are accessed anonymously.
+func ConfigKeychain(dockerConfigJSON []byte) (authn.Keychain, error) {
+	cf, err := config.LoadFromReader(bytes.NewReader(dockerConfigJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse docker config json")
+	}
+	return &configKeychain{config: cf}, nil
+}
+
+// Resolve returns the credentials for the registry of the resource, following the lookup of authn.DefaultKeychain
+func (k *configKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	var cfg, empty types.AuthConfig
+	for _, key := range []string{target.String(), target.RegistryStr()} {
+		if key == name.DefaultRegistry {
+			key = authn.DefaultAuthKey
+		}
+		var err error
+		if cfg, err = k.config.GetAuthConfig(key); err != nil {
+			return nil, err
+		}
+		// GetAuthConfig sets the server address which is not relevant for the comparison
+		cfg.ServerAddress = ""
+		if cfg != empty {
+			break
+		}
+	}
+	if cfg == empty {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authn.AuthConfig{
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		Auth:          cfg.Auth,
+		IdentityToken: cfg.IdentityToken,
+		RegistryToken: cfg.RegistryToken,
+	}), nil
+}
@@ -0,0 +1,61 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigKeychain(t *testing.T) {
+	keychain, err := ConfigKeychain([]byte(`{"auths": {
+		"https://my.registry": {"auth": "dXNlcjpwYXNzd29yZA=="},
+		"https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="}
+	}}`))
+	require.NoError(t, err)
+
+	t.Run("registry with credentials", func(t *testing.T) {
+		ref, err := name.ParseReference("my.registry/base/image:1.0")
+		require.NoError(t, err)
+
+		auth, err := keychain.Resolve(ref.Context())
+
+		require.NoError(t, err)
+		cfg, err := auth.Authorization()
+		require.NoError(t, err)
+		assert.Equal(t, "user", cfg.Username)
+		assert.Equal(t, "password", cfg.Password)
+	})
+
+	t.Run("Docker Hub", func(t *testing.T) {
+		ref, err := name.ParseReference("alpine:3.19")
+		require.NoError(t, err)
+
+		auth, err := keychain.Resolve(ref.Context())
+
+		require.NoError(t, err)
+		cfg, err := auth.Authorization()
+		require.NoError(t, err)
+		assert.Equal(t, "hub", cfg.Username)
+	})
+
+	t.Run("registry without credentials", func(t *testing.T) {
+		ref, err := name.ParseReference("other.registry/image:1.0")
+		require.NoError(t, err)
+
+		auth, err := keychain.Resolve(ref.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, authn.Anonymous, auth)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := ConfigKeychain([]byte("{"))
+		assert.ErrorContains(t, err, "failed to parse docker config json")
+	})
+}
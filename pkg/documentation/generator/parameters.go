@@ -120,7 +120,8 @@ func parameterFurtherInfo(paramName string, stepData *config.StepData, execution
 			if param.Secret {
 				secretInfo := fmt.Sprintf("%s pass via ENV or Jenkins credentials", secretBadge)
 
-				isVaultSecret := param.GetReference("vaultSecret") != nil || param.GetReference("vaultSecretFile") != nil
+				isVaultSecret := param.GetReference("vaultSecret") != nil || param.GetReference("vaultSecretFile") != nil ||
+					param.GetReference(config.RefTypeVaultDynamicSecret) != nil || param.GetReference(config.RefTypeVaultDynamicSecretFile) != nil
 				isTrustengineSecret := param.GetReference(config.RefTypeTrustengineSecret) != nil
 				if isVaultSecret && isTrustengineSecret {
 					secretInfo = fmt.Sprintf(" %s %s %s pass via ENV, Vault, Trust Engine or Jenkins credentials", vaultBadge, trustengineBadge, secretBadge)
@@ -346,6 +347,10 @@ func resourceReferenceDetails(resourceRef []config.ResourceReference) string {
 			resourceDetails = addVaultResourceDetails(resource, resourceDetails)
 			continue
 		}
+		if resource.Type == config.RefTypeVaultDynamicSecret || resource.Type == config.RefTypeVaultDynamicSecretFile {
+			resourceDetails = addVaultDynamicResourceDetails(resource, resourceDetails)
+			continue
+		}
 		if resource.Type == config.RefTypeTrustengineSecret {
 			resourceDetails = addTrustEngineResourceDetails(resource, resourceDetails)
 		}
@@ -368,6 +373,17 @@ func addVaultResourceDetails(resource config.ResourceReference, resourceDetails
 	return resourceDetails
 }
 
+func addVaultDynamicResourceDetails(resource config.ResourceReference, resourceDetails string) string {
+	resourceDetails += "<br/>Vault dynamic secret:<br />"
+	resourceDetails += fmt.Sprintf("&nbsp;&nbsp;name: `%v`<br />", resource.Name)
+	resourceDetails += fmt.Sprintf("&nbsp;&nbsp;default path: `%v`<br />", resource.Default)
+	if resource.Param != "" {
+		resourceDetails += fmt.Sprintf("&nbsp;&nbsp;field: `%v`<br />", resource.Param)
+	}
+
+	return resourceDetails
+}
+
 func addTrustEngineResourceDetails(resource config.ResourceReference, resourceDetails string) string {
 	resourceDetails += "<br/>Trust Engine resource:<br />"
 	resourceDetails += fmt.Sprintf("&nbsp;&nbsp;name: `%v`<br />", resource.Name)
@@ -14,7 +14,10 @@ type Assessment struct {
 	Vulnerability string             `json:"vulnerability"`
 	Status        AssessmentStatus   `json:"status"`
 	Analysis      AssessmentAnalysis `json:"analysis"`
-	Purls         []Purl             `json:"purls"`
+	Purls         []Purl             `json:"purls,omitempty"`
+	// Tool names the scanner of a code finding assessment, the vulnerability is then the finding ID of that scanner
+	Tool          string `json:"tool,omitempty"`
+	Justification string `json:"justification,omitempty"`
 }
 
 type AssessmentStatus string
@@ -37,6 +40,8 @@ const (
 	FixedByDevTeam        AssessmentAnalysis = "fixedByDevTeam"        //"OSS Component fixed by development team"
 	Mitigated             AssessmentAnalysis = "mitigated"             //"Mitigated by the Application"
 	WronglyReported       AssessmentAnalysis = "wronglyReported"       //"Wrongly reported CVE"
+	FalsePositive         AssessmentAnalysis = "falsePositive"         //"Finding is a false positive of the scanner"
+	NotExploitable        AssessmentAnalysis = "notExploitable"        //"Finding cannot be exploited"
 )
 
 type Purl struct {
@@ -77,6 +82,10 @@ func (a Assessment) ToImpactJustification() cdx.ImpactAnalysisJustification {
 		return cdx.IAJProtectedByMitigatingControl
 	case WronglyReported:
 		return cdx.IAJCodeNotPresent
+	case FalsePositive:
+		return cdx.IAJCodeNotPresent
+	case NotExploitable:
+		return cdx.IAJCodeNotReachable
 	}
 	return cdx.IAJProtectedAtRuntime
 }
@@ -99,6 +108,10 @@ func (a Assessment) ToImpactAnalysisResponse() *[]cdx.ImpactAnalysisResponse {
 		return &[]cdx.ImpactAnalysisResponse{cdx.IARWorkaroundAvailable}
 	case WronglyReported:
 		return &[]cdx.ImpactAnalysisResponse{cdx.IARCanNotFix}
+	case FalsePositive:
+		return &[]cdx.ImpactAnalysisResponse{cdx.IARCanNotFix}
+	case NotExploitable:
+		return &[]cdx.ImpactAnalysisResponse{cdx.IARWillNotFix}
 	}
 	return &[]cdx.ImpactAnalysisResponse{cdx.IARWillNotFix}
 }
@@ -123,3 +136,21 @@ func ReadAssessments(assessmentFile io.ReadCloser) (*[]Assessment, error) {
 	}
 	return &ignore.Assessments, nil
 }
+
+// WriteAssessments writes the assessments in the format read by ReadAssessments
+func WriteAssessments(assessmentFile io.Writer, assessments []Assessment) error {
+	ignore := struct {
+		Assessments []Assessment `json:"ignore"`
+	}{
+		Assessments: assessments,
+	}
+
+	content, err := yaml.Marshal(ignore)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal assessments")
+	}
+	if _, err := assessmentFile.Write(content); err != nil {
+		return errors.Wrap(err, "failed to write assessments")
+	}
+	return nil
+}
@@ -40,9 +40,17 @@ type Results struct {
 	CodeFlows           []CodeFlow          `json:"codeFlows,omitempty"`
 	RelatedLocations    []RelatedLocation   `json:"relatedLocations,omitempty"`
 	PartialFingerprints PartialFingerprints `json:"partialFingerprints,omitempty"`
+	Suppressions        []Suppression       `json:"suppressions,omitempty"`
 	Properties          *SarifProperties    `json:"properties,omitempty"`
 }
 
+// Suppression marks a finding as accepted, e.g. because it is part of a baseline
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
+
 // Message to detail the finding
 type Message struct {
 	Text string `json:"text,omitempty"`
@@ -83,6 +91,8 @@ type Region struct {
 // LogicalLocation of the finding
 type LogicalLocation struct {
 	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Name               string `json:"name,omitempty"`
+	Kind               string `json:"kind,omitempty"`
 }
 
 // PartialFingerprints
@@ -91,6 +101,8 @@ type PartialFingerprints struct {
 	CheckmarxSimilarityID   string `json:"checkmarxSimilarityID,omitempty"`
 	PrimaryLocationLineHash string `json:"primaryLocationLineHash,omitempty"`
 	PackageURLPlusCVEHash   string `json:"packageUrlPlusCveHash,omitempty"`
+	AbapFindingHash         string `json:"abapFindingHash,omitempty"`
+	SecretFingerprint       string `json:"secretFingerprint,omitempty"`
 }
 
 // SarifProperties adding additional information/context to the finding
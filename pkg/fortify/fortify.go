@@ -67,6 +67,7 @@ type System interface {
 	GetIssueDetails(projectVersionId int64, issueInstanceId string) ([]*models.ProjectVersionIssue, error)
 	GetAllIssueDetails(projectVersionId int64) ([]*models.ProjectVersionIssue, error)
 	GetIssueComments(parentId int64) ([]*models.IssueAuditComment, error)
+	AuditIssue(projectVersionID int64, issue *models.ProjectVersionIssue, suppressed bool, comment string) error
 	UploadResultFile(endpoint, file string, projectVersionID int64) error
 	DownloadReportFile(endpoint string, reportID int64) ([]byte, error)
 	DownloadResultFile(endpoint string, projectVersionID int64) ([]byte, error)
@@ -680,6 +681,49 @@ func (sys *SystemInstance) GetIssueComments(parentId int64) ([]*models.IssueAudi
 	return result.GetPayload().Data, nil
 }
 
+// AuditIssue suppresses or unsuppresses the issue of the project version and adds the comment to its audit history
+func (sys *SystemInstance) AuditIssue(projectVersionID int64, issue *models.ProjectVersionIssue, suppressed bool, comment string) error {
+	revision := int32(0)
+	if issue.Revision != nil {
+		revision = *issue.Revision
+	}
+	if suppressed || len(comment) > 0 {
+		request := &models.IssueAuditRequest{
+			Comment:        comment,
+			CustomTagAudit: []*models.CustomTag{},
+			Issues:         []*models.EntityStateIdentifier{{ID: issue.ID, Revision: revision}},
+			Suppressed:     suppressed,
+		}
+		params := &issue_of_project_version_controller.AuditIssueOfProjectVersionParams{ParentID: projectVersionID, Resource: request}
+		params.WithTimeout(sys.timeout)
+		result, err := sys.client.IssueOfProjectVersionController.AuditIssueOfProjectVersion(params, sys)
+		if err != nil {
+			return fmt.Errorf("Error from url %s %w", sys.serverURL, err)
+		}
+		if suppressed {
+			return nil
+		}
+		// the audit request omits suppressed=false, unsuppressing requires the new revision of the issue
+		if payload := result.GetPayload(); payload != nil && payload.Data != nil {
+			for _, audited := range payload.Data.Data {
+				if audited.ID == issue.ID {
+					revision = audited.Revision
+				}
+			}
+		}
+	}
+	request := &models.IssueSuppressRequest{
+		Issues:     []*models.EntityStateIdentifier{{ID: issue.ID, Revision: revision}},
+		Suppressed: &suppressed,
+	}
+	params := &issue_of_project_version_controller.SuppressIssueOfProjectVersionParams{ParentID: projectVersionID, Resource: request}
+	params.WithTimeout(sys.timeout)
+	if _, err := sys.client.IssueOfProjectVersionController.SuppressIssueOfProjectVersion(params, sys); err != nil {
+		return fmt.Errorf("Error from url %s %w", sys.serverURL, err)
+	}
+	return nil
+}
+
 func (sys *SystemInstance) invalidateFileTokens() error {
 	log.Entry().Debug("invalidating file tokens")
 	params := &file_token_controller.MultiDeleteFileTokenParams{}
@@ -0,0 +1,104 @@
+package fortify
+
+import (
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/piper-validation/fortify-client-go/models"
+	"github.com/pkg/errors"
+)
+
+// values of the Analysis tag of Fortify issues
+const (
+	analysisNotAnIssue       = "Not an Issue"
+	analysisSuspicious       = "Suspicious"
+	analysisExploitable      = "Exploitable"
+	analysisBadPractice      = "Bad Practice"
+	analysisReliabilityIssue = "Reliability Issue"
+)
+
+// TriageAuditor synchronizes assessments with the suppression and audit comments of Fortify issues.
+// Assessments are pushed as suppression, the Analysis tag is only pulled.
+type TriageAuditor struct {
+	sys              System
+	projectVersionID int64
+	issues           map[string]*models.ProjectVersionIssue
+}
+
+// NewTriageAuditor creates an auditor for the issues of a project version
+func NewTriageAuditor(sys System, projectVersionID int64) *TriageAuditor {
+	return &TriageAuditor{sys: sys, projectVersionID: projectVersionID}
+}
+
+// Tool returns the tool name of Fortify assessments
+func (t *TriageAuditor) Tool() string {
+	return "fortify"
+}
+
+// Findings returns the issues of the project version identified by their issue instance ID, which is stable across project versions
+func (t *TriageAuditor) Findings() ([]triage.Finding, error) {
+	issues, err := t.sys.GetAllIssueDetails(t.projectVersionID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get issues of project version %v", t.projectVersionID)
+	}
+
+	t.issues = map[string]*models.ProjectVersionIssue{}
+	findings := []triage.Finding{}
+	for _, issue := range issues {
+		if issue.IssueInstanceID == nil || (issue.Removed != nil && *issue.Removed) {
+			continue
+		}
+		t.issues[*issue.IssueInstanceID] = issue
+
+		finding := triage.Finding{ID: *issue.IssueInstanceID, Assessment: issueAssessment(issue)}
+		if finding.Assessment != nil && issue.HasComments != nil && *issue.HasComments {
+			comments, err := t.sys.GetIssueComments(issue.ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get comments of issue %v", finding.ID)
+			}
+			if len(comments) > 0 && comments[0].Comment != nil {
+				finding.Assessment.Justification = unescapeXML(*comments[0].Comment)
+			}
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// Assess suppresses issues which are not relevant and unsuppresses all others
+func (t *TriageAuditor) Assess(finding triage.Finding, assessment format.Assessment) (bool, error) {
+	issue, ok := t.issues[finding.ID]
+	if !ok {
+		return false, errors.Errorf("issue %v is not part of project version %v", finding.ID, t.projectVersionID)
+	}
+	suppressed := assessment.Status == format.NotRelevant
+	if (issue.Suppressed != nil && *issue.Suppressed) == suppressed {
+		return false, nil
+	}
+
+	if err := t.sys.AuditIssue(t.projectVersionID, issue, suppressed, assessment.Justification); err != nil {
+		return false, errors.Wrapf(err, "failed to audit issue %v", finding.ID)
+	}
+	return true, nil
+}
+
+func issueAssessment(issue *models.ProjectVersionIssue) *format.Assessment {
+	analysis := ""
+	if issue.PrimaryTag != nil {
+		analysis = *issue.PrimaryTag
+	}
+	if issue.Suppressed != nil && *issue.Suppressed {
+		if analysis == analysisNotAnIssue {
+			return &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive}
+		}
+		return &format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable}
+	}
+	switch analysis {
+	case analysisNotAnIssue:
+		return &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive}
+	case analysisSuspicious:
+		return &format.Assessment{Status: format.InProcess}
+	case analysisExploitable, analysisBadPractice, analysisReliabilityIssue:
+		return &format.Assessment{Status: format.Relevant}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+//go:build unit
+// +build unit
+
+package fortify
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/triage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const triageIssues = `{"data": [
+	{"id": 1, "issueInstanceId": "A1", "revision": 0, "suppressed": false, "removed": false},
+	{"id": 2, "issueInstanceId": "B2", "revision": 3, "suppressed": false, "removed": false, "primaryTag": "Not an Issue", "hasComments": true},
+	{"id": 3, "issueInstanceId": "C3", "revision": 1, "suppressed": true, "removed": false, "primaryTag": "Exploitable"},
+	{"id": 4, "issueInstanceId": "D4", "revision": 0, "suppressed": false, "removed": false, "primaryTag": "Suspicious"},
+	{"id": 5, "issueInstanceId": "E5", "revision": 0, "suppressed": false, "removed": true, "primaryTag": "Exploitable"}
+], "count": 5, "responseCode": 200}`
+
+func spinUpTriageServer(t *testing.T) (*SystemInstance, *[]string) {
+	requests := []string{}
+	sys, server := spinUpServer(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+strings.TrimSpace(string(body)))
+		rw.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/projectVersions/4711/issues":
+			rw.Write([]byte(triageIssues))
+		case "/issues/2/comments":
+			rw.Write([]byte(`{"data": [{"comment": "sanitized by the framework", "seqNumber": 2}, {"comment": "older", "seqNumber": 1}], "responseCode": 200}`))
+		case "/projectVersions/4711/issues/action/audit":
+			rw.Write([]byte(`{"data": {"data": [{"id": 3, "revision": 2}]}, "responseCode": 200}`))
+		case "/projectVersions/4711/issues/action/suppress":
+			rw.Write([]byte(`{"data": {"data": []}, "responseCode": 200}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	t.Cleanup(server.Close)
+	return sys, &requests
+}
+
+func TestTriageAuditorFindings(t *testing.T) {
+	t.Parallel()
+
+	sys, _ := spinUpTriageServer(t)
+	auditor := NewTriageAuditor(sys, 4711)
+
+	findings, err := auditor.Findings()
+	assert.NoError(t, err)
+	assert.Equal(t, []triage.Finding{
+		{ID: "A1"},
+		{ID: "B2", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive, Justification: "sanitized by the framework"}},
+		{ID: "C3", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.NotExploitable}},
+		{ID: "D4", Assessment: &format.Assessment{Status: format.InProcess}},
+	}, findings)
+
+	_, err = NewTriageAuditor(sys, 1).Findings()
+	assert.ErrorContains(t, err, "failed to get issues of project version 1")
+}
+
+func TestTriageAuditorAssess(t *testing.T) {
+	t.Parallel()
+
+	t.Run("suppress", func(t *testing.T) {
+		sys, requests := spinUpTriageServer(t)
+		auditor := NewTriageAuditor(sys, 4711)
+		findings, err := auditor.Findings()
+		require.NoError(t, err)
+
+		changed, err := auditor.Assess(findings[0], format.Assessment{Status: format.NotRelevant, Justification: "test code"})
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, `POST /projectVersions/4711/issues/action/audit {"comment":"test code","customTagAudit":[],"issues":[{"id":1,"revision":0}],"suppressed":true}`, (*requests)[len(*requests)-1])
+	})
+
+	t.Run("unsuppress", func(t *testing.T) {
+		sys, requests := spinUpTriageServer(t)
+		auditor := NewTriageAuditor(sys, 4711)
+		findings, err := auditor.Findings()
+		require.NoError(t, err)
+		before := len(*requests)
+
+		changed, err := auditor.Assess(findings[2], format.Assessment{Status: format.Relevant, Justification: "reachable from the API"})
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{
+			`POST /projectVersions/4711/issues/action/audit {"comment":"reachable from the API","customTagAudit":[],"issues":[{"id":3,"revision":1}]}`,
+			`POST /projectVersions/4711/issues/action/suppress {"issues":[{"id":3,"revision":2}],"suppressed":false}`,
+		}, (*requests)[before:])
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		sys, requests := spinUpTriageServer(t)
+		auditor := NewTriageAuditor(sys, 4711)
+		findings, err := auditor.Findings()
+		require.NoError(t, err)
+		before := len(*requests)
+
+		changed, err := auditor.Assess(findings[3], format.Assessment{Status: format.Relevant})
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Len(t, *requests, before)
+
+		_, err = auditor.Assess(triage.Finding{ID: "E5"}, format.Assessment{Status: format.NotRelevant})
+		assert.EqualError(t, err, "issue E5 is not part of project version 4711")
+	})
+}
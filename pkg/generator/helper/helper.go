@@ -35,6 +35,7 @@ type stepInfo struct {
 	Outputs          config.StepOutputs
 	Resources        []config.StepResources
 	Secrets          []config.StepSecrets
+	Cache            *config.StepCache
 }
 
 // StepGoTemplate ...
@@ -186,9 +187,20 @@ func {{.CobraCmdFuncName}}() *cobra.Command {
 					{{if $.ExportPrefix}}{{ $.ExportPrefix }}.{{end}}GeneralConfig.EnvRootPath, {{ index $oRes "name" | quote }}{{- end -}}
 				){{- end }}
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = {{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}GitCommit
+				{{- $influxName := "" }}
+				{{- range $notused, $oRes := .OutputResources }}
+				{{- if eq (index $oRes "type") "influx" }}{{ $influxName = index $oRes "name" }}{{ end }}
+				{{- end }}
+				{{- if $influxName }}
+				measurements, tags := {{ $influxName }}.metrics()
+				{{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}ExportStepMetrics(STEP_NAME, &stepTelemetryData, measurements, tags)
+				{{- else }}
+				{{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}ExportStepMetrics(STEP_NAME, &stepTelemetryData, nil, nil)
+				{{- end }}
 				telemetryClient.SetData(&stepTelemetryData)
 				telemetryClient.Send()
 				if len({{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
@@ -221,10 +233,21 @@ func {{.CobraCmdFuncName}}() *cobra.Command {
 					}
 				}
 			}
+			{{- if .Cache }}
+			// registered before the handler so that cached outputs are restored after the handler persisted the output resources
+			stepCache := {{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}NewStepCache(STEP_NAME, &metadata, stepConfig)
+			defer stepCache.Finish(&stepTelemetryData)
+			{{- end }}
 			log.DeferExitHandler(handler)
 			defer handler()
 			telemetryClient.Initialize({{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}GeneralConfig.NoTelemetry, STEP_NAME, {{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}GeneralConfig.HookConfig.PendoConfig.Token)
+			{{- if .Cache }}
+			if !stepCache.Hit() {
+				{{.StepName}}(stepConfig, &stepTelemetryData{{ range $notused, $oRes := .OutputResources}}{{ if ne (index $oRes "type") "reports" }}, &{{ index $oRes "name" }}{{ end }}{{ end }})
+			}
+			{{- else }}
 			{{.StepName}}(stepConfig, &stepTelemetryData{{ range $notused, $oRes := .OutputResources}}{{ if ne (index $oRes "type") "reports" }}, &{{ index $oRes "name" }}{{ end }}{{ end }})
+			{{- end }}
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -339,6 +362,19 @@ func {{ .StepName }}Metadata() config.StepData {
 				}, {{ end }}
 			},
 			{{ end -}}
+			{{- if .Cache -}}
+			Cache: &config.StepCache{
+				{{- if .Cache.Inputs }}
+				Inputs: []string{ {{- range $i, $p := .Cache.Inputs }}{{ if gt $i 0 }}, {{ end }}{{ $p | quote }}{{ end -}} },
+				{{- end }}
+				{{- if .Cache.Outputs }}
+				Outputs: []string{ {{- range $i, $p := .Cache.Outputs }}{{ if gt $i 0 }}, {{ end }}{{ $p | quote }}{{ end -}} },
+				{{- end }}
+				{{- if .Cache.Excludes }}
+				Excludes: []string{ {{- range $i, $p := .Cache.Excludes }}{{ if gt $i 0 }}, {{ end }}{{ $p | quote }}{{ end -}} },
+				{{- end }}
+			},
+			{{ end -}}
 			{{- if .Outputs.Resources -}}
 			Outputs: config.StepOutputs{
 				Resources: []config.StepResources{
@@ -688,6 +724,7 @@ func getStepInfo(stepData *config.StepData, osImport bool, exportPrefix string)
 			Outputs:          stepData.Spec.Outputs,
 			Resources:        stepData.Spec.Inputs.Resources,
 			Secrets:          stepData.Spec.Inputs.Secrets,
+			Cache:            stepData.Spec.Cache,
 		},
 		err
 }
@@ -25,6 +25,11 @@ func configOpenFileMock(name string) (io.ReadCloser, error) {
   longDescription: |
     Long Test description
 spec:
+  cache:
+    inputs:
+      - "**/*.go"
+    outputs:
+      - "report1"
   outputs:
     resources:
       - name: reports
@@ -168,6 +168,30 @@ func (i *{{ .StepName }}{{ .Name | title}}) persist(path, resourceName string) {
 	if errCount > 0 {
 		log.Entry().Error("failed to persist Influx environment")
 	}
+}
+
+func (i *{{ .StepName }}{{ .Name | title}}) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		{{- range $notused, $measurement := .Measurements }}
+		"{{ $measurement.Name }}": {
+			{{- range $notused, $field := $measurement.Fields }}
+			"{{ $field.Name }}": i.{{ $measurement.Name }}.fields.{{ $field.Name | golangName }},
+			{{- end }}
+		},
+		{{- end }}
+	}
+	tags := map[string]map[string]string{
+		{{- range $notused, $measurement := .Measurements }}
+		{{- if $measurement.Tags }}
+		"{{ $measurement.Name }}": {
+			{{- range $notused, $tag := $measurement.Tags }}
+			"{{ $tag.Name }}": i.{{ $measurement.Name }}.tags.{{ $tag.Name | golangName }},
+			{{- end }}
+		},
+		{{- end }}
+		{{- end }}
+	}
+	return fields, tags
 }`
 
 // StructString returns the golang coding for the struct definition of the InfluxResource
@@ -83,6 +83,30 @@ func (i *TestStepTestInflux) persist(path, resourceName string) {
 	if errCount > 0 {
 		log.Entry().Error("failed to persist Influx environment")
 	}
+}
+
+func (i *TestStepTestInflux) metrics() (map[string]map[string]interface{}, map[string]map[string]string) {
+	fields := map[string]map[string]interface{}{
+		"m1": {
+			"field1_1": i.m1.fields.field1_1,
+			"field1_2": i.m1.fields.field1_2,
+		},
+		"m2": {
+			"field2_1": i.m2.fields.field2_1,
+			"field2_2": i.m2.fields.field2_2,
+		},
+	}
+	tags := map[string]map[string]string{
+		"m1": {
+			"tag1_1": i.m1.tags.tag1_1,
+			"tag1_2": i.m1.tags.tag1_2,
+		},
+		"m2": {
+			"tag2_1": i.m2.tags.tag2_1,
+			"tag2_2": i.m2.tags.tag2_2,
+		},
+	}
+	return fields, tags
 }`,
 		},
 	}
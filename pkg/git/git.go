@@ -1,11 +1,14 @@
 package git
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/pkg/errors"
@@ -172,6 +175,124 @@ func LogRange(repo *git.Repository, from, to string) (object.CommitIter, error)
 	return object.NewCommitPreorderIter(cTo, map[plumbing.Hash]bool{}, ignore), nil
 }
 
+// ChangedFiles returns the paths of all files which have been added, modified or deleted on 'head' since it
+// diverged from 'base', i.e. the changes a pull request from 'head' into 'base' consists of.
+// Renamed files are reported with their old and new path.
+func ChangedFiles(repo *git.Repository, base, head string) ([]string, error) {
+	changes, err := changesSinceMergeBase(repo, base, head, "changed files")
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	seen := map[string]bool{}
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if len(name) > 0 && !seen[name] {
+				seen[name] = true
+				files = append(files, name)
+			}
+		}
+	}
+	return files, nil
+}
+
+// LineChanges maps the path of a file to the sorted numbers of the lines which have been added or modified
+type LineChanges map[string][]int
+
+// Contains returns true if the line of the file has been added or modified
+func (c LineChanges) Contains(file string, line int) bool {
+	lines := c[file]
+	i := sort.SearchInts(lines, line)
+	return i < len(lines) && lines[i] == line
+}
+
+// ChangedLines returns the lines which have been added or modified on 'head' since it diverged from 'base',
+// i.e. the lines a pull request from 'head' into 'base' touches. Deleted files and binary files are not contained.
+func ChangedLines(repo *git.Repository, base, head string) (LineChanges, error) {
+	changes, err := changesSinceMergeBase(repo, base, head, "changed lines")
+	if err != nil {
+		return nil, err
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot compute patch")
+	}
+
+	lineChanges := LineChanges{}
+	for _, filePatch := range patch.FilePatches() {
+		_, to := filePatch.Files()
+		if to == nil || filePatch.IsBinary() {
+			continue
+		}
+		lines := []int{}
+		lineNumber := 1
+		for _, chunk := range filePatch.Chunks() {
+			if len(chunk.Content()) == 0 {
+				continue
+			}
+			count := strings.Count(chunk.Content(), "\n")
+			if !strings.HasSuffix(chunk.Content(), "\n") {
+				count++
+			}
+			switch chunk.Type() {
+			case diff.Equal:
+				lineNumber += count
+			case diff.Add:
+				for i := 0; i < count; i++ {
+					lines = append(lines, lineNumber)
+					lineNumber++
+				}
+			}
+		}
+		if len(lines) > 0 {
+			lineChanges[to.Path()] = lines
+		}
+	}
+	return lineChanges, nil
+}
+
+// changesSinceMergeBase compares the tree of 'head' with the tree of the merge base of 'base' and 'head'
+func changesSinceMergeBase(repo *git.Repository, base, head, subject string) (object.Changes, error) {
+	cHead, err := getCommitObject(head, repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot determine %s (head: '%s' not found)", subject, head)
+	}
+	cBase, err := getCommitObject(base, repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot determine %s (base: '%s' not found)", subject, base)
+	}
+	mergeBases, err := cHead.MergeBase(cBase)
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot determine merge base")
+	}
+	if len(mergeBases) == 0 {
+		return nil, errors.Errorf("'%s' and '%s' do not have a common history", base, head)
+	}
+	baseTree, err := mergeBases[0].Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot read tree of merge base")
+	}
+	headTree, err := cHead.Tree()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot read tree of '%s'", head)
+	}
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot compare trees")
+	}
+	return changes, nil
+}
+
+// CommitTime returns the committer time of the commit 'ref' resolves to
+func CommitTime(repo *git.Repository, ref string) (time.Time, error) {
+	c, err := getCommitObject(ref, repo)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "Cannot determine commit time (ref: '%s' not found)", ref)
+	}
+	return c.Committer.When, nil
+}
+
 func getCommitObject(ref string, repo *git.Repository) (*object.Commit, error) {
 	if len(ref) == 0 {
 		// with go-git v5.1.0 we panic otherwise inside ResolveRevision
@@ -14,6 +14,7 @@ import (
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestCommit(t *testing.T) {
@@ -455,3 +456,131 @@ func (UtilsGitMockError) plainClone(string, bool, *git.CloneOptions) (*git.Repos
 func (UtilsGitMockError) plainOpen(path string) (*git.Repository, error) {
 	return nil, errors.New("error during git plain open")
 }
+
+func TestChangedFiles(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	w, err := r.Worktree()
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit := func(files map[string]string) plumbing.Hash {
+		for name, content := range files {
+			f, err := fs.Create(name)
+			assert.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			assert.NoError(t, err)
+			assert.NoError(t, f.Close())
+			_, err = w.Add(name)
+			assert.NoError(t, err)
+		}
+		hash, err := w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "me", Email: "me@example.org"}})
+		assert.NoError(t, err)
+		return hash
+	}
+
+	// A - B <-- master
+	//   \ C <-- HEAD <-- feature
+	hashA := commit(map[string]string{"module-a/pom.xml": "a", "module-b/pom.xml": "b"})
+	commit(map[string]string{"module-b/pom.xml": "changed on master"})
+	assert.NoError(t, w.Checkout(&git.CheckoutOptions{Hash: hashA}))
+	assert.NoError(t, w.Checkout(&git.CheckoutOptions{Create: true, Branch: plumbing.ReferenceName("refs/heads/feature")}))
+	commit(map[string]string{"module-a/src/Main.java": "class Main {}"})
+
+	t.Run("changes since merge base", func(t *testing.T) {
+		files, err := ChangedFiles(r, "master", "HEAD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"module-a/src/Main.java"}, files)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		files, err := ChangedFiles(r, "feature", "HEAD")
+
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+	t.Run("unknown base", func(t *testing.T) {
+		_, err := ChangedFiles(r, "origin/main", "HEAD")
+
+		assert.EqualError(t, err, "Cannot determine changed files (base: 'origin/main' not found): Trouble resolving 'origin/main': reference not found")
+	})
+}
+
+func TestChangedLines(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	w, err := r.Worktree()
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit := func(files map[string]string) plumbing.Hash {
+		for name, content := range files {
+			f, err := fs.Create(name)
+			assert.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			assert.NoError(t, err)
+			assert.NoError(t, f.Close())
+			_, err = w.Add(name)
+			assert.NoError(t, err)
+		}
+		hash, err := w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "me", Email: "me@example.org"}})
+		assert.NoError(t, err)
+		return hash
+	}
+
+	// A - B <-- master
+	//   \ C <-- HEAD <-- feature
+	hashA := commit(map[string]string{"src/Main.java": "class Main {\n  void a() {}\n  void b() {}\n}\n", "README.md": "readme\n"})
+	commit(map[string]string{"README.md": "changed on master\n"})
+	assert.NoError(t, w.Checkout(&git.CheckoutOptions{Hash: hashA}))
+	assert.NoError(t, w.Checkout(&git.CheckoutOptions{Create: true, Branch: plumbing.ReferenceName("refs/heads/feature")}))
+	commit(map[string]string{
+		"src/Main.java": "class Main {\n  void a() {}\n  void changed() {}\n  void added() {}\n}\n",
+		"src/New.java":  "class New {}",
+	})
+
+	t.Run("lines changed since merge base", func(t *testing.T) {
+		changes, err := ChangedLines(r, "master", "HEAD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, LineChanges{"src/Main.java": {3, 4}, "src/New.java": {1}}, changes)
+		assert.True(t, changes.Contains("src/Main.java", 4))
+		assert.False(t, changes.Contains("src/Main.java", 2))
+		assert.False(t, changes.Contains("README.md", 1))
+	})
+	t.Run("unknown head", func(t *testing.T) {
+		_, err := ChangedLines(r, "master", "unknown")
+
+		assert.EqualError(t, err, "Cannot determine changed lines (head: 'unknown' not found): Trouble resolving 'unknown': reference not found")
+	})
+}
+
+func TestCommitTime(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if !assert.NoError(t, err) {
+		return
+	}
+	w, err := r.Worktree()
+	if !assert.NoError(t, err) {
+		return
+	}
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	_, err = w.Commit("commit", &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "me", Email: "me@example.org", When: when}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	commitTime, err := CommitTime(r, "HEAD")
+
+	assert.NoError(t, err)
+	assert.Equal(t, when.Unix(), commitTime.Unix())
+
+	_, err = CommitTime(r, "unknown")
+	assert.EqualError(t, err, "Cannot determine commit time (ref: 'unknown' not found): Trouble resolving 'unknown': reference not found")
+}
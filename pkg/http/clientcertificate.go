@@ -0,0 +1,115 @@
+package http
+
+import (
+	"crypto/tls"
+	"strings"
+	"sync"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var contextKeyRequestHost = &contextKey{"RequestHost"}
+
+var clientCertificates = struct {
+	sync.RWMutex
+	byHost map[string]tls.Certificate
+}{byHost: map[string]tls.Certificate{}}
+
+// RegisterClientCertificate makes all clients present the certificate when the given host requests client authentication.
+// A host like "*.example.com" matches all subdomains of example.com.
+func RegisterClientCertificate(host string, certificate tls.Certificate) {
+	clientCertificates.Lock()
+	defer clientCertificates.Unlock()
+	clientCertificates.byHost[strings.ToLower(host)] = certificate
+}
+
+// ResetClientCertificates removes all registered client certificates
+func ResetClientCertificates() {
+	clientCertificates.Lock()
+	defer clientCertificates.Unlock()
+	clientCertificates.byHost = map[string]tls.Certificate{}
+}
+
+func hasClientCertificates() bool {
+	clientCertificates.RLock()
+	defer clientCertificates.RUnlock()
+	return len(clientCertificates.byHost) > 0
+}
+
+// clientCertificateForHost returns the registered certificate for the host, exact matches take precedence over wildcards
+func clientCertificateForHost(host string) (tls.Certificate, bool) {
+	clientCertificates.RLock()
+	defer clientCertificates.RUnlock()
+	host = strings.ToLower(host)
+	if certificate, ok := clientCertificates.byHost[host]; ok {
+		return certificate, true
+	}
+	for _, domain := range parentDomains(host) {
+		if certificate, ok := clientCertificates.byHost["*."+domain]; ok {
+			return certificate, true
+		}
+	}
+	return tls.Certificate{}, false
+}
+
+// parentDomains returns the parent domains of the host, the closest one first
+func parentDomains(host string) []string {
+	domains := []string{}
+	for {
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return domains
+		}
+		domains = append(domains, parent)
+		host = parent
+	}
+}
+
+// getClientCertificate selects the certificate registered for the requested host and falls back to the certificates of the client options
+func (c *Client) getClientCertificate(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if host, ok := info.Context().Value(contextKeyRequestHost).(string); ok {
+		if certificate, ok := clientCertificateForHost(host); ok {
+			log.Entry().Debugf("Presenting client certificate for host '%v'", host)
+			return &certificate, nil
+		}
+	}
+	for i := range c.certificates {
+		if err := info.SupportsCertificate(&c.certificates[i]); err == nil {
+			return &c.certificates[i], nil
+		}
+	}
+	// an empty certificate lets the server decide whether client authentication is optional
+	return &tls.Certificate{}, nil
+}
+
+// LoadPKCS12Certificate decodes a PKCS#12 archive containing the client certificate, its private key and optionally the CA chain
+func LoadPKCS12Certificate(data []byte, password string) (tls.Certificate, error) {
+	key, leaf, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "failed to decode PKCS#12 archive")
+	}
+	if leaf == nil {
+		return tls.Certificate{}, errors.New("PKCS#12 archive does not contain a client certificate")
+	}
+
+	certificate := tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	for _, caCertificate := range chain {
+		certificate.Certificate = append(certificate.Certificate, caCertificate.Raw)
+	}
+	return certificate, nil
+}
+
+// LoadPEMCertificate creates a client certificate from PEM encoded certificates, the first one being the client certificate, and the private key
+func LoadPEMCertificate(certificates, key []byte) (tls.Certificate, error) {
+	certificate, err := tls.X509KeyPair(certificates, key)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "failed to load client certificate")
+	}
+	return certificate, nil
+}
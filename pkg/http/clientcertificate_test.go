@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCertificatePerHost(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(req.TLS.PeerCertificates[0].Subject.Organization[0]))
+	}))
+	clientPemKey, clientPemCert := GenerateSelfSignedClientAuthCertificate()
+	clientCertPool := x509.NewCertPool()
+	clientCertPool.AppendCertsFromPEM(clientPemCert)
+	server.TLS = &tls.Config{ClientCAs: clientCertPool, ClientAuth: tls.RequireAndVerifyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certificate, err := LoadPEMCertificate(clientPemCert, clientPemKey)
+	require.NoError(t, err)
+	serverURL, _ := url.Parse(server.URL)
+	localhostURL := "https://localhost:" + serverURL.Port()
+
+	t.Run("certificate registered for host", func(t *testing.T) {
+		defer ResetClientCertificates()
+		RegisterClientCertificate("localhost", certificate)
+
+		c := Client{}
+		c.SetOptions(ClientOptions{TransportSkipVerification: true, MaxRetries: -1})
+		response, err := c.SendRequest(http.MethodGet, localhostURL, nil, nil, nil)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		content, _ := io.ReadAll(response.Body)
+		assert.Equal(t, "My Corp", string(content))
+	})
+
+	t.Run("certificate registered for other host", func(t *testing.T) {
+		defer ResetClientCertificates()
+		RegisterClientCertificate("*.example.com", certificate)
+
+		c := Client{}
+		c.SetOptions(ClientOptions{TransportSkipVerification: true, MaxRetries: -1})
+		_, err := c.SendRequest(http.MethodGet, localhostURL, nil, nil, nil)
+		assert.ErrorContains(t, err, "certificate required")
+	})
+
+	t.Run("fallback to certificates of client options", func(t *testing.T) {
+		defer ResetClientCertificates()
+		RegisterClientCertificate("*.example.com", certificate)
+
+		c := Client{}
+		c.SetOptions(ClientOptions{TransportSkipVerification: true, MaxRetries: -1, Certificates: []tls.Certificate{certificate}})
+		response, err := c.SendRequest(http.MethodGet, localhostURL, nil, nil, nil)
+		require.NoError(t, err)
+		response.Body.Close()
+	})
+}
+
+func TestClientCertificateForHost(t *testing.T) {
+	defer ResetClientCertificates()
+	exact := tls.Certificate{Certificate: [][]byte{[]byte("exact")}}
+	wildcard := tls.Certificate{Certificate: [][]byte{[]byte("wildcard")}}
+	RegisterClientCertificate("API.mesh.example.com", exact)
+	RegisterClientCertificate("*.example.com", wildcard)
+
+	certificate, ok := clientCertificateForHost("api.mesh.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, exact, certificate)
+
+	certificate, ok = clientCertificateForHost("other.mesh.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, wildcard, certificate)
+
+	_, ok = clientCertificateForHost("example.com")
+	assert.False(t, ok)
+}
+
+func TestLoadPKCS12Certificate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "client.p12"))
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		certificate, err := LoadPKCS12Certificate(data, "changeit")
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+		require.NoError(t, err)
+		assert.Equal(t, "piper-client", leaf.Subject.CommonName)
+		assert.NotNil(t, certificate.PrivateKey)
+	})
+
+	t.Run("success - OpenSSL 3 archive with CA chain", func(t *testing.T) {
+		// created with the OpenSSL 3 defaults: PBES2 with AES-256-CBC and a SHA-256 MAC
+		openssl3, err := os.ReadFile(filepath.Join("testdata", "client-openssl3.p12"))
+		require.NoError(t, err)
+		certificate, err := LoadPKCS12Certificate(openssl3, "changeit")
+		require.NoError(t, err)
+		require.Len(t, certificate.Certificate, 2)
+		leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+		require.NoError(t, err)
+		assert.Equal(t, "piper-client", leaf.Subject.CommonName)
+		ca, err := x509.ParseCertificate(certificate.Certificate[1])
+		require.NoError(t, err)
+		assert.Equal(t, "piper-ca", ca.Subject.CommonName)
+		assert.NotNil(t, certificate.PrivateKey)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := LoadPKCS12Certificate(data, "wrong")
+		assert.ErrorContains(t, err, "failed to decode PKCS#12 archive")
+	})
+}
@@ -292,10 +292,7 @@ func (c *Client) initializeHttpClient() *http.Client {
 			ResponseHeaderTimeout: c.transportTimeout,
 			ExpectContinueTimeout: c.transportTimeout,
 			TLSHandshakeTimeout:   c.transportTimeout,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: c.transportSkipVerification,
-				Certificates:       c.certificates,
-			},
+			TLSClientConfig:       c.tlsClientConfig(c.transportSkipVerification, nil),
 		},
 		doLogRequestBodyOnDebug:  c.doLogRequestBodyOnDebug,
 		doLogResponseBodyOnDebug: c.doLogResponseBodyOnDebug,
@@ -369,6 +366,8 @@ var authHeaderKey = "Authorization"
 // Executes HTTP requests with request/response logging.
 func (t *TransportWrapper) RoundTrip(req *http.Request) (*http.Response, error) {
 	ctx := context.WithValue(req.Context(), contextKeyRequestStart, time.Now())
+	// the host is needed to select the client certificate during the TLS handshake
+	ctx = context.WithValue(ctx, contextKeyRequestHost, req.URL.Hostname())
 	req = req.WithContext(ctx)
 
 	handleAuthentication(req, t.username, t.password, t.token)
@@ -516,6 +515,19 @@ func (c *Client) handleResponse(response *http.Response, url string) (*http.Resp
 	return response, fmt.Errorf("request to %v returned with response %v", response.Request.URL, response.Status)
 }
 
+// tlsClientConfig presents the certificates of the client options or, when registered, the client certificate for the requested host
+func (c *Client) tlsClientConfig(insecureSkipVerify bool, rootCAs *x509.CertPool) *tls.Config {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+		RootCAs:            rootCAs,
+		Certificates:       c.certificates,
+	}
+	if hasClientCertificates() {
+		tlsConfig.GetClientCertificate = c.getClientCertificate
+	}
+	return tlsConfig
+}
+
 func (c *Client) applyDefaults() {
 	if c.transportTimeout == 0 {
 		c.transportTimeout = 3 * time.Minute
@@ -550,11 +562,7 @@ func (c *Client) configureTLSToTrustCertificates(transport *TransportWrapper) er
 			ResponseHeaderTimeout: c.transportTimeout,
 			ExpectContinueTimeout: c.transportTimeout,
 			TLSHandshakeTimeout:   c.transportTimeout,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: false,
-				RootCAs:            rootCAs,
-				Certificates:       c.certificates,
-			},
+			TLSClientConfig:       c.tlsClientConfig(false, rootCAs),
 		},
 		doLogRequestBodyOnDebug:  c.doLogRequestBodyOnDebug,
 		doLogResponseBodyOnDebug: c.doLogResponseBodyOnDebug,
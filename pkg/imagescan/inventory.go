@@ -0,0 +1,463 @@
+package imagescan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"debug/buildinfo"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	rpmdb "github.com/knqyf263/go-rpmdb/pkg"
+	"github.com/pkg/errors"
+
+	// registers the sqlite driver used for rpm databases in sqlite format
+	_ "github.com/glebarez/go-sqlite"
+)
+
+// maxNestingDepth limits the recursion into archives contained in Java archives
+const maxNestingDepth = 3
+
+// rpmDatabases are the locations of the rpm databases in Berkeley DB, NDB and sqlite format
+var rpmDatabases = []string{
+	"var/lib/rpm/Packages",
+	"var/lib/rpm/Packages.db",
+	"var/lib/rpm/rpmdb.sqlite",
+	"usr/lib/sysimage/rpm/rpmdb.sqlite",
+}
+
+// OSRelease contains the identification of the operating system of an image as given in os-release
+type OSRelease struct {
+	ID         string
+	VersionID  string
+	PrettyName string
+}
+
+// String returns the human readable name of the operating system
+func (r OSRelease) String() string {
+	if len(r.PrettyName) > 0 {
+		return r.PrettyName
+	}
+	if len(r.ID) == 0 {
+		return "unknown"
+	}
+	return strings.TrimSpace(r.ID + " " + r.VersionID)
+}
+
+// Ecosystem returns the OSV ecosystem of the packages of the operating system, or an empty string if it is not supported
+func (r OSRelease) Ecosystem() string {
+	ecosystem := ""
+	release := r.VersionID
+	switch r.ID {
+	case "debian":
+		ecosystem = osv.EcosystemDebian
+	case "ubuntu":
+		ecosystem = osv.EcosystemUbuntu
+	case "alpine":
+		ecosystem = osv.EcosystemAlpine
+		if parts := strings.Split(release, "."); len(parts) > 1 {
+			release = "v" + parts[0] + "." + parts[1]
+		}
+	case "mariner":
+		ecosystem = osv.EcosystemMariner
+	case "azurelinux":
+		ecosystem = osv.EcosystemAzureLinux
+	case "rocky":
+		ecosystem = osv.EcosystemRocky
+		release = strings.Split(release, ".")[0]
+	case "almalinux":
+		ecosystem = osv.EcosystemAlma
+		release = strings.Split(release, ".")[0]
+	default:
+		return ""
+	}
+	if len(release) == 0 {
+		return ecosystem
+	}
+	return ecosystem + ":" + release
+}
+
+// Inventory lists the operating system and the packages contained in an image
+type Inventory struct {
+	OS       OSRelease
+	Packages []osv.Package
+}
+
+// ReadInventory walks through the flattened file system of the image and collects the packages
+// from the package databases of dpkg, apk and rpm as well as from Java, npm, Python and Go artifacts
+func ReadInventory(image v1.Image) (*Inventory, error) {
+	content := mutate.Extract(image)
+	defer content.Close()
+
+	inventory := &Inventory{}
+	osPackages := []osv.Package{}
+	languagePackages := []osv.Package{}
+	var osRelease, fallbackOSRelease []byte
+
+	reader := tar.NewReader(content)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read image content")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		source := "/" + name
+
+		switch {
+		case name == "etc/os-release":
+			if osRelease, err = io.ReadAll(reader); err != nil {
+				return nil, errors.Wrapf(err, "failed to read %v", source)
+			}
+		case name == "usr/lib/os-release":
+			if fallbackOSRelease, err = io.ReadAll(reader); err != nil {
+				return nil, errors.Wrapf(err, "failed to read %v", source)
+			}
+		case name == "var/lib/dpkg/status" || strings.HasPrefix(name, "var/lib/dpkg/status.d/"):
+			osPackages = append(osPackages, parseDpkgStatus(reader, source)...)
+		case name == "lib/apk/db/installed":
+			osPackages = append(osPackages, parseApkInstalled(reader, source)...)
+		case name == "var/lib/rpmmanifest/container-manifest-2":
+			osPackages = append(osPackages, parseRPMManifest(reader, source)...)
+		case piperutils.ContainsString(rpmDatabases, name):
+			packages, err := parseRPMDatabase(reader, source)
+			if err != nil {
+				return nil, err
+			}
+			osPackages = append(osPackages, packages...)
+		case isJavaArchive(name):
+			archive, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %v", source)
+			}
+			languagePackages = append(languagePackages, parseJavaArchive(archive, source, 0)...)
+		case path.Base(name) == "package.json" && isNodeModule(path.Dir(name)):
+			if p, ok := parsePackageJSON(reader, source); ok {
+				languagePackages = append(languagePackages, p)
+			}
+		case strings.HasSuffix(name, ".dist-info/METADATA") || strings.HasSuffix(name, ".egg-info/PKG-INFO"):
+			if p, ok := parsePythonMetadata(reader, source); ok {
+				languagePackages = append(languagePackages, p)
+			}
+		case header.Mode&0111 != 0:
+			languagePackages = append(languagePackages, parseGoBinary(reader, source)...)
+		}
+	}
+
+	if len(osRelease) == 0 {
+		osRelease = fallbackOSRelease
+	}
+	inventory.OS = parseOSRelease(osRelease)
+	ecosystem := inventory.OS.Ecosystem()
+	if len(osPackages) > 0 && len(ecosystem) == 0 {
+		log.Entry().Warnf("Operating system '%v' is not supported, vulnerabilities of its packages cannot be detected", inventory.OS)
+		ecosystem = inventory.OS.ID
+	}
+	for i := range osPackages {
+		osPackages[i].Ecosystem = ecosystem
+	}
+
+	inventory.Packages = osv.Deduplicate(append(osPackages, languagePackages...))
+	return inventory, nil
+}
+
+func parseOSRelease(content []byte) OSRelease {
+	release := OSRelease{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			release.ID = value
+		case "VERSION_ID":
+			release.VersionID = value
+		case "PRETTY_NAME":
+			release.PrettyName = value
+		}
+	}
+	return release
+}
+
+// readStanzas reads the paragraphs of "Key: value" fields used by the package databases of dpkg and apk
+func readStanzas(reader io.Reader, separator string) []map[string]string {
+	stanzas := []map[string]string{}
+	stanza := map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(stanza) > 0 {
+				stanzas = append(stanzas, stanza)
+				stanza = map[string]string{}
+			}
+			continue
+		}
+		// continuation lines of multi-line fields are not needed
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if key, value, found := strings.Cut(line, separator); found {
+			stanza[key] = strings.TrimSpace(value)
+		}
+	}
+	if len(stanza) > 0 {
+		stanzas = append(stanzas, stanza)
+	}
+	return stanzas
+}
+
+// parseDpkgStatus reads the installed packages, vulnerabilities of Debian based distributions are reported for source packages
+func parseDpkgStatus(reader io.Reader, source string) []osv.Package {
+	packages := []osv.Package{}
+	for _, stanza := range readStanzas(reader, ":") {
+		if status, ok := stanza["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+		name, version := stanza["Package"], stanza["Version"]
+		if sourcePackage, ok := stanza["Source"]; ok {
+			// the source version is given in brackets if it differs from the binary version, e.g. "glibc (2.36-9)"
+			sourceName, sourceVersion, found := strings.Cut(sourcePackage, " ")
+			name = sourceName
+			if found {
+				version = strings.Trim(sourceVersion, "()")
+			}
+		}
+		if len(name) == 0 || len(version) == 0 {
+			continue
+		}
+		packages = append(packages, osv.Package{Name: name, Version: version, Source: source})
+	}
+	return packages
+}
+
+// parseApkInstalled reads the installed packages, vulnerabilities of Alpine are reported for origin packages
+func parseApkInstalled(reader io.Reader, source string) []osv.Package {
+	packages := []osv.Package{}
+	for _, stanza := range readStanzas(reader, ":") {
+		name, version := stanza["P"], stanza["V"]
+		if origin, ok := stanza["o"]; ok {
+			name = origin
+		}
+		if len(name) == 0 || len(version) == 0 {
+			continue
+		}
+		packages = append(packages, osv.Package{Name: name, Version: version, Source: source})
+	}
+	return packages
+}
+
+// parseRPMManifest reads the rpm manifest of distroless images with the tab separated columns
+// name, version-release, install time, build time, vendor, epoch, size, architecture, epoch number and source rpm
+func parseRPMManifest(reader io.Reader, source string) []osv.Package {
+	packages := []osv.Package{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		columns := strings.Split(scanner.Text(), "\t")
+		if len(columns) < 2 || len(columns[0]) == 0 {
+			continue
+		}
+		name, version, sourceRPM := columns[0], columns[1], ""
+		if len(columns) > 9 {
+			sourceRPM = columns[9]
+		}
+		epoch := ""
+		if len(columns) > 5 && columns[5] != "(none)" && columns[5] != "0" {
+			epoch = columns[5]
+		}
+		packages = append(packages, rpmPackage(name, epoch, version, sourceRPM, source))
+	}
+	return packages
+}
+
+// parseRPMDatabase reads the packages from an rpm database in Berkeley DB, NDB or sqlite format.
+// The database is copied into a temporary file since the formats require random access.
+func parseRPMDatabase(reader io.Reader, source string) ([]osv.Package, error) {
+	file, err := os.CreateTemp("", "rpmdb")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create temporary file for %v", source)
+	}
+	defer os.Remove(file.Name())
+	_, err = io.Copy(file, reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %v", source)
+	}
+
+	db, err := rpmdb.Open(file.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open rpm database %v", source)
+	}
+	defer db.Close()
+	entries, err := db.ListPackages()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read rpm database %v", source)
+	}
+
+	packages := []osv.Package{}
+	for _, entry := range entries {
+		epoch := ""
+		if entry.Epoch != nil && *entry.Epoch != 0 {
+			epoch = strconv.Itoa(*entry.Epoch)
+		}
+		packages = append(packages, rpmPackage(entry.Name, epoch, entry.Version+"-"+entry.Release, entry.SourceRpm, source))
+	}
+	return packages, nil
+}
+
+// rpmPackage names the package after its source rpm, which is what the advisories refer to
+func rpmPackage(name, epoch, version, sourceRPM, source string) osv.Package {
+	if len(sourceRPM) > 0 && sourceRPM != "(none)" {
+		name = strings.TrimSuffix(strings.TrimSuffix(sourceRPM, ".src.rpm"), "-"+version)
+	}
+	if len(epoch) > 0 {
+		version = epoch + ":" + version
+	}
+	return osv.Package{Name: name, Version: version, Source: source}
+}
+
+func isJavaArchive(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jar", ".war", ".ear":
+		return true
+	}
+	return false
+}
+
+// parseJavaArchive reads the Maven coordinates from the pom.properties files of the archive and of the archives nested into it
+func parseJavaArchive(content []byte, source string, depth int) []osv.Package {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		log.Entry().Debugf("Skipping %v: %v", source, err)
+		return nil
+	}
+	packages := []osv.Package{}
+	for _, file := range archive.File {
+		isPomProperties := strings.HasPrefix(file.Name, "META-INF/maven/") && strings.HasSuffix(file.Name, "/pom.properties")
+		isNested := depth < maxNestingDepth && isJavaArchive(file.Name)
+		if !isPomProperties && !isNested {
+			continue
+		}
+		fileContent, err := readZipFile(file)
+		if err != nil {
+			log.Entry().Debugf("Skipping %v in %v: %v", file.Name, source, err)
+			continue
+		}
+		if isNested {
+			packages = append(packages, parseJavaArchive(fileContent, source+"!/"+file.Name, depth+1)...)
+			continue
+		}
+		properties := map[string]string{}
+		scanner := bufio.NewScanner(bytes.NewReader(fileContent))
+		for scanner.Scan() {
+			if key, value, found := strings.Cut(scanner.Text(), "="); found {
+				properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+		if len(properties["groupId"]) == 0 || len(properties["artifactId"]) == 0 || len(properties["version"]) == 0 {
+			continue
+		}
+		packages = append(packages, osv.Package{
+			Name:      properties["groupId"] + ":" + properties["artifactId"],
+			Version:   properties["version"],
+			Ecosystem: osv.EcosystemMaven,
+			Source:    source,
+		})
+	}
+	return packages
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// isNodeModule checks whether the directory is a package installed into node_modules, e.g. node_modules/lodash or node_modules/@scope/name
+func isNodeModule(dir string) bool {
+	parent := path.Dir(dir)
+	if strings.HasPrefix(path.Base(parent), "@") {
+		parent = path.Dir(parent)
+	}
+	return path.Base(parent) == "node_modules"
+}
+
+func parsePackageJSON(reader io.Reader, source string) (osv.Package, bool) {
+	descriptor := struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}{}
+	if err := json.NewDecoder(reader).Decode(&descriptor); err != nil {
+		log.Entry().Debugf("Skipping %v: %v", source, err)
+		return osv.Package{}, false
+	}
+	if len(descriptor.Name) == 0 || len(descriptor.Version) == 0 {
+		return osv.Package{}, false
+	}
+	return osv.Package{Name: descriptor.Name, Version: descriptor.Version, Ecosystem: osv.EcosystemNpm, Source: source}, true
+}
+
+func parsePythonMetadata(reader io.Reader, source string) (osv.Package, bool) {
+	stanzas := readStanzas(reader, ": ")
+	if len(stanzas) == 0 || len(stanzas[0]["Name"]) == 0 || len(stanzas[0]["Version"]) == 0 {
+		return osv.Package{}, false
+	}
+	return osv.Package{Name: stanzas[0]["Name"], Version: stanzas[0]["Version"], Ecosystem: osv.EcosystemPyPI, Source: source}, true
+}
+
+// parseGoBinary reads the modules and the Go version from the build information embedded into Go executables
+func parseGoBinary(reader io.Reader, source string) []osv.Package {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(reader, magic); err != nil || !bytes.Equal(magic, []byte("\x7fELF")) {
+		return nil
+	}
+	rest, err := io.ReadAll(reader)
+	if err != nil {
+		log.Entry().Debugf("Skipping %v: %v", source, err)
+		return nil
+	}
+	info, err := buildinfo.Read(bytes.NewReader(append(magic, rest...)))
+	if err != nil {
+		// not a Go binary
+		return nil
+	}
+
+	packages := []osv.Package{}
+	if goVersion := strings.Fields(strings.TrimPrefix(info.GoVersion, "go")); len(goVersion) > 0 {
+		packages = append(packages, osv.Package{Name: "stdlib", Version: goVersion[0], Ecosystem: osv.EcosystemGo, Source: source})
+	}
+	if len(info.Main.Path) > 0 && len(info.Main.Version) > 0 && info.Main.Version != "(devel)" {
+		packages = append(packages, osv.Package{Name: info.Main.Path, Version: info.Main.Version, Ecosystem: osv.EcosystemGo, Source: source})
+	}
+	for _, module := range info.Deps {
+		if module.Replace != nil {
+			module = module.Replace
+		}
+		packages = append(packages, osv.Package{Name: module.Path, Version: module.Version, Ecosystem: osv.EcosystemGo, Source: source})
+	}
+	return packages
+}
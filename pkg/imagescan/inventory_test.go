@@ -0,0 +1,242 @@
+//go:build unit
+// +build unit
+
+package imagescan
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dpkgStatus = `Package: libssl3
+Status: install ok installed
+Source: openssl
+Version: 3.0.11-1~deb12u1
+Description: Secure Sockets Layer toolkit
+ multi-line description
+
+Package: libc6
+Status: install ok installed
+Source: glibc (2.36-9+deb12u3)
+Version: 2.36-9+deb12u3+b1
+
+Package: removed
+Status: deinstall ok config-files
+Version: 1.0-1
+`
+
+const apkInstalled = `C:Q1abc=
+P:busybox-binsh
+V:1.36.1-r15
+o:busybox
+
+P:musl
+V:1.2.4_git20230717-r4
+o:musl
+`
+
+func createJar(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestReadInventory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("debian with language packages", func(t *testing.T) {
+		t.Parallel()
+		nested := createJar(t, map[string]string{
+			"META-INF/maven/org.apache.logging.log4j/log4j-core/pom.properties": "groupId=org.apache.logging.log4j\nartifactId=log4j-core\nversion=2.14.1\n",
+		})
+		app := createJar(t, map[string]string{
+			"META-INF/maven/com.example/app/pom.properties": "version=1.0.0\ngroupId=com.example\nartifactId=app\n",
+			"BOOT-INF/lib/log4j-core-2.14.1.jar":            string(nested),
+		})
+		image, err := crane.Image(map[string][]byte{
+			"etc/os-release":                                              []byte("PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\nVERSION_ID=\"12\"\n"),
+			"var/lib/dpkg/status":                                         []byte(dpkgStatus),
+			"app/app.jar":                                                 app,
+			"app/node_modules/lodash/package.json":                        []byte(`{"name": "lodash", "version": "4.17.20"}`),
+			"app/node_modules/@scope/pkg/package.json":                    []byte(`{"name": "@scope/pkg", "version": "1.0.0"}`),
+			"app/node_modules/lodash/fp/package.json":                     []byte(`{"name": "fp"}`),
+			"app/package.json":                                            []byte(`{"name": "my-app", "version": "1.0.0"}`),
+			"usr/lib/python3/site-packages/Django-3.2.dist-info/METADATA": []byte("Metadata-Version: 2.1\nName: Django\nVersion: 3.2\n\nDescription: with: colon\n"),
+		})
+		require.NoError(t, err)
+
+		inventory, err := ReadInventory(image)
+
+		require.NoError(t, err)
+		assert.Equal(t, OSRelease{ID: "debian", VersionID: "12", PrettyName: "Debian GNU/Linux 12 (bookworm)"}, inventory.OS)
+		assert.ElementsMatch(t, []osv.Package{
+			{Name: "openssl", Version: "3.0.11-1~deb12u1", Ecosystem: "Debian:12", Source: "/var/lib/dpkg/status"},
+			{Name: "glibc", Version: "2.36-9+deb12u3", Ecosystem: "Debian:12", Source: "/var/lib/dpkg/status"},
+			{Name: "com.example:app", Version: "1.0.0", Ecosystem: osv.EcosystemMaven, Source: "/app/app.jar"},
+			{Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Ecosystem: osv.EcosystemMaven, Source: "/app/app.jar!/BOOT-INF/lib/log4j-core-2.14.1.jar"},
+			{Name: "lodash", Version: "4.17.20", Ecosystem: osv.EcosystemNpm, Source: "/app/node_modules/lodash/package.json"},
+			{Name: "@scope/pkg", Version: "1.0.0", Ecosystem: osv.EcosystemNpm, Source: "/app/node_modules/@scope/pkg/package.json"},
+			{Name: "Django", Version: "3.2", Ecosystem: osv.EcosystemPyPI, Source: "/usr/lib/python3/site-packages/Django-3.2.dist-info/METADATA"},
+		}, inventory.Packages)
+	})
+
+	t.Run("alpine", func(t *testing.T) {
+		t.Parallel()
+		image, err := crane.Image(map[string][]byte{
+			"usr/lib/os-release":   []byte("ID=alpine\nVERSION_ID=3.19.1\n"),
+			"lib/apk/db/installed": []byte(apkInstalled),
+		})
+		require.NoError(t, err)
+
+		inventory, err := ReadInventory(image)
+
+		require.NoError(t, err)
+		assert.Equal(t, "alpine 3.19.1", inventory.OS.String())
+		assert.Equal(t, []osv.Package{
+			{Name: "busybox", Version: "1.36.1-r15", Ecosystem: "Alpine:v3.19", Source: "/lib/apk/db/installed"},
+			{Name: "musl", Version: "1.2.4_git20230717-r4", Ecosystem: "Alpine:v3.19", Source: "/lib/apk/db/installed"},
+		}, inventory.Packages)
+	})
+
+	t.Run("rpm manifest", func(t *testing.T) {
+		t.Parallel()
+		manifest := strings.Join([]string{
+			"openssl-libs\t1.1.1k-27.cm2\t1700000000\t1690000000\tMicrosoft Corporation\t(none)\t4096\tx86_64\t0\topenssl-1.1.1k-27.cm2.src.rpm",
+			"tzdata\t2023c-1.cm2\t1700000000\t1690000000\tMicrosoft Corporation\t1\t4096\tnoarch\t1\ttzdata-2023c-1.cm2.src.rpm",
+		}, "\n")
+		image, err := crane.Image(map[string][]byte{
+			"etc/os-release": []byte("ID=mariner\nVERSION_ID=\"2.0\"\n"),
+			"var/lib/rpmmanifest/container-manifest-2": []byte(manifest),
+		})
+		require.NoError(t, err)
+
+		inventory, err := ReadInventory(image)
+
+		require.NoError(t, err)
+		assert.Equal(t, []osv.Package{
+			{Name: "openssl", Version: "1.1.1k-27.cm2", Ecosystem: "Mariner:2.0", Source: "/var/lib/rpmmanifest/container-manifest-2"},
+			{Name: "tzdata", Version: "1:2023c-1.cm2", Ecosystem: "Mariner:2.0", Source: "/var/lib/rpmmanifest/container-manifest-2"},
+		}, inventory.Packages)
+	})
+
+	t.Run("rpm databases", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			format   string
+			fixture  string
+			database string
+		}{
+			{"Berkeley DB", "testdata/rpm/Packages", "var/lib/rpm/Packages"},
+			{"NDB", "testdata/rpm/Packages.db", "var/lib/rpm/Packages.db"},
+			{"sqlite", "testdata/rpm/rpmdb.sqlite", "var/lib/rpm/rpmdb.sqlite"},
+			{"sqlite in sysimage", "testdata/rpm/rpmdb.sqlite", "usr/lib/sysimage/rpm/rpmdb.sqlite"},
+		}
+		for _, test := range tests {
+			test := test
+			t.Run(test.format, func(t *testing.T) {
+				t.Parallel()
+				database, err := os.ReadFile(test.fixture)
+				require.NoError(t, err)
+				image, err := crane.Image(map[string][]byte{
+					"etc/os-release": []byte("ID=rocky\nVERSION_ID=\"8.8\"\n"),
+					test.database:    database,
+				})
+				require.NoError(t, err)
+
+				inventory, err := ReadInventory(image)
+
+				require.NoError(t, err)
+				assert.Equal(t, []osv.Package{
+					{Name: "util-linux", Version: "2.32.1-42.el8_8", Ecosystem: "Rocky Linux:8", Source: "/" + test.database},
+				}, inventory.Packages)
+			})
+		}
+	})
+
+	t.Run("invalid rpm database", func(t *testing.T) {
+		t.Parallel()
+		image, err := crane.Image(map[string][]byte{
+			"etc/os-release":           []byte("ID=rhel\nVERSION_ID=\"9.3\"\n"),
+			"var/lib/rpm/rpmdb.sqlite": []byte("binary"),
+		})
+		require.NoError(t, err)
+
+		_, err = ReadInventory(image)
+
+		assert.ErrorContains(t, err, "failed to open rpm database /var/lib/rpm/rpmdb.sqlite")
+	})
+
+	t.Run("unknown operating system", func(t *testing.T) {
+		t.Parallel()
+		image, err := crane.Image(map[string][]byte{
+			"etc/os-release":      []byte("ID=custom\n"),
+			"var/lib/dpkg/status": []byte(dpkgStatus),
+		})
+		require.NoError(t, err)
+
+		inventory, err := ReadInventory(image)
+
+		require.NoError(t, err)
+		assert.Equal(t, "", inventory.OS.Ecosystem())
+		assert.Len(t, inventory.Packages, 2)
+		assert.Equal(t, "custom", inventory.Packages[0].Ecosystem)
+	})
+}
+
+func TestOSReleaseEcosystem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		release  OSRelease
+		expected string
+	}{
+		{OSRelease{ID: "debian", VersionID: "11"}, "Debian:11"},
+		{OSRelease{ID: "debian"}, "Debian"},
+		{OSRelease{ID: "ubuntu", VersionID: "22.04"}, "Ubuntu:22.04"},
+		{OSRelease{ID: "alpine", VersionID: "3.18.4"}, "Alpine:v3.18"},
+		{OSRelease{ID: "rocky", VersionID: "9.3"}, "Rocky Linux:9"},
+		{OSRelease{ID: "almalinux", VersionID: "8.9"}, "AlmaLinux:8"},
+		{OSRelease{ID: "azurelinux", VersionID: "3.0"}, "Azure Linux:3.0"},
+		{OSRelease{ID: "fedora", VersionID: "39"}, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.release.Ecosystem())
+	}
+}
+
+func TestParseGoBinary(t *testing.T) {
+	t.Parallel()
+
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	binary, err := os.Open(executable)
+	require.NoError(t, err)
+	defer binary.Close()
+
+	packages := parseGoBinary(binary, "/app/test")
+
+	if runtime.GOOS != "linux" {
+		assert.Empty(t, packages)
+		return
+	}
+	require.NotEmpty(t, packages)
+	assert.Equal(t, osv.Package{Name: "stdlib", Version: strings.TrimPrefix(runtime.Version(), "go"), Ecosystem: osv.EcosystemGo, Source: "/app/test"}, packages[0])
+
+	assert.Empty(t, parseGoBinary(strings.NewReader("#!/bin/sh\necho hello"), "/bin/script"))
+}
@@ -0,0 +1,38 @@
+package imagescan
+
+import (
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// OfflineScanner inspects the package databases and language artifacts contained in an image
+// and matches them against a local OSV vulnerability database snapshot
+type OfflineScanner struct {
+	db *osv.Database
+}
+
+// NewOfflineScanner creates a scanner using the given vulnerability database
+func NewOfflineScanner(db *osv.Database) *OfflineScanner {
+	return &OfflineScanner{db: db}
+}
+
+// Name returns the name of the scanner
+func (s *OfflineScanner) Name() string {
+	return "Offline image scan"
+}
+
+// Scan creates the package inventory of the image and matches it against the vulnerability database
+func (s *OfflineScanner) Scan(image v1.Image) (*Result, error) {
+	inventory, err := ReadInventory(image)
+	if err != nil {
+		return nil, err
+	}
+	log.Entry().Infof("Found %v packages in image (operating system: %v)", len(inventory.Packages), inventory.OS)
+
+	return &Result{
+		OS:       inventory.OS,
+		Packages: inventory.Packages,
+		Findings: s.db.Scan(inventory.Packages),
+	}, nil
+}
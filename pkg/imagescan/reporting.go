@@ -0,0 +1,199 @@
+package imagescan
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/package-url/packageurl-go"
+	"github.com/pkg/errors"
+)
+
+// ReportsDirectory defines the subfolder for the image scan reports which are generated
+const ReportsDirectory = "imagescan"
+
+// CreateCustomReport creates a vulnerability ScanReport of the image to be used for uploading into various sinks
+func CreateCustomReport(image, scannerName, databasePath string, result *Result, failOnSeverities []string) reporting.ScanReport {
+	scanReport := osv.CreateCustomReport(databasePath, result.Packages, result.Findings, failOnSeverities)
+	scanReport.ReportTitle = "Container Image Vulnerability Report"
+	scanReport.Subheaders = append([]reporting.Subheader{
+		{Description: "Image", Details: image},
+		{Description: "Operating system", Details: result.OS.String()},
+		{Description: "Scanner", Details: scannerName},
+	}, scanReport.Subheaders...)
+	return scanReport
+}
+
+// WriteCustomReports writes the ScanReport as HTML report and as JSON step report
+func WriteCustomReports(scanReport reporting.ScanReport, image string, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	htmlReportPath := filepath.Join(ReportsDirectory, "piper_container_vulnerability_report.html")
+	if err := fileUtils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Container Image Vulnerability Report", Target: htmlReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := fileUtils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := fileUtils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create step reporting directory")
+		}
+	}
+	jsonReportPath := filepath.Join(reporting.StepReportDirectory, fmt.Sprintf("containerExecuteScan_oss_%x.json", sha1.Sum([]byte(image))))
+	if err := fileUtils.FileWrite(jsonReportPath, jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+
+	return reportPaths, nil
+}
+
+// WriteSarifFile writes the findings as SARIF file
+func WriteSarifFile(scannerName string, findings []osv.Finding, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	sarif := osv.CreateSarifResultFile(findings)
+	sarif.Runs[0].Tool.Driver.Name = scannerName
+	sarifReport, err := json.Marshal(sarif)
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to marshal SARIF json file")
+	}
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	sarifReportPath := filepath.Join(ReportsDirectory, "piper_container_vulnerability.sarif")
+	if err := fileUtils.FileWrite(sarifReportPath, sarifReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write SARIF file")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Container Image Vulnerability SARIF file", Target: sarifReportPath})
+
+	return reportPaths, nil
+}
+
+// CreateCycloneSBOM creates a CycloneDX SBOM of the image listing its packages and their vulnerabilities
+func CreateCycloneSBOM(image, scannerName string, result *Result) ([]byte, error) {
+	imagePurl := packageurl.NewPackageURL(packageurl.TypeDocker, "", image, "", nil, "").ToString()
+	metadata := cdx.Metadata{
+		Component: &cdx.Component{
+			BOMRef:     imagePurl,
+			Type:       cdx.ComponentTypeContainer,
+			Name:       image,
+			PackageURL: imagePurl,
+		},
+		Properties: &[]cdx.Property{
+			{Name: "piper:image:os", Value: result.OS.String()},
+		},
+	}
+
+	components := []cdx.Component{}
+	componentRefs := []cdx.Dependency{}
+	for _, p := range result.Packages {
+		purl := p.Purl()
+		components = append(components, cdx.Component{
+			BOMRef:     purl,
+			Type:       cdx.ComponentTypeLibrary,
+			Name:       p.Name,
+			Version:    p.Version,
+			PackageURL: purl,
+			Properties: &[]cdx.Property{{Name: "piper:package:source", Value: p.Source}},
+		})
+		componentRefs = append(componentRefs, cdx.Dependency{Ref: purl})
+	}
+	sort.Slice(componentRefs, func(i, j int) bool { return componentRefs[i].Ref < componentRefs[j].Ref })
+	dependencies := []cdx.Dependency{{Ref: imagePurl, Dependencies: &componentRefs}}
+
+	vulnerabilities := []cdx.Vulnerability{}
+	for _, finding := range result.Findings {
+		score := finding.Score
+		vulnerability := cdx.Vulnerability{
+			BOMRef:      finding.Vulnerability.ID + "/" + finding.Package.Purl(),
+			ID:          finding.Vulnerability.ID,
+			Source:      &cdx.Source{Name: "OSV", URL: finding.URL()},
+			Description: finding.Vulnerability.Summary,
+			Detail:      finding.Vulnerability.Details,
+			Tools:       &[]cdx.Tool{{Name: scannerName}},
+			Ratings: &[]cdx.VulnerabilityRating{{
+				Score:    &score,
+				Severity: cdxSeverity(finding.Severity),
+				Method:   cdx.ScoringMethodCVSSv3,
+			}},
+			Affects: &[]cdx.Affects{{
+				Ref:   finding.Package.Purl(),
+				Range: &[]cdx.AffectedVersions{{Version: finding.Package.Version, Status: cdx.VulnerabilityStatusAffected}},
+			}},
+		}
+		if len(finding.Vulnerability.Aliases) > 0 {
+			references := []cdx.VulnerabilityReference{}
+			for _, alias := range finding.Vulnerability.Aliases {
+				references = append(references, cdx.VulnerabilityReference{ID: alias})
+			}
+			vulnerability.References = &references
+		}
+		if len(finding.FixedVersions) > 0 {
+			vulnerability.Recommendation = fmt.Sprintf("Upgrade %v to one of the versions %v", finding.Package.Name, strings.Join(finding.FixedVersions, ", "))
+		}
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+
+	bom := cdx.NewBOM()
+	bom.Metadata = &metadata
+	bom.Components = &components
+	bom.Dependencies = &dependencies
+	bom.Vulnerabilities = &vulnerabilities
+
+	var outputBytes []byte
+	buffer := bytes.NewBuffer(outputBytes)
+	encoder := cdx.NewBOMEncoder(buffer, cdx.BOMFileFormatXML)
+	encoder.SetPretty(true)
+	if err := encoder.Encode(bom); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// WriteCycloneSBOM writes the CycloneDX SBOM into the reports directory
+func WriteCycloneSBOM(sbom []byte, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	sbomPath := filepath.Join(ReportsDirectory, "piper_container_sbom.xml")
+	if err := fileUtils.FileWrite(sbomPath, sbom, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write SBOM")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Container Image SBOM file", Target: sbomPath})
+	return reportPaths, nil
+}
+
+func cdxSeverity(severity string) cdx.Severity {
+	switch severity {
+	case osv.SeverityCritical:
+		return cdx.SeverityCritical
+	case osv.SeverityHigh:
+		return cdx.SeverityHigh
+	case osv.SeverityMedium:
+		return cdx.SeverityMedium
+	case osv.SeverityLow:
+		return cdx.SeverityLow
+	}
+	return cdx.SeverityUnknown
+}
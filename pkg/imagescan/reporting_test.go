@@ -0,0 +1,98 @@
+//go:build unit
+// +build unit
+
+package imagescan
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testResult = &Result{
+	OS: OSRelease{ID: "debian", VersionID: "12", PrettyName: "Debian GNU/Linux 12 (bookworm)"},
+	Packages: []osv.Package{
+		{Name: "openssl", Version: "3.0.11-1~deb12u1", Ecosystem: "Debian:12", Source: "/var/lib/dpkg/status"},
+		{Name: "lodash", Version: "4.17.21", Ecosystem: osv.EcosystemNpm, Source: "/app/node_modules/lodash/package.json"},
+	},
+	Findings: []osv.Finding{{
+		Package:       osv.Package{Name: "openssl", Version: "3.0.11-1~deb12u1", Ecosystem: "Debian:12", Source: "/var/lib/dpkg/status"},
+		Vulnerability: &osv.Vulnerability{ID: "DSA-5532-1", Aliases: []string{"CVE-2023-5363"}, Summary: "openssl security update"},
+		Severity:      osv.SeverityHigh,
+		Score:         7.5,
+		FixedVersions: []string{"3.0.11-1~deb12u2"},
+	}},
+}
+
+func TestCreateCustomReport(t *testing.T) {
+	t.Parallel()
+
+	report := CreateCustomReport("my-image:1.0.0", "Offline image scan", "osv-db", testResult, []string{"HIGH"})
+
+	assert.Equal(t, "Container Image Vulnerability Report", report.ReportTitle)
+	assert.False(t, report.SuccessfulScan)
+	assert.Equal(t, []reporting.Subheader{
+		{Description: "Image", Details: "my-image:1.0.0"},
+		{Description: "Operating system", Details: "Debian GNU/Linux 12 (bookworm)"},
+		{Description: "Scanner", Details: "Offline image scan"},
+		{Description: "OSV database", Details: "osv-db"},
+	}, report.Subheaders)
+	assert.Len(t, report.DetailTable.Rows, 1)
+}
+
+func TestWriteReports(t *testing.T) {
+	t.Parallel()
+
+	utils := &mock.FilesMock{}
+	report := CreateCustomReport("my-image:1.0.0", "Offline image scan", "osv-db", testResult, nil)
+
+	paths, err := WriteCustomReports(report, "my-image:1.0.0", utils)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(ReportsDirectory, "piper_container_vulnerability_report.html"), paths[0].Target)
+	stepReports, err := utils.Glob(filepath.Join(reporting.StepReportDirectory, "containerExecuteScan_oss_*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, stepReports, 1)
+
+	paths, err = WriteSarifFile("Offline image scan", testResult.Findings, utils)
+	require.NoError(t, err)
+	content, err := utils.FileRead(paths[0].Target)
+	require.NoError(t, err)
+	var sarif format.SARIF
+	require.NoError(t, json.Unmarshal(content, &sarif))
+	assert.Equal(t, "Offline image scan", sarif.Runs[0].Tool.Driver.Name)
+	assert.Equal(t, "pkg:deb/debian/openssl@3.0.11-1~deb12u1?distro=12", sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.LogicalLocations[0].FullyQualifiedName)
+}
+
+func TestCreateCycloneSBOM(t *testing.T) {
+	t.Parallel()
+
+	sbom, err := CreateCycloneSBOM("my-image:1.0.0", "Offline image scan", testResult)
+	require.NoError(t, err)
+
+	bom := cdx.NewBOM()
+	require.NoError(t, cdx.NewBOMDecoder(bytes.NewReader(sbom), cdx.BOMFileFormatXML).Decode(bom))
+	assert.Equal(t, "my-image:1.0.0", bom.Metadata.Component.Name)
+	require.Len(t, *bom.Components, 2)
+	assert.Equal(t, "pkg:deb/debian/openssl@3.0.11-1~deb12u1?distro=12", (*bom.Components)[0].PackageURL)
+	require.Len(t, *bom.Vulnerabilities, 1)
+	vulnerability := (*bom.Vulnerabilities)[0]
+	assert.Equal(t, "DSA-5532-1", vulnerability.ID)
+	assert.Equal(t, cdx.SeverityHigh, (*vulnerability.Ratings)[0].Severity)
+	assert.Equal(t, "pkg:deb/debian/openssl@3.0.11-1~deb12u1?distro=12", (*vulnerability.Affects)[0].Ref)
+	assert.Equal(t, "Upgrade openssl to one of the versions 3.0.11-1~deb12u2", vulnerability.Recommendation)
+
+	utils := &mock.FilesMock{}
+	paths, err := WriteCycloneSBOM(sbom, utils)
+	require.NoError(t, err)
+	assert.True(t, utils.HasWrittenFile(filepath.Join(ReportsDirectory, "piper_container_sbom.xml")))
+	assert.Len(t, paths, 1)
+}
@@ -0,0 +1,46 @@
+package imagescan
+
+import (
+	"fmt"
+
+	"github.com/SAP/jenkins-library/pkg/osv"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+)
+
+// ScannerOffline is the name of the scanner which matches the image content against a local vulnerability database
+const ScannerOffline = "offline"
+
+// Scanner scans the content of a container image for vulnerabilities
+type Scanner interface {
+	// Name returns the name of the scanner as used in reports
+	Name() string
+	// Scan returns the operating system, the packages and the vulnerabilities found in the image
+	Scan(image v1.Image) (*Result, error)
+}
+
+// Result is the outcome of an image scan
+type Result struct {
+	OS       OSRelease
+	Packages []osv.Package
+	Findings []osv.Finding
+}
+
+// Options configure the creation of a scanner
+type Options struct {
+	// DatabasePath is the directory or archive containing the vulnerability database snapshot
+	DatabasePath string
+}
+
+// NewScanner creates the scanner with the given name
+func NewScanner(name string, options Options) (Scanner, error) {
+	switch name {
+	case ScannerOffline:
+		db, err := osv.LoadDatabase(options.DatabasePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load vulnerability database")
+		}
+		return NewOfflineScanner(db), nil
+	}
+	return nil, fmt.Errorf("scanner '%v' is not supported", name)
+}
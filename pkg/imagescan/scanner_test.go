@@ -0,0 +1,62 @@
+//go:build unit
+// +build unit
+
+package imagescan
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScanner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("offline", func(t *testing.T) {
+		scanner, err := NewScanner(ScannerOffline, Options{DatabasePath: t.TempDir()})
+		require.NoError(t, err)
+		assert.Equal(t, "Offline image scan", scanner.Name())
+	})
+
+	t.Run("offline without database", func(t *testing.T) {
+		_, err := NewScanner(ScannerOffline, Options{DatabasePath: "not/existing"})
+		assert.ErrorContains(t, err, "failed to load vulnerability database")
+	})
+
+	t.Run("unknown scanner", func(t *testing.T) {
+		_, err := NewScanner("other", Options{})
+		assert.EqualError(t, err, "scanner 'other' is not supported")
+	})
+}
+
+func TestOfflineScan(t *testing.T) {
+	t.Parallel()
+
+	db := osv.NewDatabase([]osv.Vulnerability{{
+		ID:      "DSA-5532-1",
+		Aliases: []string{"CVE-2023-5363"},
+		Affected: []osv.Affected{{
+			Package: osv.AffectedPackage{Ecosystem: "Debian:12", Name: "openssl"},
+			Ranges:  []osv.Range{{Type: "ECOSYSTEM", Events: []osv.Event{{Introduced: "0"}, {Fixed: "3.0.11-1~deb12u2"}}}},
+		}},
+		Severity: []osv.Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"}},
+	}})
+	image, err := crane.Image(map[string][]byte{
+		"etc/os-release":      []byte("ID=debian\nVERSION_ID=\"12\"\n"),
+		"var/lib/dpkg/status": []byte(dpkgStatus),
+	})
+	require.NoError(t, err)
+
+	result, err := NewOfflineScanner(db).Scan(image)
+
+	require.NoError(t, err)
+	assert.Equal(t, "debian", result.OS.ID)
+	assert.Len(t, result.Packages, 2)
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "DSA-5532-1", result.Findings[0].Vulnerability.ID)
+	assert.Equal(t, "openssl", result.Findings[0].Package.Name)
+	assert.Equal(t, osv.SeverityHigh, result.Findings[0].Severity)
+}
@@ -12,6 +12,8 @@ import (
 // Build is an interface to abstract gojenkins.Build.
 type Build interface {
 	GetArtifacts() []gojenkins.Artifact
+	GetResult() string
+	GetUrl() string
 	IsRunning(ctx context.Context) bool
 	Poll(ctx context.Context, options ...interface{}) (int, error)
 }
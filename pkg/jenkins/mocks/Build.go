@@ -70,6 +70,96 @@ func (_c *Build_GetArtifacts_Call) RunAndReturn(run func() []gojenkins.Artifact)
 	return _c
 }
 
+// GetResult provides a mock function with given fields:
+func (_m *Build) GetResult() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResult")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Build_GetResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResult'
+type Build_GetResult_Call struct {
+	*mock.Call
+}
+
+// GetResult is a helper method to define mock.On call
+func (_e *Build_Expecter) GetResult() *Build_GetResult_Call {
+	return &Build_GetResult_Call{Call: _e.mock.On("GetResult")}
+}
+
+func (_c *Build_GetResult_Call) Run(run func()) *Build_GetResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Build_GetResult_Call) Return(_a0 string) *Build_GetResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Build_GetResult_Call) RunAndReturn(run func() string) *Build_GetResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUrl provides a mock function with given fields:
+func (_m *Build) GetUrl() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUrl")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Build_GetUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUrl'
+type Build_GetUrl_Call struct {
+	*mock.Call
+}
+
+// GetUrl is a helper method to define mock.On call
+func (_e *Build_Expecter) GetUrl() *Build_GetUrl_Call {
+	return &Build_GetUrl_Call{Call: _e.mock.On("GetUrl")}
+}
+
+func (_c *Build_GetUrl_Call) Run(run func()) *Build_GetUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Build_GetUrl_Call) Return(_a0 string) *Build_GetUrl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Build_GetUrl_Call) RunAndReturn(run func() string) *Build_GetUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsRunning provides a mock function with given fields: ctx
 func (_m *Build) IsRunning(ctx context.Context) bool {
 	ret := _m.Called(ctx)
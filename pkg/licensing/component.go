@@ -0,0 +1,165 @@
+package licensing
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// Component is a software component with its declared license expression
+type Component struct {
+	Name       string
+	Version    string
+	PackageURL string
+	// License is an SPDX license expression
+	License string
+	// Source is the file the component has been read from
+	Source string
+}
+
+// HasLicense checks whether license information is available for the component
+func (c Component) HasLicense() bool {
+	license := strings.TrimSpace(c.License)
+	return len(license) > 0 && license != "NOASSERTION" && license != "NONE"
+}
+
+// ID returns the package URL of the component or its name and version if no package URL is available
+func (c Component) ID() string {
+	if len(c.PackageURL) > 0 {
+		return c.PackageURL
+	}
+	if len(c.Version) > 0 {
+		return c.Name + "@" + c.Version
+	}
+	return c.Name
+}
+
+// Matches checks whether the package URL or name of the component matches the given pattern.
+// Patterns without version match all versions of the component.
+func (c Component) Matches(pattern string) bool {
+	candidates := []string{c.Name}
+	if len(c.PackageURL) > 0 {
+		purl := strings.SplitN(c.PackageURL, "?", 2)[0]
+		candidates = append(candidates, purl, strings.SplitN(purl, "@", 2)[0])
+	}
+	for _, candidate := range candidates {
+		if matchesAny(candidate, []string{pattern}) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadSBOM reads the components and their licenses from a CycloneDX SBOM in XML or JSON format
+func ReadSBOM(path string, fileUtils piperutils.FileUtils) ([]Component, error) {
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read SBOM '%v'", path)
+	}
+	format := cdx.BOMFileFormatXML
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = cdx.BOMFileFormatJSON
+	}
+	bom := cdx.BOM{}
+	if err := cdx.NewBOMDecoder(bytes.NewReader(content), format).Decode(&bom); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse SBOM '%v'", path)
+	}
+
+	components := []Component{}
+	var collect func(entries *[]cdx.Component)
+	collect = func(entries *[]cdx.Component) {
+		if entries == nil {
+			return
+		}
+		for _, entry := range *entries {
+			components = append(components, Component{
+				Name:       componentName(entry),
+				Version:    entry.Version,
+				PackageURL: entry.PackageURL,
+				License:    LicenseExpression(entry.Licenses),
+				Source:     path,
+			})
+			collect(entry.Components)
+		}
+	}
+	collect(bom.Components)
+	return components, nil
+}
+
+// scannerComponents is the scanner independent format of a component list
+type scannerComponents struct {
+	Components []struct {
+		Name       string `json:"name"`
+		Version    string `json:"version,omitempty"`
+		PackageURL string `json:"purl,omitempty"`
+		License    string `json:"license,omitempty"`
+	} `json:"components"`
+}
+
+// ReadComponents reads the components and their license expressions from a JSON component list as provided by scanners which don't create SBOMs
+func ReadComponents(path string, fileUtils piperutils.FileUtils) ([]Component, error) {
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read component list '%v'", path)
+	}
+	list := scannerComponents{}
+	if err := json.Unmarshal(content, &list); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse component list '%v'", path)
+	}
+
+	components := []Component{}
+	for _, entry := range list.Components {
+		if len(entry.Name) == 0 && len(entry.PackageURL) == 0 {
+			return nil, errors.Errorf("component without name or purl in component list '%v'", path)
+		}
+		components = append(components, Component{
+			Name:       entry.Name,
+			Version:    entry.Version,
+			PackageURL: entry.PackageURL,
+			License:    entry.License,
+			Source:     path,
+		})
+	}
+	return components, nil
+}
+
+func componentName(component cdx.Component) string {
+	if len(component.Group) > 0 {
+		return component.Group + ":" + component.Name
+	}
+	return component.Name
+}
+
+var invalidLicenseRefCharacters = regexp.MustCompile(`[^A-Za-z0-9.\-]+`)
+
+// LicenseExpression combines the license choices of a CycloneDX component to one SPDX expression.
+// Multiple choices are combined with AND, licenses which are only given by name are converted into a LicenseRef.
+func LicenseExpression(licenses *cdx.Licenses) string {
+	if licenses == nil {
+		return ""
+	}
+	parts := []string{}
+	for _, choice := range *licenses {
+		switch {
+		case len(choice.Expression) > 0:
+			parts = append(parts, choice.Expression)
+		case choice.License != nil && len(choice.License.ID) > 0:
+			parts = append(parts, choice.License.ID)
+		case choice.License != nil && len(choice.License.Name) > 0:
+			parts = append(parts, "LicenseRef-"+strings.Trim(invalidLicenseRefCharacters.ReplaceAllString(choice.License.Name, "-"), "-"))
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	for i, part := range parts {
+		parts[i] = "(" + part + ")"
+	}
+	return strings.Join(parts, " AND ")
+}
@@ -0,0 +1,141 @@
+//go:build unit
+// +build unit
+
+package licensing
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSBOM(t *testing.T) {
+	t.Parallel()
+
+	t.Run("XML", func(t *testing.T) {
+		path := "bom-maven.xml"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <components>
+    <component type="library">
+      <group>org.mariadb.jdbc</group>
+      <name>mariadb-java-client</name>
+      <version>3.0.8</version>
+      <licenses>
+        <expression>LGPL-2.1-or-later</expression>
+      </licenses>
+      <purl>pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.8?type=jar</purl>
+      <components>
+        <component type="library">
+          <name>nested</name>
+          <version>1.0</version>
+        </component>
+      </components>
+    </component>
+  </components>
+</bom>`))
+
+		components, err := ReadSBOM(path, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Component{
+			{Name: "org.mariadb.jdbc:mariadb-java-client", Version: "3.0.8", PackageURL: "pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.8?type=jar", License: "LGPL-2.1-or-later", Source: path},
+			{Name: "nested", Version: "1.0", Source: path},
+		}, components)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := "bom.json"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [
+    {"type": "library", "name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21", "licenses": [{"license": {"id": "MIT"}}]}
+  ]
+}`))
+
+		components, err := ReadSBOM(path, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Component{{Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21", License: "MIT", Source: path}}, components)
+	})
+
+	t.Run("invalid SBOM", func(t *testing.T) {
+		path := "bom.json"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte("{"))
+
+		_, err := ReadSBOM(path, files)
+
+		assert.ErrorContains(t, err, "failed to parse SBOM")
+	})
+}
+
+func TestReadComponents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		path := "components.json"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte(`{
+  "components": [
+    {"name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21", "license": "MIT"},
+    {"name": "dual", "version": "1.0.0", "license": "GPL-3.0-only OR Apache-2.0"}
+  ]
+}`))
+
+		components, err := ReadComponents(path, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Component{
+			{Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21", License: "MIT", Source: path},
+			{Name: "dual", Version: "1.0.0", License: "GPL-3.0-only OR Apache-2.0", Source: path},
+		}, components)
+	})
+
+	t.Run("component without name", func(t *testing.T) {
+		path := "components.json"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte(`{"components": [{"license": "MIT"}]}`))
+
+		_, err := ReadComponents(path, files)
+
+		assert.ErrorContains(t, err, "component without name or purl")
+	})
+
+	t.Run("invalid component list", func(t *testing.T) {
+		path := "components.json"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte("{"))
+
+		_, err := ReadComponents(path, files)
+
+		assert.ErrorContains(t, err, "failed to parse component list")
+	})
+}
+
+func TestLicenseExpression(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", LicenseExpression(nil))
+	assert.Equal(t, "(MIT) AND (Apache-2.0 OR BSD-2-Clause) AND (LicenseRef-Some-Custom-License-v1)", LicenseExpression(&cdx.Licenses{
+		{License: &cdx.License{ID: "MIT"}},
+		{Expression: "Apache-2.0 OR BSD-2-Clause"},
+		{License: &cdx.License{Name: "Some Custom License (v1)"}},
+	}))
+}
+
+func TestComponentMatches(t *testing.T) {
+	t.Parallel()
+
+	component := Component{Name: "org.mariadb.jdbc:mariadb-java-client", PackageURL: "pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.8?type=jar"}
+	assert.True(t, component.Matches("pkg:maven/org.mariadb.jdbc/mariadb-java-client"))
+	assert.True(t, component.Matches("pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.8"))
+	assert.True(t, component.Matches("pkg:maven/org.mariadb.jdbc/*"))
+	assert.True(t, component.Matches("org.mariadb.jdbc:*"))
+	assert.False(t, component.Matches("pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.9"))
+}
@@ -0,0 +1,176 @@
+package licensing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expression is a parsed SPDX license expression as described in
+// https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/
+type Expression interface {
+	// Licenses returns all licenses referenced by the expression
+	Licenses() []string
+	String() string
+	evaluate(decide func(license string) Decision) (Decision, []string)
+}
+
+// license is a single license identifier, optionally with an exception, e.g. "GPL-2.0-only WITH Classpath-exception-2.0"
+type license struct {
+	id        string
+	exception string
+}
+
+func (l license) Licenses() []string {
+	return []string{l.String()}
+}
+
+func (l license) String() string {
+	if len(l.exception) > 0 {
+		return l.id + " WITH " + l.exception
+	}
+	return l.id
+}
+
+func (l license) evaluate(decide func(string) Decision) (Decision, []string) {
+	return decide(l.String()), []string{l.String()}
+}
+
+// compound joins two expressions with AND or OR
+type compound struct {
+	operator    string
+	left, right Expression
+}
+
+func (c compound) Licenses() []string {
+	return append(c.left.Licenses(), c.right.Licenses()...)
+}
+
+func (c compound) String() string {
+	return fmt.Sprintf("(%v %v %v)", c.left, c.operator, c.right)
+}
+
+func (c compound) evaluate(decide func(string) Decision) (Decision, []string) {
+	left, leftLicenses := c.left.evaluate(decide)
+	right, rightLicenses := c.right.evaluate(decide)
+	if c.operator == "OR" {
+		// the licensee may choose the more favorable license
+		if right < left {
+			return right, rightLicenses
+		}
+		return left, leftLicenses
+	}
+	// all licenses need to be fulfilled, the least favorable one decides
+	if right > left {
+		return right, rightLicenses
+	}
+	if left > right {
+		return left, leftLicenses
+	}
+	return left, append(leftLicenses, rightLicenses...)
+}
+
+// ParseExpression parses an SPDX license expression like "(MIT OR Apache-2.0) AND BSD-3-Clause".
+// Operators are case-insensitive, AND binds stronger than OR.
+func ParseExpression(expression string) (Expression, error) {
+	p := parser{tokens: tokenize(expression)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expression '%v': %w", expression, err)
+	}
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("invalid license expression '%v': unexpected '%v'", expression, p.tokens[p.position])
+	}
+	return result, nil
+}
+
+func tokenize(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	return strings.Fields(expression)
+}
+
+type parser struct {
+	tokens   []string
+	position int
+}
+
+func (p *parser) peek() string {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.position++
+	return token
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = compound{operator: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "AND") {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = compound{operator: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (Expression, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case token == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	case token == ")" || isOperator(token):
+		return nil, fmt.Errorf("unexpected '%v'", token)
+	}
+
+	result := license{id: token}
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.next()
+		exception := p.next()
+		if exception == "" || exception == "(" || exception == ")" || isOperator(exception) {
+			return nil, fmt.Errorf("missing exception after 'WITH'")
+		}
+		result.exception = exception
+	}
+	return result, nil
+}
+
+func isOperator(token string) bool {
+	return strings.EqualFold(token, "AND") || strings.EqualFold(token, "OR") || strings.EqualFold(token, "WITH")
+}
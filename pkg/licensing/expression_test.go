@@ -0,0 +1,49 @@
+//go:build unit
+// +build unit
+
+package licensing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expression string
+		expected   string
+		licenses   []string
+	}{
+		{"MIT", "MIT", []string{"MIT"}},
+		{"MIT OR Apache-2.0", "(MIT OR Apache-2.0)", []string{"MIT", "Apache-2.0"}},
+		{"MIT or Apache-2.0 and BSD-3-Clause", "(MIT OR (Apache-2.0 AND BSD-3-Clause))", []string{"MIT", "Apache-2.0", "BSD-3-Clause"}},
+		{"(MIT OR Apache-2.0) AND BSD-3-Clause", "((MIT OR Apache-2.0) AND BSD-3-Clause)", []string{"MIT", "Apache-2.0", "BSD-3-Clause"}},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0", []string{"GPL-2.0-only WITH Classpath-exception-2.0"}},
+		{"GPL-2.0+ OR LicenseRef-Proprietary", "(GPL-2.0+ OR LicenseRef-Proprietary)", []string{"GPL-2.0+", "LicenseRef-Proprietary"}},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			expression, err := ParseExpression(test.expression)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, expression.String())
+				assert.Equal(t, test.licenses, expression.Licenses())
+			}
+		})
+	}
+
+	for expression, expectedError := range map[string]string{
+		"":                    "empty license expression",
+		"MIT OR":              "invalid license expression 'MIT OR': unexpected end of expression",
+		"(MIT OR Apache-2.0":  "invalid license expression '(MIT OR Apache-2.0': missing closing parenthesis",
+		"MIT Apache-2.0":      "invalid license expression 'MIT Apache-2.0': unexpected 'Apache-2.0'",
+		"GPL-2.0-only WITH":   "invalid license expression 'GPL-2.0-only WITH': missing exception after 'WITH'",
+		"AND MIT":             "invalid license expression 'AND MIT': unexpected 'AND'",
+		"MIT) OR (Apache-2.0": "invalid license expression 'MIT) OR (Apache-2.0': unexpected ')'",
+	} {
+		_, err := ParseExpression(expression)
+		assert.EqualError(t, err, expectedError, expression)
+	}
+}
@@ -0,0 +1,254 @@
+package licensing
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// Decision is the result of evaluating a license against the policy
+type Decision int
+
+// Decisions ordered from most to least favorable
+const (
+	Allowed Decision = iota
+	Review
+	Denied
+)
+
+func (d Decision) String() string {
+	return [...]string{"allowed", "review", "denied"}[d]
+}
+
+// ParseDecision converts the textual representation of a decision
+func ParseDecision(decision string) (Decision, error) {
+	switch strings.ToLower(decision) {
+	case "allow", "allowed":
+		return Allowed, nil
+	case "review":
+		return Review, nil
+	case "deny", "denied":
+		return Denied, nil
+	}
+	return Review, fmt.Errorf("invalid decision '%v', allowed values are 'allow', 'review' and 'deny'", decision)
+}
+
+// Policy defines which licenses are allowed, denied or require a review.
+// License entries are matched case-insensitively and may contain wildcards like "GPL-*".
+type Policy struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+	// Review lists licenses which need to be assessed by the legal department
+	Review []string `json:"review,omitempty"`
+	// Unknown is the decision for licenses not contained in any list and components without license information
+	Unknown    string      `json:"unknown,omitempty"`
+	Exceptions []Exception `json:"exceptions,omitempty"`
+}
+
+// Exception allows licenses for dedicated components regardless of the general policy
+type Exception struct {
+	// Component is a package URL or component name pattern, e.g. "pkg:maven/org.mariadb.jdbc/*"
+	Component string `json:"component"`
+	// Licenses are allowed for the component, an empty list allows all licenses
+	Licenses []string `json:"licenses,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+// LoadPolicy reads a policy in YAML or JSON format
+func LoadPolicy(path string, fileUtils piperutils.FileUtils) (Policy, error) {
+	var policy Policy
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return policy, errors.Wrapf(err, "failed to read license policy '%v'", path)
+	}
+	if err := yaml.Unmarshal(content, &policy); err != nil {
+		return policy, errors.Wrapf(err, "failed to parse license policy '%v'", path)
+	}
+	return policy, policy.validate()
+}
+
+// Merge adds the entries of another policy, the unknown decision of the other policy wins if set
+func (p Policy) Merge(other Policy) Policy {
+	merged := Policy{
+		Allow:      append(append([]string{}, p.Allow...), other.Allow...),
+		Deny:       append(append([]string{}, p.Deny...), other.Deny...),
+		Review:     append(append([]string{}, p.Review...), other.Review...),
+		Unknown:    p.Unknown,
+		Exceptions: append(append([]Exception{}, p.Exceptions...), other.Exceptions...),
+	}
+	if len(other.Unknown) > 0 {
+		merged.Unknown = other.Unknown
+	}
+	return merged
+}
+
+func (p Policy) validate() error {
+	if len(p.Unknown) > 0 {
+		if _, err := ParseDecision(p.Unknown); err != nil {
+			return errors.Wrap(err, "invalid license policy")
+		}
+	}
+	for _, exception := range p.Exceptions {
+		if len(exception.Component) == 0 {
+			return fmt.Errorf("invalid license policy: exception without component")
+		}
+	}
+	return nil
+}
+
+func (p Policy) unknownDecision() Decision {
+	if len(p.Unknown) == 0 {
+		return Review
+	}
+	decision, _ := ParseDecision(p.Unknown)
+	return decision
+}
+
+// Evaluation is the result of checking a component against the policy
+type Evaluation struct {
+	Component Component
+	Decision  Decision
+	// Licenses are the licenses the decision is based on
+	Licenses []string
+	Reason   string
+}
+
+// Evaluate checks the license expression of the component against the policy
+func (p Policy) Evaluate(component Component) Evaluation {
+	evaluation := Evaluation{Component: component}
+	exception := p.exceptionFor(component)
+	if exception != nil && len(exception.Licenses) == 0 {
+		evaluation.Decision = Allowed
+		evaluation.Reason = exceptionReason(exception)
+		return evaluation
+	}
+
+	if !component.HasLicense() {
+		evaluation.Decision = p.unknownDecision()
+		evaluation.Reason = "no license information available"
+		return evaluation
+	}
+	expression, err := ParseExpression(component.License)
+	if err != nil {
+		evaluation.Decision = p.unknownDecision()
+		evaluation.Licenses = []string{component.License}
+		evaluation.Reason = err.Error()
+		return evaluation
+	}
+
+	excepted := false
+	evaluation.Decision, evaluation.Licenses = expression.evaluate(func(license string) Decision {
+		if exception != nil && matchesAny(license, exception.Licenses) {
+			excepted = true
+			return Allowed
+		}
+		return p.decide(license)
+	})
+
+	switch {
+	case excepted && evaluation.Decision == Allowed:
+		evaluation.Reason = exceptionReason(exception)
+	case evaluation.Decision == Allowed:
+		evaluation.Reason = fmt.Sprintf("license %v is allowed", strings.Join(evaluation.Licenses, ", "))
+	case p.isListed(evaluation.Licenses):
+		evaluation.Reason = fmt.Sprintf("license %v is %v by the policy", strings.Join(evaluation.Licenses, ", "), evaluation.Decision.pastTense())
+	default:
+		evaluation.Reason = fmt.Sprintf("license %v is not covered by the policy", strings.Join(evaluation.Licenses, ", "))
+	}
+	return evaluation
+}
+
+func (d Decision) pastTense() string {
+	return [...]string{"allowed", "flagged for review", "denied"}[d]
+}
+
+func (p Policy) decide(license string) Decision {
+	// a license with exception is decided as a whole if listed, otherwise the base license decides
+	if parts := strings.SplitN(license, " WITH ", 2); len(parts) == 2 {
+		if decision, ok := p.lookup(license, withExceptions); ok {
+			return decision
+		}
+		license = parts[0]
+	}
+	if decision, ok := p.lookup(license, withoutExceptions); ok {
+		return decision
+	}
+	return p.unknownDecision()
+}
+
+func (p Policy) lookup(license string, filter func(string) bool) (Decision, bool) {
+	switch {
+	case matchesAny(license, filterPatterns(p.Deny, filter)):
+		return Denied, true
+	case matchesAny(license, filterPatterns(p.Review, filter)):
+		return Review, true
+	case matchesAny(license, filterPatterns(p.Allow, filter)):
+		return Allowed, true
+	}
+	return Allowed, false
+}
+
+func withExceptions(pattern string) bool {
+	return strings.Contains(strings.ToUpper(pattern), " WITH ")
+}
+
+func withoutExceptions(pattern string) bool {
+	return !withExceptions(pattern)
+}
+
+func filterPatterns(patterns []string, filter func(string) bool) []string {
+	filtered := []string{}
+	for _, pattern := range patterns {
+		if filter(pattern) {
+			filtered = append(filtered, pattern)
+		}
+	}
+	return filtered
+}
+
+func (p Policy) isListed(licenses []string) bool {
+	for _, license := range licenses {
+		base := strings.SplitN(license, " WITH ", 2)[0]
+		if _, ok := p.lookup(license, withExceptions); ok {
+			return true
+		}
+		if _, ok := p.lookup(base, withoutExceptions); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) exceptionFor(component Component) *Exception {
+	for i, exception := range p.Exceptions {
+		if component.Matches(exception.Component) {
+			return &p.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+func exceptionReason(exception *Exception) string {
+	if len(exception.Reason) > 0 {
+		return fmt.Sprintf("allowed by exception for '%v': %v", exception.Component, exception.Reason)
+	}
+	return fmt.Sprintf("allowed by exception for '%v'", exception.Component)
+}
+
+func matchesAny(value string, patterns []string) bool {
+	value = strings.ToLower(value)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if pattern == value {
+			return true
+		}
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+//go:build unit
+// +build unit
+
+package licensing
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicy = Policy{
+	Allow:  []string{"MIT", "Apache-2.0", "BSD-*", "GPL-2.0-only WITH Classpath-exception-2.0"},
+	Deny:   []string{"GPL-*", "AGPL-*"},
+	Review: []string{"LGPL-*"},
+	Exceptions: []Exception{
+		{Component: "pkg:maven/org.mariadb.jdbc/mariadb-java-client", Licenses: []string{"LGPL-2.1-or-later"}, Reason: "approved by legal"},
+		{Component: "internal-*"},
+	},
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		component Component
+		decision  Decision
+		licenses  []string
+		reason    string
+	}{
+		{
+			name:      "allowed",
+			component: Component{Name: "lodash", License: "MIT"},
+			decision:  Allowed,
+			licenses:  []string{"MIT"},
+			reason:    "license MIT is allowed",
+		},
+		{
+			name:      "wildcard, case-insensitive",
+			component: Component{Name: "a", License: "bsd-3-clause"},
+			decision:  Allowed,
+			licenses:  []string{"bsd-3-clause"},
+		},
+		{
+			name:      "OR picks the favorable license",
+			component: Component{Name: "a", License: "GPL-3.0-only OR Apache-2.0"},
+			decision:  Allowed,
+			licenses:  []string{"Apache-2.0"},
+		},
+		{
+			name:      "AND picks the unfavorable license",
+			component: Component{Name: "a", License: "MIT AND (LGPL-2.1-only OR GPL-3.0-only)"},
+			decision:  Review,
+			licenses:  []string{"LGPL-2.1-only"},
+			reason:    "license LGPL-2.1-only is flagged for review by the policy",
+		},
+		{
+			name:      "denied",
+			component: Component{Name: "a", License: "AGPL-3.0-only"},
+			decision:  Denied,
+			licenses:  []string{"AGPL-3.0-only"},
+			reason:    "license AGPL-3.0-only is denied by the policy",
+		},
+		{
+			name:      "license with listed exception",
+			component: Component{Name: "a", License: "GPL-2.0-only WITH Classpath-exception-2.0"},
+			decision:  Allowed,
+			licenses:  []string{"GPL-2.0-only WITH Classpath-exception-2.0"},
+		},
+		{
+			name:      "license with unlisted exception",
+			component: Component{Name: "a", License: "GPL-3.0-only WITH GCC-exception-3.1"},
+			decision:  Denied,
+		},
+		{
+			name:      "unknown license",
+			component: Component{Name: "a", License: "WTFPL"},
+			decision:  Review,
+			licenses:  []string{"WTFPL"},
+			reason:    "license WTFPL is not covered by the policy",
+		},
+		{
+			name:      "no license",
+			component: Component{Name: "a", License: "NOASSERTION"},
+			decision:  Review,
+			reason:    "no license information available",
+		},
+		{
+			name:      "invalid expression",
+			component: Component{Name: "a", License: "MIT OR"},
+			decision:  Review,
+			licenses:  []string{"MIT OR"},
+			reason:    "invalid license expression 'MIT OR': unexpected end of expression",
+		},
+		{
+			name:      "component exception",
+			component: Component{Name: "org.mariadb.jdbc:mariadb-java-client", PackageURL: "pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.8?type=jar", License: "LGPL-2.1-or-later"},
+			decision:  Allowed,
+			licenses:  []string{"LGPL-2.1-or-later"},
+			reason:    "allowed by exception for 'pkg:maven/org.mariadb.jdbc/mariadb-java-client': approved by legal",
+		},
+		{
+			name:      "component exception does not cover other licenses",
+			component: Component{Name: "org.mariadb.jdbc:mariadb-java-client", PackageURL: "pkg:maven/org.mariadb.jdbc/mariadb-java-client@3.0.8", License: "LGPL-2.1-or-later AND GPL-3.0-only"},
+			decision:  Denied,
+			licenses:  []string{"GPL-3.0-only"},
+		},
+		{
+			name:      "component exception for all licenses",
+			component: Component{Name: "internal-lib", License: "LicenseRef-Proprietary"},
+			decision:  Allowed,
+			reason:    "allowed by exception for 'internal-*'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			evaluation := testPolicy.Evaluate(test.component)
+			assert.Equal(t, test.decision, evaluation.Decision)
+			if test.licenses != nil {
+				assert.Equal(t, test.licenses, evaluation.Licenses)
+			}
+			if len(test.reason) > 0 {
+				assert.Equal(t, test.reason, evaluation.Reason)
+			}
+		})
+	}
+
+	t.Run("unknown decision", func(t *testing.T) {
+		policy := Policy{Allow: []string{"MIT"}, Unknown: "deny"}
+		assert.Equal(t, Denied, policy.Evaluate(Component{Name: "a"}).Decision)
+		assert.Equal(t, Denied, policy.Evaluate(Component{Name: "a", License: "MIT AND WTFPL"}).Decision)
+	})
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		path := "licensePolicy.yml"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte(`allow:
+  - MIT
+deny:
+  - GPL-*
+unknown: deny
+exceptions:
+  - component: pkg:npm/internal
+    reason: own code
+`))
+
+		policy, err := LoadPolicy(path, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Policy{
+			Allow:      []string{"MIT"},
+			Deny:       []string{"GPL-*"},
+			Unknown:    "deny",
+			Exceptions: []Exception{{Component: "pkg:npm/internal", Reason: "own code"}},
+		}, policy)
+	})
+
+	t.Run("invalid unknown decision", func(t *testing.T) {
+		path := "licensePolicy.yml"
+		files := &mock.FilesMock{}
+		files.AddFile(path, []byte("unknown: ignore"))
+
+		_, err := LoadPolicy(path, files)
+
+		assert.EqualError(t, err, "invalid license policy: invalid decision 'ignore', allowed values are 'allow', 'review' and 'deny'")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadPolicy("not/existing.yml", &mock.FilesMock{})
+		assert.ErrorContains(t, err, "failed to read license policy 'not/existing.yml'")
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	merged := Policy{Allow: []string{"MIT"}, Unknown: "review"}.Merge(Policy{Allow: []string{"ISC"}, Deny: []string{"GPL-*"}, Unknown: "deny"})
+	assert.Equal(t, Policy{Allow: []string{"MIT", "ISC"}, Deny: []string{"GPL-*"}, Review: []string{}, Unknown: "deny", Exceptions: []Exception{}}, merged)
+}
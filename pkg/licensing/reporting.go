@@ -0,0 +1,156 @@
+package licensing
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+// ReportsDirectory defines the subfolder for the license reports which are generated
+const ReportsDirectory = "licensing"
+
+// CountByDecision returns the number of evaluations per decision
+func CountByDecision(evaluations []Evaluation) map[Decision]int {
+	counts := map[Decision]int{Allowed: 0, Review: 0, Denied: 0}
+	for _, evaluation := range evaluations {
+		counts[evaluation.Decision]++
+	}
+	return counts
+}
+
+// CreateCustomReport creates a ScanReport of the evaluated components, violations are listed first
+func CreateCustomReport(evaluations []Evaluation, policyName string) reporting.ScanReport {
+	counts := CountByDecision(evaluations)
+	scanReport := reporting.ScanReport{
+		ReportTitle: "License Policy Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "License policy", Details: policyName},
+		},
+		Overview: []reporting.OverviewRow{
+			{Description: "Components", Details: fmt.Sprint(len(evaluations))},
+			{Description: "Allowed components", Details: fmt.Sprint(counts[Allowed])},
+			{Description: "Components requiring review", Details: fmt.Sprint(counts[Review]), Style: styleFor(Review, counts[Review])},
+			{Description: "Denied components", Details: fmt.Sprint(counts[Denied]), Style: styleFor(Denied, counts[Denied])},
+		},
+		SuccessfulScan: counts[Denied] == 0,
+		ReportTime:     time.Now(),
+	}
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No components found",
+		Headers: []string{
+			"Component",
+			"Version",
+			"License expression",
+			"Decision",
+			"Reason",
+		},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, decision := range []Decision{Denied, Review, Allowed} {
+		for _, evaluation := range evaluations {
+			if evaluation.Decision != decision {
+				continue
+			}
+			row := reporting.ScanRow{}
+			row.AddColumn(evaluation.Component.Name, 0)
+			row.AddColumn(evaluation.Component.Version, 0)
+			row.AddColumn(evaluation.Component.License, 0)
+			row.AddColumn(evaluation.Decision, styleFor(evaluation.Decision, 1))
+			row.AddColumn(evaluation.Reason, 0)
+			detailTable.Rows = append(detailTable.Rows, row)
+		}
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
+
+func styleFor(decision Decision, count int) reporting.ColumnStyle {
+	switch {
+	case count == 0:
+		return 0
+	case decision == Denied:
+		return reporting.Red
+	case decision == Review:
+		return reporting.Yellow
+	}
+	return reporting.Green
+}
+
+// CreatePolicyViolationReport creates the issue content for a component which violates the policy
+func CreatePolicyViolationReport(evaluation Evaluation) reporting.PolicyViolationReport {
+	group, artifact := "", evaluation.Component.Name
+	if parts := strings.SplitN(evaluation.Component.Name, ":", 2); len(parts) == 2 {
+		group, artifact = parts[0], parts[1]
+	}
+	return reporting.PolicyViolationReport{
+		ArtifactID: artifact,
+		Group:      group,
+		Version:    evaluation.Component.Version,
+		PackageURL: evaluation.Component.ID(),
+		Description: fmt.Sprintf("The license expression `%v` of the component is %v: %v.",
+			evaluation.Component.License, evaluation.Decision, evaluation.Reason),
+		Footer: fmt.Sprintf("Detected in `%v`", evaluation.Component.Source),
+	}
+}
+
+// WriteCustomReports writes the HTML report, the JSON step report and the policy violations in markdown format
+func WriteCustomReports(scanReport reporting.ScanReport, evaluations []Evaluation, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	htmlReportPath := filepath.Join(ReportsDirectory, "piper_license_policy_report.html")
+	if err := fileUtils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "License Policy Report", Target: htmlReportPath})
+
+	violations := bytes.Buffer{}
+	for _, evaluation := range evaluations {
+		if evaluation.Decision == Allowed {
+			continue
+		}
+		policyReport := CreatePolicyViolationReport(evaluation)
+		markdown, err := policyReport.ToMarkdown()
+		if err != nil {
+			return reportPaths, errors.Wrapf(err, "failed to create policy violation report for '%v'", evaluation.Component.ID())
+		}
+		violations.Write(markdown)
+		violations.WriteString("\n")
+	}
+	if violations.Len() > 0 {
+		violationsPath := filepath.Join(ReportsDirectory, "piper_license_policy_violations.md")
+		if err := fileUtils.FileWrite(violationsPath, violations.Bytes(), 0666); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to write policy violation report")
+		}
+		reportPaths = append(reportPaths, piperutils.Path{Name: "License Policy Violations", Target: violationsPath})
+	}
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := fileUtils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := fileUtils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create step reporting directory")
+		}
+	}
+	if err := fileUtils.FileWrite(filepath.Join(reporting.StepReportDirectory, "licensePolicyCheck_licenses.json"), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+
+	return reportPaths, nil
+}
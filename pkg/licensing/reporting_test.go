@@ -0,0 +1,69 @@
+//go:build unit
+// +build unit
+
+package licensing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvaluations = []Evaluation{
+	{Component: Component{Name: "lodash", Version: "4.17.21", License: "MIT"}, Decision: Allowed, Reason: "license MIT is allowed"},
+	{Component: Component{Name: "org.example:lib", Version: "1.0", PackageURL: "pkg:maven/org.example/lib@1.0", License: "GPL-3.0-only", Source: "bom-maven.xml"}, Decision: Denied, Reason: "license GPL-3.0-only is denied by the policy"},
+	{Component: Component{Name: "other", License: "LGPL-2.1-only"}, Decision: Review, Reason: "license LGPL-2.1-only is flagged for review by the policy"},
+}
+
+func TestCreateCustomReport(t *testing.T) {
+	t.Parallel()
+
+	report := CreateCustomReport(testEvaluations, "licensePolicy.yml")
+
+	assert.False(t, report.SuccessfulScan)
+	assert.Equal(t, reporting.OverviewRow{Description: "Denied components", Details: "1", Style: reporting.Red}, report.Overview[3])
+	require.Len(t, report.DetailTable.Rows, 3)
+	assert.Equal(t, "org.example:lib", report.DetailTable.Rows[0].Columns[0].Content)
+	assert.Equal(t, "denied", report.DetailTable.Rows[0].Columns[3].Content)
+	assert.Equal(t, "other", report.DetailTable.Rows[1].Columns[0].Content)
+	assert.Equal(t, "lodash", report.DetailTable.Rows[2].Columns[0].Content)
+
+	assert.True(t, CreateCustomReport(testEvaluations[2:], "").SuccessfulScan)
+}
+
+func TestCreatePolicyViolationReport(t *testing.T) {
+	t.Parallel()
+
+	report := CreatePolicyViolationReport(testEvaluations[1])
+
+	assert.Equal(t, reporting.PolicyViolationReport{
+		ArtifactID:  "lib",
+		Group:       "org.example",
+		Version:     "1.0",
+		PackageURL:  "pkg:maven/org.example/lib@1.0",
+		Description: "The license expression `GPL-3.0-only` of the component is denied: license GPL-3.0-only is denied by the policy.",
+		Footer:      "Detected in `bom-maven.xml`",
+	}, report)
+}
+
+func TestWriteCustomReports(t *testing.T) {
+	t.Parallel()
+
+	utils := &mock.FilesMock{}
+
+	paths, err := WriteCustomReports(CreateCustomReport(testEvaluations, ""), testEvaluations, utils)
+
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.True(t, utils.HasWrittenFile(filepath.Join(ReportsDirectory, "piper_license_policy_report.html")))
+	assert.True(t, utils.HasWrittenFile(filepath.Join(reporting.StepReportDirectory, "licensePolicyCheck_licenses.json")))
+	violations, err := utils.FileRead(filepath.Join(ReportsDirectory, "piper_license_policy_violations.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(violations), "# Policy Violation - pkg:maven/org.example/lib@1.0")
+	assert.Contains(t, string(violations), "# Policy Violation - other")
+	assert.NotContains(t, string(violations), "lodash")
+}
@@ -3,8 +3,10 @@ package maven
 import (
 	"encoding/xml"
 	"fmt"
-	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"path/filepath"
+
+	"github.com/SAP/jenkins-library/pkg/changeimpact"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
 )
 
 // Project describes the Maven object model.
@@ -108,3 +110,54 @@ func VisitAllMavenModules(path string, utils visitUtils, excludes []string, call
 	}
 	return nil
 }
+
+// AffectedModules returns the modules which contain one of the changed files as well as the modules of the reactor
+// depending on them directly or via their parent. A change of a parent POM therefore affects all of its child modules.
+func AffectedModules(path string, utils visitUtils, excludes []string, changedFiles []string) ([]ModuleInfo, error) {
+	moduleInfos := map[string]ModuleInfo{}
+	modules := []changeimpact.Module{}
+	err := VisitAllMavenModules(path, utils, excludes, func(info ModuleInfo) error {
+		id := info.Project.coordinates()
+		moduleInfos[id] = info
+		module := changeimpact.Module{ID: id, Dir: filepath.Dir(info.PomXMLPath)}
+		if len(info.Project.Parent.ArtifactID) > 0 {
+			module.Dependencies = append(module.Dependencies, info.Project.Parent.GroupID+":"+info.Project.Parent.ArtifactID)
+		}
+		for _, dependency := range info.Project.Dependencies {
+			module.Dependencies = append(module.Dependencies, info.Project.resolveGroupID(dependency.GroupID)+":"+dependency.ArtifactID)
+		}
+		modules = append(modules, module)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	affected := []ModuleInfo{}
+	for _, module := range changeimpact.Affected(modules, changedFiles) {
+		affected = append(affected, moduleInfos[module.ID])
+	}
+	return affected, nil
+}
+
+func (p *Project) groupID() string {
+	if len(p.GroupID) > 0 {
+		return p.GroupID
+	}
+	return p.Parent.GroupID
+}
+
+func (p *Project) coordinates() string {
+	return p.groupID() + ":" + p.ArtifactID
+}
+
+// resolveGroupID resolves the commonly used references to the group of the project itself
+func (p *Project) resolveGroupID(groupID string) string {
+	switch groupID {
+	case "${project.groupId}", "${pom.groupId}":
+		return p.groupID()
+	case "${project.parent.groupId}":
+		return p.Parent.GroupID
+	}
+	return groupID
+}
@@ -4,9 +4,11 @@
 package maven
 
 import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
 const aggregatorPomXML = `<?xml version="1.0" encoding="UTF-8"?>
@@ -66,3 +68,51 @@ func TestParsePOM(t *testing.T) {
 		assert.Equal(t, project.Parent.ArtifactID, "artifact")
 	})
 }
+
+func TestAffectedModules(t *testing.T) {
+	files := &mock.FilesMock{}
+	files.AddFile("pom.xml", []byte(`<project><groupId>com.example</groupId><artifactId>root</artifactId><modules><module>core</module><module>api</module><module>app</module><module>tools</module></modules></project>`))
+	files.AddFile("core/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>core</artifactId></project>`))
+	files.AddFile("api/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>api</artifactId>
+		<dependencies><dependency><groupId>${project.groupId}</groupId><artifactId>core</artifactId></dependency></dependencies></project>`))
+	files.AddFile("app/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>app</artifactId>
+		<dependencies><dependency><groupId>com.example</groupId><artifactId>api</artifactId></dependency><dependency><groupId>org.other</groupId><artifactId>core</artifactId></dependency></dependencies></project>`))
+	files.AddFile("tools/pom.xml", []byte(`<project><parent><groupId>com.example</groupId><artifactId>root</artifactId></parent><artifactId>tools</artifactId></project>`))
+
+	artifactIDs := func(modules []ModuleInfo) []string {
+		ids := []string{}
+		for _, m := range modules {
+			ids = append(ids, m.Project.ArtifactID)
+		}
+		return ids
+	}
+
+	t.Run("change in module affects its dependents", func(t *testing.T) {
+		modules, err := AffectedModules(".", files, nil, []string{"core/src/main/java/Core.java"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"core", "api", "app"}, artifactIDs(modules))
+		assert.Equal(t, "api/pom.xml", modules[1].PomXMLPath)
+	})
+
+	t.Run("change of leaf module", func(t *testing.T) {
+		modules, err := AffectedModules(".", files, nil, []string{"app/pom.xml", "tools/README.md"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app", "tools"}, artifactIDs(modules))
+	})
+
+	t.Run("change of parent affects all modules", func(t *testing.T) {
+		modules, err := AffectedModules(".", files, nil, []string{"pom.xml"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"root", "core", "api", "app", "tools"}, artifactIDs(modules))
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		modules, err := AffectedModules(".", files, nil, []string{})
+
+		require.NoError(t, err)
+		assert.Empty(t, modules)
+	})
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+// Options configure the exporters, an exporter is created for each configured target
+type Options struct {
+	OpenMetricsDirectory string
+	PushgatewayURL       string
+	PushgatewayJob       string
+}
+
+// NewExporters creates the exporters for the configured targets
+func NewExporters(options Options) []Exporter {
+	exporters := []Exporter{}
+	if options.OpenMetricsDirectory != "" {
+		exporters = append(exporters, &OpenMetricsFile{Directory: options.OpenMetricsDirectory})
+	}
+	if options.PushgatewayURL != "" {
+		job := options.PushgatewayJob
+		if job == "" {
+			job = "piper"
+		}
+		exporters = append(exporters, &Pushgateway{URL: options.PushgatewayURL, Job: job, Client: &piperhttp.Client{}})
+	}
+	return exporters
+}
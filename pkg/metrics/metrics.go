@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StepMetrics contains the metrics of a step run: the data of the influx resource of the step as well as duration, result and error category
+type StepMetrics struct {
+	Step          string
+	Duration      time.Duration
+	Success       bool
+	ErrorCategory string
+	// Fields and Tags of the influx measurements as written to InfluxDB
+	Fields map[string]map[string]interface{}
+	Tags   map[string]map[string]string
+	// Labels are added to all samples, e.g. to distinguish repositories
+	Labels map[string]string
+}
+
+// Exporter sends step metrics to a monitoring system
+type Exporter interface {
+	Export(metrics StepMetrics) error
+}
+
+type sample struct {
+	name   string
+	help   string
+	labels map[string]string
+	value  float64
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// metricName converts a name into a valid metric or label name
+func metricName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(name, "_")
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// samples converts the step metrics, fields of the influx measurements become gauges named piper_<measurement>_<field>.
+// Influx tags are added as labels, string fields are skipped since they cannot be represented as sample values.
+func (s StepMetrics) samples() []sample {
+	labels := map[string]string{}
+	for name, value := range s.Labels {
+		labels[metricName(name)] = value
+	}
+	labels["step"] = s.Step
+
+	success := 0.0
+	if s.Success {
+		success = 1
+	}
+	samples := []sample{
+		{name: "piper_step_duration_seconds", help: "Duration of the step run.", labels: labels, value: s.Duration.Seconds()},
+		{name: "piper_step_success", help: "Whether the step run succeeded.", labels: labels, value: success},
+	}
+	if !s.Success {
+		samples = append(samples, sample{name: "piper_step_error_info", help: "Error category of the failed step run.", labels: withLabels(labels, map[string]string{"error_category": s.ErrorCategory}), value: 1})
+	}
+
+	for measurement, fields := range s.Fields {
+		measurementLabels := withLabels(labels, s.Tags[measurement])
+		for field, value := range fields {
+			number, ok := toFloat(value)
+			if !ok {
+				continue
+			}
+			name := metricName(fmt.Sprintf("piper_%v_%v", measurement, field))
+			samples = append(samples, sample{name: name, help: fmt.Sprintf("Field %v of the measurement %v.", field, measurement), labels: measurementLabels, value: number})
+		}
+	}
+	return samples
+}
+
+func withLabels(labels map[string]string, additional map[string]string) map[string]string {
+	merged := map[string]string{}
+	for name, value := range labels {
+		merged[name] = value
+	}
+	for name, value := range additional {
+		if value != "" {
+			merged[metricName(name)] = value
+		}
+	}
+	return merged
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// writeText writes the samples in the text exposition format. With openMetrics the output is terminated by "# EOF" as required by OpenMetrics.
+// Only gauges are written, which makes the output valid for both OpenMetrics and the Prometheus text format.
+func writeText(w io.Writer, samples []sample, openMetrics bool) error {
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].name < samples[j].name })
+
+	var builder strings.Builder
+	for i, s := range samples {
+		if i == 0 || samples[i-1].name != s.name {
+			fmt.Fprintf(&builder, "# HELP %v %v\n", s.name, escapeHelp(s.help))
+			fmt.Fprintf(&builder, "# TYPE %v gauge\n", s.name)
+		}
+		fmt.Fprintf(&builder, "%v%v %v\n", s.name, formatLabels(s.labels), formatValue(s.value))
+	}
+	if openMetrics {
+		builder.WriteString("# EOF\n")
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%v=\"%v\"", name, escapeLabelValue(labels[name])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,79 @@
+//go:build unit
+// +build unit
+
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful step", func(t *testing.T) {
+		metrics := StepMetrics{
+			Step:     "checkmarxExecuteScan",
+			Duration: 1500 * time.Millisecond,
+			Success:  true,
+			Fields: map[string]map[string]interface{}{
+				"step_data":      {"checkmarx": true},
+				"checkmarx_data": {"high_issues": 3, "preset": "default", "scan-time": 12.5},
+			},
+			Tags:   map[string]map[string]string{"checkmarx_data": {"team": "security \"core\""}},
+			Labels: map[string]string{"repository": "my-repo"},
+		}
+		buffer := &bytes.Buffer{}
+
+		assert.NoError(t, writeText(buffer, metrics.samples(), true))
+		assert.Equal(t, `# HELP piper_checkmarx_data_high_issues Field high_issues of the measurement checkmarx_data.
+# TYPE piper_checkmarx_data_high_issues gauge
+piper_checkmarx_data_high_issues{repository="my-repo",step="checkmarxExecuteScan",team="security \"core\""} 3
+# HELP piper_checkmarx_data_scan_time Field scan-time of the measurement checkmarx_data.
+# TYPE piper_checkmarx_data_scan_time gauge
+piper_checkmarx_data_scan_time{repository="my-repo",step="checkmarxExecuteScan",team="security \"core\""} 12.5
+# HELP piper_step_data_checkmarx Field checkmarx of the measurement step_data.
+# TYPE piper_step_data_checkmarx gauge
+piper_step_data_checkmarx{repository="my-repo",step="checkmarxExecuteScan"} 1
+# HELP piper_step_duration_seconds Duration of the step run.
+# TYPE piper_step_duration_seconds gauge
+piper_step_duration_seconds{repository="my-repo",step="checkmarxExecuteScan"} 1.5
+# HELP piper_step_success Whether the step run succeeded.
+# TYPE piper_step_success gauge
+piper_step_success{repository="my-repo",step="checkmarxExecuteScan"} 1
+# EOF
+`, buffer.String())
+	})
+
+	t.Run("failed step", func(t *testing.T) {
+		metrics := StepMetrics{Step: "mavenBuild", Duration: time.Minute, ErrorCategory: "build"}
+		buffer := &bytes.Buffer{}
+
+		assert.NoError(t, writeText(buffer, metrics.samples(), false))
+		assert.Equal(t, `# HELP piper_step_duration_seconds Duration of the step run.
+# TYPE piper_step_duration_seconds gauge
+piper_step_duration_seconds{step="mavenBuild"} 60
+# HELP piper_step_error_info Error category of the failed step run.
+# TYPE piper_step_error_info gauge
+piper_step_error_info{error_category="build",step="mavenBuild"} 1
+# HELP piper_step_success Whether the step run succeeded.
+# TYPE piper_step_success gauge
+piper_step_success{step="mavenBuild"} 0
+`, buffer.String())
+	})
+}
+
+func TestNewExporters(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, NewExporters(Options{}))
+
+	exporters := NewExporters(Options{OpenMetricsDirectory: "metrics", PushgatewayURL: "http://pushgateway:9091"})
+	if assert.Len(t, exporters, 2) {
+		assert.Equal(t, &OpenMetricsFile{Directory: "metrics"}, exporters[0])
+		assert.Equal(t, "piper", exporters[1].(*Pushgateway).Job)
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OpenMetricsFile writes the metrics of each step into <Directory>/piper_<step>.prom,
+// e.g. to be collected by the textfile collector of the Prometheus node exporter
+type OpenMetricsFile struct {
+	Directory string
+}
+
+// Export writes the metrics file of the step, the file is replaced atomically so that collectors never read partial content
+func (o *OpenMetricsFile) Export(metrics StepMetrics) error {
+	if err := os.MkdirAll(o.Directory, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %v: %w", o.Directory, err)
+	}
+	target := filepath.Join(o.Directory, fmt.Sprintf("piper_%v.prom", metricName(metrics.Step)))
+	file, err := os.CreateTemp(o.Directory, ".piper_metrics_*")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	err = writeText(file, metrics.samples(), true)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), target); err != nil {
+		return fmt.Errorf("failed to write metrics file %v: %w", target, err)
+	}
+	return nil
+}
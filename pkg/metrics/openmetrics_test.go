@@ -0,0 +1,31 @@
+//go:build unit
+// +build unit
+
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenMetricsFile(t *testing.T) {
+	t.Parallel()
+	directory := filepath.Join(t.TempDir(), "metrics")
+	exporter := &OpenMetricsFile{Directory: directory}
+
+	assert.NoError(t, exporter.Export(StepMetrics{Step: "mavenBuild", Duration: time.Second, Success: true}))
+	assert.NoError(t, exporter.Export(StepMetrics{Step: "mavenBuild", Duration: 2 * time.Second, Success: true}))
+
+	content, err := os.ReadFile(filepath.Join(directory, "piper_mavenBuild.prom"))
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(content), "piper_step_duration_seconds{step=\"mavenBuild\"} 2\n")
+		assert.Contains(t, string(content), "# EOF\n")
+	}
+	entries, err := os.ReadDir(directory)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+// Pushgateway pushes the metrics of each step to a Prometheus Pushgateway.
+// The metrics are grouped by job, step and the labels of the step metrics, a push replaces the previous metrics of the group.
+type Pushgateway struct {
+	URL    string
+	Job    string
+	Client piperhttp.Sender
+}
+
+// Export pushes the metrics of the step
+func (p *Pushgateway) Export(metrics StepMetrics) error {
+	body := &bytes.Buffer{}
+	if err := writeText(body, metrics.samples(), false); err != nil {
+		return err
+	}
+	header := http.Header{"Content-Type": []string{"text/plain; version=0.0.4; charset=utf-8"}}
+	response, err := p.Client.SendRequest(http.MethodPut, p.groupURL(metrics), body, header, nil)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics to %v: %w", p.URL, err)
+	}
+	defer response.Body.Close()
+	return nil
+}
+
+// groupURL returns the URL of the grouping key, e.g. <URL>/metrics/job/piper/step/mavenBuild
+func (p *Pushgateway) groupURL(metrics StepMetrics) string {
+	groupURL := strings.TrimSuffix(p.URL, "/") + "/metrics" + groupingPath("job", p.Job) + groupingPath("step", metrics.Step)
+	names := make([]string, 0, len(metrics.Labels))
+	for name := range metrics.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		groupURL += groupingPath(metricName(name), metrics.Labels[name])
+	}
+	return groupURL
+}
+
+// groupingPath encodes values which cannot be part of a path segment in base64 as supported by the Pushgateway.
+// Empty values are encoded as '=', the base64 representation the Pushgateway expects for them.
+func groupingPath(name, value string) string {
+	if value == "" {
+		return fmt.Sprintf("/%v@base64/=", name)
+	}
+	if strings.Contains(value, "/") {
+		return fmt.Sprintf("/%v@base64/%v", name, base64.RawURLEncoding.EncodeToString([]byte(value)))
+	}
+	return fmt.Sprintf("/%v/%v", name, url.PathEscape(value))
+}
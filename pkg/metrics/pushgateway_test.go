@@ -0,0 +1,59 @@
+//go:build unit
+// +build unit
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushgateway(t *testing.T) {
+	t.Parallel()
+
+	t.Run("push metrics", func(t *testing.T) {
+		var method, path, contentType, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method, path, contentType = r.Method, r.URL.EscapedPath(), r.Header.Get("Content-Type")
+			content, _ := io.ReadAll(r.Body)
+			body = string(content)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		exporter := &Pushgateway{URL: server.URL + "/", Job: "piper", Client: &piperhttp.Client{}}
+
+		err := exporter.Export(StepMetrics{Step: "mavenBuild", Duration: time.Second, Success: true, Labels: map[string]string{"repository": "org/repo", "branch": "main"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPut, method)
+		assert.Equal(t, "/metrics/job/piper/step/mavenBuild/branch/main/repository@base64/b3JnL3JlcG8", path)
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", contentType)
+		assert.Contains(t, body, `piper_step_success{branch="main",repository="org/repo",step="mavenBuild"} 1`)
+		assert.NotContains(t, body, "# EOF")
+	})
+
+	t.Run("grouping path", func(t *testing.T) {
+		assert.Equal(t, "/branch/main", groupingPath("branch", "main"))
+		assert.Equal(t, "/branch/feature%20x", groupingPath("branch", "feature x"))
+		assert.Equal(t, "/repository@base64/b3JnL3JlcG8", groupingPath("repository", "org/repo"))
+		assert.Equal(t, "/branch@base64/=", groupingPath("branch", ""))
+	})
+
+	t.Run("push rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+		exporter := &Pushgateway{URL: server.URL, Job: "piper", Client: &piperhttp.Client{}}
+
+		err := exporter.Export(StepMetrics{Step: "mavenBuild"})
+
+		assert.ErrorContains(t, err, "failed to push metrics to "+server.URL)
+	})
+}
@@ -0,0 +1,83 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/changeimpact"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+)
+
+// lockFiles change the installed dependencies of all packages located in the directory of the lock file
+var lockFiles = []string{"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml"}
+
+type packageDependencies struct {
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// FindAffectedPackageJSONFiles returns the package.json files of the packages affected by the changed files, see AffectedPackages
+func (exec *Execute) FindAffectedPackageJSONFiles(packageJSONFiles []string, changedFiles []string) ([]string, error) {
+	return AffectedPackages(packageJSONFiles, changedFiles, exec.Utils)
+}
+
+// AffectedPackages returns the package.json files of the packages containing one of the changed files and of the packages
+// depending on them, e.g. other packages of the same workspace. A changed lock file affects all packages in its directory and below.
+func AffectedPackages(packageJSONFiles []string, changedFiles []string, utils piperutils.FileUtils) ([]string, error) {
+	packages := map[string]packageDependencies{}
+	packageFiles := map[string]string{}
+	for _, file := range packageJSONFiles {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		p := packageDependencies{}
+		if err := json.Unmarshal(content, &p); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", file, err)
+		}
+		packages[file] = p
+		if len(p.Name) > 0 {
+			packageFiles[p.Name] = file
+		}
+	}
+
+	modules := []changeimpact.Module{}
+	for _, file := range packageJSONFiles {
+		module := changeimpact.Module{ID: file, Dir: filepath.Dir(file)}
+		p := packages[file]
+		for _, dependencies := range []map[string]string{p.Dependencies, p.DevDependencies, p.PeerDependencies, p.OptionalDependencies} {
+			for name := range dependencies {
+				if dependency, ok := packageFiles[name]; ok {
+					module.Dependencies = append(module.Dependencies, dependency)
+				}
+			}
+		}
+		modules = append(modules, module)
+	}
+
+	files := append([]string{}, changedFiles...)
+	for _, changedFile := range changedFiles {
+		if !piperutils.ContainsString(lockFiles, path.Base(changedFile)) {
+			continue
+		}
+		lockDir := path.Dir(changedFile)
+		for _, file := range packageJSONFiles {
+			file = filepath.ToSlash(file)
+			if lockDir == "." || strings.HasPrefix(file, lockDir+"/") {
+				files = append(files, file)
+			}
+		}
+	}
+
+	affected := []string{}
+	for _, module := range changeimpact.Affected(modules, files) {
+		affected = append(affected, module.ID)
+	}
+	return affected, nil
+}
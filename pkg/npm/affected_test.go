@@ -0,0 +1,50 @@
+//go:build unit
+// +build unit
+
+package npm
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffectedPackages(t *testing.T) {
+	files := &mock.FilesMock{}
+	files.AddFile("package.json", []byte(`{"name": "monorepo", "private": true, "workspaces": ["packages/*"]}`))
+	files.AddFile("packages/utils/package.json", []byte(`{"name": "@example/utils"}`))
+	files.AddFile("packages/ui/package.json", []byte(`{"name": "@example/ui", "dependencies": {"@example/utils": "workspace:*", "react": "^18.0.0"}}`))
+	files.AddFile("packages/app/package.json", []byte(`{"name": "@example/app", "devDependencies": {"@example/ui": "*"}}`))
+	files.AddFile("packages/cli/package.json", []byte(`{"name": "@example/cli"}`))
+	packageJSONFiles := []string{"package.json", "packages/utils/package.json", "packages/ui/package.json", "packages/app/package.json", "packages/cli/package.json"}
+
+	t.Run("internal dependents", func(t *testing.T) {
+		affected, err := AffectedPackages(packageJSONFiles, []string{"packages/utils/src/index.ts"}, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"packages/utils/package.json", "packages/ui/package.json", "packages/app/package.json"}, affected)
+	})
+
+	t.Run("lock file", func(t *testing.T) {
+		affected, err := AffectedPackages(packageJSONFiles, []string{"package-lock.json"}, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, packageJSONFiles, affected)
+	})
+
+	t.Run("files outside of workspace packages", func(t *testing.T) {
+		affected, err := AffectedPackages(packageJSONFiles, []string{".github/workflows/ci.yml"}, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"package.json"}, affected)
+	})
+
+	t.Run("invalid package.json", func(t *testing.T) {
+		files.AddFile("broken/package.json", []byte(`{`))
+
+		_, err := AffectedPackages([]string{"broken/package.json"}, []string{"broken/index.js"}, files)
+
+		assert.EqualError(t, err, "failed to unmarshal broken/package.json: unexpected end of JSON input")
+	})
+}
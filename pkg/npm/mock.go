@@ -61,6 +61,11 @@ func (n *NpmExecutorMock) FindPackageJSONFilesWithScript(packageJSONFiles []stri
 	return packageJSONFiles, nil
 }
 
+// FindAffectedPackageJSONFiles mock implementation
+func (n *NpmExecutorMock) FindAffectedPackageJSONFiles(packageJSONFiles []string, changedFiles []string) ([]string, error) {
+	return AffectedPackages(packageJSONFiles, changedFiles, n.Utils)
+}
+
 // RunScriptsInAllPackages mock implementation
 func (n *NpmExecutorMock) RunScriptsInAllPackages(runScripts []string, runOptions []string, scriptOptions []string, virtualFrameBuffer bool, excludeList []string, packagesList []string) error {
 	if len(runScripts) != len(n.Config.RunScripts) {
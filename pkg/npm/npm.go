@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/SAP/jenkins-library/pkg/command"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
@@ -20,6 +21,9 @@ const (
 	cycloneDxBomPackageVersion     = "@cyclonedx/bom@^3.10.6"
 	cycloneDxNpmInstallationFolder = "./tmp" // This folder is also added to npmignore in publish.go.Any changes to this folder needs a change in publish.go publish()
 	cycloneDxSchemaVersion         = "1.4"
+	cycloneDxYarnPackageVersion    = "@cyclonedx/yarn-plugin-cyclonedx@^1"
+	cycloneDxPnpmPackageVersion    = "@cyclonedx/cdxgen@^10"
+	pnpmBomFilename                = "bom-npm.json" // cdxgen only supports the JSON format, the BOM is converted to bom-npm.xml
 )
 
 // Execute struct holds utils to enable mocking and common parameters
@@ -33,6 +37,7 @@ type Executor interface {
 	FindPackageJSONFiles() []string
 	FindPackageJSONFilesWithExcludes(excludeList []string) ([]string, error)
 	FindPackageJSONFilesWithScript(packageJSONFiles []string, script string) ([]string, error)
+	FindAffectedPackageJSONFiles(packageJSONFiles []string, changedFiles []string) ([]string, error)
 	RunScriptsInAllPackages(runScripts []string, runOptions []string, scriptOptions []string, virtualFrameBuffer bool, excludeList []string, packagesList []string) error
 	InstallAllDependencies(packageJSONFiles []string) error
 	PublishAllPackages(packageJSONFiles []string, registry, username, password string, packBeforePublish bool, buildCoordinates *[]versioning.Coordinates) error
@@ -58,6 +63,7 @@ func NewExecutor(executorOptions ExecutorOptions) Executor {
 // ExecRunner interface to enable mocking for testing
 type ExecRunner interface {
 	SetEnv(e []string)
+	AppendEnv(e []string)
 	Stdout(out io.Writer)
 	Stderr(out io.Writer)
 	RunExecutable(executable string, params ...string) error
@@ -179,6 +185,12 @@ func (exec *Execute) executeScript(packageJSON string, script string, runOptions
 		return fmt.Errorf("failed to get current working directory before executing npm scripts: %w", err)
 	}
 
+	pm, err := exec.DetectPackageManager(packageJSON)
+	if err != nil {
+		return err
+	}
+	exec.setYarnRegistry(pm)
+
 	dir := filepath.Dir(packageJSON)
 	err = exec.Utils.Chdir(dir)
 	if err != nil {
@@ -193,19 +205,10 @@ func (exec *Execute) executeScript(packageJSON string, script string, runOptions
 
 	log.Entry().WithField("WorkingDirectory", dir).Info("run-script " + script)
 
-	npmRunArgs := []string{"run", script}
-	if len(runOptions) > 0 {
-		npmRunArgs = append(npmRunArgs, runOptions...)
-	}
-
-	if len(scriptOptions) > 0 {
-		npmRunArgs = append(npmRunArgs, "--")
-		npmRunArgs = append(npmRunArgs, scriptOptions...)
-	}
-
-	err = execRunner.RunExecutable("npm", npmRunArgs...)
+	executable, runArgs := pm.RunCommand(script, runOptions, scriptOptions)
+	err = execRunner.RunExecutable(executable, runArgs...)
 	if err != nil {
-		return fmt.Errorf("failed to run npm script %s: %w", script, err)
+		return fmt.Errorf("failed to run %s script %s: %w", executable, script, err)
 	}
 
 	err = exec.Utils.Chdir(oldWorkingDirectory)
@@ -269,8 +272,10 @@ func (exec *Execute) FindPackageJSONFilesWithScript(packageJSONFiles []string, s
 	return packagesWithScript, nil
 }
 
-// InstallAllDependencies executes npm or yarn Install for all package.json fileUtils defined in packageJSONFiles
+// InstallAllDependencies executes npm, yarn or pnpm install for all package.json fileUtils defined in packageJSONFiles.
+// The dependencies of workspace members are installed once from the root of the workspace.
 func (exec *Execute) InstallAllDependencies(packageJSONFiles []string) error {
+	installed := map[string]bool{}
 	for _, packageJSON := range packageJSONFiles {
 		fileExists, err := exec.Utils.FileExists(packageJSON)
 		if err != nil {
@@ -280,6 +285,16 @@ func (exec *Execute) InstallAllDependencies(packageJSONFiles []string) error {
 			return fmt.Errorf("package.json file '%s' not found: %w", packageJSON, err)
 		}
 
+		pm, err := exec.DetectPackageManager(packageJSON)
+		if err != nil {
+			return err
+		}
+		if installed[pm.RootDir] {
+			log.Entry().Infof("Dependencies of %s have already been installed in workspace %s", packageJSON, pm.RootDir)
+			continue
+		}
+		installed[pm.RootDir] = true
+
 		err = exec.install(packageJSON)
 		if err != nil {
 			return err
@@ -288,7 +303,7 @@ func (exec *Execute) InstallAllDependencies(packageJSONFiles []string) error {
 	return nil
 }
 
-// install executes npm or yarn Install for package.json
+// install executes npm, yarn or pnpm install for package.json, respectively for the workspace the package.json belongs to
 func (exec *Execute) install(packageJSON string) error {
 	execRunner := exec.Utils.GetExecRunner()
 
@@ -297,7 +312,13 @@ func (exec *Execute) install(packageJSON string) error {
 		return fmt.Errorf("failed to get current working directory before executing npm scripts: %w", err)
 	}
 
-	dir := filepath.Dir(packageJSON)
+	pm, err := exec.DetectPackageManager(packageJSON)
+	if err != nil {
+		return err
+	}
+	exec.setYarnRegistry(pm)
+
+	dir := pm.RootDir
 	err = exec.Utils.Chdir(dir)
 	if err != nil {
 		return fmt.Errorf("failed to change into directory for executing script: %w", err)
@@ -308,56 +329,111 @@ func (exec *Execute) install(packageJSON string) error {
 		return err
 	}
 
-	packageLockExists, yarnLockExists, err := exec.checkIfLockFilesExist()
+	log.Entry().WithField("WorkingDirectory", dir).Infof("Running Install with %s", pm.Name)
+	if len(pm.LockFile) == 0 {
+		log.Entry().Warn("No package lock file found. " +
+			"It is recommended to create a `package-lock.json` file by running `npm Install` locally." +
+			" Add this file to your version control. " +
+			"By doing so, the builds of your application become more reliable.")
+	}
+	executable, installArgs := pm.InstallCommand()
+	err = execRunner.RunExecutable(executable, installArgs...)
 	if err != nil {
 		return err
 	}
 
-	log.Entry().WithField("WorkingDirectory", dir).Info("Running Install")
-	if packageLockExists {
-		err = execRunner.RunExecutable("npm", "ci")
+	err = exec.Utils.Chdir(oldWorkingDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to change back into original directory: %w", err)
+	}
+	return nil
+}
+
+// setYarnRegistry configures the default registry for yarn berry, which does not respect the npm configuration.
+// An npmRegistryServer configured in the .yarnrc.yml of the project takes precedence.
+func (exec *Execute) setYarnRegistry(pm PackageManager) {
+	if pm.Name != YarnBerry || exec.Options.DefaultNpmRegistry == "" {
+		return
+	}
+	if yarnrc, err := exec.Utils.FileRead(filepath.Join(pm.RootDir, yarnrcFilename)); err == nil && strings.Contains(string(yarnrc), "npmRegistryServer") {
+		log.Entry().Info("Discovered pre-configured yarn registry in " + yarnrcFilename)
+		return
+	}
+	log.Entry().Info("yarn registry was not configured, setting it to " + exec.Options.DefaultNpmRegistry)
+	exec.Utils.GetExecRunner().AppendEnv([]string{"YARN_NPM_REGISTRY_SERVER=" + exec.Options.DefaultNpmRegistry})
+}
+
+// CreateBOM generates BOM file using CycloneDX from all package.json files
+func (exec *Execute) CreateBOM(packageJSONFiles []string) error {
+	npmPackageJSONFiles := []string{}
+	for _, packageJSONFile := range packageJSONFiles {
+		pm, err := exec.DetectPackageManager(packageJSONFile)
 		if err != nil {
 			return err
 		}
-	} else if yarnLockExists {
-		err = execRunner.RunExecutable("yarn", "install", "--frozen-lockfile")
-		if err != nil {
-			return err
+		switch pm.Name {
+		case YarnBerry:
+			err = exec.createBOMInDir(filepath.Dir(packageJSONFile), "yarn", "dlx", "-q", cycloneDxYarnPackageVersion, "--output-format", "XML", "--spec-version", cycloneDxSchemaVersion, "--production", "--output-file", npmBomFilename)
+		case PNPM:
+			err = exec.createBOMInDir(filepath.Dir(packageJSONFile), "pnpm", "dlx", cycloneDxPnpmPackageVersion, "--type", "pnpm", "--required-only", "--no-recurse", "--spec-version", cycloneDxSchemaVersion, "--output", pnpmBomFilename, ".")
+			if err == nil {
+				err = exec.convertBOMToXML(filepath.Join(filepath.Dir(packageJSONFile), pnpmBomFilename), filepath.Join(filepath.Dir(packageJSONFile), npmBomFilename))
+			}
+		default:
+			npmPackageJSONFiles = append(npmPackageJSONFiles, packageJSONFile)
 		}
-	} else {
-		log.Entry().Warn("No package lock file found. " +
-			"It is recommended to create a `package-lock.json` file by running `npm Install` locally." +
-			" Add this file to your version control. " +
-			"By doing so, the builds of your application become more reliable.")
-		err = execRunner.RunExecutable("npm", "install")
 		if err != nil {
 			return err
 		}
 	}
+	if len(npmPackageJSONFiles) == 0 {
+		return nil
+	}
+	return exec.createNpmBOM(npmPackageJSONFiles)
+}
 
-	err = exec.Utils.Chdir(oldWorkingDirectory)
+// createBOMInDir generates the BOM of a yarn berry or pnpm project in its directory since the tools operate on the working directory
+func (exec *Execute) createBOMInDir(dir string, executable string, params ...string) error {
+	oldWorkingDirectory, err := exec.Utils.Getwd()
 	if err != nil {
+		return fmt.Errorf("failed to get current working directory before generating BOM: %w", err)
+	}
+	if err := exec.Utils.Chdir(dir); err != nil {
+		return fmt.Errorf("failed to change into directory for generating BOM: %w", err)
+	}
+	if err := exec.Utils.GetExecRunner().RunExecutable(executable, params...); err != nil {
+		return fmt.Errorf("failed to generate CycloneDX BOM in %s: %w", dir, err)
+	}
+	if err := exec.Utils.Chdir(oldWorkingDirectory); err != nil {
 		return fmt.Errorf("failed to change back into original directory: %w", err)
 	}
 	return nil
 }
 
-// checkIfLockFilesExist checks if yarn/package lock fileUtils exist
-func (exec *Execute) checkIfLockFilesExist() (bool, bool, error) {
-	packageLockExists, err := exec.Utils.FileExists("package-lock.json")
+// convertBOMToXML converts a CycloneDX BOM in JSON format into the XML format of all other npm BOMs and removes the JSON file
+func (exec *Execute) convertBOMToXML(jsonFile, xmlFile string) error {
+	content, err := exec.Utils.FileRead(jsonFile)
 	if err != nil {
-		return false, false, err
+		return fmt.Errorf("failed to read BOM %s: %w", jsonFile, err)
 	}
-
-	yarnLockExists, err := exec.Utils.FileExists("yarn.lock")
-	if err != nil {
-		return false, false, err
+	bom := cdx.NewBOM()
+	if err := cdx.NewBOMDecoder(bytes.NewReader(content), cdx.BOMFileFormatJSON).Decode(bom); err != nil {
+		return fmt.Errorf("failed to parse BOM %s: %w", jsonFile, err)
+	}
+	var xmlContent bytes.Buffer
+	encoder := cdx.NewBOMEncoder(&xmlContent, cdx.BOMFileFormatXML)
+	encoder.SetPretty(true)
+	if err := encoder.Encode(bom); err != nil {
+		return fmt.Errorf("failed to convert BOM %s to XML: %w", jsonFile, err)
 	}
-	return packageLockExists, yarnLockExists, nil
+	if err := exec.Utils.FileWrite(xmlFile, xmlContent.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write BOM %s: %w", xmlFile, err)
+	}
+	return exec.Utils.FileRemove(jsonFile)
 }
 
-// CreateBOM generates BOM file using CycloneDX from all package.json files
-func (exec *Execute) CreateBOM(packageJSONFiles []string) error {
+// createNpmBOM generates BOM files of npm and yarn classic projects
+func (exec *Execute) createNpmBOM(packageJSONFiles []string) error {
 	// Install cyclonedx-npm in a new folder (to avoid extraneous errors) and generate BOM
 	cycloneDxNpmInstallParams := []string{"install", "--no-save", cycloneDxNpmPackageVersion, "--prefix", cycloneDxNpmInstallationFolder}
 	cycloneDxNpmRunParams := []string{"--output-format", "XML", "--spec-version", cycloneDxSchemaVersion, "--omit", "dev", "--output-file"}
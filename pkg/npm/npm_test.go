@@ -194,7 +194,7 @@ func TestNpm(t *testing.T) {
 		}
 	})
 
-	t.Run("check if yarn.lock and package-lock exist", func(t *testing.T) {
+	t.Run("check that yarn.lock takes precedence over package-lock", func(t *testing.T) {
 		utils := newNpmMockUtilsBundle()
 		utils.AddFile("package.json", []byte("{\"scripts\": { \"ci-lint\": \"exit 0\" } }"))
 		utils.AddFile("yarn.lock", []byte("{}"))
@@ -206,11 +206,10 @@ func TestNpm(t *testing.T) {
 			Utils:   &utils,
 			Options: options,
 		}
-		packageLock, yarnLock, err := exec.checkIfLockFilesExist()
+		pm, err := exec.DetectPackageManager("package.json")
 
 		if assert.NoError(t, err) {
-			assert.True(t, packageLock)
-			assert.True(t, yarnLock)
+			assert.Equal(t, PackageManager{Name: YarnClassic, LockFile: "yarn.lock", RootDir: "."}, pm)
 		}
 	})
 
@@ -224,11 +223,10 @@ func TestNpm(t *testing.T) {
 			Utils:   &utils,
 			Options: options,
 		}
-		packageLock, yarnLock, err := exec.checkIfLockFilesExist()
+		pm, err := exec.DetectPackageManager("package.json")
 
 		if assert.NoError(t, err) {
-			assert.False(t, packageLock)
-			assert.False(t, yarnLock)
+			assert.Equal(t, PackageManager{Name: NPM, RootDir: "."}, pm)
 		}
 	})
 
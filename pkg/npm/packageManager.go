@@ -0,0 +1,224 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+const (
+	// NPM identifies the npm package manager
+	NPM = "npm"
+	// YarnClassic identifies yarn in version 1
+	YarnClassic = "yarn"
+	// YarnBerry identifies yarn in version 2 and above, optionally using Plug'n'Play
+	YarnBerry = "yarn-berry"
+	// PNPM identifies the pnpm package manager
+	PNPM = "pnpm"
+)
+
+const (
+	packageLockFilename   = "package-lock.json"
+	npmShrinkwrapFilename = "npm-shrinkwrap.json"
+	yarnLockFilename      = "yarn.lock"
+	yarnrcFilename        = ".yarnrc.yml"
+	pnpmLockFilename      = "pnpm-lock.yaml"
+	pnpmWorkspaceFilename = "pnpm-workspace.yaml"
+)
+
+// PackageManager describes the package manager used for a package.json
+type PackageManager struct {
+	// Name is one of NPM, YarnClassic, YarnBerry or PNPM
+	Name string
+	// LockFile is the lock file the package manager has been detected from, empty if there is none
+	LockFile string
+	// RootDir is the directory dependencies are installed from, i.e. the directory of the package.json
+	// or the root of the workspace the package belongs to
+	RootDir string
+	// Workspace is true if the package is a member of a workspace defined in RootDir
+	Workspace bool
+}
+
+// InstallCommand returns the executable and parameters for installing the dependencies reproducibly from the lock file
+func (pm PackageManager) InstallCommand() (string, []string) {
+	switch pm.Name {
+	case PNPM:
+		return "pnpm", []string{"install", "--frozen-lockfile"}
+	case YarnBerry:
+		return "yarn", []string{"install", "--immutable"}
+	case YarnClassic:
+		return "yarn", []string{"install", "--frozen-lockfile"}
+	}
+	if len(pm.LockFile) > 0 {
+		return "npm", []string{"ci"}
+	}
+	return "npm", []string{"install"}
+}
+
+// RunCommand returns the executable and parameters for running a script defined in package.json.
+// Scripts of yarn classic projects are run with npm since yarn 1 is compatible with the node_modules layout of npm.
+func (pm PackageManager) RunCommand(script string, runOptions []string, scriptOptions []string) (string, []string) {
+	args := append([]string{"run", script}, runOptions...)
+	switch pm.Name {
+	case PNPM, YarnBerry:
+		// pnpm and yarn berry pass all arguments following the script name on to the script
+		return pm.executable(), append(args, scriptOptions...)
+	}
+	if len(scriptOptions) > 0 {
+		args = append(append(args, "--"), scriptOptions...)
+	}
+	return "npm", args
+}
+
+// PackCommand returns the executable and parameters for creating the tarball of a package.
+// In contrast to npm, pnpm and yarn berry replace workspace: dependency ranges with the actual versions.
+func (pm PackageManager) PackCommand() (string, []string) {
+	switch pm.Name {
+	case PNPM:
+		return "pnpm", []string{"pack"}
+	case YarnBerry:
+		return "yarn", []string{"pack", "--out", "package.tgz"}
+	}
+	return "npm", []string{"pack"}
+}
+
+// RequiresPack returns true if packages need to be packed with the package manager before publishing
+func (pm PackageManager) RequiresPack() bool {
+	return pm.Name == PNPM || pm.Name == YarnBerry
+}
+
+func (pm PackageManager) executable() string {
+	if pm.Name == YarnBerry {
+		return "yarn"
+	}
+	return pm.Name
+}
+
+// DetectPackageManager determines the package manager of a package.json from the lock files and the packageManager field
+// in the directory of the package.json. If there is neither, the enclosing directories are searched for the root of a
+// workspace. npm without lock file is used as fallback.
+func (exec *Execute) DetectPackageManager(packageJSON string) (PackageManager, error) {
+	dir := filepath.Dir(packageJSON)
+	for current := dir; ; current = filepath.Dir(current) {
+		pm, found, err := exec.packageManagerInDir(current, current != dir)
+		if err != nil {
+			return PackageManager{}, err
+		}
+		if found {
+			pm.Workspace = current != dir
+			log.Entry().Debugf("Detected package manager %v for %v (lock file: '%v', root directory: '%v')", pm.Name, packageJSON, pm.LockFile, pm.RootDir)
+			return pm, nil
+		}
+		if parent := filepath.Dir(current); parent == current || current == "." {
+			break
+		}
+	}
+	return PackageManager{Name: NPM, RootDir: dir}, nil
+}
+
+// packageManagerInDir detects the package manager of the directory, enclosing directories are only considered if they define a workspace
+func (exec *Execute) packageManagerInDir(dir string, workspaceOnly bool) (PackageManager, bool, error) {
+	descriptor, err := exec.readPackageManagerDescriptor(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return PackageManager{}, false, err
+	}
+	if workspaceOnly && !descriptor.hasWorkspaces() {
+		pnpmWorkspace, err := exec.Utils.FileExists(filepath.Join(dir, pnpmWorkspaceFilename))
+		if err != nil || !pnpmWorkspace {
+			return PackageManager{}, false, err
+		}
+	}
+
+	pm := PackageManager{RootDir: dir}
+	for _, lockFile := range []string{pnpmLockFilename, yarnLockFilename, packageLockFilename, npmShrinkwrapFilename} {
+		exists, err := exec.Utils.FileExists(filepath.Join(dir, lockFile))
+		if err != nil {
+			return PackageManager{}, false, fmt.Errorf("failed to check for lock file %v: %w", lockFile, err)
+		}
+		if exists {
+			pm.LockFile = lockFile
+			break
+		}
+	}
+
+	switch {
+	case len(descriptor.PackageManager) > 0:
+		pm.Name = packageManagerFromField(descriptor.PackageManager)
+	case pm.LockFile == pnpmLockFilename:
+		pm.Name = PNPM
+	case pm.LockFile == yarnLockFilename:
+		if pm.Name, err = exec.yarnFlavor(dir); err != nil {
+			return PackageManager{}, false, err
+		}
+	case len(pm.LockFile) > 0:
+		pm.Name = NPM
+	default:
+		return PackageManager{}, false, nil
+	}
+	return pm, true, nil
+}
+
+// yarnFlavor distinguishes yarn classic from yarn berry by the yarn configuration file and the lock file format
+func (exec *Execute) yarnFlavor(dir string) (string, error) {
+	yarnrcExists, err := exec.Utils.FileExists(filepath.Join(dir, yarnrcFilename))
+	if err != nil {
+		return "", err
+	}
+	if yarnrcExists {
+		return YarnBerry, nil
+	}
+	content, err := exec.Utils.FileRead(filepath.Join(dir, yarnLockFilename))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %v: %w", yarnLockFilename, err)
+	}
+	if strings.Contains(string(content), "__metadata:") {
+		return YarnBerry, nil
+	}
+	return YarnClassic, nil
+}
+
+// packageManagerFromField maps the packageManager field of package.json (e.g. "pnpm@8.15.4") to the package manager
+func packageManagerFromField(field string) string {
+	name, version, _ := strings.Cut(field, "@")
+	switch name {
+	case "pnpm":
+		return PNPM
+	case "yarn":
+		major, _ := strconv.Atoi(strings.Split(version, ".")[0])
+		if major >= 2 {
+			return YarnBerry
+		}
+		return YarnClassic
+	}
+	return NPM
+}
+
+type packageManagerDescriptor struct {
+	PackageManager string          `json:"packageManager"`
+	Workspaces     json.RawMessage `json:"workspaces"`
+}
+
+func (d packageManagerDescriptor) hasWorkspaces() bool {
+	return len(d.Workspaces) > 0 && string(d.Workspaces) != "null"
+}
+
+func (exec *Execute) readPackageManagerDescriptor(packageJSON string) (packageManagerDescriptor, error) {
+	descriptor := packageManagerDescriptor{}
+	exists, err := exec.Utils.FileExists(packageJSON)
+	if err != nil || !exists {
+		return descriptor, err
+	}
+	content, err := exec.Utils.FileRead(packageJSON)
+	if err != nil {
+		return descriptor, fmt.Errorf("failed to read %v: %w", packageJSON, err)
+	}
+	if err := json.Unmarshal(content, &descriptor); err != nil {
+		// the package.json is validated by the package manager itself
+		log.Entry().WithError(err).Debugf("failed to parse %v", packageJSON)
+	}
+	return descriptor, nil
+}
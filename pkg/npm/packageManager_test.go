@@ -0,0 +1,257 @@
+//go:build unit
+// +build unit
+
+package npm
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/versioning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPackageManager(t *testing.T) {
+	tt := []struct {
+		name        string
+		files       map[string]string
+		packageJSON string
+		expected    PackageManager
+	}{
+		{
+			name:        "pnpm",
+			files:       map[string]string{"package.json": `{}`, "pnpm-lock.yaml": "lockfileVersion: '6.0'"},
+			packageJSON: "package.json",
+			expected:    PackageManager{Name: PNPM, LockFile: "pnpm-lock.yaml", RootDir: "."},
+		},
+		{
+			name:        "yarn berry with .yarnrc.yml",
+			files:       map[string]string{"package.json": `{}`, "yarn.lock": "", ".yarnrc.yml": "nodeLinker: pnp"},
+			packageJSON: "package.json",
+			expected:    PackageManager{Name: YarnBerry, LockFile: "yarn.lock", RootDir: "."},
+		},
+		{
+			name:        "yarn berry from lock file format",
+			files:       map[string]string{"package.json": `{}`, "yarn.lock": "__metadata:\n  version: 8\n"},
+			packageJSON: "package.json",
+			expected:    PackageManager{Name: YarnBerry, LockFile: "yarn.lock", RootDir: "."},
+		},
+		{
+			name:        "packageManager field takes precedence",
+			files:       map[string]string{"package.json": `{"packageManager": "yarn@4.1.0"}`, "package-lock.json": "{}"},
+			packageJSON: "package.json",
+			expected:    PackageManager{Name: YarnBerry, LockFile: "package-lock.json", RootDir: "."},
+		},
+		{
+			name: "pnpm workspace member",
+			files: map[string]string{
+				"package.json":            `{"private": true}`,
+				"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+				"pnpm-lock.yaml":          "lockfileVersion: '6.0'",
+				"packages/a/package.json": `{"name": "a"}`,
+			},
+			packageJSON: filepath.Join("packages", "a", "package.json"),
+			expected:    PackageManager{Name: PNPM, LockFile: "pnpm-lock.yaml", RootDir: ".", Workspace: true},
+		},
+		{
+			name: "yarn workspace member",
+			files: map[string]string{
+				"package.json":            `{"workspaces": ["packages/*"], "packageManager": "yarn@3.6.0"}`,
+				"yarn.lock":               "",
+				"packages/a/package.json": `{"name": "a"}`,
+			},
+			packageJSON: filepath.Join("packages", "a", "package.json"),
+			expected:    PackageManager{Name: YarnBerry, LockFile: "yarn.lock", RootDir: ".", Workspace: true},
+		},
+		{
+			name: "nested project without workspace",
+			files: map[string]string{
+				"package.json":      `{}`,
+				"package-lock.json": "{}",
+				"sub/package.json":  `{}`,
+			},
+			packageJSON: filepath.Join("sub", "package.json"),
+			expected:    PackageManager{Name: NPM, RootDir: "sub"},
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			utils := newNpmMockUtilsBundle()
+			for path, content := range test.files {
+				utils.AddFile(path, []byte(content))
+			}
+			exec := &Execute{Utils: &utils}
+
+			pm, err := exec.DetectPackageManager(test.packageJSON)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, pm)
+			}
+		})
+	}
+}
+
+func TestPackageManagerCommands(t *testing.T) {
+	executable, args := PackageManager{Name: PNPM}.RunCommand("test", []string{"--silent"}, []string{"--watch=false"})
+	assert.Equal(t, "pnpm", executable)
+	assert.Equal(t, []string{"run", "test", "--silent", "--watch=false"}, args)
+
+	executable, args = PackageManager{Name: YarnClassic}.RunCommand("test", nil, []string{"--watch=false"})
+	assert.Equal(t, "npm", executable)
+	assert.Equal(t, []string{"run", "test", "--", "--watch=false"}, args)
+
+	executable, args = PackageManager{Name: YarnBerry}.InstallCommand()
+	assert.Equal(t, "yarn", executable)
+	assert.Equal(t, []string{"install", "--immutable"}, args)
+
+	executable, args = PackageManager{Name: NPM, LockFile: "npm-shrinkwrap.json"}.InstallCommand()
+	assert.Equal(t, "npm", executable)
+	assert.Equal(t, []string{"ci"}, args)
+}
+
+func TestPnpmAndYarnBerry(t *testing.T) {
+	pnpmWorkspace := map[string]string{
+		"package.json":            `{"private": true}`,
+		"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+		"pnpm-lock.yaml":          "lockfileVersion: '6.0'",
+		"packages/a/package.json": `{"name": "@piper/a", "version": "1.0.0", "scripts": {"build": "tsc"}}`,
+		"packages/b/package.json": `{"name": "@piper/b", "version": "1.0.0", "scripts": {"build": "tsc"}}`,
+	}
+	yarnBerryProject := map[string]string{
+		"package.json": `{"name": "app", "packageManager": "yarn@4.1.0", "scripts": {"build": "tsc"}}`,
+		"yarn.lock":    "__metadata:\n  version: 8\n",
+		".yarnrc.yml":  "nodeLinker: pnp\n",
+	}
+
+	newExecute := func(files map[string]string) (*Execute, npmMockUtilsBundle) {
+		utils := newNpmMockUtilsBundle()
+		for path, content := range files {
+			utils.AddFile(path, []byte(content))
+		}
+		return &Execute{Utils: &utils, Options: ExecutorOptions{DefaultNpmRegistry: "https://my.registry/"}}, utils
+	}
+
+	t.Run("install pnpm workspace once", func(t *testing.T) {
+		exec, utils := newExecute(pnpmWorkspace)
+
+		err := exec.InstallAllDependencies([]string{"package.json", filepath.Join("packages", "a", "package.json"), filepath.Join("packages", "b", "package.json")})
+
+		if assert.NoError(t, err) {
+			if assert.Len(t, utils.execRunner.Calls, 2) {
+				assert.Equal(t, mock.ExecCall{Exec: "pnpm", Params: []string{"install", "--frozen-lockfile"}}, utils.execRunner.Calls[1])
+			}
+		}
+	})
+
+	t.Run("run script in pnpm workspace member", func(t *testing.T) {
+		exec, utils := newExecute(pnpmWorkspace)
+
+		err := exec.RunScriptsInAllPackages([]string{"build"}, nil, []string{"--verbose"}, false, nil, []string{filepath.Join("packages", "a", "package.json")})
+
+		if assert.NoError(t, err) {
+			if assert.Len(t, utils.execRunner.Calls, 2) {
+				assert.Equal(t, mock.ExecCall{Exec: "pnpm", Params: []string{"run", "build", "--verbose"}}, utils.execRunner.Calls[1])
+			}
+		}
+	})
+
+	t.Run("install and run script with yarn berry", func(t *testing.T) {
+		exec, utils := newExecute(yarnBerryProject)
+
+		err := exec.InstallAllDependencies([]string{"package.json"})
+		if assert.NoError(t, err) {
+			err = exec.executeScript("package.json", "build", nil, nil)
+		}
+
+		if assert.NoError(t, err) {
+			if assert.Len(t, utils.execRunner.Calls, 4) {
+				assert.Equal(t, mock.ExecCall{Exec: "yarn", Params: []string{"install", "--immutable"}}, utils.execRunner.Calls[1])
+				assert.Equal(t, mock.ExecCall{Exec: "yarn", Params: []string{"run", "build"}}, utils.execRunner.Calls[3])
+			}
+			assert.Contains(t, utils.execRunner.Env, "YARN_NPM_REGISTRY_SERVER=https://my.registry/")
+		}
+	})
+
+	t.Run("yarn registry keeps the environment of the runner", func(t *testing.T) {
+		exec, utils := newExecute(yarnBerryProject)
+		utils.execRunner.SetEnv([]string{"DISPLAY=:99"})
+
+		err := exec.executeScript("package.json", "build", nil, nil)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"DISPLAY=:99", "YARN_NPM_REGISTRY_SERVER=https://my.registry/"}, utils.execRunner.Env)
+		}
+	})
+
+	t.Run("yarn registry from .yarnrc.yml", func(t *testing.T) {
+		exec, utils := newExecute(yarnBerryProject)
+		utils.AddFile(".yarnrc.yml", []byte("npmRegistryServer: https://other.registry/\n"))
+
+		err := exec.install("package.json")
+
+		if assert.NoError(t, err) {
+			assert.Empty(t, utils.execRunner.Env)
+		}
+	})
+
+	t.Run("create BOM", func(t *testing.T) {
+		files := map[string]string{}
+		for path, content := range pnpmWorkspace {
+			files[path] = content
+		}
+		for path, content := range yarnBerryProject {
+			files[filepath.Join("berry", path)] = content
+		}
+		exec, utils := newExecute(files)
+		// written by cdxgen
+		utils.AddFile(filepath.Join("packages", "a", "bom-npm.json"), []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.4", "version": 1, "components": [{"type": "library", "name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21"}]}`))
+
+		err := exec.CreateBOM([]string{filepath.Join("packages", "a", "package.json"), filepath.Join("berry", "package.json")})
+
+		if assert.NoError(t, err) {
+			if assert.Len(t, utils.execRunner.Calls, 2) {
+				assert.Equal(t, mock.ExecCall{Exec: "pnpm", Params: []string{"dlx", cycloneDxPnpmPackageVersion, "--type", "pnpm", "--required-only", "--no-recurse", "--spec-version", "1.4", "--output", "bom-npm.json", "."}}, utils.execRunner.Calls[0])
+				assert.Equal(t, mock.ExecCall{Exec: "yarn", Params: []string{"dlx", "-q", cycloneDxYarnPackageVersion, "--output-format", "XML", "--spec-version", "1.4", "--production", "--output-file", "bom-npm.xml"}}, utils.execRunner.Calls[1])
+			}
+			bom, err := utils.FileRead(filepath.Join("packages", "a", "bom-npm.xml"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(bom), "<purl>pkg:npm/lodash@4.17.21</purl>")
+			assert.False(t, utils.HasFile(filepath.Join("packages", "a", "bom-npm.json")))
+		}
+	})
+
+	t.Run("publish pnpm workspace member", func(t *testing.T) {
+		utils := newNpmMockUtilsBundleRelativeGlob()
+		for path, content := range pnpmWorkspace {
+			utils.AddFile(path, []byte(content))
+		}
+		utils.Separator = string(filepath.Separator)
+		exec := &Execute{Utils: &utils}
+
+		propertiesLoadFile = utils.FileRead
+		propertiesWriteFile = utils.FileWrite
+		writeIgnoreFile = utils.FileWrite
+		utils.execRunner.Stub = func(call string, stdoutReturn map[string]string, shouldFailOnCommand map[string]error, stdout io.Writer) error {
+			utils.AddFile(filepath.Join(".", "module-1.0.0.tgz"), []byte("this is a tgz file"))
+			return nil
+		}
+
+		coordinates := []versioning.Coordinates{}
+		err := exec.PublishAllPackages([]string{filepath.Join("packages", "a", "package.json")}, "https://my.private.npm.registry/", "user", "password", false, &coordinates)
+
+		if assert.NoError(t, err) {
+			if assert.Len(t, utils.execRunner.Calls, 2) {
+				assert.Equal(t, mock.ExecCall{Exec: "pnpm", Params: []string{"pack"}}, utils.execRunner.Calls[0])
+				assert.Equal(t, []string{"publish", "--tarball", "/packages/a/module-1.0.0.tgz", "--userconfig", ".piperNpmrc", "--registry", "https://my.private.npm.registry/"}, utils.execRunner.Calls[1].Params)
+			}
+			npmrc, err := utils.FileRead(filepath.Join("packages", "a", ".piperNpmrc"))
+			if assert.NoError(t, err) {
+				assert.Contains(t, string(npmrc), "@piper:registry=https://my.private.npm.registry/")
+				assert.Contains(t, string(npmrc), "//my.private.npm.registry/:_auth=dXNlcjpwYXNzd29yZA==")
+			}
+		}
+	})
+}
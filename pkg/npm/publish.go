@@ -133,13 +133,25 @@ func (exec *Execute) publish(packageJSON, registry, username, password string, p
 		log.Entry().Debug("no registry provided")
 	}
 
+	pm, err := exec.DetectPackageManager(packageJSON)
+	if err != nil {
+		return err
+	}
+	if pm.RequiresPack() && !packBeforePublish {
+		// the tarball created by pnpm and yarn berry contains the actual versions of workspace dependencies,
+		// it is published with npm to use the registry credentials from .piperNpmrc
+		log.Entry().Infof("packing %s with %s before publishing", packageJSON, pm.Name)
+		packBeforePublish = true
+	}
+
 	if packBeforePublish {
 		// change directory in package json file , since npm pack will run only for that packages
 		if err := exec.Utils.Chdir(filepath.Dir(packageJSON)); err != nil {
 			return fmt.Errorf("failed to change into directory for executing script: %w", err)
 		}
 
-		if err := execRunner.RunExecutable("npm", "pack"); err != nil {
+		packExecutable, packArgs := pm.PackCommand()
+		if err := execRunner.RunExecutable(packExecutable, packArgs...); err != nil {
 			return err
 		}
 
@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"io"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -252,6 +253,35 @@ func (a *azureDevopsConfigProvider) PullRequestConfig() PullRequestConfig {
 	}
 }
 
+// LastSuccessfulCommit returns the source version of the last successful build of the pipeline on the current branch
+func (a *azureDevopsConfigProvider) LastSuccessfulCommit() string {
+	URL := a.getSystemCollectionURI() + a.getTeamProjectID() + "/_apis/build/builds?definitions=" + getEnv("SYSTEM_DEFINITIONID", "n/a") +
+		"&branchName=" + url.QueryEscape(getEnv("BUILD_SOURCEBRANCH", "n/a")) + "&resultFilter=succeeded&statusFilter=completed&queryOrder=finishTimeDescending&$top=1&api-version=7.0"
+	response, err := a.client.GetRequest(URL, nil, nil)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to get the last successful build from AzureDevOps")
+		return ""
+	}
+	if response.StatusCode != 200 {
+		log.Entry().Warnf("response code is %v, could not get the last successful build from AzureDevOps", response.StatusCode)
+		return ""
+	}
+	builds := struct {
+		Value []struct {
+			SourceVersion string `json:"sourceVersion"`
+		} `json:"value"`
+	}{}
+	if err := piperHttp.ParseHTTPResponseBodyJSON(response, &builds); err != nil {
+		log.Entry().WithError(err).Warn("failed to parse the last successful build from AzureDevOps")
+		return ""
+	}
+	if len(builds.Value) == 0 {
+		log.Entry().Debug("no successful build found in AzureDevOps")
+		return ""
+	}
+	return builds.Value[0].SourceVersion
+}
+
 // IsPullRequest indicates whether the current build is a PR
 func (a *azureDevopsConfigProvider) IsPullRequest() bool {
 	return getEnv("BUILD_REASON", "n/a") == "PullRequest"
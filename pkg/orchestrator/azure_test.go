@@ -276,6 +276,50 @@ func TestAzureDevOpsConfigProvider_getAPIInformation(t *testing.T) {
 	}
 }
 
+func TestAzureDevOpsConfigProvider_LastSuccessfulCommit(t *testing.T) {
+	a := &azureDevopsConfigProvider{}
+	a.client.SetOptions(piperhttp.ClientOptions{
+		UseDefaultTransport: true, // need to use default transport for http mock
+		MaxRetries:          -1,
+	})
+
+	defer resetEnv(os.Environ())
+	os.Clearenv()
+	os.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/fabrikamfiber/")
+	os.Setenv("SYSTEM_TEAMPROJECTID", "123a4567-ab1c-12a1-1234-123456ab7890")
+	os.Setenv("SYSTEM_DEFINITIONID", "42")
+	os.Setenv("BUILD_SOURCEBRANCH", "refs/heads/main")
+
+	fakeUrl := "https://dev.azure.com/fabrikamfiber/123a4567-ab1c-12a1-1234-123456ab7890/_apis/build/builds"
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("successful build", func(t *testing.T) {
+		httpmock.RegisterResponder("GET", fakeUrl,
+			func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "42", req.URL.Query().Get("definitions"))
+				assert.Equal(t, "refs/heads/main", req.URL.Query().Get("branchName"))
+				assert.Equal(t, "succeeded", req.URL.Query().Get("resultFilter"))
+				return httpmock.NewStringResponse(200, `{"count": 1, "value": [{"sourceVersion": "abcdef42712"}]}`), nil
+			},
+		)
+
+		assert.Equal(t, "abcdef42712", a.LastSuccessfulCommit())
+	})
+
+	t.Run("no successful build", func(t *testing.T) {
+		httpmock.RegisterResponder("GET", fakeUrl, httpmock.NewStringResponder(200, `{"count": 0, "value": []}`))
+
+		assert.Equal(t, "", a.LastSuccessfulCommit())
+	})
+
+	t.Run("request fails", func(t *testing.T) {
+		httpmock.RegisterResponder("GET", fakeUrl, httpmock.NewStringResponder(401, ""))
+
+		assert.Equal(t, "", a.LastSuccessfulCommit())
+	})
+}
+
 func TestAzureDevOpsConfigProvider_GetLog(t *testing.T) {
 	tests := []struct {
 		name                    string
@@ -235,6 +235,32 @@ func (g *githubActionsConfigProvider) PullRequestConfig() PullRequestConfig {
 	}
 }
 
+// LastSuccessfulCommit returns the head commit of the last successful run of the workflow on the current branch
+func (g *githubActionsConfigProvider) LastSuccessfulCommit() string {
+	if g.client == nil {
+		log.Entry().Debug("ConfigProvider for GitHub Actions is not configured. Unable to fetch the last successful run")
+		return ""
+	}
+	fileName := workflowFileName()
+	if fileName == "" {
+		return ""
+	}
+	runs, _, err := g.client.Actions.ListWorkflowRunsByFileName(g.ctx, g.owner, g.repo, fileName, &github.ListWorkflowRunsOptions{
+		Branch:      g.Branch(),
+		Status:      "success",
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to get the last successful run from GitHub")
+		return ""
+	}
+	if len(runs.WorkflowRuns) == 0 {
+		log.Entry().Debug("no successful run found in GitHub")
+		return ""
+	}
+	return runs.WorkflowRuns[0].GetHeadSHA()
+}
+
 // IsPullRequest indicates whether the current build is triggered by a PR
 func (g *githubActionsConfigProvider) IsPullRequest() bool {
 	return envVarIsTrue("GITHUB_HEAD_REF")
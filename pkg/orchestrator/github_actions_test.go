@@ -144,6 +144,38 @@ func TestGitHubActionsConfigProvider_fetchRunData(t *testing.T) {
 	assert.Equal(t, wantRunData, g.runData)
 }
 
+func TestGitHubActionsConfigProvider_LastSuccessfulCommit(t *testing.T) {
+	// setup env vars
+	defer resetEnv(os.Environ())
+	os.Clearenv()
+	_ = os.Setenv("GITHUB_API_URL", "https://api.github.com")
+	_ = os.Setenv("GITHUB_REPOSITORY", "SAP/jenkins-library")
+	_ = os.Setenv("GITHUB_REF_NAME", "main")
+	_ = os.Setenv("GITHUB_WORKFLOW_REF", "SAP/jenkins-library/.github/workflows/piper.yml@refs/heads/main")
+
+	// setup provider
+	g := newGithubActionsConfigProvider()
+	assert.NoError(t, g.Configure(&Options{}))
+	g.client = github.NewClient(http.DefaultClient)
+
+	// setup http mock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(http.MethodGet, "https://api.github.com/repos/SAP/jenkins-library/actions/workflows/piper.yml/runs",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "main", req.URL.Query().Get("branch"))
+			assert.Equal(t, "success", req.URL.Query().Get("status"))
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"total_count":   1,
+				"workflow_runs": []map[string]interface{}{{"id": 11110, "head_sha": "abcdef42712"}},
+			})
+		},
+	)
+
+	// run
+	assert.Equal(t, "abcdef42712", g.LastSuccessfulCommit())
+}
+
 func TestGitHubActionsConfigProvider_fetchJobs(t *testing.T) {
 	// data
 	respJson := map[string]interface{}{"jobs": []map[string]interface{}{{
@@ -278,6 +278,11 @@ func (j *jenkinsConfigProvider) PullRequestConfig() PullRequestConfig {
 	}
 }
 
+// LastSuccessfulCommit returns the commit of the last successful build of the job as provided by the Git plugin
+func (j *jenkinsConfigProvider) LastSuccessfulCommit() string {
+	return getEnv("GIT_PREVIOUS_SUCCESSFUL_COMMIT", "")
+}
+
 // IsPullRequest returns boolean indicating if current job is a PR
 func (j *jenkinsConfigProvider) IsPullRequest() bool {
 	return envVarIsTrue("CHANGE_ID")
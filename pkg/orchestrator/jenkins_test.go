@@ -28,10 +28,12 @@ func TestJenkins(t *testing.T) {
 		os.Setenv("BRANCH_NAME", "main")
 		os.Setenv("GIT_COMMIT", "abcdef42713")
 		os.Setenv("GIT_URL", "github.com/foo/bar")
+		os.Setenv("GIT_PREVIOUS_SUCCESSFUL_COMMIT", "abcdef42712")
 
 		p := &jenkinsConfigProvider{}
 
 		assert.False(t, p.IsPullRequest())
+		assert.Equal(t, "abcdef42712", p.LastSuccessfulCommit())
 		assert.Equal(t, "https://jaas.url/job/foo/job/bar/job/main/1234/", p.BuildURL())
 		assert.Equal(t, "main", p.Branch())
 		assert.Equal(t, "refs/heads/main", p.GitReference())
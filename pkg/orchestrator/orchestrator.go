@@ -52,6 +52,7 @@ type ConfigProvider interface {
 	CommitSHA() string
 	PullRequestConfig() PullRequestConfig
 	IsPullRequest() bool
+	LastSuccessfulCommit() string
 	FullLogs() ([]byte, error)
 	PipelineStartTime() time.Time
 	ChangeSets() []ChangeSet
@@ -99,6 +99,11 @@ func (u *UnknownOrchestratorConfigProvider) RepoURL() string {
 	return "n/a"
 }
 
+func (u *UnknownOrchestratorConfigProvider) LastSuccessfulCommit() string {
+	log.Entry().Warning(unknownOrchestratorWarning)
+	return ""
+}
+
 func (u *UnknownOrchestratorConfigProvider) PullRequestConfig() PullRequestConfig {
 	log.Entry().Warning(unknownOrchestratorWarning)
 	return PullRequestConfig{
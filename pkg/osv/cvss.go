@@ -0,0 +1,95 @@
+package osv
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvss3BaseScore calculates the base score of a CVSS v3.x vector like
+// CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H according to the specification
+// https://www.first.org/cvss/v3.1/specification-document#7-1-Base-Metrics-Equations
+func cvss3BaseScore(vector string) (float64, error) {
+	if !strings.HasPrefix(vector, "CVSS:3") {
+		return 0, fmt.Errorf("unsupported CVSS vector '%v'", vector)
+	}
+	metrics := map[string]string{}
+	for _, part := range strings.Split(vector, "/")[1:] {
+		keyValue := strings.SplitN(part, ":", 2)
+		if len(keyValue) != 2 {
+			return 0, fmt.Errorf("invalid CVSS vector '%v'", vector)
+		}
+		metrics[keyValue[0]] = keyValue[1]
+	}
+
+	values := map[string]float64{}
+	for metric, weights := range cvss3Weights {
+		weight, ok := weights[metrics[metric]]
+		if !ok {
+			return 0, fmt.Errorf("invalid value for metric '%v' in CVSS vector '%v'", metric, vector)
+		}
+		values[metric] = weight
+	}
+	scopeChanged := false
+	switch metrics["S"] {
+	case "U":
+	case "C":
+		scopeChanged = true
+		switch metrics["PR"] {
+		case "L":
+			values["PR"] = 0.68
+		case "H":
+			values["PR"] = 0.5
+		}
+	default:
+		return 0, fmt.Errorf("invalid value for metric 'S' in CVSS vector '%v'", vector)
+	}
+
+	iss := 1 - (1-values["C"])*(1-values["I"])*(1-values["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+	exploitability := 8.22 * values["AV"] * values["AC"] * values["PR"] * values["UI"]
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp returns the smallest number, specified to one decimal place, that is equal to or higher than its input
+func roundUp(value float64) float64 {
+	intInput := int(math.Round(value * 100000))
+	if intInput%10000 == 0 {
+		return float64(intInput) / 100000.0
+	}
+	return (math.Floor(float64(intInput)/10000) + 1) / 10.0
+}
+
+// severityFromScore maps a CVSS score to the qualitative severity rating
+func severityFromScore(score float64) string {
+	switch {
+	case score >= 9.0:
+		return SeverityCritical
+	case score >= 7.0:
+		return SeverityHigh
+	case score >= 4.0:
+		return SeverityMedium
+	case score > 0:
+		return SeverityLow
+	}
+	return SeverityUnknown
+}
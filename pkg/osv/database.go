@@ -0,0 +1,207 @@
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// Vulnerability represents an entry of the OSV database as described in https://ossf.github.io/osv-schema/
+type Vulnerability struct {
+	ID               string                 `json:"id"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Modified         string                 `json:"modified,omitempty"`
+	Withdrawn        string                 `json:"withdrawn,omitempty"`
+	Severity         []Severity             `json:"severity,omitempty"`
+	Affected         []Affected             `json:"affected,omitempty"`
+	References       []Reference            `json:"references,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Severity holds a severity score of a vulnerability, e.g. a CVSS vector
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected describes which versions of a package are affected by a vulnerability
+type Affected struct {
+	Package          AffectedPackage        `json:"package"`
+	Ranges           []Range                `json:"ranges,omitempty"`
+	Versions         []string               `json:"versions,omitempty"`
+	Severity         []Severity             `json:"severity,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// AffectedPackage identifies the package an Affected entry refers to
+type AffectedPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range describes a list of version events of type SEMVER, ECOSYSTEM or GIT
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event marks a version in which a vulnerability has been introduced or fixed
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Reference is a link to further information about a vulnerability
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Database is an in-memory index of an OSV database snapshot
+type Database struct {
+	// entries are indexed by ecosystem and package name
+	entries map[string][]*Vulnerability
+	count   int
+}
+
+// NewDatabase creates an in-memory database from the given vulnerabilities
+func NewDatabase(vulnerabilities []Vulnerability) *Database {
+	db := &Database{entries: map[string][]*Vulnerability{}}
+	for i := range vulnerabilities {
+		db.add(&vulnerabilities[i])
+	}
+	return db
+}
+
+// LoadDatabase reads an OSV database snapshot from a directory.
+// The directory may contain single OSV JSON files as well as zip archives as they are
+// provided for download per ecosystem (e.g. https://osv-vulnerabilities.storage.googleapis.com/npm/all.zip).
+func LoadDatabase(path string) (*Database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to access OSV database '%v'", path)
+	}
+	db := &Database{entries: map[string][]*Vulnerability{}}
+	if !info.IsDir() {
+		if err := db.loadFile(path); err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+	err = filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		return db.loadFile(filePath)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load OSV database '%v'", path)
+	}
+	log.Entry().Debugf("Loaded %v vulnerabilities from OSV database '%v'", db.count, path)
+	return db, nil
+}
+
+// Size returns the number of vulnerabilities contained in the database
+func (db *Database) Size() int {
+	return db.count
+}
+
+func (db *Database) loadFile(filePath string) error {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read file '%v'", filePath)
+		}
+		return db.addJSON(filePath, content)
+	case ".zip":
+		return db.loadArchive(filePath)
+	}
+	return nil
+}
+
+func (db *Database) loadArchive(filePath string) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open archive '%v'", filePath)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(file.Name), ".json") {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return errors.Wrapf(err, "failed to open '%v' in archive '%v'", file.Name, filePath)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read '%v' in archive '%v'", file.Name, filePath)
+		}
+		if err := db.addJSON(filePath+"/"+file.Name, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *Database) addJSON(source string, content []byte) error {
+	var vulnerability Vulnerability
+	if err := json.Unmarshal(content, &vulnerability); err != nil {
+		return errors.Wrapf(err, "failed to parse OSV entry '%v'", source)
+	}
+	if len(vulnerability.ID) == 0 {
+		log.Entry().Debugf("Ignoring '%v' since it is no OSV entry", source)
+		return nil
+	}
+	db.add(&vulnerability)
+	return nil
+}
+
+func (db *Database) add(vulnerability *Vulnerability) {
+	if len(vulnerability.Withdrawn) > 0 {
+		return
+	}
+	keys := map[string]bool{}
+	for _, affected := range vulnerability.Affected {
+		key := packageKey(affected.Package.Ecosystem, affected.Package.Name)
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		db.entries[key] = append(db.entries[key], vulnerability)
+	}
+	db.count++
+}
+
+func (db *Database) lookup(ecosystem, name string) []*Vulnerability {
+	return db.entries[packageKey(ecosystem, name)]
+}
+
+func packageKey(ecosystem, name string) string {
+	// ecosystems may carry a suffix like "Debian:11" which is only relevant for distribution packages
+	if !isDistroEcosystem(ecosystem) {
+		ecosystem = baseEcosystem(ecosystem)
+	}
+	if ecosystem == EcosystemPyPI {
+		name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	}
+	return ecosystem + "|" + name
+}
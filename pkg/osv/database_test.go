@@ -0,0 +1,145 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const log4jEntry = `{
+  "id": "GHSA-jfh8-c2jp-5v3q",
+  "aliases": ["CVE-2021-44228"],
+  "summary": "Remote code injection in Log4j",
+  "affected": [{
+    "package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "2.13.0"}, {"fixed": "2.15.0"}, {"introduced": "2.0-beta9"}, {"fixed": "2.3.1"}]}]
+  }],
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}]
+}`
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	archive, err := os.Create(path)
+	require.NoError(t, err)
+	defer archive.Close()
+	writer := zip.NewWriter(archive)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+}
+
+func TestLoadDatabase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("directory", func(t *testing.T) {
+		db, err := LoadDatabase("testdata")
+		require.NoError(t, err)
+		assert.Equal(t, 2, db.Size())
+		assert.Len(t, db.lookup(EcosystemNpm, "lodash"), 1)
+		assert.Len(t, db.lookup(EcosystemGo, "golang.org/x/sys"), 1)
+	})
+
+	t.Run("zip archive", func(t *testing.T) {
+		dir := t.TempDir()
+		writeZip(t, filepath.Join(dir, "all.zip"), map[string]string{"GHSA-jfh8-c2jp-5v3q.json": log4jEntry})
+
+		db, err := LoadDatabase(dir)
+		require.NoError(t, err)
+		assert.Equal(t, 1, db.Size())
+		assert.Len(t, db.lookup(EcosystemMaven, "org.apache.logging.log4j:log4j-core"), 1)
+	})
+
+	t.Run("withdrawn entries are ignored", func(t *testing.T) {
+		db := NewDatabase([]Vulnerability{{ID: "GHSA-1", Withdrawn: "2022-01-01T00:00:00Z", Affected: []Affected{{Package: AffectedPackage{Ecosystem: EcosystemNpm, Name: "a"}}}}})
+		assert.Equal(t, 0, db.Size())
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644))
+
+		_, err := LoadDatabase(dir)
+		assert.ErrorContains(t, err, "failed to parse OSV entry")
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		_, err := LoadDatabase("not/existing")
+		assert.ErrorContains(t, err, "failed to access OSV database 'not/existing'")
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeZip(t, filepath.Join(dir, "maven.zip"), map[string]string{"GHSA-jfh8-c2jp-5v3q.json": log4jEntry})
+	content, err := os.ReadFile(filepath.Join("testdata", "GHSA-lodash.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "GHSA-lodash.json"), content, 0644))
+	db, err := LoadDatabase(dir)
+	require.NoError(t, err)
+
+	findings := db.Scan([]Package{
+		{Name: "lodash", Version: "4.17.20", Ecosystem: EcosystemNpm},
+		{Name: "lodash", Version: "4.17.21", Ecosystem: EcosystemNpm},
+		{Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Ecosystem: EcosystemMaven},
+		{Name: "org.apache.logging.log4j:log4j-core", Version: "2.3.1", Ecosystem: EcosystemMaven},
+		{Name: "org.apache.logging.log4j:log4j-core", Version: "2.1", Ecosystem: EcosystemMaven},
+	})
+
+	require.Len(t, findings, 3)
+	assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", findings[0].Vulnerability.ID)
+	assert.Equal(t, SeverityCritical, findings[0].Severity)
+	assert.Equal(t, 10.0, findings[0].Score)
+	assert.Equal(t, []string{"2.15.0", "2.3.1"}, findings[0].FixedVersions)
+	assert.Equal(t, "2.14.1", findings[0].Package.Version)
+	assert.Equal(t, "2.1", findings[1].Package.Version)
+	assert.Equal(t, "GHSA-35jh-r3h4-6jhm", findings[2].Vulnerability.ID)
+	assert.Equal(t, SeverityHigh, findings[2].Severity)
+	assert.Equal(t, 7.2, findings[2].Score)
+	assert.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2021-23337", findings[2].URL())
+	assert.True(t, findings[2].Matches([]string{"CVE-2021-23337"}))
+	assert.False(t, findings[2].Matches([]string{"CVE-2021-44228"}))
+
+	assert.Equal(t, map[string]int{SeverityCritical: 2, SeverityHigh: 1, SeverityMedium: 0, SeverityLow: 0, SeverityUnknown: 0}, CountBySeverity(findings))
+	assert.Len(t, SevereFindings(findings, []string{"critical"}), 2)
+}
+
+func TestScanDistroPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	entry := `{
+  "id": "DSA-5532-1",
+  "aliases": ["CVE-2023-5363"],
+  "affected": [{
+    "package": {"ecosystem": "Debian:12", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.11-1~deb12u2"}]}]
+  }],
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"}]
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "DSA-5532-1.json"), []byte(entry), 0644))
+	db, err := LoadDatabase(dir)
+	require.NoError(t, err)
+
+	findings := db.Scan([]Package{
+		{Name: "openssl", Version: "3.0.11-1~deb12u1", Ecosystem: "Debian:12"},
+		{Name: "openssl", Version: "3.0.11-1~deb12u2", Ecosystem: "Debian:12"},
+		{Name: "openssl", Version: "3.0.9-1", Ecosystem: "Debian:11"},
+	})
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, "DSA-5532-1", findings[0].Vulnerability.ID)
+	assert.Equal(t, "3.0.11-1~deb12u1", findings[0].Package.Version)
+	assert.Equal(t, []string{"3.0.11-1~deb12u2"}, findings[0].FixedVersions)
+}
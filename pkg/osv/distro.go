@@ -0,0 +1,303 @@
+package osv
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// OSV ecosystems of Linux distributions, the ecosystem of a package carries the release as suffix, e.g. "Debian:12"
+const (
+	EcosystemDebian     = "Debian"
+	EcosystemUbuntu     = "Ubuntu"
+	EcosystemAlpine     = "Alpine"
+	EcosystemMariner    = "Mariner"
+	EcosystemAzureLinux = "Azure Linux"
+	EcosystemRocky      = "Rocky Linux"
+	EcosystemAlma       = "AlmaLinux"
+)
+
+// distroPurlTypes maps the distribution ecosystems to the package URL type and namespace of their packages
+var distroPurlTypes = map[string][2]string{
+	EcosystemDebian:     {packageurl.TypeDebian, "debian"},
+	EcosystemUbuntu:     {packageurl.TypeDebian, "ubuntu"},
+	EcosystemAlpine:     {"apk", "alpine"},
+	EcosystemMariner:    {packageurl.TypeRPM, "mariner"},
+	EcosystemAzureLinux: {packageurl.TypeRPM, "azurelinux"},
+	EcosystemRocky:      {packageurl.TypeRPM, "rocky"},
+	EcosystemAlma:       {packageurl.TypeRPM, "almalinux"},
+}
+
+// baseEcosystem strips the release suffix of an ecosystem like "Debian:12"
+func baseEcosystem(ecosystem string) string {
+	return strings.SplitN(ecosystem, ":", 2)[0]
+}
+
+// isDistroEcosystem checks whether the ecosystem belongs to a Linux distribution
+func isDistroEcosystem(ecosystem string) bool {
+	_, ok := distroPurlTypes[baseEcosystem(ecosystem)]
+	return ok
+}
+
+// distroPurl returns the package URL of a distribution package, the release is added as distro qualifier
+func distroPurl(p Package) string {
+	purlType := distroPurlTypes[baseEcosystem(p.Ecosystem)]
+	qualifiers := packageurl.Qualifiers{}
+	if _, release, found := strings.Cut(p.Ecosystem, ":"); found {
+		qualifiers = append(qualifiers, packageurl.Qualifier{Key: "distro", Value: release})
+	}
+	return packageurl.NewPackageURL(purlType[0], purlType[1], p.Name, p.Version, qualifiers, "").ToString()
+}
+
+// compareDebian compares versions of the format [epoch:]upstream[-revision] following the rules of dpkg
+func compareDebian(a, b string) int {
+	epochA, upstreamA, revisionA := splitDebianVersion(a)
+	epochB, upstreamB, revisionB := splitDebianVersion(b)
+	if epochA != epochB {
+		return compareInts(epochA, epochB)
+	}
+	if c := compareDpkgPart(upstreamA, upstreamB); c != 0 {
+		return c
+	}
+	return compareDpkgPart(revisionA, revisionB)
+}
+
+func splitDebianVersion(version string) (int, string, string) {
+	epoch := 0
+	if e, rest, found := strings.Cut(version, ":"); found {
+		epoch, _ = strconv.Atoi(e)
+		version = rest
+	}
+	revision := ""
+	if i := strings.LastIndex(version, "-"); i >= 0 {
+		version, revision = version[:i], version[i+1:]
+	}
+	return epoch, version, revision
+}
+
+// dpkgOrder sorts '~' before the end of a version, the end before letters and letters before other characters
+func dpkgOrder(s string) int {
+	switch {
+	case len(s) == 0 || isDigit(s[0]):
+		return 0
+	case s[0] == '~':
+		return -1
+	case isLetter(s[0]):
+		return int(s[0])
+	}
+	return int(s[0]) + 256
+}
+
+func compareDpkgPart(a, b string) int {
+	for len(a) > 0 || len(b) > 0 {
+		for (len(a) > 0 && !isDigit(a[0])) || (len(b) > 0 && !isDigit(b[0])) {
+			orderA, orderB := dpkgOrder(a), dpkgOrder(b)
+			if orderA != orderB {
+				return compareInts(orderA, orderB)
+			}
+			a, b = a[1:], b[1:]
+		}
+		var numberA, numberB string
+		numberA, a = leadingDigits(a)
+		numberB, b = leadingDigits(b)
+		if c := compareNumbers(numberA, numberB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareRPM compares versions of the format [epoch:]version[-release] following the rules of rpm
+func compareRPM(a, b string) int {
+	epochA, versionA, releaseA := splitRPMVersion(a)
+	epochB, versionB, releaseB := splitRPMVersion(b)
+	if epochA != epochB {
+		return compareInts(epochA, epochB)
+	}
+	if c := rpmVerCmp(versionA, versionB); c != 0 {
+		return c
+	}
+	return rpmVerCmp(releaseA, releaseB)
+}
+
+func splitRPMVersion(version string) (int, string, string) {
+	epoch := 0
+	if e, rest, found := strings.Cut(version, ":"); found {
+		epoch, _ = strconv.Atoi(e)
+		version = rest
+	}
+	version, release, _ := strings.Cut(version, "-")
+	return epoch, version, release
+}
+
+// rpmVerCmp is a port of rpmvercmp which compares alternating numeric and alphabetic segments
+func rpmVerCmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	for len(a) > 0 || len(b) > 0 {
+		a = strings.TrimLeftFunc(a, isRPMSeparator)
+		b = strings.TrimLeftFunc(b, isRPMSeparator)
+
+		// '~' sorts before everything else, even before the end of the version
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		// '^' sorts after the end of the version but before everything else
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case len(a) == 0:
+				return -1
+			case len(b) == 0:
+				return 1
+			case !strings.HasPrefix(a, "^"):
+				return 1
+			case !strings.HasPrefix(b, "^"):
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if len(a) == 0 || len(b) == 0 {
+			break
+		}
+
+		var segmentA, segmentB string
+		numeric := isDigit(a[0])
+		if numeric {
+			segmentA, a = leadingDigits(a)
+			segmentB, b = leadingDigits(b)
+		} else {
+			segmentA, a = leadingLetters(a)
+			segmentB, b = leadingLetters(b)
+		}
+		if len(segmentB) == 0 {
+			// numeric segments are newer than alphabetic ones
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+		var c int
+		if numeric {
+			c = compareNumbers(segmentA, segmentB)
+		} else {
+			c = strings.Compare(segmentA, segmentB)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return -1
+	}
+	return 1
+}
+
+func isRPMSeparator(r rune) bool {
+	return !(r < 128 && (isDigit(byte(r)) || isLetter(byte(r)))) && r != '~' && r != '^'
+}
+
+// apkSuffixRanks orders the suffixes of Alpine package versions, pre-release suffixes sort before the release
+var apkSuffixRanks = map[string]int{
+	"alpha": 0,
+	"beta":  1,
+	"pre":   2,
+	"rc":    3,
+	"":      4,
+	"cvs":   5,
+	"svn":   6,
+	"git":   7,
+	"hg":    8,
+	"p":     9,
+}
+
+// compareAlpine compares versions of the format version[_suffix][-rN] used by apk
+func compareAlpine(a, b string) int {
+	versionA, suffixesA, releaseA := splitAlpineVersion(a)
+	versionB, suffixesB, releaseB := splitAlpineVersion(b)
+	if c := compareDpkgPart(versionA, versionB); c != 0 {
+		return c
+	}
+	for i := 0; i < len(suffixesA) || i < len(suffixesB); i++ {
+		var suffixA, suffixB string
+		if i < len(suffixesA) {
+			suffixA = suffixesA[i]
+		}
+		if i < len(suffixesB) {
+			suffixB = suffixesB[i]
+		}
+		nameA, numberA := leadingLetters(suffixA)
+		nameB, numberB := leadingLetters(suffixB)
+		if c := compareInts(apkSuffixRanks[nameA], apkSuffixRanks[nameB]); c != 0 {
+			return c
+		}
+		if c := compareNumbers(numberA, numberB); c != 0 {
+			return c
+		}
+	}
+	return compareNumbers(releaseA, releaseB)
+}
+
+func splitAlpineVersion(version string) (string, []string, string) {
+	release := ""
+	if i := strings.LastIndex(version, "-r"); i >= 0 {
+		version, release = version[:i], version[i+2:]
+	}
+	parts := strings.Split(version, "_")
+	return parts[0], parts[1:], release
+}
+
+func leadingDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func leadingLetters(s string) (string, string) {
+	i := 0
+	for i < len(s) && isLetter(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareNumbers compares two strings of digits of arbitrary length
+func compareNumbers(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return compareInts(len(a), len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
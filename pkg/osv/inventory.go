@@ -0,0 +1,271 @@
+package osv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/package-url/packageurl-go"
+	"github.com/pkg/errors"
+)
+
+// OSV ecosystems supported by the step
+const (
+	EcosystemGo        = "Go"
+	EcosystemNpm       = "npm"
+	EcosystemMaven     = "Maven"
+	EcosystemPyPI      = "PyPI"
+	EcosystemNuGet     = "NuGet"
+	EcosystemCrates    = "crates.io"
+	EcosystemRubyGems  = "RubyGems"
+	EcosystemPackagist = "Packagist"
+)
+
+var purlTypeToEcosystem = map[string]string{
+	packageurl.TypeGolang:   EcosystemGo,
+	packageurl.TypeNPM:      EcosystemNpm,
+	packageurl.TypeMaven:    EcosystemMaven,
+	packageurl.TypePyPi:     EcosystemPyPI,
+	packageurl.TypeNuget:    EcosystemNuGet,
+	packageurl.TypeCargo:    EcosystemCrates,
+	packageurl.TypeGem:      EcosystemRubyGems,
+	packageurl.TypeComposer: EcosystemPackagist,
+}
+
+// Package is a component which is checked against the OSV database
+type Package struct {
+	Name      string
+	Version   string
+	Ecosystem string
+	// Source is the file the package has been found in
+	Source string
+}
+
+// Purl returns the package URL of the package
+func (p Package) Purl() string {
+	if isDistroEcosystem(p.Ecosystem) {
+		return distroPurl(p)
+	}
+	for purlType, ecosystem := range purlTypeToEcosystem {
+		if ecosystem != p.Ecosystem {
+			continue
+		}
+		namespace, name := "", p.Name
+		switch purlType {
+		case packageurl.TypeMaven:
+			if parts := strings.SplitN(p.Name, ":", 2); len(parts) == 2 {
+				namespace, name = parts[0], parts[1]
+			}
+		case packageurl.TypeGolang, packageurl.TypeNPM, packageurl.TypeComposer:
+			if i := strings.LastIndex(p.Name, "/"); i > 0 {
+				namespace, name = p.Name[:i], p.Name[i+1:]
+			}
+		}
+		return packageurl.NewPackageURL(purlType, namespace, name, p.Version, nil, "").ToString()
+	}
+	return fmt.Sprintf("%v:%v@%v", p.Ecosystem, p.Name, p.Version)
+}
+
+// PackageFromPurl creates a package from a package URL
+func PackageFromPurl(purl string) (Package, error) {
+	parsed, err := packageurl.FromString(purl)
+	if err != nil {
+		return Package{}, errors.Wrapf(err, "invalid package URL '%v'", purl)
+	}
+	ecosystem, ok := purlTypeToEcosystem[parsed.Type]
+	if !ok {
+		return Package{}, fmt.Errorf("package URL type '%v' is not supported", parsed.Type)
+	}
+	name := parsed.Name
+	if len(parsed.Namespace) > 0 {
+		separator := "/"
+		if parsed.Type == packageurl.TypeMaven {
+			separator = ":"
+		}
+		name = parsed.Namespace + separator + name
+	}
+	return Package{Name: name, Version: parsed.Version, Ecosystem: ecosystem}, nil
+}
+
+// ReadPackages detects the type of the given file and extracts the packages from it.
+// Supported are CycloneDX SBOMs (XML or JSON), go.sum, package-lock.json and
+// the text output of the maven-dependency-plugin's tree goal.
+func ReadPackages(path string) ([]Package, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read file '%v'", path)
+	}
+
+	var packages []Package
+	switch name := filepath.Base(path); {
+	case name == "go.sum":
+		packages = parseGoSum(content)
+	case name == "package-lock.json" || name == "npm-shrinkwrap.json":
+		packages, err = parsePackageLock(content)
+	case strings.HasSuffix(name, ".xml"):
+		packages, err = parseCycloneDX(content, cdx.BOMFileFormatXML)
+	case strings.HasSuffix(name, ".json"):
+		packages, err = parseCycloneDX(content, cdx.BOMFileFormatJSON)
+	default:
+		packages = parseMavenDependencyTree(content)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse file '%v'", path)
+	}
+	for i := range packages {
+		packages[i].Source = path
+	}
+	return packages, nil
+}
+
+// Deduplicate removes duplicate packages while keeping the first occurrence
+func Deduplicate(packages []Package) []Package {
+	seen := map[string]bool{}
+	result := []Package{}
+	for _, p := range packages {
+		key := p.Ecosystem + "|" + p.Name + "|" + p.Version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, p)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Purl() < result[j].Purl()
+	})
+	return result
+}
+
+func parseCycloneDX(content []byte, format cdx.BOMFileFormat) ([]Package, error) {
+	bom := cdx.BOM{}
+	if err := cdx.NewBOMDecoder(bytes.NewReader(content), format).Decode(&bom); err != nil {
+		return nil, err
+	}
+	packages := []Package{}
+	var collect func(components *[]cdx.Component)
+	collect = func(components *[]cdx.Component) {
+		if components == nil {
+			return
+		}
+		for _, component := range *components {
+			if len(component.PackageURL) > 0 {
+				if p, err := PackageFromPurl(component.PackageURL); err == nil {
+					packages = append(packages, p)
+				}
+			}
+			collect(component.Components)
+		}
+	}
+	collect(bom.Components)
+	return packages, nil
+}
+
+func parseGoSum(content []byte) []Package {
+	packages := []Package{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		packages = append(packages, Package{
+			Name:      fields[0],
+			Version:   strings.TrimSuffix(fields[1], "+incompatible"),
+			Ecosystem: EcosystemGo,
+		})
+	}
+	return packages
+}
+
+type packageLock struct {
+	LockfileVersion int                              `json:"lockfileVersion"`
+	Packages        map[string]packageLockEntry      `json:"packages"`
+	Dependencies    map[string]packageLockDependency `json:"dependencies"`
+}
+
+type packageLockEntry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Link    bool   `json:"link"`
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+func parsePackageLock(content []byte) ([]Package, error) {
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	packages := []Package{}
+	if len(lock.Packages) > 0 {
+		for path, entry := range lock.Packages {
+			index := strings.LastIndex(path, "node_modules/")
+			if index < 0 || entry.Link || len(entry.Version) == 0 {
+				// the root project and workspace links are no dependencies
+				continue
+			}
+			name := entry.Name
+			if len(name) == 0 {
+				name = path[index+len("node_modules/"):]
+			}
+			packages = append(packages, Package{Name: name, Version: entry.Version, Ecosystem: EcosystemNpm})
+		}
+		return packages, nil
+	}
+
+	// lockfileVersion 1 nests the dependencies
+	var collect func(dependencies map[string]packageLockDependency)
+	collect = func(dependencies map[string]packageLockDependency) {
+		for name, dependency := range dependencies {
+			packages = append(packages, Package{Name: name, Version: dependency.Version, Ecosystem: EcosystemNpm})
+			collect(dependency.Dependencies)
+		}
+	}
+	collect(lock.Dependencies)
+	return packages, nil
+}
+
+// parseMavenDependencyTree parses the output of 'mvn dependency:tree -DoutputFile=<file>'
+func parseMavenDependencyTree(content []byte) []Package {
+	packages := []Package{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "[INFO]")
+		trimmed := strings.TrimLeft(line, " |+-\\")
+		fields := strings.Fields(trimmed)
+		if trimmed == strings.TrimSpace(line) || len(fields) == 0 {
+			// the root of the tree is the project itself
+			continue
+		}
+		coordinates := strings.Split(fields[0], ":")
+		var version string
+		switch len(coordinates) {
+		case 4:
+			// groupId:artifactId:type:version
+			version = coordinates[3]
+		case 5:
+			// groupId:artifactId:type:version:scope
+			version = coordinates[3]
+		case 6:
+			// groupId:artifactId:type:classifier:version:scope
+			version = coordinates[4]
+		default:
+			continue
+		}
+		packages = append(packages, Package{
+			Name:      coordinates[0] + ":" + coordinates[1],
+			Version:   version,
+			Ecosystem: EcosystemMaven,
+		})
+	}
+	return packages
+}
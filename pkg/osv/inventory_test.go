@@ -0,0 +1,151 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestReadPackages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("go.sum", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "go.sum", `github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+github.com/docker/docker v20.10.24+incompatible h1:Ugvxm7a8+Gz6vqQYQQ2W7GYq5EUPaAiuPgIfVyI3dYE=
+`)
+		packages, err := ReadPackages(path)
+		require.NoError(t, err)
+		assert.Equal(t, []Package{
+			{Name: "github.com/pkg/errors", Version: "v0.9.1", Ecosystem: EcosystemGo, Source: path},
+			{Name: "github.com/docker/docker", Version: "v20.10.24", Ecosystem: EcosystemGo, Source: path},
+		}, packages)
+	})
+
+	t.Run("package-lock.json v3", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "package-lock.json", `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.20"},
+    "node_modules/a/node_modules/@scope/b": {"version": "1.2.3"},
+    "node_modules/local": {"resolved": "packages/local", "link": true}
+  }
+}`)
+		packages, err := ReadPackages(path)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []Package{
+			{Name: "lodash", Version: "4.17.20", Ecosystem: EcosystemNpm, Source: path},
+			{Name: "@scope/b", Version: "1.2.3", Ecosystem: EcosystemNpm, Source: path},
+		}, packages)
+	})
+
+	t.Run("package-lock.json v1", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "package-lock.json", `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}}
+  }
+}`)
+		packages, err := ReadPackages(path)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []Package{
+			{Name: "a", Version: "1.0.0", Ecosystem: EcosystemNpm, Source: path},
+			{Name: "b", Version: "2.0.0", Ecosystem: EcosystemNpm, Source: path},
+		}, packages)
+	})
+
+	t.Run("CycloneDX SBOM", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "bom-maven.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <components>
+    <component type="library">
+      <group>org.apache.logging.log4j</group>
+      <name>log4j-core</name>
+      <version>2.14.1</version>
+      <purl>pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar</purl>
+      <components>
+        <component type="library">
+          <name>unknown</name>
+          <purl>pkg:generic/unknown@1</purl>
+        </component>
+      </components>
+    </component>
+  </components>
+</bom>`)
+		packages, err := ReadPackages(path)
+		require.NoError(t, err)
+		assert.Equal(t, []Package{
+			{Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Ecosystem: EcosystemMaven, Source: path},
+		}, packages)
+	})
+
+	t.Run("maven dependency tree", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "dependency-tree.txt", `com.example:app:jar:1.0.0
++- org.apache.logging.log4j:log4j-core:jar:2.14.1:compile
+|  \- org.apache.logging.log4j:log4j-api:jar:2.14.1:compile
+\- io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.68.Final:runtime
+`)
+		packages, err := ReadPackages(path)
+		require.NoError(t, err)
+		assert.Equal(t, []Package{
+			{Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Ecosystem: EcosystemMaven, Source: path},
+			{Name: "org.apache.logging.log4j:log4j-api", Version: "2.14.1", Ecosystem: EcosystemMaven, Source: path},
+			{Name: "io.netty:netty-transport-native-epoll", Version: "4.1.68.Final", Ecosystem: EcosystemMaven, Source: path},
+		}, packages)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "package-lock.json", `{`)
+		_, err := ReadPackages(path)
+		assert.ErrorContains(t, err, "failed to parse file")
+	})
+}
+
+func TestPurl(t *testing.T) {
+	t.Parallel()
+
+	for _, purl := range []string{
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+		"pkg:npm/%40scope/b@1.2.3",
+		"pkg:golang/github.com/pkg/errors@v0.9.1",
+		"pkg:pypi/django@3.2",
+	} {
+		p, err := PackageFromPurl(purl)
+		assert.NoError(t, err)
+		assert.Equal(t, purl, p.Purl())
+	}
+
+	assert.Equal(t, "pkg:deb/debian/openssl@3.0.11-1~deb12u2?distro=12", Package{Name: "openssl", Version: "3.0.11-1~deb12u2", Ecosystem: "Debian:12"}.Purl())
+	assert.Equal(t, "pkg:apk/alpine/busybox@1.36.1-r15?distro=v3.19", Package{Name: "busybox", Version: "1.36.1-r15", Ecosystem: "Alpine:v3.19"}.Purl())
+	assert.Equal(t, "pkg:rpm/rocky/bash@5.1.8-6.el9", Package{Name: "bash", Version: "5.1.8-6.el9", Ecosystem: EcosystemRocky}.Purl())
+
+	_, err := PackageFromPurl("pkg:generic/unknown@1")
+	assert.EqualError(t, err, "package URL type 'generic' is not supported")
+}
+
+func TestDeduplicate(t *testing.T) {
+	t.Parallel()
+
+	packages := Deduplicate([]Package{
+		{Name: "b", Version: "1", Ecosystem: EcosystemNpm, Source: "first"},
+		{Name: "a", Version: "1", Ecosystem: EcosystemNpm},
+		{Name: "b", Version: "1", Ecosystem: EcosystemNpm, Source: "second"},
+	})
+	assert.Equal(t, []Package{
+		{Name: "a", Version: "1", Ecosystem: EcosystemNpm},
+		{Name: "b", Version: "1", Ecosystem: EcosystemNpm, Source: "first"},
+	}, packages)
+}
@@ -0,0 +1,205 @@
+package osv
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+// ReportsDirectory defines the subfolder for the OSV reports which are generated
+const ReportsDirectory = "osv"
+
+// CreateCustomReport creates a vulnerability ScanReport to be used for uploading into various sinks
+func CreateCustomReport(databasePath string, packages []Package, findings []Finding, failOnSeverities []string) reporting.ScanReport {
+	counts := CountBySeverity(findings)
+	violations := len(SevereFindings(findings, failOnSeverities))
+
+	scanReport := reporting.ScanReport{
+		ReportTitle: "OSV Vulnerability Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "OSV database", Details: databasePath},
+		},
+		Overview: []reporting.OverviewRow{
+			{Description: "Scanned packages", Details: fmt.Sprint(len(packages))},
+			{Description: "Vulnerabilities", Details: fmt.Sprint(len(findings))},
+		},
+		SuccessfulScan: violations == 0,
+		ReportTime:     time.Now(),
+	}
+	for _, severity := range Severities {
+		row := reporting.OverviewRow{Description: fmt.Sprintf("Vulnerabilities with severity %v", severity), Details: fmt.Sprint(counts[severity])}
+		if counts[severity] > 0 && IsSevere(severity, failOnSeverities) {
+			row.Style = reporting.Red
+		}
+		scanReport.Overview = append(scanReport.Overview, row)
+	}
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No vulnerabilities detected",
+		Headers: []string{
+			"Vulnerability",
+			"Aliases",
+			"Severity",
+			"CVSS v3 Score",
+			"Package",
+			"Version",
+			"Fixed in",
+			"Source",
+		},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, finding := range findings {
+		style := reporting.ColumnStyle(reporting.Yellow)
+		if IsSevere(finding.Severity, failOnSeverities) {
+			style = reporting.Red
+		}
+		row := reporting.ScanRow{}
+		row.AddColumn(fmt.Sprintf(`<a href="%v">%v</a>`, finding.URL(), finding.Vulnerability.ID), 0)
+		row.AddColumn(strings.Join(finding.Vulnerability.Aliases, ", "), 0)
+		row.AddColumn(finding.Severity, style)
+		row.AddColumn(finding.Score, 0)
+		row.AddColumn(finding.Package.Name, 0)
+		row.AddColumn(finding.Package.Version, 0)
+		row.AddColumn(strings.Join(finding.FixedVersions, ", "), 0)
+		row.AddColumn(finding.Package.Source, 0)
+		detailTable.Rows = append(detailTable.Rows, row)
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
+
+// WriteCustomReports writes the ScanReport as HTML report and as JSON step report
+func WriteCustomReports(scanReport reporting.ScanReport, databasePath string, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	htmlReportPath := filepath.Join(ReportsDirectory, "piper_osv_vulnerability_report.html")
+	if err := fileUtils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "OSV Vulnerability Report", Target: htmlReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := fileUtils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := fileUtils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create step reporting directory")
+		}
+	}
+	jsonReportPath := filepath.Join(reporting.StepReportDirectory, fmt.Sprintf("osvExecuteScan_oss_%x.json", sha1.Sum([]byte(databasePath))))
+	if err := fileUtils.FileWrite(jsonReportPath, jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+
+	return reportPaths, nil
+}
+
+// CreateSarifResultFile creates a SARIF result from the findings
+func CreateSarifResultFile(findings []Finding) *format.SARIF {
+	sarif := format.SARIF{
+		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []format.Runs{{Results: []format.Results{}}},
+	}
+	tool := format.Tool{Driver: format.Driver{Name: "OSV offline scan", InformationUri: "https://osv.dev"}}
+
+	ruleIndices := map[string]int{}
+	for _, finding := range findings {
+		ruleID := finding.Vulnerability.ID
+		index, known := ruleIndices[ruleID]
+		if !known {
+			index = len(tool.Driver.Rules)
+			ruleIndices[ruleID] = index
+			tool.Driver.Rules = append(tool.Driver.Rules, format.SarifRule{
+				ID:                   ruleID,
+				Name:                 ruleID,
+				ShortDescription:     &format.Message{Text: finding.Vulnerability.Summary},
+				FullDescription:      &format.Message{Text: finding.Vulnerability.Details},
+				DefaultConfiguration: &format.DefaultConfiguration{Level: sarifLevel(finding.Severity)},
+				HelpURI:              finding.URL(),
+				Help:                 &format.Help{Text: finding.Vulnerability.Details},
+				Properties: &format.SarifRuleProperties{
+					Tags:             append([]string{"security"}, finding.Vulnerability.Aliases...),
+					SecuritySeverity: fmt.Sprint(finding.Score),
+					Precision:        "very-high",
+				},
+			})
+		}
+
+		purl := finding.Package.Purl()
+		result := format.Results{
+			RuleID:    ruleID,
+			RuleIndex: index,
+			Level:     sarifLevel(finding.Severity),
+			Message:   &format.Message{Text: fmt.Sprintf("%v %v is affected by %v (%v)", finding.Package.Name, finding.Package.Version, ruleID, finding.Severity)},
+			Locations: []format.Location{{PhysicalLocation: format.PhysicalLocation{
+				ArtifactLocation: format.ArtifactLocation{URI: filepath.ToSlash(finding.Package.Source)},
+				LogicalLocations: []format.LogicalLocation{{FullyQualifiedName: purl}},
+			}}},
+			PartialFingerprints: format.PartialFingerprints{
+				PackageURLPlusCVEHash: base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%v+%v", purl, ruleID))),
+			},
+			Properties: &format.SarifProperties{
+				ToolSeverity:          finding.Severity,
+				UnifiedSeverity:       strings.ToLower(finding.Severity),
+				UnifiedCriticality:    float32(finding.Score),
+				UnifiedAuditState:     "new",
+				AuditRequirement:      format.AUDIT_REQUIREMENT_GROUP_1_DESC,
+				AuditRequirementIndex: format.AUDIT_REQUIREMENT_GROUP_1_INDEX,
+			},
+		}
+		sarif.Runs[0].Results = append(sarif.Runs[0].Results, result)
+	}
+	sarif.Runs[0].Tool = tool
+
+	return &sarif
+}
+
+// WriteSarifFile writes the SARIF result as JSON file
+func WriteSarifFile(sarif *format.SARIF, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	sarifReport, err := json.Marshal(sarif)
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to marshal SARIF json file")
+	}
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	sarifReportPath := filepath.Join(ReportsDirectory, "piper_osv_vulnerability.sarif")
+	if err := fileUtils.FileWrite(sarifReportPath, sarifReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write SARIF file")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "OSV Vulnerability SARIF file", Target: sarifReportPath})
+
+	return reportPaths, nil
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium, SeverityLow:
+		return "warning"
+	}
+	return "note"
+}
@@ -0,0 +1,100 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFindings = []Finding{
+	{
+		Package:       Package{Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Ecosystem: EcosystemMaven, Source: "target/bom-maven.xml"},
+		Vulnerability: &Vulnerability{ID: "GHSA-jfh8-c2jp-5v3q", Aliases: []string{"CVE-2021-44228"}, Summary: "Remote code injection in Log4j"},
+		Severity:      SeverityCritical,
+		Score:         10,
+		FixedVersions: []string{"2.15.0"},
+	},
+	{
+		Package:       Package{Name: "lodash", Version: "4.17.20", Ecosystem: EcosystemNpm, Source: "package-lock.json"},
+		Vulnerability: &Vulnerability{ID: "GHSA-35jh-r3h4-6jhm", Aliases: []string{"CVE-2021-23337"}},
+		Severity:      SeverityHigh,
+		Score:         7.2,
+	},
+}
+
+func TestCreateCustomReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("violations", func(t *testing.T) {
+		report := CreateCustomReport("osv-db", make([]Package, 5), testFindings, []string{"critical"})
+
+		assert.Equal(t, "OSV Vulnerability Report", report.ReportTitle)
+		assert.False(t, report.SuccessfulScan)
+		assert.Equal(t, reporting.OverviewRow{Description: "Scanned packages", Details: "5"}, report.Overview[0])
+		assert.Equal(t, reporting.OverviewRow{Description: "Vulnerabilities with severity CRITICAL", Details: "1", Style: reporting.Red}, report.Overview[2])
+		assert.Equal(t, reporting.OverviewRow{Description: "Vulnerabilities with severity HIGH", Details: "1"}, report.Overview[3])
+		require.Len(t, report.DetailTable.Rows, 2)
+		assert.Equal(t, `<a href="https://osv.dev/vulnerability/GHSA-jfh8-c2jp-5v3q">GHSA-jfh8-c2jp-5v3q</a>`, report.DetailTable.Rows[0].Columns[0].Content)
+		assert.Equal(t, reporting.ColumnStyle(reporting.Red), report.DetailTable.Rows[0].Columns[2].Style)
+		assert.Equal(t, reporting.ColumnStyle(reporting.Yellow), report.DetailTable.Rows[1].Columns[2].Style)
+	})
+
+	t.Run("no violations", func(t *testing.T) {
+		report := CreateCustomReport("osv-db", nil, testFindings[1:], []string{"CRITICAL"})
+		assert.True(t, report.SuccessfulScan)
+	})
+}
+
+func TestWriteCustomReports(t *testing.T) {
+	t.Parallel()
+
+	utils := &mock.FilesMock{}
+	report := CreateCustomReport("osv-db", nil, testFindings, nil)
+
+	paths, err := WriteCustomReports(report, "osv-db", utils)
+
+	assert.NoError(t, err)
+	assert.Len(t, paths, 1)
+	assert.True(t, utils.HasWrittenFile(filepath.Join(ReportsDirectory, "piper_osv_vulnerability_report.html")))
+	stepReports, err := utils.Glob(filepath.Join(reporting.StepReportDirectory, "osvExecuteScan_oss_*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, stepReports, 1)
+}
+
+func TestCreateSarifResultFile(t *testing.T) {
+	t.Parallel()
+
+	findings := append(testFindings, Finding{
+		Package:       Package{Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.0", Ecosystem: EcosystemMaven, Source: "pom.xml"},
+		Vulnerability: testFindings[0].Vulnerability,
+		Severity:      SeverityCritical,
+	})
+	sarif := CreateSarifResultFile(findings)
+
+	require.Len(t, sarif.Runs, 1)
+	assert.Len(t, sarif.Runs[0].Tool.Driver.Rules, 2)
+	require.Len(t, sarif.Runs[0].Results, 3)
+	assert.Equal(t, 0, sarif.Runs[0].Results[2].RuleIndex)
+	assert.Equal(t, "error", sarif.Runs[0].Results[0].Level)
+	assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "target/bom-maven.xml", sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	utils := &mock.FilesMock{}
+	paths, err := WriteSarifFile(sarif, utils)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(ReportsDirectory, "piper_osv_vulnerability.sarif"), paths[0].Target)
+	content, err := utils.FileRead(paths[0].Target)
+	require.NoError(t, err)
+	var written format.SARIF
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Len(t, written.Runs[0].Results, 3)
+}
@@ -0,0 +1,170 @@
+package osv
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+)
+
+// Qualitative severities of a finding
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+// Severities lists all severities ordered from most to least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityUnknown}
+
+// Finding is a vulnerability affecting a package
+type Finding struct {
+	Package       Package
+	Vulnerability *Vulnerability
+	Severity      string
+	// Score is the CVSS v3 base score, 0 if the database does not provide a vector
+	Score         float64
+	FixedVersions []string
+}
+
+// IDs returns the OSV identifier of the vulnerability followed by its aliases
+func (f Finding) IDs() []string {
+	return append([]string{f.Vulnerability.ID}, f.Vulnerability.Aliases...)
+}
+
+// Matches checks whether the vulnerability is referenced by one of the given identifiers
+func (f Finding) Matches(ids []string) bool {
+	for _, id := range f.IDs() {
+		if piperutils.ContainsString(ids, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// URL returns the advisory link of the vulnerability
+func (f Finding) URL() string {
+	for _, reference := range f.Vulnerability.References {
+		if reference.Type == "ADVISORY" {
+			return reference.URL
+		}
+	}
+	return "https://osv.dev/vulnerability/" + f.Vulnerability.ID
+}
+
+// Scan matches the packages against the database and returns the findings ordered by severity
+func (db *Database) Scan(packages []Package) []Finding {
+	findings := []Finding{}
+	for _, p := range packages {
+		for _, vulnerability := range db.lookup(p.Ecosystem, p.Name) {
+			for _, affected := range vulnerability.Affected {
+				if packageKey(affected.Package.Ecosystem, affected.Package.Name) != packageKey(p.Ecosystem, p.Name) {
+					continue
+				}
+				if !isAffected(p.Ecosystem, p.Version, affected) {
+					continue
+				}
+				severity, score := severityOf(vulnerability, affected)
+				findings = append(findings, Finding{
+					Package:       p,
+					Vulnerability: vulnerability,
+					Severity:      severity,
+					Score:         score,
+					FixedVersions: fixedVersions(affected),
+				})
+				break
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return severityIndex(findings[i].Severity) < severityIndex(findings[j].Severity)
+		}
+		return findings[i].Score > findings[j].Score
+	})
+	return findings
+}
+
+// CountBySeverity returns the number of findings per severity
+func CountBySeverity(findings []Finding) map[string]int {
+	counts := map[string]int{}
+	for _, severity := range Severities {
+		counts[severity] = 0
+	}
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	return counts
+}
+
+// IsSevere checks whether the severity is contained in the list of severities, ignoring the case
+func IsSevere(severity string, severities []string) bool {
+	for _, s := range severities {
+		if strings.EqualFold(s, severity) {
+			return true
+		}
+	}
+	return false
+}
+
+// SevereFindings returns the findings with one of the given severities
+func SevereFindings(findings []Finding, severities []string) []Finding {
+	severe := []Finding{}
+	for _, finding := range findings {
+		if IsSevere(finding.Severity, severities) {
+			severe = append(severe, finding)
+		}
+	}
+	return severe
+}
+
+func severityIndex(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(Severities)
+}
+
+func severityOf(vulnerability *Vulnerability, affected Affected) (string, float64) {
+	score := 0.0
+	severities := []Severity{}
+	severities = append(severities, affected.Severity...)
+	severities = append(severities, vulnerability.Severity...)
+	for _, severity := range severities {
+		if severity.Type != "CVSS_V3" {
+			continue
+		}
+		if s, err := cvss3BaseScore(severity.Score); err == nil {
+			score = s
+			break
+		}
+	}
+	// database specific ratings (e.g. GitHub advisories) take precedence over the computed rating
+	for _, specific := range []map[string]interface{}{affected.DatabaseSpecific, vulnerability.DatabaseSpecific} {
+		if rating, ok := specific["severity"].(string); ok {
+			switch rating = strings.ToUpper(rating); rating {
+			case "MODERATE":
+				return SeverityMedium, score
+			case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow:
+				return rating, score
+			}
+		}
+	}
+	return severityFromScore(score), score
+}
+
+func fixedVersions(affected Affected) []string {
+	fixed := []string{}
+	for _, r := range affected.Ranges {
+		for _, event := range r.Events {
+			if len(event.Fixed) > 0 && r.Type != "GIT" {
+				fixed = append(fixed, event.Fixed)
+			}
+		}
+	}
+	return fixed
+}
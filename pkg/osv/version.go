@@ -0,0 +1,238 @@
+package osv
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/mod/semver"
+)
+
+// qualifierRanks orders well-known pre- and post-release qualifiers of Maven and PyPI versions
+var qualifierRanks = map[string]int{
+	"dev":       0,
+	"alpha":     1,
+	"a":         1,
+	"beta":      2,
+	"b":         2,
+	"milestone": 3,
+	"m":         3,
+	"rc":        4,
+	"cr":        4,
+	"c":         4,
+	"pre":       4,
+	"preview":   4,
+	"snapshot":  5,
+	"":          6,
+	"final":     6,
+	"ga":        6,
+	"release":   6,
+	"sp":        7,
+	"post":      7,
+}
+
+// compareVersions compares two versions of the given ecosystem and returns -1, 0 or +1
+func compareVersions(ecosystem, a, b string) int {
+	switch baseEcosystem(ecosystem) {
+	case EcosystemDebian, EcosystemUbuntu:
+		return compareDebian(a, b)
+	case EcosystemAlpine:
+		return compareAlpine(a, b)
+	case EcosystemMariner, EcosystemAzureLinux, EcosystemRocky, EcosystemAlma:
+		return compareRPM(a, b)
+	case EcosystemGo, EcosystemNpm, EcosystemCrates:
+		va, vb := "v"+strings.TrimPrefix(a, "v"), "v"+strings.TrimPrefix(b, "v")
+		if semver.IsValid(va) && semver.IsValid(vb) {
+			return semver.Compare(va, vb)
+		}
+	}
+	return compareGeneric(a, b)
+}
+
+// compareGeneric compares versions by splitting them into numeric and textual tokens.
+// It follows the ordering rules of Maven's ComparableVersion closely enough to be used for
+// Maven, PyPI and other ecosystems which do not strictly follow semantic versioning.
+func compareGeneric(a, b string) int {
+	ta, tb := tokenize(a), tokenize(b)
+	for i := 0; i < len(ta) || i < len(tb); i++ {
+		var x, y versionToken
+		if i < len(ta) {
+			x = ta[i]
+		} else {
+			x = padding(tb[i])
+		}
+		if i < len(tb) {
+			y = tb[i]
+		} else {
+			y = padding(ta[i])
+		}
+		if c := x.compare(y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+type versionToken struct {
+	numeric bool
+	number  *big.Int
+	text    string
+}
+
+// padding returns the token a missing position is compared as, so that e.g. "1.0" equals "1.0.0"
+// and "1.0-rc1" is lower than "1.0"
+func padding(other versionToken) versionToken {
+	if other.numeric {
+		return versionToken{numeric: true, number: big.NewInt(0)}
+	}
+	return versionToken{}
+}
+
+func (t versionToken) compare(other versionToken) int {
+	switch {
+	case t.numeric && other.numeric:
+		return t.number.Cmp(other.number)
+	case t.numeric:
+		// a number is newer than any qualifier except post releases
+		if qualifierRank(other.text) > qualifierRanks[""] {
+			return -1
+		}
+		return 1
+	case other.numeric:
+		return -other.compare(t)
+	}
+	ra, rb := qualifierRank(t.text), qualifierRank(other.text)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(t.text, other.text)
+}
+
+func qualifierRank(qualifier string) int {
+	if rank, ok := qualifierRanks[qualifier]; ok {
+		return rank
+	}
+	// unknown qualifiers are newer than releases
+	return len(qualifierRanks)
+}
+
+func tokenize(version string) []versionToken {
+	version = strings.ToLower(strings.TrimPrefix(strings.SplitN(version, "+", 2)[0], "v"))
+	tokens := []versionToken{}
+	current := []rune{}
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		value := string(current)
+		if unicode.IsDigit(current[0]) {
+			number, _ := new(big.Int).SetString(value, 10)
+			tokens = append(tokens, versionToken{numeric: true, number: number})
+		} else {
+			tokens = append(tokens, versionToken{text: value})
+		}
+		current = current[:0]
+	}
+	for _, r := range version {
+		switch {
+		case r == '.' || r == '-' || r == '_':
+			flush()
+		case len(current) > 0 && unicode.IsDigit(r) != unicode.IsDigit(current[0]):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	// trailing zeros and release qualifiers do not change the version
+	for len(tokens) > 0 {
+		last := tokens[len(tokens)-1]
+		if (last.numeric && last.number.Sign() == 0) || (!last.numeric && qualifierRank(last.text) == qualifierRanks[""]) {
+			tokens = tokens[:len(tokens)-1]
+			continue
+		}
+		break
+	}
+	return tokens
+}
+
+// isAffected checks whether a version is affected according to the given OSV affected entry
+func isAffected(ecosystem, version string, affected Affected) bool {
+	for _, v := range affected.Versions {
+		if v == version || compareVersions(ecosystem, v, version) == 0 {
+			return true
+		}
+	}
+	for _, r := range affected.Ranges {
+		if r.Type == "GIT" {
+			// git ranges can not be evaluated without the repository history, the versions list covers them
+			continue
+		}
+		if inRange(ecosystem, version, r.Events) {
+			return true
+		}
+	}
+	return false
+}
+
+func inRange(ecosystem, version string, events []Event) bool {
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareEvents(ecosystem, sorted[i], sorted[j]) < 0
+	})
+
+	affected := false
+	for _, event := range sorted {
+		switch {
+		case len(event.Introduced) > 0:
+			if event.Introduced == "0" || compareVersions(ecosystem, version, event.Introduced) >= 0 {
+				affected = true
+			}
+		case len(event.Fixed) > 0:
+			if compareVersions(ecosystem, version, event.Fixed) >= 0 {
+				affected = false
+			}
+		case len(event.LastAffected) > 0:
+			if compareVersions(ecosystem, version, event.LastAffected) > 0 {
+				affected = false
+			}
+		case len(event.Limit) > 0:
+			if compareVersions(ecosystem, version, event.Limit) >= 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func compareEvents(ecosystem string, a, b Event) int {
+	va, vb := eventVersion(a), eventVersion(b)
+	if va == vb {
+		return 0
+	}
+	if va == "0" && len(a.Introduced) > 0 {
+		return -1
+	}
+	if vb == "0" && len(b.Introduced) > 0 {
+		return 1
+	}
+	return compareVersions(ecosystem, va, vb)
+}
+
+func eventVersion(event Event) string {
+	switch {
+	case len(event.Introduced) > 0:
+		return event.Introduced
+	case len(event.Fixed) > 0:
+		return event.Fixed
+	case len(event.LastAffected) > 0:
+		return event.LastAffected
+	}
+	return event.Limit
+}
@@ -0,0 +1,99 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ecosystem string
+		a, b      string
+		expected  int
+	}{
+		{EcosystemNpm, "4.17.20", "4.17.21", -1},
+		{EcosystemNpm, "1.0.0-beta.2", "1.0.0", -1},
+		{EcosystemGo, "v0.0.0-20220412211240-33da011f77ad", "0.0.0-20220412211240-33da011f77ad", 0},
+		{EcosystemGo, "v0.1.0", "0.0.0-20220412211240-33da011f77ad", 1},
+		{EcosystemMaven, "2.0-beta9", "2.0", -1},
+		{EcosystemMaven, "2.0", "2.0.0", 0},
+		{EcosystemMaven, "2.3.1", "2.3", 1},
+		{EcosystemMaven, "2.3-rc1", "2.3-beta2", 1},
+		{EcosystemMaven, "1.0-SNAPSHOT", "1.0", -1},
+		{EcosystemMaven, "5.3.10.RELEASE", "5.3.10", 0},
+		{EcosystemMaven, "1.0-sp1", "1.0", 1},
+		{EcosystemPyPI, "1.0a1", "1.0", -1},
+		{EcosystemPyPI, "1.0.post1", "1.0", 1},
+		{EcosystemPyPI, "1.0.dev1", "1.0a1", -1},
+		{EcosystemPyPI, "10.0", "9.9.9", 1},
+		{"Debian:12", "1.2.3-1", "1.2.3-1+deb12u1", -1},
+		{"Debian:12", "1:1.0-1", "2.0-1", 1},
+		{"Debian:12", "1.0~rc1-1", "1.0-1", -1},
+		{EcosystemUbuntu, "2.35-0ubuntu3.1", "2.35-0ubuntu3.10", -1},
+		{"Alpine:v3.19", "3.1.4-r5", "3.1.4-r10", -1},
+		{"Alpine:v3.19", "1.36.1_rc1-r0", "1.36.1-r0", -1},
+		{"Alpine:v3.19", "9.6_p1-r0", "9.6-r0", 1},
+		{"Mariner:2.0", "1.1.1k-26.cm2", "1.1.1k-27.cm2", -1},
+		{"Rocky Linux:9", "2:8.2.2637-20.el9_1", "8.2.4-1.el9", 1},
+		{"Rocky Linux:9", "1.0~beta-1", "1.0-1", -1},
+		{"Rocky Linux:9", "1.0^git1-1", "1.0-1", 1},
+		{"Rocky Linux:9", "1.0a-1", "1.0.1-1", -1},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v %v", test.ecosystem, test.a, test.b), func(t *testing.T) {
+			assert.Equal(t, test.expected, compareVersions(test.ecosystem, test.a, test.b))
+			assert.Equal(t, -test.expected, compareVersions(test.ecosystem, test.b, test.a))
+		})
+	}
+}
+
+func TestIsAffected(t *testing.T) {
+	t.Parallel()
+
+	affected := Affected{
+		Ranges: []Range{
+			{Type: "ECOSYSTEM", Events: []Event{{Introduced: "1.0"}, {LastAffected: "1.2"}, {Introduced: "2.0"}, {Fixed: "2.1"}}},
+			{Type: "GIT", Events: []Event{{Introduced: "abc"}}},
+		},
+		Versions: []string{"0.9"},
+	}
+	for version, expected := range map[string]bool{
+		"0.8":   false,
+		"0.9":   true,
+		"1.0":   true,
+		"1.2":   true,
+		"1.2.1": false,
+		"2.0.5": true,
+		"2.1":   false,
+	} {
+		assert.Equal(t, expected, isAffected(EcosystemMaven, version, affected), version)
+	}
+}
+
+func TestCvss3BaseScore(t *testing.T) {
+	t.Parallel()
+
+	for vector, expected := range map[string]float64{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H": 10.0,
+		"CVSS:3.0/AV:L/AC:H/PR:L/UI:R/S:U/C:L/I:N/A:N": 2.2,
+		"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N": 6.4,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N": 0,
+	} {
+		score, err := cvss3BaseScore(vector)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, score, vector)
+	}
+
+	_, err := cvss3BaseScore("AV:N/AC:L/Au:N/C:P/I:P/A:P")
+	assert.EqualError(t, err, "unsupported CVSS vector 'AV:N/AC:L/Au:N/C:P/I:P/A:P'")
+	_, err = cvss3BaseScore("CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+	assert.EqualError(t, err, "invalid value for metric 'AV' in CVSS vector 'CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H'")
+}
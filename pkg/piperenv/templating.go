@@ -48,6 +48,37 @@ func (c *CPEMap) ParseTemplateWithDelimiter(cpeTemplate string, startDelimiter s
 	return &generated, nil
 }
 
+// LookupTemplateValue evaluates the template function with the given name (cpe, cpecustom, git, imageDigest, imageTag)
+// for a single argument and reports whether the CPE actually holds a value for it.
+func (c *CPEMap) LookupTemplateValue(function, element string) (string, bool) {
+	var key string
+	switch function {
+	case "cpe":
+		key = element
+	case "cpecustom":
+		key = fmt.Sprintf("custom/%v", element)
+	case "git":
+		if element == "organization" || element == "repository" {
+			key = fmt.Sprintf("github/%v", element)
+		} else {
+			key = fmt.Sprintf("git/%v", element)
+		}
+	case "imageDigest":
+		value := c.imageDigest(element)
+		return value, len(value) > 0
+	case "imageTag":
+		value := c.imageTag(element)
+		return value, len(value) > 0
+	default:
+		return "", false
+	}
+	value, ok := map[string]interface{}(*c)[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
 func (c *CPEMap) cpe(element string) string {
 	// ToDo: perform validity checks to allow only selected fields for now?
 	// This would allow a stable contract and could perform conversions in case a contract changes.
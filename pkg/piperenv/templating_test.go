@@ -249,3 +249,38 @@ func TestTemplateFunctionImageTag(t *testing.T) {
 		assert.Equal(t, "tag2", (*res).String())
 	})
 }
+
+func TestLookupTemplateValue(t *testing.T) {
+	cpe := CPEMap{
+		"artifactVersion":         "1.2.3",
+		"git/commitId":            "thisIsMyTestSha",
+		"github/repository":       "testRepo",
+		"custom/myKey":            "myValue",
+		"container/imageNames":    []interface{}{"image1"},
+		"container/imageDigests":  []interface{}{"sha256:abc"},
+		"container/imageNameTags": []interface{}{"image1:1.2.3"},
+	}
+
+	tt := []struct {
+		function string
+		element  string
+		expected string
+		found    bool
+	}{
+		{function: "cpe", element: "artifactVersion", expected: "1.2.3", found: true},
+		{function: "cpecustom", element: "myKey", expected: "myValue", found: true},
+		{function: "git", element: "commitId", expected: "thisIsMyTestSha", found: true},
+		{function: "git", element: "repository", expected: "testRepo", found: true},
+		{function: "imageDigest", element: "image1", expected: "sha256:abc", found: true},
+		{function: "imageTag", element: "image1", expected: "1.2.3", found: true},
+		{function: "cpe", element: "notThere"},
+		{function: "imageTag", element: "image2"},
+		{function: "unknown", element: "artifactVersion"},
+	}
+
+	for _, test := range tt {
+		value, found := cpe.LookupTemplateValue(test.function, test.element)
+		assert.Equal(t, test.found, found, fmt.Sprintf("%v(%v)", test.function, test.element))
+		assert.Equal(t, test.expected, value, fmt.Sprintf("%v(%v)", test.function, test.element))
+	}
+}
@@ -0,0 +1,252 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// builtinRule is a rule implemented in Go
+type builtinRule struct {
+	metadata RuleMetadata
+	check    func(rule RuleMetadata, resource Resource) []Violation
+}
+
+func (r builtinRule) Metadata() RuleMetadata {
+	return r.metadata
+}
+
+func (r builtinRule) Evaluate(ctx context.Context, resource Resource) ([]Violation, error) {
+	return r.check(r.metadata, resource), nil
+}
+
+// BuiltinRules returns the rules which are evaluated unless they are disabled
+func BuiltinRules() []Rule {
+	return []Rule{
+		builtinRule{
+			metadata: RuleMetadata{ID: "k8s-privileged-container", Description: "Containers must not run privileged", Severity: SeverityError},
+			check: forEachContainer(func(container map[string]interface{}) string {
+				if privileged, _ := lookup(container, "securityContext", "privileged").(bool); privileged {
+					return "runs privileged"
+				}
+				return ""
+			}),
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "k8s-resource-limits", Description: "Containers must define CPU and memory limits", Severity: SeverityError},
+			check: forEachContainer(func(container map[string]interface{}) string {
+				missing := []string{}
+				for _, resource := range []string{"cpu", "memory"} {
+					if lookup(container, "resources", "limits", resource) == nil {
+						missing = append(missing, resource)
+					}
+				}
+				if len(missing) > 0 {
+					return fmt.Sprintf("has no %v limit", strings.Join(missing, " and "))
+				}
+				return ""
+			}),
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "k8s-image-latest-tag", Description: "Container images must not use the latest tag", Severity: SeverityError},
+			check: forEachContainer(func(container map[string]interface{}) string {
+				if image := stringValue(container, "image"); usesLatestTag(image) {
+					return fmt.Sprintf("uses the image '%v' without a fixed tag", image)
+				}
+				return ""
+			}),
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "k8s-image-digest", Description: "Container images should be pinned by digest", Severity: SeverityWarning},
+			check: forEachContainer(func(container map[string]interface{}) string {
+				if image := stringValue(container, "image"); !pinnedByDigest(image) {
+					return fmt.Sprintf("uses the image '%v' which is not pinned by digest", image)
+				}
+				return ""
+			}),
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "k8s-host-namespaces", Description: "Pods must not share the network, PID or IPC namespace of the host", Severity: SeverityError},
+			check: func(rule RuleMetadata, resource Resource) []Violation {
+				spec := podSpec(resource)
+				if spec == nil {
+					return nil
+				}
+				shared := []string{}
+				for _, field := range []string{"hostNetwork", "hostPID", "hostIPC"} {
+					if enabled, _ := spec[field].(bool); enabled {
+						shared = append(shared, field)
+					}
+				}
+				if len(shared) == 0 {
+					return nil
+				}
+				return []Violation{rule.violation(resource, 0, fmt.Sprintf("%v enables %v", resource.ID(), strings.Join(shared, ", ")))}
+			},
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "dockerfile-image-latest-tag", Description: "Base images must not use the latest tag", Severity: SeverityError},
+			check: forEachBaseImage(func(image string) string {
+				if usesLatestTag(image) {
+					return fmt.Sprintf("uses the base image '%v' without a fixed tag", image)
+				}
+				return ""
+			}),
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "dockerfile-image-digest", Description: "Base images should be pinned by digest", Severity: SeverityWarning},
+			check: forEachBaseImage(func(image string) string {
+				if !pinnedByDigest(image) {
+					return fmt.Sprintf("uses the base image '%v' which is not pinned by digest", image)
+				}
+				return ""
+			}),
+		},
+		builtinRule{
+			metadata: RuleMetadata{ID: "dockerfile-root-user", Description: "Images should not run as root", Severity: SeverityWarning},
+			check:    checkRootUser,
+		},
+	}
+}
+
+func (r RuleMetadata) violation(resource Resource, line int, message string) Violation {
+	return Violation{RuleID: r.ID, Severity: r.Severity, Message: message, Resource: resource, Line: line}
+}
+
+// forEachContainer applies the check to all containers and init containers of workloads, a non-empty result is reported as violation
+func forEachContainer(check func(container map[string]interface{}) string) func(RuleMetadata, Resource) []Violation {
+	return func(rule RuleMetadata, resource Resource) []Violation {
+		spec := podSpec(resource)
+		if spec == nil {
+			return nil
+		}
+		violations := []Violation{}
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _ := spec[field].([]interface{})
+			for _, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if message := check(container); len(message) > 0 {
+					violations = append(violations, rule.violation(resource, 0, fmt.Sprintf("Container '%v' of %v %v", stringValue(container, "name"), resource.ID(), message)))
+				}
+			}
+		}
+		return violations
+	}
+}
+
+// podSpec returns the pod spec of pods and workload resources, or nil for other resources
+func podSpec(resource Resource) map[string]interface{} {
+	var spec interface{}
+	switch resource.Kind {
+	case "Pod":
+		spec = lookup(resource.Object, "spec")
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController", "Job":
+		spec = lookup(resource.Object, "spec", "template", "spec")
+	case "CronJob":
+		spec = lookup(resource.Object, "spec", "jobTemplate", "spec", "template", "spec")
+	}
+	podSpec, _ := spec.(map[string]interface{})
+	return podSpec
+}
+
+type instruction struct {
+	cmd   string
+	value []string
+	line  int
+}
+
+func dockerfileInstructions(resource Resource) []instruction {
+	if resource.Kind != KindDockerfile {
+		return nil
+	}
+	instructions := []instruction{}
+	entries, _ := resource.Object["instructions"].([]interface{})
+	for _, entry := range entries {
+		i, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		values := []string{}
+		list, _ := i["value"].([]interface{})
+		for _, v := range list {
+			values = append(values, fmt.Sprint(v))
+		}
+		line, _ := i["line"].(float64)
+		instructions = append(instructions, instruction{cmd: stringValue(i, "cmd"), value: values, line: int(line)})
+	}
+	return instructions
+}
+
+// forEachBaseImage applies the check to the base images of all stages, references to previous stages, scratch and
+// images defined via build arguments are skipped
+func forEachBaseImage(check func(image string) string) func(RuleMetadata, Resource) []Violation {
+	return func(rule RuleMetadata, resource Resource) []Violation {
+		violations := []Violation{}
+		stages := map[string]bool{}
+		for _, i := range dockerfileInstructions(resource) {
+			if i.cmd != "from" || len(i.value) == 0 {
+				continue
+			}
+			image := i.value[0]
+			if len(i.value) == 3 && strings.EqualFold(i.value[1], "as") {
+				stages[strings.ToLower(i.value[2])] = true
+			}
+			if image == "scratch" || stages[strings.ToLower(image)] || strings.Contains(image, "$") {
+				continue
+			}
+			if message := check(image); len(message) > 0 {
+				violations = append(violations, rule.violation(resource, i.line, fmt.Sprintf("%v %v", resource.File, message)))
+			}
+		}
+		return violations
+	}
+}
+
+// checkRootUser reports images whose final stage does not switch to a user other than root
+func checkRootUser(rule RuleMetadata, resource Resource) []Violation {
+	if resource.Kind != KindDockerfile {
+		return nil
+	}
+	user, line := "", 0
+	for _, i := range dockerfileInstructions(resource) {
+		switch i.cmd {
+		case "from":
+			user, line = "", i.line
+		case "user":
+			if len(i.value) > 0 {
+				user, line = i.value[0], i.line
+			}
+		}
+	}
+	if line == 0 {
+		return nil
+	}
+	name := strings.SplitN(user, ":", 2)[0]
+	switch name {
+	case "":
+		return []Violation{rule.violation(resource, line, fmt.Sprintf("%v does not switch to a non-root user", resource.File))}
+	case "root", "0":
+		return []Violation{rule.violation(resource, line, fmt.Sprintf("%v runs as root", resource.File))}
+	}
+	return nil
+}
+
+// usesLatestTag checks whether the image is referenced without tag and digest or with the tag latest
+func usesLatestTag(image string) bool {
+	if len(image) == 0 || pinnedByDigest(image) {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	tag := ""
+	if index := strings.LastIndex(name, ":"); index >= 0 {
+		tag = name[index+1:]
+	}
+	return tag == "" || tag == "latest"
+}
+
+func pinnedByDigest(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
@@ -0,0 +1,103 @@
+//go:build unit
+// +build unit
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const insecureDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: legacy
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      initContainers:
+        - name: init
+          image: busybox@sha256:7b3ccabffc97de872a30dfd234fd972a66d247c8cfc69b0550f276481852627c
+          resources:
+            limits:
+              cpu: 100m
+              memory: 64Mi
+      containers:
+        - name: app
+          image: registry.example.com:5000/legacy
+          securityContext:
+            privileged: true
+          resources:
+            limits:
+              memory: 1Gi
+`
+
+func evaluateBuiltin(t *testing.T, resources []Resource) map[string][]string {
+	violations, err := Evaluate(context.Background(), BuiltinRules(), resources, nil)
+	assert.NoError(t, err)
+	messages := map[string][]string{}
+	for _, violation := range violations {
+		messages[violation.RuleID] = append(messages[violation.RuleID], violation.Message)
+	}
+	return messages
+}
+
+func TestBuiltinRules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("workloads", func(t *testing.T) {
+		t.Parallel()
+		resources, err := LoadManifests("deployment.yaml", []byte(insecureDeployment))
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"k8s-privileged-container": {"Container 'app' of Deployment/legacy runs privileged"},
+			"k8s-resource-limits":      {"Container 'app' of Deployment/legacy has no cpu limit"},
+			"k8s-image-latest-tag":     {"Container 'app' of Deployment/legacy uses the image 'registry.example.com:5000/legacy' without a fixed tag"},
+			"k8s-image-digest":         {"Container 'app' of Deployment/legacy uses the image 'registry.example.com:5000/legacy' which is not pinned by digest"},
+			"k8s-host-namespaces":      {"Deployment/legacy enables hostNetwork"},
+		}, evaluateBuiltin(t, resources))
+	})
+
+	t.Run("other resources are ignored", func(t *testing.T) {
+		t.Parallel()
+		resources, err := LoadManifests("service.yaml", []byte("kind: Service\nmetadata:\n  name: app\n"))
+		assert.NoError(t, err)
+
+		assert.Empty(t, evaluateBuiltin(t, resources))
+	})
+
+	t.Run("Dockerfile", func(t *testing.T) {
+		t.Parallel()
+		resource, err := LoadDockerfile("Dockerfile", []byte("ARG BASE=alpine:3.19\nFROM golang:latest AS build\nFROM build AS test\nFROM ${BASE}\nUSER root\n"))
+		assert.NoError(t, err)
+
+		violations, err := Evaluate(context.Background(), BuiltinRules(), []Resource{resource}, []string{"dockerfile-image-digest"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, violations, 2) {
+			assert.Equal(t, Violation{RuleID: "dockerfile-image-latest-tag", Severity: SeverityError, Message: "Dockerfile uses the base image 'golang:latest' without a fixed tag", Resource: resource, Line: 2}, violations[0])
+			assert.Equal(t, Violation{RuleID: "dockerfile-root-user", Severity: SeverityWarning, Message: "Dockerfile runs as root", Resource: resource, Line: 5}, violations[1])
+		}
+	})
+
+	t.Run("compliant Dockerfile", func(t *testing.T) {
+		t.Parallel()
+		resource, err := LoadDockerfile("Dockerfile", []byte("FROM alpine:3.19@sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b\nUSER nobody:nogroup\n"))
+		assert.NoError(t, err)
+
+		assert.Empty(t, evaluateBuiltin(t, []Resource{resource}))
+	})
+}
+
+func TestUsesLatestTag(t *testing.T) {
+	t.Parallel()
+	assert.True(t, usesLatestTag("nginx"))
+	assert.True(t, usesLatestTag("nginx:latest"))
+	assert.True(t, usesLatestTag("localhost:5000/nginx"))
+	assert.False(t, usesLatestTag("localhost:5000/nginx:1.25"))
+	assert.False(t, usesLatestTag("nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"))
+}
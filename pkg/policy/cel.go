@@ -0,0 +1,118 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+)
+
+// CELRuleDefinition defines a rule as CEL expression on the variable 'object', which contains the resource object.
+// Resources for which the expression does not evaluate to true violate the rule.
+type CELRuleDefinition struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	// Match restricts the rule to the resources for which this expression evaluates to true
+	Match      string `json:"match,omitempty"`
+	Expression string `json:"expression"`
+	Message    string `json:"message,omitempty"`
+}
+
+type celRuleFile struct {
+	Rules []CELRuleDefinition `json:"rules"`
+}
+
+type celRule struct {
+	definition CELRuleDefinition
+	match      cel.Program
+	expression cel.Program
+}
+
+// CELRules compiles the rules of a YAML file with the list 'rules' of rule definitions
+func CELRules(file string, content []byte) ([]Rule, error) {
+	definitions := celRuleFile{}
+	if err := yaml.Unmarshal(content, &definitions); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse CEL rules %v", file)
+	}
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	rules := []Rule{}
+	for _, definition := range definitions.Rules {
+		if len(definition.ID) == 0 || len(definition.Expression) == 0 {
+			return nil, fmt.Errorf("rules in %v require an id and an expression", file)
+		}
+		switch definition.Severity {
+		case "":
+			definition.Severity = SeverityError
+		case SeverityError, SeverityWarning:
+		default:
+			return nil, fmt.Errorf("invalid severity '%v' of rule %v, allowed values are '%v' and '%v'", definition.Severity, definition.ID, SeverityError, SeverityWarning)
+		}
+		rule := celRule{definition: definition}
+		if rule.expression, err = compileCEL(env, definition.Expression); err != nil {
+			return nil, errors.Wrapf(err, "failed to compile the expression of rule %v", definition.ID)
+		}
+		if len(definition.Match) > 0 {
+			if rule.match, err = compileCEL(env, definition.Match); err != nil {
+				return nil, errors.Wrapf(err, "failed to compile the match expression of rule %v", definition.ID)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func compileCEL(env *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	return env.Program(ast)
+}
+
+func (r celRule) Metadata() RuleMetadata {
+	return RuleMetadata{ID: r.definition.ID, Description: r.definition.Description, Severity: r.definition.Severity}
+}
+
+// Evaluate reports a violation if the expression is not true. Errors, e.g. due to missing fields, are violations as well,
+// use has() to check optional fields. Resources for which the match expression fails are skipped.
+func (r celRule) Evaluate(ctx context.Context, resource Resource) ([]Violation, error) {
+	input := map[string]interface{}{"object": resource.Object}
+	if r.match != nil {
+		if matched, err := evalCEL(r.match, input); err != nil || !matched {
+			return nil, nil
+		}
+	}
+	compliant, err := evalCEL(r.expression, input)
+	if compliant {
+		return nil, nil
+	}
+	message := r.definition.Message
+	if len(message) == 0 {
+		message = r.definition.Description
+	}
+	if len(message) == 0 {
+		message = fmt.Sprintf("violates %v", r.definition.ID)
+	}
+	if err != nil {
+		message = fmt.Sprintf("%v (%v)", message, err)
+	}
+	return []Violation{r.Metadata().violation(resource, 0, fmt.Sprintf("%v: %v", resource.ID(), message))}, nil
+}
+
+func evalCEL(program cel.Program, input map[string]interface{}) (bool, error) {
+	value, _, err := program.Eval(input)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %v instead of a boolean", value.Value())
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+//go:build unit
+// +build unit
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const celRulesFile = `rules:
+  - id: team-label
+    description: Workloads need a team label
+    match: object.kind in ["Deployment", "StatefulSet"]
+    expression: has(object.metadata.labels) && "team" in object.metadata.labels
+    message: the team label is missing
+  - id: replicas
+    severity: warning
+    match: object.kind == "Deployment"
+    expression: object.spec.replicas >= 2
+`
+
+func TestCELRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("evaluate", func(t *testing.T) {
+		t.Parallel()
+		rules, err := CELRules("rules.yaml", []byte(celRulesFile))
+		assert.NoError(t, err)
+		resources, err := LoadManifests("manifests.yaml", []byte("kind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 1\n---\nkind: StatefulSet\nmetadata:\n  name: db\n  labels:\n    team: data\n---\nkind: Service\nmetadata:\n  name: app\n"))
+		assert.NoError(t, err)
+
+		violations, err := Evaluate(ctx, rules, resources, nil)
+
+		assert.NoError(t, err)
+		if assert.Len(t, violations, 2) {
+			assert.Equal(t, Violation{RuleID: "team-label", Severity: SeverityError, Message: "Deployment/app: the team label is missing", Resource: resources[0]}, violations[0])
+			assert.Equal(t, "replicas", violations[1].RuleID)
+			assert.Equal(t, SeverityWarning, violations[1].Severity)
+			assert.Equal(t, "Deployment/app: violates replicas", violations[1].Message)
+		}
+	})
+
+	t.Run("missing fields are violations", func(t *testing.T) {
+		t.Parallel()
+		rules, err := CELRules("rules.yaml", []byte(celRulesFile))
+		assert.NoError(t, err)
+		resources, err := LoadManifests("manifests.yaml", []byte("kind: Deployment\nmetadata:\n  name: app\n  labels:\n    team: a\n"))
+		assert.NoError(t, err)
+
+		violations, err := Evaluate(ctx, rules, resources, nil)
+
+		assert.NoError(t, err)
+		if assert.Len(t, violations, 1) {
+			assert.Contains(t, violations[0].Message, "Deployment/app: violates replicas (no such key: spec)")
+		}
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		t.Parallel()
+		_, err := CELRules("rules.yaml", []byte("rules:\n  - id: broken\n    expression: object.kind ==\n"))
+
+		assert.ErrorContains(t, err, "failed to compile the expression of rule broken")
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		t.Parallel()
+		_, err := CELRules("rules.yaml", []byte("rules:\n  - id: broken\n    severity: fatal\n    expression: 'true'\n"))
+
+		assert.EqualError(t, err, "invalid severity 'fatal' of rule broken, allowed values are 'error' and 'warning'")
+	})
+}
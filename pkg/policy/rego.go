@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/pkg/errors"
+)
+
+// regoRule evaluates the rules 'deny' and 'warn' of a Rego package, the resource object is provided as input.
+// Like in conftest the rules produce the violation messages either as strings or as objects with the field 'msg'.
+type regoRule struct {
+	metadata RuleMetadata
+	query    rego.PreparedEvalQuery
+}
+
+// RegoRules compiles the Rego modules and returns one rule per package
+func RegoRules(ctx context.Context, modules map[string][]byte) ([]Rule, error) {
+	packages := map[string]bool{}
+	options := []func(*rego.Rego){}
+	for file, content := range modules {
+		module, err := ast.ParseModule(file, string(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse Rego policy %v", file)
+		}
+		packages[module.Package.Path.String()] = true
+		options = append(options, rego.Module(file, string(content)))
+	}
+	names := []string{}
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rules := []Rule{}
+	for _, name := range names {
+		query, err := rego.New(append(options, rego.Query(name))...).PrepareForEval(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile Rego package %v", name)
+		}
+		id := strings.TrimPrefix(name, "data.")
+		rules = append(rules, regoRule{
+			metadata: RuleMetadata{ID: id, Description: fmt.Sprintf("Rego policy %v", id), Severity: SeverityError},
+			query:    query,
+		})
+	}
+	return rules, nil
+}
+
+func (r regoRule) Metadata() RuleMetadata {
+	return r.metadata
+}
+
+func (r regoRule) Evaluate(ctx context.Context, resource Resource) ([]Violation, error) {
+	results, err := r.query.Eval(ctx, rego.EvalInput(resource.Object))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to evaluate Rego package %v for %v", r.metadata.ID, resource.ID())
+	}
+	violations := []Violation{}
+	for _, result := range results {
+		for _, expression := range result.Expressions {
+			document, _ := expression.Value.(map[string]interface{})
+			for rule, severity := range map[string]string{"deny": SeverityError, "warn": SeverityWarning} {
+				messages, _ := document[rule].([]interface{})
+				for _, message := range messages {
+					violations = append(violations, Violation{
+						RuleID:   r.metadata.ID,
+						Severity: severity,
+						Message:  fmt.Sprintf("%v: %v", resource.ID(), regoMessage(message)),
+						Resource: resource,
+					})
+				}
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Message < violations[j].Message })
+	return violations, nil
+}
+
+func regoMessage(message interface{}) string {
+	if object, ok := message.(map[string]interface{}); ok {
+		if msg, ok := object["msg"]; ok {
+			return fmt.Sprint(msg)
+		}
+	}
+	return fmt.Sprint(message)
+}
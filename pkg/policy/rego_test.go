@@ -0,0 +1,54 @@
+//go:build unit
+// +build unit
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const labelsPolicy = `package kubernetes.labels
+
+deny[msg] {
+	input.kind == "Deployment"
+	not input.metadata.labels.team
+	msg := "the team label is missing"
+}
+
+warn[{"msg": msg}] {
+	input.kind == "Deployment"
+	not input.metadata.labels["app.kubernetes.io/version"]
+	msg := "the version label is missing"
+}
+`
+
+func TestRegoRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("deny and warn", func(t *testing.T) {
+		t.Parallel()
+		rules, err := RegoRules(ctx, map[string][]byte{"policy/labels.rego": []byte(labelsPolicy)})
+		assert.NoError(t, err)
+		resources, err := LoadManifests("deployment.yaml", []byte("kind: Deployment\nmetadata:\n  name: app\n---\nkind: Deployment\nmetadata:\n  name: labelled\n  labels:\n    team: platform\n    app.kubernetes.io/version: '1.0'\n"))
+		assert.NoError(t, err)
+
+		violations, err := Evaluate(ctx, rules, resources, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Violation{
+			{RuleID: "kubernetes.labels", Severity: SeverityError, Message: "Deployment/app: the team label is missing", Resource: resources[0]},
+			{RuleID: "kubernetes.labels", Severity: SeverityWarning, Message: "Deployment/app: the version label is missing", Resource: resources[0]},
+		}, violations)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		t.Parallel()
+		_, err := RegoRules(ctx, map[string][]byte{"broken.rego": []byte("package main\ndeny[msg] {")})
+
+		assert.ErrorContains(t, err, "failed to parse Rego policy broken.rego")
+	})
+}
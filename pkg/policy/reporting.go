@@ -0,0 +1,166 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+// ReportsDirectory defines the subfolder for the policy check reports which are generated
+const ReportsDirectory = "policycheck"
+
+// CreateCustomReport creates a ScanReport of the violations to be used for uploading into various sinks
+func CreateCustomReport(resources []Resource, rules []Rule, violations []Violation) reporting.ScanReport {
+	counts := CountBySeverity(violations)
+
+	scanReport := reporting.ScanReport{
+		ReportTitle: "Manifest Policy Report",
+		Overview: []reporting.OverviewRow{
+			{Description: "Checked resources", Details: fmt.Sprint(len(resources))},
+			{Description: "Rules", Details: fmt.Sprint(len(rules))},
+		},
+		SuccessfulScan: counts[SeverityError] == 0,
+		ReportTime:     time.Now(),
+	}
+	errorsRow := reporting.OverviewRow{Description: "Errors", Details: fmt.Sprint(counts[SeverityError])}
+	if counts[SeverityError] > 0 {
+		errorsRow.Style = reporting.Red
+	}
+	scanReport.Overview = append(scanReport.Overview, errorsRow, reporting.OverviewRow{Description: "Warnings", Details: fmt.Sprint(counts[SeverityWarning])})
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No policy violations",
+		Headers: []string{
+			"Rule",
+			"Severity",
+			"Resource",
+			"Location",
+			"Message",
+		},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, violation := range violations {
+		style := reporting.ColumnStyle(reporting.Yellow)
+		if violation.Severity == SeverityError {
+			style = reporting.Red
+		}
+		location := fmt.Sprintf("%v:%v", violation.Resource.File, violation.location())
+		if len(violation.Resource.Source) > 0 {
+			location = fmt.Sprintf("%v (%v)", location, violation.Resource.Source)
+		}
+		row := reporting.ScanRow{}
+		row.AddColumn(violation.RuleID, 0)
+		row.AddColumn(violation.Severity, style)
+		row.AddColumn(violation.Resource.ID(), 0)
+		row.AddColumn(location, 0)
+		row.AddColumn(violation.Message, 0)
+		detailTable.Rows = append(detailTable.Rows, row)
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
+
+// WriteCustomReports writes the ScanReport as markdown report and as JSON step report
+func WriteCustomReports(scanReport reporting.ScanReport, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	markdownReport, _ := scanReport.ToMarkdown()
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	markdownReportPath := filepath.Join(ReportsDirectory, "piper_manifest_policy_report.md")
+	if err := fileUtils.FileWrite(markdownReportPath, markdownReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write markdown report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Manifest Policy Report", Target: markdownReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := fileUtils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := fileUtils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create step reporting directory")
+		}
+	}
+	if err := fileUtils.FileWrite(filepath.Join(reporting.StepReportDirectory, "manifestPolicyCheck_violations.json"), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+
+	return reportPaths, nil
+}
+
+// CreateSarifResultFile creates a SARIF result from the violations
+func CreateSarifResultFile(rules []Rule, violations []Violation) *format.SARIF {
+	sarif := format.SARIF{
+		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []format.Runs{{Results: []format.Results{}}},
+	}
+	tool := format.Tool{Driver: format.Driver{Name: "Piper manifest policy check"}}
+
+	ruleIndices := map[string]int{}
+	for _, rule := range rules {
+		metadata := rule.Metadata()
+		if _, known := ruleIndices[metadata.ID]; known {
+			continue
+		}
+		ruleIndices[metadata.ID] = len(tool.Driver.Rules)
+		tool.Driver.Rules = append(tool.Driver.Rules, format.SarifRule{
+			ID:                   metadata.ID,
+			Name:                 metadata.ID,
+			ShortDescription:     &format.Message{Text: metadata.Description},
+			DefaultConfiguration: &format.DefaultConfiguration{Level: metadata.Severity},
+			Properties:           &format.SarifRuleProperties{Tags: []string{"security", "configuration"}},
+		})
+	}
+
+	for _, violation := range violations {
+		result := format.Results{
+			RuleID:    violation.RuleID,
+			RuleIndex: ruleIndices[violation.RuleID],
+			Level:     violation.Severity,
+			Message:   &format.Message{Text: violation.Message},
+			Locations: []format.Location{{PhysicalLocation: format.PhysicalLocation{
+				ArtifactLocation: format.ArtifactLocation{URI: filepath.ToSlash(violation.Resource.File)},
+				Region:           format.Region{StartLine: violation.location()},
+				LogicalLocations: []format.LogicalLocation{{FullyQualifiedName: violation.Resource.ID(), Kind: violation.Resource.Kind}},
+			}}},
+		}
+		sarif.Runs[0].Results = append(sarif.Runs[0].Results, result)
+	}
+	sarif.Runs[0].Tool = tool
+
+	return &sarif
+}
+
+// WriteSarifFile writes the SARIF result as JSON file
+func WriteSarifFile(sarif *format.SARIF, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	sarifReport, err := json.Marshal(sarif)
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to marshal SARIF json file")
+	}
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	sarifReportPath := filepath.Join(ReportsDirectory, "piper_manifest_policy.sarif")
+	if err := fileUtils.FileWrite(sarifReportPath, sarifReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write SARIF file")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Manifest Policy SARIF file", Target: sarifReportPath})
+
+	return reportPaths, nil
+}
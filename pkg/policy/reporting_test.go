@@ -0,0 +1,61 @@
+//go:build unit
+// +build unit
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporting(t *testing.T) {
+	t.Parallel()
+	resources, err := LoadManifests("rendered.yaml", []byte(helmTemplateOutput))
+	assert.NoError(t, err)
+	rules := BuiltinRules()
+	violations, err := Evaluate(context.Background(), rules, resources, nil)
+	assert.NoError(t, err)
+
+	t.Run("custom report", func(t *testing.T) {
+		t.Parallel()
+		scanReport := CreateCustomReport(resources, rules, violations)
+		files := &mock.FilesMock{}
+
+		paths, err := WriteCustomReports(scanReport, files)
+
+		assert.NoError(t, err)
+		assert.False(t, scanReport.SuccessfulScan)
+		if assert.Len(t, scanReport.DetailTable.Rows, 2) {
+			assert.Equal(t, "rendered.yaml:8 (my-app/templates/deployment.yaml)", scanReport.DetailTable.Rows[0].Columns[3].Content)
+		}
+		assert.Len(t, paths, 1)
+		markdown, err := files.FileRead("policycheck/piper_manifest_policy_report.md")
+		assert.NoError(t, err)
+		assert.Contains(t, string(markdown), "Manifest Policy Report")
+		assert.True(t, files.HasFile(".pipeline/stepReports/manifestPolicyCheck_violations.json"))
+	})
+
+	t.Run("SARIF", func(t *testing.T) {
+		t.Parallel()
+		sarif := CreateSarifResultFile(rules, violations)
+		files := &mock.FilesMock{}
+
+		_, err := WriteSarifFile(sarif, files)
+
+		assert.NoError(t, err)
+		assert.Len(t, sarif.Runs[0].Tool.Driver.Rules, len(rules))
+		if assert.Len(t, sarif.Runs[0].Results, 2) {
+			result := sarif.Runs[0].Results[0]
+			assert.Equal(t, rules[result.RuleIndex].Metadata().ID, result.RuleID)
+			assert.Equal(t, 8, result.Locations[0].PhysicalLocation.Region.StartLine)
+			assert.Equal(t, "Deployment/prod/my-app", result.Locations[0].PhysicalLocation.LogicalLocations[0].FullyQualifiedName)
+		}
+		content, err := files.FileRead("policycheck/piper_manifest_policy.sarif")
+		assert.NoError(t, err)
+		assert.True(t, json.Valid(content))
+	})
+}
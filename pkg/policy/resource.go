@@ -0,0 +1,156 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/pkg/errors"
+)
+
+// KindDockerfile is the kind of resources representing a Dockerfile
+const KindDockerfile = "Dockerfile"
+
+// Resource is a Kubernetes object of a manifest or a Dockerfile the rules are evaluated against
+type Resource struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// File and Line locate the YAML document or the Dockerfile
+	File string
+	Line int
+	// Source is the template the document has been rendered from by helm, if any
+	Source string
+	// Object is the content of the document as decoded from JSON, i.e. numbers are float64
+	Object map[string]interface{}
+}
+
+// ID identifies the resource in reports, e.g. Deployment/my-namespace/my-app
+func (r Resource) ID() string {
+	if r.Kind == KindDockerfile {
+		return r.File
+	}
+	if len(r.Namespace) > 0 {
+		return fmt.Sprintf("%v/%v/%v", r.Kind, r.Namespace, r.Name)
+	}
+	return fmt.Sprintf("%v/%v", r.Kind, r.Name)
+}
+
+var (
+	documentSeparator = regexp.MustCompile(`^---\s*(#.*)?$`)
+	helmSourceComment = regexp.MustCompile(`^#\s*Source:\s*(\S+)`)
+)
+
+// LoadManifests reads the Kubernetes objects from multi-document YAML as rendered by 'helm template' or
+// 'kubectl kustomize'. Empty documents are skipped and the items of lists are returned as separate resources.
+func LoadManifests(file string, content []byte) ([]Resource, error) {
+	resources := []Resource{}
+	lines := strings.Split(string(content), "\n")
+	start := 0
+	for i := 0; i <= len(lines); i++ {
+		if i < len(lines) && !documentSeparator.MatchString(strings.TrimRight(lines[i], "\r")) {
+			continue
+		}
+		documentResources, err := loadDocument(file, start+1, lines[start:i])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, documentResources...)
+		start = i + 1
+	}
+	return resources, nil
+}
+
+func loadDocument(file string, line int, lines []string) ([]Resource, error) {
+	source := ""
+	for _, l := range lines {
+		if match := helmSourceComment.FindStringSubmatch(l); match != nil {
+			source = match[1]
+			break
+		}
+	}
+	object := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &object); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the YAML document at %v:%v", file, line)
+	}
+	if len(object) == 0 {
+		return []Resource{}, nil
+	}
+	if items, isList := object["items"].([]interface{}); isList && strings.HasSuffix(stringValue(object, "kind"), "List") {
+		resources := []Resource{}
+		for _, item := range items {
+			if itemObject, ok := item.(map[string]interface{}); ok {
+				resources = append(resources, newResource(file, line, source, itemObject))
+			}
+		}
+		return resources, nil
+	}
+	return []Resource{newResource(file, line, source, object)}, nil
+}
+
+func newResource(file string, line int, source string, object map[string]interface{}) Resource {
+	return Resource{
+		Kind:      stringValue(object, "kind"),
+		Name:      stringValue(object, "metadata", "name"),
+		Namespace: stringValue(object, "metadata", "namespace"),
+		File:      file,
+		Line:      line,
+		Source:    source,
+		Object:    object,
+	}
+}
+
+// LoadDockerfile parses the Dockerfile into a resource of kind Dockerfile. Its object contains the instructions, e.g.
+// {"kind": "Dockerfile", "instructions": [{"cmd": "from", "flags": [], "value": ["golang:1.22", "AS", "build"], "line": 1, "original": "FROM golang:1.22 AS build"}]}
+func LoadDockerfile(file string, content []byte) (Resource, error) {
+	result, err := parser.Parse(bytes.NewReader(content))
+	if err != nil {
+		return Resource{}, errors.Wrapf(err, "failed to parse Dockerfile %v", file)
+	}
+	instructions := []interface{}{}
+	for _, node := range result.AST.Children {
+		value := []interface{}{}
+		for next := node.Next; next != nil; next = next.Next {
+			value = append(value, next.Value)
+		}
+		flags := []interface{}{}
+		for _, flag := range node.Flags {
+			flags = append(flags, flag)
+		}
+		instructions = append(instructions, map[string]interface{}{
+			"cmd":      strings.ToLower(node.Value),
+			"flags":    flags,
+			"value":    value,
+			"line":     float64(node.StartLine),
+			"original": node.Original,
+		})
+	}
+	return Resource{
+		Kind:   KindDockerfile,
+		Name:   file,
+		File:   file,
+		Line:   1,
+		Object: map[string]interface{}{"kind": KindDockerfile, "instructions": instructions},
+	}, nil
+}
+
+// stringValue returns the string at the path of nested maps, or an empty string
+func stringValue(object map[string]interface{}, path ...string) string {
+	value, _ := lookup(object, path...).(string)
+	return value
+}
+
+func lookup(object map[string]interface{}, path ...string) interface{} {
+	var current interface{} = object
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const helmTemplateOutput = `---
+# Source: my-app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: my-app
+---
+# Source: my-app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+  namespace: prod
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: app
+          image: registry.example.com/my-app:1.0.0
+---
+# Source: my-app/templates/empty.yaml
+`
+
+func TestLoadManifests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("helm template output", func(t *testing.T) {
+		t.Parallel()
+		resources, err := LoadManifests("rendered.yaml", []byte(helmTemplateOutput))
+
+		assert.NoError(t, err)
+		if assert.Len(t, resources, 2) {
+			assert.Equal(t, "Service/my-app", resources[0].ID())
+			assert.Equal(t, 2, resources[0].Line)
+			assert.Equal(t, "my-app/templates/service.yaml", resources[0].Source)
+			assert.Equal(t, "Deployment/prod/my-app", resources[1].ID())
+			assert.Equal(t, 8, resources[1].Line)
+			assert.Equal(t, 2.0, lookup(resources[1].Object, "spec", "replicas"))
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+		resources, err := LoadManifests("list.yaml", []byte("apiVersion: v1\nkind: List\nitems:\n  - kind: ConfigMap\n    metadata:\n      name: a\n  - kind: ConfigMap\n    metadata:\n      name: b\n"))
+
+		assert.NoError(t, err)
+		if assert.Len(t, resources, 2) {
+			assert.Equal(t, "ConfigMap/b", resources[1].ID())
+		}
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadManifests("broken.yaml", []byte("kind: ConfigMap\n---\nkind: [\n"))
+
+		assert.ErrorContains(t, err, "failed to parse the YAML document at broken.yaml:3")
+	})
+}
+
+func TestLoadDockerfile(t *testing.T) {
+	t.Parallel()
+	resource, err := LoadDockerfile("Dockerfile", []byte("FROM golang:1.22 AS build\nRUN go build \\\n  ./...\nFROM scratch\nUSER 1000\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Dockerfile", resource.ID())
+	instructions := dockerfileInstructions(resource)
+	if assert.Len(t, instructions, 4) {
+		assert.Equal(t, instruction{cmd: "from", value: []string{"golang:1.22", "AS", "build"}, line: 1}, instructions[0])
+		assert.Equal(t, "run", instructions[1].cmd)
+		assert.Equal(t, instruction{cmd: "user", value: []string{"1000"}, line: 5}, instructions[3])
+	}
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"context"
+	"sort"
+)
+
+// Severities of violations, violations with severity error fail the check
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// RuleMetadata describes a rule
+type RuleMetadata struct {
+	ID          string
+	Description string
+	// Severity is the default severity of the violations of the rule
+	Severity string
+}
+
+// Violation is a resource not complying with a rule
+type Violation struct {
+	RuleID   string
+	Severity string
+	Message  string
+	Resource Resource
+	// Line is the line of the violating instruction within a Dockerfile, 0 refers to the resource
+	Line int
+}
+
+// Rule checks resources
+type Rule interface {
+	Metadata() RuleMetadata
+	Evaluate(ctx context.Context, resource Resource) ([]Violation, error)
+}
+
+// Evaluate evaluates all rules against all resources. Disabled rules are skipped.
+// Violations are ordered by file and line.
+func Evaluate(ctx context.Context, rules []Rule, resources []Resource, disabledRules []string) ([]Violation, error) {
+	disabled := map[string]bool{}
+	for _, id := range disabledRules {
+		disabled[id] = true
+	}
+	violations := []Violation{}
+	for _, rule := range rules {
+		if disabled[rule.Metadata().ID] {
+			continue
+		}
+		for _, resource := range resources {
+			ruleViolations, err := rule.Evaluate(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, ruleViolations...)
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Resource.File != violations[j].Resource.File {
+			return violations[i].Resource.File < violations[j].Resource.File
+		}
+		return violations[i].location() < violations[j].location()
+	})
+	return violations, nil
+}
+
+// location returns the line of the violation within its file
+func (v Violation) location() int {
+	if v.Line > 0 {
+		return v.Line
+	}
+	return v.Resource.Line
+}
+
+// CountBySeverity counts the violations per severity
+func CountBySeverity(violations []Violation) map[string]int {
+	counts := map[string]int{}
+	for _, violation := range violations {
+		counts[violation.Severity]++
+	}
+	return counts
+}
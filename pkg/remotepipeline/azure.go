@@ -0,0 +1,115 @@
+package remotepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+)
+
+// AzurePipelines queues a run of an Azure Pipelines definition
+type AzurePipelines struct {
+	Client  build.Client
+	Project string
+	// PipelineID is the id of the pipeline definition
+	PipelineID int
+	// Ref is the branch or tag the pipeline runs on, the default branch of the pipeline is used if empty
+	Ref string
+}
+
+// Trigger queues a run of the pipeline. Parameters are passed as queue time variables.
+func (a *AzurePipelines) Trigger(ctx context.Context, parameters map[string]string) (Run, error) {
+	queued := build.Build{Definition: &build.DefinitionReference{Id: &a.PipelineID}}
+	if a.Ref != "" {
+		queued.SourceBranch = &a.Ref
+	}
+	if len(parameters) > 0 {
+		variables, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, err
+		}
+		value := string(variables)
+		queued.Parameters = &value
+	}
+	run, err := a.Client.QueueBuild(ctx, build.QueueBuildArgs{Build: &queued, Project: &a.Project})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue pipeline %v: %w", a.PipelineID, err)
+	}
+	return &azureRun{pipelines: a, run: run}, nil
+}
+
+type azureRun struct {
+	pipelines *AzurePipelines
+	run       *build.Build
+}
+
+func (r *azureRun) Refresh(ctx context.Context) (bool, error) {
+	run, err := r.pipelines.Client.GetBuild(ctx, build.GetBuildArgs{Project: &r.pipelines.Project, BuildId: r.run.Id})
+	if err != nil {
+		return false, err
+	}
+	r.run = run
+	return run.Status != nil && *run.Status == build.BuildStatusValues.Completed, nil
+}
+
+func (r *azureRun) Result() string {
+	if r.run.Result == nil {
+		return ResultFailure
+	}
+	switch *r.run.Result {
+	case build.BuildResultValues.Succeeded:
+		return ResultSuccess
+	case build.BuildResultValues.PartiallySucceeded:
+		return ResultUnstable
+	case build.BuildResultValues.Canceled:
+		return ResultAborted
+	default:
+		return ResultFailure
+	}
+}
+
+// URL returns the link to the run in the web UI
+func (r *azureRun) URL() string {
+	links, _ := r.run.Links.(map[string]interface{})
+	web, _ := links["web"].(map[string]interface{})
+	if href, ok := web["href"].(string); ok {
+		return href
+	}
+	if r.run.Url != nil {
+		return *r.run.Url
+	}
+	return ""
+}
+
+// DownloadArtifact extracts the published artifact of the run into the target directory
+func (r *azureRun) DownloadArtifact(ctx context.Context, name, targetDir string) error {
+	content, err := r.pipelines.Client.GetArtifactContentZip(ctx, build.GetArtifactContentZipArgs{Project: &r.pipelines.Project, BuildId: r.run.Id, ArtifactName: &name})
+	if err != nil {
+		return fmt.Errorf("failed to download artifact '%v': %w", name, err)
+	}
+	defer content.Close()
+
+	tempDir, err := os.MkdirTemp("", "remoteArtifact")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	archive := filepath.Join(tempDir, "artifact.zip")
+	file, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download artifact '%v': %w", name, err)
+	}
+	return extractZip(archive, targetDir)
+}
@@ -0,0 +1,73 @@
+//go:build unit
+// +build unit
+
+package remotepipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/ado/mocks"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAzurePipelines(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("trigger", func(t *testing.T) {
+		client := &mocks.Client{}
+		id := 42
+		client.On("QueueBuild", ctx, mock.MatchedBy(func(args build.QueueBuildArgs) bool {
+			return *args.Project == "project" && *args.Build.Definition.Id == 3 && *args.Build.SourceBranch == "refs/heads/main" && *args.Build.Parameters == `{"environment":"staging"}`
+		})).Return(&build.Build{Id: &id}, nil)
+		pipeline := &AzurePipelines{Client: client, Project: "project", PipelineID: 3, Ref: "refs/heads/main"}
+
+		run, err := pipeline.Trigger(ctx, map[string]string{"environment": "staging"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &build.Build{Id: &id}, run.(*azureRun).run)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("trigger error", func(t *testing.T) {
+		client := &mocks.Client{}
+		client.On("QueueBuild", ctx, mock.Anything).Return(nil, fmt.Errorf("unauthorized"))
+		pipeline := &AzurePipelines{Client: client, Project: "project", PipelineID: 3}
+
+		_, err := pipeline.Trigger(ctx, nil)
+
+		assert.EqualError(t, err, "failed to queue pipeline 3: unauthorized")
+	})
+
+	t.Run("result", func(t *testing.T) {
+		id := 42
+		for result, expected := range map[build.BuildResult]string{
+			build.BuildResultValues.Succeeded:          ResultSuccess,
+			build.BuildResultValues.PartiallySucceeded: ResultUnstable,
+			build.BuildResultValues.Failed:             ResultFailure,
+			build.BuildResultValues.Canceled:           ResultAborted,
+		} {
+			buildResult := result
+			completed := build.BuildStatusValues.Completed
+			client := &mocks.Client{}
+			client.On("GetBuild", ctx, mock.Anything).Return(&build.Build{
+				Id:     &id,
+				Status: &completed,
+				Result: &buildResult,
+				Links:  map[string]interface{}{"web": map[string]interface{}{"href": "https://dev.azure.com/org/project/_build/results?buildId=42"}},
+			}, nil)
+			run := &azureRun{pipelines: &AzurePipelines{Client: client, Project: "project"}, run: &build.Build{Id: &id}}
+
+			finished, err := run.Refresh(ctx)
+
+			assert.NoError(t, err)
+			assert.True(t, finished)
+			assert.Equal(t, expected, run.Result(), string(result))
+			assert.Equal(t, "https://dev.azure.com/org/project/_build/results?buildId=42", run.URL())
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package remotepipeline
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/google/go-github/v45/github"
+)
+
+// discovery of the run started by a workflow_dispatch event, GitHub does not return it
+var (
+	runDiscoveryAttempts = 20
+	runDiscoveryInterval = 3 * time.Second
+	// runClockSkew tolerates a clock of the agent ahead of GitHub when comparing the creation time of runs
+	runClockSkew = 30 * time.Second
+)
+
+type githubActionsService interface {
+	CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error)
+	ListWorkflowRunsByFileName(ctx context.Context, owner, repo, workflowFileName string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error)
+	ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error)
+	DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64, followRedirects bool) (*url.URL, *github.Response, error)
+}
+
+// GitHubActions triggers a workflow with a workflow_dispatch trigger
+type GitHubActions struct {
+	Actions    githubActionsService
+	Downloader piperhttp.Downloader
+	Owner      string
+	Repository string
+	// Workflow is the file name of the workflow, e.g. integration.yml
+	Workflow string
+	// Ref is the branch or tag the workflow runs on
+	Ref string
+}
+
+// Trigger dispatches the workflow and looks up the run which has been started by the event.
+// Parameters are passed as inputs of the workflow.
+func (g *GitHubActions) Trigger(ctx context.Context, parameters map[string]string) (Run, error) {
+	previousRuns, err := g.listDispatchedRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := map[int64]bool{}
+	for _, run := range previousRuns {
+		known[run.GetID()] = true
+	}
+
+	inputs := map[string]interface{}{}
+	for key, value := range parameters {
+		inputs[key] = value
+	}
+	event := github.CreateWorkflowDispatchEventRequest{Ref: g.Ref, Inputs: inputs}
+	dispatched := time.Now().Add(-runClockSkew)
+	if _, err := g.Actions.CreateWorkflowDispatchEventByFileName(ctx, g.Owner, g.Repository, g.Workflow, event); err != nil {
+		return nil, fmt.Errorf("failed to dispatch workflow '%v': %w", g.Workflow, err)
+	}
+
+	for attempt := 0; attempt < runDiscoveryAttempts; attempt++ {
+		if err := sleep(ctx, runDiscoveryInterval); err != nil {
+			return nil, err
+		}
+		runs, err := g.listDispatchedRuns(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if run := newestRunSince(runs, known, dispatched); run != nil {
+			log.Entry().Debugf("Workflow run %v has been started", run.GetID())
+			return &githubRun{actions: g, run: run}, nil
+		}
+	}
+	return nil, fmt.Errorf("no run of workflow '%v' has been started for ref '%v'", g.Workflow, g.Ref)
+}
+
+// newestRunSince returns the most recently created run which is not known yet and has been created after the given time
+func newestRunSince(runs []*github.WorkflowRun, known map[int64]bool, since time.Time) *github.WorkflowRun {
+	var newest *github.WorkflowRun
+	for _, run := range runs {
+		if known[run.GetID()] || run.GetCreatedAt().Before(since) {
+			continue
+		}
+		if newest == nil || run.GetCreatedAt().After(newest.GetCreatedAt().Time) {
+			newest = run
+		}
+	}
+	return newest
+}
+
+func (g *GitHubActions) listDispatchedRuns(ctx context.Context) ([]*github.WorkflowRun, error) {
+	options := &github.ListWorkflowRunsOptions{Branch: g.Ref, Event: "workflow_dispatch", ListOptions: github.ListOptions{PerPage: 20}}
+	result, _, err := g.Actions.ListWorkflowRunsByFileName(ctx, g.Owner, g.Repository, g.Workflow, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs of workflow '%v': %w", g.Workflow, err)
+	}
+	return result.WorkflowRuns, nil
+}
+
+type githubRun struct {
+	actions *GitHubActions
+	run     *github.WorkflowRun
+}
+
+func (r *githubRun) Refresh(ctx context.Context) (bool, error) {
+	run, _, err := r.actions.Actions.GetWorkflowRunByID(ctx, r.actions.Owner, r.actions.Repository, r.run.GetID())
+	if err != nil {
+		return false, err
+	}
+	r.run = run
+	return run.GetStatus() == "completed", nil
+}
+
+func (r *githubRun) Result() string {
+	switch r.run.GetConclusion() {
+	case "success", "skipped", "neutral":
+		return ResultSuccess
+	case "cancelled":
+		return ResultAborted
+	default:
+		return ResultFailure
+	}
+}
+
+func (r *githubRun) URL() string {
+	return r.run.GetHTMLURL()
+}
+
+// DownloadArtifact extracts the uploaded artifact of the run into the target directory
+func (r *githubRun) DownloadArtifact(ctx context.Context, name, targetDir string) error {
+	g := r.actions
+	artifacts, _, err := g.Actions.ListWorkflowRunArtifacts(ctx, g.Owner, g.Repository, r.run.GetID(), &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return fmt.Errorf("failed to list artifacts of run %v: %w", r.run.GetID(), err)
+	}
+	for _, artifact := range artifacts.Artifacts {
+		if artifact.GetName() != name {
+			continue
+		}
+		downloadURL, _, err := g.Actions.DownloadArtifact(ctx, g.Owner, g.Repository, artifact.GetID(), true)
+		if err != nil {
+			return fmt.Errorf("failed to get download URL of artifact '%v': %w", name, err)
+		}
+		return downloadZip(g.Downloader, downloadURL.String(), targetDir)
+	}
+	return fmt.Errorf("failed to fetch artifact: Artifact '%v' not found", name)
+}
+
+// downloadZip downloads the zip archive and extracts it into the target directory
+func downloadZip(downloader piperhttp.Downloader, downloadURL, targetDir string) error {
+	tempDir, err := os.MkdirTemp("", "remoteArtifact")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	archive := filepath.Join(tempDir, "artifact.zip")
+	if err := downloader.DownloadFile(downloadURL, archive, nil, nil); err != nil {
+		return fmt.Errorf("failed to download artifact: %w", err)
+	}
+	return extractZip(archive, targetDir)
+}
+
+func extractZip(archive, targetDir string) error {
+	if _, err := piperutils.Unzip(archive, targetDir); err != nil {
+		return fmt.Errorf("failed to extract artifact into %v: %w", targetDir, err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+//go:build unit
+// +build unit
+
+package remotepipeline
+
+import (
+	"archive/zip"
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/google/go-github/v45/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type actionsMock struct {
+	runs       [][]*github.WorkflowRun
+	listed     int
+	dispatched *github.CreateWorkflowDispatchEventRequest
+	run        *github.WorkflowRun
+	artifacts  []*github.Artifact
+}
+
+func (a *actionsMock) CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error) {
+	a.dispatched = &event
+	return nil, nil
+}
+
+func (a *actionsMock) ListWorkflowRunsByFileName(ctx context.Context, owner, repo, workflowFileName string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	runs := a.runs[a.listed]
+	if a.listed < len(a.runs)-1 {
+		a.listed++
+	}
+	return &github.WorkflowRuns{WorkflowRuns: runs}, nil, nil
+}
+
+func (a *actionsMock) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+	return a.run, nil, nil
+}
+
+func (a *actionsMock) ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	return &github.ArtifactList{Artifacts: a.artifacts}, nil, nil
+}
+
+func (a *actionsMock) DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64, followRedirects bool) (*url.URL, *github.Response, error) {
+	downloadURL, err := url.Parse("https://storage.example.com/artifact.zip")
+	return downloadURL, nil, err
+}
+
+type zipDownloaderMock struct {
+	files map[string]string
+}
+
+func (d *zipDownloaderMock) SetOptions(options piperhttp.ClientOptions) {}
+
+func (d *zipDownloaderMock) DownloadFile(url, filename string, header http.Header, cookies []*http.Cookie) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	for name, content := range d.files {
+		entry, err := writer.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func TestGitHubActions(t *testing.T) {
+	runDiscoveryInterval = 0
+	ctx := context.Background()
+
+	t.Run("trigger", func(t *testing.T) {
+		now := time.Now()
+		oldRun := &github.WorkflowRun{ID: github.Int64(1), CreatedAt: &github.Timestamp{Time: now.Add(-time.Hour)}}
+		otherRun := &github.WorkflowRun{ID: github.Int64(2), CreatedAt: &github.Timestamp{Time: now.Add(-10 * time.Minute)}}
+		newRun := &github.WorkflowRun{ID: github.Int64(3), CreatedAt: &github.Timestamp{Time: now.Add(time.Second)}, HTMLURL: github.String("https://github.com/owner/repo/actions/runs/3")}
+		// the list is not guaranteed to be ordered, the run created before the dispatch must not be picked
+		actions := &actionsMock{runs: [][]*github.WorkflowRun{{oldRun}, {oldRun}, {otherRun, newRun, oldRun}}}
+		pipeline := &GitHubActions{Actions: actions, Owner: "owner", Repository: "repo", Workflow: "tests.yml", Ref: "main"}
+
+		run, err := pipeline.Trigger(ctx, map[string]string{"environment": "staging"})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "https://github.com/owner/repo/actions/runs/3", run.URL())
+			assert.Equal(t, "main", actions.dispatched.Ref)
+			assert.Equal(t, map[string]interface{}{"environment": "staging"}, actions.dispatched.Inputs)
+		}
+	})
+
+	t.Run("cancelled while waiting for the run", func(t *testing.T) {
+		actions := &actionsMock{runs: [][]*github.WorkflowRun{{}}}
+		pipeline := &GitHubActions{Actions: actions, Workflow: "tests.yml", Ref: "main"}
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := pipeline.Trigger(cancelled, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("run not started", func(t *testing.T) {
+		actions := &actionsMock{runs: [][]*github.WorkflowRun{{}}}
+		pipeline := &GitHubActions{Actions: actions, Workflow: "tests.yml", Ref: "main"}
+
+		_, err := pipeline.Trigger(ctx, nil)
+
+		assert.EqualError(t, err, "no run of workflow 'tests.yml' has been started for ref 'main'")
+	})
+
+	t.Run("result", func(t *testing.T) {
+		for conclusion, expected := range map[string]string{"success": ResultSuccess, "failure": ResultFailure, "cancelled": ResultAborted, "timed_out": ResultFailure} {
+			actions := &actionsMock{run: &github.WorkflowRun{Status: github.String("completed"), Conclusion: github.String(conclusion)}}
+			run := &githubRun{actions: &GitHubActions{Actions: actions}, run: &github.WorkflowRun{ID: github.Int64(2)}}
+
+			finished, err := run.Refresh(ctx)
+
+			assert.NoError(t, err)
+			assert.True(t, finished)
+			assert.Equal(t, expected, run.Result(), conclusion)
+		}
+	})
+
+	t.Run("download artifact", func(t *testing.T) {
+		actions := &actionsMock{artifacts: []*github.Artifact{{ID: github.Int64(7), Name: github.String("test-results")}}}
+		downloader := &zipDownloaderMock{files: map[string]string{"junit/results.xml": "<testsuites/>"}}
+		run := &githubRun{actions: &GitHubActions{Actions: actions, Downloader: downloader}, run: &github.WorkflowRun{ID: github.Int64(2)}}
+		targetDir := t.TempDir()
+
+		assert.NoError(t, run.DownloadArtifact(ctx, "test-results", targetDir))
+		content, err := os.ReadFile(filepath.Join(targetDir, "junit", "results.xml"))
+		assert.NoError(t, err)
+		assert.Equal(t, "<testsuites/>", string(content))
+
+		assert.EqualError(t, run.DownloadArtifact(ctx, "coverage", targetDir), "failed to fetch artifact: Artifact 'coverage' not found")
+	})
+}
@@ -0,0 +1,65 @@
+package remotepipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/SAP/jenkins-library/pkg/jenkins"
+)
+
+// Jenkins triggers a job on a Jenkins instance
+type Jenkins struct {
+	Instance jenkins.Jenkins
+	// JobName is the full name of the job, e.g. folder/job/branch
+	JobName string
+}
+
+// Trigger starts a build of the job
+func (j *Jenkins) Trigger(ctx context.Context, parameters map[string]string) (Run, error) {
+	job, err := jenkins.GetJob(ctx, j.Instance, j.JobName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job '%v': %w", j.JobName, err)
+	}
+	build, err := jenkins.TriggerJob(ctx, j.Instance, job, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger job '%v': %w", j.JobName, err)
+	}
+	return &jenkinsRun{build: build}, nil
+}
+
+type jenkinsRun struct {
+	build jenkins.Build
+}
+
+func (r *jenkinsRun) Refresh(ctx context.Context) (bool, error) {
+	if _, err := r.build.Poll(ctx); err != nil {
+		return false, err
+	}
+	return !r.build.IsRunning(ctx), nil
+}
+
+// Result returns the build result, Jenkins uses the same vocabulary
+func (r *jenkinsRun) Result() string {
+	return r.build.GetResult()
+}
+
+func (r *jenkinsRun) URL() string {
+	return r.build.GetUrl()
+}
+
+// DownloadArtifact stores the archived file with the given name in the target directory
+func (r *jenkinsRun) DownloadArtifact(ctx context.Context, name, targetDir string) error {
+	artifact, err := jenkins.FetchBuildArtifact(ctx, r.build, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(targetDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %v: %w", targetDir, err)
+	}
+	if _, err := artifact.Save(ctx, filepath.Join(targetDir, artifact.FileName())); err != nil {
+		return fmt.Errorf("failed to download artifact '%v': %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+//go:build unit
+// +build unit
+
+package remotepipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/jenkins/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJenkinsRun(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("running", func(t *testing.T) {
+		build := &mocks.Build{}
+		build.On("Poll", ctx).Return(200, nil)
+		build.On("IsRunning", ctx).Return(true)
+		run := &jenkinsRun{build: build}
+
+		finished, err := run.Refresh(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, finished)
+		build.AssertExpectations(t)
+	})
+
+	t.Run("finished", func(t *testing.T) {
+		build := &mocks.Build{}
+		build.On("Poll", ctx).Return(200, nil)
+		build.On("IsRunning", ctx).Return(false)
+		build.On("GetResult").Return(ResultUnstable)
+		build.On("GetUrl").Return("https://jenkins.example.com/job/tests/1/")
+		run := &jenkinsRun{build: build}
+
+		finished, err := run.Refresh(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, finished)
+		assert.Equal(t, ResultUnstable, run.Result())
+		assert.Equal(t, "https://jenkins.example.com/job/tests/1/", run.URL())
+	})
+
+	t.Run("missing artifact", func(t *testing.T) {
+		build := &mocks.Build{}
+		build.On("IsRunning", ctx).Return(false)
+		build.On("GetArtifacts").Return(nil)
+		run := &jenkinsRun{build: build}
+
+		err := run.DownloadArtifact(ctx, "report.xml", t.TempDir())
+
+		assert.EqualError(t, err, "failed to fetch artifact: Artifact 'report.xml' not found")
+	})
+}
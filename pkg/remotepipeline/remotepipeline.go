@@ -0,0 +1,68 @@
+package remotepipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// Results of a remote run, the vocabulary of the platforms is mapped to these values
+const (
+	ResultSuccess  = "SUCCESS"
+	ResultUnstable = "UNSTABLE"
+	ResultFailure  = "FAILURE"
+	ResultAborted  = "ABORTED"
+)
+
+// Pipeline is a downstream pipeline on a remote CI/CD platform
+type Pipeline interface {
+	// Trigger starts a run of the pipeline with the given parameters
+	Trigger(ctx context.Context, parameters map[string]string) (Run, error)
+}
+
+// Run is a triggered run of a remote pipeline
+type Run interface {
+	// Refresh updates the state of the run and returns whether it is finished
+	Refresh(ctx context.Context) (bool, error)
+	// Result of the finished run
+	Result() string
+	// URL of the run for the log output
+	URL() string
+	// DownloadArtifact stores the named artifact of the finished run in the target directory
+	DownloadArtifact(ctx context.Context, name, targetDir string) error
+}
+
+// WaitForRun polls the run until it is finished. It fails when the run does not finish within the timeout.
+func WaitForRun(ctx context.Context, run Run, timeout, pollInterval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		finished, err := run.Refresh(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the state of run %v: %w", run.URL(), err)
+		}
+		if finished {
+			return nil
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			return fmt.Errorf("run %v did not finish within %v", run.URL(), timeout)
+		}
+		log.Entry().Debugf("Run %v is still running, waiting %v", run.URL(), pollInterval)
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep waits for the duration unless the context is cancelled before
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+//go:build unit
+// +build unit
+
+package remotepipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type runMock struct {
+	refreshes  int
+	finishedAt int
+	refreshErr error
+}
+
+func (r *runMock) Refresh(ctx context.Context) (bool, error) {
+	r.refreshes++
+	return r.refreshes >= r.finishedAt, r.refreshErr
+}
+
+func (r *runMock) Result() string {
+	return ResultSuccess
+}
+
+func (r *runMock) URL() string {
+	return "https://ci.example.com/run/1"
+}
+
+func (r *runMock) DownloadArtifact(ctx context.Context, name, targetDir string) error {
+	return nil
+}
+
+func TestWaitForRun(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("finished", func(t *testing.T) {
+		run := &runMock{finishedAt: 3}
+		assert.NoError(t, WaitForRun(ctx, run, time.Second, time.Millisecond))
+		assert.Equal(t, 3, run.refreshes)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		run := &runMock{finishedAt: 1000}
+		err := WaitForRun(ctx, run, 5*time.Millisecond, 2*time.Millisecond)
+		assert.EqualError(t, err, "run https://ci.example.com/run/1 did not finish within 5ms")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		run := &runMock{refreshErr: fmt.Errorf("connection refused")}
+		err := WaitForRun(ctx, run, time.Second, time.Millisecond)
+		assert.EqualError(t, err, "failed to get the state of run https://ci.example.com/run/1: connection refused")
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		run := &runMock{finishedAt: 1000}
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		err := WaitForRun(cancelled, run, time.Hour, time.Minute)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, run.refreshes)
+	})
+}
@@ -0,0 +1,74 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/scm"
+)
+
+// SCM reports issues via the SCM provider hosting the repository, e.g. GitHub, GitLab or Azure Repos
+type SCM struct {
+	Provider  scm.Provider
+	Assignees []string
+}
+
+// UploadSingleReport creates or updates the issue for a single report
+func (s *SCM) UploadSingleReport(ctx context.Context, scanReport IssueDetail) error {
+	title := scanReport.Title()
+	markdownReport, _ := scanReport.ToMarkdown()
+
+	log.Entry().Debugf("Creating/updating issue with title %v", title)
+	if err := s.Provider.UpsertIssue(ctx, scm.Issue{Title: title, Body: string(markdownReport), Assignees: s.Assignees}); err != nil {
+		return fmt.Errorf("failed to upload results for '%v' into issue: %w", title, err)
+	}
+	return nil
+}
+
+// UploadMultipleReports creates or updates one issue per report to create transparency
+func (s *SCM) UploadMultipleReports(ctx context.Context, scanReports *[]IssueDetail) error {
+	for _, scanReport := range *scanReports {
+		if err := s.UploadSingleReport(ctx, scanReport); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CodeFinding is a finding of a static code analysis located in a line of a source file
+type CodeFinding struct {
+	// ID identifies the finding across scans, e.g. the similarity id of Checkmarx One or the issue instance id of Fortify
+	ID       string
+	Category string
+	Severity string
+	// File is relative to the root of the repository
+	File string
+	Line int
+	URL  string
+}
+
+// UploadPullRequestFindings comments each finding on its line of the pull request and summarizes the findings in a comment of the tool
+func (s *SCM) UploadPullRequestFindings(ctx context.Context, number int, tool string, findings []CodeFinding) error {
+	comments := []scm.ReviewComment{}
+	for _, finding := range findings {
+		body := fmt.Sprintf("**%v** %v: %v", tool, finding.Severity, finding.Category)
+		if len(finding.URL) > 0 {
+			body += fmt.Sprintf(" ([details](%v))", finding.URL)
+		}
+		comments = append(comments, scm.ReviewComment{Path: finding.File, Line: finding.Line, Marker: tool + ":" + finding.ID, Body: body})
+	}
+	if err := s.Provider.CreateReviewComments(ctx, number, comments); err != nil {
+		return fmt.Errorf("failed to comment findings on pull request %v: %w", number, err)
+	}
+
+	summary := fmt.Sprintf("**%v** found no new findings in the lines changed by this pull request.", tool)
+	if len(findings) > 0 {
+		summary = fmt.Sprintf("**%v** found %v new findings in the lines changed by this pull request, see the review comments for details.", tool, len(findings))
+	}
+	log.Entry().Debugf("Updating summary of %v on pull request %v", tool, number)
+	if err := s.Provider.UpsertPullRequestComment(ctx, number, tool, summary); err != nil {
+		return fmt.Errorf("failed to comment summary on pull request %v: %w", number, err)
+	}
+	return nil
+}
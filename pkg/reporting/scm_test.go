@@ -0,0 +1,139 @@
+//go:build unit
+// +build unit
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/scm"
+	"github.com/stretchr/testify/assert"
+)
+
+type scanReportlMock struct {
+	markdown       []byte
+	text           string
+	title          string
+	failToMarkdown bool
+}
+
+func (s *scanReportlMock) Title() string {
+	return s.title
+}
+
+func (s *scanReportlMock) ToMarkdown() ([]byte, error) {
+	if s.failToMarkdown {
+		return s.markdown, fmt.Errorf("toMarkdown failure")
+	}
+	return s.markdown, nil
+}
+
+func (s *scanReportlMock) ToTxt() string {
+	return s.text
+}
+
+type scmProviderMock struct {
+	scm.Provider
+	issues         []scm.Issue
+	upsertErr      error
+	reviewComments []scm.ReviewComment
+	reviewErr      error
+	comments       map[string]string
+}
+
+func (s *scmProviderMock) CreateReviewComments(ctx context.Context, number int, comments []scm.ReviewComment) error {
+	s.reviewComments = append(s.reviewComments, comments...)
+	return s.reviewErr
+}
+
+func (s *scmProviderMock) UpsertPullRequestComment(ctx context.Context, number int, marker, body string) error {
+	if s.comments == nil {
+		s.comments = map[string]string{}
+	}
+	s.comments[marker] = body
+	return nil
+}
+
+func (s *scmProviderMock) UpsertIssue(ctx context.Context, issue scm.Issue) error {
+	s.issues = append(s.issues, issue)
+	return s.upsertErr
+}
+
+func TestSCMUploadSingleReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		provider := &scmProviderMock{}
+		s := SCM{Provider: provider, Assignees: []string{"alice"}}
+
+		err := s.UploadSingleReport(context.Background(), &scanReportlMock{title: "theTitle", markdown: []byte("theReport")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []scm.Issue{{Title: "theTitle", Body: "theReport", Assignees: []string{"alice"}}}, provider.issues)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		provider := &scmProviderMock{upsertErr: fmt.Errorf("upsert error")}
+		s := SCM{Provider: provider}
+
+		err := s.UploadSingleReport(context.Background(), &scanReportlMock{title: "theTitle"})
+
+		assert.EqualError(t, err, "failed to upload results for 'theTitle' into issue: upsert error")
+	})
+}
+
+func TestSCMUploadMultipleReports(t *testing.T) {
+	t.Parallel()
+
+	provider := &scmProviderMock{}
+	s := SCM{Provider: provider}
+	reports := []IssueDetail{&scanReportlMock{title: "title1"}, &scanReportlMock{title: "title2"}}
+
+	err := s.UploadMultipleReports(context.Background(), &reports)
+
+	assert.NoError(t, err)
+	assert.Len(t, provider.issues, 2)
+}
+
+func TestSCMUploadPullRequestFindings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("findings", func(t *testing.T) {
+		provider := &scmProviderMock{}
+		s := SCM{Provider: provider}
+		findings := []CodeFinding{{ID: "42", Category: "SQL_Injection", Severity: "HIGH", File: "src/db.go", Line: 12, URL: "https://cx.example.com/results/42"}}
+
+		err := s.UploadPullRequestFindings(context.Background(), 7, "checkmarxOneExecuteScan", findings)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []scm.ReviewComment{{
+			Path:   "src/db.go",
+			Line:   12,
+			Marker: "checkmarxOneExecuteScan:42",
+			Body:   "**checkmarxOneExecuteScan** HIGH: SQL_Injection ([details](https://cx.example.com/results/42))",
+		}}, provider.reviewComments)
+		assert.Equal(t, "**checkmarxOneExecuteScan** found 1 new findings in the lines changed by this pull request, see the review comments for details.", provider.comments["checkmarxOneExecuteScan"])
+	})
+
+	t.Run("no findings", func(t *testing.T) {
+		provider := &scmProviderMock{}
+		s := SCM{Provider: provider}
+
+		err := s.UploadPullRequestFindings(context.Background(), 7, "fortifyExecuteScan", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, provider.reviewComments)
+		assert.Equal(t, "**fortifyExecuteScan** found no new findings in the lines changed by this pull request.", provider.comments["fortifyExecuteScan"])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		provider := &scmProviderMock{reviewErr: fmt.Errorf("review error")}
+		s := SCM{Provider: provider}
+
+		err := s.UploadPullRequestFindings(context.Background(), 7, "fortifyExecuteScan", []CodeFinding{{ID: "1"}})
+
+		assert.EqualError(t, err, "failed to comment findings on pull request 7: review error")
+	})
+}
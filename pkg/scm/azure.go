@@ -0,0 +1,193 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/pkg/errors"
+)
+
+const azureAPIVersion = "api-version=7.0"
+
+// Azure implements Provider for Azure Repos. Issues are tracked as work items of type Issue in Azure Boards.
+// Azure Repos has no releases.
+type Azure struct {
+	rest       *restClient
+	project    string
+	repository string
+}
+
+// NewAzure creates an Azure Repos provider, the APIURL is the URL of the organization, e.g. https://dev.azure.com/<organization>
+func NewAzure(options Options) *Azure {
+	// personal access tokens are passed via basic authentication with an empty user
+	return &Azure{
+		rest:       newRestClient(options, nil, piperhttp.ClientOptions{Password: options.Token}),
+		project:    url.PathEscape(options.Owner),
+		repository: url.PathEscape(options.Repository),
+	}
+}
+
+var azureStates = map[string]string{
+	StatePending: "pending",
+	StateSuccess: "succeeded",
+	StateFailure: "failed",
+	StateError:   "error",
+}
+
+// SetCommitStatus creates a commit status
+func (a *Azure) SetCommitStatus(ctx context.Context, sha string, status CommitStatus) error {
+	payload := map[string]interface{}{
+		"state":       azureStates[status.State],
+		"description": status.Description,
+		"targetUrl":   status.TargetURL,
+		"context":     map[string]string{"name": status.Context, "genre": "piper"},
+	}
+	if err := a.rest.send(http.MethodPost, fmt.Sprintf("%v/commits/%v/statuses?%v", a.repositoryPath(), sha, azureAPIVersion), payload, nil); err != nil {
+		return errors.Wrapf(err, "failed to set status '%v' on commit %v", status.State, sha)
+	}
+	return nil
+}
+
+// UpsertPullRequestComment creates or updates a comment thread on the pull request
+func (a *Azure) UpsertPullRequestComment(ctx context.Context, number int, marker, body string) error {
+	return upsertPullRequestComment(ctx, a, number, marker, body)
+}
+
+func (a *Azure) listComments(ctx context.Context, number int) ([]comment, error) {
+	threads := struct {
+		Value []struct {
+			ID       int `json:"id"`
+			Comments []struct {
+				ID      int    `json:"id"`
+				Content string `json:"content"`
+			} `json:"comments"`
+		} `json:"value"`
+	}{}
+	if err := a.rest.send(http.MethodGet, fmt.Sprintf("%v/threads?%v", a.pullRequestPath(number), azureAPIVersion), nil, &threads); err != nil {
+		return nil, err
+	}
+	comments := []comment{}
+	for _, thread := range threads.Value {
+		// only the first comment of a thread is created by piper, replies are ignored
+		if len(thread.Comments) > 0 {
+			first := thread.Comments[0]
+			comments = append(comments, comment{id: fmt.Sprintf("%v/comments/%v", thread.ID, first.ID), body: first.Content})
+		}
+	}
+	return comments, nil
+}
+
+func (a *Azure) createComment(ctx context.Context, number int, body string) error {
+	payload := map[string]interface{}{
+		"comments": []map[string]interface{}{{"parentCommentId": 0, "content": body, "commentType": 1}},
+		"status":   1,
+	}
+	return a.rest.send(http.MethodPost, fmt.Sprintf("%v/threads?%v", a.pullRequestPath(number), azureAPIVersion), payload, nil)
+}
+
+func (a *Azure) updateComment(ctx context.Context, number int, existing comment, body string) error {
+	return a.rest.send(http.MethodPatch, fmt.Sprintf("%v/threads/%v?%v", a.pullRequestPath(number), existing.id, azureAPIVersion), map[string]string{"content": body}, nil)
+}
+
+// CreateReviewComments creates a comment thread on each line of the pull request
+func (a *Azure) CreateReviewComments(ctx context.Context, number int, comments []ReviewComment) error {
+	return createReviewComments(ctx, a, number, comments)
+}
+
+func (a *Azure) listReviewComments(ctx context.Context, number int) ([]comment, error) {
+	// threads on files are listed together with the threads of the pull request
+	return a.listComments(ctx, number)
+}
+
+func (a *Azure) createReviewComment(ctx context.Context, number int, path string, line int, body string) error {
+	position := map[string]int{"line": line, "offset": 1}
+	payload := map[string]interface{}{
+		"comments": []map[string]interface{}{{"parentCommentId": 0, "content": body, "commentType": 1}},
+		"status":   1,
+		"threadContext": map[string]interface{}{
+			"filePath":       "/" + strings.TrimPrefix(path, "/"),
+			"rightFileStart": position,
+			"rightFileEnd":   position,
+		},
+	}
+	return a.rest.send(http.MethodPost, fmt.Sprintf("%v/threads?%v", a.pullRequestPath(number), azureAPIVersion), payload, nil)
+}
+
+func (a *Azure) repositoryPath() string {
+	return fmt.Sprintf("/%v/_apis/git/repositories/%v", a.project, a.repository)
+}
+
+func (a *Azure) pullRequestPath(number int) string {
+	return fmt.Sprintf("%v/pullRequests/%v", a.repositoryPath(), number)
+}
+
+// UpsertIssue creates or updates a work item of type Issue
+func (a *Azure) UpsertIssue(ctx context.Context, issue Issue) error {
+	return upsertIssue(ctx, a, issue)
+}
+
+type azureWorkItemOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+func (a *Azure) findIssue(ctx context.Context, title string) (*existingIssue, error) {
+	query := fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.WorkItemType] = 'Issue' AND [System.Title] = '%v' AND [System.State] <> 'Closed' AND [System.State] <> 'Done' AND [System.State] <> 'Removed'",
+		strings.ReplaceAll(title, "'", "''"))
+	result := struct {
+		WorkItems []struct {
+			ID int `json:"id"`
+		} `json:"workItems"`
+	}{}
+	if err := a.rest.send(http.MethodPost, fmt.Sprintf("/%v/_apis/wit/wiql?%v", a.project, azureAPIVersion), map[string]string{"query": query}, &result); err != nil {
+		return nil, err
+	}
+	if len(result.WorkItems) == 0 {
+		return nil, nil
+	}
+	workItem := struct {
+		Fields map[string]interface{} `json:"fields"`
+	}{}
+	id := result.WorkItems[0].ID
+	if err := a.rest.send(http.MethodGet, fmt.Sprintf("/%v/_apis/wit/workitems/%v?%v", a.project, id, azureAPIVersion), nil, &workItem); err != nil {
+		return nil, err
+	}
+	description, _ := workItem.Fields["System.Description"].(string)
+	return &existingIssue{number: id, body: description}, nil
+}
+
+func (a *Azure) createIssue(ctx context.Context, issue Issue) error {
+	operations := []azureWorkItemOperation{
+		{Op: "add", Path: "/fields/System.Title", Value: issue.Title},
+		{Op: "add", Path: "/fields/System.Description", Value: issue.Body},
+	}
+	if len(issue.Assignees) > 0 {
+		// work items can only be assigned to a single user
+		operations = append(operations, azureWorkItemOperation{Op: "add", Path: "/fields/System.AssignedTo", Value: issue.Assignees[0]})
+	}
+	return a.rest.sendContent(http.MethodPost, fmt.Sprintf("/%v/_apis/wit/workitems/$Issue?%v", a.project, azureAPIVersion), "application/json-patch+json", operations, nil)
+}
+
+func (a *Azure) updateIssue(ctx context.Context, existing *existingIssue, body string) error {
+	operations := []azureWorkItemOperation{{Op: "add", Path: "/fields/System.Description", Value: body}}
+	return a.rest.sendContent(http.MethodPatch, fmt.Sprintf("/%v/_apis/wit/workitems/%v?%v", a.project, existing.number, azureAPIVersion), "application/json-patch+json", operations, nil)
+}
+
+func (a *Azure) commentIssue(ctx context.Context, number int, body string) error {
+	return a.rest.send(http.MethodPost, fmt.Sprintf("/%v/_apis/wit/workItems/%v/comments?api-version=7.0-preview.3", a.project, number), map[string]string{"text": body}, nil)
+}
+
+// CreateRelease is not supported by Azure Repos
+func (a *Azure) CreateRelease(ctx context.Context, release Release) (*Release, error) {
+	return nil, errors.Wrap(ErrNotSupported, "Azure Repos has no releases")
+}
+
+// UploadReleaseAsset is not supported by Azure Repos
+func (a *Azure) UploadReleaseAsset(ctx context.Context, release *Release, filePath string) error {
+	return errors.Wrap(ErrNotSupported, "Azure Repos has no releases")
+}
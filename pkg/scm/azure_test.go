@@ -0,0 +1,92 @@
+//go:build unit
+// +build unit
+
+package scm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("commit status", func(t *testing.T) {
+		server, requests := newSCMServer(t, nil)
+		azure := NewAzure(Options{APIURL: server.URL + "/org", Token: "secret", Owner: "project", Repository: "repo"})
+
+		err := azure.SetCommitStatus(ctx, "abc123", CommitStatus{State: StateSuccess, Context: "scan"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 1) {
+			request := (*requests)[0]
+			assert.Equal(t, "POST /org/project/_apis/git/repositories/repo/commits/abc123/statuses?api-version=7.0", request.method+" "+request.path)
+			assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte(":secret")), request.header.Get("Authorization"))
+			assert.Equal(t, "succeeded", decodeBody(t, request.body)["state"])
+		}
+	})
+
+	t.Run("create pull request thread", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /org/project/_apis/git/repositories/repo/pullRequests/5/threads?api-version=7.0": `{"value": [{"id": 1, "comments": [{"id": 1, "content": "LGTM"}]}]}`,
+		})
+		azure := NewAzure(Options{APIURL: server.URL + "/org", Owner: "project", Repository: "repo"})
+
+		err := azure.UpsertPullRequestComment(ctx, 5, "scan", "findings")
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 2) {
+			assert.Equal(t, "POST /org/project/_apis/git/repositories/repo/pullRequests/5/threads?api-version=7.0", (*requests)[1].method+" "+(*requests)[1].path)
+			assert.Contains(t, (*requests)[1].body, `\u003c!-- piper:scan --\u003e`)
+		}
+	})
+
+	t.Run("create thread on line", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /org/project/_apis/git/repositories/repo/pullRequests/5/threads?api-version=7.0": `{"value": []}`,
+		})
+		azure := NewAzure(Options{APIURL: server.URL + "/org", Owner: "project", Repository: "repo"})
+
+		err := azure.CreateReviewComments(ctx, 5, []ReviewComment{{Path: "src/a.go", Line: 3, Marker: "scan:1", Body: "sql injection"}})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 2) {
+			threadContext := decodeBody(t, (*requests)[1].body)["threadContext"].(map[string]interface{})
+			assert.Equal(t, "/src/a.go", threadContext["filePath"])
+			assert.Equal(t, float64(3), threadContext["rightFileStart"].(map[string]interface{})["line"])
+		}
+	})
+
+	t.Run("update work item", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"POST /org/project/_apis/wit/wiql?api-version=7.0":        `{"workItems": [{"id": 12}]}`,
+			"GET /org/project/_apis/wit/workitems/12?api-version=7.0": `{"fields": {"System.Description": "old"}}`,
+		})
+		azure := NewAzure(Options{APIURL: server.URL + "/org", Owner: "project", Repository: "repo"})
+
+		err := azure.UpsertIssue(ctx, Issue{Title: "Owner's scan results", Body: "findings"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 4) {
+			assert.Contains(t, decodeBody(t, (*requests)[0].body)["query"], "[System.Title] = 'Owner''s scan results'")
+			request := (*requests)[2]
+			assert.Equal(t, "PATCH /org/project/_apis/wit/workitems/12?api-version=7.0", request.method+" "+request.path)
+			assert.Equal(t, "application/json-patch+json", request.header.Get("Content-Type"))
+			operations := []azureWorkItemOperation{}
+			assert.NoError(t, json.Unmarshal([]byte(request.body), &operations))
+			assert.Equal(t, []azureWorkItemOperation{{Op: "add", Path: "/fields/System.Description", Value: "findings"}}, operations)
+			assert.Equal(t, "POST /org/project/_apis/wit/workItems/12/comments?api-version=7.0-preview.3", (*requests)[3].method+" "+(*requests)[3].path)
+		}
+	})
+
+	t.Run("releases not supported", func(t *testing.T) {
+		azure := NewAzure(Options{APIURL: "https://dev.azure.com/org"})
+		_, err := azure.CreateRelease(ctx, Release{TagName: "v1.0.0"})
+		assert.ErrorIs(t, err, ErrNotSupported)
+	})
+}
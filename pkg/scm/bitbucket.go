@@ -0,0 +1,144 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/pkg/errors"
+)
+
+// Bitbucket implements Provider for Bitbucket Server and Bitbucket Data Center.
+// Bitbucket Server has neither issues nor releases, issues are usually tracked in Jira.
+type Bitbucket struct {
+	rest       *restClient
+	project    string
+	repository string
+}
+
+// NewBitbucket creates a Bitbucket provider, the APIURL is the URL of the Bitbucket instance
+func NewBitbucket(options Options) *Bitbucket {
+	header := http.Header{"Authorization": []string{"Bearer " + options.Token}}
+	return &Bitbucket{
+		rest:       newRestClient(options, header, piperhttp.ClientOptions{}),
+		project:    url.PathEscape(options.Owner),
+		repository: url.PathEscape(options.Repository),
+	}
+}
+
+var bitbucketStates = map[string]string{
+	StatePending: "INPROGRESS",
+	StateSuccess: "SUCCESSFUL",
+	StateFailure: "FAILED",
+	StateError:   "FAILED",
+}
+
+// SetCommitStatus creates a build status for the commit
+func (b *Bitbucket) SetCommitStatus(ctx context.Context, sha string, status CommitStatus) error {
+	payload := map[string]string{
+		"state":       bitbucketStates[status.State],
+		"key":         status.Context,
+		"name":        status.Context,
+		"description": status.Description,
+		"url":         status.TargetURL,
+	}
+	path := fmt.Sprintf("/rest/api/1.0/projects/%v/repos/%v/commits/%v/builds", b.project, b.repository, sha)
+	if err := b.rest.send(http.MethodPost, path, payload, nil); err != nil {
+		return errors.Wrapf(err, "failed to set status '%v' on commit %v", status.State, sha)
+	}
+	return nil
+}
+
+// UpsertPullRequestComment creates or updates a comment on the pull request
+func (b *Bitbucket) UpsertPullRequestComment(ctx context.Context, number int, marker, body string) error {
+	return upsertPullRequestComment(ctx, b, number, marker, body)
+}
+
+type bitbucketComment struct {
+	ID      int    `json:"id"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+func (b *Bitbucket) listComments(ctx context.Context, number int) ([]comment, error) {
+	comments := []comment{}
+	start := 0
+	for {
+		page := struct {
+			Values []struct {
+				Action  string           `json:"action"`
+				Comment bitbucketComment `json:"comment"`
+			} `json:"values"`
+			IsLastPage    bool `json:"isLastPage"`
+			NextPageStart int  `json:"nextPageStart"`
+		}{}
+		path := fmt.Sprintf("%v/activities?limit=100&start=%v", b.pullRequestPath(number), start)
+		if err := b.rest.send(http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, activity := range page.Values {
+			if activity.Action == "COMMENTED" {
+				comments = append(comments, comment{id: strconv.Itoa(activity.Comment.ID), version: activity.Comment.Version, body: activity.Comment.Text})
+			}
+		}
+		if page.IsLastPage || page.NextPageStart <= start {
+			return comments, nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+func (b *Bitbucket) createComment(ctx context.Context, number int, body string) error {
+	return b.rest.send(http.MethodPost, b.pullRequestPath(number)+"/comments", map[string]string{"text": body}, nil)
+}
+
+func (b *Bitbucket) updateComment(ctx context.Context, number int, existing comment, body string) error {
+	payload := map[string]interface{}{"text": body, "version": existing.version}
+	return b.rest.send(http.MethodPut, fmt.Sprintf("%v/comments/%v", b.pullRequestPath(number), existing.id), payload, nil)
+}
+
+// CreateReviewComments creates comments anchored to the lines of the pull request diff
+func (b *Bitbucket) CreateReviewComments(ctx context.Context, number int, comments []ReviewComment) error {
+	return createReviewComments(ctx, b, number, comments)
+}
+
+func (b *Bitbucket) listReviewComments(ctx context.Context, number int) ([]comment, error) {
+	// the activities of a pull request contain the comments on lines as well
+	return b.listComments(ctx, number)
+}
+
+func (b *Bitbucket) createReviewComment(ctx context.Context, number int, path string, line int, body string) error {
+	payload := map[string]interface{}{
+		"text": body,
+		"anchor": map[string]interface{}{
+			"path":     path,
+			"line":     line,
+			"lineType": "ADDED",
+			"fileType": "TO",
+			"diffType": "EFFECTIVE",
+		},
+	}
+	return b.rest.send(http.MethodPost, b.pullRequestPath(number)+"/comments", payload, nil)
+}
+
+func (b *Bitbucket) pullRequestPath(number int) string {
+	return fmt.Sprintf("/rest/api/1.0/projects/%v/repos/%v/pull-requests/%v", b.project, b.repository, number)
+}
+
+// UpsertIssue is not supported by Bitbucket Server
+func (b *Bitbucket) UpsertIssue(ctx context.Context, issue Issue) error {
+	return errors.Wrap(ErrNotSupported, "Bitbucket Server has no issue tracker")
+}
+
+// CreateRelease is not supported by Bitbucket Server
+func (b *Bitbucket) CreateRelease(ctx context.Context, release Release) (*Release, error) {
+	return nil, errors.Wrap(ErrNotSupported, "Bitbucket Server has no releases")
+}
+
+// UploadReleaseAsset is not supported by Bitbucket Server
+func (b *Bitbucket) UploadReleaseAsset(ctx context.Context, release *Release, filePath string) error {
+	return errors.Wrap(ErrNotSupported, "Bitbucket Server has no releases")
+}
@@ -0,0 +1,73 @@
+//go:build unit
+// +build unit
+
+package scm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitbucket(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("commit status", func(t *testing.T) {
+		server, requests := newSCMServer(t, nil)
+		bitbucket := NewBitbucket(Options{APIURL: server.URL, Token: "secret", Owner: "PRJ", Repository: "repo"})
+
+		err := bitbucket.SetCommitStatus(ctx, "abc123", CommitStatus{State: StatePending, Context: "piper/scan"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 1) {
+			request := (*requests)[0]
+			assert.Equal(t, "POST /rest/api/1.0/projects/PRJ/repos/repo/commits/abc123/builds", request.method+" "+request.path)
+			assert.Equal(t, "Bearer secret", request.header.Get("Authorization"))
+			assert.Equal(t, "INPROGRESS", decodeBody(t, request.body)["state"])
+		}
+	})
+
+	t.Run("update pull request comment", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/5/activities?limit=100&start=0": `{"values": [{"action": "APPROVED"}, {"action": "COMMENTED", "comment": {"id": 9, "version": 2, "text": "old\n\n<!-- piper:scan -->"}}], "isLastPage": true}`,
+		})
+		bitbucket := NewBitbucket(Options{APIURL: server.URL, Owner: "PRJ", Repository: "repo"})
+
+		err := bitbucket.UpsertPullRequestComment(ctx, 5, "scan", "new")
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 2) {
+			request := (*requests)[1]
+			assert.Equal(t, "PUT /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/5/comments/9", request.method+" "+request.path)
+			assert.Equal(t, float64(2), decodeBody(t, request.body)["version"])
+		}
+	})
+
+	t.Run("comment on line", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/5/activities?limit=100&start=0": `{"values": [], "isLastPage": true}`,
+		})
+		bitbucket := NewBitbucket(Options{APIURL: server.URL, Owner: "PRJ", Repository: "repo"})
+
+		err := bitbucket.CreateReviewComments(ctx, 5, []ReviewComment{{Path: "src/a.go", Line: 3, Marker: "scan:1", Body: "sql injection"}})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 2) {
+			request := (*requests)[1]
+			assert.Equal(t, "POST /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/5/comments", request.method+" "+request.path)
+			anchor := decodeBody(t, request.body)["anchor"].(map[string]interface{})
+			assert.Equal(t, "src/a.go", anchor["path"])
+			assert.Equal(t, float64(3), anchor["line"])
+			assert.Equal(t, "ADDED", anchor["lineType"])
+		}
+	})
+
+	t.Run("unsupported operations", func(t *testing.T) {
+		bitbucket := NewBitbucket(Options{APIURL: "https://bitbucket.example.com"})
+		assert.ErrorIs(t, bitbucket.UpsertIssue(ctx, Issue{Title: "Scan results"}), ErrNotSupported)
+		_, err := bitbucket.CreateRelease(ctx, Release{TagName: "v1.0.0"})
+		assert.ErrorIs(t, err, ErrNotSupported)
+	})
+}
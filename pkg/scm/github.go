@@ -0,0 +1,240 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+)
+
+type githubIssueService interface {
+	Create(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	ListComments(ctx context.Context, owner string, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+}
+
+type githubSearchService interface {
+	Issues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error)
+}
+
+type githubRepositoriesService interface {
+	CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error)
+	CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
+	UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, opts *github.UploadOptions, file *os.File) (*github.ReleaseAsset, *github.Response, error)
+}
+
+type githubPullRequestsService interface {
+	Get(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListComments(ctx context.Context, owner string, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.PullRequestComment) (*github.PullRequestComment, *github.Response, error)
+}
+
+// GitHub implements Provider for github.com and GitHub Enterprise Server
+type GitHub struct {
+	Owner        string
+	Repository   string
+	Issues       githubIssueService
+	Search       githubSearchService
+	Repositories githubRepositoriesService
+	PullRequests githubPullRequestsService
+}
+
+// NewGitHub creates a GitHub provider using the GitHub API URL of the options
+func NewGitHub(options Options) (*GitHub, error) {
+	_, client, err := piperGithub.NewClientBuilder(options.Token, options.APIURL).WithTrustedCerts(options.TrustedCerts).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get GitHub client")
+	}
+	return &GitHub{
+		Owner:        options.Owner,
+		Repository:   options.Repository,
+		Issues:       client.Issues,
+		Search:       client.Search,
+		Repositories: client.Repositories,
+		PullRequests: client.PullRequests,
+	}, nil
+}
+
+// SetCommitStatus creates a commit status
+func (g *GitHub) SetCommitStatus(ctx context.Context, sha string, status CommitStatus) error {
+	repoStatus := github.RepoStatus{
+		State:       &status.State,
+		Context:     &status.Context,
+		Description: &status.Description,
+	}
+	if status.TargetURL != "" {
+		repoStatus.TargetURL = &status.TargetURL
+	}
+	if _, _, err := g.Repositories.CreateStatus(ctx, g.Owner, g.Repository, sha, &repoStatus); err != nil {
+		return errors.Wrapf(err, "failed to set status '%v' on commit %v", status.State, sha)
+	}
+	return nil
+}
+
+// UpsertPullRequestComment creates or updates a comment on the pull request
+func (g *GitHub) UpsertPullRequestComment(ctx context.Context, number int, marker, body string) error {
+	return upsertPullRequestComment(ctx, g, number, marker, body)
+}
+
+func (g *GitHub) listComments(ctx context.Context, number int) ([]comment, error) {
+	comments := []comment{}
+	options := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, response, err := g.Issues.ListComments(ctx, g.Owner, g.Repository, number, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page {
+			comments = append(comments, comment{id: strconv.FormatInt(c.GetID(), 10), body: c.GetBody()})
+		}
+		if response == nil || response.NextPage == 0 {
+			return comments, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+func (g *GitHub) createComment(ctx context.Context, number int, body string) error {
+	_, _, err := g.Issues.CreateComment(ctx, g.Owner, g.Repository, number, &github.IssueComment{Body: &body})
+	return err
+}
+
+func (g *GitHub) updateComment(ctx context.Context, number int, existing comment, body string) error {
+	id, err := strconv.ParseInt(existing.id, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, _, err = g.Issues.EditComment(ctx, g.Owner, g.Repository, id, &github.IssueComment{Body: &body})
+	return err
+}
+
+// CreateReviewComments creates review comments on the head commit of the pull request
+func (g *GitHub) CreateReviewComments(ctx context.Context, number int, comments []ReviewComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	// review comments have to reference a commit of the pull request, builds of pull requests usually run on a merge commit
+	pullRequest, _, err := g.PullRequests.Get(ctx, g.Owner, g.Repository, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get pull request %v", number)
+	}
+	return createReviewComments(ctx, &githubReview{GitHub: g, headSHA: pullRequest.GetHead().GetSHA()}, number, comments)
+}
+
+// githubReview creates review comments on the head commit of a pull request
+type githubReview struct {
+	*GitHub
+	headSHA string
+}
+
+func (g *githubReview) listReviewComments(ctx context.Context, number int) ([]comment, error) {
+	comments := []comment{}
+	options := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, response, err := g.PullRequests.ListComments(ctx, g.Owner, g.Repository, number, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page {
+			comments = append(comments, comment{id: strconv.FormatInt(c.GetID(), 10), body: c.GetBody()})
+		}
+		if response == nil || response.NextPage == 0 {
+			return comments, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+func (g *githubReview) createReviewComment(ctx context.Context, number int, path string, line int, body string) error {
+	side := "RIGHT"
+	_, _, err := g.PullRequests.CreateComment(ctx, g.Owner, g.Repository, number, &github.PullRequestComment{
+		Body:     &body,
+		CommitID: &g.headSHA,
+		Path:     &path,
+		Line:     &line,
+		Side:     &side,
+	})
+	return err
+}
+
+// UpsertIssue creates or updates an issue
+func (g *GitHub) UpsertIssue(ctx context.Context, issue Issue) error {
+	return upsertIssue(ctx, g, issue)
+}
+
+func (g *GitHub) findIssue(ctx context.Context, title string) (*existingIssue, error) {
+	query := fmt.Sprintf("is:issue repo:%v/%v in:title %v", g.Owner, g.Repository, title)
+	result, _, err := g.Search.Issues(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred when looking for existing issue: %w", err)
+	}
+	for _, i := range result.Issues {
+		if i != nil && i.GetTitle() == title {
+			return &existingIssue{number: i.GetNumber(), body: i.GetBody(), closed: i.GetState() == "closed"}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *GitHub) createIssue(ctx context.Context, issue Issue) error {
+	request := github.IssueRequest{Title: &issue.Title, Body: &issue.Body, Assignees: &issue.Assignees}
+	_, _, err := g.Issues.Create(ctx, g.Owner, g.Repository, &request)
+	return err
+}
+
+func (g *GitHub) updateIssue(ctx context.Context, existing *existingIssue, body string) error {
+	open := "open"
+	request := github.IssueRequest{Body: &body, State: &open}
+	_, _, err := g.Issues.Edit(ctx, g.Owner, g.Repository, existing.number, &request)
+	return err
+}
+
+func (g *GitHub) commentIssue(ctx context.Context, number int, body string) error {
+	_, _, err := g.Issues.CreateComment(ctx, g.Owner, g.Repository, number, &github.IssueComment{Body: &body})
+	return err
+}
+
+// CreateRelease creates a release
+func (g *GitHub) CreateRelease(ctx context.Context, release Release) (*Release, error) {
+	request := github.RepositoryRelease{
+		TagName:    &release.TagName,
+		Name:       &release.Name,
+		Body:       &release.Body,
+		Draft:      &release.Draft,
+		Prerelease: &release.Prerelease,
+	}
+	if release.Commitish != "" {
+		request.TargetCommitish = &release.Commitish
+	}
+	created, _, err := g.Repositories.CreateRelease(ctx, g.Owner, g.Repository, &request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create release %v", release.TagName)
+	}
+	release.ID = strconv.FormatInt(created.GetID(), 10)
+	release.URL = created.GetHTMLURL()
+	return &release, nil
+}
+
+// UploadReleaseAsset uploads the file as asset of the release
+func (g *GitHub) UploadReleaseAsset(ctx context.Context, release *Release, filePath string) error {
+	id, err := strconv.ParseInt(release.ID, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid release id '%v'", release.ID)
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open release asset %v", filePath)
+	}
+	defer file.Close()
+	if _, _, err := g.Repositories.UploadReleaseAsset(ctx, g.Owner, g.Repository, id, &github.UploadOptions{Name: filepath.Base(filePath)}, file); err != nil {
+		return errors.Wrapf(err, "failed to upload release asset %v", filePath)
+	}
+	return nil
+}
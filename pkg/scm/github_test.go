@@ -0,0 +1,166 @@
+//go:build unit
+// +build unit
+
+package scm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type githubIssueServiceMock struct {
+	comments      []*github.IssueComment
+	editedComment *github.IssueComment
+	editedID      int64
+	created       *github.IssueRequest
+	edited        *github.IssueRequest
+}
+
+func (g *githubIssueServiceMock) Create(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	g.created = issue
+	return &github.Issue{}, nil, nil
+}
+
+func (g *githubIssueServiceMock) Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	g.edited = issue
+	return &github.Issue{}, nil, nil
+}
+
+func (g *githubIssueServiceMock) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	g.comments = append(g.comments, comment)
+	return comment, nil, nil
+}
+
+func (g *githubIssueServiceMock) EditComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	g.editedID = commentID
+	g.editedComment = comment
+	return comment, nil, nil
+}
+
+func (g *githubIssueServiceMock) ListComments(ctx context.Context, owner string, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return g.comments, &github.Response{}, nil
+}
+
+type githubSearchServiceMock struct {
+	query  string
+	issues []*github.Issue
+}
+
+func (g *githubSearchServiceMock) Issues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	g.query = query
+	return &github.IssuesSearchResult{Issues: g.issues}, nil, nil
+}
+
+type githubRepositoriesServiceMock struct {
+	status    *github.RepoStatus
+	release   *github.RepositoryRelease
+	assetName string
+	releaseID int64
+}
+
+func (g *githubRepositoriesServiceMock) CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	g.status = status
+	return status, nil, nil
+}
+
+func (g *githubRepositoriesServiceMock) CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	g.release = release
+	return &github.RepositoryRelease{ID: github.Int64(17), HTMLURL: github.String("https://github.com/owner/repo/releases/v1.0.0")}, nil, nil
+}
+
+func (g *githubRepositoriesServiceMock) UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, opts *github.UploadOptions, file *os.File) (*github.ReleaseAsset, *github.Response, error) {
+	g.releaseID = id
+	g.assetName = opts.Name
+	return &github.ReleaseAsset{}, nil, nil
+}
+
+type githubPullRequestsServiceMock struct {
+	comments []*github.PullRequestComment
+	created  []*github.PullRequestComment
+}
+
+func (g *githubPullRequestsServiceMock) Get(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return &github.PullRequest{Head: &github.PullRequestBranch{SHA: github.String("head123")}}, nil, nil
+}
+
+func (g *githubPullRequestsServiceMock) ListComments(ctx context.Context, owner string, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	return g.comments, &github.Response{}, nil
+}
+
+func (g *githubPullRequestsServiceMock) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.PullRequestComment) (*github.PullRequestComment, *github.Response, error) {
+	g.created = append(g.created, comment)
+	return comment, nil, nil
+}
+
+func TestGitHub(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("commit status", func(t *testing.T) {
+		repositories := &githubRepositoriesServiceMock{}
+		gh := &GitHub{Owner: "owner", Repository: "repo", Repositories: repositories}
+
+		assert.NoError(t, gh.SetCommitStatus(ctx, "abc123", CommitStatus{State: StateFailure, Context: "piper/scan"}))
+		assert.Equal(t, "failure", repositories.status.GetState())
+		assert.Nil(t, repositories.status.TargetURL)
+	})
+
+	t.Run("update pull request comment", func(t *testing.T) {
+		issues := &githubIssueServiceMock{comments: []*github.IssueComment{{ID: github.Int64(5), Body: github.String("old\n\n<!-- piper:scan -->")}}}
+		gh := &GitHub{Owner: "owner", Repository: "repo", Issues: issues}
+
+		assert.NoError(t, gh.UpsertPullRequestComment(ctx, 1, "scan", "new"))
+		assert.Equal(t, int64(5), issues.editedID)
+		assert.Equal(t, "new\n\n<!-- piper:scan -->", issues.editedComment.GetBody())
+	})
+
+	t.Run("review comments", func(t *testing.T) {
+		pullRequests := &githubPullRequestsServiceMock{comments: []*github.PullRequestComment{{ID: github.Int64(5), Body: github.String("xss\n\n<!-- piper:scan:2 -->")}}}
+		gh := &GitHub{Owner: "owner", Repository: "repo", PullRequests: pullRequests}
+
+		err := gh.CreateReviewComments(ctx, 1, []ReviewComment{{Path: "src/a.go", Line: 3, Marker: "scan:1", Body: "sql injection"}, {Path: "src/b.go", Line: 7, Marker: "scan:2", Body: "xss"}})
+
+		assert.NoError(t, err)
+		if assert.Len(t, pullRequests.created, 1) {
+			assert.Equal(t, "head123", pullRequests.created[0].GetCommitID())
+			assert.Equal(t, "src/a.go", pullRequests.created[0].GetPath())
+			assert.Equal(t, 3, pullRequests.created[0].GetLine())
+			assert.Equal(t, "RIGHT", pullRequests.created[0].GetSide())
+		}
+	})
+
+	t.Run("reopen issue", func(t *testing.T) {
+		issues := &githubIssueServiceMock{}
+		search := &githubSearchServiceMock{issues: []*github.Issue{{Number: github.Int(3), Title: github.String("Scan results"), Body: github.String("findings"), State: github.String("closed")}}}
+		gh := &GitHub{Owner: "owner", Repository: "repo", Issues: issues, Search: search}
+
+		assert.NoError(t, gh.UpsertIssue(ctx, Issue{Title: "Scan results", Body: "findings"}))
+		assert.Equal(t, "is:issue repo:owner/repo in:title Scan results", search.query)
+		assert.Equal(t, "open", issues.edited.GetState())
+		assert.Nil(t, issues.created)
+	})
+
+	t.Run("release with asset", func(t *testing.T) {
+		repositories := &githubRepositoriesServiceMock{}
+		gh := &GitHub{Owner: "owner", Repository: "repo", Repositories: repositories}
+		asset := filepath.Join(t.TempDir(), "app.zip")
+		assert.NoError(t, os.WriteFile(asset, []byte("content"), 0644))
+
+		release, err := gh.CreateRelease(ctx, Release{TagName: "v1.0.0", Name: "1.0.0", Prerelease: true})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "17", release.ID)
+			assert.Equal(t, "https://github.com/owner/repo/releases/v1.0.0", release.URL)
+			assert.True(t, repositories.release.GetPrerelease())
+			assert.Nil(t, repositories.release.TargetCommitish)
+		}
+
+		assert.NoError(t, gh.UploadReleaseAsset(ctx, release, asset))
+		assert.Equal(t, int64(17), repositories.releaseID)
+		assert.Equal(t, "app.zip", repositories.assetName)
+	})
+}
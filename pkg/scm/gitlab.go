@@ -0,0 +1,272 @@
+package scm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/pkg/errors"
+)
+
+// GitLab implements Provider for gitlab.com and self-managed GitLab instances
+type GitLab struct {
+	rest    *restClient
+	project string
+}
+
+// NewGitLab creates a GitLab provider, the APIURL is the v4 API, e.g. https://gitlab.com/api/v4
+func NewGitLab(options Options) *GitLab {
+	header := http.Header{"Private-Token": []string{options.Token}}
+	return &GitLab{
+		rest:    newRestClient(options, header, piperhttp.ClientOptions{}),
+		project: url.PathEscape(options.Owner + "/" + options.Repository),
+	}
+}
+
+var gitlabStates = map[string]string{
+	StatePending: "pending",
+	StateSuccess: "success",
+	StateFailure: "failed",
+	StateError:   "failed",
+}
+
+// SetCommitStatus creates a commit status, GitLab shows it as external job of the pipeline
+func (g *GitLab) SetCommitStatus(ctx context.Context, sha string, status CommitStatus) error {
+	payload := map[string]string{
+		"state":       gitlabStates[status.State],
+		"name":        status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	}
+	if err := g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/statuses/%v", g.project, sha), payload, nil); err != nil {
+		return errors.Wrapf(err, "failed to set status '%v' on commit %v", status.State, sha)
+	}
+	return nil
+}
+
+type gitlabNote struct {
+	ID     int    `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+}
+
+// UpsertPullRequestComment creates or updates a note on the merge request
+func (g *GitLab) UpsertPullRequestComment(ctx context.Context, number int, marker, body string) error {
+	return upsertPullRequestComment(ctx, g, number, marker, body)
+}
+
+func (g *GitLab) listComments(ctx context.Context, number int) ([]comment, error) {
+	comments := []comment{}
+	for page := 1; ; page++ {
+		notes := []gitlabNote{}
+		if err := g.rest.send(http.MethodGet, fmt.Sprintf("/projects/%v/merge_requests/%v/notes?per_page=100&page=%v", g.project, number, page), nil, &notes); err != nil {
+			return nil, err
+		}
+		for _, note := range notes {
+			if !note.System {
+				comments = append(comments, comment{id: strconv.Itoa(note.ID), body: note.Body})
+			}
+		}
+		if len(notes) < 100 {
+			return comments, nil
+		}
+	}
+}
+
+func (g *GitLab) createComment(ctx context.Context, number int, body string) error {
+	return g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/merge_requests/%v/notes", g.project, number), map[string]string{"body": body}, nil)
+}
+
+func (g *GitLab) updateComment(ctx context.Context, number int, existing comment, body string) error {
+	return g.rest.send(http.MethodPut, fmt.Sprintf("/projects/%v/merge_requests/%v/notes/%v", g.project, number, existing.id), map[string]string{"body": body}, nil)
+}
+
+type gitlabDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// CreateReviewComments starts a discussion on each line of the merge request diff
+func (g *GitLab) CreateReviewComments(ctx context.Context, number int, comments []ReviewComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	// the position of a diff note references the versions of the merge request diff
+	mergeRequest := struct {
+		DiffRefs gitlabDiffRefs `json:"diff_refs"`
+	}{}
+	if err := g.rest.send(http.MethodGet, fmt.Sprintf("/projects/%v/merge_requests/%v", g.project, number), nil, &mergeRequest); err != nil {
+		return errors.Wrapf(err, "failed to get merge request %v", number)
+	}
+	return createReviewComments(ctx, &gitlabReview{GitLab: g, diffRefs: mergeRequest.DiffRefs}, number, comments)
+}
+
+// gitlabReview creates diff notes on a version of the merge request diff
+type gitlabReview struct {
+	*GitLab
+	diffRefs gitlabDiffRefs
+}
+
+func (g *gitlabReview) listReviewComments(ctx context.Context, number int) ([]comment, error) {
+	// diff notes are part of the notes of the merge request
+	return g.listComments(ctx, number)
+}
+
+func (g *gitlabReview) createReviewComment(ctx context.Context, number int, path string, line int, body string) error {
+	payload := map[string]interface{}{
+		"body": body,
+		"position": map[string]interface{}{
+			"position_type": "text",
+			"base_sha":      g.diffRefs.BaseSHA,
+			"head_sha":      g.diffRefs.HeadSHA,
+			"start_sha":     g.diffRefs.StartSHA,
+			"old_path":      path,
+			"new_path":      path,
+			"new_line":      line,
+		},
+	}
+	return g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/merge_requests/%v/discussions", g.project, number), payload, nil)
+}
+
+type gitlabIssue struct {
+	IID         int    `json:"iid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+// UpsertIssue creates or updates an issue
+func (g *GitLab) UpsertIssue(ctx context.Context, issue Issue) error {
+	return upsertIssue(ctx, g, issue)
+}
+
+func (g *GitLab) findIssue(ctx context.Context, title string) (*existingIssue, error) {
+	issues := []gitlabIssue{}
+	query := url.Values{"search": []string{title}, "in": []string{"title"}, "per_page": []string{"100"}}
+	if err := g.rest.send(http.MethodGet, fmt.Sprintf("/projects/%v/issues?%v", g.project, query.Encode()), nil, &issues); err != nil {
+		return nil, err
+	}
+	for _, i := range issues {
+		if i.Title == title {
+			return &existingIssue{number: i.IID, body: i.Description, closed: i.State == "closed"}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *GitLab) createIssue(ctx context.Context, issue Issue) error {
+	payload := map[string]interface{}{"title": issue.Title, "description": issue.Body}
+	if len(issue.Assignees) > 0 {
+		ids, err := g.userIDs(issue.Assignees)
+		if err != nil {
+			return err
+		}
+		payload["assignee_ids"] = ids
+	}
+	return g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/issues", g.project), payload, nil)
+}
+
+// userIDs resolves user names since GitLab assigns issues by user id
+func (g *GitLab) userIDs(usernames []string) ([]int, error) {
+	ids := []int{}
+	for _, username := range usernames {
+		users := []struct {
+			ID int `json:"id"`
+		}{}
+		if err := g.rest.send(http.MethodGet, "/users?username="+url.QueryEscape(username), nil, &users); err != nil {
+			return nil, errors.Wrapf(err, "failed to look up user %v", username)
+		}
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (g *GitLab) updateIssue(ctx context.Context, existing *existingIssue, body string) error {
+	payload := map[string]string{"description": body}
+	if existing.closed {
+		payload["state_event"] = "reopen"
+	}
+	return g.rest.send(http.MethodPut, fmt.Sprintf("/projects/%v/issues/%v", g.project, existing.number), payload, nil)
+}
+
+func (g *GitLab) commentIssue(ctx context.Context, number int, body string) error {
+	return g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/issues/%v/notes", g.project, number), map[string]string{"body": body}, nil)
+}
+
+// CreateRelease creates a release, GitLab creates the tag from the commitish if it does not exist
+func (g *GitLab) CreateRelease(ctx context.Context, release Release) (*Release, error) {
+	if release.Draft {
+		return nil, errors.Wrap(ErrNotSupported, "GitLab has no draft releases")
+	}
+	payload := map[string]string{
+		"tag_name":    release.TagName,
+		"name":        release.Name,
+		"description": release.Body,
+	}
+	if release.Commitish != "" {
+		payload["ref"] = release.Commitish
+	}
+	created := struct {
+		Links struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}{}
+	if err := g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/releases", g.project), payload, &created); err != nil {
+		return nil, errors.Wrapf(err, "failed to create release %v", release.TagName)
+	}
+	release.ID = release.TagName
+	release.URL = created.Links.Self
+	return &release, nil
+}
+
+// UploadReleaseAsset uploads the file to the project and links it as asset of the release
+func (g *GitLab) UploadReleaseAsset(ctx context.Context, release *Release, filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read release asset %v", filePath)
+	}
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if err == nil {
+		_, err = part.Write(content)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to prepare upload of release asset %v", filePath)
+	}
+
+	uploaded := struct {
+		FullPath string `json:"full_path"`
+	}{}
+	if err := g.rest.sendContent(http.MethodPost, fmt.Sprintf("/projects/%v/uploads", g.project), writer.FormDataContentType(), body, &uploaded); err != nil {
+		return errors.Wrapf(err, "failed to upload release asset %v", filePath)
+	}
+
+	link := map[string]string{"name": filepath.Base(filePath), "url": g.instanceURL() + uploaded.FullPath}
+	if err := g.rest.send(http.MethodPost, fmt.Sprintf("/projects/%v/releases/%v/assets/links", g.project, url.PathEscape(release.TagName)), link, nil); err != nil {
+		return errors.Wrapf(err, "failed to link release asset %v", filePath)
+	}
+	return nil
+}
+
+// instanceURL returns the URL of the GitLab instance without the API path
+func (g *GitLab) instanceURL() string {
+	u, err := url.Parse(g.rest.baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,169 @@
+//go:build unit
+// +build unit
+
+package scm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+// newSCMServer serves the given responses per "<METHOD> <request URI>" and records all requests
+func newSCMServer(t *testing.T, responses map[string]string) (*httptest.Server, *[]recordedRequest) {
+	requests := []recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{method: r.Method, path: r.URL.RequestURI(), header: r.Header, body: string(body)})
+		response, ok := responses[r.Method+" "+r.URL.RequestURI()]
+		if !ok {
+			response = "{}"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func decodeBody(t *testing.T, body string) map[string]interface{} {
+	payload := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &payload))
+	return payload
+}
+
+func TestGitLab(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("commit status", func(t *testing.T) {
+		server, requests := newSCMServer(t, nil)
+		gitlab := NewGitLab(Options{APIURL: server.URL + "/api/v4", Token: "secret", Owner: "group", Repository: "project"})
+
+		err := gitlab.SetCommitStatus(ctx, "abc123", CommitStatus{State: StateFailure, Context: "piper/scan", Description: "findings"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 1) {
+			request := (*requests)[0]
+			assert.Equal(t, "POST /api/v4/projects/group%2Fproject/statuses/abc123", request.method+" "+request.path)
+			assert.Equal(t, "secret", request.header.Get("Private-Token"))
+			assert.Equal(t, "failed", decodeBody(t, request.body)["state"])
+			assert.Equal(t, "piper/scan", decodeBody(t, request.body)["name"])
+		}
+	})
+
+	t.Run("merge request diff notes", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /api/v4/projects/group%2Fproject/merge_requests/5":                           `{"diff_refs": {"base_sha": "base", "head_sha": "head", "start_sha": "start"}}`,
+			"GET /api/v4/projects/group%2Fproject/merge_requests/5/notes?per_page=100&page=1": `[]`,
+		})
+		gitlab := NewGitLab(Options{APIURL: server.URL + "/api/v4", Owner: "group", Repository: "project"})
+
+		err := gitlab.CreateReviewComments(ctx, 5, []ReviewComment{{Path: "src/a.go", Line: 3, Marker: "scan:1", Body: "sql injection"}})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 3) {
+			request := (*requests)[2]
+			assert.Equal(t, "POST /api/v4/projects/group%2Fproject/merge_requests/5/discussions", request.method+" "+request.path)
+			position := decodeBody(t, request.body)["position"].(map[string]interface{})
+			assert.Equal(t, "head", position["head_sha"])
+			assert.Equal(t, "src/a.go", position["new_path"])
+			assert.Equal(t, float64(3), position["new_line"])
+		}
+	})
+
+	t.Run("update merge request note", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /api/v4/projects/group%2Fproject/merge_requests/5/notes?per_page=100&page=1": `[{"id": 1, "body": "changed title", "system": true}, {"id": 2, "body": "old\n\n<!-- piper:scan -->"}]`,
+		})
+		gitlab := NewGitLab(Options{APIURL: server.URL + "/api/v4", Owner: "group", Repository: "project"})
+
+		err := gitlab.UpsertPullRequestComment(ctx, 5, "scan", "new")
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 2) {
+			assert.Equal(t, "PUT /api/v4/projects/group%2Fproject/merge_requests/5/notes/2", (*requests)[1].method+" "+(*requests)[1].path)
+			assert.Equal(t, "new\n\n<!-- piper:scan -->", decodeBody(t, (*requests)[1].body)["body"])
+		}
+	})
+
+	t.Run("create issue", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /api/v4/projects/group%2Fproject/issues?in=title&per_page=100&search=Scan+results": `[{"iid": 1, "title": "Scan results of other"}]`,
+			"GET /api/v4/users?username=alice": `[{"id": 42}]`,
+		})
+		gitlab := NewGitLab(Options{APIURL: server.URL + "/api/v4", Owner: "group", Repository: "project"})
+
+		err := gitlab.UpsertIssue(ctx, Issue{Title: "Scan results", Body: "findings", Assignees: []string{"alice"}})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 3) {
+			request := (*requests)[2]
+			assert.Equal(t, "POST /api/v4/projects/group%2Fproject/issues", request.method+" "+request.path)
+			payload := decodeBody(t, request.body)
+			assert.Equal(t, "findings", payload["description"])
+			assert.Equal(t, []interface{}{float64(42)}, payload["assignee_ids"])
+		}
+	})
+
+	t.Run("reopen closed issue", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"GET /api/v4/projects/group%2Fproject/issues?in=title&per_page=100&search=Scan+results": `[{"iid": 3, "title": "Scan results", "description": "old", "state": "closed"}]`,
+		})
+		gitlab := NewGitLab(Options{APIURL: server.URL + "/api/v4", Owner: "group", Repository: "project"})
+
+		err := gitlab.UpsertIssue(ctx, Issue{Title: "Scan results", Body: "findings"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 3) {
+			assert.Equal(t, "PUT /api/v4/projects/group%2Fproject/issues/3", (*requests)[1].method+" "+(*requests)[1].path)
+			assert.Equal(t, "reopen", decodeBody(t, (*requests)[1].body)["state_event"])
+			assert.Equal(t, "POST /api/v4/projects/group%2Fproject/issues/3/notes", (*requests)[2].method+" "+(*requests)[2].path)
+		}
+	})
+
+	t.Run("release with asset", func(t *testing.T) {
+		server, requests := newSCMServer(t, map[string]string{
+			"POST /api/v4/projects/group%2Fproject/releases": `{"_links": {"self": "https://gitlab.example.com/group/project/-/releases/v1.0.0"}}`,
+			"POST /api/v4/projects/group%2Fproject/uploads":  `{"full_path": "/group/project/uploads/1234/app.zip"}`,
+		})
+		gitlab := NewGitLab(Options{APIURL: server.URL + "/api/v4", Owner: "group", Repository: "project"})
+		asset := filepath.Join(t.TempDir(), "app.zip")
+		assert.NoError(t, os.WriteFile(asset, []byte("content"), 0644))
+
+		release, err := gitlab.CreateRelease(ctx, Release{TagName: "v1.0.0", Name: "1.0.0", Commitish: "main"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://gitlab.example.com/group/project/-/releases/v1.0.0", release.URL)
+
+		err = gitlab.UploadReleaseAsset(ctx, release, asset)
+		assert.NoError(t, err)
+		if assert.Len(t, *requests, 3) {
+			assert.Equal(t, "main", decodeBody(t, (*requests)[0].body)["ref"])
+			assert.True(t, strings.HasPrefix((*requests)[1].header.Get("Content-Type"), "multipart/form-data"))
+			assert.Contains(t, (*requests)[1].body, "content")
+			assert.Equal(t, "POST /api/v4/projects/group%2Fproject/releases/v1.0.0/assets/links", (*requests)[2].method+" "+(*requests)[2].path)
+			assert.Equal(t, server.URL+"/group/project/uploads/1234/app.zip", decodeBody(t, (*requests)[2].body)["url"])
+		}
+	})
+
+	t.Run("draft release", func(t *testing.T) {
+		gitlab := NewGitLab(Options{APIURL: "https://gitlab.example.com/api/v4"})
+		_, err := gitlab.CreateRelease(ctx, Release{TagName: "v1.0.0", Draft: true})
+		assert.ErrorIs(t, err, ErrNotSupported)
+	})
+}
@@ -0,0 +1,67 @@
+package scm
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/pkg/errors"
+)
+
+// restClient sends JSON requests to the REST API of an SCM
+type restClient struct {
+	client  piperhttp.Sender
+	baseURL string
+	header  http.Header
+}
+
+func newRestClient(options Options, header http.Header, clientOptions piperhttp.ClientOptions) *restClient {
+	client := &piperhttp.Client{}
+	clientOptions.TrustedCerts = options.TrustedCerts
+	client.SetOptions(clientOptions)
+	return &restClient{client: client, baseURL: strings.TrimSuffix(options.APIURL, "/"), header: header}
+}
+
+// send sends the payload as JSON and decodes the response into result unless it is nil
+func (r *restClient) send(method, path string, payload, result interface{}) error {
+	return r.sendContent(method, path, "application/json", payload, result)
+}
+
+func (r *restClient) sendContent(method, path, contentType string, payload, result interface{}) error {
+	header := r.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Accept", "application/json")
+
+	var body io.Reader
+	switch p := payload.(type) {
+	case nil:
+	case io.Reader:
+		body = p
+		header.Set("Content-Type", contentType)
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+		header.Set("Content-Type", contentType)
+	}
+
+	response, err := r.client.SendRequest(method, r.baseURL+path, body, header, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return errors.Wrapf(err, "failed to decode response of %v %v", method, path)
+	}
+	return nil
+}
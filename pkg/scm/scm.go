@@ -0,0 +1,240 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Supported SCM providers
+const (
+	ProviderGitHub    = "github"
+	ProviderGitLab    = "gitlab"
+	ProviderBitbucket = "bitbucket"
+	ProviderAzure     = "azure"
+)
+
+// Commit states, providers map them to their own vocabulary
+const (
+	StatePending = "pending"
+	StateSuccess = "success"
+	StateFailure = "failure"
+	StateError   = "error"
+)
+
+// ErrNotSupported is returned for operations the SCM does not offer, e.g. releases on Bitbucket Server
+var ErrNotSupported = errors.New("operation is not supported by the SCM provider")
+
+// Provider abstracts the operations of an SCM hosting the repository of the pipeline
+type Provider interface {
+	// SetCommitStatus reports the status of a check for the given commit
+	SetCommitStatus(ctx context.Context, sha string, status CommitStatus) error
+	// UpsertPullRequestComment creates a comment on the pull request or updates the comment created before with the same marker
+	UpsertPullRequestComment(ctx context.Context, number int, marker, body string) error
+	// CreateReviewComments comments on lines of the pull request, comments whose marker is already present on the pull request are skipped
+	CreateReviewComments(ctx context.Context, number int, comments []ReviewComment) error
+	// UpsertIssue creates an issue or updates the open issue with the same title
+	UpsertIssue(ctx context.Context, issue Issue) error
+	// CreateRelease creates a release for the tag
+	CreateRelease(ctx context.Context, release Release) (*Release, error)
+	// UploadReleaseAsset attaches the file to a release created before
+	UploadReleaseAsset(ctx context.Context, release *Release, filePath string) error
+}
+
+// Options to create a Provider
+type Options struct {
+	Provider string
+	// APIURL of the SCM, e.g. https://api.github.com, https://gitlab.com/api/v4, https://bitbucket.example.com or https://dev.azure.com/<organization>
+	APIURL string
+	Token  string
+	// Owner is the organization or user on GitHub, the group on GitLab, the project key on Bitbucket and the project on Azure DevOps
+	Owner      string
+	Repository string
+	// TrustedCerts are downloaded and added to the trust store of the HTTP client
+	TrustedCerts []string
+}
+
+// CommitStatus describes the result of a check for a commit
+type CommitStatus struct {
+	State       string
+	Context     string
+	Description string
+	TargetURL   string
+}
+
+// ReviewComment on a line of a file changed by a pull request
+type ReviewComment struct {
+	// Path of the file relative to the root of the repository
+	Path string
+	// Line of the file after the change
+	Line int
+	// Marker identifies the comment so that it is not created again by later runs
+	Marker string
+	Body   string
+}
+
+// Issue to be created or updated
+type Issue struct {
+	Title     string
+	Body      string
+	Assignees []string
+}
+
+// Release of a repository
+type Release struct {
+	ID         string
+	TagName    string
+	Name       string
+	Body       string
+	Commitish  string
+	Draft      bool
+	Prerelease bool
+	URL        string
+}
+
+// IsGitHub tells whether the provider is GitHub, which is used if no provider is configured
+func IsGitHub(provider string) bool {
+	return provider == "" || strings.EqualFold(provider, ProviderGitHub)
+}
+
+// NewProvider creates the provider for the configured SCM
+func NewProvider(options Options) (Provider, error) {
+	if IsGitHub(options.Provider) {
+		return NewGitHub(options)
+	}
+	switch strings.ToLower(options.Provider) {
+	case ProviderGitLab:
+		return NewGitLab(options), nil
+	case ProviderBitbucket:
+		return NewBitbucket(options), nil
+	case ProviderAzure:
+		return NewAzure(options), nil
+	}
+	return nil, fmt.Errorf("SCM provider '%v' is not supported", options.Provider)
+}
+
+// CommitURL returns the API URL of the commit in the repository of the configured SCM
+func CommitURL(options Options, sha string) string {
+	switch strings.ToLower(options.Provider) {
+	case ProviderGitLab:
+		return fmt.Sprintf("%v/projects/%v/repository/commits/%v", options.APIURL, url.PathEscape(options.Owner+"/"+options.Repository), sha)
+	case ProviderBitbucket:
+		return fmt.Sprintf("%v/rest/api/1.0/projects/%v/repos/%v/commits/%v", options.APIURL, url.PathEscape(options.Owner), url.PathEscape(options.Repository), sha)
+	case ProviderAzure:
+		return fmt.Sprintf("%v/%v/_apis/git/repositories/%v/commits/%v", options.APIURL, url.PathEscape(options.Owner), url.PathEscape(options.Repository), sha)
+	}
+	return fmt.Sprintf("%v/repos/%v/%v/commits/%v", options.APIURL, options.Owner, options.Repository, sha)
+}
+
+// markerComment identifies comments created by piper so that they can be updated instead of adding new ones
+func markerComment(marker string) string {
+	return fmt.Sprintf("<!-- piper:%v -->", marker)
+}
+
+// withMarker appends the marker to the body of a comment
+func withMarker(marker, body string) string {
+	return body + "\n\n" + markerComment(marker)
+}
+
+type comment struct {
+	id      string
+	version int
+	body    string
+}
+
+// commentTracker provides the comment operations of a provider on a pull request
+type commentTracker interface {
+	listComments(ctx context.Context, number int) ([]comment, error)
+	createComment(ctx context.Context, number int, body string) error
+	updateComment(ctx context.Context, number int, existing comment, body string) error
+}
+
+func upsertPullRequestComment(ctx context.Context, tracker commentTracker, number int, marker, body string) error {
+	comments, err := tracker.listComments(ctx, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list comments of pull request %v", number)
+	}
+	body = withMarker(marker, body)
+	for _, existing := range comments {
+		if strings.Contains(existing.body, markerComment(marker)) {
+			if existing.body == body {
+				return nil
+			}
+			return errors.Wrapf(tracker.updateComment(ctx, number, existing, body), "failed to update comment of pull request %v", number)
+		}
+	}
+	return errors.Wrapf(tracker.createComment(ctx, number, body), "failed to comment on pull request %v", number)
+}
+
+// reviewTracker provides the line comment operations of a provider on a pull request
+type reviewTracker interface {
+	listReviewComments(ctx context.Context, number int) ([]comment, error)
+	createReviewComment(ctx context.Context, number int, path string, line int, body string) error
+}
+
+func createReviewComments(ctx context.Context, tracker reviewTracker, number int, comments []ReviewComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	existing, err := tracker.listReviewComments(ctx, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list review comments of pull request %v", number)
+	}
+	for _, c := range comments {
+		if containsMarker(existing, c.Marker) {
+			continue
+		}
+		if err := tracker.createReviewComment(ctx, number, c.Path, c.Line, withMarker(c.Marker, c.Body)); err != nil {
+			return errors.Wrapf(err, "failed to comment on line %v of %v in pull request %v", c.Line, c.Path, number)
+		}
+	}
+	return nil
+}
+
+func containsMarker(comments []comment, marker string) bool {
+	for _, c := range comments {
+		if strings.Contains(c.body, markerComment(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+type existingIssue struct {
+	number int
+	body   string
+	closed bool
+}
+
+// issueTracker provides the issue operations of a provider
+type issueTracker interface {
+	findIssue(ctx context.Context, title string) (*existingIssue, error)
+	createIssue(ctx context.Context, issue Issue) error
+	updateIssue(ctx context.Context, existing *existingIssue, body string) error
+	commentIssue(ctx context.Context, number int, body string) error
+}
+
+// upsertIssue creates the issue or reopens and updates the existing one with the same title.
+// An update is noted with a comment so that subscribers are notified.
+func upsertIssue(ctx context.Context, tracker issueTracker, issue Issue) error {
+	existing, err := tracker.findIssue(ctx, issue.Title)
+	if err != nil {
+		return errors.Wrap(err, "error when looking up issue")
+	}
+	if existing == nil {
+		return errors.Wrap(tracker.createIssue(ctx, issue), "failed to create issue")
+	}
+	if existing.body == issue.Body && !existing.closed {
+		return nil
+	}
+	if err := tracker.updateIssue(ctx, existing, issue.Body); err != nil {
+		return errors.Wrap(err, "failed to edit issue")
+	}
+	if existing.body != issue.Body {
+		return errors.Wrap(tracker.commentIssue(ctx, existing.number, "issue content has been updated"), "failed to create comment")
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+//go:build unit
+// +build unit
+
+package scm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type commentTrackerMock struct {
+	comments []comment
+	created  []string
+	updated  map[string]string
+	listErr  error
+}
+
+func (c *commentTrackerMock) listComments(ctx context.Context, number int) ([]comment, error) {
+	return c.comments, c.listErr
+}
+
+func (c *commentTrackerMock) createComment(ctx context.Context, number int, body string) error {
+	c.created = append(c.created, body)
+	return nil
+}
+
+func (c *commentTrackerMock) updateComment(ctx context.Context, number int, existing comment, body string) error {
+	if c.updated == nil {
+		c.updated = map[string]string{}
+	}
+	c.updated[existing.id] = body
+	return nil
+}
+
+type issueTrackerMock struct {
+	existing  *existingIssue
+	findErr   error
+	created   []Issue
+	updated   []string
+	commented []string
+}
+
+func (i *issueTrackerMock) findIssue(ctx context.Context, title string) (*existingIssue, error) {
+	return i.existing, i.findErr
+}
+
+func (i *issueTrackerMock) createIssue(ctx context.Context, issue Issue) error {
+	i.created = append(i.created, issue)
+	return nil
+}
+
+func (i *issueTrackerMock) updateIssue(ctx context.Context, existing *existingIssue, body string) error {
+	i.updated = append(i.updated, body)
+	return nil
+}
+
+func (i *issueTrackerMock) commentIssue(ctx context.Context, number int, body string) error {
+	i.commented = append(i.commented, body)
+	return nil
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Parallel()
+	t.Run("supported providers", func(t *testing.T) {
+		for providerType, expected := range map[string]interface{}{
+			"":          &GitHub{},
+			"github":    &GitHub{},
+			"GitLab":    &GitLab{},
+			"bitbucket": &Bitbucket{},
+			"azure":     &Azure{},
+		} {
+			provider, err := NewProvider(Options{Provider: providerType, APIURL: "https://scm.example.com", Token: "token", Owner: "owner", Repository: "repo"})
+			if assert.NoError(t, err, providerType) {
+				assert.IsType(t, expected, provider, providerType)
+			}
+		}
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		_, err := NewProvider(Options{Provider: "svn"})
+		assert.EqualError(t, err, "SCM provider 'svn' is not supported")
+	})
+}
+
+func TestCommitURL(t *testing.T) {
+	tests := []struct {
+		provider string
+		apiURL   string
+		expected string
+	}{
+		{"", "https://api.github.com", "https://api.github.com/repos/my-org/my-repo/commits/abc123"},
+		{ProviderGitHub, "https://github.example.com/api/v3", "https://github.example.com/api/v3/repos/my-org/my-repo/commits/abc123"},
+		{ProviderGitLab, "https://gitlab.com/api/v4", "https://gitlab.com/api/v4/projects/my-org%2Fmy-repo/repository/commits/abc123"},
+		{ProviderBitbucket, "https://bitbucket.example.com", "https://bitbucket.example.com/rest/api/1.0/projects/my-org/repos/my-repo/commits/abc123"},
+		{ProviderAzure, "https://dev.azure.com/my-collection", "https://dev.azure.com/my-collection/my-org/_apis/git/repositories/my-repo/commits/abc123"},
+	}
+	for _, test := range tests {
+		options := Options{Provider: test.provider, APIURL: test.apiURL, Owner: "my-org", Repository: "my-repo"}
+		assert.Equal(t, test.expected, CommitURL(options, "abc123"))
+	}
+}
+
+func TestUpsertPullRequestComment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("create comment", func(t *testing.T) {
+		tracker := &commentTrackerMock{comments: []comment{{id: "1", body: "LGTM"}}}
+		assert.NoError(t, upsertPullRequestComment(ctx, tracker, 1, "scan", "findings"))
+		assert.Equal(t, []string{"findings\n\n<!-- piper:scan -->"}, tracker.created)
+		assert.Empty(t, tracker.updated)
+	})
+
+	t.Run("update comment with marker", func(t *testing.T) {
+		tracker := &commentTrackerMock{comments: []comment{{id: "1", body: "LGTM"}, {id: "2", body: "old findings\n\n<!-- piper:scan -->"}}}
+		assert.NoError(t, upsertPullRequestComment(ctx, tracker, 1, "scan", "findings"))
+		assert.Empty(t, tracker.created)
+		assert.Equal(t, map[string]string{"2": "findings\n\n<!-- piper:scan -->"}, tracker.updated)
+	})
+
+	t.Run("unchanged comment", func(t *testing.T) {
+		tracker := &commentTrackerMock{comments: []comment{{id: "2", body: "findings\n\n<!-- piper:scan -->"}}}
+		assert.NoError(t, upsertPullRequestComment(ctx, tracker, 1, "scan", "findings"))
+		assert.Empty(t, tracker.created)
+		assert.Empty(t, tracker.updated)
+	})
+
+	t.Run("comment with other marker", func(t *testing.T) {
+		tracker := &commentTrackerMock{comments: []comment{{id: "2", body: "findings\n\n<!-- piper:other -->"}}}
+		assert.NoError(t, upsertPullRequestComment(ctx, tracker, 1, "scan", "findings"))
+		assert.Len(t, tracker.created, 1)
+	})
+
+	t.Run("error listing comments", func(t *testing.T) {
+		tracker := &commentTrackerMock{listErr: fmt.Errorf("list error")}
+		assert.EqualError(t, upsertPullRequestComment(ctx, tracker, 7, "scan", "findings"), "failed to list comments of pull request 7: list error")
+	})
+}
+
+type reviewTrackerMock struct {
+	comments []comment
+	created  []string
+	listErr  error
+}
+
+func (r *reviewTrackerMock) listReviewComments(ctx context.Context, number int) ([]comment, error) {
+	return r.comments, r.listErr
+}
+
+func (r *reviewTrackerMock) createReviewComment(ctx context.Context, number int, path string, line int, body string) error {
+	r.created = append(r.created, fmt.Sprintf("%v:%v %v", path, line, body))
+	return nil
+}
+
+func TestCreateReviewComments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("skip comments created before", func(t *testing.T) {
+		tracker := &reviewTrackerMock{comments: []comment{{id: "1", body: "sql injection\n\n<!-- piper:scan:1 -->"}}}
+		comments := []ReviewComment{
+			{Path: "src/a.go", Line: 3, Marker: "scan:1", Body: "sql injection"},
+			{Path: "src/b.go", Line: 7, Marker: "scan:2", Body: "xss"},
+		}
+		assert.NoError(t, createReviewComments(ctx, tracker, 1, comments))
+		assert.Equal(t, []string{"src/b.go:7 xss\n\n<!-- piper:scan:2 -->"}, tracker.created)
+	})
+
+	t.Run("no comments", func(t *testing.T) {
+		tracker := &reviewTrackerMock{listErr: fmt.Errorf("list error")}
+		assert.NoError(t, createReviewComments(ctx, tracker, 1, nil))
+	})
+
+	t.Run("error listing comments", func(t *testing.T) {
+		tracker := &reviewTrackerMock{listErr: fmt.Errorf("list error")}
+		err := createReviewComments(ctx, tracker, 7, []ReviewComment{{Path: "src/a.go", Line: 3, Marker: "scan:1"}})
+		assert.EqualError(t, err, "failed to list review comments of pull request 7: list error")
+	})
+}
+
+func TestUpsertIssue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	issue := Issue{Title: "Scan results", Body: "findings", Assignees: []string{"alice"}}
+
+	t.Run("create issue", func(t *testing.T) {
+		tracker := &issueTrackerMock{}
+		assert.NoError(t, upsertIssue(ctx, tracker, issue))
+		assert.Equal(t, []Issue{issue}, tracker.created)
+	})
+
+	t.Run("update issue", func(t *testing.T) {
+		tracker := &issueTrackerMock{existing: &existingIssue{number: 3, body: "old findings"}}
+		assert.NoError(t, upsertIssue(ctx, tracker, issue))
+		assert.Empty(t, tracker.created)
+		assert.Equal(t, []string{"findings"}, tracker.updated)
+		assert.Equal(t, []string{"issue content has been updated"}, tracker.commented)
+	})
+
+	t.Run("reopen issue", func(t *testing.T) {
+		tracker := &issueTrackerMock{existing: &existingIssue{number: 3, body: "findings", closed: true}}
+		assert.NoError(t, upsertIssue(ctx, tracker, issue))
+		assert.Equal(t, []string{"findings"}, tracker.updated)
+		assert.Empty(t, tracker.commented)
+	})
+
+	t.Run("unchanged issue", func(t *testing.T) {
+		tracker := &issueTrackerMock{existing: &existingIssue{number: 3, body: "findings"}}
+		assert.NoError(t, upsertIssue(ctx, tracker, issue))
+		assert.Empty(t, tracker.updated)
+		assert.Empty(t, tracker.commented)
+	})
+
+	t.Run("error looking up issue", func(t *testing.T) {
+		tracker := &issueTrackerMock{findErr: fmt.Errorf("search error")}
+		assert.EqualError(t, upsertIssue(ctx, tracker, issue), "error when looking up issue: search error")
+	})
+}
@@ -0,0 +1,52 @@
+package secretscan
+
+import (
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// Allowlist contains the fingerprints of accepted findings, e.g. test fixtures or revoked secrets which remain in the history
+type Allowlist struct {
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+// Suppression accepts the finding with the fingerprint
+type Suppression struct {
+	Fingerprint   string `json:"fingerprint"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// ReadAllowlist reads the allowlist from a YAML file, a missing file results in an empty allowlist
+func ReadAllowlist(path string, fileUtils piperutils.FileUtils) (*Allowlist, error) {
+	allowlist := &Allowlist{}
+	if len(path) == 0 {
+		return allowlist, nil
+	}
+	if exists, _ := fileUtils.FileExists(path); !exists {
+		return allowlist, nil
+	}
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read allowlist %v", path)
+	}
+	if err := yaml.Unmarshal(content, allowlist); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse allowlist %v", path)
+	}
+	return allowlist, nil
+}
+
+// Apply marks the findings contained in the allowlist as suppressed
+func (a *Allowlist) Apply(findings []Finding) []Finding {
+	justifications := map[string]string{}
+	for _, suppression := range a.Suppressions {
+		justifications[suppression.Fingerprint] = suppression.Justification
+	}
+	for i, finding := range findings {
+		if justification, suppressed := justifications[finding.Fingerprint]; suppressed {
+			findings[i].Suppressed = true
+			findings[i].Justification = justification
+		}
+	}
+	return findings
+}
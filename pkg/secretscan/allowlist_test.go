@@ -0,0 +1,48 @@
+//go:build unit
+// +build unit
+
+package secretscan
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowlist(t *testing.T) {
+	t.Parallel()
+
+	t.Run("apply suppressions", func(t *testing.T) {
+		t.Parallel()
+		files := &mock.FilesMock{}
+		files.AddFile(".secretscan-allowlist.yml", []byte(`suppressions:
+  - fingerprint: 3f1c
+    justification: revoked token
+`))
+
+		allowlist, err := ReadAllowlist(".secretscan-allowlist.yml", files)
+		assert.NoError(t, err)
+		findings := allowlist.Apply([]Finding{{Fingerprint: "3f1c"}, {Fingerprint: "a5d7"}})
+
+		assert.Equal(t, []Finding{{Fingerprint: "3f1c", Suppressed: true, Justification: "revoked token"}, {Fingerprint: "a5d7"}}, findings)
+	})
+
+	t.Run("missing allowlist", func(t *testing.T) {
+		t.Parallel()
+		allowlist, err := ReadAllowlist(".secretscan-allowlist.yml", &mock.FilesMock{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, allowlist.Suppressions)
+	})
+
+	t.Run("invalid allowlist", func(t *testing.T) {
+		t.Parallel()
+		files := &mock.FilesMock{}
+		files.AddFile(".secretscan-allowlist.yml", []byte("suppressions: 42"))
+
+		_, err := ReadAllowlist(".secretscan-allowlist.yml", files)
+
+		assert.ErrorContains(t, err, "failed to parse allowlist .secretscan-allowlist.yml")
+	})
+}
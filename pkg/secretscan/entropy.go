@@ -0,0 +1,23 @@
+package secretscan
+
+import "math"
+
+// shannonEntropy returns the Shannon entropy of the value in bits per character.
+// Random tokens reach about 4 to 6 bits, words and placeholders stay well below.
+func shannonEntropy(value string) float64 {
+	if len(value) == 0 {
+		return 0
+	}
+	counts := map[rune]int{}
+	length := 0
+	for _, r := range value {
+		counts[r]++
+		length++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
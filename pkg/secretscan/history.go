@@ -0,0 +1,95 @@
+package secretscan
+
+import (
+	"strings"
+
+	piperGit "github.com/SAP/jenkins-library/pkg/git"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+)
+
+// ScanHistory scans the lines added by the commits reachable from 'to' but not from 'from',
+// e.g. the commits of a pull request. Merge commits are skipped like in 'git log -p' since their
+// changes are already part of the merged commits.
+// It returns the findings and the number of scanned commits.
+func (s *Scanner) ScanHistory(repo *git.Repository, from, to string) ([]Finding, int, error) {
+	commits, err := piperGit.LogRange(repo, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+	findings := []Finding{}
+	count := 0
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if commit.NumParents() > 1 {
+			return nil
+		}
+		count++
+		commitFindings, err := s.scanCommit(commit)
+		if err != nil {
+			return errors.Wrapf(err, "failed to scan commit %v", commit.Hash)
+		}
+		findings = append(findings, commitFindings...)
+		return nil
+	})
+	if err != nil {
+		return nil, count, err
+	}
+	return findings, count, nil
+}
+
+func (s *Scanner) scanCommit(commit *object.Commit) ([]Finding, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if commit.NumParents() == 1 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []Finding{}
+	for _, filePatch := range patch.FilePatches() {
+		_, to := filePatch.Files()
+		if to == nil || filePatch.IsBinary() || s.excluded(to.Path()) {
+			continue
+		}
+		lineNumber := 1
+		for _, chunk := range filePatch.Chunks() {
+			lines := strings.SplitAfter(chunk.Content(), "\n")
+			if lines[len(lines)-1] == "" {
+				lines = lines[:len(lines)-1]
+			}
+			switch chunk.Type() {
+			case diff.Equal:
+				lineNumber += len(lines)
+			case diff.Add:
+				for _, line := range lines {
+					for _, finding := range s.ScanLine(to.Path(), lineNumber, strings.TrimRight(line, "\r\n")) {
+						finding.Commit = commit.Hash.String()
+						finding.Author = commit.Author.Email
+						finding.Date = commit.Author.When
+						findings = append(findings, finding)
+					}
+					lineNumber++
+				}
+			}
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,77 @@
+//go:build unit
+// +build unit
+
+package secretscan
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanHistory(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	w, err := r.Worktree()
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit := func(files map[string]string) plumbing.Hash {
+		for name, content := range files {
+			f, err := fs.Create(name)
+			assert.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			assert.NoError(t, err)
+			assert.NoError(t, f.Close())
+			_, err = w.Add(name)
+			assert.NoError(t, err)
+		}
+		hash, err := w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "me", Email: "me@example.org"}})
+		assert.NoError(t, err)
+		return hash
+	}
+
+	// A - B - C <-- HEAD
+	commit(map[string]string{"README.md": "# project\n" + githubToken + "\n"})
+	base, err := r.Head()
+	if !assert.NoError(t, err) {
+		return
+	}
+	added := commit(map[string]string{"deploy.properties": "host=example.com\nuser=deploy\naws.key=" + awsKeyID + "\n"})
+	commit(map[string]string{"deploy.properties": "host=example.com\nuser=deploy\naws.key=${AWS_KEY}\n"})
+
+	t.Run("secrets removed later on are still reported", func(t *testing.T) {
+		findings, commits, err := NewScanner(nil).ScanHistory(r, base.Hash().String(), "HEAD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, commits)
+		if assert.Len(t, findings, 1) {
+			assert.Equal(t, "aws-access-key-id", findings[0].RuleID)
+			assert.Equal(t, "deploy.properties", findings[0].File)
+			assert.Equal(t, 3, findings[0].Line)
+			assert.Equal(t, added.String(), findings[0].Commit)
+			assert.Equal(t, "me@example.org", findings[0].Author)
+		}
+	})
+
+	t.Run("excluded files", func(t *testing.T) {
+		findings, _, err := NewScanner([]string{"*.properties"}).ScanHistory(r, base.Hash().String(), "HEAD")
+
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, _, err := NewScanner(nil).ScanHistory(r, "origin/main", "HEAD")
+
+		assert.ErrorContains(t, err, "from: 'origin/main' not found")
+	})
+}
@@ -0,0 +1,205 @@
+package secretscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+// ReportsDirectory defines the subfolder for the secret scan reports which are generated
+const ReportsDirectory = "secretscan"
+
+// Summary describes what has been scanned
+type Summary struct {
+	Directory    string
+	Files        int
+	HistoryRange string
+	Commits      int
+}
+
+// CountUnsuppressed returns the number of findings which are not contained in the allowlist
+func CountUnsuppressed(findings []Finding) int {
+	count := 0
+	for _, finding := range findings {
+		if !finding.Suppressed {
+			count++
+		}
+	}
+	return count
+}
+
+// CreateCustomReport creates a ScanReport of the findings to be used for uploading into various sinks
+func CreateCustomReport(summary Summary, findings []Finding) reporting.ScanReport {
+	unsuppressed := CountUnsuppressed(findings)
+
+	scanReport := reporting.ScanReport{
+		ReportTitle:    "Secret Scan Report",
+		Subheaders:     []reporting.Subheader{},
+		Overview:       []reporting.OverviewRow{},
+		SuccessfulScan: unsuppressed == 0,
+		ReportTime:     time.Now(),
+	}
+	if len(summary.Directory) > 0 {
+		scanReport.Subheaders = append(scanReport.Subheaders, reporting.Subheader{Description: "Working tree", Details: summary.Directory})
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: "Scanned files", Details: fmt.Sprint(summary.Files)})
+	}
+	if len(summary.HistoryRange) > 0 {
+		scanReport.Subheaders = append(scanReport.Subheaders, reporting.Subheader{Description: "Git history", Details: summary.HistoryRange})
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: "Scanned commits", Details: fmt.Sprint(summary.Commits)})
+	}
+	secretsRow := reporting.OverviewRow{Description: "Secrets", Details: fmt.Sprint(unsuppressed)}
+	if unsuppressed > 0 {
+		secretsRow.Style = reporting.Red
+	}
+	scanReport.Overview = append(scanReport.Overview, secretsRow,
+		reporting.OverviewRow{Description: "Suppressed secrets", Details: fmt.Sprint(len(findings) - unsuppressed)})
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No secrets detected",
+		Headers: []string{
+			"Rule",
+			"File",
+			"Line",
+			"Secret",
+			"Commit",
+			"Fingerprint",
+			"Suppressed",
+		},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, finding := range findings {
+		style := reporting.ColumnStyle(reporting.Red)
+		suppressed := "No"
+		if finding.Suppressed {
+			style = reporting.Grey
+			suppressed = "Yes"
+			if len(finding.Justification) > 0 {
+				suppressed = fmt.Sprintf("Yes (%v)", finding.Justification)
+			}
+		}
+		row := reporting.ScanRow{}
+		row.AddColumn(finding.Description, style)
+		row.AddColumn(finding.File, 0)
+		row.AddColumn(finding.Line, 0)
+		row.AddColumn(finding.Secret, 0)
+		row.AddColumn(finding.Commit, 0)
+		row.AddColumn(finding.Fingerprint, 0)
+		row.AddColumn(suppressed, 0)
+		detailTable.Rows = append(detailTable.Rows, row)
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
+
+// WriteCustomReports writes the ScanReport as HTML report and as JSON step report
+func WriteCustomReports(scanReport reporting.ScanReport, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	htmlReportPath := filepath.Join(ReportsDirectory, "piper_secret_scan_report.html")
+	if err := fileUtils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Secret Scan Report", Target: htmlReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := fileUtils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := fileUtils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create step reporting directory")
+		}
+	}
+	jsonReportPath := filepath.Join(reporting.StepReportDirectory, "secretExecuteScan_secrets.json")
+	if err := fileUtils.FileWrite(jsonReportPath, jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+
+	return reportPaths, nil
+}
+
+// CreateSarifResultFile creates a SARIF result from the findings, suppressed findings are marked as accepted
+func CreateSarifResultFile(rules []Rule, findings []Finding) *format.SARIF {
+	sarif := format.SARIF{
+		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []format.Runs{{Results: []format.Results{}}},
+	}
+	tool := format.Tool{Driver: format.Driver{Name: "Piper secret scan"}}
+
+	ruleIndices := map[string]int{}
+	for i, rule := range rules {
+		ruleIndices[rule.ID] = i
+		tool.Driver.Rules = append(tool.Driver.Rules, format.SarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.ID,
+			ShortDescription:     &format.Message{Text: rule.Description},
+			DefaultConfiguration: &format.DefaultConfiguration{Level: rule.Severity},
+			Help:                 &format.Help{Text: "Revoke the secret and remove it from the repository, e.g. by reading it from a secret store at runtime."},
+			Properties: &format.SarifRuleProperties{
+				Tags:      []string{"security", "secret"},
+				Precision: "high",
+			},
+		})
+	}
+
+	for _, finding := range findings {
+		message := fmt.Sprintf("%v %v found", finding.Description, finding.Secret)
+		if len(finding.Commit) > 0 {
+			message = fmt.Sprintf("%v in commit %v", message, finding.Commit)
+		}
+		result := format.Results{
+			RuleID:    finding.RuleID,
+			RuleIndex: ruleIndices[finding.RuleID],
+			Level:     finding.Severity,
+			Message:   &format.Message{Text: message},
+			Locations: []format.Location{{PhysicalLocation: format.PhysicalLocation{
+				ArtifactLocation: format.ArtifactLocation{URI: filepath.ToSlash(finding.File)},
+				Region:           format.Region{StartLine: finding.Line, StartColumn: finding.Column},
+			}}},
+			PartialFingerprints: format.PartialFingerprints{SecretFingerprint: finding.Fingerprint},
+		}
+		if finding.Suppressed {
+			result.Suppressions = []format.Suppression{{Kind: "external", Status: "accepted", Justification: finding.Justification}}
+		}
+		sarif.Runs[0].Results = append(sarif.Runs[0].Results, result)
+	}
+	sarif.Runs[0].Tool = tool
+
+	return &sarif
+}
+
+// WriteSarifFile writes the SARIF result as JSON file
+func WriteSarifFile(sarif *format.SARIF, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	sarifReport, err := json.Marshal(sarif)
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to marshal SARIF json file")
+	}
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	sarifReportPath := filepath.Join(ReportsDirectory, "piper_secret_scan.sarif")
+	if err := fileUtils.FileWrite(sarifReportPath, sarifReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write SARIF file")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Secret Scan SARIF file", Target: sarifReportPath})
+
+	return reportPaths, nil
+}
@@ -0,0 +1,78 @@
+//go:build unit
+// +build unit
+
+package secretscan
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+var reportFindings = []Finding{
+	{RuleID: "github-token", Description: "GitHub token", Severity: "error", File: "src/app.js", Line: 3, Column: 7, Secret: "ghp_*****", Fingerprint: "3f1c"},
+	{RuleID: "private-key", Description: "Private key", Severity: "error", File: "test/key.pem", Line: 1, Column: 1, Secret: "----*****", Fingerprint: "a5d7", Commit: "0a1b2c", Suppressed: true, Justification: "test fixture"},
+}
+
+func TestCreateCustomReport(t *testing.T) {
+	t.Parallel()
+	scanReport := CreateCustomReport(Summary{Directory: ".", Files: 12, HistoryRange: "origin/main..HEAD", Commits: 2}, reportFindings)
+
+	assert.False(t, scanReport.SuccessfulScan)
+	assert.Equal(t, []reporting.OverviewRow{
+		{Description: "Scanned files", Details: "12"},
+		{Description: "Scanned commits", Details: "2"},
+		{Description: "Secrets", Details: "1", Style: reporting.Red},
+		{Description: "Suppressed secrets", Details: "1"},
+	}, scanReport.Overview)
+	if assert.Len(t, scanReport.DetailTable.Rows, 2) {
+		assert.Equal(t, "Yes (test fixture)", scanReport.DetailTable.Rows[1].Columns[6].Content)
+	}
+
+	assert.True(t, CreateCustomReport(Summary{Directory: "."}, reportFindings[1:]).SuccessfulScan)
+}
+
+func TestWriteCustomReports(t *testing.T) {
+	t.Parallel()
+	files := &mock.FilesMock{}
+
+	paths, err := WriteCustomReports(CreateCustomReport(Summary{Directory: "."}, reportFindings), files)
+
+	assert.NoError(t, err)
+	assert.Len(t, paths, 1)
+	assert.True(t, files.HasFile("secretscan/piper_secret_scan_report.html"))
+	assert.True(t, files.HasFile(".pipeline/stepReports/secretExecuteScan_secrets.json"))
+}
+
+func TestCreateSarifResultFile(t *testing.T) {
+	t.Parallel()
+	rules := DefaultRules()
+
+	sarif := CreateSarifResultFile(rules, reportFindings)
+
+	assert.Len(t, sarif.Runs[0].Tool.Driver.Rules, len(rules))
+	results := sarif.Runs[0].Results
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "github-token", results[0].RuleID)
+		assert.Equal(t, "github-token", sarif.Runs[0].Tool.Driver.Rules[results[0].RuleIndex].ID)
+		assert.Equal(t, "GitHub token ghp_***** found", results[0].Message.Text)
+		assert.Equal(t, 3, results[0].Locations[0].PhysicalLocation.Region.StartLine)
+		assert.Equal(t, "3f1c", results[0].PartialFingerprints.SecretFingerprint)
+		assert.Empty(t, results[0].Suppressions)
+
+		assert.Equal(t, "Private key ----***** found in commit 0a1b2c", results[1].Message.Text)
+		if assert.Len(t, results[1].Suppressions, 1) {
+			assert.Equal(t, "test fixture", results[1].Suppressions[0].Justification)
+		}
+	}
+
+	files := &mock.FilesMock{}
+	_, err := WriteSarifFile(sarif, files)
+	assert.NoError(t, err)
+	content, err := files.FileRead("secretscan/piper_secret_scan.sarif")
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(content))
+}
@@ -0,0 +1,153 @@
+package secretscan
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rule detects one kind of secret
+type Rule struct {
+	ID          string
+	Description string
+	// Keywords are matched case-insensitively before the pattern is evaluated in order to skip most lines cheaply
+	Keywords []string
+	Pattern  *regexp.Regexp
+	// SecretGroup is the index of the capture group containing the secret, the whole match is used if it is 0
+	SecretGroup int
+	// MinEntropy is the Shannon entropy in bits per character the secret must reach, it is not checked if it is 0
+	MinEntropy float64
+	// Severity is either "error" or "warning"
+	Severity string
+}
+
+func (r Rule) matchesKeywords(lowerLine string) bool {
+	if len(r.Keywords) == 0 {
+		return true
+	}
+	for _, keyword := range r.Keywords {
+		if strings.Contains(lowerLine, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRules returns the built-in rules covering cloud keys, tokens of common services and private keys,
+// as well as generic assignments of high-entropy values to variables named like secrets
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          "aws-access-key-id",
+			Description: "AWS access key ID",
+			Keywords:    []string{"akia", "asia"},
+			Pattern:     regexp.MustCompile(`\b((?:AKIA|ASIA)[A-Z2-7]{16})\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "aws-secret-access-key",
+			Description: "AWS secret access key",
+			Keywords:    []string{"aws"},
+			Pattern:     regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|key).{0,20}?['"=:\s]+([A-Za-z0-9/+]{40})\b`),
+			SecretGroup: 1,
+			MinEntropy:  4,
+			Severity:    "error",
+		},
+		{
+			ID:          "gcp-api-key",
+			Description: "Google Cloud API key",
+			Keywords:    []string{"aiza"},
+			Pattern:     regexp.MustCompile(`\b(AIza[0-9A-Za-z_\-]{35})\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "azure-storage-account-key",
+			Description: "Azure storage account key",
+			Keywords:    []string{"accountkey"},
+			Pattern:     regexp.MustCompile(`(?i)AccountKey=([A-Za-z0-9+/]{86}==)`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "github-token",
+			Description: "GitHub token",
+			Keywords:    []string{"ghp_", "gho_", "ghu_", "ghs_", "ghr_", "github_pat_"},
+			Pattern:     regexp.MustCompile(`\b((?:gh[pousr]_[A-Za-z0-9]{36})|(?:github_pat_[A-Za-z0-9_]{82}))\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "gitlab-token",
+			Description: "GitLab personal access token",
+			Keywords:    []string{"glpat-"},
+			Pattern:     regexp.MustCompile(`\b(glpat-[A-Za-z0-9_\-]{20})\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "slack-token",
+			Description: "Slack token",
+			Keywords:    []string{"xox"},
+			Pattern:     regexp.MustCompile(`\b(xox[baprs]-[0-9A-Za-z\-]{10,72})\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "slack-webhook",
+			Description: "Slack incoming webhook",
+			Keywords:    []string{"hooks.slack.com"},
+			Pattern:     regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]{24}`),
+			Severity:    "error",
+		},
+		{
+			ID:          "npm-token",
+			Description: "npm access token",
+			Keywords:    []string{"npm_"},
+			Pattern:     regexp.MustCompile(`\b(npm_[A-Za-z0-9]{36})\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "stripe-secret-key",
+			Description: "Stripe secret key",
+			Keywords:    []string{"sk_live_", "rk_live_"},
+			Pattern:     regexp.MustCompile(`\b((?:sk|rk)_live_[0-9A-Za-z]{24,99})\b`),
+			SecretGroup: 1,
+			Severity:    "error",
+		},
+		{
+			ID:          "private-key",
+			Description: "Private key",
+			Keywords:    []string{"private key"},
+			Pattern:     regexp.MustCompile(`-----BEGIN (?:RSA |DSA |EC |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`),
+			Severity:    "error",
+		},
+		{
+			ID:          "jwt",
+			Description: "JSON Web Token",
+			Keywords:    []string{"eyj"},
+			Pattern:     regexp.MustCompile(`\b(eyJ[A-Za-z0-9_\-]{10,}\.eyJ[A-Za-z0-9_\-]{10,}\.[A-Za-z0-9_\-]{10,})\b`),
+			SecretGroup: 1,
+			Severity:    "warning",
+		},
+		{
+			ID:          "basic-auth-url",
+			Description: "Password in URL",
+			Keywords:    []string{"://"},
+			Pattern:     regexp.MustCompile(`[a-z][a-z0-9+.\-]*://[^\s:@/'"]+:([^\s:@/'"$<{]{6,})@[^\s'"]+`),
+			SecretGroup: 1,
+			MinEntropy:  3,
+			Severity:    "error",
+		},
+		{
+			ID:          "generic-secret",
+			Description: "High-entropy value assigned to a secret",
+			Keywords:    []string{"secret", "password", "passwd", "pwd", "token", "apikey", "api_key", "api-key", "credential"},
+			Pattern:     regexp.MustCompile(`(?i)(?:secret|password|passwd|pwd|token|api_?-?key|credentials?)[a-z0-9_\-]*['"]?\s*[:=]{1,2}>?\s*['"]([^'"\s]{12,})['"]`),
+			SecretGroup: 1,
+			MinEntropy:  3.5,
+			Severity:    "warning",
+		},
+	}
+}
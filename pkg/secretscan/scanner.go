@@ -0,0 +1,198 @@
+package secretscan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/bmatcuk/doublestar"
+	"github.com/pkg/errors"
+)
+
+// defaultMaxFileSize skips generated files and archives which rarely contain readable secrets but slow down the scan
+const defaultMaxFileSize = 1024 * 1024
+
+// Finding is a secret detected in a file of the working tree or in a line added by a commit
+type Finding struct {
+	RuleID      string
+	Description string
+	Severity    string
+	File        string
+	Line        int
+	Column      int
+	// Secret is the redacted secret, the actual value is never kept
+	Secret string
+	// Fingerprint identifies the secret within the file independent of its line and of the commit, it is used to suppress findings
+	Fingerprint string
+	// Commit, Author and Date are only set for findings in the git history
+	Commit string
+	Author string
+	Date   time.Time
+	// Suppressed is set for findings contained in the allowlist
+	Suppressed    bool
+	Justification string
+}
+
+// Scanner detects secrets line by line
+type Scanner struct {
+	Rules []Rule
+	// Excludes are glob patterns of paths relative to the scanned directory which are not scanned
+	Excludes    []string
+	MaxFileSize int64
+}
+
+// NewScanner creates a Scanner with the default rules
+func NewScanner(excludes []string) *Scanner {
+	return &Scanner{Rules: DefaultRules(), Excludes: excludes, MaxFileSize: defaultMaxFileSize}
+}
+
+// ScanLine returns the findings of all rules in the line, the line number is only used for reporting.
+// Rules are evaluated in order, a secret already detected by a rule is not reported again by a later, more generic rule.
+func (s *Scanner) ScanLine(file string, lineNumber int, line string) []Finding {
+	findings := []Finding{}
+	detected := [][2]int{}
+	lowerLine := strings.ToLower(line)
+	for _, rule := range s.Rules {
+		if !rule.matchesKeywords(lowerLine) {
+			continue
+		}
+		for _, match := range rule.Pattern.FindAllStringSubmatchIndex(line, -1) {
+			start, end := match[0], match[1]
+			if rule.SecretGroup > 0 {
+				start, end = match[2*rule.SecretGroup], match[2*rule.SecretGroup+1]
+			}
+			if start < 0 || overlaps(detected, start, end) {
+				continue
+			}
+			secret := line[start:end]
+			if rule.MinEntropy > 0 && shannonEntropy(secret) < rule.MinEntropy {
+				continue
+			}
+			detected = append(detected, [2]int{start, end})
+			findings = append(findings, Finding{
+				RuleID:      rule.ID,
+				Description: rule.Description,
+				Severity:    rule.Severity,
+				File:        file,
+				Line:        lineNumber,
+				Column:      start + 1,
+				Secret:      redact(secret),
+				Fingerprint: fingerprint(rule.ID, file, secret),
+			})
+		}
+	}
+	return findings
+}
+
+// ScanContent scans the content of a file, binary content is skipped
+func (s *Scanner) ScanContent(file string, content []byte) []Finding {
+	findings := []Finding{}
+	if isBinary(content) {
+		return findings
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		findings = append(findings, s.ScanLine(file, i+1, strings.TrimSuffix(line, "\r"))...)
+	}
+	return findings
+}
+
+// ScanDirectory scans all files below the directory except for the .git folder and excluded paths.
+// It returns the findings and the number of scanned files.
+func (s *Scanner) ScanDirectory(directory string) ([]Finding, int, error) {
+	findings := []Finding{}
+	files := 0
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if entry.IsDir() {
+			if entry.Name() == ".git" || (relativePath != "." && s.excluded(relativePath)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || s.excluded(relativePath) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > s.MaxFileSize {
+			log.Entry().Debugf("Skipping %v since it exceeds %v bytes", relativePath, s.MaxFileSize)
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %v", relativePath)
+		}
+		files++
+		findings = append(findings, s.ScanContent(relativePath, content)...)
+		return nil
+	})
+	if err != nil {
+		return nil, files, errors.Wrapf(err, "failed to scan directory %v", directory)
+	}
+	return findings, files, nil
+}
+
+func (s *Scanner) excluded(path string) bool {
+	for _, pattern := range s.Excludes {
+		if matched, _ := doublestar.Match(pattern, path); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Deduplicate keeps the first finding of each fingerprint, e.g. a secret found in the working tree and in the history
+func Deduplicate(findings []Finding) []Finding {
+	unique := []Finding{}
+	seen := map[string]bool{}
+	for _, finding := range findings {
+		if !seen[finding.Fingerprint] {
+			seen[finding.Fingerprint] = true
+			unique = append(unique, finding)
+		}
+	}
+	return unique
+}
+
+func overlaps(spans [][2]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+func fingerprint(ruleID, file, secret string) string {
+	hash := sha256.Sum256([]byte(ruleID + ":" + file + ":" + secret))
+	return hex.EncodeToString(hash[:16])
+}
+
+func redact(secret string) string {
+	if len(secret) <= 8 {
+		return "*****"
+	}
+	return secret[:4] + "*****"
+}
@@ -0,0 +1,153 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// pemTypeEncryptedSigstore is the PEM type of private keys created with cosign generate-key-pair
+	pemTypeEncryptedSigstore = "ENCRYPTED SIGSTORE PRIVATE KEY"
+	// pemTypeEncryptedCosign is the PEM type of private keys created with older versions of cosign
+	pemTypeEncryptedCosign = "ENCRYPTED COSIGN PRIVATE KEY"
+)
+
+// encryptedKey is the envelope cosign uses for password protected private keys
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadPrivateKey parses a PEM encoded private key.
+// Besides unencrypted PKCS#8, EC and PKCS#1 keys, password protected keys generated by cosign are supported.
+func LoadPrivateKey(content, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	der := block.Bytes
+	switch block.Type {
+	case pemTypeEncryptedSigstore, pemTypeEncryptedCosign:
+		var err error
+		if der, err = decryptPrivateKey(block.Bytes, password); err != nil {
+			return nil, err
+		}
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(der)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(der)
+	case "PRIVATE KEY":
+	default:
+		return nil, fmt.Errorf("unsupported private key type '%v'", block.Type)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key %T", key)
+	}
+	return signer, nil
+}
+
+func decryptPrivateKey(content, password []byte) ([]byte, error) {
+	envelope := encryptedKey{}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, errors.Wrap(err, "failed to parse encrypted private key")
+	}
+	if envelope.KDF.Name != "scrypt" || envelope.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported encryption '%v' with key derivation '%v'", envelope.Cipher.Name, envelope.KDF.Name)
+	}
+	if len(envelope.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce of encrypted private key")
+	}
+
+	secretKey, err := scrypt.Key(password, envelope.KDF.Salt, envelope.KDF.Params.N, envelope.KDF.Params.R, envelope.KDF.Params.P, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key from password")
+	}
+	var key [32]byte
+	var nonce [24]byte
+	copy(key[:], secretKey)
+	copy(nonce[:], envelope.Cipher.Nonce)
+
+	der, ok := secretbox.Open(nil, envelope.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.New("failed to decrypt private key, please check the password")
+	}
+	return der, nil
+}
+
+// LoadPublicKeys parses all PEM encoded public keys contained in the content
+func LoadPublicKeys(content []byte) ([]crypto.PublicKey, error) {
+	keys := []crypto.PublicKey{}
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse public key")
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	return keys, nil
+}
+
+// signPayload signs the payload the same way cosign does: ECDSA and RSA keys sign the SHA-256 digest, ed25519 keys the payload itself
+func signPayload(signer crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(nil, payload, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(payload)
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// verifyPayload checks the signature of the payload with the public key
+func verifyPayload(publicKey crypto.PublicKey, payload, signature []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	}
+	return false
+}
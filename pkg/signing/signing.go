@@ -0,0 +1,350 @@
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ModeTag stores signatures in the tag sha256-<digest>.sig of the image repository like cosign does by default
+	ModeTag = "tag"
+	// ModeReferrers stores signatures as OCI 1.1 artifacts referring to the image
+	ModeReferrers = "referrers"
+
+	// SimpleSigningMediaType is the media type of the layers containing the signed payload
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// SignatureAnnotation is the layer annotation holding the base64 encoded signature
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SignatureArtifactType is the artifact type of signatures stored as OCI referrers
+	SignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+
+	payloadType = "cosign container image signature"
+)
+
+// Config contains the signing configuration of the build steps
+type Config struct {
+	// SigningKey is the path of the PEM encoded private key
+	SigningKey         string
+	SigningKeyPassword string
+	// SignatureMode is either ModeTag or ModeReferrers
+	SignatureMode string
+}
+
+// keyReader reads the key files, it is satisfied by piperutils.FileUtils
+type keyReader interface {
+	FileRead(path string) ([]byte, error)
+}
+
+// payload is the simple signing payload signed by cosign
+type payload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+func newPayload(image name.Digest) ([]byte, error) {
+	p := payload{}
+	p.Critical.Identity.DockerReference = image.Context().Name()
+	p.Critical.Image.DockerManifestDigest = image.DigestStr()
+	p.Critical.Type = payloadType
+	return json.Marshal(p)
+}
+
+// SignatureTag returns the tag cosign stores the signatures of the image in
+func SignatureTag(image name.Digest) name.Tag {
+	return image.Context().Tag(strings.Replace(image.DigestStr(), ":", "-", 1) + ".sig")
+}
+
+// ResolveDigest returns the digest reference of an image, tags are resolved using the registry
+func ResolveDigest(image string, options ...remote.Option) (name.Digest, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "invalid image reference '%v'", image)
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest, nil
+	}
+	desc, err := remote.Head(ref, options...)
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "failed to resolve digest of image '%v'", image)
+	}
+	return ref.Context().Digest(desc.Digest.String()), nil
+}
+
+// Sign creates a cosign compatible signature of the image and pushes it to the registry of the image
+func Sign(image name.Digest, signer crypto.Signer, mode string, options ...remote.Option) error {
+	content, err := newPayload(image)
+	if err != nil {
+		return errors.Wrap(err, "failed to create signature payload")
+	}
+	signature, err := signPayload(signer, content)
+	if err != nil {
+		return errors.Wrapf(err, "failed to sign image '%v'", image)
+	}
+	signatureLayer := mutate.Addendum{
+		Layer:       static.NewLayer(content, SimpleSigningMediaType),
+		Annotations: map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	}
+
+	switch mode {
+	case ModeReferrers:
+		return pushSignatureReferrer(image, signatureLayer, options)
+	case ModeTag, "":
+		return pushSignatureTag(image, signatureLayer, options)
+	}
+	return fmt.Errorf("unsupported signature mode '%v'", mode)
+}
+
+func pushSignatureTag(image name.Digest, signatureLayer mutate.Addendum, options []remote.Option) error {
+	tag := SignatureTag(image)
+	base, err := remote.Image(tag, options...)
+	if isNotFound(err) {
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to read existing signatures of image '%v'", image)
+	}
+	// further signatures are appended like cosign does, e.g. when the image is pushed to several registries
+	signatures, err := mutate.Append(base, signatureLayer)
+	if err != nil {
+		return errors.Wrap(err, "failed to create signature image")
+	}
+	if err := remote.Write(tag, signatures, options...); err != nil {
+		return errors.Wrapf(err, "failed to push signature to '%v'", tag)
+	}
+	log.Entry().Infof("Signature of image '%v' pushed to '%v'", image, tag)
+	return nil
+}
+
+func pushSignatureReferrer(image name.Digest, signatureLayer mutate.Addendum, options []remote.Option) error {
+	subject, err := remote.Head(image, options...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read image '%v'", image)
+	}
+	signatures, err := mutate.Append(mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), SignatureArtifactType), signatureLayer)
+	if err != nil {
+		return errors.Wrap(err, "failed to create signature image")
+	}
+	referrer := mutate.Subject(signatures, *subject).(v1.Image)
+	digest, err := referrer.Digest()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate digest of signature")
+	}
+	target := image.Context().Digest(digest.String())
+	if err := remote.Write(target, referrer, options...); err != nil {
+		return errors.Wrapf(err, "failed to push signature to '%v'", target)
+	}
+	log.Entry().Infof("Signature of image '%v' pushed as referrer '%v'", image, target)
+	return nil
+}
+
+// Verify checks that the image carries a signature created by one of the keys.
+// Signatures stored in the signature tag as well as signatures stored as OCI referrers are considered.
+func Verify(image name.Digest, publicKeys []crypto.PublicKey, options ...remote.Option) error {
+	candidates := []v1.Image{}
+	signatures, err := remote.Image(SignatureTag(image), options...)
+	switch {
+	case err == nil:
+		candidates = append(candidates, signatures)
+	case !isNotFound(err):
+		return errors.Wrapf(err, "failed to read signatures of image '%v'", image)
+	}
+
+	referrers, err := remote.Referrers(image, append(options, remote.WithFilter("artifactType", SignatureArtifactType))...)
+	if err != nil {
+		log.Entry().WithError(err).Debugf("failed to read referrers of image '%v'", image)
+	} else if index, err := referrers.IndexManifest(); err == nil {
+		for _, referrer := range index.Manifests {
+			if referrer.ArtifactType != SignatureArtifactType {
+				continue
+			}
+			signatures, err := remote.Image(image.Context().Digest(referrer.Digest.String()), options...)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read signature '%v' of image '%v'", referrer.Digest, image)
+			}
+			candidates = append(candidates, signatures)
+		}
+	}
+
+	for _, signatures := range candidates {
+		verified, err := verifySignatures(image, signatures, publicKeys)
+		if err != nil {
+			return err
+		}
+		if verified {
+			return nil
+		}
+	}
+	return fmt.Errorf("no valid signature found for image '%v'", image)
+}
+
+func verifySignatures(image name.Digest, signatures v1.Image, publicKeys []crypto.PublicKey) (bool, error) {
+	manifest, err := signatures.Manifest()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read signatures of image '%v'", image)
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[SignatureAnnotation])
+		if err != nil {
+			log.Entry().WithError(err).Debugf("ignoring invalid signature %v", layer.Digest)
+			continue
+		}
+		content, err := layerContent(signatures, layer.Digest)
+		if err != nil {
+			return false, err
+		}
+		p := payload{}
+		if err := json.Unmarshal(content, &p); err != nil || p.Critical.Image.DockerManifestDigest != image.DigestStr() {
+			log.Entry().Debugf("ignoring signature %v for a different image", layer.Digest)
+			continue
+		}
+		for _, publicKey := range publicKeys {
+			if verifyPayload(publicKey, content, signature) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func layerContent(img v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read signature %v", digest)
+	}
+	reader, err := layer.Compressed()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read signature %v", digest)
+	}
+	defer reader.Close()
+	content := bytes.Buffer{}
+	if _, err := io.Copy(&content, reader); err != nil {
+		return nil, errors.Wrapf(err, "failed to read signature %v", digest)
+	}
+	return content.Bytes(), nil
+}
+
+func isNotFound(err error) bool {
+	var transportErr *transport.Error
+	return errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound
+}
+
+func defaultOptions(options []remote.Option) []remote.Option {
+	return append([]remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}, options...)
+}
+
+// ImageReferences returns the references of the images pushed by a build step as provided in the commonPipelineEnvironment.
+// The digests are used if they are known for all images.
+func ImageReferences(registryURL string, imageNameTags, imageDigests []string) ([]string, error) {
+	registry, err := docker.ContainerRegistryFromURL(registryURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read registry url %v", registryURL)
+	}
+	useDigests := len(imageDigests) == len(imageNameTags)
+	images := []string{}
+	for i, imageNameTag := range imageNameTags {
+		image := fmt.Sprintf("%v/%v", registry, imageNameTag)
+		if useDigests {
+			imageName, _, _ := strings.Cut(imageNameTag, ":")
+			image = fmt.Sprintf("%v/%v@%v", registry, imageName, imageDigests[i])
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// SignImages signs the images with the configured key. The images are resolved to their digests before signing.
+func SignImages(images []string, config Config, fileUtils keyReader, options ...remote.Option) error {
+	if len(config.SigningKey) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.New("no signing key provided")
+	}
+	key, err := fileUtils.FileRead(config.SigningKey)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrap(err, "failed to read signing key")
+	}
+	signer, err := LoadPrivateKey(key, []byte(config.SigningKeyPassword))
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	options = defaultOptions(options)
+	for _, image := range images {
+		digest, err := ResolveDigest(image, options...)
+		if err != nil {
+			return err
+		}
+		if err := Sign(digest, signer, config.SignatureMode, options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyImages checks that all images are signed with one of the public keys contained in the public key file
+func VerifyImages(images []string, publicKeyFile string, fileUtils keyReader, options ...remote.Option) error {
+	if len(publicKeyFile) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.New("no public key for signature verification provided")
+	}
+	content, err := fileUtils.FileRead(publicKeyFile)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrap(err, "failed to read public key")
+	}
+	publicKeys, err := LoadPublicKeys(content)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	options = defaultOptions(options)
+	unsigned := []string{}
+	for _, image := range images {
+		digest, err := ResolveDigest(image, options...)
+		if err != nil {
+			return err
+		}
+		if err := Verify(digest, publicKeys, options...); err != nil {
+			log.Entry().WithError(err).Errorf("Signature verification of image '%v' failed", image)
+			unsigned = append(unsigned, image)
+			continue
+		}
+		log.Entry().Infof("Signature of image '%v' verified", image)
+	}
+	if len(unsigned) > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("signature verification failed for images: %v", strings.Join(unsigned, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+//go:build unit
+// +build unit
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// newTestRegistry starts an in-memory registry and pushes a random image to it
+func newTestRegistry(t *testing.T, referrers bool) (string, name.Digest) {
+	server := httptest.NewServer(registry.New(registry.WithReferrersSupport(referrers)))
+	t.Cleanup(server.Close)
+
+	image := strings.TrimPrefix(server.URL, "http://") + "/my/image:1.0.0"
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(100, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return image, ref.Context().Digest(digest.String())
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// encryptTestKey encrypts the key the same way cosign generate-key-pair does
+func encryptTestKey(t *testing.T, key crypto.Signer, password string) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	envelope := encryptedKey{}
+	envelope.KDF.Name = "scrypt"
+	envelope.KDF.Params.N, envelope.KDF.Params.R, envelope.KDF.Params.P = 1024, 8, 1
+	envelope.KDF.Salt = []byte("0123456789abcdef0123456789abcdef")
+	envelope.Cipher.Name = "nacl/secretbox"
+	envelope.Cipher.Nonce = []byte("0123456789abcdef01234567")
+	secretKey, err := scrypt.Key([]byte(password), envelope.KDF.Salt, 1024, 8, 1, 32)
+	require.NoError(t, err)
+	var k [32]byte
+	var nonce [24]byte
+	copy(k[:], secretKey)
+	copy(nonce[:], envelope.Cipher.Nonce)
+	envelope.Ciphertext = secretbox.Seal(nil, der, &nonce, &k)
+	content, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedSigstore, Bytes: content})
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key, _ := newTestKey(t)
+
+	t.Run("cosign encrypted key", func(t *testing.T) {
+		signer, err := LoadPrivateKey(encryptTestKey(t, key, "secret"), []byte("secret"))
+
+		if assert.NoError(t, err) {
+			assert.True(t, key.PublicKey.Equal(signer.Public()))
+		}
+	})
+
+	t.Run("unencrypted PKCS#8 key", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		signer, err := LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil)
+
+		if assert.NoError(t, err) {
+			assert.True(t, key.PublicKey.Equal(signer.Public()))
+		}
+	})
+
+	t.Run("error - wrong password", func(t *testing.T) {
+		_, err := LoadPrivateKey(encryptTestKey(t, key, "secret"), []byte("wrong"))
+
+		assert.EqualError(t, err, "failed to decrypt private key, please check the password")
+	})
+
+	t.Run("error - no key", func(t *testing.T) {
+		_, err := LoadPrivateKey([]byte("no key"), nil)
+
+		assert.EqualError(t, err, "no PEM encoded private key found")
+	})
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, publicKey := newTestKey(t)
+	publicKeys, err := LoadPublicKeys(publicKey)
+	require.NoError(t, err)
+
+	t.Run("signature tag", func(t *testing.T) {
+		_, digest := newTestRegistry(t, false)
+
+		require.NoError(t, Sign(digest, key, ModeTag))
+		require.NoError(t, Sign(digest, key, ModeTag))
+
+		assert.NoError(t, Verify(digest, publicKeys))
+		signatures, err := remote.Image(SignatureTag(digest))
+		require.NoError(t, err)
+		layers, err := signatures.Layers()
+		require.NoError(t, err)
+		assert.Len(t, layers, 2)
+	})
+
+	t.Run("referrers", func(t *testing.T) {
+		_, digest := newTestRegistry(t, true)
+
+		require.NoError(t, Sign(digest, key, ModeReferrers))
+
+		assert.NoError(t, Verify(digest, publicKeys))
+		_, err := remote.Image(SignatureTag(digest))
+		assert.True(t, isNotFound(err))
+	})
+
+	t.Run("referrers without referrers API", func(t *testing.T) {
+		_, digest := newTestRegistry(t, false)
+
+		require.NoError(t, Sign(digest, key, ModeReferrers))
+
+		assert.NoError(t, Verify(digest, publicKeys))
+	})
+
+	t.Run("ed25519 key", func(t *testing.T) {
+		_, digest := newTestRegistry(t, false)
+		edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		require.NoError(t, Sign(digest, edKey, ModeTag))
+
+		assert.NoError(t, Verify(digest, []crypto.PublicKey{edPublicKey}))
+	})
+
+	t.Run("error - unsigned image", func(t *testing.T) {
+		_, digest := newTestRegistry(t, true)
+
+		err := Verify(digest, publicKeys)
+
+		assert.EqualError(t, err, "no valid signature found for image '"+digest.String()+"'")
+	})
+
+	t.Run("error - signed with other key", func(t *testing.T) {
+		_, digest := newTestRegistry(t, false)
+		otherKey, _ := newTestKey(t)
+		require.NoError(t, Sign(digest, otherKey, ModeTag))
+
+		err := Verify(digest, publicKeys)
+
+		assert.EqualError(t, err, "no valid signature found for image '"+digest.String()+"'")
+	})
+
+	t.Run("error - signature of other image", func(t *testing.T) {
+		_, digest := newTestRegistry(t, false)
+		_, otherDigest := newTestRegistry(t, false)
+		require.NoError(t, Sign(otherDigest, key, ModeTag))
+		// copy the signature of the other image to the signature tag of the image
+		signatures, err := remote.Image(SignatureTag(otherDigest))
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(SignatureTag(digest), signatures))
+
+		err = Verify(digest, publicKeys)
+
+		assert.EqualError(t, err, "no valid signature found for image '"+digest.String()+"'")
+	})
+}
+
+func TestSignAndVerifyImages(t *testing.T) {
+	key, publicKey := newTestKey(t)
+	fileUtils := &mock.FilesMock{}
+	fileUtils.AddFile("cosign.key", encryptTestKey(t, key, "secret"))
+	fileUtils.AddFile("cosign.pub", publicKey)
+
+	t.Run("success", func(t *testing.T) {
+		image, _ := newTestRegistry(t, false)
+
+		err := SignImages([]string{image}, Config{SigningKey: "cosign.key", SigningKeyPassword: "secret", SignatureMode: ModeTag}, fileUtils)
+		require.NoError(t, err)
+
+		assert.NoError(t, VerifyImages([]string{image}, "cosign.pub", fileUtils))
+	})
+
+	t.Run("error - unsigned image", func(t *testing.T) {
+		image, _ := newTestRegistry(t, false)
+
+		err := VerifyImages([]string{image}, "cosign.pub", fileUtils)
+
+		assert.EqualError(t, err, "signature verification failed for images: "+image)
+	})
+
+	t.Run("error - no signing key", func(t *testing.T) {
+		err := SignImages([]string{"my/image:1"}, Config{}, fileUtils)
+
+		assert.EqualError(t, err, "no signing key provided")
+	})
+
+	t.Run("error - unsupported mode", func(t *testing.T) {
+		image, _ := newTestRegistry(t, false)
+
+		err := SignImages([]string{image}, Config{SigningKey: "cosign.key", SigningKeyPassword: "secret", SignatureMode: "unknown"}, fileUtils)
+
+		assert.EqualError(t, err, "unsupported signature mode 'unknown'")
+	})
+}
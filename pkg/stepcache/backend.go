@@ -0,0 +1,83 @@
+package stepcache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pkg/errors"
+)
+
+// Backend stores cache entries
+type Backend interface {
+	// Download fetches the entry with the given name into targetFile and reports whether the entry exists
+	Download(name, targetFile string) (bool, error)
+	// Upload stores sourceFile as entry with the given name
+	Upload(name, sourceFile string) error
+}
+
+// NewBackend creates the backend for a cache location.
+// Supported are gs://<bucket>/<folder> for Google Cloud Storage, s3://<bucket>/<folder> for AWS S3 and local directories.
+func NewBackend(location, gcpJsonKeyFilePath string) (Backend, error) {
+	switch {
+	case strings.HasPrefix(location, "gs://"):
+		bucket, folder := splitBucketLocation(strings.TrimPrefix(location, "gs://"))
+		envVars := []gcs.EnvVar{
+			{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: gcpJsonKeyFilePath, Modified: false},
+		}
+		client, err := gcs.NewClient(gcs.WithEnvVars(envVars))
+		if err != nil {
+			return nil, errors.Wrap(err, "creation of GCS client failed")
+		}
+		return &GCSBackend{Client: client, Bucket: bucket, Folder: folder}, nil
+	case strings.HasPrefix(location, "s3://"):
+		bucket, folder := splitBucketLocation(strings.TrimPrefix(location, "s3://"))
+		cfg, err := awsConfig.LoadDefaultConfig(context.TODO())
+		if err != nil {
+			return nil, errors.Wrap(err, "AWS client configuration failed")
+		}
+		return &S3Backend{Client: s3.NewFromConfig(cfg), Bucket: bucket, Folder: folder}, nil
+	case strings.Contains(location, "://"):
+		return nil, fmt.Errorf("unsupported cache location '%s'", location)
+	}
+	return &DirectoryBackend{Path: location}, nil
+}
+
+func splitBucketLocation(location string) (string, string) {
+	bucket, folder, _ := strings.Cut(location, "/")
+	return bucket, strings.Trim(folder, "/")
+}
+
+// DirectoryBackend stores cache entries in a local directory, e.g. on a persistent volume of the agent
+type DirectoryBackend struct {
+	Path string
+}
+
+// Download copies the entry from the directory
+func (d *DirectoryBackend) Download(name, targetFile string) (bool, error) {
+	source := filepath.Join(d.Path, name)
+	if exists, _ := piperutils.FileExists(source); !exists {
+		return false, nil
+	}
+	if _, err := piperutils.Copy(source, targetFile); err != nil {
+		return false, errors.Wrapf(err, "failed to read cache entry '%s'", source)
+	}
+	return true, nil
+}
+
+// Upload copies the entry into the directory
+func (d *DirectoryBackend) Upload(name, sourceFile string) error {
+	if err := os.MkdirAll(d.Path, 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create cache directory '%s'", d.Path)
+	}
+	if _, err := piperutils.Copy(sourceFile, filepath.Join(d.Path, name)); err != nil {
+		return errors.Wrapf(err, "failed to write cache entry '%s'", name)
+	}
+	return nil
+}
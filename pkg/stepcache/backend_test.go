@@ -0,0 +1,60 @@
+//go:build unit
+// +build unit
+
+package stepcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("local directory", func(t *testing.T) {
+		backend, err := NewBackend("/var/cache/piper", "")
+		assert.NoError(t, err)
+		assert.Equal(t, &DirectoryBackend{Path: "/var/cache/piper"}, backend)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := NewBackend("ftp://cache", "")
+		assert.EqualError(t, err, "unsupported cache location 'ftp://cache'")
+	})
+
+	t.Run("bucket location", func(t *testing.T) {
+		bucket, folder := splitBucketLocation("my-bucket/piper/cache/")
+		assert.Equal(t, "my-bucket", bucket)
+		assert.Equal(t, "piper/cache", folder)
+
+		bucket, folder = splitBucketLocation("my-bucket")
+		assert.Equal(t, "my-bucket", bucket)
+		assert.Equal(t, "", folder)
+	})
+}
+
+func TestDirectoryBackend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	backend := &DirectoryBackend{Path: filepath.Join(dir, "cache")}
+	source := filepath.Join(dir, "entry.tar.gz")
+	require.NoError(t, os.WriteFile(source, []byte("content"), 0o644))
+
+	found, err := backend.Download("entry.tar.gz", filepath.Join(dir, "downloaded"))
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	assert.NoError(t, backend.Upload("entry.tar.gz", source))
+
+	found, err = backend.Download("entry.tar.gz", filepath.Join(dir, "downloaded"))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	content, err := os.ReadFile(filepath.Join(dir, "downloaded"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
@@ -0,0 +1,36 @@
+package stepcache
+
+import (
+	"path"
+
+	"cloud.google.com/go/storage"
+	"github.com/SAP/jenkins-library/pkg/gcs"
+	"github.com/pkg/errors"
+)
+
+// GCSBackend stores cache entries in a Google Cloud Storage bucket
+type GCSBackend struct {
+	Client gcs.Client
+	Bucket string
+	Folder string
+}
+
+// Download fetches the entry from the bucket
+func (g *GCSBackend) Download(name, targetFile string) (bool, error) {
+	err := g.Client.DownloadFile(g.Bucket, path.Join(g.Folder, name), targetFile)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to download cache entry '%s'", name)
+	}
+	return true, nil
+}
+
+// Upload stores the entry in the bucket
+func (g *GCSBackend) Upload(name, sourceFile string) error {
+	if err := g.Client.UploadFile(g.Bucket, sourceFile, path.Join(g.Folder, name)); err != nil {
+		return errors.Wrapf(err, "failed to upload cache entry '%s'", name)
+	}
+	return nil
+}
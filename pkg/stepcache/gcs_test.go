@@ -0,0 +1,56 @@
+//go:build unit
+// +build unit
+
+package stepcache
+
+import (
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/SAP/jenkins-library/pkg/gcs/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCSBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("download existing entry", func(t *testing.T) {
+		client := &mocks.Client{}
+		client.On("DownloadFile", "bucket", "piper/cache/key.tar.gz", "/tmp/key.tar.gz").Return(nil)
+		backend := &GCSBackend{Client: client, Bucket: "bucket", Folder: "piper/cache"}
+
+		found, err := backend.Download("key.tar.gz", "/tmp/key.tar.gz")
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("download missing entry", func(t *testing.T) {
+		client := &mocks.Client{}
+		client.On("DownloadFile", "bucket", "key.tar.gz", "/tmp/key.tar.gz").Return(errors.Wrap(storage.ErrObjectNotExist, "could not open source file"))
+		backend := &GCSBackend{Client: client, Bucket: "bucket"}
+
+		found, err := backend.Download("key.tar.gz", "/tmp/key.tar.gz")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("download failure", func(t *testing.T) {
+		client := &mocks.Client{}
+		client.On("DownloadFile", "bucket", "key.tar.gz", "/tmp/key.tar.gz").Return(fmt.Errorf("permission denied"))
+		backend := &GCSBackend{Client: client, Bucket: "bucket"}
+
+		_, err := backend.Download("key.tar.gz", "/tmp/key.tar.gz")
+		assert.EqualError(t, err, "failed to download cache entry 'key.tar.gz': permission denied")
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		client := &mocks.Client{}
+		client.On("UploadFile", "bucket", "/tmp/key.tar.gz", "piper/key.tar.gz").Return(nil)
+		backend := &GCSBackend{Client: client, Bucket: "bucket", Folder: "piper"}
+
+		assert.NoError(t, backend.Upload("key.tar.gz", "/tmp/key.tar.gz"))
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,64 @@
+package stepcache
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/pkg/errors"
+)
+
+// S3API defines the subset of the AWS S3 client used for caching
+type S3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3Backend stores cache entries in an AWS S3 bucket
+type S3Backend struct {
+	Client S3API
+	Bucket string
+	Folder string
+}
+
+// Download fetches the entry from the bucket
+func (b *S3Backend) Download(name, targetFile string) (bool, error) {
+	key := path.Join(b.Folder, name)
+	output, err := b.Client.GetObject(context.TODO(), &s3.GetObjectInput{Bucket: &b.Bucket, Key: &key})
+	var notFound *types.NoSuchKey
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to download cache entry '%s'", name)
+	}
+	defer output.Body.Close()
+
+	target, err := os.Create(targetFile)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to create file '%s'", targetFile)
+	}
+	defer target.Close()
+	if _, err := io.Copy(target, output.Body); err != nil {
+		return false, errors.Wrapf(err, "failed to download cache entry '%s'", name)
+	}
+	return true, nil
+}
+
+// Upload stores the entry in the bucket
+func (b *S3Backend) Upload(name, sourceFile string) error {
+	source, err := os.Open(sourceFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file '%s'", sourceFile)
+	}
+	defer source.Close()
+
+	key := path.Join(b.Folder, name)
+	if _, err := b.Client.PutObject(context.TODO(), &s3.PutObjectInput{Bucket: &b.Bucket, Key: &key, Body: source}); err != nil {
+		return errors.Wrapf(err, "failed to upload cache entry '%s'", name)
+	}
+	return nil
+}
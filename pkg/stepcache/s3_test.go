@@ -0,0 +1,63 @@
+//go:build unit
+// +build unit
+
+package stepcache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type s3Mock struct {
+	objects map[string][]byte
+}
+
+func (m *s3Mock) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	content, ok := m.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(content))}, nil
+}
+
+func (m *s3Mock) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	content, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	m.objects[*params.Bucket+"/"+*params.Key] = content
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3Backend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	client := &s3Mock{objects: map[string][]byte{}}
+	backend := &S3Backend{Client: client, Bucket: "bucket", Folder: "cache"}
+
+	found, err := backend.Download("key.tar.gz", filepath.Join(dir, "downloaded"))
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	source := filepath.Join(dir, "key.tar.gz")
+	require.NoError(t, os.WriteFile(source, []byte("content"), 0o644))
+	assert.NoError(t, backend.Upload("key.tar.gz", source))
+	assert.Equal(t, []byte("content"), client.objects["bucket/cache/key.tar.gz"])
+
+	found, err = backend.Download("key.tar.gz", filepath.Join(dir, "downloaded"))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	content, err := os.ReadFile(filepath.Join(dir, "downloaded"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
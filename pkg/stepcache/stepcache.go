@@ -0,0 +1,224 @@
+package stepcache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/bmatcuk/doublestar"
+	"github.com/pkg/errors"
+)
+
+// Inputs describes everything which determines the outcome of a step run
+type Inputs struct {
+	StepName string
+	// Config holds the resolved step configuration, secrets should be removed before
+	Config map[string]interface{}
+	// FilePatterns are glob patterns relative to the workspace, the content of all matching files is considered
+	FilePatterns []string
+	// ExcludePatterns are glob patterns relative to the workspace for files which are never considered
+	ExcludePatterns []string
+	ToolVersion     string
+}
+
+// Fingerprint computes a hash over the step inputs which serves as key for the cache
+func Fingerprint(workspace string, inputs Inputs) (string, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "step:%s\ntool:%s\n", inputs.StepName, inputs.ToolVersion)
+
+	config, err := json.Marshal(inputs.Config)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal step configuration")
+	}
+	fmt.Fprintf(hash, "config:%s\n", config)
+
+	files, err := matchFiles(workspace, inputs.FilePatterns, inputs.ExcludePatterns)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		fileHash, err := hashFile(filepath.Join(workspace, file))
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to hash input file '%s'", file)
+		}
+		fmt.Fprintf(hash, "file:%s:%s\n", filepath.ToSlash(file), fileHash)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Cache restores and records the outputs of a step run for a fingerprint
+type Cache struct {
+	backend Backend
+	key     string
+	entry   string
+}
+
+// New creates a cache for the entry with the given key
+func New(backend Backend, key string) *Cache {
+	return &Cache{backend: backend, key: key}
+}
+
+// Lookup fetches the cache entry and reports whether it exists
+func (c *Cache) Lookup() (bool, error) {
+	tmpDir, err := os.MkdirTemp("", "stepcache")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create temporary directory")
+	}
+	entry := filepath.Join(tmpDir, archiveName(c.key))
+	found, err := c.backend.Download(archiveName(c.key), entry)
+	if err != nil || !found {
+		os.RemoveAll(tmpDir)
+		return false, err
+	}
+	c.entry = entry
+	return true, nil
+}
+
+// Restore extracts the outputs recorded in the cache entry into the workspace
+func (c *Cache) Restore(workspace string) error {
+	if len(c.entry) == 0 {
+		return fmt.Errorf("no cache entry available for key '%s'", c.key)
+	}
+	defer os.RemoveAll(filepath.Dir(c.entry))
+	if err := piperutils.Untar(c.entry, workspace, 0); err != nil {
+		return errors.Wrapf(err, "failed to extract cache entry '%s'", c.key)
+	}
+	return nil
+}
+
+// Store records all files matching the output patterns as cache entry
+func (c *Cache) Store(workspace string, outputPatterns, excludePatterns []string) error {
+	files, err := matchFiles(workspace, outputPatterns, excludePatterns)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp("", "stepcache")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	entry := filepath.Join(tmpDir, archiveName(c.key))
+	if err := writeArchive(entry, workspace, files); err != nil {
+		return errors.Wrapf(err, "failed to create cache entry '%s'", c.key)
+	}
+	log.Entry().Debugf("Recording %d file(s) in cache entry '%s'", len(files), c.key)
+	return c.backend.Upload(archiveName(c.key), entry)
+}
+
+func archiveName(key string) string {
+	return key + ".tar.gz"
+}
+
+// matchFiles returns the sorted workspace relative paths of all regular files matching the patterns but none of the excludes
+func matchFiles(workspace string, patterns, excludes []string) ([]string, error) {
+	unique := map[string]bool{}
+	for _, pattern := range patterns {
+		matches, err := doublestar.Glob(filepath.Join(workspace, pattern))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid file pattern '%s'", pattern)
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			rel, err := filepath.Rel(workspace, match)
+			if err != nil {
+				return nil, err
+			}
+			if excluded, err := matchesAny(filepath.ToSlash(rel), excludes); err != nil || excluded {
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			unique[rel] = true
+		}
+	}
+	files := make([]string, 0, len(unique))
+	for file := range unique {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func matchesAny(file string, patterns []string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := doublestar.PathMatch(pattern, file)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid exclude pattern '%s'", pattern)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeArchive(target, workspace string, files []string) error {
+	archive, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		if err := addToArchive(tarWriter, workspace, file); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func addToArchive(tarWriter *tar.Writer, workspace, file string) error {
+	source, err := os.Open(filepath.Join(workspace, file))
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(file)
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, source)
+	return err
+}
@@ -0,0 +1,145 @@
+//go:build unit
+// +build unit
+
+package stepcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	workspace := t.TempDir()
+	writeFiles(t, workspace, map[string]string{
+		"pom.xml":                  "<project/>",
+		"module/pom.xml":           "<project/>",
+		"src/main/java/App.java":   "class App {}",
+		"target/classes/App.class": "binary",
+	})
+	inputs := Inputs{
+		StepName:     "mavenBuild",
+		Config:       map[string]interface{}{"goals": []string{"install"}, "publish": false},
+		FilePatterns: []string{"**/pom.xml", "src/**"},
+		ToolVersion:  "maven:3.6-jdk-8",
+	}
+
+	fingerprint, err := Fingerprint(workspace, inputs)
+	require.NoError(t, err)
+	assert.Len(t, fingerprint, 64)
+
+	t.Run("stable for unchanged inputs", func(t *testing.T) {
+		again, err := Fingerprint(workspace, inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, fingerprint, again)
+	})
+
+	t.Run("unaffected by files outside of the patterns", func(t *testing.T) {
+		writeFiles(t, workspace, map[string]string{"target/classes/Other.class": "binary"})
+		again, err := Fingerprint(workspace, inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, fingerprint, again)
+	})
+
+	t.Run("unaffected by excluded files", func(t *testing.T) {
+		writeFiles(t, workspace, map[string]string{"src/node_modules/lib/index.js": "module"})
+		excluding := inputs
+		excluding.ExcludePatterns = []string{"**/node_modules/**"}
+		again, err := Fingerprint(workspace, excluding)
+		assert.NoError(t, err)
+		assert.Equal(t, fingerprint, again)
+	})
+
+	t.Run("changes with the configuration", func(t *testing.T) {
+		changed := inputs
+		changed.Config = map[string]interface{}{"goals": []string{"verify"}, "publish": false}
+		other, err := Fingerprint(workspace, changed)
+		assert.NoError(t, err)
+		assert.NotEqual(t, fingerprint, other)
+	})
+
+	t.Run("changes with the tool version", func(t *testing.T) {
+		changed := inputs
+		changed.ToolVersion = "maven:3.8-jdk-11"
+		other, err := Fingerprint(workspace, changed)
+		assert.NoError(t, err)
+		assert.NotEqual(t, fingerprint, other)
+	})
+
+	t.Run("changes with input file content", func(t *testing.T) {
+		otherWorkspace := t.TempDir()
+		writeFiles(t, otherWorkspace, map[string]string{
+			"pom.xml":                "<project/>",
+			"module/pom.xml":         "<project><modules/></project>",
+			"src/main/java/App.java": "class App {}",
+		})
+		other, err := Fingerprint(otherWorkspace, inputs)
+		assert.NoError(t, err)
+		assert.NotEqual(t, fingerprint, other)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		changed := inputs
+		changed.FilePatterns = []string{"[a-"}
+		_, err := Fingerprint(workspace, changed)
+		assert.ErrorContains(t, err, "invalid file pattern '[a-'")
+	})
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	backend := &DirectoryBackend{Path: filepath.Join(t.TempDir(), "cache")}
+
+	t.Run("miss", func(t *testing.T) {
+		found, err := New(backend, "unknown").Lookup()
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("store and restore", func(t *testing.T) {
+		workspace := t.TempDir()
+		writeFiles(t, workspace, map[string]string{
+			"hadolint.xml": "<checkstyle/>",
+			".pipeline/commonPipelineEnvironment/custom/buildSettingsInfo": "{}",
+			"hadolintExecute_reports.json":                                 "[]",
+			"Dockerfile":                                                   "FROM scratch",
+		})
+		err := New(backend, "key1").Store(workspace, []string{"hadolint.xml", ".pipeline/commonPipelineEnvironment/custom/*", "*_reports.json", "notThere.json"}, nil)
+		require.NoError(t, err)
+
+		restoreWorkspace := t.TempDir()
+		cache := New(backend, "key1")
+		found, err := cache.Lookup()
+		require.NoError(t, err)
+		require.True(t, found)
+		require.NoError(t, cache.Restore(restoreWorkspace))
+
+		content, err := os.ReadFile(filepath.Join(restoreWorkspace, "hadolint.xml"))
+		assert.NoError(t, err)
+		assert.Equal(t, "<checkstyle/>", string(content))
+		content, err = os.ReadFile(filepath.Join(restoreWorkspace, ".pipeline/commonPipelineEnvironment/custom/buildSettingsInfo"))
+		assert.NoError(t, err)
+		assert.Equal(t, "{}", string(content))
+		assert.FileExists(t, filepath.Join(restoreWorkspace, "hadolintExecute_reports.json"))
+		assert.NoFileExists(t, filepath.Join(restoreWorkspace, "Dockerfile"))
+	})
+
+	t.Run("restore without lookup", func(t *testing.T) {
+		err := New(backend, "key1").Restore(t.TempDir())
+		assert.EqualError(t, err, "no cache entry available for key 'key1'")
+	})
+}
@@ -0,0 +1,227 @@
+package testresults
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Status is the outcome of a test case
+type Status string
+
+const (
+	// Passed is the status of a successful test case
+	Passed Status = "passed"
+	// Failed is the status of a test case with a failed assertion
+	Failed Status = "failed"
+	// Errored is the status of a test case which terminated with an unexpected error
+	Errored Status = "error"
+	// Skipped is the status of a test case which has not been executed
+	Skipped Status = "skipped"
+)
+
+// TestCase is a single test case independent of the test framework which executed it
+type TestCase struct {
+	Suite     string  `json:"suite,omitempty"`
+	Classname string  `json:"classname,omitempty"`
+	Name      string  `json:"name"`
+	File      string  `json:"file,omitempty"`
+	Time      float64 `json:"time"`
+	Status    Status  `json:"status"`
+	Message   string  `json:"message,omitempty"`
+	// Source is the result file the test case has been read from
+	Source string `json:"source,omitempty"`
+}
+
+// ID returns a human readable identifier of the test case
+func (t TestCase) ID() string {
+	if len(t.Classname) > 0 {
+		return t.Classname + "." + t.Name
+	}
+	if len(t.Suite) > 0 {
+		return t.Suite + "." + t.Name
+	}
+	return t.Name
+}
+
+// TestSummary contains the number of test cases per status
+type TestSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errors  int `json:"errors"`
+	Skipped int `json:"skipped"`
+}
+
+// Executed returns the number of test cases which have not been skipped
+func (s TestSummary) Executed() int {
+	return s.Total - s.Skipped
+}
+
+// PassRate returns the percentage of passed test cases among the executed ones, 100 in case no test case has been executed
+func (s TestSummary) PassRate() float64 {
+	if s.Executed() == 0 {
+		return 100
+	}
+	return percentage(s.Passed, s.Executed())
+}
+
+// SummarizeTests counts the test cases per status
+func SummarizeTests(testCases []TestCase) TestSummary {
+	summary := TestSummary{Total: len(testCases)}
+	for _, t := range testCases {
+		switch t.Status {
+		case Passed:
+			summary.Passed++
+		case Failed:
+			summary.Failed++
+		case Errored:
+			summary.Errors++
+		case Skipped:
+			summary.Skipped++
+		}
+	}
+	return summary
+}
+
+// LineCoverage contains the coverage information of a single source line
+type LineCoverage struct {
+	Hits            int
+	Branches        int
+	CoveredBranches int
+}
+
+// FileCoverage contains the coverage information of a source file per line
+type FileCoverage struct {
+	Path  string
+	Lines map[int]*LineCoverage
+}
+
+// Coverage contains line based coverage information of several source files.
+// Coverage of the same source file reported by several tools or test runs is merged,
+// a line counts as covered if it has been covered in any of them.
+type Coverage struct {
+	Files map[string]*FileCoverage
+}
+
+// NewCoverage creates an empty coverage
+func NewCoverage() *Coverage {
+	return &Coverage{Files: map[string]*FileCoverage{}}
+}
+
+// AddLine adds the coverage information of a line, existing information of the line is merged
+func (c *Coverage) AddLine(file string, line, hits, branches, coveredBranches int) {
+	file = normalizePath(file)
+	fileCoverage, ok := c.Files[file]
+	if !ok {
+		fileCoverage = &FileCoverage{Path: file, Lines: map[int]*LineCoverage{}}
+		c.Files[file] = fileCoverage
+	}
+	lineCoverage, ok := fileCoverage.Lines[line]
+	if !ok {
+		fileCoverage.Lines[line] = &LineCoverage{Hits: hits, Branches: branches, CoveredBranches: coveredBranches}
+		return
+	}
+	lineCoverage.Hits = max(lineCoverage.Hits, hits)
+	lineCoverage.Branches = max(lineCoverage.Branches, branches)
+	lineCoverage.CoveredBranches = max(lineCoverage.CoveredBranches, coveredBranches)
+}
+
+// Merge adds the coverage information of other to the coverage
+func (c *Coverage) Merge(other *Coverage) {
+	for _, file := range other.Files {
+		for number, line := range file.Lines {
+			c.AddLine(file.Path, number, line.Hits, line.Branches, line.CoveredBranches)
+		}
+	}
+}
+
+// Filter returns the coverage of the given files only.
+// Since coverage tools report paths relative to different roots (e.g. the source folder or the Java package),
+// a file matches if one of the paths is a suffix of the other one.
+func (c *Coverage) Filter(files []string) *Coverage {
+	filtered := NewCoverage()
+	for name, fileCoverage := range c.Files {
+		for _, file := range files {
+			if pathMatches(name, normalizePath(file)) {
+				filtered.Files[name] = fileCoverage
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// CoverageSummary contains the number of total and covered lines and branches
+type CoverageSummary struct {
+	Path            string `json:"path,omitempty"`
+	Lines           int    `json:"lines"`
+	CoveredLines    int    `json:"coveredLines"`
+	Branches        int    `json:"branches"`
+	CoveredBranches int    `json:"coveredBranches"`
+}
+
+// LineRate returns the percentage of covered lines
+func (s CoverageSummary) LineRate() float64 {
+	return percentage(s.CoveredLines, s.Lines)
+}
+
+// BranchRate returns the percentage of covered branches
+func (s CoverageSummary) BranchRate() float64 {
+	return percentage(s.CoveredBranches, s.Branches)
+}
+
+func (s *CoverageSummary) add(other CoverageSummary) {
+	s.Lines += other.Lines
+	s.CoveredLines += other.CoveredLines
+	s.Branches += other.Branches
+	s.CoveredBranches += other.CoveredBranches
+}
+
+// Summary returns the coverage of the file
+func (f *FileCoverage) Summary() CoverageSummary {
+	summary := CoverageSummary{Path: f.Path, Lines: len(f.Lines)}
+	for _, line := range f.Lines {
+		if line.Hits > 0 {
+			summary.CoveredLines++
+		}
+		summary.Branches += line.Branches
+		summary.CoveredBranches += min(line.CoveredBranches, line.Branches)
+	}
+	return summary
+}
+
+// Summary returns the coverage across all files
+func (c *Coverage) Summary() CoverageSummary {
+	summary := CoverageSummary{}
+	for _, file := range c.Files {
+		summary.add(file.Summary())
+	}
+	return summary
+}
+
+// FileSummaries returns the coverage per file sorted by path
+func (c *Coverage) FileSummaries() []CoverageSummary {
+	summaries := []CoverageSummary{}
+	for _, file := range c.Files {
+		summaries = append(summaries, file.Summary())
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Path < summaries[j].Path })
+	return summaries
+}
+
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+func normalizePath(file string) string {
+	file = strings.ReplaceAll(file, "\\", "/")
+	return strings.TrimPrefix(path.Clean(file), "./")
+}
+
+func pathMatches(a, b string) bool {
+	return a == b || strings.HasSuffix(a, "/"+b) || strings.HasSuffix(b, "/"+a)
+}
@@ -0,0 +1,248 @@
+package testresults
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the format of a test result or coverage file
+type Format string
+
+const (
+	// JUnit is the JUnit XML format for test results which is supported by most test frameworks
+	JUnit Format = "junit"
+	// Cobertura is the Cobertura XML coverage format
+	Cobertura Format = "cobertura"
+	// JaCoCo is the JaCoCo XML coverage format
+	JaCoCo Format = "jacoco"
+	// GoCoverProfile is the coverage profile created by go test -coverprofile
+	GoCoverProfile Format = "gocover"
+)
+
+// DetectFormat determines the format of a test result or coverage file from its content
+func DetectFormat(content []byte) (Format, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("mode:")) {
+		return GoCoverProfile, nil
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "unsupported file format")
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			switch element.Name.Local {
+			case "testsuites", "testsuite":
+				return JUnit, nil
+			case "coverage":
+				return Cobertura, nil
+			case "report":
+				return JaCoCo, nil
+			}
+			return "", fmt.Errorf("unsupported file format with root element '%v'", element.Name.Local)
+		}
+	}
+	return "", fmt.Errorf("unsupported file format")
+}
+
+type junitSuite struct {
+	Name      string          `xml:"name,attr"`
+	File      string          `xml:"file,attr"`
+	Suites    []junitSuite    `xml:"testsuite"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Time      string        `xml:"time,attr"`
+	Failures  []junitResult `xml:"failure"`
+	Errors    []junitResult `xml:"error"`
+	Skipped   []junitResult `xml:"skipped"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r junitResult) String() string {
+	if len(r.Message) > 0 {
+		return r.Message
+	}
+	return strings.TrimSpace(r.Text)
+}
+
+// ParseJUnit reads the test cases of a JUnit XML file. The root element may be a single test suite or a list of (nested) test suites.
+func ParseJUnit(content []byte) ([]TestCase, error) {
+	root := junitSuite{}
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JUnit XML")
+	}
+	testCases := []TestCase{}
+	collectJUnitTestCases(root, &testCases)
+	return testCases, nil
+}
+
+func collectJUnitTestCases(suite junitSuite, testCases *[]TestCase) {
+	for _, t := range suite.TestCases {
+		testCase := TestCase{Suite: suite.Name, Classname: t.Classname, Name: t.Name, File: t.File, Status: Passed}
+		if len(testCase.File) == 0 {
+			testCase.File = suite.File
+		}
+		testCase.Time, _ = strconv.ParseFloat(strings.TrimSpace(t.Time), 64)
+		switch {
+		case len(t.Errors) > 0:
+			testCase.Status = Errored
+			testCase.Message = t.Errors[0].String()
+		case len(t.Failures) > 0:
+			testCase.Status = Failed
+			testCase.Message = t.Failures[0].String()
+		case len(t.Skipped) > 0:
+			testCase.Status = Skipped
+			testCase.Message = t.Skipped[0].String()
+		}
+		*testCases = append(*testCases, testCase)
+	}
+	for _, s := range suite.Suites {
+		collectJUnitTestCases(s, testCases)
+	}
+}
+
+type coberturaReport struct {
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number            int    `xml:"number,attr"`
+				Hits              int    `xml:"hits,attr"`
+				Branch            bool   `xml:"branch,attr"`
+				ConditionCoverage string `xml:"condition-coverage,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+// conditionCoverage matches the branch information of Cobertura like "50% (1/2)"
+var conditionCoverage = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+// ParseCobertura reads the line coverage of a Cobertura XML file.
+// File names are kept as reported, i.e. relative to the source folders of the report.
+func ParseCobertura(content []byte) (*Coverage, error) {
+	report := coberturaReport{}
+	if err := xml.Unmarshal(content, &report); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Cobertura XML")
+	}
+	coverage := NewCoverage()
+	for _, p := range report.Packages {
+		for _, c := range p.Classes {
+			for _, line := range c.Lines {
+				branches, covered := 0, 0
+				if m := conditionCoverage.FindStringSubmatch(line.ConditionCoverage); line.Branch && m != nil {
+					covered, _ = strconv.Atoi(m[1])
+					branches, _ = strconv.Atoi(m[2])
+				}
+				coverage.AddLine(c.Filename, line.Number, line.Hits, branches, covered)
+			}
+		}
+	}
+	return coverage, nil
+}
+
+type jacocoGroup struct {
+	Groups   []jacocoGroup `xml:"group"`
+	Packages []struct {
+		Name        string `xml:"name,attr"`
+		SourceFiles []struct {
+			Name  string `xml:"name,attr"`
+			Lines []struct {
+				Number             int `xml:"nr,attr"`
+				MissedInstructions int `xml:"mi,attr"`
+				CoveredInstruction int `xml:"ci,attr"`
+				MissedBranches     int `xml:"mb,attr"`
+				CoveredBranches    int `xml:"cb,attr"`
+			} `xml:"line"`
+		} `xml:"sourcefile"`
+	} `xml:"package"`
+}
+
+// ParseJaCoCo reads the line coverage of a JaCoCo XML file. File names are relative to the source root, e.g. com/example/Main.java.
+func ParseJaCoCo(content []byte) (*Coverage, error) {
+	report := jacocoGroup{}
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	// JaCoCo reports reference a DTD which must not be resolved
+	decoder.Strict = false
+	if err := decoder.Decode(&report); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JaCoCo XML")
+	}
+	coverage := NewCoverage()
+	collectJaCoCoCoverage(report, coverage)
+	return coverage, nil
+}
+
+func collectJaCoCoCoverage(group jacocoGroup, coverage *Coverage) {
+	for _, p := range group.Packages {
+		for _, sourceFile := range p.SourceFiles {
+			file := path.Join(p.Name, sourceFile.Name)
+			for _, line := range sourceFile.Lines {
+				coverage.AddLine(file, line.Number, line.CoveredInstruction, line.MissedBranches+line.CoveredBranches, line.CoveredBranches)
+			}
+		}
+	}
+	for _, g := range group.Groups {
+		collectJaCoCoCoverage(g, coverage)
+	}
+}
+
+// goCoverBlock matches a block of a Go coverage profile: <file>:<startLine>.<startCol>,<endLine>.<endCol> <statements> <count>
+var goCoverBlock = regexp.MustCompile(`^(.+):(\d+)\.\d+,(\d+)\.\d+ (\d+) (\d+)$`)
+
+// ParseGoCoverProfile reads the line coverage of a Go coverage profile.
+// The module path is removed from the file names in order to get paths relative to the module root.
+func ParseGoCoverProfile(content []byte, modulePath string) (*Coverage, error) {
+	coverage := NewCoverage()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		m := goCoverBlock.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("invalid coverage profile in line %v: '%v'", lineNumber, line)
+		}
+		startLine, _ := strconv.Atoi(m[2])
+		endLine, _ := strconv.Atoi(m[3])
+		statements, _ := strconv.Atoi(m[4])
+		count, _ := strconv.Atoi(m[5])
+		if statements == 0 {
+			continue
+		}
+		file := m[1]
+		if len(modulePath) > 0 {
+			file = strings.TrimPrefix(strings.TrimPrefix(file, modulePath), "/")
+		}
+		for l := startLine; l <= endLine; l++ {
+			coverage.AddLine(file, l, count, 0, 0)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read coverage profile")
+	}
+	return coverage, nil
+}
@@ -0,0 +1,169 @@
+package testresults
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+// ReportsDirectory defines the subfolder for the test result reports which are generated
+const ReportsDirectory = "testresults"
+
+// Gate is a threshold a metric of the results needs to meet
+type Gate struct {
+	Name      string  `json:"name"`
+	Threshold int     `json:"threshold"`
+	Actual    float64 `json:"actual"`
+	Passed    bool    `json:"passed"`
+}
+
+// NewGate evaluates the actual value of a metric in percent against the threshold
+func NewGate(name string, actual float64, threshold int) Gate {
+	return Gate{Name: name, Threshold: threshold, Actual: actual, Passed: actual >= float64(threshold)}
+}
+
+func (g Gate) String() string {
+	return fmt.Sprintf("%v of %.2f%% (threshold %v%%)", g.Name, g.Actual, g.Threshold)
+}
+
+// Report is the aggregated result of all test and coverage files
+type Report struct {
+	Tests        TestSummary       `json:"tests"`
+	PassRate     float64           `json:"passRate"`
+	FailedTests  []TestCase        `json:"failedTests"`
+	Coverage     CoverageSummary   `json:"coverage"`
+	LineRate     float64           `json:"lineCoverage"`
+	BranchRate   float64           `json:"branchCoverage"`
+	Gates        []Gate            `json:"gates"`
+	ChangedFiles []CoverageSummary `json:"changedFiles,omitempty"`
+	// ChangedFilesCoverage is only set if the changed files have been determined
+	ChangedFilesCoverage *CoverageSummary `json:"changedFilesCoverage,omitempty"`
+}
+
+// NewReport creates the report of the results, changedFiles are only considered if not nil
+func NewReport(results *Results, changedFiles []string) Report {
+	tests := SummarizeTests(results.TestCases)
+	coverage := results.Coverage.Summary()
+	report := Report{
+		Tests:       tests,
+		PassRate:    tests.PassRate(),
+		FailedTests: results.FailedTests(),
+		Coverage:    coverage,
+		LineRate:    coverage.LineRate(),
+		BranchRate:  coverage.BranchRate(),
+		Gates:       []Gate{},
+	}
+	if changedFiles != nil {
+		changed := results.Coverage.Filter(changedFiles)
+		summary := changed.Summary()
+		report.ChangedFilesCoverage = &summary
+		report.ChangedFiles = changed.FileSummaries()
+	}
+	return report
+}
+
+// Passed returns false if any of the gates is not met
+func (r Report) Passed() bool {
+	for _, gate := range r.Gates {
+		if !gate.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateCustomReport creates a ScanReport containing the summary, the gates and the failed tests
+func CreateCustomReport(report Report) reporting.ScanReport {
+	scanReport := reporting.ScanReport{
+		ReportTitle: "Test Results Report",
+		Overview: []reporting.OverviewRow{
+			{Description: "Tests", Details: fmt.Sprint(report.Tests.Total)},
+			{Description: "Passed tests", Details: fmt.Sprint(report.Tests.Passed)},
+			{Description: "Failed tests", Details: fmt.Sprint(report.Tests.Failed + report.Tests.Errors), Style: redIf(report.Tests.Failed+report.Tests.Errors > 0)},
+			{Description: "Skipped tests", Details: fmt.Sprint(report.Tests.Skipped)},
+			{Description: "Pass rate", Details: fmt.Sprintf("%.2f%%", report.PassRate)},
+			{Description: "Line coverage", Details: fmt.Sprintf("%.2f%% (%v/%v)", report.LineRate, report.Coverage.CoveredLines, report.Coverage.Lines)},
+			{Description: "Branch coverage", Details: fmt.Sprintf("%.2f%% (%v/%v)", report.BranchRate, report.Coverage.CoveredBranches, report.Coverage.Branches)},
+		},
+		SuccessfulScan: report.Passed(),
+		ReportTime:     time.Now(),
+	}
+	if changed := report.ChangedFilesCoverage; changed != nil {
+		scanReport.Overview = append(scanReport.Overview,
+			reporting.OverviewRow{Description: "Changed files line coverage", Details: fmt.Sprintf("%.2f%% (%v/%v)", changed.LineRate(), changed.CoveredLines, changed.Lines)},
+			reporting.OverviewRow{Description: "Changed files branch coverage", Details: fmt.Sprintf("%.2f%% (%v/%v)", changed.BranchRate(), changed.CoveredBranches, changed.Branches)},
+		)
+	}
+	for _, gate := range report.Gates {
+		status := "passed"
+		if !gate.Passed {
+			status = "failed"
+		}
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{
+			Description: fmt.Sprintf("Gate: %v >= %v%%", gate.Name, gate.Threshold),
+			Details:     fmt.Sprintf("%v (%.2f%%)", status, gate.Actual),
+			Style:       redIf(!gate.Passed),
+		})
+	}
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No failed tests",
+		Headers:       []string{"Test", "Status", "Message", "Source"},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, t := range report.FailedTests {
+		row := reporting.ScanRow{}
+		row.AddColumn(t.ID(), 0)
+		row.AddColumn(t.Status, reporting.Red)
+		row.AddColumn(t.Message, 0)
+		row.AddColumn(t.Source, 0)
+		detailTable.Rows = append(detailTable.Rows, row)
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
+
+func redIf(condition bool) reporting.ColumnStyle {
+	if condition {
+		return reporting.Red
+	}
+	return 0
+}
+
+// WriteReports writes the markdown summary and the JSON report
+func WriteReports(report Report, fileUtils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+	if err := fileUtils.MkdirAll(ReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+
+	scanReport := CreateCustomReport(report)
+	markdown, err := scanReport.ToMarkdown()
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create markdown report")
+	}
+	markdownPath := filepath.Join(ReportsDirectory, "piper_test_results.md")
+	if err := fileUtils.FileWrite(markdownPath, markdown, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write markdown report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Test Results Summary", Target: markdownPath})
+
+	jsonReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create json report")
+	}
+	jsonPath := filepath.Join(ReportsDirectory, "piper_test_results.json")
+	if err := fileUtils.FileWrite(jsonPath, jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Test Results", Target: jsonPath})
+
+	return reportPaths, nil
+}
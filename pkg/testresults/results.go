@@ -0,0 +1,62 @@
+package testresults
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Results contains the test cases and the coverage read from the result files of several steps
+type Results struct {
+	TestCases []TestCase
+	Coverage  *Coverage
+	// GoModulePath is removed from the file names of Go coverage profiles
+	GoModulePath string
+}
+
+// NewResults creates empty results
+func NewResults() *Results {
+	return &Results{TestCases: []TestCase{}, Coverage: NewCoverage()}
+}
+
+// Add detects the format of the file content and adds the contained test cases or coverage to the results
+func (r *Results) Add(fileName string, content []byte) (Format, error) {
+	format, err := DetectFormat(content)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read '%v'", fileName)
+	}
+
+	var coverage *Coverage
+	switch format {
+	case JUnit:
+		testCases, err := ParseJUnit(content)
+		if err != nil {
+			return format, errors.Wrapf(err, "failed to read '%v'", fileName)
+		}
+		for i := range testCases {
+			testCases[i].Source = fileName
+		}
+		r.TestCases = append(r.TestCases, testCases...)
+		return format, nil
+	case Cobertura:
+		coverage, err = ParseCobertura(content)
+	case JaCoCo:
+		coverage, err = ParseJaCoCo(content)
+	case GoCoverProfile:
+		coverage, err = ParseGoCoverProfile(content, r.GoModulePath)
+	}
+	if err != nil {
+		return format, errors.Wrapf(err, "failed to read '%v'", fileName)
+	}
+	r.Coverage.Merge(coverage)
+	return format, nil
+}
+
+// FailedTests returns the test cases which failed or terminated with an error
+func (r *Results) FailedTests() []TestCase {
+	failed := []TestCase{}
+	for _, t := range r.TestCases {
+		if t.Status == Failed || t.Status == Errored {
+			failed = append(failed, t)
+		}
+	}
+	return failed
+}
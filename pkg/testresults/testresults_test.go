@@ -0,0 +1,178 @@
+//go:build unit
+// +build unit
+
+package testresults
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJUnitResult = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="com.example.MainTest" file="src/test/java/com/example/MainTest.java">
+    <testcase classname="com.example.MainTest" name="passes" time="0.12"/>
+    <testcase classname="com.example.MainTest" name="fails" time="0.5"><failure message="expected 1 but was 2">stack</failure></testcase>
+    <testsuite name="nested">
+      <testcase classname="com.example.Nested" name="errors"><error>NullPointerException</error></testcase>
+      <testcase classname="com.example.Nested" name="skipped"><skipped/></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+const testCoberturaReport = `<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.5" branch-rate="0.5" version="1.9">
+  <sources><source>/workspace/project</source></sources>
+  <packages>
+    <package name="src">
+      <classes>
+        <class name="index.js" filename="src/index.js">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="0" branch="true" condition-coverage="50% (1/2)"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+const testJaCoCoReport = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd"><report name="app"><group name="module"><package name="com/example"><sourcefile name="Main.java"><line nr="3" mi="0" ci="3" mb="1" cb="1"/><line nr="4" mi="2" ci="0" mb="0" cb="0"/></sourcefile></package></group></report>`
+
+const testGoCoverProfile = `mode: set
+github.com/example/project/pkg/a.go:3.14,5.2 2 1
+github.com/example/project/pkg/a.go:7.14,8.2 1 0
+github.com/example/project/pkg/a.go:5.2,6.3 1 0
+`
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected Format
+	}{
+		{testJUnitResult, JUnit},
+		{`<testsuite name="single"/>`, JUnit},
+		{testCoberturaReport, Cobertura},
+		{testJaCoCoReport, JaCoCo},
+		{"\xef\xbb\xbf" + testGoCoverProfile, GoCoverProfile},
+	}
+	for _, test := range tests {
+		format, err := DetectFormat([]byte(test.content))
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, format)
+	}
+
+	_, err := DetectFormat([]byte(`<html/>`))
+	assert.EqualError(t, err, "unsupported file format with root element 'html'")
+}
+
+func TestParseJUnit(t *testing.T) {
+	testCases, err := ParseJUnit([]byte(testJUnitResult))
+
+	require.NoError(t, err)
+	if assert.Len(t, testCases, 4) {
+		assert.Equal(t, TestCase{Suite: "com.example.MainTest", Classname: "com.example.MainTest", Name: "passes", File: "src/test/java/com/example/MainTest.java", Time: 0.12, Status: Passed}, testCases[0])
+		assert.Equal(t, Failed, testCases[1].Status)
+		assert.Equal(t, "expected 1 but was 2", testCases[1].Message)
+		assert.Equal(t, Errored, testCases[2].Status)
+		assert.Equal(t, "NullPointerException", testCases[2].Message)
+		assert.Equal(t, "nested", testCases[2].Suite)
+		assert.Equal(t, Skipped, testCases[3].Status)
+	}
+	summary := SummarizeTests(testCases)
+	assert.Equal(t, TestSummary{Total: 4, Passed: 1, Failed: 1, Errors: 1, Skipped: 1}, summary)
+	assert.InDelta(t, 33.33, summary.PassRate(), 0.01)
+	assert.Equal(t, float64(100), TestSummary{}.PassRate())
+}
+
+func TestParseCoverage(t *testing.T) {
+	t.Run("Cobertura", func(t *testing.T) {
+		coverage, err := ParseCobertura([]byte(testCoberturaReport))
+
+		require.NoError(t, err)
+		assert.Equal(t, CoverageSummary{Lines: 2, CoveredLines: 1, Branches: 2, CoveredBranches: 1}, coverage.Summary())
+	})
+
+	t.Run("JaCoCo", func(t *testing.T) {
+		coverage, err := ParseJaCoCo([]byte(testJaCoCoReport))
+
+		require.NoError(t, err)
+		assert.Equal(t, []CoverageSummary{{Path: "com/example/Main.java", Lines: 2, CoveredLines: 1, Branches: 2, CoveredBranches: 1}}, coverage.FileSummaries())
+	})
+
+	t.Run("Go coverage profile", func(t *testing.T) {
+		coverage, err := ParseGoCoverProfile([]byte(testGoCoverProfile), "github.com/example/project")
+
+		require.NoError(t, err)
+		// line 5 is contained in a covered and an uncovered block
+		assert.Equal(t, []CoverageSummary{{Path: "pkg/a.go", Lines: 6, CoveredLines: 3}}, coverage.FileSummaries())
+	})
+
+	t.Run("invalid Go coverage profile", func(t *testing.T) {
+		_, err := ParseGoCoverProfile([]byte("mode: set\nsomething else\n"), "")
+
+		assert.EqualError(t, err, "invalid coverage profile in line 2: 'something else'")
+	})
+}
+
+func TestCoverageMergeAndFilter(t *testing.T) {
+	coverage := NewCoverage()
+	coverage.AddLine("./src/index.js", 1, 0, 2, 0)
+	coverage.AddLine("src/index.js", 1, 3, 2, 1)
+	coverage.AddLine("src/other.js", 1, 0, 0, 0)
+	coverage.AddLine("com/example/Main.java", 1, 1, 0, 0)
+
+	assert.Equal(t, CoverageSummary{Lines: 3, CoveredLines: 2, Branches: 2, CoveredBranches: 1}, coverage.Summary())
+
+	changed := coverage.Filter([]string{"frontend/src/index.js", "app/src/main/java/com/example/Main.java", "README.md"})
+	assert.Equal(t, CoverageSummary{Lines: 2, CoveredLines: 2, Branches: 2, CoveredBranches: 1}, changed.Summary())
+	assert.Empty(t, coverage.Filter([]string{"dex.js"}).Files, "only complete path segments match")
+}
+
+func TestResults(t *testing.T) {
+	results := NewResults()
+	results.GoModulePath = "github.com/example/project"
+
+	for name, content := range map[string]string{"TEST-Main.xml": testJUnitResult, "cover.out": testGoCoverProfile, "jacoco.xml": testJaCoCoReport} {
+		_, err := results.Add(name, []byte(content))
+		require.NoError(t, err)
+	}
+	_, err := results.Add("index.html", []byte("<html/>"))
+	assert.EqualError(t, err, "failed to read 'index.html': unsupported file format with root element 'html'")
+
+	report := NewReport(results, []string{"pkg/a.go"})
+	report.Gates = append(report.Gates, NewGate("line coverage", report.LineRate, 50), NewGate("pass rate", report.PassRate, 100))
+
+	assert.Len(t, report.FailedTests, 2)
+	assert.Equal(t, "TEST-Main.xml", report.FailedTests[0].Source)
+	assert.Equal(t, CoverageSummary{Lines: 8, CoveredLines: 4, Branches: 2, CoveredBranches: 1}, report.Coverage)
+	assert.Equal(t, &CoverageSummary{Lines: 6, CoveredLines: 3}, report.ChangedFilesCoverage)
+	assert.True(t, report.Gates[0].Passed)
+	assert.False(t, report.Gates[1].Passed)
+	assert.False(t, report.Passed())
+
+	t.Run("write reports", func(t *testing.T) {
+		files := &mock.FilesMock{}
+
+		paths, err := WriteReports(report, files)
+
+		require.NoError(t, err)
+		assert.Len(t, paths, 2)
+		markdown, err := files.FileRead(filepath.Join(ReportsDirectory, "piper_test_results.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(markdown), "com.example.MainTest.fails")
+		assert.Contains(t, string(markdown), "Gate: pass rate >= 100%")
+		content, err := files.FileRead(filepath.Join(ReportsDirectory, "piper_test_results.json"))
+		require.NoError(t, err)
+		written := Report{}
+		require.NoError(t, json.Unmarshal(content, &written))
+		assert.Equal(t, report.Coverage, written.Coverage)
+		assert.Equal(t, report.Gates, written.Gates)
+	})
+}
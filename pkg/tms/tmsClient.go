@@ -346,6 +346,122 @@ func (communicationInstance *CommunicationInstance) UploadFile(file, namedUser s
 
 }
 
+func (communicationInstance *CommunicationInstance) GetTransportRequests(nodeId int64) ([]TransportRequest, error) {
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Obtaining transport requests started")
+		communicationInstance.logger.Infof("tmsUrl: %v, nodeId: %v", communicationInstance.tmsUrl, nodeId)
+	}
+
+	header := http.Header{}
+	header.Add("Content-Type", "application/json")
+
+	var aTransportRequests []TransportRequest
+	data, err := sendRequest(communicationInstance, http.MethodGet, fmt.Sprintf("/v2/nodes/%v/transportRequests", nodeId), nil, header, http.StatusOK, false)
+	if err != nil {
+		return aTransportRequests, err
+	}
+
+	var getTransportRequestsResponse transportRequests
+	if err := json.Unmarshal(data, &getTransportRequestsResponse); err != nil {
+		return aTransportRequests, errors.Wrap(err, "unable to parse transport requests")
+	}
+	aTransportRequests = getTransportRequestsResponse.TransportRequests
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Transport requests obtained successfully")
+	}
+	return aTransportRequests, nil
+}
+
+func (communicationInstance *CommunicationInstance) GetTransportRequest(nodeId, transportRequestId int64) (TransportRequest, error) {
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Obtaining transport request started")
+		communicationInstance.logger.Infof("tmsUrl: %v, nodeId: %v, transportRequestId: %v", communicationInstance.tmsUrl, nodeId, transportRequestId)
+	}
+
+	header := http.Header{}
+	header.Add("Content-Type", "application/json")
+
+	var transportRequest TransportRequest
+	data, err := sendRequest(communicationInstance, http.MethodGet, fmt.Sprintf("/v2/nodes/%v/transportRequests/%v", nodeId, transportRequestId), nil, header, http.StatusOK, false)
+	if err != nil {
+		return transportRequest, err
+	}
+
+	if err := json.Unmarshal(data, &transportRequest); err != nil {
+		return transportRequest, errors.Wrapf(err, "unable to parse transport request %v", transportRequestId)
+	}
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Infof("Transport request obtained successfully, status: %v", transportRequest.Status)
+	}
+	return transportRequest, nil
+}
+
+func (communicationInstance *CommunicationInstance) ImportTransportRequests(nodeId int64, transportRequestIds []int64, namedUser string) error {
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Import of transport requests started")
+		communicationInstance.logger.Infof("tmsUrl: %v, nodeId: %v, transportRequestIds: %v, namedUser: %v", communicationInstance.tmsUrl, nodeId, transportRequestIds, namedUser)
+	}
+	err := sendTransportRequestAction(communicationInstance, fmt.Sprintf("/v2/nodes/%v/transportRequests/import", nodeId), transportRequestIds, namedUser)
+	if err != nil {
+		return err
+	}
+	communicationInstance.logger.Infof("Import of transport requests %v into node %v triggered successfully", transportRequestIds, nodeId)
+	return nil
+}
+
+func (communicationInstance *CommunicationInstance) ForwardTransportRequests(nodeId int64, transportRequestIds []int64, namedUser string) error {
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Forward of transport requests started")
+		communicationInstance.logger.Infof("tmsUrl: %v, nodeId: %v, transportRequestIds: %v, namedUser: %v", communicationInstance.tmsUrl, nodeId, transportRequestIds, namedUser)
+	}
+	err := sendTransportRequestAction(communicationInstance, fmt.Sprintf("/v2/nodes/%v/transportRequests/forward", nodeId), transportRequestIds, namedUser)
+	if err != nil {
+		return err
+	}
+	communicationInstance.logger.Infof("Transport requests %v forwarded successfully from node %v to its follow-on nodes", transportRequestIds, nodeId)
+	return nil
+}
+
+func sendTransportRequestAction(communicationInstance *CommunicationInstance, urlPath string, transportRequestIds []int64, namedUser string) error {
+	header := http.Header{}
+	header.Add("Content-Type", "application/json")
+
+	body := TransportRequestActionEntity{NamedUser: namedUser, TransportRequests: transportRequestIds}
+	bodyBytes, errMarshaling := json.Marshal(body)
+	if errMarshaling != nil {
+		return errors.Wrapf(errMarshaling, "unable to marshal request body %v", body)
+	}
+
+	_, errSendRequest := sendRequest(communicationInstance, http.MethodPost, urlPath, bytes.NewReader(bodyBytes), header, http.StatusOK, false)
+	return errSendRequest
+}
+
+func (communicationInstance *CommunicationInstance) GetTransportRequestLogs(nodeId, transportRequestId int64) ([]TransportRequestLog, error) {
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Obtaining transport request logs started")
+		communicationInstance.logger.Infof("tmsUrl: %v, nodeId: %v, transportRequestId: %v", communicationInstance.tmsUrl, nodeId, transportRequestId)
+	}
+
+	header := http.Header{}
+	header.Add("Content-Type", "application/json")
+
+	var logs []TransportRequestLog
+	data, err := sendRequest(communicationInstance, http.MethodGet, fmt.Sprintf("/v2/nodes/%v/transportRequests/%v/logs", nodeId, transportRequestId), nil, header, http.StatusOK, false)
+	if err != nil {
+		return logs, err
+	}
+
+	var getLogsResponse transportRequestLogs
+	if err := json.Unmarshal(data, &getLogsResponse); err != nil {
+		return logs, errors.Wrapf(err, "unable to parse logs of transport request %v", transportRequestId)
+	}
+	logs = getLogsResponse.Logs
+	if communicationInstance.isVerbose {
+		communicationInstance.logger.Info("Transport request logs obtained successfully")
+	}
+	return logs, nil
+}
+
 func upload(communicationInstance *CommunicationInstance, uploadRequestData piperHttp.UploadRequestData, expectedStatusCode int) ([]byte, error) {
 	response, err := communicationInstance.httpClient.Upload(uploadRequestData)
 
@@ -506,6 +506,106 @@ func TestUploadFileToNode(t *testing.T) {
 	})
 }
 
+func TestGetTransportRequests(t *testing.T) {
+	logger := log.Entry().WithField("package", "SAP/jenkins-library/pkg/tms_test")
+	t.Run("test success", func(t *testing.T) {
+		getTransportRequestsResponse := `{"transportRequests": [{"id": 555,"description": "Release 1.0","status": "initial","origin": "DEV"}]}`
+		uploaderMock := uploaderMock{responseBody: getTransportRequestsResponse, httpStatusCode: http.StatusOK}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		transportRequests, err := communicationInstance.GetTransportRequests(456)
+
+		assert.NoError(t, err, "Error occurred, but none expected")
+		assert.Equal(t, "https://tms.dummy.sap.com/v2/nodes/456/transportRequests", uploaderMock.urlCalled, "Called url incorrect")
+		assert.Equal(t, http.MethodGet, uploaderMock.httpMethod, "Http method incorrect")
+		assert.Equal(t, []TransportRequest{{Id: 555, Description: "Release 1.0", Status: TR_STATUS_INITIAL, Origin: "DEV"}}, transportRequests, "Transport requests incorrect")
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		uploaderMock := uploaderMock{responseBody: `Bad request provided`, httpStatusCode: http.StatusBadRequest}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		_, err := communicationInstance.GetTransportRequests(456)
+
+		assert.EqualError(t, err, "http error 400")
+	})
+}
+
+func TestGetTransportRequest(t *testing.T) {
+	logger := log.Entry().WithField("package", "SAP/jenkins-library/pkg/tms_test")
+	t.Run("test success", func(t *testing.T) {
+		uploaderMock := uploaderMock{responseBody: `{"id": 555,"description": "Release 1.0","status": "running"}`, httpStatusCode: http.StatusOK}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com/", httpClient: &uploaderMock, logger: logger, isVerbose: true}
+
+		transportRequest, err := communicationInstance.GetTransportRequest(456, 555)
+
+		assert.NoError(t, err, "Error occurred, but none expected")
+		assert.Equal(t, "https://tms.dummy.sap.com/v2/nodes/456/transportRequests/555", uploaderMock.urlCalled, "Called url incorrect")
+		assert.Equal(t, TR_STATUS_RUNNING, transportRequest.Status, "Status incorrect")
+		assert.False(t, transportRequest.IsImportFinished())
+	})
+
+	t.Run("test invalid response", func(t *testing.T) {
+		uploaderMock := uploaderMock{responseBody: `not json`, httpStatusCode: http.StatusOK}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		_, err := communicationInstance.GetTransportRequest(456, 555)
+
+		assert.ErrorContains(t, err, "unable to parse transport request 555")
+	})
+}
+
+func TestTransportRequestActions(t *testing.T) {
+	logger := log.Entry().WithField("package", "SAP/jenkins-library/pkg/tms_test")
+	t.Run("test import", func(t *testing.T) {
+		uploaderMock := uploaderMock{responseBody: `{"actionId": 1}`, httpStatusCode: http.StatusOK}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		err := communicationInstance.ImportTransportRequests(456, []int64{555}, "testUser")
+
+		assert.NoError(t, err, "Error occurred, but none expected")
+		assert.Equal(t, "https://tms.dummy.sap.com/v2/nodes/456/transportRequests/import", uploaderMock.urlCalled, "Called url incorrect")
+		assert.Equal(t, http.MethodPost, uploaderMock.httpMethod, "Http method incorrect")
+		assert.Equal(t, []string{"application/json"}, uploaderMock.header[http.CanonicalHeaderKey("content-type")], "Content-Type header incorrect")
+		assert.Equal(t, `{"namedUser":"testUser","transportRequests":[555]}`, uploaderMock.requestBody, "Request body incorrect")
+	})
+
+	t.Run("test forward", func(t *testing.T) {
+		uploaderMock := uploaderMock{responseBody: `{}`, httpStatusCode: http.StatusOK}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		err := communicationInstance.ForwardTransportRequests(456, []int64{555, 556}, "testUser")
+
+		assert.NoError(t, err, "Error occurred, but none expected")
+		assert.Equal(t, "https://tms.dummy.sap.com/v2/nodes/456/transportRequests/forward", uploaderMock.urlCalled, "Called url incorrect")
+		assert.Equal(t, `{"namedUser":"testUser","transportRequests":[555,556]}`, uploaderMock.requestBody, "Request body incorrect")
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		uploaderMock := uploaderMock{responseBody: `Bad request provided`, httpStatusCode: http.StatusBadRequest}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		err := communicationInstance.ImportTransportRequests(456, []int64{555}, "testUser")
+
+		assert.EqualError(t, err, "http error 400")
+	})
+}
+
+func TestGetTransportRequestLogs(t *testing.T) {
+	logger := log.Entry().WithField("package", "SAP/jenkins-library/pkg/tms_test")
+	t.Run("test success", func(t *testing.T) {
+		getLogsResponse := `{"logs": [{"id": 1,"actionId": 7,"logLevel": "ERROR","message": "Deployment failed","createdAt": "2021-11-16T13:06:05.711Z"}]}`
+		uploaderMock := uploaderMock{responseBody: getLogsResponse, httpStatusCode: http.StatusOK}
+		communicationInstance := CommunicationInstance{tmsUrl: "https://tms.dummy.sap.com", httpClient: &uploaderMock, logger: logger, isVerbose: false}
+
+		logs, err := communicationInstance.GetTransportRequestLogs(456, 555)
+
+		assert.NoError(t, err, "Error occurred, but none expected")
+		assert.Equal(t, "https://tms.dummy.sap.com/v2/nodes/456/transportRequests/555/logs", uploaderMock.urlCalled, "Called url incorrect")
+		assert.Equal(t, []TransportRequestLog{{Id: 1, ActionId: 7, Level: "ERROR", Message: "Deployment failed", CreatedAt: "2021-11-16T13:06:05.711Z"}}, logs, "Logs incorrect")
+	})
+}
+
 func TestSendRequest(t *testing.T) {
 	logger := log.Entry().WithField("package", "SAP/jenkins-library/pkg/tms_test")
 	t.Run("test success against uaa", func(t *testing.T) {
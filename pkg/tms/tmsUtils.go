@@ -99,6 +99,63 @@ type Entry struct {
 	Uri string `json:"uri"`
 }
 
+type TransportRequest struct {
+	Id          int64  `json:"id"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Origin      string `json:"origin"`
+	CreatedBy   string `json:"createdBy"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+type transportRequests struct {
+	TransportRequests []TransportRequest `json:"transportRequests"`
+}
+
+type TransportRequestLog struct {
+	Id        int64  `json:"id"`
+	ActionId  int64  `json:"actionId"`
+	Level     string `json:"logLevel"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type transportRequestLogs struct {
+	Logs []TransportRequestLog `json:"logs"`
+}
+
+type TransportRequestActionEntity struct {
+	NamedUser         string  `json:"namedUser"`
+	TransportRequests []int64 `json:"transportRequests"`
+}
+
+// Statuses of a transport request in the import queue of a node
+const (
+	TR_STATUS_INITIAL    = "initial"
+	TR_STATUS_RUNNING    = "running"
+	TR_STATUS_SUCCEEDED  = "succeeded"
+	TR_STATUS_WARNING    = "warning"
+	TR_STATUS_ERROR      = "error"
+	TR_STATUS_FATAL      = "fatal"
+	TR_STATUS_REPEATABLE = "repeatable"
+	TR_STATUS_SKIPPED    = "skipped"
+	TR_STATUS_DELETED    = "deleted"
+)
+
+// IsImportFinished returns true if the status of the transport request does not change anymore without user interaction
+func (transportRequest TransportRequest) IsImportFinished() bool {
+	switch transportRequest.Status {
+	case TR_STATUS_INITIAL, TR_STATUS_RUNNING:
+		return false
+	}
+	return true
+}
+
+// IsImported returns true if the transport request has been imported successfully, possibly with warnings
+func (transportRequest TransportRequest) IsImported() bool {
+	return transportRequest.Status == TR_STATUS_SUCCEEDED || transportRequest.Status == TR_STATUS_WARNING
+}
+
 type CommunicationInterface interface {
 	GetNodes() ([]Node, error)
 	GetMtaExtDescriptor(nodeId int64, mtaId, mtaVersion string) (MtaExtDescriptor, error)
@@ -107,6 +164,11 @@ type CommunicationInterface interface {
 	UploadFile(file, namedUser string) (FileInfo, error)
 	UploadFileToNode(fileInfo FileInfo, nodeName, description, namedUser string) (NodeUploadResponseEntity, error)
 	ExportFileToNode(fileInfo FileInfo, nodeName, description, namedUser string) (NodeUploadResponseEntity, error)
+	GetTransportRequests(nodeId int64) ([]TransportRequest, error)
+	GetTransportRequest(nodeId, transportRequestId int64) (TransportRequest, error)
+	ImportTransportRequests(nodeId int64, transportRequestIds []int64, namedUser string) error
+	ForwardTransportRequests(nodeId int64, transportRequestIds []int64, namedUser string) error
+	GetTransportRequestLogs(nodeId, transportRequestId int64) ([]TransportRequestLog, error)
 }
 
 type Options struct {
@@ -0,0 +1,204 @@
+package triage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// Directions of the synchronization between the assessment file and the scanner
+const (
+	// None disables the synchronization
+	None = "none"
+	// Push applies the assessments of the file to the scanner
+	Push = "push"
+	// Pull adds and updates the assessments of the file with the audit state of the scanner
+	Pull = "pull"
+	// Sync pushes the assessments of the file and pulls the assessments only made in the scanner
+	Sync = "sync"
+)
+
+// Finding is a finding of a scanner together with its audit state
+type Finding struct {
+	ID string
+	// Assessment is nil as long as the finding has not been audited in the scanner
+	Assessment *format.Assessment
+}
+
+// Auditor reads and changes the audit state of the findings of a scanner
+type Auditor interface {
+	// Tool returns the tool name used in the assessment file
+	Tool() string
+	// Findings returns all findings of the scanner
+	Findings() ([]Finding, error)
+	// Assess applies the assessment to the finding and reports whether the audit state of the scanner changed
+	Assess(finding Finding, assessment format.Assessment) (bool, error)
+}
+
+// Summary counts the changes of a synchronization
+type Summary struct {
+	Pushed int
+	Pulled int
+	// Unknown lists the IDs of assessments without a finding in the scanner
+	Unknown []string
+}
+
+// ValidateDirection checks that the direction is one of the supported directions
+func ValidateDirection(direction string) error {
+	switch direction {
+	case None, Push, Pull, Sync:
+		return nil
+	}
+	return fmt.Errorf("invalid assessment sync direction '%v', supported are %v, %v, %v and %v", direction, None, Push, Pull, Sync)
+}
+
+// Synchronize synchronizes the assessments of the auditor's tool with the scanner and returns the resulting assessments.
+// Assessments of other tools are returned unchanged.
+func Synchronize(auditor Auditor, assessments []format.Assessment, direction string) ([]format.Assessment, Summary, error) {
+	summary := Summary{}
+	if err := ValidateDirection(direction); err != nil {
+		return assessments, summary, err
+	}
+	if direction == None {
+		return assessments, summary, nil
+	}
+
+	findings, err := auditor.Findings()
+	if err != nil {
+		return assessments, summary, errors.Wrapf(err, "failed to read findings of %v", auditor.Tool())
+	}
+	findingsByID := map[string]Finding{}
+	for _, finding := range findings {
+		findingsByID[finding.ID] = finding
+	}
+
+	result := append([]format.Assessment{}, assessments...)
+	indexByID := map[string]int{}
+	for i, assessment := range result {
+		if strings.EqualFold(assessment.Tool, auditor.Tool()) {
+			indexByID[assessment.Vulnerability] = i
+		}
+	}
+
+	if direction == Push || direction == Sync {
+		for _, assessment := range result {
+			if !strings.EqualFold(assessment.Tool, auditor.Tool()) {
+				continue
+			}
+			finding, ok := findingsByID[assessment.Vulnerability]
+			if !ok {
+				summary.Unknown = append(summary.Unknown, assessment.Vulnerability)
+				continue
+			}
+			changed, err := auditor.Assess(finding, assessment)
+			if err != nil {
+				return assessments, summary, errors.Wrapf(err, "failed to assess %v finding %v", auditor.Tool(), assessment.Vulnerability)
+			}
+			if changed {
+				log.Entry().Debugf("pushed assessment of %v finding %v", auditor.Tool(), assessment.Vulnerability)
+				summary.Pushed++
+			}
+		}
+	}
+
+	if direction == Pull || direction == Sync {
+		for _, finding := range findings {
+			if finding.Assessment == nil {
+				continue
+			}
+			pulled := *finding.Assessment
+			pulled.Tool = auditor.Tool()
+			pulled.Vulnerability = finding.ID
+
+			i, ok := indexByID[finding.ID]
+			if !ok {
+				indexByID[finding.ID] = len(result)
+				result = append(result, pulled)
+				summary.Pulled++
+				continue
+			}
+			// in sync mode the assessment file wins for findings assessed on both sides
+			if direction == Sync || equal(result[i], pulled) {
+				continue
+			}
+			pulled.Tool = result[i].Tool
+			pulled.Purls = result[i].Purls
+			result[i] = pulled
+			summary.Pulled++
+		}
+	}
+
+	return result, summary, nil
+}
+
+func equal(a, b format.Assessment) bool {
+	return a.Status == b.Status && a.Analysis == b.Analysis && a.Justification == b.Justification
+}
+
+// ReadAssessmentFile reads the assessments of the file, a missing file contains no assessments
+func ReadAssessmentFile(path string, utils piperutils.FileUtils) ([]format.Assessment, error) {
+	exists, err := utils.FileExists(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check existence of assessment file '%v'", path)
+	}
+	if !exists {
+		log.Entry().Infof("assessment file '%v' does not exist, starting without assessments", path)
+		return []format.Assessment{}, nil
+	}
+	content, err := utils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read assessment file '%v'", path)
+	}
+	assessments, err := format.ReadAssessments(io.NopCloser(bytes.NewReader(content)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse assessment file '%v'", path)
+	}
+	return *assessments, nil
+}
+
+// WriteAssessmentFile writes the assessments to the file
+func WriteAssessmentFile(path string, assessments []format.Assessment, utils piperutils.FileUtils) error {
+	var content strings.Builder
+	if err := format.WriteAssessments(&content, assessments); err != nil {
+		return err
+	}
+	if err := utils.FileWrite(path, []byte(content.String()), 0666); err != nil {
+		return errors.Wrapf(err, "failed to write assessment file '%v'", path)
+	}
+	return nil
+}
+
+// Run reads the assessment file, synchronizes it with the scanner and writes back pulled assessments
+func Run(auditor Auditor, path, direction string, utils piperutils.FileUtils) (Summary, error) {
+	if err := ValidateDirection(direction); err != nil {
+		return Summary{}, err
+	}
+	if direction == None {
+		return Summary{}, nil
+	}
+	assessments, err := ReadAssessmentFile(path, utils)
+	if err != nil {
+		return Summary{}, err
+	}
+	result, summary, err := Synchronize(auditor, assessments, direction)
+	if err != nil {
+		return summary, err
+	}
+	for _, id := range summary.Unknown {
+		log.Entry().Warnf("%v finding %v of the assessment file '%v' not found", auditor.Tool(), id, path)
+	}
+	if summary.Pulled > 0 {
+		if err := WriteAssessmentFile(path, result, utils); err != nil {
+			return summary, err
+		}
+		log.Entry().Infof("pulled %v assessments of %v into '%v', commit the file to keep them", summary.Pulled, auditor.Tool(), path)
+	}
+	log.Entry().Infof("pushed %v assessments to %v", summary.Pushed, auditor.Tool())
+	return summary, nil
+}
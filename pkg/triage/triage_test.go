@@ -0,0 +1,165 @@
+//go:build unit
+// +build unit
+
+package triage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditorMock struct {
+	findings []Finding
+	assessed map[string]format.Assessment
+	failOn   string
+}
+
+func (a *auditorMock) Tool() string {
+	return "scanner"
+}
+
+func (a *auditorMock) Findings() ([]Finding, error) {
+	return a.findings, nil
+}
+
+func (a *auditorMock) Assess(finding Finding, assessment format.Assessment) (bool, error) {
+	if finding.ID == a.failOn {
+		return false, fmt.Errorf("audit failed")
+	}
+	if finding.Assessment != nil && finding.Assessment.Status == assessment.Status {
+		return false, nil
+	}
+	if a.assessed == nil {
+		a.assessed = map[string]format.Assessment{}
+	}
+	a.assessed[finding.ID] = assessment
+	return true, nil
+}
+
+func newAuditorMock() *auditorMock {
+	return &auditorMock{findings: []Finding{
+		{ID: "1"},
+		{ID: "2", Assessment: &format.Assessment{Status: format.NotRelevant, Analysis: format.FalsePositive, Justification: "sanitized"}},
+		{ID: "3", Assessment: &format.Assessment{Status: format.Relevant}},
+	}}
+}
+
+func TestSynchronize(t *testing.T) {
+	t.Parallel()
+
+	assessments := []format.Assessment{
+		{Vulnerability: "CVE-2008-4318", Status: format.NotRelevant, Analysis: format.Mitigated, Purls: []format.Purl{{Purl: "pkg:npm/observer@0.3.2"}}},
+		{Tool: "scanner", Vulnerability: "1", Status: format.NotRelevant, Analysis: format.NotExploitable, Justification: "input is a constant"},
+		{Tool: "scanner", Vulnerability: "3", Status: format.NotRelevant, Analysis: format.FalsePositive},
+		{Tool: "scanner", Vulnerability: "4", Status: format.NotRelevant},
+		{Tool: "other", Vulnerability: "2", Status: format.NotRelevant},
+	}
+
+	t.Run("none", func(t *testing.T) {
+		auditor := newAuditorMock()
+		result, summary, err := Synchronize(auditor, assessments, None)
+		assert.NoError(t, err)
+		assert.Equal(t, assessments, result)
+		assert.Equal(t, Summary{}, summary)
+		assert.Empty(t, auditor.assessed)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		auditor := newAuditorMock()
+		result, summary, err := Synchronize(auditor, assessments, Push)
+		assert.NoError(t, err)
+		assert.Equal(t, assessments, result)
+		assert.Equal(t, 2, summary.Pushed)
+		assert.Equal(t, 0, summary.Pulled)
+		assert.Equal(t, []string{"4"}, summary.Unknown)
+		assert.Equal(t, format.NotExploitable, auditor.assessed["1"].Analysis)
+		assert.Equal(t, format.FalsePositive, auditor.assessed["3"].Analysis)
+		assert.NotContains(t, auditor.assessed, "2")
+	})
+
+	t.Run("pull", func(t *testing.T) {
+		auditor := newAuditorMock()
+		result, summary, err := Synchronize(auditor, assessments, Pull)
+		assert.NoError(t, err)
+		assert.Empty(t, auditor.assessed)
+		assert.Equal(t, 2, summary.Pulled)
+		require.Len(t, result, 6)
+		assert.Equal(t, assessments[0], result[0])
+		assert.Equal(t, assessments[1], result[1])
+		assert.Equal(t, format.Assessment{Tool: "scanner", Vulnerability: "3", Status: format.Relevant}, result[2])
+		assert.Equal(t, assessments[4], result[4])
+		assert.Equal(t, format.Assessment{Tool: "scanner", Vulnerability: "2", Status: format.NotRelevant, Analysis: format.FalsePositive, Justification: "sanitized"}, result[5])
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		auditor := newAuditorMock()
+		result, summary, err := Synchronize(auditor, assessments, Sync)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, summary.Pushed)
+		assert.Equal(t, 1, summary.Pulled)
+		require.Len(t, result, 6)
+		assert.Equal(t, assessments, result[:5])
+		assert.Equal(t, "2", result[5].Vulnerability)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		auditor := newAuditorMock()
+		auditor.failOn = "3"
+		result, _, err := Synchronize(auditor, assessments, Push)
+		assert.EqualError(t, err, "failed to assess scanner finding 3: audit failed")
+		assert.Equal(t, assessments, result)
+	})
+
+	t.Run("invalid direction", func(t *testing.T) {
+		_, _, err := Synchronize(newAuditorMock(), assessments, "both")
+		assert.EqualError(t, err, "invalid assessment sync direction 'both', supported are none, push, pull and sync")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pull into missing file", func(t *testing.T) {
+		utils := &mock.FilesMock{}
+		summary, err := Run(newAuditorMock(), "hs-assessments.yaml", Pull, utils)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, summary.Pulled)
+
+		assessments, err := ReadAssessmentFile("hs-assessments.yaml", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, []format.Assessment{
+			{Tool: "scanner", Vulnerability: "2", Status: format.NotRelevant, Analysis: format.FalsePositive, Justification: "sanitized"},
+			{Tool: "scanner", Vulnerability: "3", Status: format.Relevant},
+		}, assessments)
+	})
+
+	t.Run("push keeps file untouched", func(t *testing.T) {
+		content := []byte(`ignore:
+  - tool: scanner
+    vulnerability: "1"
+    status: notRelevant
+    analysis: falsePositive
+    justification: parameterized query
+`)
+		utils := &mock.FilesMock{}
+		utils.AddFile("hs-assessments.yaml", content)
+		auditor := newAuditorMock()
+		summary, err := Run(auditor, "hs-assessments.yaml", Push, utils)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, summary.Pushed)
+		assert.Equal(t, "parameterized query", auditor.assessed["1"].Justification)
+		assert.False(t, utils.HasWrittenFile("hs-assessments.yaml"))
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		utils := &mock.FilesMock{}
+		utils.AddFile("hs-assessments.yaml", []byte("ignore: {"))
+		_, err := Run(newAuditorMock(), "hs-assessments.yaml", Push, utils)
+		assert.ErrorContains(t, err, "failed to parse assessment file 'hs-assessments.yaml'")
+	})
+}
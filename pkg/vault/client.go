@@ -167,6 +167,11 @@ func (v Client) GetKvSecret(path string) (map[string]string, error) {
 		return nil, fmt.Errorf("Excpected 'data' field to be a map[string]interface{} but got %T instead", rawData)
 	}
 
+	return stringifySecretData(data), nil
+}
+
+// stringifySecretData converts the values of a secret to strings, non-string values are encoded as JSON
+func stringifySecretData(data map[string]interface{}) map[string]string {
 	secretData := make(map[string]string, len(data))
 	for k, v := range data {
 		switch t := v.(type) {
@@ -184,7 +189,7 @@ func (v Client) GetKvSecret(path string) (map[string]string, error) {
 			secretData[k] = string(jsonBytes)
 		}
 	}
-	return secretData, nil
+	return secretData
 }
 
 // WriteKvSecret writes secret to kv engine
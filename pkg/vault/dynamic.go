@@ -0,0 +1,165 @@
+package vault
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/hashicorp/vault/api"
+)
+
+// DynamicSecret is a credential generated on request by a Vault secrets engine like database, aws, azure or pki
+type DynamicSecret struct {
+	Data          map[string]string
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+}
+
+// GetDynamicSecret requests a credential from a dynamic secrets engine.
+// Engines which expect parameters, e.g. pki/issue/<role>, are called with a write request containing the data.
+func (v Client) GetDynamicSecret(path string, data map[string]interface{}) (*DynamicSecret, error) {
+	path = sanitizePath(path)
+	var secret *api.Secret
+	var err error
+	if len(data) > 0 {
+		secret, err = v.lClient.Write(path, data)
+	} else {
+		secret, err = v.lClient.Read(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("No credentials returned for path %s", path)
+	}
+	return &DynamicSecret{
+		Data:          stringifySecretData(secret.Data),
+		LeaseID:       secret.LeaseID,
+		LeaseDuration: time.Duration(secret.LeaseDuration) * time.Second,
+		Renewable:     secret.Renewable,
+	}, nil
+}
+
+// RenewLease extends the lease by the given increment and returns the granted lease duration.
+// Vault may grant less than the increment when the lease reaches its maximum TTL.
+func (v Client) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	secret, err := v.lClient.Write("sys/leases/renew", map[string]interface{}{
+		"lease_id":  leaseID,
+		"increment": int(increment.Seconds()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if secret == nil {
+		return 0, fmt.Errorf("Could not renew lease %s", leaseID)
+	}
+	return time.Duration(secret.LeaseDuration) * time.Second, nil
+}
+
+// RevokeLease revokes the lease, the secrets engine invalidates the credential immediately
+func (v Client) RevokeLease(leaseID string) error {
+	_, err := v.lClient.Write("sys/leases/revoke", map[string]interface{}{
+		"lease_id": leaseID,
+	})
+	return err
+}
+
+// leaseClient interface for mocking
+type leaseClient interface {
+	RenewLease(leaseID string, increment time.Duration) (time.Duration, error)
+	RevokeLease(leaseID string) error
+}
+
+// LeaseManager keeps the leases of dynamic secrets alive while a step is running and revokes them afterwards
+type LeaseManager struct {
+	client leaseClient
+	mutex  sync.Mutex
+	leases map[string]chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewLeaseManager creates a LeaseManager using the given client
+func NewLeaseManager(client leaseClient) *LeaseManager {
+	return &LeaseManager{client: client, leases: map[string]chan struct{}{}}
+}
+
+// Track registers the lease of the secret. Renewable leases are renewed in the background until they are revoked.
+func (m *LeaseManager) Track(secret *DynamicSecret) {
+	if secret == nil || secret.LeaseID == "" {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.leases[secret.LeaseID]; ok {
+		return
+	}
+	stop := make(chan struct{})
+	m.leases[secret.LeaseID] = stop
+	if secret.Renewable && secret.LeaseDuration > 0 {
+		m.wg.Add(1)
+		go m.renew(secret.LeaseID, secret.LeaseDuration, stop)
+	}
+}
+
+// Leases returns the number of tracked leases
+func (m *LeaseManager) Leases() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.leases)
+}
+
+// renew extends the lease after two thirds of its duration have passed
+func (m *LeaseManager) renew(leaseID string, duration time.Duration, stop chan struct{}) {
+	defer m.wg.Done()
+	increment := duration
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(duration * 2 / 3):
+		}
+		granted, err := m.client.RenewLease(leaseID, increment)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Could not renew Vault lease %s", leaseID)
+			return
+		}
+		if granted <= 0 {
+			log.Entry().Warnf("Vault lease %s reached its maximum TTL and cannot be renewed anymore", leaseID)
+			return
+		}
+		if granted < increment {
+			log.Entry().Warnf("Vault lease %s reaches its maximum TTL in %v", leaseID, granted)
+		}
+		log.Entry().Debugf("Renewed Vault lease %s for %v", leaseID, granted)
+		duration = granted
+	}
+}
+
+// RevokeAll stops the renewal of all tracked leases and revokes them
+func (m *LeaseManager) RevokeAll() error {
+	m.mutex.Lock()
+	leases := m.leases
+	m.leases = map[string]chan struct{}{}
+	m.mutex.Unlock()
+
+	for _, stop := range leases {
+		close(stop)
+	}
+	m.wg.Wait()
+
+	failed := 0
+	for leaseID := range leases {
+		if err := m.client.RevokeLease(leaseID); err != nil {
+			log.Entry().WithError(err).Warnf("Could not revoke Vault lease %s", leaseID)
+			failed++
+			continue
+		}
+		log.Entry().Debugf("Revoked Vault lease %s", leaseID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to revoke %d of %d Vault leases", failed, len(leases))
+	}
+	return nil
+}
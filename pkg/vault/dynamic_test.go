@@ -0,0 +1,137 @@
+//go:build unit
+// +build unit
+
+package vault
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/vault/mocks"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDynamicSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("read credentials", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		client := Client{vaultMock, &Config{}}
+		vaultMock.On("Read", "database/creds/readonly").Return(&api.Secret{
+			LeaseID:       "database/creds/readonly/abc",
+			LeaseDuration: 3600,
+			Renewable:     true,
+			Data:          map[string]interface{}{"username": "v-token-readonly", "password": "secret"},
+		}, nil)
+
+		secret, err := client.GetDynamicSecret("/database/creds/readonly/", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DynamicSecret{
+			Data:          map[string]string{"username": "v-token-readonly", "password": "secret"},
+			LeaseID:       "database/creds/readonly/abc",
+			LeaseDuration: time.Hour,
+			Renewable:     true,
+		}, secret)
+	})
+
+	t.Run("issue certificate", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		client := Client{vaultMock, &Config{}}
+		vaultMock.On("Write", "pki/issue/web", map[string]interface{}{"common_name": "app.example.com"}).Return(&api.Secret{
+			Data: map[string]interface{}{"certificate": "CERT", "ca_chain": []string{"CA"}},
+		}, nil)
+
+		secret, err := client.GetDynamicSecret("pki/issue/web", map[string]interface{}{"common_name": "app.example.com"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "CERT", secret.Data["certificate"])
+		assert.Equal(t, `["CA"]`, secret.Data["ca_chain"])
+		assert.Empty(t, secret.LeaseID)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		client := Client{vaultMock, &Config{}}
+		vaultMock.On("Read", "aws/creds/deploy").Return(nil, nil)
+
+		_, err := client.GetDynamicSecret("aws/creds/deploy", nil)
+
+		assert.EqualError(t, err, "No credentials returned for path aws/creds/deploy")
+	})
+}
+
+func TestRenewAndRevokeLease(t *testing.T) {
+	t.Parallel()
+
+	vaultMock := &mocks.VaultMock{}
+	client := Client{vaultMock, &Config{}}
+	vaultMock.On("Write", "sys/leases/renew", map[string]interface{}{"lease_id": "lease", "increment": 60}).Return(&api.Secret{LeaseDuration: 30}, nil)
+	vaultMock.On("Write", "sys/leases/revoke", map[string]interface{}{"lease_id": "lease"}).Return(nil, nil)
+
+	granted, err := client.RenewLease("lease", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, granted)
+	assert.NoError(t, client.RevokeLease("lease"))
+}
+
+type leaseClientMock struct {
+	mutex     sync.Mutex
+	renewed   map[string]int
+	revoked   []string
+	granted   time.Duration
+	revokeErr error
+}
+
+func (c *leaseClientMock) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.renewed[leaseID]++
+	return c.granted, nil
+}
+
+func (c *leaseClientMock) RevokeLease(leaseID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.revoked = append(c.revoked, leaseID)
+	return c.revokeErr
+}
+
+func (c *leaseClientMock) renewals(leaseID string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.renewed[leaseID]
+}
+
+func TestLeaseManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renew and revoke", func(t *testing.T) {
+		client := &leaseClientMock{renewed: map[string]int{}, granted: 30 * time.Millisecond}
+		manager := NewLeaseManager(client)
+
+		manager.Track(&DynamicSecret{LeaseID: "renewable", LeaseDuration: 30 * time.Millisecond, Renewable: true})
+		manager.Track(&DynamicSecret{LeaseID: "renewable", LeaseDuration: 30 * time.Millisecond, Renewable: true})
+		manager.Track(&DynamicSecret{LeaseID: "fixed", LeaseDuration: time.Hour})
+		manager.Track(&DynamicSecret{})
+		assert.Equal(t, 2, manager.Leases())
+
+		assert.Eventually(t, func() bool { return client.renewals("renewable") >= 2 }, time.Second, 10*time.Millisecond)
+		assert.NoError(t, manager.RevokeAll())
+		assert.ElementsMatch(t, []string{"renewable", "fixed"}, client.revoked)
+		assert.Equal(t, 0, manager.Leases())
+		assert.Equal(t, 0, client.renewals("fixed"))
+	})
+
+	t.Run("revocation fails", func(t *testing.T) {
+		client := &leaseClientMock{renewed: map[string]int{}, revokeErr: fmt.Errorf("permission denied")}
+		manager := NewLeaseManager(client)
+		manager.Track(&DynamicSecret{LeaseID: "lease", LeaseDuration: time.Hour, Renewable: true})
+
+		assert.EqualError(t, manager.RevokeAll(), "failed to revoke 1 of 1 Vault leases")
+	})
+}
@@ -198,6 +198,7 @@ public class CommonStepsTest extends BasePiperTest{
         'integrationArtifactGetServiceEndpoint', //implementing new golang pattern without fields
         'integrationArtifactDownload', //implementing new golang pattern without fields
         'integrationArtifactUpload', //implementing new golang pattern without fields
+        'integrationPackageSync', //implementing new golang pattern without fields
         'integrationArtifactTransport', //implementing new golang pattern without fields
         'integrationArtifactTriggerIntegrationTest', //implementing new golang pattern without fields
         'integrationArtifactUnDeploy', //implementing new golang pattern without fields
@@ -232,8 +233,18 @@ public class CommonStepsTest extends BasePiperTest{
         'apiProviderList', //implementing new golang pattern without fields
         'tmsUpload',
         'tmsExport',
+        'tmsPromote',
         'imagePushToRegistry',
-        'gcpPublishEvent'
+        'gcpPublishEvent',
+        'osvExecuteScan',
+        'licensePolicyCheck',
+        'testResultsAggregate',
+        'containerCheckBaseImage',
+        'containerVerifySignature',
+        'containerExecuteScan',
+        'pipelineTriggerRemote',
+        'secretExecuteScan',
+        'manifestPolicyCheck'
     ]
 
     @Test
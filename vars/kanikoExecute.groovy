@@ -4,6 +4,10 @@ import groovy.transform.Field
 @Field String METADATA_FILE = 'metadata/kanikoExecute.yaml'
 
 void call(Map parameters = [:]) {
-    List credentials = [[type: 'file', id: 'dockerConfigJsonCredentialsId', env: ['PIPER_dockerConfigJSON']]]
+    List credentials = [
+        [type: 'file', id: 'dockerConfigJsonCredentialsId', env: ['PIPER_dockerConfigJSON']],
+        [type: 'file', id: 'signingKeyCredentialsId', env: ['PIPER_signingKey']],
+        [type: 'token', id: 'signingKeyPasswordCredentialsId', env: ['PIPER_signingKeyPassword']],
+    ]
     piperExecuteBin(parameters, STEP_NAME, METADATA_FILE, credentials)
 }
@@ -0,0 +1,13 @@
+import groovy.transform.Field
+
+@Field String STEP_NAME = getClass().getName()
+@Field String METADATA_FILE = 'metadata/pipelineTriggerRemote.yaml'
+
+void call(Map parameters = [:]) {
+    List credentials = [
+        [type: 'usernamePassword', id: 'jenkinsCredentialsId', env: ['PIPER_jenkinsUsername', 'PIPER_jenkinsToken']],
+        [type: 'token', id: 'githubTokenCredentialsId', env: ['PIPER_githubToken']],
+        [type: 'token', id: 'adoPersonalAccessTokenCredentialsId', env: ['PIPER_adoPersonalAccessToken']],
+    ]
+    piperExecuteBin(parameters, STEP_NAME, METADATA_FILE, credentials)
+}